@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/bot"
+)
+
+// trackedThreadJSON is the API representation of a tracked PR thread/message.
+type trackedThreadJSON struct {
+	UpdatedAt   time.Time `json:"updated_at"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	Org         string    `json:"org"`
+	Repo        string    `json:"repo"`
+	PRURL       string    `json:"pr_url"`
+	ChannelID   string    `json:"channel_id"`
+	ChannelType string    `json:"channel_type"`
+	ThreadID    string    `json:"thread_id,omitempty"`
+	MessageID   string    `json:"message_id,omitempty"`
+	State       string    `json:"state"`
+	Number      int       `json:"number"`
+}
+
+// threadsResponse is the response body for the tracked threads endpoint.
+type threadsResponse struct {
+	Threads []trackedThreadJSON `json:"threads"`
+}
+
+// makeThreadsHandler returns a read-only handler exposing the PR -> Discord
+// mapping for external dashboards. Requests must carry "Authorization: Bearer <token>".
+// An optional ?org= parameter limits results to a single org.
+func makeThreadsHandler(store ThreadLister, allOrgs func() []string, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !validBearerToken(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		orgs := allOrgs()
+		if org := r.URL.Query().Get("org"); org != "" {
+			orgs = []string{org}
+		}
+
+		resp := threadsResponse{Threads: make([]trackedThreadJSON, 0)}
+		for _, org := range orgs {
+			for _, t := range store.ListThreads(r.Context(), org) {
+				resp.Threads = append(resp.Threads, trackedThreadJSON{
+					UpdatedAt:   t.Info.UpdatedAt,
+					FirstSeenAt: t.Info.FirstSeenAt,
+					Org:         t.Owner,
+					Repo:        t.Repo,
+					Number:      t.Number,
+					PRURL:       bot.FormatPRURL(t.Owner, t.Repo, t.Number),
+					ChannelID:   t.Info.ChannelID,
+					ChannelType: t.Info.ChannelType,
+					ThreadID:    t.Info.ThreadID,
+					MessageID:   t.Info.MessageID,
+					State:       t.Info.LastState,
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			slog.Debug("threads write error", "error", err)
+		}
+	}
+}
+
+// validBearerToken reports whether the request carries the expected bearer token.
+// An empty expected token never matches.
+func validBearerToken(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
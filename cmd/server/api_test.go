@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func newThreadsTestStore(t *testing.T) *state.MemoryStore {
+	t.Helper()
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+
+	threads := []struct {
+		org, repo string
+		number    int
+		info      state.ThreadInfo
+	}{
+		{"org1", "api", 1, state.ThreadInfo{ThreadID: "t1", MessageID: "m1", ChannelID: "c1", ChannelType: "forum", LastState: "needs_review"}},
+		{"org2", "web", 2, state.ThreadInfo{MessageID: "m2", ChannelID: "c2", ChannelType: "text", LastState: "approved"}},
+	}
+	for _, tt := range threads {
+		if err := store.SaveThread(ctx, tt.org, tt.repo, tt.number, tt.info.ChannelID, tt.info); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+	return store
+}
+
+func TestThreadsHandler(t *testing.T) {
+	store := newThreadsTestStore(t)
+	handler := makeThreadsHandler(store, func() []string { return []string{"org1", "org2"} }, "secret")
+
+	tests := []struct {
+		name       string
+		query      string
+		auth       string
+		wantStatus int
+		wantCount  int
+	}{
+		{"all orgs", "", "Bearer secret", http.StatusOK, 2},
+		{"filtered by org", "?org=org2", "Bearer secret", http.StatusOK, 1},
+		{"unknown org", "?org=nope", "Bearer secret", http.StatusOK, 0},
+		{"missing token", "", "", http.StatusUnauthorized, 0},
+		{"wrong token", "", "Bearer nope", http.StatusUnauthorized, 0},
+		{"wrong scheme", "", "Basic secret", http.StatusUnauthorized, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/threads"+tt.query, http.NoBody)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+
+			var resp threadsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if len(resp.Threads) != tt.wantCount {
+				t.Errorf("got %d threads, want %d", len(resp.Threads), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestThreadsHandler_JSONShape(t *testing.T) {
+	store := newThreadsTestStore(t)
+	handler := makeThreadsHandler(store, func() []string { return nil }, "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/threads?org=org1", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	var raw map[string][]map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	threads := raw["threads"]
+	if len(threads) != 1 {
+		t.Fatalf("got %d threads, want 1", len(threads))
+	}
+
+	want := map[string]any{
+		"org":          "org1",
+		"repo":         "api",
+		"number":       float64(1),
+		"pr_url":       "https://github.com/org1/api/pull/1",
+		"channel_id":   "c1",
+		"channel_type": "forum",
+		"thread_id":    "t1",
+		"message_id":   "m1",
+		"state":        "needs_review",
+	}
+	for key, val := range want {
+		if threads[0][key] != val {
+			t.Errorf("%s = %v, want %v", key, threads[0][key], val)
+		}
+	}
+	for _, key := range []string{"updated_at", "first_seen_at"} {
+		if _, ok := threads[0][key]; !ok {
+			t.Errorf("missing %s", key)
+		}
+	}
+}
+
+func TestThreadsHandler_EmptyList(t *testing.T) {
+	handler := makeThreadsHandler(state.NewMemoryStore(), func() []string { return []string{"org1"} }, "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/threads", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	// Empty results serialize as [] rather than null
+	if got := rec.Body.String(); got != "{\"threads\":[]}\n" {
+		t.Errorf("body = %q, want empty threads array", got)
+	}
+}
+
+func TestValidBearerToken_EmptyExpected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/threads", http.NoBody)
+	req.Header.Set("Authorization", "Bearer ")
+	if validBearerToken(req, "") {
+		t.Error("empty expected token should never validate")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/codeGROOVE-dev/discordian/internal/discord"
 	"github.com/codeGROOVE-dev/discordian/internal/github"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
 // GitHubManager defines GitHub operations needed by the server.
@@ -17,5 +18,11 @@ type GitHubManager interface {
 
 // DiscordGuildManager defines Discord guild management operations.
 type DiscordGuildManager interface {
+	NewClient(guildID, token string) (*discord.Client, error)
 	RegisterClient(guildID string, client *discord.Client)
 }
+
+// ThreadLister lists tracked PR threads for an org.
+type ThreadLister interface {
+	ListThreads(ctx context.Context, owner string) []state.TrackedThread
+}
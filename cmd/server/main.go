@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -99,12 +103,22 @@ func run(ctx context.Context, cancel context.CancelFunc) int {
 
 	// Create config manager
 	configMgr := config.New()
+	configMgr.SetDefaultGuildID(cfg.DefaultGuildID)
 
 	// Create notification manager
 	notifyMgr := notify.New(store, slog.Default())
+	if cfg.DeadLetterFile != "" {
+		notifyMgr.SetDeadLetterSink(notify.NewFileDeadLetterSink(cfg.DeadLetterFile, slog.Default()))
+	}
+	notifyMgr.SetDigestMode(cfg.DMDigest)
+	notifyMgr.SetBurstWindow(cfg.DMBurstWindow)
 
 	// Create Discord guild manager
 	guildManager := discord.NewGuildManager(slog.Default())
+	guildManager.SetShardCount(cfg.DiscordShardCount)
+	guildManager.SetOpenAttempts(cfg.DiscordOpenAttempts)
+	guildManager.SetRequestPacer(discord.NewRequestPacer(cfg.DiscordRequestRate))
+	readiness := discord.NewReadiness(guildManager)
 
 	// Create HTTP router
 	router := mux.NewRouter()
@@ -113,7 +127,13 @@ func run(ctx context.Context, cancel context.CancelFunc) int {
 	// Health endpoints
 	router.HandleFunc("/", healthHandler).Methods("GET")
 	router.HandleFunc("/health", healthHandler).Methods("GET")
-	router.HandleFunc("/healthz", makeHealthzHandler(githubManager)).Methods("GET")
+	router.HandleFunc("/healthz", makeHealthzHandler(githubManager, guildManager)).Methods("GET")
+	router.HandleFunc("/readyz", makeReadyzHandler(readiness)).Methods("GET")
+
+	// Read-only state API for external dashboards
+	if cfg.APIToken != "" {
+		router.HandleFunc("/api/threads", makeThreadsHandler(store, githubManager.AllOrgs, cfg.APIToken)).Methods("GET")
+	}
 
 	// Create HTTP server
 	port := cfg.Port
@@ -160,7 +180,7 @@ func run(ctx context.Context, cancel context.CancelFunc) int {
 
 	// Start coordinator manager for all GitHub installations
 	eg.Go(func() error {
-		return runCoordinators(ctx, cfg, githubManager, configMgr, guildManager, store, notifyMgr)
+		return runCoordinators(ctx, cfg, githubManager, configMgr, guildManager, readiness, store, notifyMgr)
 	})
 
 	// Wait for all services
@@ -195,14 +215,18 @@ type coordinatorManager struct {
 	reverseMapper  *usermapping.ReverseMapper
 	active         map[string]context.CancelFunc
 	guildManager   DiscordGuildManager
+	readiness      *discord.Readiness
 	failed         map[string]time.Time
 	coordinators   map[string]*bot.Coordinator
+	searcher       *github.Searcher      // Shared by /goose report and daily reports so repeated searches hit its cache
+	pacer          *discord.RequestPacer // Paces Discord writes across every guild's client
 	configManager  bot.ConfigManager
 	githubManager  GitHubManager
 	cfg            config.ServerConfig
 	dmsSent        int64
 	dailyReports   int64
 	channelMsgs    int64
+	globalCommands bool // Global slash commands are registered; until then each new guild's client tries
 	mu             sync.Mutex
 }
 
@@ -212,6 +236,7 @@ func runCoordinators(
 	githubManager *github.Manager,
 	configManager *config.Manager,
 	guildManager *discord.GuildManager,
+	readiness *discord.Readiness,
 	store state.Store,
 	notifyMgr *notify.Manager,
 ) error {
@@ -220,6 +245,8 @@ func runCoordinators(
 		githubManager:  githubManager,
 		configManager:  configManager,
 		guildManager:   guildManager,
+		readiness:      readiness,
+		pacer:          guildManager.RequestPacer(),
 		store:          store,
 		notifyMgr:      notifyMgr,
 		reverseMapper:  usermapping.NewReverseMapper(),
@@ -231,6 +258,8 @@ func runCoordinators(
 		startTime:      time.Now(),
 		lastEventTime:  make(map[string]time.Time),
 	}
+	notifyMgr.SetDMFallback(cm)
+	notifyMgr.SetLatencyRecorder(cm)
 
 	// Initial discovery
 	slog.Info("discovering GitHub installations")
@@ -281,6 +310,13 @@ func runCoordinators(
 			if err := store.Cleanup(ctx); err != nil {
 				slog.Warn("state cleanup failed", "error", err)
 			}
+			// Pacing is shared by every org, so it's logged once rather than per coordinator
+			if requests, delayed, waited := cm.pacer.Stats(); requests > 0 {
+				slog.Info("discord request pacing",
+					"requests", requests,
+					"delayed", delayed,
+					"waited", waited)
+			}
 		}
 	}
 }
@@ -311,6 +347,27 @@ func (m *coordinatorManager) startCoordinators(ctx context.Context) {
 	for _, org := range orgs {
 		m.startSingleCoordinator(ctx, org)
 	}
+	// The running orgs' guilds are now expected; ready once their sessions are open
+	if m.readiness != nil {
+		m.readiness.MarkLoaded(m.activeGuilds())
+	}
+}
+
+// activeGuilds returns the guilds of running orgs that have a Discord client. A guild is
+// expected only once there's a client to wait on: one whose client can't be created would
+// otherwise hold the instance unready until the retry succeeds. Callers hold m.mu.
+func (m *coordinatorManager) activeGuilds() []string {
+	var guilds []string
+	for org := range m.active {
+		cfg, exists := m.configManager.Config(org)
+		if !exists || cfg.Global.GuildID == "" || m.discordClients[cfg.Global.GuildID] == nil {
+			continue
+		}
+		if !slices.Contains(guilds, cfg.Global.GuildID) {
+			guilds = append(guilds, cfg.Global.GuildID)
+		}
+	}
+	return guilds
 }
 
 func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org string) bool {
@@ -353,10 +410,16 @@ func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org str
 		m.failed[org] = time.Now()
 		return false
 	}
-
 	// Register with notification manager
 	m.notifyMgr.RegisterGuild(guildID, discordClient)
 
+	// Maintenance mode outlives restarts; show it again on the new connection
+	if info, ok := m.store.Maintenance(ctx, org); ok && info.On {
+		if err := discordClient.SetMaintenancePresence(true); err != nil {
+			slog.Warn("failed to restore maintenance status", "org", org, "error", err)
+		}
+	}
+
 	// Create user mapper
 	userMapper := usermapping.New(org, m.configManager, discordClient, m.store, guildID)
 
@@ -366,16 +429,28 @@ func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org str
 	// Create PR searcher for polling backup
 	searcher := github.NewSearcher(m.githubManager.AppClient(), slog.Default())
 
+	// Default branches and archived status come from the same cached repository lookup
+	branches := github.NewBranchResolver(m.githubManager.AppClient(), slog.Default())
+
 	// Create coordinator
 	coordinator := bot.NewCoordinator(bot.CoordinatorConfig{
-		Org:        org,
-		Discord:    discordClient,
-		Config:     m.configManager,
-		Store:      m.store,
-		Turn:       turnClient,
-		UserMapper: userMapper,
-		Searcher:   searcher,
-		Logger:     slog.Default(),
+		Org:         org,
+		Discord:     discordClient,
+		Config:      m.configManager,
+		Store:       m.store,
+		Turn:        turnClient,
+		UserMapper:  userMapper,
+		Searcher:    searcher,
+		Branches:    branches,
+		Repos:       branches,
+		Comments:    github.NewCommentReader(m.githubManager.AppClient(), slog.Default()),
+		Logger:      slog.Default(),
+		ClaimJitter: m.cfg.ClaimJitter,
+		Unparseable: bot.UnparseablePolicy{
+			Action:   m.cfg.UnparseableURLs,
+			LogLevel: m.cfg.UnparseableLogLevel,
+			Sink:     unparseableDeadLetters{sink: m.notifyMgr.DeadLetterSink()},
+		},
 	})
 
 	// Start coordinator in goroutine
@@ -424,7 +499,23 @@ func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org str
 		cleanupTicker := time.NewTicker(10 * time.Minute)
 		defer cleanupTicker.Stop()
 
-		// Run initial reconciliation on startup
+		// Check digest-mode channels for due digests and post windows that have opened
+		digestTicker := time.NewTicker(1 * time.Minute)
+		defer digestTicker.Stop()
+
+		// Advance review escalation ladders
+		escalationTicker := time.NewTicker(5 * time.Minute)
+		defer escalationTicker.Stop()
+
+		// Post the activity summary once per UTC day
+		summaryTicker := time.NewTicker(1 * time.Hour)
+		defer summaryTicker.Stop()
+
+		// Surface misnamed channels now rather than when their events arrive
+		coord.ValidateChannels(orgCtx)
+
+		// Catch up in the background on what changed while the bot was down, then run initial reconciliation
+		coord.Backfill(orgCtx, time.Now())
 		coord.PollAndReconcile(orgCtx)
 
 		// Start sprinkler in goroutine
@@ -442,6 +533,34 @@ func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org str
 				coord.PollAndReconcile(orgCtx)
 			case <-cleanupTicker.C:
 				coord.CleanupLocks()
+				// Structured so log-based metrics can chart activity per org
+				stats := coord.CoordinatorStats()
+				slog.Info("coordinator activity",
+					"org", org,
+					"events_processed", stats.EventsProcessed,
+					"messages_posted", stats.MessagesPosted,
+					"messages_edited", stats.MessagesEdited,
+					"dms_queued", stats.DMsQueued,
+					"turn_calls", stats.TurnCalls,
+					"turn_deferred", stats.TurnDeferred,
+					"turn_throttled", stats.TurnThrottled,
+					"errors", stats.Errors,
+					"unparseable_urls", stats.UnparseableURLs,
+					"post_latency", latencyAttrs(stats.PostLatency),
+					"dm_latency", latencyAttrs(stats.DMLatency))
+			case <-digestTicker.C:
+				coord.FlushDigests(orgCtx)
+				coord.RefreshBoards(orgCtx)
+				coord.FlushPostWindows(orgCtx)
+				coord.FlushArchives(orgCtx)
+				coord.Heartbeat(orgCtx, time.Now())
+				coord.FollowConfigReload(orgCtx)
+			case <-escalationTicker.C:
+				coord.EscalateStalledReviews(orgCtx)
+				coord.EscalateUnacknowledgedDMs(orgCtx)
+				coord.RemindReviewers(orgCtx, time.Now())
+			case now := <-summaryTicker.C:
+				coord.PostActivitySummary(orgCtx, now)
 			case err := <-sprinklerDone:
 				m.handleCoordinatorExit(org, err)
 				return
@@ -452,45 +571,63 @@ func (m *coordinatorManager) startSingleCoordinator(ctx context.Context, org str
 	return true
 }
 
-func (m *coordinatorManager) discordClientForGuild(_ context.Context, guildID string) (*discord.Client, error) {
+func (m *coordinatorManager) discordClientForGuild(ctx context.Context, guildID string) (*discord.Client, error) {
 	// Check if client already exists (caller must hold m.mu lock)
 	if client, exists := m.discordClients[guildID]; exists {
 		return client, nil
 	}
 
-	client, err := discord.New(m.cfg.DiscordBotToken)
+	// Guilds on the same shard share one gateway session, and every client shares the pacer
+	client, err := m.guildManager.NewClient(guildID, m.cfg.DiscordBotToken)
 	if err != nil {
 		return nil, fmt.Errorf("create Discord client: %w", err)
 	}
 
-	client.SetGuildID(guildID)
+	client.SetWriteConcurrency(m.cfg.DiscordGuildWrites)
+	client.SetReactionHandler(m)
+	client.SetMemberRemoveHandler(m)
+	client.SetMemberScan(m.cfg.DiscordMemberBatch, m.cfg.DiscordMaxMembers)
+	client.SetStarterFetchAttempts(m.cfg.ForumStarterAttempts)
+	client.SetChannelRenamePolicy(m.cfg.ChannelRenames)
+	client.SetPreviewLogging(m.cfg.MessagePreviewLength, m.cfg.MessagePreviewSample, m.cfg.MessagePreviewRedact)
 
-	if err := client.Open(); err != nil {
-		return nil, fmt.Errorf("open Discord connection: %w", err)
+	if err := client.OpenWithRetry(ctx, m.cfg.DiscordOpenAttempts); err != nil {
+		return nil, err
 	}
 
 	// Register with guild manager
 	m.guildManager.RegisterClient(guildID, client)
 
-	// Set up slash command handler
-	slashHandler := discord.NewSlashCommandHandler(client.Session(), slog.Default())
-	slashHandler.SetupHandler()
-
-	// Set status, report, usermap, and channel map getters
-	slashHandler.SetStatusGetter(m)
-	slashHandler.SetReportGetter(m)
-	slashHandler.SetUserMapGetter(m)
-	slashHandler.SetChannelMapGetter(m)
-	slashHandler.SetDailyReportGetter(m)
-	slashHandler.SetStore(m.store)
+	// A shared session delivers every guild's interactions to each handler on it, so guilds
+	// on one session share a handler; it looks everything up by the interaction's guild
+	slashHandler := m.sessionSlashHandler(client)
+	if slashHandler == nil {
+		// Set up slash command handler
+		slashHandler = discord.NewSlashCommandHandler(client.Session(), slog.Default())
+		slashHandler.SetupHandler()
+
+		// Set status, report, usermap, and channel map getters
+		slashHandler.SetStatusGetter(m)
+		slashHandler.SetReportGetter(m)
+		slashHandler.SetUserMapGetter(m)
+		slashHandler.SetChannelMapGetter(m)
+		slashHandler.SetDedupClearer(m)
+		slashHandler.SetPRInspector(m)
+		slashHandler.SetHandledMarker(m)
+		slashHandler.SetMaintenanceToggler(m)
+		slashHandler.SetRepoRedirector(m)
+		slashHandler.SetConfigReloader(m)
+		slashHandler.SetTimezoneGetter(m)
+		slashHandler.SetMappingInvalidator(m)
+		slashHandler.SetDailyReportGetter(m)
+		slashHandler.SetStore(m.store)
+		slashHandler.SetDMActionHandler(m.notifyMgr)
+		slashHandler.SetDashboardMaxPRs(m.cfg.DashboardMaxPRs)
+		slashHandler.SetDashboardLinker(m)
+	}
 
 	// Register slash commands with Discord
-	if err := slashHandler.RegisterCommands(guildID); err != nil {
-		slog.Warn("failed to register slash commands",
-			"guild_id", guildID,
-			"error", err)
-		// Don't fail - continue without slash commands
-	}
+	m.registerCommands(slashHandler, guildID)
 
 	m.discordClients[guildID] = client
 	m.slashHandlers[guildID] = slashHandler
@@ -499,6 +636,56 @@ func (m *coordinatorManager) discordClientForGuild(_ context.Context, guildID st
 	return client, nil
 }
 
+// sessionSlashHandler returns the slash command handler already set up on a client's gateway
+// session for another guild, or nil if there isn't one. Caller must hold m.mu.
+func (m *coordinatorManager) sessionSlashHandler(client *discord.Client) *discord.SlashCommandHandler {
+	for guildID, other := range m.discordClients {
+		if other.Session() == client.Session() {
+			return m.slashHandlers[guildID]
+		}
+	}
+	return nil
+}
+
+// registerCommands registers a guild's slash commands as COMMAND_SCOPE says: with the guild
+// itself, or globally, once for every guild. Under the global scope the guild's own commands
+// are removed, so a guild registered before isn't shown each command twice. A failed global
+// registration is retried with the next guild's client. The caller must hold m.mu.
+func (m *coordinatorManager) registerCommands(slashHandler *discord.SlashCommandHandler, guildID string) {
+	target := discord.CommandTarget(m.cfg.CommandScope, guildID)
+	if target == "" {
+		if err := slashHandler.RemoveCommands(guildID); err != nil {
+			slog.Warn("failed to remove guild slash commands", "guild_id", guildID, "error", err)
+		}
+		if m.globalCommands {
+			return
+		}
+		if err := slashHandler.RegisterCommands(""); err != nil {
+			slog.Warn("failed to register global slash commands", "error", err)
+			return
+		}
+		m.globalCommands = true
+		return
+	}
+	if err := slashHandler.RegisterCommands(target); err != nil {
+		slog.Warn("failed to register slash commands",
+			"guild_id", guildID,
+			"error", err)
+		// Don't fail - continue without slash commands
+	}
+}
+
+// prSearcher returns the searcher shared by user-facing reports, creating it on first use.
+func (m *coordinatorManager) prSearcher() *github.Searcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.searcher == nil {
+		m.searcher = github.NewSearcher(m.githubManager.AppClient(), slog.Default())
+		m.searcher.SetCacheTTL(m.cfg.SearchCacheTTL)
+	}
+	return m.searcher
+}
+
 func (m *coordinatorManager) handleCoordinatorExit(org string, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -550,6 +737,7 @@ func (m *coordinatorManager) Status(ctx context.Context, guildID string) discord
 		DailyReportsSent:     m.dailyReports,
 		ChannelMessagesSent:  m.channelMsgs,
 	}
+	status.DiscordWrites, status.DiscordWritesPaced, status.DiscordPaceWait = m.pacer.Stats()
 
 	// Find all orgs for this guild (a guild may monitor multiple orgs)
 	var orgsForGuild []string
@@ -562,6 +750,29 @@ func (m *coordinatorManager) Status(ctx context.Context, guildID string) discord
 		}
 	}
 
+	// Activity counters cover the guild's orgs, like the rest of the status
+	var postLatency, dmLatency bot.LatencyStats
+	for _, org := range orgsForGuild {
+		coord, exists := m.coordinators[org]
+		if !exists {
+			continue
+		}
+		stats := coord.CoordinatorStats()
+		status.EventsProcessed += stats.EventsProcessed
+		status.ChannelMessagesSent += stats.MessagesPosted
+		status.ChannelMessageEdits += stats.MessagesEdited
+		status.DMsQueued += stats.DMsQueued
+		status.TurnCalls += stats.TurnCalls
+		status.TurnDeferred += stats.TurnDeferred
+		status.TurnThrottled += stats.TurnThrottled
+		status.ProcessingErrors += stats.Errors
+		status.UnparseableURLs += stats.UnparseableURLs
+		postLatency = postLatency.Merge(stats.PostLatency)
+		dmLatency = dmLatency.Merge(stats.DMLatency)
+	}
+	status.PostLatencyP50, status.PostLatencyP95 = postLatency.Quantile(0.5), postLatency.Quantile(0.95)
+	status.DMLatencyP50, status.DMLatencyP95 = dmLatency.Quantile(0.5), dmLatency.Quantile(0.95)
+
 	// Count cached users from both forward and reverse mappers
 	if m.reverseMapper != nil {
 		status.UsersCached = len(m.reverseMapper.ExportCache())
@@ -606,6 +817,54 @@ func (m *coordinatorManager) Status(ctx context.Context, guildID string) discord
 	return status
 }
 
+// latencyAttrs renders a latency histogram for log-based metrics: its count, sum and
+// percentiles, and the count in each bucket, keyed by the bucket's upper bound.
+func latencyAttrs(stats bot.LatencyStats) slog.Value {
+	attrs := []slog.Attr{
+		slog.Int64("count", stats.Count),
+		slog.Int64("sum_ms", stats.Sum.Milliseconds()),
+		slog.Int64("p50_ms", stats.Quantile(0.5).Milliseconds()),
+		slog.Int64("p95_ms", stats.Quantile(0.95).Milliseconds()),
+	}
+	bounds := bot.LatencyBuckets()
+	for i, n := range stats.Buckets {
+		le := "inf"
+		if i < len(bounds) {
+			le = bounds[i].String()
+		}
+		attrs = append(attrs, slog.Int64("le_"+le, n))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// unparseableDeadLetters hands events with unparseable URLs to the DM dead-letter sink, so
+// they're recorded alongside undeliverable DMs.
+type unparseableDeadLetters struct {
+	sink notify.DeadLetterSink
+}
+
+// UnparseableEvent implements bot.UnparseableSink interface.
+func (u unparseableDeadLetters) UnparseableEvent(ctx context.Context, org string, event bot.SprinklerEvent) {
+	if u.sink == nil {
+		return
+	}
+	u.sink.DeadLetter(ctx, notify.DeadLetter{
+		FailedAt:    time.Now(),
+		CreatedAt:   event.Timestamp,
+		ID:          event.DeliveryID,
+		Org:         org,
+		PRURL:       event.URL,
+		MessageText: event.Type,
+		Reason:      notify.ReasonUnparseableURL,
+	})
+}
+
+// DashboardOrgURL implements discord.DashboardLinker interface.
+func (m *coordinatorManager) DashboardOrgURL(org string) string {
+	// PR-shaped templates have no org page, so org links fall back to the slash handler's dashboard URL
+	return format.DashboardTemplateOrgURL(m.configManager.DashboardURLTemplate(org), org)
+}
+
 // Report implements discord.ReportGetter interface.
 func (m *coordinatorManager) Report(ctx context.Context, guildID, userID string) (*discord.PRReport, error) {
 	slog.Info("report requested",
@@ -704,7 +963,8 @@ func (m *coordinatorManager) Report(ctx context.Context, guildID, userID string)
 	var incomingPRs []discord.PRSummary
 	var outgoingPRs []discord.PRSummary
 
-	searcher := github.NewSearcher(m.githubManager.AppClient(), slog.Default())
+	searcher := m.prSearcher()
+	profiles, hasProfiles := m.discordClients[guildID]
 
 	for _, org := range orgsForGuild {
 		slog.Info("searching PRs for org",
@@ -725,6 +985,12 @@ func (m *coordinatorManager) Report(ctx context.Context, guildID, userID string)
 		// Create Turn client for this org
 		turn := bot.NewTurnClient(m.cfg.TurnURL, client)
 
+		// Authors and reviewers are shown by their Discord identity if the org opted in
+		var names bot.UserMapper
+		if coord, ok := m.coordinators[org]; ok && hasProfiles && m.configManager.DiscordNames(org) {
+			names = coord.UserMapper
+		}
+
 		// Search for PRs authored by this user (outgoing)
 		slog.Info("searching authored PRs",
 			"org", org,
@@ -741,6 +1007,9 @@ func (m *coordinatorManager) Report(ctx context.Context, guildID, userID string)
 			for _, pr := range authored {
 				summary := analyzePRForReport(ctx, pr, githubUsername, turn)
 				if summary != nil {
+					if names != nil {
+						withDiscordNames(ctx, summary, names, profiles)
+					}
 					outgoingPRs = append(outgoingPRs, *summary)
 				}
 			}
@@ -762,6 +1031,9 @@ func (m *coordinatorManager) Report(ctx context.Context, guildID, userID string)
 			for _, pr := range review {
 				summary := analyzePRForReport(ctx, pr, githubUsername, turn)
 				if summary != nil {
+					if names != nil {
+						withDiscordNames(ctx, summary, names, profiles)
+					}
 					incomingPRs = append(incomingPRs, *summary)
 				}
 			}
@@ -863,7 +1135,7 @@ func (m *coordinatorManager) DailyReport(ctx context.Context, guildID, userID st
 	var incomingPRs []discord.PRSummary
 	var outgoingPRs []discord.PRSummary
 
-	searcher := github.NewSearcher(m.githubManager.AppClient(), slog.Default())
+	searcher := m.prSearcher()
 
 	for _, org := range orgsForGuild {
 		client, exists := m.githubManager.ClientForOrg(org)
@@ -920,6 +1192,7 @@ func (m *coordinatorManager) DailyReport(ctx context.Context, guildID, userID st
 	if force || eligible {
 		// Create daily report sender
 		sender := dailyreport.NewSender(m.store, slog.Default())
+		sender.SetPersistent(slices.ContainsFunc(orgsForGuild, m.configManager.PersistentReport))
 
 		// Register guild DM sender
 		sender.RegisterGuild(guildID, discordClient)
@@ -1109,6 +1382,379 @@ func (m *coordinatorManager) UserMappings(ctx context.Context, guildID string) (
 	}, nil
 }
 
+// WhoAmI implements discord.UserMapGetter interface.
+func (m *coordinatorManager) WhoAmI(ctx context.Context, guildID, userID string) (*discord.WhoAmI, error) {
+	who := &discord.WhoAmI{}
+
+	// Most recent self-service link wins if the user linked more than once
+	var linkedAt time.Time
+	for _, link := range m.store.ListUserMappings(ctx, guildID) {
+		if link.DiscordUserID == userID && !link.CreatedAt.Before(linkedAt) {
+			who.LinkedUsername = link.GitHubUsername
+			linkedAt = link.CreatedAt
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if !exists || cfg.Global.GuildID != guildID {
+			continue
+		}
+
+		// Check the linked username plus any config entries naming this user
+		var candidates []string
+		if who.LinkedUsername != "" {
+			candidates = append(candidates, who.LinkedUsername)
+		}
+		for githubUsername, discordID := range cfg.UserMappings() {
+			if discordID == userID {
+				who.ConfigUsernames = append(who.ConfigUsernames, githubUsername)
+				candidates = append(candidates, githubUsername)
+			}
+		}
+
+		mapper, ok := coord.UserMapper.(*usermapping.Mapper)
+		if !ok {
+			continue
+		}
+		slices.Sort(candidates)
+		for _, githubUsername := range slices.Compact(candidates) {
+			c, found := mapper.CheckConflict(ctx, githubUsername)
+			if !found {
+				continue
+			}
+			who.Conflicts = append(who.Conflicts, discord.MappingConflict{
+				GitHubUsername: c.GitHubUsername,
+				Org:            org,
+				ConfigID:       c.ConfigID,
+				LinkedID:       c.StorageID,
+				ResolvedID:     c.ResolvedID,
+				Policy:         c.Policy,
+			})
+		}
+	}
+	slices.Sort(who.ConfigUsernames)
+	who.ConfigUsernames = slices.Compact(who.ConfigUsernames)
+
+	slog.Info("whoami requested",
+		"guild_id", guildID,
+		"user_id", userID,
+		"linked_username", who.LinkedUsername,
+		"conflicts", len(who.Conflicts))
+
+	return who, nil
+}
+
+// ClearDedup implements discord.DedupClearer interface.
+// Only entries for the guild's orgs are cleared, so one guild can't force another's reprocessing.
+func (m *coordinatorManager) ClearDedup(ctx context.Context, guildID, prURL string) (int, error) {
+	orgsForGuild := m.orgsForGuild(guildID)
+
+	if prURL != "" {
+		pr, ok := bot.ParsePRURL(prURL)
+		if !ok {
+			return 0, fmt.Errorf("invalid PR URL: %s", prURL)
+		}
+		if !slices.Contains(orgsForGuild, pr.Owner) {
+			return 0, fmt.Errorf("org %s is not monitored by this server", pr.Owner)
+		}
+		// Normalize so the prefix matches keys recorded for the canonical URL
+		return m.store.ClearProcessed(ctx, bot.EventKeyPrefix(bot.FormatPRURL(pr.Owner, pr.Repo, pr.Number)))
+	}
+
+	cleared := 0
+	for _, org := range orgsForGuild {
+		n, err := m.store.ClearProcessed(ctx, "https://github.com/"+org+"/")
+		cleared += n
+		if err != nil {
+			return cleared, fmt.Errorf("clear dedup for org %s: %w", org, err)
+		}
+	}
+	slog.Info("cleared event dedup entries",
+		"guild_id", guildID,
+		"orgs", orgsForGuild,
+		"cleared", cleared)
+	return cleared, nil
+}
+
+// orgsForGuild returns the active orgs whose config points at the guild.
+func (m *coordinatorManager) orgsForGuild(guildID string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var orgs []string
+	for org := range m.active {
+		cfg, exists := m.configManager.Config(org)
+		if exists && cfg.Global.GuildID == guildID {
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs
+}
+
+// GuildLocation implements discord.TimezoneGetter interface. A guild whose orgs set
+// different timezones gets the first of them, by org name.
+func (m *coordinatorManager) GuildLocation(guildID string) *time.Location {
+	for _, org := range slices.Sorted(slices.Values(m.orgsForGuild(guildID))) {
+		if loc := m.configManager.Location(org); loc != time.UTC {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// InspectPR implements discord.PRInspector interface.
+func (m *coordinatorManager) InspectPR(ctx context.Context, guildID, prURL string) (discord.PRInspection, error) {
+	pr, ok := bot.ParsePRURL(prURL)
+	if !ok {
+		return discord.PRInspection{}, errors.New("not a GitHub PR URL")
+	}
+	if !slices.Contains(m.orgsForGuild(guildID), pr.Owner) {
+		return discord.PRInspection{}, fmt.Errorf("org %s is not monitored by this server", pr.Owner)
+	}
+	return discord.InspectPR(ctx, m.store, prURL)
+}
+
+// InvalidateUserMapping implements discord.MappingInvalidator interface.
+func (m *coordinatorManager) InvalidateUserMapping(guildID, gitHubUsername, discordUserID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.reverseMapper != nil {
+		m.reverseMapper.Forget(discordUserID)
+	}
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if !exists || cfg.Global.GuildID != guildID {
+			continue
+		}
+		if mapper, ok := coord.UserMapper.(*usermapping.Mapper); ok {
+			mapper.Forget(gitHubUsername)
+		}
+	}
+	slog.Info("invalidated cached user mapping",
+		"guild_id", guildID,
+		"github_username", gitHubUsername,
+		"discord_user_id", discordUserID)
+}
+
+// HandleReaction implements discord.ReactionHandler interface.
+// Each of the guild's coordinators checks whether the message is one of its PRs.
+func (m *coordinatorManager) HandleReaction(ctx context.Context, guildID, channelID, messageID, userID, emoji string) {
+	m.mu.Lock()
+	var coords []*bot.Coordinator
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if exists && cfg.Global.GuildID == guildID {
+			coords = append(coords, coord)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, coord := range coords {
+		coord.HandleReaction(ctx, channelID, messageID, userID, emoji)
+	}
+}
+
+// HandleMemberRemove implements discord.MemberRemoveHandler interface. The mapping of a
+// user who left is flagged, deleted, or kept per the guild's orgs' member_leave setting,
+// with "delete" winning over "flag" over "keep", and their queued DMs are dropped.
+func (m *coordinatorManager) HandleMemberRemove(ctx context.Context, guildID, userID string) {
+	m.mu.Lock()
+	action := usermapping.MemberLeaveKeep
+	var mappers []*usermapping.Mapper
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if !exists || cfg.Global.GuildID != guildID {
+			continue
+		}
+		switch m.configManager.MemberLeave(org) {
+		case usermapping.MemberLeaveDelete:
+			action = usermapping.MemberLeaveDelete
+		case usermapping.MemberLeaveFlag:
+			if action == usermapping.MemberLeaveKeep {
+				action = usermapping.MemberLeaveFlag
+			}
+		default:
+		}
+		if mapper, ok := coord.UserMapper.(*usermapping.Mapper); ok {
+			mappers = append(mappers, mapper)
+		}
+	}
+	if m.reverseMapper != nil {
+		m.reverseMapper.Forget(userID)
+	}
+	m.mu.Unlock()
+
+	if len(mappers) == 0 {
+		return
+	}
+	var githubUsernames []string
+	for _, mapper := range mappers {
+		for _, username := range mapper.MemberLeft(ctx, userID, action) {
+			if !slices.Contains(githubUsernames, username) {
+				githubUsernames = append(githubUsernames, username)
+			}
+		}
+	}
+	if action != usermapping.MemberLeaveKeep {
+		if _, err := m.notifyMgr.DropUserDMs(ctx, guildID, userID); err != nil {
+			slog.Warn("failed to drop pending DMs for user who left the guild",
+				"error", err,
+				"guild_id", guildID,
+				"user_id", userID)
+		}
+	}
+	slog.Info("mapped user left the guild",
+		"guild_id", guildID,
+		"user_id", userID,
+		"github_usernames", githubUsernames,
+		"action", action)
+}
+
+// SetMaintenance implements discord.MaintenanceToggler interface.
+func (m *coordinatorManager) SetMaintenance(ctx context.Context, guildID, userID string, on bool) error {
+	m.mu.Lock()
+	var coords []*bot.Coordinator
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if exists && cfg.Global.GuildID == guildID {
+			coords = append(coords, coord)
+		}
+	}
+	client := m.discordClients[guildID]
+	m.mu.Unlock()
+
+	if len(coords) == 0 {
+		return errors.New("no orgs are monitored by this server")
+	}
+	for _, coord := range coords {
+		if err := coord.SetMaintenance(ctx, on, userID); err != nil {
+			return err
+		}
+	}
+	if client != nil {
+		if err := client.SetMaintenancePresence(on); err != nil {
+			slog.Warn("failed to update bot status for maintenance mode",
+				"error", err,
+				"guild_id", guildID)
+		}
+	}
+	return nil
+}
+
+// RedirectRepo implements discord.RepoRedirector interface. A bare repo name is redirected
+// in each of the guild's orgs.
+func (m *coordinatorManager) RedirectRepo(
+	ctx context.Context, guildID, userID, repo, channel string, until time.Time,
+) error {
+	org, name, qualified := strings.Cut(repo, "/")
+	if !qualified {
+		name = org
+	}
+
+	m.mu.Lock()
+	var coords []*bot.Coordinator
+	for o, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(o)
+		if exists && cfg.Global.GuildID == guildID && (!qualified || strings.EqualFold(o, org)) {
+			coords = append(coords, coord)
+		}
+	}
+	m.mu.Unlock()
+
+	if len(coords) == 0 {
+		if qualified {
+			return fmt.Errorf("org %s is not monitored by this server", org)
+		}
+		return errors.New("no orgs are monitored by this server")
+	}
+	for _, coord := range coords {
+		if err := coord.SetRepoRedirect(ctx, name, channel, until, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReloadConfig implements discord.ConfigReloader interface.
+func (m *coordinatorManager) ReloadConfig(ctx context.Context, guildID string) ([]discord.ConfigReload, error) {
+	m.mu.Lock()
+	coords := make(map[string]*bot.Coordinator)
+	for org, coord := range m.coordinators {
+		cfg, exists := m.configManager.Config(org)
+		if exists && cfg.Global.GuildID == guildID {
+			coords[org] = coord
+		}
+	}
+	m.mu.Unlock()
+
+	if len(coords) == 0 {
+		return nil, errors.New("no orgs are monitored by this server")
+	}
+	reloads := make([]discord.ConfigReload, 0, len(coords))
+	for _, org := range slices.Sorted(maps.Keys(coords)) {
+		changes, err := coords[org].ForceReloadConfig(ctx)
+		reloads = append(reloads, discord.ConfigReload{
+			Org:        org,
+			Err:        err,
+			Added:      changes.Added,
+			Removed:    changes.Removed,
+			Unresolved: changes.Unresolved,
+		})
+	}
+	return reloads, nil
+}
+
+// MarkHandled implements discord.HandledMarker interface.
+func (m *coordinatorManager) MarkHandled(ctx context.Context, guildID, userID, prURL string) error {
+	pr, ok := bot.ParsePRURL(prURL)
+	if !ok {
+		return fmt.Errorf("invalid PR URL: %s", prURL)
+	}
+
+	m.mu.Lock()
+	coord := m.coordinators[pr.Owner]
+	cfg, exists := m.configManager.Config(pr.Owner)
+	m.mu.Unlock()
+
+	if coord == nil || !exists || cfg.Global.GuildID != guildID {
+		return fmt.Errorf("org %s is not monitored by this server", pr.Owner)
+	}
+	if err := coord.MarkHandled(ctx, userID, prURL); err != nil {
+		if errors.Is(err, bot.ErrNotActionTarget) {
+			return discord.ErrNotActionTarget
+		}
+		return err
+	}
+	return nil
+}
+
+// DMFallback implements notify.DMFallback interface.
+func (m *coordinatorManager) DMFallback(ctx context.Context, org, userID, text string) error {
+	m.mu.Lock()
+	coord := m.coordinators[org]
+	m.mu.Unlock()
+
+	if coord == nil {
+		return fmt.Errorf("org %s is not monitored by this server", org)
+	}
+	return coord.DMFallback(ctx, userID, text)
+}
+
+// RecordDMLatency implements notify.LatencyRecorder interface.
+func (m *coordinatorManager) RecordDMLatency(org string, latency time.Duration) {
+	m.mu.Lock()
+	coord := m.coordinators[org]
+	m.mu.Unlock()
+
+	if coord != nil {
+		coord.ObserveDMLatency(latency)
+	}
+}
+
 // ChannelMappings implements discord.ChannelMapGetter interface.
 func (m *coordinatorManager) ChannelMappings(ctx context.Context, guildID string) (*discord.ChannelMappings, error) {
 	slog.Info("channel mappings requested",
@@ -1187,6 +1833,40 @@ func (m *coordinatorManager) ChannelMappings(ctx context.Context, guildID string
 	}, nil
 }
 
+// userProfiler looks up how guild members appear on Discord.
+type userProfiler interface {
+	UserProfile(ctx context.Context, userID string) (discord.UserProfile, bool)
+}
+
+// withDiscordNames fills in the Discord display names of a report PR's author and reviewers
+// who are mapped to guild members. Unmapped users keep showing their GitHub login.
+func withDiscordNames(ctx context.Context, summary *discord.PRSummary, mapper bot.UserMapper, profiles userProfiler) {
+	summary.AuthorName = discordName(ctx, summary.Author, mapper, profiles)
+	for _, reviewer := range summary.Reviewers {
+		name := discordName(ctx, reviewer, mapper, profiles)
+		if name == "" {
+			continue
+		}
+		if summary.ReviewerNames == nil {
+			summary.ReviewerNames = make(map[string]string)
+		}
+		summary.ReviewerNames[reviewer] = name
+	}
+}
+
+// discordName returns the display name of the guild member a GitHub user is mapped to, or "".
+func discordName(ctx context.Context, githubUsername string, mapper bot.UserMapper, profiles userProfiler) string {
+	discordID := mapper.DiscordID(ctx, githubUsername)
+	if discordID == "" {
+		return ""
+	}
+	profile, ok := profiles.UserProfile(ctx, discordID)
+	if !ok {
+		return ""
+	}
+	return profile.DisplayName
+}
+
 // analyzePRForReport analyzes a single PR and returns a summary if relevant.
 func analyzePRForReport(
 	ctx context.Context,
@@ -1255,6 +1935,7 @@ func analyzePRForReport(
 		State:     string(st),
 		URL:       pr.URL,
 		UpdatedAt: pr.UpdatedAt.Format(time.RFC3339),
+		Reviewers: slices.Sorted(maps.Keys(resp.PullRequest.Reviewers)),
 		IsBlocked: blocked,
 	}
 
@@ -1322,9 +2003,160 @@ func loadConfig(ctx context.Context) (config.ServerConfig, error) {
 		SprinklerURL:          sprinklerURL,
 		TurnURL:               turnURL,
 		DiscordBotToken:       getSecret("DISCORD_BOT_TOKEN"),
+		APIToken:              getSecret("API_TOKEN"),
+		DeadLetterFile:        os.Getenv("DEAD_LETTER_FILE"),
+		DefaultGuildID:        os.Getenv("DEFAULT_GUILD_ID"),
+		DiscordOpenAttempts:   discord.DefaultOpenAttempts,
+		DiscordRequestRate:    discord.DefaultRequestsPerSecond,
+		DiscordGuildWrites:    discord.DefaultGuildWriteConcurrency,
+		DiscordMemberBatch:    discord.DefaultMemberBatchSize,
+		ForumStarterAttempts:  discord.DefaultStarterFetchAttempts,
 		GCPProject:            os.Getenv("GCP_PROJECT"),
 		Port:                  port,
 		AllowPersonalAccounts: os.Getenv("ALLOW_PERSONAL_ACCOUNTS") == "true",
+		DMDigest:              os.Getenv("DM_DIGEST") == "true",
+		SearchCacheTTL:        github.DefaultSearchCacheTTL,
+		MessagePreviewLength:  discord.DefaultPreviewLength,
+		MessagePreviewSample:  1,
+		MessagePreviewRedact:  os.Getenv("MESSAGE_PREVIEW_REDACT") == "true",
+		CommandScope:          discord.CommandScopeGuild,
+		ChannelRenames:        discord.ChannelRenamesFollow,
+		DashboardMaxPRs:       discord.DefaultDashboardMaxPRs,
+		UnparseableURLs:       bot.UnparseableLog,
+		UnparseableLogLevel:   slog.LevelError,
+	}
+
+	if v := os.Getenv("UNPARSEABLE_URL_POLICY"); v != "" {
+		if v != bot.UnparseableLog && v != bot.UnparseableDrop && v != bot.UnparseableDeadLetter {
+			return cfg, fmt.Errorf("invalid UNPARSEABLE_URL_POLICY %q: must be %q, %q or %q",
+				v, bot.UnparseableLog, bot.UnparseableDrop, bot.UnparseableDeadLetter)
+		}
+		cfg.UnparseableURLs = v
+	}
+
+	if v := os.Getenv("UNPARSEABLE_URL_LOG_LEVEL"); v != "" {
+		if err := cfg.UnparseableLogLevel.UnmarshalText([]byte(v)); err != nil {
+			return cfg, fmt.Errorf("invalid UNPARSEABLE_URL_LOG_LEVEL %q: must be debug, info, warn or error", v)
+		}
+	}
+
+	if v := os.Getenv("COMMAND_SCOPE"); v != "" {
+		if v != discord.CommandScopeGuild && v != discord.CommandScopeGlobal {
+			return cfg, fmt.Errorf("invalid COMMAND_SCOPE %q: must be %q or %q", v, discord.CommandScopeGuild, discord.CommandScopeGlobal)
+		}
+		cfg.CommandScope = v
+	}
+
+	if v := os.Getenv("CHANNEL_RENAMES"); v != "" {
+		if v != discord.ChannelRenamesFollow && v != discord.ChannelRenamesStrict {
+			return cfg, fmt.Errorf("invalid CHANNEL_RENAMES %q: must be %q or %q",
+				v, discord.ChannelRenamesFollow, discord.ChannelRenamesStrict)
+		}
+		cfg.ChannelRenames = v
+	}
+
+	if v := os.Getenv("DISCORD_OPEN_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid DISCORD_OPEN_ATTEMPTS %q: must be a positive integer", v)
+		}
+		cfg.DiscordOpenAttempts = n
+	}
+
+	if v := os.Getenv("DISCORD_SHARD_COUNT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid DISCORD_SHARD_COUNT %q: must be a positive integer", v)
+		}
+		cfg.DiscordShardCount = n
+	}
+
+	if v := os.Getenv("DISCORD_REQUESTS_PER_SECOND"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid DISCORD_REQUESTS_PER_SECOND %q: must be a non-negative integer, or 0 to disable", v)
+		}
+		cfg.DiscordRequestRate = n
+	}
+
+	if v := os.Getenv("DISCORD_GUILD_WRITE_CONCURRENCY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid DISCORD_GUILD_WRITE_CONCURRENCY %q: must be a non-negative integer, or 0 for no cap", v)
+		}
+		cfg.DiscordGuildWrites = n
+	}
+
+	if v := os.Getenv("DISCORD_MEMBER_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > discord.DefaultMemberBatchSize {
+			return cfg, fmt.Errorf("invalid DISCORD_MEMBER_BATCH_SIZE %q: must be an integer from 1 to %d", v, discord.DefaultMemberBatchSize)
+		}
+		cfg.DiscordMemberBatch = n
+	}
+
+	if v := os.Getenv("DISCORD_MAX_MEMBERS_SCANNED"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid DISCORD_MAX_MEMBERS_SCANNED %q: must be a non-negative integer, or 0 for no cap", v)
+		}
+		cfg.DiscordMaxMembers = n
+	}
+
+	if v := os.Getenv("FORUM_STARTER_FETCH_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid FORUM_STARTER_FETCH_ATTEMPTS %q: must be a positive integer", v)
+		}
+		cfg.ForumStarterAttempts = n
+	}
+
+	if v := os.Getenv("SEARCH_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("invalid SEARCH_CACHE_TTL %q: must be a duration such as 30s, or 0 to disable", v)
+		}
+		cfg.SearchCacheTTL = d
+	}
+
+	if v := os.Getenv("DM_BURST_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("invalid DM_BURST_WINDOW %q: must be a duration such as 2m, or 0 to disable", v)
+		}
+		cfg.DMBurstWindow = d
+	}
+
+	if v := os.Getenv("EVENT_CLAIM_JITTER"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("invalid EVENT_CLAIM_JITTER %q: must be a duration such as 250ms, or 0 to disable", v)
+		}
+		cfg.ClaimJitter = d
+	}
+
+	if v := os.Getenv("MESSAGE_PREVIEW_LENGTH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid MESSAGE_PREVIEW_LENGTH %q: must be a non-negative integer, or 0 to disable", v)
+		}
+		cfg.MessagePreviewLength = n
+	}
+
+	if v := os.Getenv("MESSAGE_PREVIEW_SAMPLE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid MESSAGE_PREVIEW_SAMPLE %q: must be a positive integer", v)
+		}
+		cfg.MessagePreviewSample = n
+	}
+
+	if v := os.Getenv("DASHBOARD_MAX_PRS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid DASHBOARD_MAX_PRS %q: must be a positive integer", v)
+		}
+		cfg.DashboardMaxPRs = n
 	}
 
 	// Validate required fields
@@ -1349,17 +2181,53 @@ func healthHandler(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
-func makeHealthzHandler(githubManager *github.Manager) http.HandlerFunc {
+func makeHealthzHandler(githubManager *github.Manager, guildManager *discord.GuildManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		orgs := githubManager.AllOrgs()
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusOK)
-		if _, err := fmt.Fprintf(w, "ok - %d orgs\n", len(orgs)); err != nil {
+		// discordgo reconnects on its own, so a disconnected guild is reported rather than failing the check
+		msg := fmt.Sprintf("ok - %d orgs", len(orgs))
+		if down := guildManager.DisconnectedGuilds(); len(down) > 0 {
+			msg += fmt.Sprintf(", %d Discord guilds reconnecting", len(down))
+		}
+		// Tokens refresh on demand, so the soonest expiry shows whether rotation is keeping up
+		var soonest time.Time
+		for _, org := range orgs {
+			if exp, ok := githubManager.TokenExpiry(org); ok && (soonest.IsZero() || exp.Before(soonest)) {
+				soonest = exp
+			}
+		}
+		if !soonest.IsZero() {
+			msg += fmt.Sprintf(", GitHub token expires in %s", time.Until(soonest).Round(time.Second))
+		}
+		if _, err := fmt.Fprintln(w, msg); err != nil {
 			slog.Debug("healthz write error", "error", err)
 		}
 	}
 }
 
+// makeReadyzHandler serves the readiness probe: 503 until org configs are loaded and every
+// configured guild's Discord session is open, so no traffic arrives while events would be dropped.
+func makeReadyzHandler(readiness *discord.Readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		msg := "ready"
+		if ready, waiting := readiness.Ready(); ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			msg = fmt.Sprintf("not ready - waiting for %d Discord guilds", len(waiting))
+			if len(waiting) == 0 {
+				msg = "not ready - loading config"
+			}
+		}
+		if _, err := fmt.Fprintln(w, msg); err != nil {
+			slog.Debug("readyz write error", "error", err)
+		}
+	}
+}
+
 func securityHeadersMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -3,14 +3,18 @@ package main
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/codeGROOVE-dev/discordian/internal/bot"
 	"github.com/codeGROOVE-dev/discordian/internal/config"
 	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/notify"
 	"github.com/codeGROOVE-dev/discordian/internal/state"
 	"github.com/codeGROOVE-dev/discordian/internal/usermapping"
 )
@@ -35,7 +39,8 @@ func (m *mockTurnClient) Check(_ context.Context, _, _ string, _ time.Time) (*bo
 }
 
 type mockConfigManager struct {
-	configs map[string]*config.DiscordConfig
+	configs   map[string]*config.DiscordConfig
+	locations map[string]*time.Location
 }
 
 func (m *mockConfigManager) LoadConfig(_ context.Context, _ string) error {
@@ -46,6 +51,14 @@ func (m *mockConfigManager) ReloadConfig(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *mockConfigManager) LoadError(_ string) error {
+	return nil
+}
+
+func (m *mockConfigManager) OOOBackup(_, _ string) string {
+	return ""
+}
+
 func (m *mockConfigManager) Config(org string) (*config.DiscordConfig, bool) {
 	if m.configs == nil {
 		return nil, false
@@ -58,6 +71,10 @@ func (m *mockConfigManager) ChannelsForRepo(_, _ string) []string {
 	return []string{}
 }
 
+func (m *mockConfigManager) ChannelsForAuthor(_, _ string) []string {
+	return nil
+}
+
 func (m *mockConfigManager) ChannelType(_, _ string) string {
 	return "text"
 }
@@ -70,6 +87,325 @@ func (m *mockConfigManager) ReminderDMDelay(_, _ string) int {
 	return 0
 }
 
+func (m *mockConfigManager) MinDMDelay(_ string) int {
+	return 0
+}
+
+func (m *mockConfigManager) ReviveReopenedThreads(_ string) bool {
+	return true
+}
+
+func (m *mockConfigManager) SilentEdits(_ string) bool {
+	return true
+}
+
+func (m *mockConfigManager) NotifyOnTransitions(_ string) []string {
+	return nil
+}
+
+func (m *mockConfigManager) SuppressLabels(_ string) []string {
+	return nil
+}
+
+func (m *mockConfigManager) SystemIndicator(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ArchivedRepo(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) SkipArchivedRepos(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ArchivedRepoNote(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) SuppressSelfApproved(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DMOnClosedUnmerged(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DMAuthorOnReview(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DMOnReopenedReview(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DMOnUnrequest(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DMEscalationAfter(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) ReviewReminders(_ string) (every time.Duration, maxCount int) {
+	return 0, 0
+}
+
+func (m *mockConfigManager) ReopenRepostAfter(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) QueueDuringMaintenance(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) SoloReviewer(_ string) string {
+	return "both"
+}
+
+func (m *mockConfigManager) SelfMentions(_ string) string {
+	return "escape"
+}
+
+func (m *mockConfigManager) MemberLeave(_ string) string {
+	return "flag"
+}
+
+func (m *mockConfigManager) TurnRateLimit(_ string) (perMinute, burst int) {
+	return 0, 0
+}
+
+func (m *mockConfigManager) SkipTurnForDrafts(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowNonDefaultBase(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DefaultBranch(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) ShowFailingChecks(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowCIStatus(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowLinkedIssues(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) Location(org string) *time.Location {
+	if loc, ok := m.locations[org]; ok {
+		return loc
+	}
+	return time.UTC
+}
+
+func (m *mockConfigManager) ShowApprovals(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowReviewRounds(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowAssignees(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) BackfillWindow(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) MaxEventAge(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) FeatureEnabled(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) BotJoinGrace(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) PushBurstWindow(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) MinEventsToPost(_ string) int {
+	return 1
+}
+
+func (m *mockConfigManager) TestStateDebounce(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) WriteCooldown(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) MappingConflictPolicy(_ string) string {
+	return "config"
+}
+
+func (m *mockConfigManager) Crosspost(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) MessagePrefix(_, _ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) ShowOrg(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) PlainURL(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ShowBodySnippet(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ReviewChecklist(_, _, _ string) []string {
+	return nil
+}
+
+func (m *mockConfigManager) DashboardFooter(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DetailThread(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) AppendMode(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) StateSuppressed(_, _, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DashboardURLTemplate(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) Layout(_, _ string) string {
+	return "wide"
+}
+
+func (m *mockConfigManager) TitleStyle(_, _ string) string {
+	return "none"
+}
+
+func (m *mockConfigManager) PrefixForumTitles(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) RepoEmoji(_, _ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) DigestInterval(_, _ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) Board(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) BoardMaxPRs(_, _ string) int {
+	return 0
+}
+
+func (m *mockConfigManager) DeleteOnClose(_, _ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) AuthorAllowed(_, _, _ string) bool {
+	return true
+}
+
+func (m *mockConfigManager) PostWindow(_, _ string) (config.PostWindow, bool) {
+	return config.PostWindow{}, false
+}
+
+func (m *mockConfigManager) AdminChannel(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) FirehoseChannel(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) DMFallbackChannel(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) PersistentReport(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) DiscordNames(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ActivitySummary(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ConflictResolved(_ string) string {
+	return ""
+}
+
+func (m *mockConfigManager) ConflictResolvedNote(_ string) string {
+	return "✅ conflict resolved"
+}
+
+func (m *mockConfigManager) TurnUnavailableMessage(_ string) string {
+	return "details unavailable, see GitHub"
+}
+
+func (m *mockConfigManager) HeartbeatStaleAfter(_ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) HeartbeatInterval(_ string) time.Duration {
+	return time.Hour
+}
+
+func (m *mockConfigManager) CanonicalRepo(_, repo string) string {
+	return repo
+}
+
+func (m *mockConfigManager) MaxForumThreads(_, _ string) int {
+	return 0
+}
+
+func (m *mockConfigManager) ArchiveDelay(_, _ string) time.Duration {
+	return 0
+}
+
+func (m *mockConfigManager) Escalation(_, _ string) []config.EscalationStep {
+	return nil
+}
+
+func (m *mockConfigManager) TeamRoleMention(_, _ string) (mention string, threshold int) {
+	return "", 0
+}
+
+func (m *mockConfigManager) DMTemplate(_, _ string) string {
+	return ""
+}
+
 func (m *mockConfigManager) When(_, _ string) string {
 	return ""
 }
@@ -91,10 +427,38 @@ func (m *mockStateStore) SaveThread(_ context.Context, _, _ string, _ int, _ str
 	return nil
 }
 
+func (m *mockStateStore) ListThreads(_ context.Context, _ string) []state.TrackedThread {
+	return nil
+}
+
+func (m *mockStateStore) SetThreadMessageIDs(_ context.Context, _, _ string, _ int, _ string, _ []string) error {
+	return nil
+}
+
+func (m *mockStateStore) MessagesForPR(_ context.Context, _ string) []state.MessageRef {
+	return nil
+}
+
+func (m *mockStateStore) ThreadForMessage(_ context.Context, _, _ string) (state.TrackedThread, bool) {
+	return state.TrackedThread{}, false
+}
+
+func (m *mockStateStore) RemoveThread(_ context.Context, _, _ string, _ int, _ string) error {
+	return nil
+}
+
 func (m *mockStateStore) ClaimThread(_ context.Context, _, _ string, _ int, _ string, _ time.Duration) bool {
 	return true // Always succeed in tests
 }
 
+func (m *mockStateStore) RenewThreadClaim(_ context.Context, _, _ string, _ int, _ string, _ time.Duration) bool {
+	return true
+}
+
+func (m *mockStateStore) ReleaseThreadClaim(_ context.Context, _, _ string, _ int, _ string) error {
+	return nil
+}
+
 func (m *mockStateStore) DMInfo(_ context.Context, _, _ string) (state.DMInfo, bool) {
 	return state.DMInfo{}, false
 }
@@ -107,10 +471,22 @@ func (m *mockStateStore) ClaimDM(_ context.Context, _, _ string, _ time.Duration
 	return true // Always succeed in tests
 }
 
+func (m *mockStateStore) ReleaseDMClaim(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStateStore) ClaimConfigReload(_ context.Context, _ string, _ time.Duration) bool {
+	return true
+}
+
 func (m *mockStateStore) ListDMUsers(_ context.Context, _ string) []string {
 	return nil
 }
 
+func (m *mockStateStore) ListPRsForUser(_ context.Context, _ string) []state.TrackedDM {
+	return nil
+}
+
 func (m *mockStateStore) WasProcessed(_ context.Context, _ string) bool {
 	return false
 }
@@ -119,6 +495,14 @@ func (m *mockStateStore) MarkProcessed(_ context.Context, _ string, _ time.Durat
 	return nil
 }
 
+func (m *mockStateStore) ClaimEvent(_ context.Context, _ string, _ time.Duration) bool {
+	return true
+}
+
+func (m *mockStateStore) ClearProcessed(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
 func (m *mockStateStore) QueuePendingDM(_ context.Context, dm *state.PendingDM) error {
 	m.pendingDMs = append(m.pendingDMs, dm)
 	return nil
@@ -151,6 +535,98 @@ func (m *mockStateStore) SaveDailyReportInfo(_ context.Context, userID string, i
 	return nil
 }
 
+func (m *mockStateStore) Digest(_ context.Context, _ string) (state.DigestInfo, bool) {
+	return state.DigestInfo{}, false
+}
+
+func (m *mockStateStore) SaveDigest(_ context.Context, _ string, _ state.DigestInfo) error {
+	return nil
+}
+
+func (m *mockStateStore) UpdateDigest(_ context.Context, _ string, _ func(*state.DigestInfo) bool) error {
+	return nil
+}
+
+func (m *mockStateStore) Board(_ context.Context, _ string) (state.BoardInfo, bool) {
+	return state.BoardInfo{}, false
+}
+
+func (m *mockStateStore) SaveBoard(_ context.Context, _ string, _ state.BoardInfo) error {
+	return nil
+}
+
+func (m *mockStateStore) UpdateBoard(_ context.Context, _ string, _ func(*state.BoardInfo) bool) error {
+	return nil
+}
+
+func (m *mockStateStore) UpdatePostWindows(_ context.Context, _ string, _ func(*state.PostWindowInfo) bool) error {
+	return nil
+}
+
+func (m *mockStateStore) PROverride(_ context.Context, _ string) (state.PROverride, bool) {
+	return state.PROverride{}, false
+}
+
+func (m *mockStateStore) Maintenance(_ context.Context, _ string) (state.MaintenanceInfo, bool) {
+	return state.MaintenanceInfo{}, false
+}
+
+func (m *mockStateStore) SaveMaintenance(_ context.Context, _ string, _ state.MaintenanceInfo) error {
+	return nil
+}
+
+func (m *mockStateStore) OOO(_ context.Context, _ string) (state.OOOInfo, bool) {
+	return state.OOOInfo{}, false
+}
+
+func (m *mockStateStore) SaveOOO(_ context.Context, _ string, _ state.OOOInfo) error {
+	return nil
+}
+
+func (m *mockStateStore) RepoRedirect(_ context.Context, _, _ string) (state.RepoRedirect, bool) {
+	return state.RepoRedirect{}, false
+}
+
+func (m *mockStateStore) SaveRepoRedirect(_ context.Context, _, _ string, _ state.RepoRedirect) error {
+	return nil
+}
+
+func (m *mockStateStore) LastDM(_ context.Context, _ string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *mockStateStore) SaveLastDM(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *mockStateStore) LastEventAt(_ context.Context, _ string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *mockStateStore) SaveLastEventAt(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *mockStateStore) ConfigReloadRequestedAt(_ context.Context, _ string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *mockStateStore) SaveConfigReloadRequest(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *mockStateStore) SavePROverride(_ context.Context, _ string, _ state.PROverride) error {
+	return nil
+}
+
+func (m *mockStateStore) Escalation(_ context.Context, _, _ string) (state.EscalationInfo, bool) {
+	return state.EscalationInfo{}, false
+}
+
+func (m *mockStateStore) SaveEscalation(_ context.Context, _, _ string, _ state.EscalationInfo) error {
+	return nil
+}
+
 func (m *mockStateStore) UserMapping(_ context.Context, _, _ string) (state.UserMappingInfo, bool) {
 	return state.UserMappingInfo{}, false
 }
@@ -159,6 +635,10 @@ func (m *mockStateStore) SaveUserMapping(_ context.Context, _ string, _ state.Us
 	return nil
 }
 
+func (m *mockStateStore) RemoveUserMapping(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (m *mockStateStore) ListUserMappings(_ context.Context, _ string) []state.UserMappingInfo {
 	return nil
 }
@@ -396,6 +876,131 @@ func TestCoordinatorManager_StatusGetterInterface(t *testing.T) {
 	var _ discord.StatusGetter = (*coordinatorManager)(nil)
 }
 
+func TestCoordinatorManager_ClearDedup(t *testing.T) {
+	store := state.NewMemoryStore()
+	ctx := context.Background()
+	for _, key := range []string{
+		bot.EventKeyPrefix("https://github.com/test-org/repo/pull/1") + "d1",
+		bot.EventKeyPrefix("https://github.com/test-org/repo/pull/2") + "d2",
+		bot.EventKeyPrefix("https://github.com/other-org/repo/pull/1") + "d3",
+	} {
+		if err := store.MarkProcessed(ctx, key, time.Hour); err != nil {
+			t.Fatalf("MarkProcessed() error = %v", err)
+		}
+	}
+
+	cm := &coordinatorManager{
+		active: map[string]context.CancelFunc{"test-org": func() {}, "other-org": func() {}},
+		store:  store,
+		configManager: &mockConfigManager{configs: map[string]*config.DiscordConfig{
+			"test-org":  {Global: config.GlobalConfig{GuildID: "test-guild"}},
+			"other-org": {Global: config.GlobalConfig{GuildID: "other-guild"}},
+		}},
+	}
+
+	if _, err := cm.ClearDedup(ctx, "test-guild", "https://github.com/other-org/repo/pull/1"); err == nil {
+		t.Error("ClearDedup() for another guild's org: error = nil, want error")
+	}
+	if n, err := cm.ClearDedup(ctx, "test-guild", "https://github.com/test-org/repo/pull/1"); err != nil || n != 1 {
+		t.Errorf("ClearDedup(pr) = (%d, %v), want (1, nil)", n, err)
+	}
+	if n, err := cm.ClearDedup(ctx, "test-guild", ""); err != nil || n != 1 {
+		t.Errorf("ClearDedup(all) = (%d, %v), want (1, nil)", n, err)
+	}
+	if n, _ := store.ClearProcessed(ctx, ""); n != 1 {
+		t.Errorf("remaining entries = %d, want 1 (other guild's event kept)", n)
+	}
+}
+
+func TestCoordinatorManager_InspectPR(t *testing.T) {
+	var _ discord.PRInspector = (*coordinatorManager)(nil)
+
+	store := state.NewMemoryStore()
+	ctx := context.Background()
+	for _, org := range []string{"test-org", "other-org"} {
+		if err := store.SaveThread(ctx, org, "repo", 1, "chan-1", state.ThreadInfo{MessageID: "msg-1"}); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+
+	cm := &coordinatorManager{
+		active: map[string]context.CancelFunc{"test-org": func() {}, "other-org": func() {}},
+		store:  store,
+		configManager: &mockConfigManager{configs: map[string]*config.DiscordConfig{
+			"test-org":  {Global: config.GlobalConfig{GuildID: "test-guild"}},
+			"other-org": {Global: config.GlobalConfig{GuildID: "other-guild"}},
+		}},
+	}
+
+	if _, err := cm.InspectPR(ctx, "test-guild", "https://github.com/other-org/repo/pull/1"); err == nil {
+		t.Error("InspectPR() for another guild's org: error = nil, want error")
+	}
+	if _, err := cm.InspectPR(ctx, "test-guild", "not a PR"); err == nil {
+		t.Error("InspectPR() for an invalid URL: error = nil, want error")
+	}
+	inspection, err := cm.InspectPR(ctx, "test-guild", "https://github.com/test-org/repo/pull/1")
+	if err != nil {
+		t.Fatalf("InspectPR() error = %v", err)
+	}
+	if len(inspection.Threads) != 1 {
+		t.Errorf("InspectPR() threads = %d, want 1", len(inspection.Threads))
+	}
+}
+
+func TestCoordinatorManager_GuildLocation(t *testing.T) {
+	var _ discord.TimezoneGetter = (*coordinatorManager)(nil)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	cm := &coordinatorManager{
+		active: map[string]context.CancelFunc{"a-org": func() {}, "b-org": func() {}, "other-org": func() {}},
+		configManager: &mockConfigManager{
+			configs: map[string]*config.DiscordConfig{
+				"a-org":     {Global: config.GlobalConfig{GuildID: "test-guild"}},
+				"b-org":     {Global: config.GlobalConfig{GuildID: "test-guild"}},
+				"other-org": {Global: config.GlobalConfig{GuildID: "other-guild"}},
+			},
+			locations: map[string]*time.Location{"b-org": tokyo},
+		},
+	}
+	if got := cm.GuildLocation("test-guild"); got != tokyo {
+		t.Errorf("GuildLocation(test-guild) = %v, want Asia/Tokyo from b-org", got)
+	}
+	if got := cm.GuildLocation("other-guild"); got != time.UTC {
+		t.Errorf("GuildLocation(other-guild) = %v, want UTC", got)
+	}
+}
+
+func TestCoordinatorManager_ReloadConfig_NoOrgs(t *testing.T) {
+	var _ discord.ConfigReloader = (*coordinatorManager)(nil)
+
+	cm := &coordinatorManager{
+		coordinators: map[string]*bot.Coordinator{"other-org": nil},
+		configManager: &mockConfigManager{configs: map[string]*config.DiscordConfig{
+			"other-org": {Global: config.GlobalConfig{GuildID: "other-guild"}},
+		}},
+	}
+	if _, err := cm.ReloadConfig(context.Background(), "test-guild"); err == nil {
+		t.Error("ReloadConfig() for a guild without orgs: error = nil, want error")
+	}
+}
+
+func TestCoordinatorManager_DMFallback_UnknownOrg(t *testing.T) {
+	var _ notify.DMFallback = (*coordinatorManager)(nil)
+
+	cm := &coordinatorManager{coordinators: make(map[string]*bot.Coordinator)}
+	if err := cm.DMFallback(context.Background(), "other-org", "user1", "hello"); err == nil {
+		t.Error("DMFallback() for an org without a coordinator: error = nil, want error")
+	}
+}
+
+func TestCoordinatorManager_MappingInvalidatorInterface(t *testing.T) {
+	// Test that coordinatorManager implements MappingInvalidator interface
+	var _ discord.MappingInvalidator = (*coordinatorManager)(nil)
+}
+
 func TestCoordinatorManager_ReportGetterInterface(t *testing.T) {
 	// Test that coordinatorManager implements ReportGetter interface
 	var _ discord.ReportGetter = (*coordinatorManager)(nil)
@@ -420,6 +1025,47 @@ func TestHealthHandler(t *testing.T) {
 	}
 }
 
+func TestCoordinatorManager_ActiveGuilds(t *testing.T) {
+	cm := &coordinatorManager{
+		active: map[string]context.CancelFunc{"a-org": func() {}, "b-org": func() {}, "no-client-org": func() {}},
+		discordClients: map[string]*discord.Client{
+			"guild1": {},
+			"guild2": {}, // Its org was removed
+		},
+		configManager: &mockConfigManager{configs: map[string]*config.DiscordConfig{
+			"a-org":         {Global: config.GlobalConfig{GuildID: "guild1"}},
+			"b-org":         {Global: config.GlobalConfig{GuildID: "guild1"}},
+			"no-client-org": {Global: config.GlobalConfig{GuildID: "guild3"}},
+		}},
+	}
+	if got := cm.activeGuilds(); !slices.Equal(got, []string{"guild1"}) {
+		t.Errorf("activeGuilds() = %v, want [guild1]", got)
+	}
+}
+
+func TestReadyzHandler(t *testing.T) {
+	readiness := discord.NewReadiness(discord.NewGuildManager(nil))
+	handler := makeReadyzHandler(readiness)
+	check := func(wantStatus int, wantBody string) {
+		t.Helper()
+		rec := &responseRecorder{headers: make(http.Header)}
+		handler(rec, &http.Request{})
+		if rec.status != wantStatus || rec.body != wantBody {
+			t.Errorf("readyz = (%d, %q), want (%d, %q)", rec.status, rec.body, wantStatus, wantBody)
+		}
+	}
+
+	check(http.StatusServiceUnavailable, "not ready - loading config\n")
+
+	readiness.MarkLoaded([]string{"guild1"})
+	check(http.StatusServiceUnavailable, "not ready - waiting for 1 Discord guilds\n")
+
+	ready := discord.NewReadiness(discord.NewGuildManager(nil))
+	ready.MarkLoaded(nil)
+	handler = makeReadyzHandler(ready)
+	check(http.StatusOK, "ready\n")
+}
+
 func TestSecurityHeadersMiddleware(t *testing.T) {
 	nextCalled := false
 	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -623,6 +1269,82 @@ func TestLoadConfig_MissingRequired(t *testing.T) {
 		if !cfg.AllowPersonalAccounts {
 			t.Error("AllowPersonalAccounts should be true")
 		}
+		if cfg.CommandScope != discord.CommandScopeGuild {
+			t.Errorf("CommandScope = %q, want %q", cfg.CommandScope, discord.CommandScopeGuild)
+		}
+	})
+
+	t.Run("command scope", func(t *testing.T) {
+		t.Setenv("GITHUB_APP_ID", "12345")
+		t.Setenv("GITHUB_PRIVATE_KEY", "test-key")
+		t.Setenv("DISCORD_BOT_TOKEN", "test-token")
+
+		t.Setenv("COMMAND_SCOPE", "global")
+		cfg, err := loadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := discord.CommandTarget(cfg.CommandScope, "guild-123"); got != "" {
+			t.Errorf("command target = %q, want global", got)
+		}
+
+		t.Setenv("COMMAND_SCOPE", "everywhere")
+		if _, err := loadConfig(context.Background()); err == nil {
+			t.Error("expected error for an unknown COMMAND_SCOPE")
+		}
+	})
+
+	t.Run("channel renames", func(t *testing.T) {
+		t.Setenv("GITHUB_APP_ID", "12345")
+		t.Setenv("GITHUB_PRIVATE_KEY", "test-key")
+		t.Setenv("DISCORD_BOT_TOKEN", "test-token")
+
+		cfg, err := loadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.ChannelRenames != discord.ChannelRenamesFollow {
+			t.Errorf("ChannelRenames = %q, want %q by default", cfg.ChannelRenames, discord.ChannelRenamesFollow)
+		}
+
+		t.Setenv("CHANNEL_RENAMES", "rename")
+		if _, err := loadConfig(context.Background()); err == nil {
+			t.Error("expected error for an unknown CHANNEL_RENAMES")
+		}
+	})
+
+	t.Run("unparseable URL policy", func(t *testing.T) {
+		t.Setenv("GITHUB_APP_ID", "12345")
+		t.Setenv("GITHUB_PRIVATE_KEY", "test-key")
+		t.Setenv("DISCORD_BOT_TOKEN", "test-token")
+
+		cfg, err := loadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.UnparseableURLs != bot.UnparseableLog || cfg.UnparseableLogLevel != slog.LevelError {
+			t.Errorf("default policy = %q at %v, want log at ERROR", cfg.UnparseableURLs, cfg.UnparseableLogLevel)
+		}
+
+		t.Setenv("UNPARSEABLE_URL_POLICY", "dead_letter")
+		t.Setenv("UNPARSEABLE_URL_LOG_LEVEL", "warn")
+		cfg, err = loadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.UnparseableURLs != bot.UnparseableDeadLetter || cfg.UnparseableLogLevel != slog.LevelWarn {
+			t.Errorf("policy = %q at %v, want dead_letter at WARN", cfg.UnparseableURLs, cfg.UnparseableLogLevel)
+		}
+
+		t.Setenv("UNPARSEABLE_URL_POLICY", "ignore")
+		if _, err := loadConfig(context.Background()); err == nil {
+			t.Error("expected error for an unknown UNPARSEABLE_URL_POLICY")
+		}
+		t.Setenv("UNPARSEABLE_URL_POLICY", "log")
+		t.Setenv("UNPARSEABLE_URL_LOG_LEVEL", "loud")
+		if _, err := loadConfig(context.Background()); err == nil {
+			t.Error("expected error for an unknown UNPARSEABLE_URL_LOG_LEVEL")
+		}
 	})
 }
 
@@ -853,6 +1575,33 @@ func TestCoordinatorManager_Status_WithMetrics(t *testing.T) {
 	}
 }
 
+func TestCoordinatorManager_Status_RequestPacing(t *testing.T) {
+	pacer := discord.NewRequestPacer(1000)
+	for range 3 {
+		if err := pacer.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+
+	cm := &coordinatorManager{
+		active:        make(map[string]context.CancelFunc),
+		coordinators:  make(map[string]*bot.Coordinator),
+		store:         &mockStateStore{},
+		configManager: config.New(),
+		pacer:         pacer,
+	}
+
+	status := cm.Status(context.Background(), "test-guild")
+	if status.DiscordWrites != 3 {
+		t.Errorf("Status() DiscordWrites = %d, want 3", status.DiscordWrites)
+	}
+	// Back to back writes after the first are held back for the pace
+	if status.DiscordWritesPaced != 2 || status.DiscordPaceWait <= 0 {
+		t.Errorf("Status() DiscordWritesPaced, DiscordPaceWait = %d, %v, want 2 and a wait",
+			status.DiscordWritesPaced, status.DiscordPaceWait)
+	}
+}
+
 func TestAnalyzePRForReport(t *testing.T) {
 	t.Run("invalid PR URL", func(t *testing.T) {
 		pr := bot.PRSearchResult{
@@ -974,3 +1723,51 @@ func TestAnalyzePRForReport(t *testing.T) {
 		}
 	})
 }
+
+type fakeAuthorMapper map[string]string
+
+func (f fakeAuthorMapper) DiscordID(_ context.Context, githubUsername string) string {
+	return f[githubUsername]
+}
+
+func (f fakeAuthorMapper) Mention(_ context.Context, githubUsername string) string {
+	if id := f[githubUsername]; id != "" {
+		return "<@" + id + ">"
+	}
+	return githubUsername
+}
+
+type fakeUserProfiler map[string]discord.UserProfile
+
+func (f fakeUserProfiler) UserProfile(_ context.Context, userID string) (discord.UserProfile, bool) {
+	profile, ok := f[userID]
+	return profile, ok
+}
+
+func TestWithDiscordNames(t *testing.T) {
+	ctx := context.Background()
+	mapper := fakeAuthorMapper{"alice": "discord-alice", "carol": "discord-carol", "dave": "discord-dave"}
+	profiles := fakeUserProfiler{
+		"discord-alice": {DisplayName: "Alice L."},
+		"discord-dave":  {DisplayName: "Dave R."},
+	}
+
+	mapped := discord.PRSummary{Author: "alice", Reviewers: []string{"bob", "carol", "dave"}}
+	withDiscordNames(ctx, &mapped, mapper, profiles)
+	if mapped.AuthorName != "Alice L." {
+		t.Errorf("mapped author = %+v, want the Discord display name", mapped)
+	}
+	// Only dave is mapped to a guild member
+	if want := map[string]string{"dave": "Dave R."}; !maps.Equal(mapped.ReviewerNames, want) {
+		t.Errorf("ReviewerNames = %v, want %v", mapped.ReviewerNames, want)
+	}
+
+	// Unmapped, or mapped to someone no longer in the guild: the GitHub login is shown
+	for _, author := range []string{"bob", "carol"} {
+		summary := discord.PRSummary{Author: author}
+		withDiscordNames(ctx, &summary, mapper, profiles)
+		if summary.AuthorName != "" || summary.ReviewerNames != nil {
+			t.Errorf("author %s = %+v, want the GitHub login left as is", author, summary)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+const (
+	// activitySummaryPeriod is how much activity each summary covers, and how often one is posted.
+	activitySummaryPeriod = 24 * time.Hour
+	// activitySummaryClaimTTL keeps a day's summary claimed past the day, so no instance posts it twice.
+	activitySummaryClaimTTL = 2 * activitySummaryPeriod
+)
+
+// activitySummaries remembers the last day this instance saw summarized, so it doesn't
+// claim the day's summary in the store on every tick.
+type activitySummaries struct {
+	day string // Date in the org's timezone, YYYY-MM-DD
+	mu  sync.Mutex
+}
+
+// PostActivitySummary posts a summary of the org's PR activity over the past day to its admin
+// channel, once per day in the org's timezone across instances: PRs opened, merged, and closed,
+// the average wait for a first review, and DMs sent. It's a no-op unless activity_summary and
+// admin_channel are set.
+func (c *Coordinator) PostActivitySummary(ctx context.Context, now time.Time) {
+	admin := c.config.AdminChannel(c.org)
+	if !c.config.ActivitySummary(c.org) || admin == "" || c.inMaintenance(ctx) {
+		return
+	}
+
+	day := now.In(c.config.Location(c.org)).Format(time.DateOnly)
+	s := &c.activity
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.day == day {
+		return
+	}
+	if !c.store.ClaimEvent(ctx, "activity-summary:"+c.org+":"+day, activitySummaryClaimTTL) {
+		s.day = day
+		return
+	}
+
+	adminID := c.discord.ResolveChannelID(ctx, admin)
+	if adminID == admin {
+		c.logger.Warn("admin channel not found", "channel", admin)
+		s.day = day
+		return
+	}
+	summary := c.collectActivity(ctx, now.Add(-activitySummaryPeriod), now)
+	if _, err := c.discord.PostMessage(ctx, adminID, format.ActivitySummaryMessage(summary)); err != nil {
+		// Leave the day unmarked here; the store claim still stops a repost until it expires
+		c.logger.Warn("failed to post activity summary", "error", err, "channel", admin)
+		return
+	}
+	s.day = day
+	c.logger.Info("posted activity summary",
+		"channel", admin,
+		"opened", summary.Opened,
+		"merged", summary.Merged,
+		"closed", summary.Closed,
+		"dms_sent", summary.DMsSent)
+}
+
+// collectActivity tallies the org's PR activity in [since, until) from its tracked threads and
+// the DMs sent about them. A PR posted to several channels counts once.
+func (c *Coordinator) collectActivity(ctx context.Context, since, until time.Time) format.ActivitySummaryParams {
+	within := func(t time.Time) bool {
+		return !t.Before(since) && t.Before(until)
+	}
+
+	// Merge each PR's channel threads: earliest first seen and first review, latest state
+	// and when it closed
+	prs := make(map[string]state.ThreadInfo)
+	for _, t := range c.store.ListThreads(ctx, c.org) {
+		prURL := FormatPRURL(t.Owner, t.Repo, t.Number)
+		pr, ok := prs[prURL]
+		if !ok {
+			prs[prURL] = t.Info
+			continue
+		}
+		if t.Info.FirstSeenAt.Before(pr.FirstSeenAt) {
+			pr.FirstSeenAt = t.Info.FirstSeenAt
+		}
+		if !t.Info.FirstReviewAt.IsZero() && (pr.FirstReviewAt.IsZero() || t.Info.FirstReviewAt.Before(pr.FirstReviewAt)) {
+			pr.FirstReviewAt = t.Info.FirstReviewAt
+		}
+		if t.Info.UpdatedAt.After(pr.UpdatedAt) {
+			pr.UpdatedAt = t.Info.UpdatedAt
+			pr.LastState = t.Info.LastState
+			pr.ClosedAt = t.Info.ClosedAt
+		}
+		prs[prURL] = pr
+	}
+
+	summary := format.ActivitySummaryParams{Org: c.org}
+	var reviewWait time.Duration
+	for prURL, pr := range prs {
+		if within(pr.FirstSeenAt) {
+			summary.Opened++
+		}
+		// Counted on the day it closed, not again on every later update
+		if within(pr.ClosedAt) {
+			switch format.PRState(pr.LastState) {
+			case format.StateMerged:
+				summary.Merged++
+			case format.StateClosed:
+				summary.Closed++
+			default:
+			}
+		}
+		if within(pr.FirstReviewAt) && pr.FirstReviewAt.After(pr.FirstSeenAt) {
+			summary.Reviewed++
+			reviewWait += pr.FirstReviewAt.Sub(pr.FirstSeenAt)
+		}
+		for _, userID := range c.store.ListDMUsers(ctx, prURL) {
+			if info, ok := c.store.DMInfo(ctx, userID, prURL); ok && within(info.PostedAt) {
+				summary.DMsSent++
+			}
+		}
+	}
+	if summary.Reviewed > 0 {
+		summary.AvgFirstReview = reviewWait / time.Duration(summary.Reviewed)
+	}
+	return summary
+}
+
+// threadNotes are bookkeeping fields of a PR's thread in a channel worked out for an update.
+// They're saved with whatever the update saves, rather than with a write of their own.
+type threadNotes struct {
+	firstReviewAt time.Time
+	closedAt      time.Time
+	reviewRounds  int
+	lastReview    string
+	draft         bool
+}
+
+// noteThread works out the thread's bookkeeping fields for an update to prState: when the PR
+// was first reviewed, for the activity summary's time to first review, and when it closed. They're
+// set on params.threadInfo along with the review round and draft status already in params.notes,
+// and kept in params.notes for a thread saved afresh.
+func (*Coordinator) noteThread(params *channelProcessParams, prState format.PRState) {
+	info := &params.threadInfo
+	if info.FirstReviewAt.IsZero() && (prState == format.StateApproved || prState == format.StateChanges) {
+		info.FirstReviewAt = time.Now()
+	}
+	switch {
+	case prState != format.StateMerged && prState != format.StateClosed:
+		info.ClosedAt = time.Time{}
+	case info.ClosedAt.IsZero():
+		info.ClosedAt = time.Now()
+	default:
+	}
+	info.ReviewRounds, info.LastReview = params.notes.reviewRounds, params.notes.lastReview
+	info.Draft = params.notes.draft
+	params.notes.firstReviewAt, params.notes.closedAt = info.FirstReviewAt, info.ClosedAt
+}
+
+// withNotes returns info, built afresh for a new or found message, with the update's
+// bookkeeping fields.
+func (p *channelProcessParams) withNotes(info state.ThreadInfo) state.ThreadInfo {
+	info.FirstReviewAt = p.notes.firstReviewAt
+	info.ClosedAt = p.notes.closedAt
+	info.ReviewRounds, info.LastReview = p.notes.reviewRounds, p.notes.lastReview
+	info.Draft = p.notes.draft
+	return info
+}
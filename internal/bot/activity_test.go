@@ -0,0 +1,214 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_CollectActivity(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	now := time.Now()
+
+	threads := []struct {
+		repo      string
+		number    int
+		channelID string
+		info      state.ThreadInfo
+	}{
+		// Reviewed an hour after it was first posted in chan-b, so it counts once, from chan-b
+		{"repo", 1, "chan-a", state.ThreadInfo{FirstSeenAt: now.Add(-2 * time.Hour), FirstReviewAt: now.Add(-time.Hour), LastState: "approved"}},
+		{"repo", 1, "chan-b", state.ThreadInfo{FirstSeenAt: now.Add(-3 * time.Hour), LastState: "approved"}},
+		// Opened and reviewed before the window, merged in it
+		{"repo", 2, "chan-a", state.ThreadInfo{FirstSeenAt: now.Add(-48 * time.Hour), FirstReviewAt: now.Add(-47 * time.Hour), LastState: "merged", ClosedAt: now.Add(-2 * time.Hour)}},
+		{"repo", 3, "chan-a", state.ThreadInfo{FirstSeenAt: now.Add(-time.Hour), LastState: "closed", ClosedAt: now.Add(-time.Hour)}},
+		// Merged before the window; a later update doesn't count it again
+		{"repo", 5, "chan-a", state.ThreadInfo{FirstSeenAt: now.Add(-72 * time.Hour), LastState: "merged", ClosedAt: now.Add(-48 * time.Hour), UpdatedAt: now}},
+		{"other", 4, "chan-a", state.ThreadInfo{FirstSeenAt: now.Add(-5 * time.Hour), FirstReviewAt: now.Add(-time.Hour), LastState: "changes_requested"}},
+	}
+	for _, th := range threads {
+		if err := store.SaveThread(ctx, "testorg", th.repo, th.number, th.channelID, th.info); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+	dms := []struct {
+		userID   string
+		prURL    string
+		postedAt time.Time
+		sentAt   time.Time
+	}{
+		{"user-1", "https://github.com/testorg/repo/pull/1", now.Add(-time.Hour), now.Add(-time.Hour)},
+		{"user-2", "https://github.com/testorg/repo/pull/1", now.Add(-30 * time.Hour), now.Add(-time.Hour)}, // Sent before the window, edited in it
+		{"user-1", "https://github.com/testorg/repo/pull/2", now.Add(-2 * time.Hour), now.Add(-2 * time.Hour)},
+	}
+	for _, dm := range dms {
+		if err := store.SaveDMInfo(ctx, dm.userID, dm.prURL, state.DMInfo{PostedAt: dm.postedAt, SentAt: dm.sentAt}); err != nil {
+			t.Fatalf("SaveDMInfo() error = %v", err)
+		}
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	got := coord.collectActivity(ctx, now.Add(-activitySummaryPeriod), now.Add(time.Minute))
+	want := format.ActivitySummaryParams{
+		Org:            "testorg",
+		Opened:         3,
+		Merged:         1,
+		Closed:         1,
+		Reviewed:       2,
+		AvgFirstReview: 3 * time.Hour, // (2h + 4h) / 2
+		DMsSent:        2,
+	}
+	if got != want {
+		t.Errorf("collectActivity() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCoordinator_PostActivitySummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		admin     string
+		wantPosts int
+	}{
+		{"posts once per day", true, "bot-admin", 1},
+		{"disabled", false, "bot-admin", 0},
+		{"no admin channel", true, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["bot-admin"] = "chan-admin"
+
+			configMgr := newMockConfigManager()
+			configMgr.activitySummary = tt.enabled
+			configMgr.adminChannel = tt.admin
+			store := state.NewMemoryStore()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			now := time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC)
+			coord.PostActivitySummary(ctx, now)
+			coord.PostActivitySummary(ctx, now.Add(time.Hour))
+			// Another instance sharing the store doesn't post the day's summary again
+			other := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+			other.PostActivitySummary(ctx, now.Add(2*time.Hour))
+
+			if len(discord.postedMessages) != tt.wantPosts {
+				t.Fatalf("postedMessages = %d, want %d", len(discord.postedMessages), tt.wantPosts)
+			}
+			if tt.wantPosts == 0 {
+				return
+			}
+			if msg := discord.postedMessages[0]; msg.channelID != "chan-admin" || !strings.Contains(msg.text, "**testorg** in the last day") {
+				t.Errorf("posted = %+v, want the summary in the admin channel", msg)
+			}
+
+			// The next day gets its own summary
+			coord.PostActivitySummary(ctx, now.Add(activitySummaryPeriod))
+			if len(discord.postedMessages) != 2 {
+				t.Errorf("postedMessages = %d after the next day, want 2", len(discord.postedMessages))
+			}
+		})
+	}
+}
+
+func TestCoordinator_PostActivitySummary_OrgTimezone(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["bot-admin"] = "chan-admin"
+
+	configMgr := newMockConfigManager()
+	configMgr.activitySummary = true
+	configMgr.adminChannel = "bot-admin"
+	configMgr.location = time.FixedZone("UTC-5", -5*60*60)
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	// Both are March 2 in UTC, but the org's day changes in between
+	now := time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC)
+	coord.PostActivitySummary(ctx, now)
+	coord.PostActivitySummary(ctx, now.Add(6*time.Hour))
+	if len(discord.postedMessages) != 2 {
+		t.Errorf("postedMessages = %d, want one per day in the org's timezone", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_NoteThread(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{WorkflowState: "waiting_for_review"},
+	}
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); !info.FirstReviewAt.IsZero() {
+		t.Fatalf("FirstReviewAt = %v before any review, want zero", info.FirstReviewAt)
+	}
+
+	turn.responses[prURL].Analysis = Analysis{Approved: true}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request_review", DeliveryID: "d-2"})
+	coord.Wait()
+	info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if info.FirstReviewAt.IsZero() || info.FirstReviewAt.Before(info.FirstSeenAt) {
+		t.Errorf("FirstReviewAt = %v, want set after approval (first seen %v)", info.FirstReviewAt, info.FirstSeenAt)
+	}
+	if !info.ClosedAt.IsZero() {
+		t.Errorf("ClosedAt = %v while open, want zero", info.ClosedAt)
+	}
+
+	turn.responses[prURL].PullRequest = PRInfo{Title: "Test PR", Author: "alice", State: "closed", Merged: true}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-3"})
+	coord.Wait()
+	merged, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if merged.ClosedAt.IsZero() {
+		t.Error("ClosedAt is zero after merge, want set")
+	}
+	if !merged.FirstReviewAt.Equal(info.FirstReviewAt) {
+		t.Errorf("FirstReviewAt = %v after merge, want unchanged %v", merged.FirstReviewAt, info.FirstReviewAt)
+	}
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// processAppendMessage handles text channels with append_mode: when the PR's state changes, a new
+// message linking to the previous one is posted instead of the previous one being edited. Changes
+// within a state still edit the latest message. Earlier messages are kept in the PR's stored
+// thread info, and left as they were.
+func (c *Coordinator) processAppendMessage(ctx context.Context, params *channelProcessParams, content string) error {
+	info := params.threadInfo
+	stateChanged := info.LastState != "" && info.LastState != string(params.params.State)
+	if !params.exists || info.MessageID == "" || !stateChanged {
+		if n := len(info.PriorMessageIDs); n > 0 {
+			content = format.WithPreviousLink(content, c.messageLink(params.channelID, info.PriorMessageIDs[n-1]))
+		}
+		return c.processTextMessage(ctx, params, content)
+	}
+
+	prURL := params.params.PRURL
+	if c.deferWrite(ctx, params.channelID, prURL) {
+		c.trackTaggedUsers(params.params)
+		return nil
+	}
+	// Another instance may be appending for the same state change
+	key := EventKeyPrefix(prURL) + "append:" + params.channelID + ":" + info.MessageID + ":" + string(params.params.State)
+	if !c.store.ClaimEvent(ctx, key, eventClaimTTL) {
+		return nil
+	}
+
+	content = format.WithPreviousLink(content, c.messageLink(params.channelID, info.MessageID))
+	chunks := format.SplitMessage(content, c.messageLimit)
+	messageID, err := c.discord.PostMessage(ctx, params.channelID, chunks[0])
+	if err != nil {
+		return fmt.Errorf("append message: %w", err)
+	}
+	c.counters.posts.Add(1)
+	c.noteWrite(params.channelID, prURL)
+	c.observePostLatency(params.eventAt)
+	c.crosspostIfAnnouncement(ctx, params, messageID)
+
+	info.PriorMessageIDs = append(slices.Clone(info.PriorMessageIDs), info.MessageID)
+	info.MessageID = messageID
+	info.MessageIDs = nil
+	info.MessageText = content
+	info.LastState = string(params.params.State)
+	if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, info); err != nil {
+		c.logger.Warn("failed to save thread info", "error", err)
+	}
+	c.postChunks(ctx, params, messageID, chunks)
+	c.logger.Info("appended channel message for state change",
+		"message_id", messageID,
+		"state", params.params.State,
+		"messages", len(info.PriorMessageIDs)+1,
+		"pr", prURL)
+
+	c.trackTaggedUsers(params.params)
+	return nil
+}
+
+// messageLink returns the URL of a message in one of the guild's channels.
+func (c *Coordinator) messageLink(channelID, messageID string) string {
+	return format.MessageLink(c.discord.GuildID(), channelID, messageID)
+}
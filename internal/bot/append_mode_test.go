@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_AppendMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		appendMode  bool
+		wantPosted  int
+		wantUpdated int
+	}{
+		{name: "append mode posts on each state change", appendMode: true, wantPosted: 2},
+		{name: "edit mode edits one message", wantPosted: 1, wantUpdated: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.guildID = "guild-1"
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.appendMode = tt.appendMode
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Audit me", Author: "alice", State: "open"},
+				Analysis:    Analysis{Checks: Checks{Pending: 1}},
+			}
+
+			store := state.NewMemoryStore()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+			turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-2"})
+			coord.Wait()
+
+			if len(discord.postedMessages) != tt.wantPosted || len(discord.updatedMessages) != tt.wantUpdated {
+				t.Fatalf("postedMessages = %d, updatedMessages = %d, want %d and %d",
+					len(discord.postedMessages), len(discord.updatedMessages), tt.wantPosted, tt.wantUpdated)
+			}
+			if !tt.appendMode {
+				return
+			}
+
+			first := discord.postedMessages[0]
+			second := discord.postedMessages[1]
+			if !strings.Contains(second.text, "?st=tests_broken") ||
+				!strings.Contains(second.text, "https://discord.com/channels/guild-1/chan-testrepo/msg-chan-testrepo") {
+				t.Errorf("appended message = %q, want the new state and a link to the first", second.text)
+			}
+			if !strings.Contains(first.text, "?st=tests_running") {
+				t.Errorf("first message = %q, want it left in its state", first.text)
+			}
+
+			info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+			if !ok || len(info.PriorMessageIDs) != 1 || info.PriorMessageIDs[0] != "msg-chan-testrepo" {
+				t.Fatalf("stored thread info = %+v, want the first message kept as prior", info)
+			}
+
+			// A change within the state edits the latest message, keeping its link
+			turn.responses[prURL].PullRequest.Title = "Audit me again"
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-3"})
+			coord.Wait()
+			if len(discord.postedMessages) != 2 || len(discord.updatedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, updatedMessages = %d after a title change, want 2 and 1",
+					len(discord.postedMessages), len(discord.updatedMessages))
+			}
+			if updated := discord.updatedMessages[0]; updated.messageID != info.MessageID ||
+				!strings.Contains(updated.text, "previous update") {
+				t.Errorf("updated = %+v, want the latest message edited with its link", updated)
+			}
+		})
+	}
+}
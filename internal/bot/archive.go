@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// archiveQueue holds merged/closed forum threads waiting out their channel's archive delay.
+type archiveQueue struct {
+	pending map[string]delayedArchive // thread ID -> archive
+	mu      sync.Mutex
+}
+
+// delayedArchive is a forum thread to archive once its delay has passed.
+type delayedArchive struct {
+	at        time.Time
+	owner     string
+	repo      string
+	channelID string
+	prURL     string
+	number    int
+}
+
+// archiveFinishedThread archives a merged/closed PR's forum thread, or if the channel has an
+// archive delay, schedules it so the final update stays visible for a while.
+func (c *Coordinator) archiveFinishedThread(ctx context.Context, params *channelProcessParams) {
+	threadID := params.threadInfo.ThreadID
+	delay := c.config.ArchiveDelay(c.org, params.params.ChannelName)
+	if delay <= 0 {
+		if err := c.discord.ArchiveThread(ctx, threadID); err != nil {
+			c.logger.Warn("failed to archive thread", "error", err)
+		}
+		return
+	}
+
+	// Keep the original deadline; later edits don't push it back
+	at := params.threadInfo.ArchiveAt
+	if at.IsZero() {
+		at = time.Now().Add(delay)
+		params.threadInfo.ArchiveAt = at
+		if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, params.threadInfo); err != nil {
+			c.logger.Warn("failed to save thread archive time", "error", err)
+		}
+	}
+	if c.queueArchive(threadID, delayedArchive{
+		at:        at,
+		owner:     params.owner,
+		repo:      params.repo,
+		channelID: params.channelID,
+		prURL:     params.params.PRURL,
+		number:    params.number,
+	}) {
+		c.logger.Debug("scheduled thread archive",
+			"thread_id", threadID,
+			"pr", params.params.PRURL,
+			"at", at)
+	}
+}
+
+// queueArchive adds a forum thread to the archive queue, reporting whether it wasn't queued yet.
+func (c *Coordinator) queueArchive(threadID string, archive delayedArchive) bool {
+	q := &c.archives
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.pending[threadID]; ok {
+		return false
+	}
+	if q.pending == nil {
+		q.pending = make(map[string]delayedArchive)
+	}
+	q.pending[threadID] = archive
+	return true
+}
+
+// requeueArchives queues the tracked forum threads still due to be archived, which only
+// the store knows of after a restart or when another instance scheduled them.
+func (c *Coordinator) requeueArchives(ctx context.Context) {
+	for _, t := range c.store.ListThreads(ctx, c.org) {
+		if t.Info.ArchiveAt.IsZero() || t.Info.ThreadID == "" {
+			continue
+		}
+		c.queueArchive(t.Info.ThreadID, delayedArchive{
+			at:        t.Info.ArchiveAt,
+			owner:     t.Owner,
+			repo:      t.Repo,
+			channelID: t.Info.ChannelID,
+			prURL:     FormatPRURL(t.Owner, t.Repo, t.Number),
+			number:    t.Number,
+		})
+	}
+}
+
+// FlushArchives archives the forum threads whose archive delay has passed. Threads whose
+// PR was reopened in the meantime are left active.
+func (c *Coordinator) FlushArchives(ctx context.Context) {
+	if c.inMaintenance(ctx) {
+		return
+	}
+	now := time.Now()
+	due := make(map[string]delayedArchive)
+
+	q := &c.archives
+	q.mu.Lock()
+	for threadID, archive := range q.pending {
+		if archive.at.After(now) {
+			continue
+		}
+		due[threadID] = archive
+		delete(q.pending, threadID)
+	}
+	q.mu.Unlock()
+
+	for _, threadID := range slices.Sorted(maps.Keys(due)) {
+		archive := due[threadID]
+		info, ok := c.store.Thread(ctx, archive.owner, archive.repo, archive.number, archive.channelID)
+		if !ok || info.ThreadID != threadID || info.ArchiveAt.IsZero() {
+			continue // Gone, replaced, or archived by another instance
+		}
+		if last := format.PRState(info.LastState); last != format.StateMerged && last != format.StateClosed {
+			c.logger.Debug("PR reopened before its thread was archived",
+				"thread_id", threadID,
+				"pr", archive.prURL)
+			c.clearArchiveAt(ctx, archive, info)
+			continue
+		}
+		if err := c.discord.ArchiveThread(ctx, threadID); err != nil {
+			c.logger.Warn("failed to archive thread", "error", err, "thread_id", threadID)
+			continue
+		}
+		c.clearArchiveAt(ctx, archive, info)
+		c.logger.Info("archived thread after delay",
+			"thread_id", threadID,
+			"pr", archive.prURL)
+	}
+}
+
+// clearArchiveAt records that a thread is no longer due to be archived.
+func (c *Coordinator) clearArchiveAt(ctx context.Context, archive delayedArchive, info state.ThreadInfo) {
+	info.ArchiveAt = time.Time{}
+	if err := c.store.SaveThread(ctx, archive.owner, archive.repo, archive.number, archive.channelID, info); err != nil {
+		c.logger.Warn("failed to clear thread archive time", "error", err)
+	}
+}
@@ -0,0 +1,191 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ArchiveDelay(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.archiveDelays = map[string]time.Duration{"testrepo": 50 * time.Millisecond}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 42, "chan-testrepo", state.ThreadInfo{
+		ThreadID:    "thread-42",
+		MessageID:   "msg-42",
+		ChannelID:   "chan-testrepo",
+		ChannelType: "forum",
+		MessageText: "open content",
+		LastState:   string(format.StateNeedsReview),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "closed", Merged: true},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-merge"})
+	coord.Wait()
+
+	if len(discord.updatedForumPosts) != 1 {
+		t.Fatalf("updatedForumPosts = %d, want the merge shown", len(discord.updatedForumPosts))
+	}
+	if len(discord.archivedThreads) != 0 {
+		t.Fatalf("archivedThreads = %v right after merge, want none until the delay passes", discord.archivedThreads)
+	}
+
+	// Not due yet
+	coord.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 0 {
+		t.Fatalf("archivedThreads = %v before the delay, want none", discord.archivedThreads)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	coord.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 1 || discord.archivedThreads[0] != "thread-42" {
+		t.Errorf("archivedThreads = %v after the delay, want [thread-42]", discord.archivedThreads)
+	}
+
+	// Archives only once
+	coord.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 1 {
+		t.Errorf("archivedThreads = %v after a second flush, want one archive", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ArchiveDelay_Reopened(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.archiveDelays = map[string]time.Duration{"testrepo": time.Millisecond}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 42, "chan-testrepo", state.ThreadInfo{
+		ThreadID:    "thread-42",
+		MessageID:   "msg-42",
+		ChannelID:   "chan-testrepo",
+		ChannelType: "forum",
+		MessageText: "open content",
+		LastState:   string(format.StateNeedsReview),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "closed"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-close"})
+	coord.Wait()
+
+	turn.responses[prURL].PullRequest.State = "open"
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-reopen"})
+	coord.Wait()
+
+	time.Sleep(5 * time.Millisecond)
+	coord.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 0 {
+		t.Errorf("archivedThreads = %v, want none for a reopened PR", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ArchiveDelay_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.archiveDelays = map[string]time.Duration{"testrepo": 50 * time.Millisecond}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 42, "chan-testrepo", state.ThreadInfo{
+		ThreadID:    "thread-42",
+		MessageID:   "msg-42",
+		ChannelID:   "chan-testrepo",
+		ChannelType: "forum",
+		MessageText: "open content",
+		LastState:   string(format.StateNeedsReview),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "closed", Merged: true},
+	}
+	newCoord := func() *Coordinator {
+		return NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    turn,
+			Org:     "testorg",
+		})
+	}
+
+	coord := newCoord()
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-merge"})
+	coord.Wait()
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); info.ArchiveAt.IsZero() {
+		t.Fatal("ArchiveAt not saved for the delayed archive")
+	}
+
+	// The instance that scheduled it restarts before the delay passes
+	restarted := newCoord()
+	time.Sleep(60 * time.Millisecond)
+	restarted.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 0 {
+		t.Fatalf("archivedThreads = %v before the poll requeued it, want none", discord.archivedThreads)
+	}
+	restarted.PollAndReconcile(ctx)
+	restarted.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 1 || discord.archivedThreads[0] != "thread-42" {
+		t.Fatalf("archivedThreads = %v after the poll, want [thread-42]", discord.archivedThreads)
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); !info.ArchiveAt.IsZero() {
+		t.Errorf("ArchiveAt = %v after archiving, want it cleared", info.ArchiveAt)
+	}
+
+	// Later polls don't archive it again
+	restarted.PollAndReconcile(ctx)
+	restarted.FlushArchives(ctx)
+	if len(discord.archivedThreads) != 1 {
+		t.Errorf("archivedThreads = %v after another poll, want one archive", discord.archivedThreads)
+	}
+}
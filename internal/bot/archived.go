@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// archivedNoteTTL is how long an archived repo's note claim is kept, so the note is posted
+// once rather than on every event. Archived repos see few events, so a month is plenty.
+const archivedNoteTTL = 30 * 24 * time.Hour
+
+// repoArchived reports whether a repo's PRs should get no posts or DMs: it's listed in
+// archived_repos, or skip_archived_repos is set and GitHub reports it archived or disabled.
+// Lookup errors fail open, so a GitHub outage doesn't silence active repos.
+func (c *Coordinator) repoArchived(ctx context.Context, owner, repo string) bool {
+	if c.config.ArchivedRepo(c.org, repo) {
+		c.logger.Debug("skipping event for repo listed in archived_repos", "repo", repo)
+		return true
+	}
+	if !c.config.SkipArchivedRepos(c.org) || c.repos == nil {
+		return false
+	}
+	archived, err := c.repos.RepoArchived(ctx, owner, repo)
+	if err != nil {
+		c.logger.Warn("failed to look up whether repo is archived, processing event", "repo", repo, "error", err)
+		return false
+	}
+	if archived {
+		c.logger.Debug("skipping event for archived repo", "repo", repo)
+	}
+	return archived
+}
+
+// noteArchivedRepo posts archived_repo_note, if set, to the text channels of a skipped repo.
+// It's posted once per repo, however many instances see its events.
+func (c *Coordinator) noteArchivedRepo(ctx context.Context, owner, repo string) {
+	note := c.config.ArchivedRepoNote(c.org)
+	if note == "" {
+		return
+	}
+	if !c.store.ClaimEvent(ctx, "archived-repo:"+owner+"/"+repo, archivedNoteTTL) {
+		return
+	}
+
+	text := format.ArchivedRepoMessage(owner, repo, note)
+	for _, channelName := range c.config.ChannelsForRepo(c.org, repo) {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName || !c.discord.IsBotInChannel(ctx, channelID) {
+			continue
+		}
+		// Forum channels only take threads; the note isn't worth one
+		if c.discord.IsForumChannel(ctx, channelID) {
+			continue
+		}
+		if _, err := c.discord.PostMessage(ctx, channelID, text); err != nil {
+			c.logger.Warn("failed to post archived repo note",
+				"channel", channelName,
+				"repo", repo,
+				"error", err)
+			continue
+		}
+		c.counters.posts.Add(1)
+		c.logger.Info("posted archived repo note", "channel", channelName, "repo", repo)
+	}
+}
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+type mockRepoStatus struct {
+	archived map[string]bool // owner/repo -> archived
+	err      error
+	calls    int
+}
+
+func (m *mockRepoStatus) RepoArchived(_ context.Context, owner, repo string) (bool, error) {
+	m.calls++
+	return m.archived[owner+"/"+repo], m.err
+}
+
+func TestCoordinator_ProcessEvent_ArchivedRepo(t *testing.T) {
+	tests := []struct {
+		name         string
+		listed       []string
+		skipArchived bool
+		statusErr    error
+		wantPosted   bool
+	}{
+		{name: "archived on GitHub, skipped", skipArchived: true},
+		{name: "listed in archived_repos, skipped", listed: []string{"oldrepo"}},
+		{name: "archived on GitHub without skip_archived_repos, processed", wantPosted: true},
+		{name: "lookup fails, processed", skipArchived: true, statusErr: errors.New("rate limited"), wantPosted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["oldrepo"] = "chan-oldrepo"
+			discord.botInChannel["chan-oldrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.archivedRepos = tt.listed
+			configMgr.skipArchived = tt.skipArchived
+
+			prURL := "https://github.com/testorg/oldrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Old change", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Repos:   &mockRepoStatus{archived: map[string]bool{"testorg/oldrepo": true}, err: tt.statusErr},
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+
+			if posted := len(discord.postedMessages) > 0; posted != tt.wantPosted {
+				t.Errorf("posted = %v, want %v", posted, tt.wantPosted)
+			}
+			if called := turn.callCount > 0; called != tt.wantPosted {
+				t.Errorf("Turn called = %v, want %v", called, tt.wantPosted)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ActiveRepoNotSkipped(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.skipArchived = true
+	configMgr.archivedNote = "📦 archived"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add feature", Author: "alice", State: "open"},
+	}
+	repos := &mockRepoStatus{archived: map[string]bool{"testorg/oldrepo": true}}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Repos:   repos,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	if repos.calls != 1 {
+		t.Errorf("RepoArchived calls = %d, want 1", repos.calls)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if strings.Contains(discord.postedMessages[0].text, "📦 archived") {
+		t.Errorf("message = %q, want no archived note for an active repo", discord.postedMessages[0].text)
+	}
+}
+
+func TestCoordinator_ProcessEvent_ArchivedRepoNoteOnce(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["oldrepo"] = "chan-oldrepo"
+	discord.botInChannel["chan-oldrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.archivedRepos = []string{"oldrepo"}
+	configMgr.archivedNote = "📦 archived"
+
+	turn := newMockTurnClient()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	for number := range 2 {
+		coord.ProcessEvent(ctx, SprinklerEvent{
+			URL:        fmt.Sprintf("https://github.com/testorg/oldrepo/pull/%d", number+1),
+			Type:       "pull_request",
+			DeliveryID: fmt.Sprintf("d-%d", number+1),
+		})
+	}
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want the note once", len(discord.postedMessages))
+	}
+	want := "📦 archived: [testorg/oldrepo](<https://github.com/testorg/oldrepo>)"
+	if got := discord.postedMessages[0]; got.channelID != "chan-oldrepo" || got.text != want {
+		t.Errorf("posted %q to %s, want %q to chan-oldrepo", got.text, got.channelID, want)
+	}
+	if turn.callCount != 0 {
+		t.Errorf("Turn calls = %d, want 0", turn.callCount)
+	}
+}
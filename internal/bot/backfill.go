@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// lastEventSaveEvery is how often the time of the latest event is saved to the store. The
+// backfill starts at most this much earlier than needed, which only re-checks a few PRs.
+const lastEventSaveEvery = time.Minute
+
+// lastEventTracker remembers when this instance last saved the org's latest event time.
+type lastEventTracker struct {
+	saved time.Time
+	mu    sync.Mutex
+}
+
+// noteEventSeen saves when the org's latest event was seen, for the next startup's backfill.
+func (c *Coordinator) noteEventSeen(ctx context.Context, now time.Time) {
+	t := &c.lastEvent
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.saved) < lastEventSaveEvery {
+		return
+	}
+	if err := c.store.SaveLastEventAt(ctx, c.org, now); err != nil {
+		c.logger.Warn("failed to save last event time", "error", err)
+		return
+	}
+	t.saved = now
+}
+
+// Backfill processes the PRs updated while the bot was down, so events it never received
+// don't go unnoticed: those updated after the last event seen before the restart, looking
+// back at most the org's backfill_window, up to now. It's meant to run once on startup,
+// before events arrive: it reads when the last event was seen right away, then processes
+// the PRs in the background so live events aren't held up. Replicas restarted together
+// share the last event's time, and only the first to claim it backfills. PRs are processed
+// as polls, so a later poll of the same update is deduplicated. It's a no-op unless
+// backfill_window is set and an earlier event was seen.
+func (c *Coordinator) Backfill(ctx context.Context, now time.Time) {
+	window := c.config.BackfillWindow(c.org)
+	if window <= 0 || c.searcher == nil {
+		return
+	}
+	lastSeen, ok := c.store.LastEventAt(ctx, c.org)
+	if !ok {
+		c.logger.Debug("skipping backfill - no earlier event seen")
+		return
+	}
+	since := lastSeen
+	if earliest := now.Add(-window); since.Before(earliest) {
+		since = earliest
+	}
+	if !since.Before(now) {
+		return
+	}
+	if !c.store.ClaimEvent(ctx, "backfill:"+c.org+":"+strconv.FormatInt(lastSeen.UnixNano(), 10), eventDeduplicationTTL) {
+		c.logger.Debug("skipping backfill - another instance is doing it")
+		return
+	}
+
+	c.wg.Go(func() {
+		c.backfill(ctx, since, now)
+	})
+}
+
+// backfill processes the org's PRs updated between since and now.
+func (c *Coordinator) backfill(ctx context.Context, since, now time.Time) {
+
+	hours := int(math.Ceil(now.Sub(since).Hours()))
+	openPRs, err := c.searcher.ListOpenPRs(ctx, c.org, hours)
+	if err != nil {
+		c.logger.Error("failed to list open PRs for backfill", "error", err)
+	}
+	closedPRs, err := c.searcher.ListClosedPRs(ctx, c.org, hours)
+	if err != nil {
+		c.logger.Error("failed to list closed PRs for backfill", "error", err)
+	}
+
+	seen := make(map[string]bool)
+	backfilled := 0
+	for _, pr := range slices.Concat(openPRs, closedPRs) {
+		// Search is by the hour, so it also finds PRs updated just before the downtime
+		if seen[pr.URL] || !pr.UpdatedAt.After(since) || pr.UpdatedAt.After(now) {
+			continue
+		}
+		seen[pr.URL] = true
+		c.reconcilePR(ctx, pr)
+		backfilled++
+	}
+	c.logger.Info("backfilled PRs updated while the bot was down",
+		"since", since.Format(time.RFC3339),
+		"prs", backfilled)
+}
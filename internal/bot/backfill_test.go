@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_Backfill(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	prURL := func(n int) string { return fmt.Sprintf("https://github.com/testorg/testrepo/pull/%d", n) }
+	search := func(n int, updatedAt time.Time) PRSearchResult {
+		return PRSearchResult{URL: prURL(n), Owner: "testorg", Repo: "testrepo", Number: n, UpdatedAt: updatedAt}
+	}
+
+	tests := []struct {
+		name     string
+		lastSeen time.Time // Zero if no event was seen
+		window   time.Duration
+		want     []int
+	}{
+		{name: "since the last event", lastSeen: now.Add(-3 * time.Hour), window: 24 * time.Hour, want: []int{1, 4}},
+		{name: "capped at the window", lastSeen: now.Add(-72 * time.Hour), window: 4 * time.Hour, want: []int{1, 4}},
+		{name: "window reaches further back", lastSeen: now.Add(-6 * time.Hour), window: 24 * time.Hour, want: []int{1, 2, 4}},
+		{name: "no earlier event", window: 24 * time.Hour},
+		{name: "disabled", lastSeen: now.Add(-3 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			configMgr := newMockConfigManager()
+			configMgr.backfillWindow = tt.window
+
+			turn := newMockTurnClient()
+			for n := 1; n <= 4; n++ {
+				turn.responses[prURL(n)] = &CheckResponse{
+					PullRequest: PRInfo{Title: fmt.Sprintf("PR %d", n), Author: "alice", State: "open"},
+				}
+			}
+			searcher := &mockPRSearcher{
+				openPRs: []PRSearchResult{
+					search(1, now.Add(-time.Hour)),   // Updated while down
+					search(2, now.Add(-5*time.Hour)), // Before the last event
+					search(3, now.Add(time.Minute)),  // Since startup; events cover it
+				},
+				closedPRs: []PRSearchResult{
+					search(4, now.Add(-2*time.Hour)), // Merged while down
+				},
+			}
+
+			store := state.NewMemoryStore()
+			if !tt.lastSeen.IsZero() {
+				if err := store.SaveLastEventAt(ctx, "testorg", tt.lastSeen); err != nil {
+					t.Fatalf("SaveLastEventAt() error = %v", err)
+				}
+			}
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:  discord,
+				Config:   configMgr,
+				Store:    store,
+				Turn:     turn,
+				Searcher: searcher,
+				Org:      "testorg",
+			})
+
+			coord.Backfill(ctx, now)
+			coord.Wait()
+
+			var got []int
+			for n := 1; n <= 4; n++ {
+				if _, ok := store.Thread(ctx, "testorg", "testrepo", n, "chan-testrepo"); ok {
+					got = append(got, n)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("backfilled PRs = %v, want %v", got, tt.want)
+			}
+
+			// The next poll sees PR 1's same update and skips it
+			if len(tt.want) == 0 {
+				return
+			}
+			calls := turn.callCount
+			searcher.openPRs = searcher.openPRs[:1]
+			searcher.closedPRs = nil
+			coord.PollAndReconcile(ctx)
+			if turn.callCount != calls {
+				t.Errorf("Turn calls = %d after the poll, want %d", turn.callCount, calls)
+			}
+		})
+	}
+}
+
+func TestCoordinator_Backfill_OneReplica(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+
+	// Replicas restarted together share the store, and with it the last event's time
+	store := state.NewMemoryStore()
+	if err := store.SaveLastEventAt(ctx, "testorg", now.Add(-3*time.Hour)); err != nil {
+		t.Fatalf("SaveLastEventAt() error = %v", err)
+	}
+
+	var turns []*mockTurnClient
+	for range 2 {
+		discord := newMockDiscordClient()
+		discord.channelIDs["testrepo"] = "chan-testrepo"
+		discord.botInChannel["chan-testrepo"] = true
+		configMgr := newMockConfigManager()
+		configMgr.backfillWindow = 24 * time.Hour
+		turn := newMockTurnClient()
+		turn.responses[prURL] = &CheckResponse{
+			PullRequest: PRInfo{Title: "PR 1", Author: "alice", State: "open"},
+		}
+		turns = append(turns, turn)
+
+		coord := NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    turn,
+			Searcher: &mockPRSearcher{openPRs: []PRSearchResult{
+				{URL: prURL, Owner: "testorg", Repo: "testrepo", Number: 1, UpdatedAt: now.Add(-time.Hour)},
+			}},
+			Org: "testorg",
+		})
+		coord.Backfill(ctx, now)
+		coord.Wait()
+	}
+
+	if turns[0].callCount != 1 || turns[1].callCount != 0 {
+		t.Errorf("Turn calls per replica = %d, %d; want 1, 0", turns[0].callCount, turns[1].callCount)
+	}
+}
+
+func TestCoordinator_ProcessEvent_SavesLastEventTime(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	before := time.Now()
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL: "https://github.com/testorg/testrepo/pull/1", Type: "pull_request", DeliveryID: "d-1",
+	})
+	coord.Wait()
+
+	seenAt, ok := store.LastEventAt(ctx, "testorg")
+	if !ok || seenAt.Before(before) {
+		t.Errorf("LastEventAt() = %v, %v; want a time after %v", seenAt, ok, before)
+	}
+}
@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// recordBoardChange updates a PR's line on a board-mode channel's board, dropping it once
+// the PR is merged or closed, instead of posting or editing a message of its own.
+// The board message itself is edited by RefreshBoards.
+func (c *Coordinator) recordBoardChange(ctx context.Context, channelID string, params format.ChannelMessageParams) error {
+	entry := state.BoardEntry{
+		Owner:  params.Owner,
+		Repo:   params.Repo,
+		Number: params.Number,
+		Title:  params.Title,
+		State:  string(params.State),
+	}
+	finished := params.State == format.StateMerged || params.State == format.StateClosed
+
+	recorded := false
+	listed := 0
+	err := c.store.UpdateBoard(ctx, channelID, func(info *state.BoardInfo) bool {
+		prev, ok := info.PRs[params.PRURL]
+		switch {
+		case finished && !ok:
+			return false
+		case !finished && ok && prev.Title == entry.Title && prev.State == entry.State:
+			// Polling re-reports PRs that haven't changed
+			return false
+		}
+
+		info.PRs = maps.Clone(info.PRs)
+		if info.PRs == nil {
+			info.PRs = make(map[string]state.BoardEntry)
+		}
+		if finished {
+			delete(info.PRs, params.PRURL)
+		} else {
+			entry.UpdatedAt = time.Now()
+			info.PRs[params.PRURL] = entry
+		}
+		info.Revision++
+		recorded, listed = true, len(info.PRs)
+		return true
+	})
+	if err != nil || !recorded {
+		return err
+	}
+
+	c.logger.Debug("recorded change for channel board",
+		"channel", params.ChannelName,
+		"pr", params.PRURL,
+		"state", params.State,
+		"prs", listed)
+	return nil
+}
+
+// RefreshBoards edits each board-mode channel's board message to show its open PRs'
+// latest states, posting and pinning the message if the channel doesn't have one yet.
+func (c *Coordinator) RefreshBoards(ctx context.Context) {
+	if c.inMaintenance(ctx) {
+		return
+	}
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return
+	}
+
+	for _, channelName := range slices.Sorted(maps.Keys(cfg.Channels)) {
+		if !c.config.Board(c.org, channelName) {
+			continue
+		}
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if err := c.refreshBoard(ctx, channelID, channelName); err != nil {
+			c.logger.Warn("failed to refresh channel board",
+				"channel", channelName,
+				"error", err)
+		}
+	}
+}
+
+// dashboardOrgURL returns the org's dashboard page, which a board links for PRs it leaves out.
+func (c *Coordinator) dashboardOrgURL() string {
+	if link := format.DashboardTemplateOrgURL(c.config.DashboardURLTemplate(c.org), c.org); link != "" {
+		return link
+	}
+	return format.DashboardOrgURL(c.dashboardURL, c.org)
+}
+
+func (c *Coordinator) refreshBoard(ctx context.Context, channelID, channelName string) error {
+	info, exists := c.store.Board(ctx, channelID)
+	if !exists || (info.MessageID != "" && info.Rendered == info.Revision) {
+		return nil
+	}
+	// Every instance refreshes; the first to claim this revision of the board writes it
+	if !c.store.ClaimEvent(ctx, "board:"+channelID+":"+strconv.Itoa(info.Revision), eventClaimTTL) {
+		return nil
+	}
+
+	prs := make([]format.ChannelMessageParams, 0, len(info.PRs))
+	for prURL, entry := range info.PRs {
+		prs = append(prs, format.ChannelMessageParams{
+			Owner:       entry.Owner,
+			Repo:        entry.Repo,
+			Number:      entry.Number,
+			Title:       entry.Title,
+			State:       format.PRState(entry.State),
+			PRURL:       prURL,
+			ChannelName: channelName,
+		})
+	}
+	text := format.BoardMessage(prs, c.config.BoardMaxPRs(c.org, channelName), c.dashboardOrgURL())
+
+	if info.MessageID != "" {
+		err := c.discord.UpdateMessage(ctx, channelID, info.MessageID, text, true)
+		if err != nil && !errors.Is(err, discord.ErrNotFound) {
+			return err
+		}
+		if err != nil {
+			c.logger.Info("channel board message no longer exists in Discord, re-creating it",
+				"channel", channelName,
+				"message_id", info.MessageID)
+			info.MessageID = ""
+		}
+	}
+	if info.MessageID == "" {
+		messageID, err := c.discord.PostMessage(ctx, channelID, text)
+		if err != nil {
+			return err
+		}
+		c.counters.posts.Add(1)
+		info.MessageID = messageID
+		c.logger.Info("posted channel board",
+			"channel", channelName,
+			"message_id", messageID,
+			"prs", len(prs))
+
+		// Later changes edit this message, so it only needs pinning once
+		if err := c.discord.PinMessage(ctx, channelID, messageID); err != nil {
+			c.logger.Warn("failed to pin channel board",
+				"channel", channelName,
+				"message_id", messageID,
+				"error", err)
+		}
+	}
+
+	// Changes recorded meanwhile bump the revision past this one, so the next refresh shows them
+	return c.store.UpdateBoard(ctx, channelID, func(cur *state.BoardInfo) bool {
+		cur.MessageID = info.MessageID
+		cur.Rendered = max(cur.Rendered, info.Revision)
+		return true
+	})
+}
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_Board(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {Board: true}},
+	}
+	configMgr.boards = map[string]bool{"testrepo": true}
+
+	turn := newMockTurnClient()
+	prURL := func(n int) string { return fmt.Sprintf("https://github.com/testorg/testrepo/pull/%d", n) }
+	turn.responses[prURL(1)] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Approved PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{Approved: true},
+	}
+	turn.responses[prURL(2)] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Broken PR", Author: "bob", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Failing: 1}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	for _, n := range []int{1, 2} {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL(n), Type: "pull_request", DeliveryID: fmt.Sprintf("d-%d", n)})
+		coord.Wait()
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d, want 0 (board channels get no live posts)", len(discord.postedMessages))
+	}
+
+	coord.RefreshBoards(ctx)
+	coord.RefreshBoards(ctx)
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1 board", len(discord.postedMessages))
+	}
+	if len(discord.pinned) != 1 || discord.pinned[0] != "chan-testrepo/msg-chan-testrepo" {
+		t.Errorf("pinned = %v, want the board message pinned on creation", discord.pinned)
+	}
+	text := discord.postedMessages[0].text
+	broken, approved := strings.Index(text, "Broken PR"), strings.Index(text, "Approved PR")
+	if broken < 0 || approved < 0 || broken > approved {
+		t.Errorf("board = %q, want the broken PR listed before the approved one", text)
+	}
+
+	// Merging a PR edits the same board message to drop it
+	turn.responses[prURL(2)] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Broken PR", Author: "bob", State: "closed", Merged: true},
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL(2), Type: "pull_request", DeliveryID: "d-merged"})
+	coord.Wait()
+	coord.RefreshBoards(ctx)
+
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after a change, want the board edited instead", len(discord.postedMessages))
+	}
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1 board edit", len(discord.updatedMessages))
+	}
+	edit := discord.updatedMessages[0]
+	if edit.messageID != "msg-chan-testrepo" || !edit.silent || strings.Contains(edit.text, "Broken PR") {
+		t.Errorf("board edit = %+v, want the merged PR dropped from the posted board", edit)
+	}
+	if len(discord.pinned) != 1 {
+		t.Errorf("pinned = %v after an edit, want no re-pin", discord.pinned)
+	}
+}
+
+func TestCoordinator_Board_MaxPRs(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {Board: true, BoardMaxPRs: 2}},
+	}
+	configMgr.boards = map[string]bool{"testrepo": true}
+	configMgr.boardMaxPRs = map[string]int{"testrepo": 2}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:      discord,
+		Config:       configMgr,
+		Store:        store,
+		Turn:         newMockTurnClient(),
+		Org:          "testorg",
+		DashboardURL: "https://dash.example.com",
+	})
+
+	prs := make(map[string]state.BoardEntry)
+	for n := 1; n <= 3; n++ {
+		prs[fmt.Sprintf("https://github.com/testorg/testrepo/pull/%d", n)] = state.BoardEntry{
+			Owner: "testorg", Repo: "testrepo", Number: n, Title: fmt.Sprintf("PR %d", n), State: "needs_review",
+		}
+	}
+	if err := store.SaveBoard(ctx, "chan-testrepo", state.BoardInfo{PRs: prs, Revision: 1}); err != nil {
+		t.Fatalf("SaveBoard() error = %v", err)
+	}
+
+	coord.RefreshBoards(ctx)
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1 board", len(discord.postedMessages))
+	}
+	text := discord.postedMessages[0].text
+	if strings.Count(text, "\n") != 3 || strings.Contains(text, "PR 3") {
+		t.Errorf("board = %q, want 2 PR lines and an overflow note", text)
+	}
+	if !strings.Contains(text, "…and 1 more · [dashboard](https://dash.example.com/orgs/testorg)") {
+		t.Errorf("board = %q, want the overflow note linking the org's dashboard", text)
+	}
+}
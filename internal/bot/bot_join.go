@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// botJoinEvent is the event type replayed to recheck a channel the bot couldn't post to.
+const botJoinEvent = "bot_join_recheck"
+
+// joinRetries tracks the channels the bot couldn't post a PR to, each rechecked by replaying
+// the PR with backoff until the org's bot_join_grace runs out. The waits happen in timers,
+// so a channel that's still joining doesn't hold up the PR's lock or an event slot.
+type joinRetries struct {
+	pending map[string]*joinRetry // joinRetryKey -> retry state
+	mu      sync.Mutex
+}
+
+// joinRetryKey keys a channel's join retries for a PR, apart from the write cooldown's keys.
+func joinRetryKey(channelID, prURL string) string {
+	return "join/" + channelID + "/" + prURL
+}
+
+type joinRetry struct {
+	deadline time.Time   // Rechecks stop once this passes
+	timer    *time.Timer // Pending replay; nil while the replay is running
+	delay    time.Duration
+	attempts int
+}
+
+// botInChannel checks whether the bot can post to a channel. If it can't, the PR is replayed
+// with backoff for the org's bot_join_grace, since permissions can lag for a few seconds
+// after the bot joins.
+func (c *Coordinator) botInChannel(ctx context.Context, channelID, channelName, prURL string) bool {
+	key := joinRetryKey(channelID, prURL)
+	if c.discord.IsBotInChannel(ctx, channelID) {
+		j := &c.joinRetries
+		j.mu.Lock()
+		r, retried := j.pending[key]
+		delete(j.pending, key)
+		j.mu.Unlock()
+		if retried {
+			c.logger.Info("bot can post to channel after retrying",
+				"channel", channelName,
+				"pr_url", prURL,
+				"attempts", r.attempts+1)
+		}
+		return true
+	}
+	c.retryBotJoin(ctx, key, channelName, prURL)
+	return false
+}
+
+// retryBotJoin schedules the next recheck of a channel the bot couldn't post a PR to,
+// giving up once the org's bot_join_grace has passed since the first failed check.
+func (c *Coordinator) retryBotJoin(ctx context.Context, key, channelName, prURL string) {
+	j := &c.joinRetries
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	r, ok := j.pending[key]
+	if !ok {
+		grace := c.config.BotJoinGrace(c.org)
+		if grace <= 0 {
+			return
+		}
+		if j.pending == nil {
+			j.pending = make(map[string]*joinRetry)
+		}
+		// Forget retries whose replay was never rendered, e.g. because the PR left the channel
+		for k, old := range j.pending {
+			if old.timer == nil && now.After(old.deadline) {
+				delete(j.pending, k)
+			}
+		}
+		r = &joinRetry{deadline: now.Add(grace), delay: botJoinRetryDelay}
+		j.pending[key] = r
+	}
+	if r.timer != nil {
+		return // A recheck is already scheduled
+	}
+	if !now.Before(r.deadline) {
+		delete(j.pending, key)
+		c.logger.Debug("bot still can't post to channel, giving up",
+			"channel", channelName,
+			"pr_url", prURL,
+			"attempts", r.attempts+1)
+		return
+	}
+
+	wait := min(r.delay, r.deadline.Sub(now))
+	r.delay *= 2
+	r.attempts++
+
+	// Counted in wg until the replay is dispatched, so Wait covers pending replays
+	c.wg.Add(1)
+	r.timer = time.AfterFunc(wait, func() {
+		defer c.wg.Done()
+		j.mu.Lock()
+		r.timer = nil
+		j.mu.Unlock()
+
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        prURL,
+			Type:       botJoinEvent,
+			DeliveryID: "join-" + strconv.FormatInt(time.Now().UnixNano(), 10),
+			Timestamp:  time.Now(),
+		})
+	})
+	c.logger.Debug("bot can't post to channel yet, rechecking",
+		"channel", channelName,
+		"pr_url", prURL,
+		"wait", wait)
+}
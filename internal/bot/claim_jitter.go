@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// maxJitteredEvents bounds how many jittered events may wait for their claim at once. Past
+// it, ProcessEvent blocks, pushing back on the event stream rather than piling up goroutines.
+const maxJitteredEvents = 10 * maxConcurrentEvents
+
+// jitterOrder keeps each PR's jittered events in the order they arrived, though each draws
+// its own delay: an event waits for the PR's previous one to be handled as well as its delay.
+type jitterOrder struct {
+	tails map[string]chan struct{} // PR URL -> closed once its latest jittered event is handled
+	mu    sync.Mutex
+}
+
+// enqueue queues a jittered event for a PR. It returns a channel closed once the PR's
+// previous jittered event is handled, nil if there's none, and a func to call once this
+// one is handled.
+func (o *jitterOrder) enqueue(prURL string) (prev <-chan struct{}, done func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.tails == nil {
+		o.tails = make(map[string]chan struct{})
+	}
+	if tail, ok := o.tails[prURL]; ok {
+		prev = tail
+	}
+	mine := make(chan struct{})
+	o.tails[prURL] = mine
+	return prev, func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		close(mine)
+		if o.tails[prURL] == mine {
+			delete(o.tails, prURL)
+		}
+	}
+}
+
+// jitterDelay returns the delay before claiming a webhook event given the claim jitter;
+// tests replace it to order replicas' claims.
+func (c *Coordinator) jitterDelay(jitter time.Duration) time.Duration {
+	if c.claimDelay != nil {
+		return c.claimDelay(jitter)
+	}
+	return claimJitterDelay(jitter)
+}
+
+// claimJitterDelay returns a random delay of up to maxDelay before claiming an event.
+func claimJitterDelay(maxDelay time.Duration) time.Duration {
+	if maxDelay <= 0 {
+		return 0
+	}
+	return rand.N(maxDelay + 1) //nolint:gosec // spreading out replicas needs no cryptographic randomness
+}
+
+// awaitJitteredTurn waits until a jittered event may be processed: its delay has passed, the
+// PR's previous jittered event has been handled, and it holds an event slot.
+func (c *Coordinator) awaitJitteredTurn(ctx context.Context, jitter time.Duration, prev <-chan struct{}) error {
+	if err := c.waitToClaim(ctx, jitter); err != nil {
+		return err
+	}
+	if prev != nil {
+		select {
+		case <-prev:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	select {
+	case c.eventSem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitToClaim waits out a random delay of up to jitter before a webhook event is claimed.
+// Replicas consuming the same event stream get it at about the same moment, and a claim can
+// take a moment to reach the shared store; spreading them out lets the later replica see the
+// earlier's claim instead of both claiming the event and double-posting.
+func (c *Coordinator) waitToClaim(ctx context.Context, jitter time.Duration) error {
+	delay := c.jitterDelay(jitter)
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,266 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// laggyClaimStore is a shared store whose event claims only become visible to other claimers
+// after a delay, like a claim still on its way to the store's backend.
+type laggyClaimStore struct {
+	*state.MemoryStore
+	claims map[string]time.Time // Event key -> when its claim becomes visible
+	lag    time.Duration
+	mu     sync.Mutex
+}
+
+func (s *laggyClaimStore) ClaimEvent(_ context.Context, eventKey string, _ time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if visibleAt, ok := s.claims[eventKey]; ok && !time.Now().Before(visibleAt) {
+		return false
+	}
+	if _, ok := s.claims[eventKey]; !ok {
+		s.claims[eventKey] = time.Now().Add(s.lag)
+	}
+	return true
+}
+
+func TestCoordinator_ProcessEvent_ReplicasClaimOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		jitter     time.Duration
+		wantChecks int // Events processed across both replicas, each checking Turn once
+	}{
+		// Without jitter both replicas claim before either claim is visible; this is what
+		// the jitter is for
+		{"no jitter", 0, 2},
+		{"jitter", 100 * time.Millisecond, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			store := &laggyClaimStore{ // Shared by both replicas
+				MemoryStore: state.NewMemoryStore(),
+				claims:      make(map[string]time.Time),
+				lag:         20 * time.Millisecond,
+			}
+
+			var replicas []*Coordinator
+			var turns []*mockTurnClient
+			for i := range 2 {
+				discord := newMockDiscordClient()
+				discord.channelIDs["testrepo"] = "chan-testrepo"
+				discord.botInChannel["chan-testrepo"] = true
+				turn := newMockTurnClient()
+				turn.responses[prURL] = &CheckResponse{
+					PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+				}
+				coord := NewCoordinator(CoordinatorConfig{
+					Discord:     discord,
+					Config:      newMockConfigManager(),
+					Store:       store,
+					Turn:        turn,
+					Org:         "testorg",
+					ClaimJitter: tt.jitter,
+				})
+				// The first replica draws no delay and the second the longest
+				coord.claimDelay = func(jitter time.Duration) time.Duration { return time.Duration(i) * jitter }
+				replicas = append(replicas, coord)
+				turns = append(turns, turn)
+			}
+
+			event := SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"}
+			for _, coord := range replicas {
+				coord.ProcessEvent(ctx, event)
+			}
+			for _, coord := range replicas {
+				coord.Wait()
+			}
+
+			if checks := turns[0].callCount + turns[1].callCount; checks != tt.wantChecks {
+				t.Errorf("events processed across replicas = %d, want %d", checks, tt.wantChecks)
+			}
+		})
+	}
+}
+
+func TestCoordinator_OwnEvents_NotJittered(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	configMgr := newMockConfigManager()
+	configMgr.heartbeatStale = time.Millisecond
+	configMgr.heartbeatEvery = time.Hour
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+	}
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		MessageID: "msg-1", ChannelType: "text", LastState: "open", MessageText: "old",
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:     discord,
+		Config:      configMgr,
+		Store:       store,
+		Turn:        turn,
+		Org:         "testorg",
+		ClaimJitter: time.Hour,
+	})
+	var jittered atomic.Int32
+	coord.claimDelay = func(time.Duration) time.Duration {
+		jittered.Add(1)
+		return 0
+	}
+
+	// Replays and heartbeat re-checks are only seen by this replica, so they aren't jittered
+	coord.replayEvent(ctx, SprinklerEvent{URL: prURL, Type: "replay", DeliveryID: "replay-1"})
+	coord.Wait()
+	time.Sleep(5 * time.Millisecond)
+	coord.Heartbeat(ctx, time.Now())
+	coord.Wait()
+	if turn.callCount != 2 {
+		t.Fatalf("Turn calls = %d, want the replay and the heartbeat's re-check", turn.callCount)
+	}
+	if n := jittered.Load(); n != 0 {
+		t.Errorf("claim delay drawn %d times, want none", n)
+	}
+
+	// Webhook events are
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if n := jittered.Load(); n != 1 {
+		t.Errorf("claim delay drawn %d times for a webhook event, want 1", n)
+	}
+}
+
+// orderedClaimStore records the order events are claimed in.
+type orderedClaimStore struct {
+	*state.MemoryStore
+	claimed []string
+	mu      sync.Mutex
+}
+
+func (s *orderedClaimStore) ClaimEvent(ctx context.Context, eventKey string, ttl time.Duration) bool {
+	s.mu.Lock()
+	s.claimed = append(s.claimed, eventKey)
+	s.mu.Unlock()
+	return s.MemoryStore.ClaimEvent(ctx, eventKey, ttl)
+}
+
+func TestCoordinator_ProcessEvent_JitterKeepsPROrder(t *testing.T) {
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+	}
+	store := &orderedClaimStore{MemoryStore: state.NewMemoryStore()}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:     newMockDiscordClient(),
+		Config:      newMockConfigManager(),
+		Store:       store,
+		Turn:        turn,
+		Org:         "testorg",
+		ClaimJitter: time.Hour,
+	})
+	// Each event draws a shorter delay than the one before it
+	var drawn atomic.Int32
+	coord.claimDelay = func(time.Duration) time.Duration {
+		return time.Duration(3-drawn.Add(1)) * 20 * time.Millisecond
+	}
+
+	for _, id := range []string{"d-1", "d-2", "d-3"} {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: id})
+	}
+	coord.Wait()
+
+	var order []string
+	for _, key := range store.claimed {
+		order = append(order, strings.TrimPrefix(key, EventKeyPrefix(prURL)))
+	}
+	if !slices.Equal(order, []string{"d-1", "d-2", "d-3"}) {
+		t.Errorf("events claimed in order %v, want the order they arrived", order)
+	}
+}
+
+func TestCoordinator_ProcessEvent_JitterBounded(t *testing.T) {
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:     newMockDiscordClient(),
+		Config:      newMockConfigManager(),
+		Store:       state.NewMemoryStore(),
+		Turn:        newMockTurnClient(),
+		Org:         "testorg",
+		ClaimJitter: time.Hour,
+	})
+	for range cap(coord.jitterSem) {
+		coord.jitterSem <- struct{}{}
+	}
+
+	// With every waiting slot taken, the caller blocks rather than starting another goroutine
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan struct{})
+	go func() {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: "https://github.com/testorg/testrepo/pull/1", Type: "pull_request", DeliveryID: "d-1"})
+		close(returned)
+	}()
+	select {
+	case <-returned:
+		t.Fatal("ProcessEvent() returned with every waiting slot taken, want it to block")
+	case <-time.After(50 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("ProcessEvent() still blocked after its context was canceled")
+	}
+	coord.Wait()
+}
+
+func TestClaimJitterDelay(t *testing.T) {
+	if got := claimJitterDelay(0); got != 0 {
+		t.Errorf("claimJitterDelay(0) = %v, want 0", got)
+	}
+	for range 100 {
+		if got := claimJitterDelay(time.Second); got < 0 || got > time.Second {
+			t.Fatalf("claimJitterDelay(1s) = %v, want within [0, 1s]", got)
+		}
+	}
+}
+
+func TestCoordinator_WaitToClaim_Canceled(t *testing.T) {
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:     newMockDiscordClient(),
+		Config:      newMockConfigManager(),
+		Store:       state.NewMemoryStore(),
+		Turn:        newMockTurnClient(),
+		Org:         "testorg",
+		ClaimJitter: time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := coord.waitToClaim(ctx, time.Hour); err == nil {
+		t.Error("waitToClaim() error = nil, want the context's error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitToClaim() took %v, want it to return on cancellation", elapsed)
+	}
+}
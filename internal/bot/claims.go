@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"context"
+	"time"
+)
+
+// holdThreadClaim keeps a claimed thread creation from lapsing while it runs: the claim is
+// renewed every half TTL, since creating a forum thread can outlast it when old threads are
+// archived first or Discord is slow. The returned release stops renewing and frees the claim,
+// so a crash leaves it only until the TTL and a finished or failed creation doesn't hold it at all.
+func (c *Coordinator) holdThreadClaim(ctx context.Context, params *channelProcessParams, ttl time.Duration) (release func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if !c.store.RenewThreadClaim(ctx, params.owner, params.repo, params.number, params.channelID, ttl) {
+					c.logger.Warn("thread claim lapsed during creation", "pr", params.params.PRURL)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		// Freed even if ctx is canceled, so the claim doesn't outlive a shutdown
+		if err := c.store.ReleaseThreadClaim(context.WithoutCancel(ctx), params.owner, params.repo, params.number, params.channelID); err != nil {
+			c.logger.Warn("failed to release thread claim", "error", err, "pr", params.params.PRURL)
+		}
+	}
+}
+
+// releaseDMClaim frees a DM claim once the DM is sent or queued, or has failed.
+func (c *Coordinator) releaseDMClaim(ctx context.Context, discordID, prURL string) {
+	if err := c.store.ReleaseDMClaim(context.WithoutCancel(ctx), discordID, prURL); err != nil {
+		c.logger.Warn("failed to release DM claim", "error", err, "user_id", discordID, "pr_url", prURL)
+	}
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_holdThreadClaim(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	params := &channelProcessParams{
+		owner:     "testorg",
+		repo:      "testrepo",
+		number:    1,
+		channelID: "chan-testrepo",
+		params:    format.ChannelMessageParams{PRURL: "https://github.com/testorg/testrepo/pull/1"},
+	}
+
+	const ttl = 40 * time.Millisecond
+	if !store.ClaimThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", ttl) {
+		t.Fatal("ClaimThread() = false, want true")
+	}
+	release := coord.holdThreadClaim(ctx, params, ttl)
+
+	// Renewed past its TTL while held
+	time.Sleep(3 * ttl)
+	if store.ClaimThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", ttl) {
+		t.Error("ClaimThread() = true while the claim is held, want false")
+	}
+
+	// Released immediately once done
+	release()
+	if !store.ClaimThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", ttl) {
+		t.Error("ClaimThread() = false right after release, want true")
+	}
+}
+
+func TestCoordinator_ProcessEvent_ReleasesThreadClaim(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add feature", Author: "alice", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if !store.ClaimThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", time.Minute) {
+		t.Error("ClaimThread() = false after the message was created, want the claim released")
+	}
+}
@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// announceConflictResolved posts the org's conflict_resolved_note in each channel tracking a PR
+// when it goes from a merge conflict back to mergeable; forum posts get it in the PR's thread.
+// The message edit for the new state doesn't notify anyone, so with conflict_resolved set to
+// "ping" the note also mentions the PR's approvers, who may be waiting to merge it. Only the
+// transition itself announces; further events in the new state don't.
+func (c *Coordinator) announceConflictResolved(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	channels []string,
+	last state.ThreadInfo,
+	prState format.PRState,
+) {
+	mode := c.config.ConflictResolved(c.org)
+	if mode == "" || format.PRState(last.LastState) != format.StateConflict {
+		return
+	}
+	if prState == format.StateConflict || prState == format.StateMerged || prState == format.StateClosed {
+		return
+	}
+
+	prURL := FormatPRURL(owner, repo, number)
+	// Other events, here or on another instance, may have brought the same transition
+	if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"conflict-resolved:"+transitionKey(last, prState), eventDeduplicationTTL) {
+		return
+	}
+
+	var mentions string
+	if mode == "ping" {
+		mentions = c.approverMentions(ctx, checkResp)
+	}
+	text := format.ConflictResolvedMessage(owner, repo, number, prURL, c.config.ConflictResolvedNote(c.org), mentions)
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		info, ok := c.store.Thread(ctx, owner, repo, number, channelID)
+		if !ok {
+			continue
+		}
+		target := channelID
+		if info.ChannelType == "forum" && info.ThreadID != "" {
+			target = info.ThreadID
+		}
+		if _, err := c.discord.PostMessage(ctx, target, text); err != nil {
+			c.logger.Warn("failed to announce resolved conflict",
+				"channel", channelName,
+				"pr", prURL,
+				"error", err)
+			continue
+		}
+		c.counters.posts.Add(1)
+		c.logger.Info("announced resolved conflict",
+			"channel", channelName,
+			"pr", prURL,
+			"state", prState)
+	}
+}
+
+// approverMentions returns mentions of the GitHub users whose latest review of a PR approved it.
+func (c *Coordinator) approverMentions(ctx context.Context, checkResp *CheckResponse) string {
+	if c.UserMapper == nil {
+		return ""
+	}
+	var mentions []string
+	for _, reviewer := range slices.Sorted(maps.Keys(checkResp.PullRequest.Reviewers)) {
+		if checkResp.PullRequest.Reviewers[reviewer] == "approved" {
+			mentions = append(mentions, c.UserMapper.Mention(ctx, reviewer))
+		}
+	}
+	return strings.Join(mentions, " ")
+}
@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_AnnounceConflictResolved(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		wantNote    bool
+		wantMention bool
+	}{
+		{name: "off", mode: ""},
+		{name: "note", mode: "note", wantNote: true},
+		{name: "ping", mode: "ping", wantNote: true, wantMention: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			mapper := newMockUserMapper()
+			mapper.mappings["bob"] = "discord-bob"
+
+			configMgr := newMockConfigManager()
+			configMgr.conflictResolved = tt.mode
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Add feature", Author: "alice", State: "open", Reviewers: map[string]string{"bob": "approved"}},
+				Analysis:    Analysis{Approved: true, MergeConflict: true},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-conflict"})
+			coord.Wait()
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+
+			// The conflict is resolved, then another event arrives in the same state
+			turn.responses[prURL].Analysis.MergeConflict = false
+			for _, id := range []string{"d-resolved", "d-later"} {
+				coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: id})
+				coord.Wait()
+			}
+
+			var notes []string
+			for _, msg := range discord.postedMessages[1:] {
+				notes = append(notes, msg.text)
+			}
+			if !tt.wantNote {
+				if len(notes) != 0 {
+					t.Errorf("posted %q, want no note", notes)
+				}
+				return
+			}
+			if len(notes) != 1 || !strings.HasPrefix(notes[0], "✅ conflict resolved: ") {
+				t.Fatalf("posted %q, want one conflict resolved note", notes)
+			}
+			if got := strings.Contains(notes[0], "<@discord-bob>"); got != tt.wantMention {
+				t.Errorf("note = %q, mentions approver = %v, want %v", notes[0], got, tt.wantMention)
+			}
+		})
+	}
+}
+
+func TestCoordinator_announceConflictResolved_OncePerTransition(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	configMgr := newMockConfigManager()
+	configMgr.conflictResolved = "note"
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		ChannelID: "chan-testrepo", ChannelType: "text", MessageID: "msg-1", LastState: string(format.StateConflict),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	last, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+
+	// Two instances sharing the store process different events bringing the same transition
+	checkResp := &CheckResponse{PullRequest: PRInfo{Title: "Add feature", Author: "alice", State: "open"}}
+	for range 2 {
+		coord := NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    newMockTurnClient(),
+			Org:     "testorg",
+		})
+		coord.announceConflictResolved(ctx, "testorg", "testrepo", 1, checkResp, []string{"testrepo"}, last, format.StateApproved)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want one note for the transition", len(discord.postedMessages))
+	}
+
+	// A later conflict and resolution is a new transition
+	last.UpdatedAt = last.UpdatedAt.Add(time.Hour)
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	coord.announceConflictResolved(ctx, "testorg", "testrepo", 1, checkResp, []string{"testrepo"}, last, format.StateApproved)
+	if len(discord.postedMessages) != 2 {
+		t.Errorf("postedMessages = %d, want another note for the next transition", len(discord.postedMessages))
+	}
+}
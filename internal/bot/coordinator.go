@@ -2,9 +2,15 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codeGROOVE-dev/discordian/internal/dailyreport"
@@ -16,6 +22,7 @@ import (
 
 const (
 	eventDeduplicationTTL  = time.Hour
+	eventClaimTTL          = 5 * time.Minute // Covers processing; events that aren't marked processed can be redelivered after
 	maxConcurrentEvents    = 10
 	pollOpenPRHours        = 24                     // Look back 24 hours for open PRs
 	pollClosedPRHours      = 1                      // Look back 1 hour for closed PRs
@@ -23,8 +30,13 @@ const (
 	maxTagTrackerEntries   = 5000                   // Max PRs to track before cleanup
 	lockCleanupInterval    = 10 * time.Minute       // How often to clean up unused locks
 	lockIdleTimeout        = 30 * time.Minute       // Remove locks not used for this duration
+	configReloadDebounce   = 3 * time.Second        // Config pushes within this window coalesce into one reload
+	botJoinRetryDelay      = 250 * time.Millisecond // First delay before rechecking a channel the bot can't post to
 )
 
+// featureReviewerAge shows how long each action user has been waited on, from Turn's per-user timestamps.
+const featureReviewerAge = "reviewer_age"
+
 // timedLock wraps a mutex with last-access tracking for cleanup.
 type timedLock struct {
 	lastUsed time.Time
@@ -70,13 +82,47 @@ type Coordinator struct {
 	turn       TurnClient
 	UserMapper UserMapper
 	searcher   PRSearcher
+	branches   BranchResolver
+	repos      RepoStatus
+	comments   CommentReader
 	logger     *slog.Logger
 	eventSem   chan struct{}
+	jitterSem  chan struct{} // Jittered events not yet processing, bounded so bursts block the caller
 	tagTracker *tagTracker
 	prLocks    lockMap // PR URL -> mutex (serializes channel operations per PR)
 	dmLocks    lockMap // userID:prURL -> mutex (serializes DM operations per user+PR)
 	org        string
 	wg         sync.WaitGroup
+
+	turnLimiter    turnLimiter
+	turnRetries    turnRetries
+	turnRetryDelay time.Duration // How long a PR throttled by the Turn rate limit waits before its replay
+	counters       coordinatorCounters
+
+	reloadDebounce time.Duration
+	reloadPending  atomic.Bool
+	messageLimit   int        // Channel messages longer than this are split across several messages
+	reloadMu       sync.Mutex // Only one config reload runs at a time; guards configLoadedAt
+	configLoadedAt time.Time  // When this instance last loaded the org config
+
+	escalationMu sync.Mutex // Serializes read-modify-write of review escalation state
+
+	testDebounce     testStateDebouncer
+	archives         archiveQueue
+	joinRetries      joinRetries
+	recoverySearches recoverySearches
+	maintenance      maintenanceQueue
+	editGuard        editLoopGuard
+	writeCooldown    writeCooldown
+	activity         activitySummaries
+	pushBursts       pushBursts
+	heartbeat        heartbeat
+	lastEvent        lastEventTracker
+	unparseable      UnparseablePolicy
+	claimJitter      time.Duration // Spreads out replicas' claims on the same event; 0 claims at once
+	jitterOrder      jitterOrder
+	claimDelay       func(jitter time.Duration) time.Duration // Overrides claimJitterDelay in tests
+	dashboardURL     string                                   // Base of dashboard links for orgs without a template
 }
 
 // CoordinatorConfig holds configuration for creating a coordinator.
@@ -87,8 +133,15 @@ type CoordinatorConfig struct {
 	Turn       TurnClient
 	UserMapper UserMapper
 	Searcher   PRSearcher
+	Branches   BranchResolver // Optional; used to show non-default base branches
+	Comments   CommentReader  // Optional; enables /discordian comment directives
+	Repos      RepoStatus     // Optional; lets skip_archived_repos look up archived repos
 	Logger     *slog.Logger
 	Org        string
+
+	Unparseable  UnparseablePolicy // Handling of events whose URL isn't a GitHub PR URL
+	ClaimJitter  time.Duration     // Optional; random delay of up to this long before claiming each webhook event
+	DashboardURL string            // Optional; dashboard linked for orgs without a dashboard_url_template, default reviewGOOSE's
 }
 
 // NewCoordinator creates a new coordinator for an organization.
@@ -97,6 +150,10 @@ func NewCoordinator(cfg CoordinatorConfig) *Coordinator {
 	if logger == nil {
 		logger = slog.Default()
 	}
+	dashboardURL := cfg.DashboardURL
+	if dashboardURL == "" {
+		dashboardURL = format.DefaultDashboardURL
+	}
 
 	return &Coordinator{
 		org:        cfg.Org,
@@ -106,14 +163,89 @@ func NewCoordinator(cfg CoordinatorConfig) *Coordinator {
 		turn:       cfg.Turn,
 		UserMapper: cfg.UserMapper,
 		searcher:   cfg.Searcher,
+		branches:   cfg.Branches,
+		repos:      cfg.Repos,
+		comments:   cfg.Comments,
 		logger:     logger.With("org", cfg.Org),
 		eventSem:   make(chan struct{}, maxConcurrentEvents),
+		jitterSem:  make(chan struct{}, maxJitteredEvents),
 		tagTracker: newTagTracker(),
+
+		turnRetryDelay: maxTurnThrottleWait,
+		reloadDebounce: configReloadDebounce,
+		configLoadedAt: time.Now(),
+		messageLimit:   format.MessageLimit,
+		unparseable:    cfg.Unparseable,
+		claimJitter:    cfg.ClaimJitter,
+		dashboardURL:   dashboardURL,
 	}
 }
 
-// ProcessEvent handles an incoming sprinkler event.
+// EventKeyPrefix returns the prefix of every dedup key recorded for a PR's events,
+// for clearing them with the store's ClearProcessed.
+func EventKeyPrefix(prURL string) string {
+	return prURL + "#"
+}
+
+// legacyEventKey returns the dedup key an event was recorded under before keys were grouped
+// by PR. It's still checked so a deploy doesn't reprocess events already in the dedup window.
+func legacyEventKey(event SprinklerEvent) string {
+	return event.DeliveryID + ":" + event.URL
+}
+
+// ProcessEvent handles an incoming sprinkler event. Malformed events are logged and dropped.
+// Every replica gets the same webhook event, so with claim jitter set it waits out a random
+// delay before competing for the event's claim.
 func (c *Coordinator) ProcessEvent(ctx context.Context, event SprinklerEvent) {
+	c.dispatchEvent(ctx, event, c.claimJitter)
+}
+
+// replayEvent handles an event the coordinator made itself to re-render a PR, such as a held
+// back PR's replay. No other replica sees it, so it's never jittered.
+func (c *Coordinator) replayEvent(ctx context.Context, event SprinklerEvent) {
+	c.dispatchEvent(ctx, event, 0)
+}
+
+// dispatchEvent processes an event in the background, limited to maxConcurrentEvents at a
+// time. A jittered event waits before taking a slot, so waiting doesn't hold one up; up to
+// maxJitteredEvents may wait at once, beyond which the caller blocks. A PR's jittered events
+// are processed in the order they arrived, whatever delays they drew.
+func (c *Coordinator) dispatchEvent(ctx context.Context, event SprinklerEvent, jitter time.Duration) {
+	event.Normalize()
+	if err := event.Validate(); err != nil {
+		if isUnparseable(err) {
+			c.handleUnparseable(ctx, event, err)
+			return
+		}
+		c.counters.errors.Add(1)
+		c.logger.Error("dropping event",
+			"error", err,
+			"type", event.Type,
+			"delivery_id", event.DeliveryID)
+		return
+	}
+	c.noteEventSeen(ctx, time.Now())
+
+	if jitter > 0 {
+		select {
+		case c.jitterSem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		prev, done := c.jitterOrder.enqueue(event.URL)
+		c.wg.Go(func() {
+			defer done()
+			err := c.awaitJitteredTurn(ctx, jitter, prev)
+			<-c.jitterSem
+			if err != nil {
+				return // Shutting down; the event is left unclaimed for redelivery
+			}
+			defer func() { <-c.eventSem }()
+			c.handleEvent(ctx, event)
+		})
+		return
+	}
+
 	// Acquire semaphore
 	select {
 	case c.eventSem <- struct{}{}:
@@ -123,43 +255,70 @@ func (c *Coordinator) ProcessEvent(ctx context.Context, event SprinklerEvent) {
 
 	c.wg.Go(func() {
 		defer func() { <-c.eventSem }()
+		c.handleEvent(ctx, event)
+	})
+}
 
-		if err := c.processEventSync(ctx, event); err != nil {
-			c.logger.Error("failed to process event",
-				"error", err,
-				"url", event.URL,
-				"type", event.Type)
+// handleEvent processes an event, logging what goes wrong.
+func (c *Coordinator) handleEvent(ctx context.Context, event SprinklerEvent) {
+	if err := c.processEventSync(ctx, event); err != nil {
+		if isUnparseable(err) {
+			c.handleUnparseable(ctx, event, err)
+			return
 		}
-	})
+		c.counters.errors.Add(1)
+		c.logger.Error("failed to process event",
+			"error", err,
+			"url", event.URL,
+			"type", event.Type)
+	}
 }
 
 func (c *Coordinator) processEventSync(ctx context.Context, event SprinklerEvent) error {
 	// Parse PR URL
 	prInfo, ok := ParsePRURL(event.URL)
 	if !ok {
-		return fmt.Errorf("invalid PR URL: %s", event.URL)
+		return fmt.Errorf("%w: %s", ErrUnparseableURL, event.URL)
 	}
 	owner, repo, number := prInfo.Owner, prInfo.Repo, prInfo.Number
 
+	// Events under a renamed repo's old name share state with its current name
+	if canonical := c.config.CanonicalRepo(c.org, repo); canonical != repo {
+		repo = canonical
+		event.URL = FormatPRURL(owner, repo, number)
+	}
+
 	// Auto-reload config when .codeGROOVE repo is updated
 	if repo == ".codeGROOVE" {
-		c.logger.Info("config repo updated, reloading config", "org", c.org)
-		if err := c.config.ReloadConfig(ctx, c.org); err != nil {
-			c.logger.Warn("failed to reload config", "error", err)
-		}
+		c.logger.Info("config repo updated, scheduling config reload", "org", c.org)
+		c.scheduleConfigReload(ctx)
 		return nil // Don't post notifications for config repo PRs
 	}
 
-	// Check if event already processed
-	eventKey := fmt.Sprintf("%s:%s", event.DeliveryID, event.URL)
-	if c.store.WasProcessed(ctx, eventKey) {
-		c.logger.Debug("event already processed, skipping",
+	// Claim the event so a concurrent identical delivery can't also process it.
+	// Marking it processed on success extends the claim to the full dedup window.
+	eventKey := EventKeyPrefix(event.URL) + event.DeliveryID
+	if c.store.WasProcessed(ctx, legacyEventKey(event)) || !c.store.ClaimEvent(ctx, eventKey, eventClaimTTL) {
+		c.logger.Debug("event already claimed or processed, skipping",
 			"delivery_id", event.DeliveryID,
 			"event_key", eventKey,
 			"pr_url", event.URL,
 			"type", event.Type)
 		return nil
 	}
+	c.counters.events.Add(1)
+
+	// Maintenance mode holds back posts and DMs; the event still counts as processed
+	if c.inMaintenance(ctx) {
+		c.logger.Debug("maintenance mode on, skipping notifications",
+			"delivery_id", event.DeliveryID,
+			"pr_url", event.URL)
+		c.holdForMaintenance(event.URL)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
 
 	// Lock per PR URL to prevent duplicate threads/messages
 	prLock := c.prLocks.get(event.URL)
@@ -176,53 +335,172 @@ func (c *Coordinator) processEventSync(ctx context.Context, event SprinklerEvent
 		c.logger.Warn("failed to load config, using defaults", "error", err)
 	}
 
-	// Call Turn API for PR analysis
+	// PRs in an archived repo can't move, so there's nothing worth posting
+	if c.repoArchived(ctx, owner, repo) {
+		c.noteArchivedRepo(ctx, owner, repo)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	// A push's check events follow within seconds; render the burst once, after it's over
+	if c.coalescePushBurst(ctx, event) {
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	// Stale events (e.g. redelivered hours later) still update messages, but mustn't ping anyone
+	stale := c.isStaleEvent(event)
+	if stale {
+		c.logger.Info("processing stale event without notifications",
+			"delivery_id", event.DeliveryID,
+			"pr_url", event.URL,
+			"event_time", event.Timestamp.Format(time.RFC3339))
+	}
+
+	// Call Turn API for PR analysis, unless a poll's search result is enough to render a draft
 	// Use event.Timestamp (not PR's UpdatedAt) because some events like check runs
 	// don't update the PR's UpdatedAt field, but we need Turn to analyze current state
-	checkResp, err := c.turn.Check(ctx, event.URL, "", event.Timestamp)
+	checkResp, skipTurn := c.draftFromSearch(event)
+	var err error
+	if skipTurn {
+		checkResp = c.withoutSelfMentions(ctx, event.URL, checkResp)
+	} else {
+		checkResp, err = c.checkTurn(ctx, event.URL, "", event.Timestamp)
+	}
+	if errors.Is(err, errTurnThrottled) {
+		// Leave messages at their last-known state until the replay renders the PR
+		c.retryThrottled(ctx, event.URL)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
 	if err != nil {
 		c.logger.Warn("turn API call failed", "error", err)
 		// Continue with limited info
 		checkResp = &CheckResponse{}
 	}
 
-	// Determine state using same logic as slacker
-	prState := format.StateFromAnalysis(format.StateAnalysisParams{
-		Merged:             checkResp.PullRequest.Merged,
-		Closed:             checkResp.PullRequest.Closed,
-		Draft:              checkResp.PullRequest.Draft,
-		MergeConflict:      checkResp.Analysis.MergeConflict,
-		Approved:           checkResp.Analysis.Approved,
-		ChecksFailing:      checkResp.Analysis.Checks.Failing,
-		ChecksPending:      checkResp.Analysis.Checks.Pending,
-		ChecksWaiting:      checkResp.Analysis.Checks.Waiting,
-		UnresolvedComments: checkResp.Analysis.UnresolvedComments,
-		WorkflowState:      checkResp.Analysis.WorkflowState,
-	})
+	prState := prStateFromCheck(checkResp)
+
+	// Apply /discordian directives from new comments before deciding where to post
+	if isCommentEvent(event.Type) {
+		c.applyCommentDirectives(ctx, owner, repo, number, checkResp.PullRequest.Author)
+	}
+	override, _ := c.store.PROverride(ctx, FormatPRURL(owner, repo, number))
+	if override.Muted && !c.closesPostedPR(ctx, owner, repo, number, checkResp, override, prState) {
+		c.logger.Info("PR muted by comment directive, skipping notifications",
+			"pr_url", event.URL,
+			"muted_by", override.SetBy)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	// Labeled work in progress stays quiet; the event that removes the label posts it
+	if label := c.suppressLabel(checkResp); label != "" {
+		c.logger.Info("PR has a suppress label, skipping notifications",
+			"pr_url", event.URL,
+			"label", label)
+		c.cancelPendingDMsForPR(ctx, FormatPRURL(owner, repo, number))
+		c.withdrawMessages(ctx, owner, repo, number, c.routedChannels(ctx, repo, checkResp.PullRequest.Author, override))
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	// Solo maintainers merging their own PRs don't need anyone told about it, but messages
+	// already posted are kept up to date
+	quiet := c.config.SuppressSelfApproved(c.org) && selfApproved(checkResp, prState)
+	if quiet {
+		c.logger.Info("PR ready for its author to merge unreviewed, skipping notifications",
+			"pr_url", event.URL,
+			"author", checkResp.PullRequest.Author)
+		c.cancelPendingDMsForPR(ctx, FormatPRURL(owner, repo, number))
+	}
+
+	// CI reruns flip PRs between tests running and broken; show only the settled state
+	if c.deferTestState(ctx, event, prState) {
+		c.logger.Debug("deferring test state change until it settles",
+			"pr_url", event.URL,
+			"state", prState)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	// Out of office reviewers' pings and DMs go to their backups
+	checkResp = c.redirectOOO(ctx, checkResp)
 
 	// Build action users
-	actionUsers := c.buildActionUsers(ctx, checkResp)
+	actionUsers := c.unmentionHandled(ctx, event.URL, prState, c.buildActionUsers(ctx, checkResp))
 
 	// Get channels for this repo
-	channels := c.config.ChannelsForRepo(c.org, repo)
+	channels := c.routedChannels(ctx, repo, checkResp.PullRequest.Author, override)
 	if len(channels) == 0 {
 		c.logger.Warn("no channels found for repo - check that a channel named the same as the repo exists in Discord",
 			"repo", repo,
 			"org", c.org)
 		return nil
 	}
+	if quiet || override.Muted {
+		// Only messages already posted are updated, so a muted PR's close can't start a new one
+		channels = c.postedChannels(ctx, owner, repo, number, channels)
+	}
+
+	// New PRs in high churn repos wait for more events, so ones closed straight away never post
+	if c.holdNewPR(ctx, owner, repo, number, channels, prState) {
+		c.logger.Info("holding back new PR until it has more events",
+			"pr_url", event.URL,
+			"state", prState)
+		if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
+			c.logger.Warn("failed to mark event as processed", "error", err, "delivery_id", event.DeliveryID)
+		}
+		return nil
+	}
+
+	var last state.ThreadInfo
+	if c.config.DMOnReopenedReview(c.org) || c.config.ConflictResolved(c.org) != "" || c.config.DMAuthorOnReview(c.org) {
+		last = c.lastThread(ctx, owner, repo, number, channels)
+	}
+	var lastReviewers []string
+	unrequest := c.config.DMOnUnrequest(c.org)
+	if unrequest {
+		lastReviewers = c.lastRequestedReviewers(ctx, owner, repo, number, channels)
+	}
 
 	// Process each channel
 	for _, channelName := range channels {
-		if err := c.processChannel(ctx, channelName, owner, repo, number, checkResp, prState, actionUsers); err != nil {
+		if err := c.processChannel(
+			ctx, channelName, owner, repo, number, checkResp, prState, actionUsers, stale, eventTime(event),
+		); err != nil {
+			c.counters.errors.Add(1)
 			c.logger.Error("failed to process channel",
 				"channel", channelName,
 				"error", err)
 		}
 	}
 
-	// Queue DM notifications
-	c.queueDMNotifications(ctx, owner, repo, number, checkResp, prState)
+	// Queue DM notifications; the next fresh event or poll catches up on stale ones
+	if !stale && !quiet {
+		c.queueDMNotifications(ctx, owner, repo, number, checkResp, prState, eventTime(event))
+		c.notifyAuthorOfReview(ctx, owner, repo, number, checkResp, last, prState)
+		c.notifyAuthorOfReopenedReview(ctx, owner, repo, number, checkResp, last, prState)
+		c.announceConflictResolved(ctx, owner, repo, number, checkResp, channels, last, prState)
+		if unrequest {
+			c.notifyUnrequested(ctx, event, owner, repo, number, checkResp, lastReviewers, prState)
+		}
+	}
+	if unrequest {
+		c.noteRequestedReviewers(ctx, owner, repo, number, channels, requestedReviewers(checkResp))
+	}
 
 	// Mark event as processed after successful completion
 	if err := c.store.MarkProcessed(ctx, eventKey, eventDeduplicationTTL); err != nil {
@@ -232,6 +510,173 @@ func (c *Coordinator) processEventSync(ctx context.Context, event SprinklerEvent
 	return nil
 }
 
+// routedChannels returns the channels a PR's messages go to: its repo's channels and its
+// author's team channels, replaced by an active repo redirect, plus the org's firehose
+// channel. The PR's channel override replaces them all, the firehose included.
+func (c *Coordinator) routedChannels(ctx context.Context, repo, author string, override state.PROverride) []string {
+	channels := c.config.ChannelsForRepo(c.org, repo)
+	for _, channel := range c.config.ChannelsForAuthor(c.org, author) {
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	if redirect := c.redirectedChannel(ctx, repo, time.Now()); redirect != "" {
+		c.logger.Debug("repo is redirected", "repo", repo, "channel", redirect)
+		channels = []string{redirect}
+	}
+	if override.Channel != "" {
+		return []string{override.Channel}
+	}
+	if firehose := c.config.FirehoseChannel(c.org); firehose != "" && !slices.Contains(channels, firehose) {
+		channels = append(channels, firehose)
+	}
+	return channels
+}
+
+// turnUnavailable reports whether a check response is the empty one used when Turn failed,
+// so the PR's message has nothing to show but its link.
+func turnUnavailable(checkResp *CheckResponse) bool {
+	pr := checkResp.PullRequest
+	return pr.Title == "" && pr.Author == "" && pr.State == ""
+}
+
+// prStateFromCheck determines a PR's state from its Turn analysis, using the same logic as slacker.
+func prStateFromCheck(checkResp *CheckResponse) format.PRState {
+	return format.StateFromAnalysis(format.StateAnalysisParams{
+		Merged:             checkResp.PullRequest.Merged,
+		Closed:             checkResp.PullRequest.Closed,
+		Draft:              checkResp.PullRequest.Draft,
+		MergeConflict:      checkResp.Analysis.MergeConflict,
+		Approved:           checkResp.Analysis.Approved,
+		ChecksFailing:      checkResp.Analysis.Checks.Failing,
+		ChecksPending:      checkResp.Analysis.Checks.Pending,
+		ChecksWaiting:      checkResp.Analysis.Checks.Waiting,
+		UnresolvedComments: checkResp.Analysis.UnresolvedComments,
+		WorkflowState:      checkResp.Analysis.WorkflowState,
+	})
+}
+
+// suppressLabel returns the PR's first label listed in the org's suppress_labels, or "" if none.
+func (c *Coordinator) suppressLabel(checkResp *CheckResponse) string {
+	suppress := c.config.SuppressLabels(c.org)
+	for _, label := range checkResp.PullRequest.Labels {
+		if slices.ContainsFunc(suppress, func(s string) bool { return strings.EqualFold(s, label) }) {
+			return label
+		}
+	}
+	return ""
+}
+
+// withdrawMessages deletes a PR's messages that were posted before it was suppressed, so
+// they don't sit in its channels showing a state it has left. The event that lifts the
+// suppression posts the PR afresh. Forum threads hold the PR's discussion, so they're
+// archived instead, and revived when the suppression lifts.
+func (c *Coordinator) withdrawMessages(ctx context.Context, owner, repo string, number int, channels []string) {
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		info, ok := c.store.Thread(ctx, owner, repo, number, channelID)
+		if !ok || info.Withdrawn {
+			continue
+		}
+		forum := c.discord.IsForumChannel(ctx, channelID)
+		if forum && info.ThreadID != "" {
+			c.withdrawThread(ctx, channelID, channelName, owner, repo, number, info)
+			continue
+		}
+		if err := c.deleteTracked(ctx, channelID, channelName, owner, repo, number, info, forum); err != nil {
+			c.logger.Warn("failed to withdraw suppressed PR message",
+				"channel", channelName,
+				"pr", FormatPRURL(owner, repo, number),
+				"error", err)
+		}
+	}
+}
+
+// withdrawThread archives a suppressed PR's forum thread and keeps tracking it. Its recorded
+// content is cleared, so the event that lifts the suppression edits and revives it.
+func (c *Coordinator) withdrawThread(
+	ctx context.Context,
+	channelID, channelName, owner, repo string,
+	number int,
+	info state.ThreadInfo,
+) {
+	if err := c.discord.ArchiveThread(ctx, info.ThreadID); err != nil {
+		c.logger.Warn("failed to archive suppressed PR's thread",
+			"channel", channelName,
+			"pr", FormatPRURL(owner, repo, number),
+			"thread_id", info.ThreadID,
+			"error", err)
+		return
+	}
+	info.Withdrawn = true
+	info.MessageText = ""
+	if err := c.store.SaveThread(ctx, owner, repo, number, channelID, info); err != nil {
+		c.logger.Warn("failed to save withdrawn thread info", "error", err)
+	}
+	c.logger.Info("archived suppressed PR's thread",
+		"channel", channelName,
+		"pr", FormatPRURL(owner, repo, number),
+		"thread_id", info.ThreadID)
+}
+
+// selfApproved reports whether an open PR is ready to merge with nobody but its author
+// involved: the solo maintainer flow, where the author merges their own work. GitHub doesn't
+// let authors approve their own PRs, so Turn reports these as ready to merge without
+// approvals, with merging as the author's next action and no one else's.
+func selfApproved(checkResp *CheckResponse, prState format.PRState) bool {
+	author := checkResp.PullRequest.Author
+	if author == "" || !checkResp.Analysis.ReadyToMerge || prState == format.StateMerged || prState == format.StateClosed {
+		return false
+	}
+	for reviewer := range checkResp.PullRequest.Reviewers {
+		if !strings.EqualFold(reviewer, author) {
+			return false
+		}
+	}
+	for _, approver := range checkResp.PullRequest.ApprovedBy {
+		if !strings.EqualFold(approver, author) {
+			return false
+		}
+	}
+	if len(checkResp.Analysis.NextAction) == 0 {
+		return false
+	}
+	for user := range checkResp.Analysis.NextAction {
+		if !strings.EqualFold(user, author) {
+			return false
+		}
+	}
+	return true
+}
+
+// postedChannels returns the channels that already have a message for a PR.
+func (c *Coordinator) postedChannels(ctx context.Context, owner, repo string, number int, channels []string) []string {
+	var posted []string
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if _, ok := c.store.Thread(ctx, owner, repo, number, channelID); ok {
+			posted = append(posted, channelName)
+		}
+	}
+	return posted
+}
+
+// isStaleEvent reports whether a webhook event is older than the org's max_event_age.
+// Poll events are never stale: their timestamp is the PR's last update, not when we saw it.
+func (c *Coordinator) isStaleEvent(event SprinklerEvent) bool {
+	maxAge := c.config.MaxEventAge(c.org)
+	if maxAge <= 0 || event.Type == "poll" || event.Timestamp.IsZero() {
+		return false
+	}
+	return time.Since(event.Timestamp) > maxAge
+}
+
 func (c *Coordinator) buildActionUsers(ctx context.Context, checkResp *CheckResponse) []format.ActionUser {
 	var users []format.ActionUser
 
@@ -239,16 +684,18 @@ func (c *Coordinator) buildActionUsers(ctx context.Context, checkResp *CheckResp
 		"next_action_count", len(checkResp.Analysis.NextAction),
 		"next_action", checkResp.Analysis.NextAction)
 
-	for username, action := range checkResp.Analysis.NextAction {
+	// Sorted so messages list users in a stable order and don't change on every re-render
+	for _, username := range slices.Sorted(maps.Keys(checkResp.Analysis.NextAction)) {
+		action := checkResp.Analysis.NextAction[username]
 		// Skip _system pseudo-user - these are system-level actions without a human assignee
 		if username == "_system" {
 			c.logger.Debug("skipping _system action", "action", action.Kind)
 			continue
 		}
 
-		mention := username
-		if c.UserMapper != nil {
-			mention = c.UserMapper.Mention(ctx, username)
+		mention := c.mentionFor(ctx, username)
+		if mention == "" {
+			continue
 		}
 
 		actionLabel := format.ActionLabel(action.Kind)
@@ -258,10 +705,16 @@ func (c *Coordinator) buildActionUsers(ctx context.Context, checkResp *CheckResp
 			"raw_action", action.Kind,
 			"action_label", actionLabel)
 
+		var waiting time.Duration
+		if !action.Since.IsZero() && c.config.FeatureEnabled(c.org, featureReviewerAge) {
+			waiting = time.Since(action.Since)
+		}
+
 		users = append(users, format.ActionUser{
 			Username: username,
 			Mention:  mention,
 			Action:   actionLabel,
+			Waiting:  waiting,
 		})
 	}
 
@@ -269,6 +722,162 @@ func (c *Coordinator) buildActionUsers(ctx context.Context, checkResp *CheckResp
 	return users
 }
 
+// analyzing reports whether a PR's only next action is a _system one configured to show
+// as "analyzing…", such as Turn still processing it. Real actions replace the indicator.
+func (c *Coordinator) analyzing(checkResp *CheckResponse) bool {
+	action, ok := checkResp.Analysis.NextAction["_system"]
+	return ok && len(checkResp.Analysis.NextAction) == 1 && c.config.SystemIndicator(c.org, action.Kind)
+}
+
+// channelActionUsers replaces reviewers' individual mentions with the channel's team role
+// when a PR has more of them than the channel's role mention threshold. The author's own
+// actions aren't the team's, so they keep their mention and the role's actions follow them.
+func (c *Coordinator) channelActionUsers(channelName, author string, users []format.ActionUser) []format.ActionUser {
+	role, threshold := c.config.TeamRoleMention(c.org, channelName)
+	if role == "" {
+		return users
+	}
+	var authored, reviewers []format.ActionUser
+	for _, u := range users {
+		if u.Username == author {
+			authored = append(authored, u)
+		} else {
+			reviewers = append(reviewers, u)
+		}
+	}
+	if len(reviewers) <= threshold {
+		return users
+	}
+
+	// One entry per action, so each action reads "**review** → @team"
+	seen := make(map[string]bool)
+	for _, u := range reviewers {
+		if seen[u.Action] {
+			continue
+		}
+		seen[u.Action] = true
+		authored = append(authored, format.ActionUser{Mention: role, Action: u.Action})
+	}
+	return authored
+}
+
+// unmentioned returns action users named by their username, so they aren't pinged.
+func unmentioned(users []format.ActionUser) []format.ActionUser {
+	plain := make([]format.ActionUser, len(users))
+	for i, u := range users {
+		u.Mention = u.Username
+		plain[i] = u
+	}
+	return plain
+}
+
+// assigneeMentions returns mentions for the PR's assignees, or nil unless show_assignees is enabled.
+// Without ping the assignees' usernames are returned instead.
+func (c *Coordinator) assigneeMentions(ctx context.Context, checkResp *CheckResponse, ping bool) []string {
+	if !c.config.ShowAssignees(c.org) || len(checkResp.PullRequest.Assignees) == 0 {
+		return nil
+	}
+
+	mentions := make([]string, 0, len(checkResp.PullRequest.Assignees))
+	for _, username := range checkResp.PullRequest.Assignees {
+		mention := c.mentionFor(ctx, username)
+		if mention != "" && !ping {
+			mention = username
+		}
+		if mention == "" {
+			continue
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// approvals returns who has approved a PR and whose review it still waits on, each sorted.
+// Approvals come from Turn's ApprovedBy, or the reviewers' latest reviews without it.
+func approvals(checkResp *CheckResponse) (approvedBy, awaiting []string) {
+	approvedBy = slices.Clone(checkResp.PullRequest.ApprovedBy)
+	if len(approvedBy) == 0 {
+		for reviewer, review := range checkResp.PullRequest.Reviewers {
+			if review == "approved" {
+				approvedBy = append(approvedBy, reviewer)
+			}
+		}
+	}
+	slices.Sort(approvedBy)
+
+	for username, action := range checkResp.Analysis.NextAction {
+		if isReviewAction(action.Kind) && !slices.Contains(approvedBy, username) {
+			awaiting = append(awaiting, username)
+		}
+	}
+	slices.Sort(awaiting)
+	return approvedBy, awaiting
+}
+
+// failingChecks returns links to the PR's failing checks, or nil unless show_failing_checks is enabled.
+func (c *Coordinator) failingChecks(checkResp *CheckResponse) []format.CheckLink {
+	if !c.config.ShowFailingChecks(c.org) || len(checkResp.Analysis.FailingChecks) == 0 {
+		return nil
+	}
+
+	links := make([]format.CheckLink, 0, len(checkResp.Analysis.FailingChecks))
+	for _, check := range checkResp.Analysis.FailingChecks {
+		links = append(links, format.CheckLink{Name: check.Name, URL: check.URL})
+	}
+	return links
+}
+
+// ciStatus returns the PR's CI traffic light, or "" unless show_ci_status is enabled.
+func (c *Coordinator) ciStatus(checkResp *CheckResponse) string {
+	if !c.config.ShowCIStatus(c.org) {
+		return ""
+	}
+	checks := checkResp.Analysis.Checks
+	return format.CIEmoji(format.Checks{
+		Passing: checks.Passing,
+		Pending: checks.Pending,
+		Failing: checks.Failing,
+		Waiting: checks.Waiting,
+	})
+}
+
+// linkedIssues returns the issues the PR closes, or nil unless show_linked_issues is enabled.
+func (c *Coordinator) linkedIssues(checkResp *CheckResponse) []int {
+	if !c.config.ShowLinkedIssues(c.org) {
+		return nil
+	}
+	return checkResp.PullRequest.ClosesIssues
+}
+
+// nonDefaultBase returns the PR's base branch if show_non_default_base is enabled
+// and the base isn't the repo's default branch, otherwise "".
+func (c *Coordinator) nonDefaultBase(ctx context.Context, owner, repo string, checkResp *CheckResponse) string {
+	base := checkResp.PullRequest.BaseBranch
+	if base == "" || !c.config.ShowNonDefaultBase(c.org) {
+		return ""
+	}
+
+	defaultBranch := c.config.DefaultBranch(c.org)
+	if defaultBranch == "" && c.branches != nil {
+		var err error
+		defaultBranch, err = c.branches.DefaultBranch(ctx, owner, repo)
+		if err != nil {
+			c.logger.Debug("failed to resolve default branch", "repo", repo, "error", err)
+		}
+	}
+	if defaultBranch == "" {
+		// Unknown default: only the conventional names are assumed to be it
+		if base == "main" || base == "master" {
+			return ""
+		}
+		return base
+	}
+	if base == defaultBranch {
+		return ""
+	}
+	return base
+}
+
 // shouldPostThread determines if a PR thread should be posted based on configured threshold.
 // Returns (shouldPost bool, reason string).
 func (c *Coordinator) shouldPostThread(checkResult *CheckResponse, when string) (shouldPost bool, reason string) {
@@ -352,7 +961,15 @@ func (c *Coordinator) processChannel(
 	checkResp *CheckResponse,
 	prState format.PRState,
 	actionUsers []format.ActionUser,
+	stale bool,
+	eventAt time.Time,
 ) error {
+	// An unknown author (Turn failed) is let through so existing messages can still be cleaned up
+	if author := checkResp.PullRequest.Author; author != "" && !c.config.AuthorAllowed(c.org, channelName, author) {
+		c.logger.Debug("channel filters out PR author", "channel", channelName, "author", author)
+		return nil
+	}
+
 	// Resolve channel ID
 	channelID := c.discord.ResolveChannelID(ctx, channelName)
 	if channelID == channelName {
@@ -362,27 +979,77 @@ func (c *Coordinator) processChannel(
 	}
 
 	// Check if bot can send to channel
-	if !c.discord.IsBotInChannel(ctx, channelID) {
+	if !c.botInChannel(ctx, channelID, channelName, FormatPRURL(owner, repo, number)) {
 		c.logger.Debug("bot not in channel", "channel", channelName)
 		return nil
 	}
 
+	// Check for existing thread/message
+	threadInfo, exists := c.store.Thread(ctx, owner, repo, number, channelID)
+
+	// Edits for stale events are sent silently, but new posts have no silent flag,
+	// so a stale event's new post names people without mentioning them
+	mentions := !stale || exists
+	channelUsers := unmentioned(actionUsers)
+	if mentions {
+		channelUsers = c.channelActionUsers(channelName, checkResp.PullRequest.Author, actionUsers)
+	}
+
 	// Build message params
 	prURL := FormatPRURL(owner, repo, number)
 	params := format.ChannelMessageParams{
 		Owner:       owner,
 		Repo:        repo,
 		Number:      number,
-		Title:       checkResp.PullRequest.Title,
+		Title:       format.NormalizeTitle(checkResp.PullRequest.Title, c.config.TitleStyle(c.org, channelName)),
 		Author:      checkResp.PullRequest.Author,
 		State:       prState,
-		ActionUsers: actionUsers,
+		ActionUsers: channelUsers,
+		Assignees:   c.assigneeMentions(ctx, checkResp, mentions),
+		Failing:     c.failingChecks(checkResp),
+		CI:          c.ciStatus(checkResp),
+		Closes:      c.linkedIssues(checkResp),
 		PRURL:       prURL,
 		ChannelName: channelName,
+		Prefix:      c.config.MessagePrefix(c.org, channelName),
+		RepoEmoji:   c.config.RepoEmoji(c.org, repo),
+		BaseBranch:  c.nonDefaultBase(ctx, owner, repo, checkResp),
+		ShowOrg:     c.config.ShowOrg(c.org, channelName) || channelName == c.config.FirehoseChannel(c.org),
+		PlainURL:    c.config.PlainURL(c.org, channelName),
+		Analyzing:   c.analyzing(checkResp),
+		Narrow:      c.config.Layout(c.org, channelName) == "narrow",
+	}
+	params.ActionUsers = c.coalesceSoloReviewer(ctx, channelID, checkResp, params.ActionUsers)
+	if override, ok := c.store.PROverride(ctx, prURL); ok {
+		params.AcknowledgedBy = acknowledgedBy(override, prState)
+	}
+	if c.config.ShowApprovals(c.org) {
+		params.ApprovedBy, params.AwaitingApproval = approvals(checkResp)
+	}
+	if c.config.DashboardFooter(c.org, channelName) {
+		if tmpl := c.config.DashboardURLTemplate(c.org); tmpl != "" {
+			params.DashboardURL = format.DashboardTemplateURL(tmpl, owner, repo, number)
+		} else {
+			// The dashboard has no per-PR page; its org page lists the PR
+			params.DashboardURL = format.DashboardOrgURL(c.dashboardURL, owner)
+		}
+	}
+	if turnUnavailable(checkResp) {
+		params.Unavailable = c.config.TurnUnavailableMessage(c.org)
 	}
 
-	// Check for existing thread/message
-	threadInfo, exists := c.store.Thread(ctx, owner, repo, number, channelID)
+	// Review rounds are only counted while they're shown or the author is DMed on verdicts
+	notes := threadNotes{
+		reviewRounds: threadInfo.ReviewRounds,
+		lastReview:   threadInfo.LastReview,
+		draft:        checkResp.PullRequest.Draft,
+	}
+	if showRounds := c.config.ShowReviewRounds(c.org); showRounds || c.config.DMAuthorOnReview(c.org) {
+		notes.reviewRounds, notes.lastReview = reviewRound(threadInfo, prState)
+		if showRounds {
+			params.ReviewRound = notes.reviewRounds
+		}
+	}
 
 	// Skip channel message if we don't have basic PR info (Turn API failed)
 	// However, if message already exists in Discord, we should try to update it when Turn API recovers
@@ -405,21 +1072,47 @@ func (c *Coordinator) processChannel(
 			"has_author", params.Author != "")
 	}
 
-	// Auto-detect forum channels from Discord API
-	if c.discord.IsForumChannel(ctx, channelID) {
-		return c.processForumChannel(ctx, &channelProcessParams{
-			channelID:  channelID,
-			owner:      owner,
-			repo:       repo,
-			number:     number,
-			params:     params,
-			checkResp:  checkResp,
-			threadInfo: threadInfo,
-			exists:     exists,
-		})
+	// Auto-detect forum channels from Discord API
+	forum := c.discord.IsForumChannel(ctx, channelID)
+	if !c.hasChannelPermissions(ctx, channelID, channelName, forum) {
+		return nil
+	}
+
+	if len(c.config.Escalation(c.org, channelName)) > 0 {
+		c.trackEscalation(ctx, channelID, prURL, prState, actionUsers)
+	}
+
+	if c.suppressState(ctx, channelID, channelName, owner, repo, number, threadInfo, exists, prState, notes.draft) {
+		return nil
+	}
+
+	if c.config.DigestInterval(c.org, channelName) > 0 {
+		if !forum {
+			return c.recordDigestChange(ctx, channelID, params)
+		}
+		c.logger.Debug("digest_interval only applies to text channels, posting live", "channel", channelName)
+	}
+
+	if c.config.Board(c.org, channelName) {
+		if !forum {
+			return c.recordBoardChange(ctx, channelID, params)
+		}
+		c.logger.Debug("board only applies to text channels, posting live", "channel", channelName)
+	}
+
+	if c.deferToPostWindow(ctx, channelName, prURL, prState) {
+		return nil
+	}
+
+	if (prState == format.StateMerged || prState == format.StateClosed) && c.config.DeleteOnClose(c.org, channelName) {
+		if !exists {
+			// Nothing was posted, and a closed PR isn't worth a new message
+			return nil
+		}
+		return c.deleteTracked(ctx, channelID, channelName, owner, repo, number, threadInfo, forum)
 	}
 
-	return c.processTextChannel(ctx, &channelProcessParams{
+	channelParams := &channelProcessParams{
 		channelID:  channelID,
 		owner:      owner,
 		repo:       repo,
@@ -428,7 +1121,79 @@ func (c *Coordinator) processChannel(
 		checkResp:  checkResp,
 		threadInfo: threadInfo,
 		exists:     exists,
-	})
+		stale:      stale,
+		readied:    c.markedReady(channelName, prURL, threadInfo, exists, checkResp),
+		eventAt:    eventAt,
+		notes:      notes,
+	}
+	c.repostReopened(ctx, channelParams)
+	c.noteThread(channelParams, prState)
+	var err error
+	if forum {
+		err = c.processForumChannel(ctx, channelParams)
+	} else {
+		err = c.processTextChannel(ctx, channelParams)
+	}
+	return err
+}
+
+// hasChannelPermissions verifies the bot has what this channel type needs to post,
+// logging exactly which permissions are missing so admins know what to grant.
+func (c *Coordinator) hasChannelPermissions(ctx context.Context, channelID, channelName string, forum bool) bool {
+	perms, err := c.discord.BotChannelPermissions(ctx, channelID)
+	if err != nil {
+		// IsBotInChannel already passed, so try posting anyway
+		c.logger.Debug("failed to check channel permissions, proceeding",
+			"channel", channelName,
+			"error", err)
+		return true
+	}
+
+	missing := perms.Missing(forum)
+	if len(missing) == 0 {
+		return true
+	}
+
+	c.logger.Warn("bot is missing channel permissions, skipping",
+		"channel", channelName,
+		"channel_id", channelID,
+		"forum", forum,
+		"missing", missing)
+	return false
+}
+
+// mentionPattern matches Discord user and role mentions.
+var mentionPattern = regexp.MustCompile(`<@[!&]?\d+>`)
+
+// silentEdit reports whether an edit from oldText to newText should suppress notifications.
+// Edits that add a mention are never silent so newly added reviewers still get pinged.
+func (c *Coordinator) silentEdit(oldText, newText string) bool {
+	if !c.config.SilentEdits(c.org) {
+		return false
+	}
+	for _, mention := range mentionPattern.FindAllString(newText, -1) {
+		if !strings.Contains(oldText, mention) {
+			return false
+		}
+	}
+	return true
+}
+
+// silentChannelEdit is silentEdit for channel processing, where stale events and acknowledged
+// PRs are always silent, and a draft just marked ready for review always pings. If the org
+// lists notify_on_transitions, only those transitions from the PR's stored state re-ping.
+func (c *Coordinator) silentChannelEdit(params *channelProcessParams, oldText, newText string) bool {
+	if params.stale || params.params.AcknowledgedBy != "" {
+		return true
+	}
+	if params.readied {
+		return false
+	}
+	from := params.threadInfo.LastState
+	if transitions := c.config.NotifyOnTransitions(c.org); len(transitions) > 0 && from != "" {
+		return !slices.Contains(transitions, from+"->"+string(params.params.State))
+	}
+	return c.silentEdit(oldText, newText)
 }
 
 type channelProcessParams struct {
@@ -440,10 +1205,87 @@ type channelProcessParams struct {
 	params     format.ChannelMessageParams
 	number     int
 	exists     bool
+	stale      bool      // Edits must not ping anyone
+	recreate   bool      // The PR's old message was forgotten; post a new one without searching for it
+	readied    bool      // The PR was a draft when last processed; edits ping its reviewers
+	eventAt    time.Time // When the event being processed happened; zero for polls
+	notes      threadNotes
+}
+
+// recoverStarterMessageID fetches the starter message ID of a forum thread whose ID couldn't be
+// fetched when it was created, so its content can be edited along with its title. It's saved
+// with the update; on failure only the title is updated, and the next update tries again.
+func (c *Coordinator) recoverStarterMessageID(ctx context.Context, params *channelProcessParams) {
+	messageID, err := c.discord.ForumStarterMessageID(ctx, params.threadInfo.ThreadID)
+	if err != nil {
+		c.logger.Warn("failed to recover forum thread's starter message ID",
+			"error", err,
+			"thread_id", params.threadInfo.ThreadID,
+			"pr", params.params.PRURL)
+		return
+	}
+	params.threadInfo.MessageID = messageID
+	c.logger.Info("recovered forum thread's starter message ID",
+		"thread_id", params.threadInfo.ThreadID,
+		"message_id", messageID,
+		"pr", params.params.PRURL)
+}
+
+// deleteTracked removes a PR's message, or its thread in a forum channel, and forgets it.
+// A thread the bot can't delete is archived instead, so it at least leaves the active list.
+func (c *Coordinator) deleteTracked(
+	ctx context.Context,
+	channelID, channelName, owner, repo string,
+	number int,
+	threadInfo state.ThreadInfo,
+	forum bool,
+) error {
+	var err error
+	if forum && threadInfo.ThreadID != "" {
+		if err = c.discord.DeleteThread(ctx, threadInfo.ThreadID); err != nil {
+			c.logger.Warn("failed to delete PR thread, archiving instead",
+				"channel", channelName,
+				"thread_id", threadInfo.ThreadID,
+				"error", err)
+			err = c.discord.ArchiveThread(ctx, threadInfo.ThreadID)
+		}
+	} else {
+		for _, id := range messageChunkIDs(threadInfo) {
+			if err = c.discord.DeleteMessage(ctx, channelID, id); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete PR message: %w", err)
+	}
+
+	c.logger.Info("deleted PR message",
+		"channel", channelName,
+		"pr", FormatPRURL(owner, repo, number),
+		"thread_id", threadInfo.ThreadID,
+		"message_id", threadInfo.MessageID)
+	return c.store.RemoveThread(ctx, owner, repo, number, channelID)
+}
+
+// reopened reports whether a tracked forum thread's PR went from closed or merged back to open,
+// and its thread should be revived.
+func (c *Coordinator) reopened(params *channelProcessParams) bool {
+	last := format.PRState(params.threadInfo.LastState)
+	wasClosed := last == format.StateMerged || last == format.StateClosed
+	isClosed := params.params.State == format.StateMerged || params.params.State == format.StateClosed
+	return wasClosed && !isClosed && c.config.ReviveReopenedThreads(c.org)
 }
 
 func (c *Coordinator) processForumChannel(ctx context.Context, params *channelProcessParams) error {
 	title := format.ForumThreadTitle(params.params.Repo, params.params.Number, params.params.Title)
+	if c.config.PrefixForumTitles(c.org, params.params.ChannelName) {
+		title = format.PrefixedForumThreadTitle(params.params.Prefix, params.params.Repo, params.params.Number, params.params.Title)
+	}
+	if c.config.ShowBodySnippet(c.org, params.params.ChannelName) {
+		params.params.BodySnippet = format.BodySnippet(params.checkResp.PullRequest.Body)
+	}
+	params.params.ReviewChecklist = c.config.ReviewChecklist(c.org, params.params.ChannelName, params.params.Repo)
 	content := format.ChannelMessage(params.params)
 
 	if params.exists && params.threadInfo.ThreadID != "" {
@@ -455,28 +1297,59 @@ func (c *Coordinator) processForumChannel(ctx context.Context, params *channelPr
 			c.trackTaggedUsers(params.params)
 			return nil
 		}
+		if c.deferWrite(ctx, params.channelID, params.params.PRURL) {
+			c.trackTaggedUsers(params.params)
+			return nil
+		}
+
+		// A reopened PR's thread was archived when it closed, and a suppressed PR's when it
+		// was withdrawn; archived threads can't be edited
+		if c.reopened(params) || params.threadInfo.Withdrawn {
+			if err := c.discord.UnarchiveThread(ctx, params.threadInfo.ThreadID); err != nil {
+				c.logger.Warn("failed to unarchive PR's thread", "error", err)
+			} else {
+				c.logger.Info("unarchived PR's thread",
+					"thread_id", params.threadInfo.ThreadID,
+					"pr", params.params.PRURL,
+					"withdrawn", params.threadInfo.Withdrawn)
+			}
+		}
+
+		if !c.editAllowed(params.threadInfo.ThreadID, params.threadInfo.MessageID, params.params.PRURL) {
+			c.trackTaggedUsers(params.params)
+			return nil
+		}
+
+		if params.threadInfo.MessageID == "" {
+			c.recoverStarterMessageID(ctx, params)
+		}
 
 		// Update existing thread
-		err := c.discord.UpdateForumPost(ctx, params.threadInfo.ThreadID, params.threadInfo.MessageID, title, content)
+		silent := c.silentChannelEdit(params, params.threadInfo.MessageText, content)
+		err := c.discord.UpdateForumPost(ctx, params.threadInfo.ThreadID, params.threadInfo.MessageID, title, content, silent)
 		if err == nil {
+			c.counters.edits.Add(1)
+			c.noteWrite(params.channelID, params.params.PRURL)
+			c.observePostLatency(params.eventAt)
 			// Update state
 			params.threadInfo.MessageText = content
 			params.threadInfo.LastState = string(params.params.State)
+			params.threadInfo.Withdrawn = false
 			if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, params.threadInfo); err != nil {
 				c.logger.Warn("failed to save thread info", "error", err)
 			}
 
 			// Archive if merged/closed
 			if params.params.State == format.StateMerged || params.params.State == format.StateClosed {
-				if err := c.discord.ArchiveThread(ctx, params.threadInfo.ThreadID); err != nil {
-					c.logger.Warn("failed to archive thread", "error", err)
-				}
+				c.archiveFinishedThread(ctx, params)
 			}
 
 			c.trackTaggedUsers(params.params)
 			return nil
 		}
-		c.logger.Warn("failed to update forum post, will search/create", "error", err)
+		if !c.recreateVanished(ctx, params, err) {
+			c.logger.Warn("failed to update forum post, will search/create", "error", err)
+		}
 	}
 
 	// Thread doesn't exist - check if we should create it based on "when" threshold
@@ -502,7 +1375,11 @@ func (c *Coordinator) processForumChannel(ctx context.Context, params *channelPr
 
 	// Try to claim this thread creation
 	const claimTTL = 10 * time.Second
-	if !c.store.ClaimThread(ctx, params.owner, params.repo, params.number, params.channelID, claimTTL) {
+	if c.store.ClaimThread(ctx, params.owner, params.repo, params.number, params.channelID, claimTTL) {
+		// Renewed while creating, and freed as soon as creation is done or has failed
+		release := c.holdThreadClaim(ctx, params, claimTTL)
+		defer release()
+	} else {
 		// Another instance claimed it, search for their thread
 		c.logger.Info("another instance claimed forum thread, searching for their thread",
 			"pr", params.params.PRURL)
@@ -511,27 +1388,29 @@ func (c *Coordinator) processForumChannel(ctx context.Context, params *channelPr
 		time.Sleep(crossInstanceRaceDelay * 2)
 
 		// Search for existing thread created by the other instance
-		if foundThreadID, foundMsgID, found := c.discord.FindForumThread(ctx, params.channelID, params.params.PRURL); found {
+		if foundThreadID, foundMsgID, found := c.findForumThread(ctx, params); found {
 			c.logger.Info("found forum thread created by another instance",
 				"thread_id", foundThreadID,
 				"pr", params.params.PRURL)
 
 			// Save the found thread and update it
-			newInfo := state.ThreadInfo{
+			newInfo := params.withNotes(state.ThreadInfo{
 				ThreadID:    foundThreadID,
 				MessageID:   foundMsgID,
 				ChannelID:   params.channelID,
 				ChannelType: "forum",
 				LastState:   string(params.params.State),
 				MessageText: content,
-			}
+			})
 			if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
 				c.logger.Warn("failed to save found thread info", "error", err)
 			}
 
 			// Update the found thread with current content
-			if err := c.discord.UpdateForumPost(ctx, foundThreadID, foundMsgID, title, content); err != nil {
+			if err := c.discord.UpdateForumPost(ctx, foundThreadID, foundMsgID, title, content, c.silentChannelEdit(params, "", content)); err != nil {
 				c.logger.Warn("failed to update found forum post", "error", err)
+			} else {
+				c.counters.edits.Add(1)
 			}
 
 			c.trackTaggedUsers(params.params)
@@ -546,48 +1425,55 @@ func (c *Coordinator) processForumChannel(ctx context.Context, params *channelPr
 	time.Sleep(crossInstanceRaceDelay)
 
 	// Search for existing thread (in case claim race occurred)
-	if foundThreadID, foundMsgID, found := c.discord.FindForumThread(ctx, params.channelID, params.params.PRURL); found {
+	if foundThreadID, foundMsgID, found := c.findForumThread(ctx, params); found {
 		c.logger.Info("found existing forum thread from search",
 			"thread_id", foundThreadID,
 			"pr", params.params.PRURL)
 
 		// Save the found thread and update it
-		newInfo := state.ThreadInfo{
+		newInfo := params.withNotes(state.ThreadInfo{
 			ThreadID:    foundThreadID,
 			MessageID:   foundMsgID,
 			ChannelID:   params.channelID,
 			ChannelType: "forum",
 			LastState:   string(params.params.State),
 			MessageText: content,
-		}
+		})
 		if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
 			c.logger.Warn("failed to save found thread info", "error", err)
 		}
 
 		// Update the found thread with current content
-		if err := c.discord.UpdateForumPost(ctx, foundThreadID, foundMsgID, title, content); err != nil {
+		if err := c.discord.UpdateForumPost(ctx, foundThreadID, foundMsgID, title, content, c.silentChannelEdit(params, "", content)); err != nil {
 			c.logger.Warn("failed to update found forum post", "error", err)
+		} else {
+			c.counters.edits.Add(1)
 		}
 
 		c.trackTaggedUsers(params.params)
 		return nil
 	}
 
+	c.makeRoomInForum(ctx, params.channelID, params.params.ChannelName)
+
 	// Create new forum thread
 	threadID, messageID, err := c.discord.PostForumThread(ctx, params.channelID, title, content)
 	if err != nil {
 		return fmt.Errorf("create forum thread: %w", err)
 	}
+	c.counters.posts.Add(1)
+	c.noteWrite(params.channelID, params.params.PRURL)
+	c.observePostLatency(params.eventAt)
 
 	// Save thread info
-	newInfo := state.ThreadInfo{
+	newInfo := params.withNotes(state.ThreadInfo{
 		ThreadID:    threadID,
 		MessageID:   messageID,
 		ChannelID:   params.channelID,
 		ChannelType: "forum",
 		LastState:   string(params.params.State),
 		MessageText: content,
-	}
+	})
 	if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
 		c.logger.Warn("failed to save thread info", "error", err)
 	}
@@ -597,7 +1483,18 @@ func (c *Coordinator) processForumChannel(ctx context.Context, params *channelPr
 }
 
 func (c *Coordinator) processTextChannel(ctx context.Context, params *channelProcessParams) error {
-	content := format.ChannelMessage(params.params)
+	if c.config.DetailThread(c.org, params.params.ChannelName) {
+		return c.processDetailThread(ctx, params)
+	}
+	if c.config.AppendMode(c.org, params.params.ChannelName) {
+		return c.processAppendMessage(ctx, params, format.ChannelMessage(params.params))
+	}
+	return c.processTextMessage(ctx, params, format.ChannelMessage(params.params))
+}
+
+// processTextMessage posts or updates a PR's text channel message with content.
+func (c *Coordinator) processTextMessage(ctx context.Context, params *channelProcessParams, content string) error {
+	chunks := format.SplitMessage(content, c.messageLimit)
 
 	if params.exists && params.threadInfo.MessageID != "" {
 		c.logger.Info("found thread in cache",
@@ -615,9 +1512,26 @@ func (c *Coordinator) processTextChannel(ctx context.Context, params *channelPro
 			return nil
 		}
 
+		if c.deferWrite(ctx, params.channelID, params.params.PRURL) {
+			c.trackTaggedUsers(params.params)
+			return nil
+		}
+		if !c.editAllowed(params.channelID, params.threadInfo.MessageID, params.params.PRURL) {
+			c.trackTaggedUsers(params.params)
+			return nil
+		}
+
 		// Update existing message
-		err := c.discord.UpdateMessage(ctx, params.channelID, params.threadInfo.MessageID, content)
+		silent := c.silentChannelEdit(params, params.threadInfo.MessageText, content)
+		ids, err := c.updateChunks(ctx, params.channelID, messageChunkIDs(params.threadInfo), chunks, silent)
 		if err == nil {
+			c.counters.edits.Add(1)
+			c.noteWrite(params.channelID, params.params.PRURL)
+			c.observePostLatency(params.eventAt)
+			params.threadInfo.MessageIDs = nil
+			if len(ids) > 1 {
+				params.threadInfo.MessageIDs = ids
+			}
 			params.threadInfo.MessageText = content
 			params.threadInfo.LastState = string(params.params.State)
 			if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, params.threadInfo); err != nil {
@@ -627,13 +1541,26 @@ func (c *Coordinator) processTextChannel(ctx context.Context, params *channelPro
 			c.trackTaggedUsers(params.params)
 			return nil
 		}
-		c.logger.Warn("failed to update message, will search/create", "error", err)
-	} else {
+		if !c.recreateVanished(ctx, params, err) {
+			c.logger.Warn("failed to update message, will search/create", "error", err)
+		}
+	} else if !params.recreate && c.recoverySearches.first(params.channelID, params.params.PRURL) {
 		c.logger.Info("thread not found in cache, will search channel history",
 			"channel_id", params.channelID,
 			"pr", params.params.PRURL,
 			"exists", params.exists,
 			"has_message_id", params.exists && params.threadInfo.MessageID != "")
+
+		// Recover a message the store lost track of (e.g. after a state reset) before the
+		// "when" threshold can hide it, so it keeps being updated instead of duplicated.
+		// Only a PR's first event searches; a miss isn't searched for again.
+		if foundMsgID, found := c.discord.FindChannelMessage(ctx, params.channelID, params.params.PRURL); found {
+			c.logger.Info("recovered existing channel message",
+				"message_id", foundMsgID,
+				"pr", params.params.PRURL)
+			c.adoptChannelMessage(ctx, params, foundMsgID, content)
+			return nil
+		}
 	}
 
 	// Message doesn't exist - check if we should create it based on "when" threshold
@@ -659,7 +1586,11 @@ func (c *Coordinator) processTextChannel(ctx context.Context, params *channelPro
 
 	// Try to claim this thread creation
 	const claimTTL = 10 * time.Second
-	if !c.store.ClaimThread(ctx, params.owner, params.repo, params.number, params.channelID, claimTTL) {
+	if c.store.ClaimThread(ctx, params.owner, params.repo, params.number, params.channelID, claimTTL) {
+		// Renewed while creating, and freed as soon as creation is done or has failed
+		release := c.holdThreadClaim(ctx, params, claimTTL)
+		defer release()
+	} else {
 		// Another instance claimed it, search for their message
 		c.logger.Info("another instance claimed thread, searching for their message",
 			"pr", params.params.PRURL)
@@ -668,34 +1599,11 @@ func (c *Coordinator) processTextChannel(ctx context.Context, params *channelPro
 		time.Sleep(crossInstanceRaceDelay * 2)
 
 		// Search for existing message created by the other instance
-		if foundMsgID, found := c.discord.FindChannelMessage(ctx, params.channelID, params.params.PRURL); found {
+		if foundMsgID, found := c.findChannelMessage(ctx, params); found {
 			c.logger.Info("found message created by another instance",
 				"message_id", foundMsgID,
 				"pr", params.params.PRURL)
-
-			// Check if content needs updating
-			currentContent, err := c.discord.MessageContent(ctx, params.channelID, foundMsgID)
-			if err == nil && currentContent == content {
-				c.logger.Info("found message content unchanged, skipping update",
-					"message_id", foundMsgID,
-					"pr", params.params.PRURL)
-			} else if err := c.discord.UpdateMessage(ctx, params.channelID, foundMsgID, content); err != nil {
-				c.logger.Warn("failed to update found message", "error", err)
-			}
-
-			// Save thread info to cache
-			newInfo := state.ThreadInfo{
-				MessageID:   foundMsgID,
-				ChannelID:   params.channelID,
-				ChannelType: "text",
-				LastState:   string(params.params.State),
-				MessageText: content,
-			}
-			if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
-				c.logger.Warn("failed to save found message info", "error", err)
-			}
-
-			c.trackTaggedUsers(params.params)
+			c.adoptChannelMessage(ctx, params, foundMsgID, content)
 			return nil
 		}
 
@@ -707,59 +1615,93 @@ func (c *Coordinator) processTextChannel(ctx context.Context, params *channelPro
 	time.Sleep(crossInstanceRaceDelay)
 
 	// Search for existing message (in case claim race occurred)
-	if foundMsgID, found := c.discord.FindChannelMessage(ctx, params.channelID, params.params.PRURL); found {
+	if foundMsgID, found := c.findChannelMessage(ctx, params); found {
 		c.logger.Info("found existing channel message from search",
 			"message_id", foundMsgID,
 			"pr", params.params.PRURL)
-
-		// Check if content actually changed before updating
-		currentContent, err := c.discord.MessageContent(ctx, params.channelID, foundMsgID)
-		if err == nil && currentContent == content {
-			c.logger.Info("found message content unchanged, skipping update",
-				"message_id", foundMsgID,
-				"pr", params.params.PRURL)
-		} else if err := c.discord.UpdateMessage(ctx, params.channelID, foundMsgID, content); err != nil {
-			c.logger.Warn("failed to update found message", "error", err)
-		}
-
-		// Save thread info to cache
-		newInfo := state.ThreadInfo{
-			MessageID:   foundMsgID,
-			ChannelID:   params.channelID,
-			ChannelType: "text",
-			LastState:   string(params.params.State),
-			MessageText: content,
-		}
-		if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
-			c.logger.Warn("failed to save found message info", "error", err)
-		}
-
-		c.trackTaggedUsers(params.params)
+		c.adoptChannelMessage(ctx, params, foundMsgID, content)
 		return nil
 	}
 
 	// Create new message
-	messageID, err := c.discord.PostMessage(ctx, params.channelID, content)
+	messageID, err := c.discord.PostMessage(ctx, params.channelID, chunks[0])
 	if err != nil {
 		return fmt.Errorf("post message: %w", err)
 	}
+	c.counters.posts.Add(1)
+	c.noteWrite(params.channelID, params.params.PRURL)
+	c.observePostLatency(params.eventAt)
+	c.crosspostIfAnnouncement(ctx, params, messageID)
 
 	// Save message info
-	newInfo := state.ThreadInfo{
+	newInfo := params.withNotes(state.ThreadInfo{
 		MessageID:   messageID,
 		ChannelID:   params.channelID,
 		ChannelType: "text",
 		LastState:   string(params.params.State),
 		MessageText: content,
-	}
+	})
 	if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
 		c.logger.Warn("failed to save thread info", "error", err)
 	}
+	c.postChunks(ctx, params, messageID, chunks)
 
 	c.trackTaggedUsers(params.params)
 	return nil
 }
 
+// adoptChannelMessage takes over an existing channel message for a PR, found by searching
+// the channel: it's edited to the current content if needed and saved as the PR's message.
+func (c *Coordinator) adoptChannelMessage(ctx context.Context, params *channelProcessParams, messageID, content string) {
+	currentContent, err := c.discord.MessageContent(ctx, params.channelID, messageID)
+	if err == nil && currentContent == content {
+		c.logger.Info("found message content unchanged, skipping update",
+			"message_id", messageID,
+			"pr", params.params.PRURL)
+	} else if !c.editAllowed(params.channelID, messageID, params.params.PRURL) {
+		content = currentContent
+	} else if err := c.discord.UpdateMessage(
+		ctx, params.channelID, messageID, content, c.silentChannelEdit(params, currentContent, content),
+	); err != nil {
+		c.logger.Warn("failed to update found message", "error", err)
+	} else {
+		c.counters.edits.Add(1)
+	}
+
+	newInfo := params.withNotes(state.ThreadInfo{
+		MessageID:   messageID,
+		ChannelID:   params.channelID,
+		ChannelType: "text",
+		LastState:   string(params.params.State),
+		MessageText: content,
+	})
+	if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, newInfo); err != nil {
+		c.logger.Warn("failed to save found message info", "error", err)
+	}
+
+	c.trackTaggedUsers(params.params)
+}
+
+// crosspostIfAnnouncement publishes a new post to following servers when the
+// channel is an announcement channel with crossposting enabled. Later edits
+// propagate to followers on their own, so only new posts are crossposted.
+func (c *Coordinator) crosspostIfAnnouncement(ctx context.Context, params *channelProcessParams, messageID string) {
+	if !c.config.Crosspost(params.owner, params.params.ChannelName) {
+		return
+	}
+	if !c.discord.IsNewsChannel(ctx, params.channelID) {
+		c.logger.Debug("crosspost enabled but channel is not an announcement channel",
+			"channel", params.params.ChannelName)
+		return
+	}
+	if err := c.discord.CrosspostMessage(ctx, params.channelID, messageID); err != nil {
+		c.logger.Warn("failed to crosspost message",
+			"error", err,
+			"channel", params.params.ChannelName,
+			"message_id", messageID)
+	}
+}
+
 func (c *Coordinator) trackTaggedUsers(params format.ChannelMessageParams) {
 	prURL := params.PRURL
 	for _, au := range params.ActionUsers {
@@ -776,12 +1718,17 @@ func (c *Coordinator) queueDMNotifications(
 	number int,
 	checkResp *CheckResponse,
 	prState format.PRState,
+	eventAt time.Time,
 ) {
 	prURL := FormatPRURL(owner, repo, number)
+	c.clearHandled(ctx, prURL, prState)
 
 	// For merged/closed PRs, update ALL previous DM recipients
 	if prState == format.StateMerged || prState == format.StateClosed {
 		c.updateAllDMsForClosedPR(ctx, owner, repo, number, checkResp, prState, prURL)
+		if prState == format.StateClosed && c.config.DMOnClosedUnmerged(c.org) {
+			c.notifyAuthorClosedUnmerged(ctx, owner, repo, number, checkResp, prURL)
+		}
 		return
 	}
 
@@ -796,6 +1743,7 @@ func (c *Coordinator) queueDMNotifications(
 			prURL:      prURL,
 			username:   username,
 			actionKind: action.Kind,
+			eventAt:    eventAt,
 		})
 	}
 }
@@ -809,6 +1757,7 @@ type dmProcessParams struct {
 	username   string
 	actionKind string
 	number     int
+	eventAt    time.Time // When the event that queued the DM happened; zero for polls
 }
 
 // discordIDForUser returns the Discord ID for a GitHub username.
@@ -821,9 +1770,30 @@ func (c *Coordinator) discordIDForUser(ctx context.Context, username string) str
 		c.logger.Debug("skipping DM - no Discord mapping",
 			"github_user", username)
 	}
+	if c.isBotUser(ctx, discordID) {
+		c.logger.Warn("skipping DM - GitHub user is mapped to the bot itself",
+			"github_user", username)
+		return ""
+	}
 	return discordID
 }
 
+// dmMessage formats a DM for an action, using the org's custom template for
+// that action kind if one is configured.
+func (c *Coordinator) dmMessage(p format.ChannelMessageParams, actionKind string) string {
+	label := format.ActionLabel(actionKind)
+	if tmpl := c.config.DMTemplate(c.org, actionKind); tmpl != "" {
+		msg, err := format.DMMessageFromTemplate(tmpl, p, label)
+		if err == nil {
+			return msg
+		}
+		c.logger.Warn("invalid DM template, using default format",
+			"action", actionKind,
+			"error", err)
+	}
+	return format.DMMessage(p, label)
+}
+
 // shouldSkipDM returns true if the DM should be skipped for this user.
 func (c *Coordinator) shouldSkipDM(ctx context.Context, discordID, username string) bool {
 	if !c.discord.IsUserInGuild(ctx, discordID) {
@@ -854,6 +1824,20 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 		return
 	}
 
+	info, ok := c.store.DMInfo(ctx, discordID, params.prURL)
+	if ok && info.Muted {
+		c.logger.Debug("skipping DM - muted by user",
+			"github_user", params.username,
+			"pr_url", params.prURL)
+		return
+	}
+	if ok && info.HandledState == string(params.prState) {
+		c.logger.Debug("skipping DM - marked handled by user",
+			"github_user", params.username,
+			"pr_url", params.prURL)
+		return
+	}
+
 	// Build DM message
 	msgParams := format.ChannelMessageParams{
 		Owner:  params.owner,
@@ -864,7 +1848,7 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 		State:  params.prState,
 		PRURL:  params.prURL,
 	}
-	newMessage := format.DMMessage(msgParams, format.ActionLabel(params.actionKind))
+	newMessage := c.dmMessage(msgParams, params.actionKind)
 
 	// Check for existing queued DMs for this user+PR
 	pendingDMs, err := c.store.PendingDMs(ctx, time.Now().Add(24*time.Hour))
@@ -924,6 +1908,15 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 		return
 	}
 
+	// DMs sent as part of a digest have no message of their own to edit,
+	// but an unchanged notification still needn't be sent again
+	if dmExists && dmInfo.MessageID == "" && dmInfo.MessageText == newMessage {
+		c.logger.Info("DM skipped - already sent in digest",
+			"user", params.username,
+			"pr_url", params.prURL)
+		return
+	}
+
 	// If we have an existing DM, update it immediately
 	if dmExists && dmInfo.ChannelID != "" && dmInfo.MessageID != "" {
 		// Content comparison: skip if message unchanged
@@ -940,7 +1933,7 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 			dmInfo.MessageText = newMessage
 			dmInfo.LastState = string(params.prState)
 			dmInfo.SentAt = time.Now()
-			if err := c.store.SaveDMInfo(ctx, discordID, params.prURL, dmInfo); err != nil {
+			if err := c.saveDMInfo(ctx, discordID, params.prURL, dmInfo); err != nil {
 				c.logger.Warn("failed to save updated DM info", "error", err)
 			}
 			c.logger.Info("updated DM notification",
@@ -959,7 +1952,10 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 
 	// New DM - try to claim it to prevent duplicate DMs from multiple instances
 	const dmClaimTTL = 10 * time.Second
-	if !c.store.ClaimDM(ctx, discordID, params.prURL, dmClaimTTL) {
+	if c.store.ClaimDM(ctx, discordID, params.prURL, dmClaimTTL) {
+		// Freed once the DM is queued or has failed; queued DMs are found before claiming
+		defer c.releaseDMClaim(ctx, discordID, params.prURL)
+	} else {
 		// Another instance claimed this DM, search for it
 		c.logger.Debug("another instance claimed DM, searching for their message",
 			"user", params.username,
@@ -975,13 +1971,8 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 				"pr_url", params.prURL)
 
 			// Save the found DM info
-			dmInfo = state.DMInfo{
-				ChannelID:   foundChannelID,
-				MessageID:   foundMsgID,
-				MessageText: newMessage,
-				LastState:   string(params.prState),
-				SentAt:      time.Now(),
-			}
+			stored, _ := c.store.DMInfo(ctx, discordID, params.prURL)
+			dmInfo = state.NewDMInfo(stored, foundChannelID, foundMsgID, newMessage, string(params.prState))
 			if err := c.store.SaveDMInfo(ctx, discordID, params.prURL, dmInfo); err != nil {
 				c.logger.Warn("failed to save found DM info", "error", err)
 			}
@@ -1006,13 +1997,7 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 			"pr_url", params.prURL)
 	}
 
-	// Check delay configuration
-	channels := c.config.ChannelsForRepo(c.org, params.repo)
-	delay := 65 // default
-	if len(channels) > 0 {
-		delay = c.config.ReminderDMDelay(c.org, channels[0])
-	}
-
+	delay := c.reminderDelay(params.repo)
 	if delay == 0 {
 		c.logger.Debug("skipping DM - notifications disabled",
 			"github_user", params.username,
@@ -1020,11 +2005,16 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 		return
 	}
 
-	// Calculate send time
-	sendAt := time.Now()
+	// Calculate send time: never earlier than the minimum grace period,
+	// and later still if the user was tagged in channel.
+	sendAt := time.Now().Add(time.Duration(c.config.MinDMDelay(c.org)) * time.Minute)
 	if c.tagTracker.wasTagged(params.prURL, params.username) {
-		// User was tagged in channel, delay DM
-		sendAt = sendAt.Add(time.Duration(delay) * time.Minute)
+		if taggedAt := time.Now().Add(time.Duration(delay) * time.Minute); taggedAt.After(sendAt) {
+			sendAt = taggedAt
+		}
+	}
+	if dmInfo.SnoozedUntil.After(sendAt) {
+		sendAt = dmInfo.SnoozedUntil
 	}
 
 	// Queue the DM
@@ -1039,6 +2029,8 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 		ExpiresAt:   now.Add(7 * 24 * time.Hour), // Expire after 7 days
 		GuildID:     c.discord.GuildID(),
 		Org:         c.org,
+		ActionKind:  params.actionKind,
+		EventAt:     params.eventAt,
 		RetryCount:  0,
 	}
 
@@ -1048,12 +2040,29 @@ func (c *Coordinator) processDMForUser(ctx context.Context, params dmProcessPara
 			"user", params.username)
 		return
 	}
+	c.counters.dmsQueued.Add(1)
 	c.logger.Debug("queued DM notification",
 		"user", params.username,
 		"discord_id", discordID,
 		"send_at", sendAt)
 }
 
+// reminderDelay returns the DM delay in minutes for a repo. When the repo posts
+// to several channels, the longest delay wins so a user tagged in all of them
+// still gets a single DM, after they've had the most time to see the posts.
+// Returns 0 (DMs disabled) only if every channel disables them.
+func (c *Coordinator) reminderDelay(repo string) int {
+	channels := c.config.ChannelsForRepo(c.org, repo)
+	if len(channels) == 0 {
+		return 65 // default
+	}
+	delay := 0
+	for _, channel := range channels {
+		delay = max(delay, c.config.ReminderDMDelay(c.org, channel))
+	}
+	return delay
+}
+
 // updateAllDMsForClosedPR updates DMs for all users who received notifications about this PR.
 // This ensures users see the final merged/closed state.
 func (c *Coordinator) updateAllDMsForClosedPR(
@@ -1090,7 +2099,8 @@ func (c *Coordinator) updateAllDMsForClosedPR(
 		State:  prState,
 		PRURL:  prURL,
 	}
-	finalMessage := format.DMMessage(params, "") // No action for closed PRs
+	// No action for closed PRs; closed-unmerged gets a label so it isn't mistaken for a merge
+	finalMessage := format.DMMessage(params, format.StateText(prState))
 
 	// Update each user's DM
 	for _, discordID := range userIDs {
@@ -1101,6 +2111,76 @@ func (c *Coordinator) updateAllDMsForClosedPR(
 	c.cancelPendingDMsForPR(ctx, prURL)
 }
 
+// notifyAuthorClosedUnmerged DMs the author that their PR was closed without merging.
+// Authors who already have a DM for this PR get it updated by updateAllDMsForClosedPR instead.
+func (c *Coordinator) notifyAuthorClosedUnmerged(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	prURL string,
+) {
+	author := checkResp.PullRequest.Author
+	if author == "" {
+		return
+	}
+	discordID := c.discordIDForUser(ctx, author)
+	if discordID == "" {
+		return
+	}
+
+	lock := c.dmLocks.get(discordID + ":" + prURL)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, exists := c.store.DMInfo(ctx, discordID, prURL); exists {
+		return
+	}
+	if c.shouldSkipDM(ctx, discordID, author) {
+		return
+	}
+
+	const dmClaimTTL = 10 * time.Second
+	if !c.store.ClaimDM(ctx, discordID, prURL, dmClaimTTL) {
+		c.logger.Debug("another instance claimed closed-unmerged DM",
+			"user", author,
+			"pr_url", prURL)
+		return
+	}
+	defer c.releaseDMClaim(ctx, discordID, prURL)
+
+	params := format.ChannelMessageParams{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Title:  checkResp.PullRequest.Title,
+		Author: author,
+		State:  format.StateClosed,
+		PRURL:  prURL,
+	}
+	msg := format.DMMessage(params, format.StateText(format.StateClosed))
+
+	channelID, messageID, err := c.sendDM(ctx, discordID, msg)
+	if err != nil {
+		c.logger.Warn("failed to DM author about closed PR",
+			"error", err,
+			"user", author,
+			"pr_url", prURL)
+		return
+	}
+
+	stored, _ := c.store.DMInfo(ctx, discordID, prURL)
+	if err := c.store.SaveDMInfo(ctx, discordID, prURL, state.NewDMInfo(
+		stored, channelID, messageID, msg, string(format.StateClosed),
+	)); err != nil {
+		c.logger.Warn("failed to save DM info", "error", err)
+	}
+	c.logger.Info("notified author of closed-unmerged PR",
+		"user", author,
+		"discord_id", discordID,
+		"pr_url", prURL)
+}
+
 // updateDMForClosedPR updates a single user's DM for a closed PR.
 func (c *Coordinator) updateDMForClosedPR(ctx context.Context, discordID, prURL string, prState format.PRState, msg string) {
 	lock := c.dmLocks.get(discordID + ":" + prURL)
@@ -1128,7 +2208,7 @@ func (c *Coordinator) updateDMForClosedPR(ctx context.Context, discordID, prURL
 	dmInfo.MessageText = msg
 	dmInfo.LastState = string(prState)
 	dmInfo.SentAt = time.Now()
-	if err := c.store.SaveDMInfo(ctx, discordID, prURL, dmInfo); err != nil {
+	if err := c.saveDMInfo(ctx, discordID, prURL, dmInfo); err != nil {
 		c.logger.Warn("failed to save DM info", "error", err)
 	}
 	c.logger.Debug("updated DM for closed PR",
@@ -1156,7 +2236,7 @@ func (c *Coordinator) cancelPendingDMsForPR(ctx context.Context, prURL string) {
 				"error", err)
 			continue
 		}
-		c.logger.Debug("cancelled pending DM for closed PR",
+		c.logger.Debug("cancelled pending DM",
 			"dm_id", dm.ID,
 			"user_id", dm.UserID,
 			"pr_url", prURL)
@@ -1232,9 +2312,12 @@ func (t *tagTracker) wasTagged(prURL, username string) bool {
 	return t.tagged[prURL][username]
 }
 
-// PollAndReconcile queries GitHub for PRs and reconciles their state.
-// This serves as a backup mechanism when sprinkler events are missed.
+// PollAndReconcile queries GitHub for PRs and reconciles their state, and picks back up the
+// forum thread archives it finds due in the store. This serves as a backup mechanism when
+// sprinkler events are missed.
 func (c *Coordinator) PollAndReconcile(ctx context.Context) {
+	c.requeueArchives(ctx)
+
 	if c.searcher == nil {
 		c.logger.Debug("skipping poll - no PR searcher configured")
 		return
@@ -1288,6 +2371,7 @@ func (c *Coordinator) reconcilePR(ctx context.Context, pr PRSearchResult) {
 		URL:        pr.URL,
 		Timestamp:  pr.UpdatedAt,
 		DeliveryID: fmt.Sprintf("poll-%s-%s", pr.URL, pr.UpdatedAt.Format(time.RFC3339)),
+		searched:   &pr,
 	}
 
 	// Process the event (reuses all the normal event processing logic)
@@ -1305,9 +2389,14 @@ func (c *Coordinator) checkDailyReports(ctx context.Context, prs []PRSearchResul
 		c.logger.Debug("skipping daily reports - no PRs found")
 		return
 	}
+	if c.inMaintenance(ctx) {
+		c.logger.Debug("skipping daily reports - maintenance mode on")
+		return
+	}
 
 	// Create daily report sender
 	sender := dailyreport.NewSender(c.store, c.logger)
+	sender.SetPersistent(c.config.PersistentReport(c.org))
 
 	// Load config to check if daily reports are disabled
 	cfg, exists := c.config.Config(c.org)
@@ -1334,7 +2423,7 @@ func (c *Coordinator) checkDailyReports(ctx context.Context, prs []PRSearchResul
 		}
 
 		// Call Turn API to get next actions for this PR
-		checkResp, err := c.turn.Check(ctx, pr.URL, "", pr.UpdatedAt)
+		checkResp, err := c.checkTurn(ctx, pr.URL, "", pr.UpdatedAt)
 		if err != nil {
 			c.logger.Debug("failed to check PR for daily report",
 				"pr_url", pr.URL,
@@ -1378,7 +2467,7 @@ func (c *Coordinator) checkUserDailyReport(
 	if c.UserMapper != nil {
 		discordID = c.UserMapper.DiscordID(ctx, githubUsername)
 	}
-	if discordID == "" {
+	if discordID == "" || c.isBotUser(ctx, discordID) {
 		c.logger.Debug("skipping daily report - no Discord mapping",
 			"github_user", githubUsername)
 		return
@@ -1403,7 +2492,7 @@ func (c *Coordinator) checkUserDailyReport(
 		}
 
 		// Call Turn API to analyze this PR for this user
-		checkResp, err := c.turn.Check(ctx, pr.URL, githubUsername, pr.UpdatedAt)
+		checkResp, err := c.checkTurn(ctx, pr.URL, githubUsername, pr.UpdatedAt)
 		if err != nil {
 			c.logger.Debug("failed to check PR for user report",
 				"pr_url", pr.URL,
@@ -1412,19 +2501,7 @@ func (c *Coordinator) checkUserDailyReport(
 			continue
 		}
 
-		// Determine PR state
-		prState := format.StateFromAnalysis(format.StateAnalysisParams{
-			Merged:             checkResp.PullRequest.Merged,
-			Closed:             checkResp.PullRequest.Closed,
-			Draft:              checkResp.PullRequest.Draft,
-			MergeConflict:      checkResp.Analysis.MergeConflict,
-			Approved:           checkResp.Analysis.Approved,
-			ChecksFailing:      checkResp.Analysis.Checks.Failing,
-			ChecksPending:      checkResp.Analysis.Checks.Pending,
-			ChecksWaiting:      checkResp.Analysis.Checks.Waiting,
-			UnresolvedComments: checkResp.Analysis.UnresolvedComments,
-			WorkflowState:      checkResp.Analysis.WorkflowState,
-		})
+		prState := prStateFromCheck(checkResp)
 
 		// Check if user has an action on this PR
 		action, hasAction := checkResp.Analysis.NextAction[githubUsername]
@@ -2,11 +2,17 @@ package bot
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
 	"github.com/codeGROOVE-dev/discordian/internal/format"
 	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
@@ -15,22 +21,48 @@ import (
 
 type mockDiscordClient struct {
 	postedMessages     []postedMessage
+	postIDs            []string // IDs PostMessage returns in turn; then "msg-" + channelID
 	updatedMessages    []updatedMessage
+	updatedForumPosts  []updatedMessage
 	forumThreads       []forumThread
 	sentDMs            []sentDM
+	dmBlocked          map[string]bool // userID -> doesn't accept DMs from the bot
+	onSendDM           func(userID string)
 	updatedDMs         []updatedDM
 	channelIDs         map[string]string
 	forumChannels      map[string]bool
+	newsChannels       map[string]bool
+	crossposted        []string // message IDs
+	pinned             []string // "channelID/messageID"
 	usersInGuild       map[string]bool
 	activeUsers        map[string]bool
 	botInChannel       map[string]bool
-	channelMessages    map[string]map[string]string // channelID -> messageID -> content
-	existingDMs        map[string]existingDM        // userID:prURL -> DM info
+	botJoinPending     map[string]int                 // channelID -> IsBotInChannel calls that fail before botInChannel applies
+	findMessageCalls   int                            // FindChannelMessage calls, i.e. channel history scans
+	channelPerms       map[string]discord.Permissions // channelID -> perms (default: all)
+	channelMessages    map[string]map[string]string   // channelID -> messageID -> content
+	existingDMs        map[string]existingDM          // userID:prURL -> DM info
 	archivedThreads    []string
+	unarchivedThreads  []string
+	deletedMessages    []string // "channelID/messageID"
+	deletedThreads     []string
+	activeThreads      map[string][]string    // forumID -> active thread IDs
 	foundForumThreads  map[string]foundThread // channelID:prURL -> thread info
 	guildID            string
 	shouldFailUpdate   bool
 	shouldFailUpdateDM bool
+	updateErr          error             // Returned by UpdateMessage and UpdateForumPost when set
+	starterMessageIDs  map[string]string // threadID -> starter message ID; missing IDs fail to fetch
+	noStarterID        bool              // PostForumThread returns no message ID, as when its fetch fails
+	startedThreads     []startedThread
+	botUserID          string
+	channelViewers     map[string][]string // channelID -> people who can see it
+}
+
+type startedThread struct {
+	channelID string
+	messageID string
+	name      string
 }
 
 type existingDM struct {
@@ -52,6 +84,7 @@ type updatedMessage struct {
 	channelID string
 	messageID string
 	text      string
+	silent    bool
 }
 
 type forumThread struct {
@@ -75,6 +108,7 @@ func newMockDiscordClient() *mockDiscordClient {
 	return &mockDiscordClient{
 		channelIDs:        make(map[string]string),
 		forumChannels:     make(map[string]bool),
+		newsChannels:      make(map[string]bool),
 		usersInGuild:      make(map[string]bool),
 		activeUsers:       make(map[string]bool),
 		botInChannel:      make(map[string]bool),
@@ -88,24 +122,66 @@ func newMockDiscordClient() *mockDiscordClient {
 
 func (m *mockDiscordClient) PostMessage(_ context.Context, channelID, text string) (string, error) {
 	m.postedMessages = append(m.postedMessages, postedMessage{channelID, text})
+	if len(m.postIDs) > 0 {
+		id := m.postIDs[0]
+		m.postIDs = m.postIDs[1:]
+		return id, nil
+	}
 	return "msg-" + channelID, nil
 }
 
-func (m *mockDiscordClient) UpdateMessage(_ context.Context, channelID, messageID, text string) error {
-	m.updatedMessages = append(m.updatedMessages, updatedMessage{channelID, messageID, text})
+func (m *mockDiscordClient) UpdateMessage(_ context.Context, channelID, messageID, text string, silent bool) error {
+	m.updatedMessages = append(m.updatedMessages, updatedMessage{channelID, messageID, text, silent})
 	if m.shouldFailUpdate {
 		return fmt.Errorf("mock update failed")
 	}
+	return m.updateErr
+}
+
+func (m *mockDiscordClient) CrosspostMessage(_ context.Context, _, messageID string) error {
+	m.crossposted = append(m.crossposted, messageID)
+	return nil
+}
+
+func (m *mockDiscordClient) PinMessage(_ context.Context, channelID, messageID string) error {
+	m.pinned = append(m.pinned, channelID+"/"+messageID)
 	return nil
 }
 
 func (m *mockDiscordClient) PostForumThread(_ context.Context, forumID, title, content string) (threadID, messageID string, err error) {
 	m.forumThreads = append(m.forumThreads, forumThread{forumID, title, content})
+	if m.noStarterID {
+		return "thread-" + forumID, "", nil
+	}
 	return "thread-" + forumID, "msg-" + forumID, nil
 }
 
-func (m *mockDiscordClient) UpdateForumPost(_ context.Context, _, _, _, _ string) error {
-	return nil
+func (m *mockDiscordClient) UpdateForumPost(_ context.Context, threadID, messageID, _, content string, silent bool) error {
+	m.updatedForumPosts = append(m.updatedForumPosts, updatedMessage{threadID, messageID, content, silent})
+	return m.updateErr
+}
+
+func (m *mockDiscordClient) BotInfo(_ context.Context) (discord.BotInfo, error) {
+	if m.botUserID == "" {
+		return discord.BotInfo{}, errors.New("bot user not available")
+	}
+	return discord.BotInfo{UserID: m.botUserID, Username: "goose"}, nil
+}
+
+func (m *mockDiscordClient) StartMessageThread(_ context.Context, channelID, messageID, name string) (string, error) {
+	m.startedThreads = append(m.startedThreads, startedThread{channelID: channelID, messageID: messageID, name: name})
+	return "thread-" + messageID, nil
+}
+
+func (m *mockDiscordClient) ForumStarterMessageID(_ context.Context, threadID string) (string, error) {
+	if id, ok := m.starterMessageIDs[threadID]; ok {
+		return id, nil
+	}
+	return "", errors.New("starter message not found")
+}
+
+func (m *mockDiscordClient) ActiveForumThreads(_ context.Context, forumID string) ([]string, error) {
+	return m.activeThreads[forumID], nil
 }
 
 func (m *mockDiscordClient) ArchiveThread(_ context.Context, threadID string) error {
@@ -113,7 +189,28 @@ func (m *mockDiscordClient) ArchiveThread(_ context.Context, threadID string) er
 	return nil
 }
 
+func (m *mockDiscordClient) UnarchiveThread(_ context.Context, threadID string) error {
+	m.unarchivedThreads = append(m.unarchivedThreads, threadID)
+	return nil
+}
+
+func (m *mockDiscordClient) DeleteThread(_ context.Context, threadID string) error {
+	m.deletedThreads = append(m.deletedThreads, threadID)
+	return nil
+}
+
+func (m *mockDiscordClient) DeleteMessage(_ context.Context, channelID, messageID string) error {
+	m.deletedMessages = append(m.deletedMessages, channelID+"/"+messageID)
+	return nil
+}
+
 func (m *mockDiscordClient) SendDM(_ context.Context, userID, text string) (channelID, messageID string, err error) {
+	if m.dmBlocked[userID] {
+		return "", "", fmt.Errorf("failed to send DM: %w", discord.ErrDMBlocked)
+	}
+	if m.onSendDM != nil {
+		m.onSendDM(userID)
+	}
 	m.sentDMs = append(m.sentDMs, sentDM{userID, text})
 	return "dm-chan-" + userID, "dm-msg-" + userID, nil
 }
@@ -137,7 +234,26 @@ func (m *mockDiscordClient) LookupUserByUsername(_ context.Context, _ string) st
 	return ""
 }
 
+func (m *mockDiscordClient) BotChannelPermissions(_ context.Context, channelID string) (discord.Permissions, error) {
+	if perms, ok := m.channelPerms[channelID]; ok {
+		return perms, nil
+	}
+	return discord.Permissions{
+		ViewChannel:           true,
+		SendMessages:          true,
+		SendMessagesInThreads: true,
+		CreatePublicThreads:   true,
+		EmbedLinks:            true,
+		ReadMessageHistory:    true,
+		ManageThreads:         true,
+	}, nil
+}
+
 func (m *mockDiscordClient) IsBotInChannel(_ context.Context, channelID string) bool {
+	if m.botJoinPending[channelID] > 0 {
+		m.botJoinPending[channelID]--
+		return false
+	}
 	return m.botInChannel[channelID]
 }
 
@@ -149,10 +265,19 @@ func (m *mockDiscordClient) IsUserActive(_ context.Context, userID string) bool
 	return m.activeUsers[userID]
 }
 
+func (m *mockDiscordClient) ChannelViewers(_ context.Context, channelID string, limit int) []string {
+	viewers := m.channelViewers[channelID]
+	return viewers[:min(limit, len(viewers))]
+}
+
 func (m *mockDiscordClient) IsForumChannel(_ context.Context, channelID string) bool {
 	return m.forumChannels[channelID]
 }
 
+func (m *mockDiscordClient) IsNewsChannel(_ context.Context, channelID string) bool {
+	return m.newsChannels[channelID]
+}
+
 func (m *mockDiscordClient) GuildID() string {
 	return m.guildID
 }
@@ -166,6 +291,7 @@ func (m *mockDiscordClient) FindForumThread(_ context.Context, channelID, prURL
 }
 
 func (m *mockDiscordClient) FindChannelMessage(_ context.Context, channelID, prURL string) (string, bool) {
+	m.findMessageCalls++
 	if messages, ok := m.channelMessages[channelID]; ok {
 		for msgID := range messages {
 			// Return first message in channel (for testing purposes)
@@ -195,10 +321,91 @@ func (m *mockDiscordClient) FindDMForPR(_ context.Context, userID, prURL string)
 type mockConfigManager struct {
 	configs          map[string]*config.DiscordConfig
 	channels         map[string][]string // org:repo -> channels
+	authorChannels   map[string][]string // org:author -> author team channels
 	whenSettings     map[string]string   // org:channel -> when value
 	reloadCount      int
+	minDMDelay       int
+	dmTemplates      map[string]string // action kind -> template
+	loudEdits        bool              // SilentEdits disabled
+	transitions      []string          // NotifyOnTransitions
+	suppressLabels   []string
+	suppressSelf     bool
+	dmClosedUnmerged bool
+	dmOnReview       bool
+	dmOnReopened     bool
+	maintenanceQueue bool
+	showAssignees    bool
+	showChecks       bool
+	showCIStatus     bool
+	showLinkedIssues bool
+	location         *time.Location
+	showBase         bool
+	defaultBranch    string
+	turnPerMinute    int
+	skipDraftTurn    bool
+	turnBurst        int
+	maxEventAge      time.Duration
+	testDebounce     time.Duration
+	pushBurst        time.Duration
+	writeCooldown    time.Duration
+	joinGrace        time.Duration
+	features         config.Features
+	titleStyle       string
+	keepArchived     bool              // ReviveReopenedThreads disabled
+	crosspost        map[string]bool   // org:channel -> crosspost
+	prefixes         map[string]string // org:channel -> message prefix
+	repoEmoji        map[string]string // repo -> emoji
+	showOrg          bool
+	prefixTitles     bool
+	digestIntervals  map[string]time.Duration        // channel -> digest interval
+	boards           map[string]bool                 // channel -> board mode
+	boardMaxPRs      map[string]int                  // channel -> board cap
+	deleteOnClose    map[string]bool                 // channel -> delete_on_close
+	authorFilters    map[string]config.ChannelConfig // channel -> only_authors/ignore_authors
+	postWindows      map[string]config.PostWindow    // channel -> post window
+	adminChannel     string
+	activitySummary  bool
+	repoAliases      map[string]string // old repo name -> current name
+	maxForumThreads  int
+	archiveDelays    map[string]time.Duration           // channel -> archive delay
+	escalation       map[string][]config.EscalationStep // channel -> escalation ladder
+	teamRole         string
+	roleThreshold    int
 	shouldFailReload bool
 	shouldFailLoad   bool
+	reloaded         *config.DiscordConfig // Config swapped in by ReloadConfig
+	oooBackups       map[string]string     // GitHub user -> backup while out of office
+	loadErr          error
+	firehose         string
+	plainURL         bool
+	dmOnUnrequest    bool
+	minEvents        int
+	dmFallback       string
+	dmEscalation     time.Duration
+	systemIndicators []string
+	dashboardFooter  bool
+	reopenRepost     time.Duration
+	heartbeatStale   time.Duration
+	heartbeatEvery   time.Duration
+	conflictResolved string
+	dashboardTmpl    string
+	bodySnippet      bool
+	archivedRepos    []string
+	skipArchived     bool
+	archivedNote     string
+	detailThread     bool
+	reminderEvery    time.Duration
+	reminderMax      int
+	selfMentions     string
+	appendMode       bool
+	showApprovals    bool
+	suppressStates   []string
+	soloReviewer     string
+	reviewChecklist  []string
+	reviewRounds     bool
+	backfillWindow   time.Duration
+	layout           string
+	persistentReport bool
 }
 
 func newMockConfigManager() *mockConfigManager {
@@ -216,14 +423,25 @@ func (m *mockConfigManager) LoadConfig(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockConfigManager) ReloadConfig(_ context.Context, _ string) error {
+func (m *mockConfigManager) ReloadConfig(_ context.Context, org string) error {
 	m.reloadCount++
 	if m.shouldFailReload {
 		return fmt.Errorf("mock reload failed")
 	}
+	if m.reloaded != nil {
+		m.configs[org] = m.reloaded
+	}
 	return nil
 }
 
+func (m *mockConfigManager) LoadError(_ string) error {
+	return m.loadErr
+}
+
+func (m *mockConfigManager) OOOBackup(_, githubUsername string) string {
+	return m.oooBackups[githubUsername]
+}
+
 func (m *mockConfigManager) Config(org string) (*config.DiscordConfig, bool) {
 	cfg, ok := m.configs[org]
 	return cfg, ok
@@ -237,6 +455,10 @@ func (m *mockConfigManager) ChannelsForRepo(org, repo string) []string {
 	return []string{repo} // Default: use repo name as channel
 }
 
+func (m *mockConfigManager) ChannelsForAuthor(org, author string) []string {
+	return m.authorChannels[org+":"+author]
+}
+
 func (m *mockConfigManager) ChannelType(_, _ string) string {
 	return "text"
 }
@@ -249,6 +471,341 @@ func (m *mockConfigManager) ReminderDMDelay(_, _ string) int {
 	return 65
 }
 
+func (m *mockConfigManager) MinDMDelay(_ string) int {
+	return m.minDMDelay
+}
+
+func (m *mockConfigManager) ReviveReopenedThreads(_ string) bool {
+	return !m.keepArchived
+}
+
+func (m *mockConfigManager) SilentEdits(_ string) bool {
+	return !m.loudEdits
+}
+
+func (m *mockConfigManager) NotifyOnTransitions(_ string) []string {
+	return m.transitions
+}
+
+func (m *mockConfigManager) SuppressLabels(_ string) []string {
+	return m.suppressLabels
+}
+
+func (m *mockConfigManager) SystemIndicator(_, kind string) bool {
+	return slices.Contains(m.systemIndicators, kind)
+}
+
+func (m *mockConfigManager) ArchivedRepo(_, repo string) bool {
+	return slices.Contains(m.archivedRepos, repo)
+}
+
+func (m *mockConfigManager) SkipArchivedRepos(_ string) bool {
+	return m.skipArchived
+}
+
+func (m *mockConfigManager) ArchivedRepoNote(_ string) string {
+	return m.archivedNote
+}
+
+func (m *mockConfigManager) SuppressSelfApproved(_ string) bool {
+	return m.suppressSelf
+}
+
+func (m *mockConfigManager) DMOnClosedUnmerged(_ string) bool {
+	return m.dmClosedUnmerged
+}
+
+func (m *mockConfigManager) DMAuthorOnReview(_ string) bool {
+	return m.dmOnReview
+}
+
+func (m *mockConfigManager) DMOnReopenedReview(_ string) bool {
+	return m.dmOnReopened
+}
+
+func (m *mockConfigManager) DMOnUnrequest(_ string) bool {
+	return m.dmOnUnrequest
+}
+
+func (m *mockConfigManager) DMEscalationAfter(_ string) time.Duration {
+	return m.dmEscalation
+}
+
+func (m *mockConfigManager) ReopenRepostAfter(_ string) time.Duration {
+	return m.reopenRepost
+}
+
+func (m *mockConfigManager) QueueDuringMaintenance(_ string) bool {
+	return m.maintenanceQueue
+}
+
+func (m *mockConfigManager) SoloReviewer(_ string) string {
+	if m.soloReviewer == "" {
+		return "both"
+	}
+	return m.soloReviewer
+}
+
+func (m *mockConfigManager) SelfMentions(_ string) string {
+	if m.selfMentions == "" {
+		return "escape"
+	}
+	return m.selfMentions
+}
+
+func (*mockConfigManager) MemberLeave(_ string) string {
+	return "flag"
+}
+
+func (m *mockConfigManager) TurnRateLimit(_ string) (perMinute, burst int) {
+	return m.turnPerMinute, m.turnBurst
+}
+
+func (m *mockConfigManager) SkipTurnForDrafts(_ string) bool {
+	return m.skipDraftTurn
+}
+
+func (m *mockConfigManager) ShowNonDefaultBase(_ string) bool {
+	return m.showBase
+}
+
+func (m *mockConfigManager) DefaultBranch(_ string) string {
+	return m.defaultBranch
+}
+
+func (m *mockConfigManager) ShowAssignees(_ string) bool {
+	return m.showAssignees
+}
+
+func (m *mockConfigManager) ShowApprovals(_ string) bool {
+	return m.showApprovals
+}
+
+func (m *mockConfigManager) ShowReviewRounds(_ string) bool {
+	return m.reviewRounds
+}
+
+func (m *mockConfigManager) ShowFailingChecks(_ string) bool {
+	return m.showChecks
+}
+
+func (m *mockConfigManager) ShowCIStatus(_ string) bool {
+	return m.showCIStatus
+}
+
+func (m *mockConfigManager) ShowLinkedIssues(_ string) bool {
+	return m.showLinkedIssues
+}
+
+func (m *mockConfigManager) Location(_ string) *time.Location {
+	if m.location == nil {
+		return time.UTC
+	}
+	return m.location
+}
+
+func (m *mockConfigManager) BackfillWindow(_ string) time.Duration {
+	return m.backfillWindow
+}
+
+func (m *mockConfigManager) MaxEventAge(_ string) time.Duration {
+	return m.maxEventAge
+}
+
+func (m *mockConfigManager) FeatureEnabled(_, name string) bool {
+	return m.features.Enabled(name)
+}
+
+func (m *mockConfigManager) BotJoinGrace(_ string) time.Duration {
+	return m.joinGrace
+}
+
+func (m *mockConfigManager) TestStateDebounce(_ string) time.Duration {
+	return m.testDebounce
+}
+
+func (m *mockConfigManager) PushBurstWindow(_ string) time.Duration {
+	return m.pushBurst
+}
+
+func (m *mockConfigManager) MinEventsToPost(_ string) int {
+	return max(m.minEvents, 1)
+}
+
+func (m *mockConfigManager) WriteCooldown(_ string) time.Duration {
+	return m.writeCooldown
+}
+
+func (m *mockConfigManager) MappingConflictPolicy(_ string) string {
+	return "config"
+}
+
+func (m *mockConfigManager) Crosspost(org, channel string) bool {
+	return m.crosspost[org+":"+channel]
+}
+
+func (m *mockConfigManager) MessagePrefix(org, channel string) string {
+	return m.prefixes[org+":"+channel]
+}
+
+func (m *mockConfigManager) PrefixForumTitles(_, _ string) bool {
+	return m.prefixTitles
+}
+
+func (m *mockConfigManager) RepoEmoji(_, repo string) string {
+	return m.repoEmoji[repo]
+}
+
+func (m *mockConfigManager) ShowOrg(_, _ string) bool {
+	return m.showOrg
+}
+
+func (m *mockConfigManager) PlainURL(_, _ string) bool {
+	return m.plainURL
+}
+
+func (m *mockConfigManager) ShowBodySnippet(_, _ string) bool {
+	return m.bodySnippet
+}
+
+func (m *mockConfigManager) ReviewChecklist(_, _, _ string) []string {
+	return m.reviewChecklist
+}
+
+func (m *mockConfigManager) DashboardFooter(_, _ string) bool {
+	return m.dashboardFooter
+}
+
+func (m *mockConfigManager) ReviewReminders(_ string) (every time.Duration, maxCount int) {
+	return m.reminderEvery, m.reminderMax
+}
+
+func (m *mockConfigManager) DetailThread(_, _ string) bool {
+	return m.detailThread
+}
+
+func (m *mockConfigManager) AppendMode(_, _ string) bool {
+	return m.appendMode
+}
+
+func (m *mockConfigManager) StateSuppressed(_, _, prState string) bool {
+	return slices.Contains(m.suppressStates, prState)
+}
+
+func (m *mockConfigManager) DashboardURLTemplate(_ string) string {
+	return m.dashboardTmpl
+}
+
+func (m *mockConfigManager) Layout(_, _ string) string {
+	if m.layout == "" {
+		return "wide"
+	}
+	return m.layout
+}
+
+func (m *mockConfigManager) TitleStyle(_, _ string) string {
+	if m.titleStyle == "" {
+		return "none"
+	}
+	return m.titleStyle
+}
+
+func (m *mockConfigManager) DigestInterval(_, channel string) time.Duration {
+	return m.digestIntervals[channel]
+}
+
+func (m *mockConfigManager) Board(_, channel string) bool {
+	return m.boards[channel]
+}
+
+func (m *mockConfigManager) BoardMaxPRs(_, channel string) int {
+	return m.boardMaxPRs[channel]
+}
+
+func (m *mockConfigManager) DeleteOnClose(_, channel string) bool {
+	return m.deleteOnClose[channel]
+}
+
+func (m *mockConfigManager) AuthorAllowed(_, channel, author string) bool {
+	return m.authorFilters[channel].AllowsAuthor(author)
+}
+
+func (m *mockConfigManager) PostWindow(_, channel string) (config.PostWindow, bool) {
+	window, ok := m.postWindows[channel]
+	return window, ok
+}
+
+func (m *mockConfigManager) AdminChannel(_ string) string {
+	return m.adminChannel
+}
+
+func (m *mockConfigManager) FirehoseChannel(_ string) string {
+	return m.firehose
+}
+
+func (m *mockConfigManager) DMFallbackChannel(_ string) string {
+	return m.dmFallback
+}
+
+func (m *mockConfigManager) ActivitySummary(_ string) bool {
+	return m.activitySummary
+}
+
+func (m *mockConfigManager) PersistentReport(_ string) bool {
+	return m.persistentReport
+}
+
+func (m *mockConfigManager) DiscordNames(_ string) bool {
+	return false
+}
+
+func (m *mockConfigManager) ConflictResolved(_ string) string {
+	return m.conflictResolved
+}
+
+func (m *mockConfigManager) ConflictResolvedNote(_ string) string {
+	return "✅ conflict resolved"
+}
+
+func (m *mockConfigManager) TurnUnavailableMessage(_ string) string {
+	return "details unavailable, see GitHub"
+}
+
+func (m *mockConfigManager) HeartbeatStaleAfter(_ string) time.Duration {
+	return m.heartbeatStale
+}
+
+func (m *mockConfigManager) HeartbeatInterval(_ string) time.Duration {
+	return m.heartbeatEvery
+}
+
+func (m *mockConfigManager) CanonicalRepo(_, repo string) string {
+	if canonical, ok := m.repoAliases[repo]; ok {
+		return canonical
+	}
+	return repo
+}
+
+func (m *mockConfigManager) MaxForumThreads(_, _ string) int {
+	return m.maxForumThreads
+}
+
+func (m *mockConfigManager) ArchiveDelay(_, channel string) time.Duration {
+	return m.archiveDelays[channel]
+}
+
+func (m *mockConfigManager) Escalation(_, channel string) []config.EscalationStep {
+	return m.escalation[channel]
+}
+
+func (m *mockConfigManager) TeamRoleMention(_, _ string) (mention string, threshold int) {
+	return m.teamRole, m.roleThreshold
+}
+
+func (m *mockConfigManager) DMTemplate(_, action string) string {
+	return m.dmTemplates[action]
+}
+
 func (m *mockConfigManager) When(org, channel string) string {
 	key := org + ":" + channel
 	if when, exists := m.whenSettings[key]; exists {
@@ -461,65 +1018,1483 @@ func TestCoordinator_ProcessEvent_BasicFlow(t *testing.T) {
 	}
 }
 
-func TestCoordinator_ProcessEvent_Deduplication(t *testing.T) {
-	ctx := context.Background()
-
-	discord := newMockDiscordClient()
-	discord.channelIDs["testrepo"] = "chan-testrepo"
-	discord.botInChannel["chan-testrepo"] = true
-
-	configMgr := newMockConfigManager()
-	store := state.NewMemoryStore()
-	turn := newMockTurnClient()
-	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
-		Analysis: Analysis{
-			NextAction: map[string]Action{
-				"bob": {Kind: "review"},
-			},
-		},
+func TestCoordinator_ProcessEvent_Crosspost(t *testing.T) {
+	tests := []struct {
+		name      string
+		news      bool
+		crosspost bool
+		want      int
+	}{
+		{"announcement channel with crosspost", true, true, 1},
+		{"text channel with crosspost", false, true, 0},
+		{"announcement channel without crosspost", true, false, 0},
 	}
 
-	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/testrepo/pull/42",
-		Type:       "pull_request",
-		DeliveryID: "delivery-same",
-	}
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.newsChannels["chan-testrepo"] = tt.news
+
+			configMgr := newMockConfigManager()
+			configMgr.crosspost = map[string]bool{"testorg:testrepo": tt.crosspost}
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			if len(discord.crossposted) != tt.want {
+				t.Errorf("crossposted = %v, want %d", discord.crossposted, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowApprovals(t *testing.T) {
+	tests := []struct {
+		name     string
+		show     bool
+		pr       PRInfo
+		wantLine string
+	}{
+		{
+			name:     "from Turn's approvals",
+			show:     true,
+			pr:       PRInfo{ApprovedBy: []string{"alice"}, Reviewers: map[string]string{"alice": "approved"}},
+			wantLine: "\napproved by alice; waiting on bob, carol",
+		},
+		{
+			name:     "from reviews without them",
+			show:     true,
+			pr:       PRInfo{Reviewers: map[string]string{"alice": "approved", "bob": "commented"}},
+			wantLine: "\napproved by alice; waiting on bob, carol",
+		},
+		{name: "flag disabled", pr: PRInfo{ApprovedBy: []string{"alice"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showApprovals = tt.show
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+			pr := tt.pr
+			pr.Title, pr.Author, pr.State = "Test PR", "dave", "open"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: pr,
+				Analysis: Analysis{NextAction: map[string]Action{
+					"bob":   {Kind: "review"},
+					"carol": {Kind: "approve"},
+					"dave":  {Kind: "fix_tests"},
+				}},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1"})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if tt.wantLine == "" && strings.Contains(text, "approved by") {
+				t.Errorf("message = %q, want no approval line", text)
+			}
+			if tt.wantLine != "" && !strings.HasSuffix(text, tt.wantLine) {
+				t.Errorf("message = %q, want approval line %q", text, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowAssignees(t *testing.T) {
+	tests := []struct {
+		name       string
+		show       bool
+		nextAction map[string]Action
+		wantLine   bool
+	}{
+		{"assignees with reviewers", true, map[string]Action{"bob": {Kind: "review"}}, true},
+		{"assignees without review actions", true, nil, true},
+		{"flag disabled", false, map[string]Action{"bob": {Kind: "review"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showAssignees = tt.show
+			mapper := newMockUserMapper()
+			mapper.mappings["carol"] = "1003"
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open", Assignees: []string{"carol"}},
+				Analysis:    Analysis{NextAction: tt.nextAction},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if got := strings.HasSuffix(text, "\nassigned to <@1003>"); got != tt.wantLine {
+				t.Errorf("message = %q, want assignee line %v", text, tt.wantLine)
+			}
+			if tt.nextAction != nil && !strings.Contains(text, "**review** →") {
+				t.Errorf("message = %q, want reviewer actions kept", text)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowFailingChecks(t *testing.T) {
+	failing := []FailingCheck{
+		{Name: "lint", URL: "https://github.com/testorg/testrepo/actions/runs/1"},
+		{Name: "test", URL: "https://github.com/testorg/testrepo/actions/runs/2"},
+	}
+	tests := []struct {
+		name     string
+		checks   Checks
+		failing  []FailingCheck
+		wantLine bool
+	}{
+		{"broken tests link failing checks", Checks{Failing: 2}, failing, true},
+		{"passing PR shows none", Checks{Passing: 3}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showChecks = true
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis:    Analysis{Checks: tt.checks, FailingChecks: tt.failing},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			wantLine := "\nfailing: [lint](https://github.com/testorg/testrepo/actions/runs/1), [test](https://github.com/testorg/testrepo/actions/runs/2)"
+			if got := strings.Contains(text, wantLine); got != tt.wantLine {
+				t.Errorf("message = %q, want failing checks line %v", text, tt.wantLine)
+			}
+			if !tt.wantLine && strings.Contains(text, "failing:") {
+				t.Errorf("message = %q, want no failing checks", text)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowCIStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		checks  Checks
+		want    string
+	}{
+		{"passing", true, Checks{Passing: 3}, "🟢"},
+		{"running", true, Checks{Passing: 1, Pending: 2}, "🟡"},
+		{"failing", true, Checks{Passing: 1, Failing: 1}, "🔴"},
+		{"disabled", false, Checks{Passing: 3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showCIStatus = tt.enabled
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis:    Analysis{Checks: tt.checks},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			for _, light := range []string{"🟢", "🟡", "🔴"} {
+				if strings.Contains(text, light) != (light == tt.want) {
+					t.Errorf("message = %q, want CI status %q", text, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowLinkedIssues(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		closes  []int
+		want    bool
+	}{
+		{"enabled", true, []int{45}, true},
+		{"no linked issues", true, nil, false},
+		{"disabled", false, []int{45}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showLinkedIssues = tt.enabled
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open", ClosesIssues: tt.closes},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if got := strings.Contains(text, "closes [#45](https://github.com/testorg/testrepo/issues/45)"); got != tt.want {
+				t.Errorf("message = %q, want linked issues shown: %v", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_TeamRoleMention(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviewers []string
+		wantRole  bool
+	}{
+		{"at threshold mentions individuals", []string{"bob", "carol"}, false},
+		{"above threshold mentions role", []string{"bob", "carol", "dave"}, true},
+		{"author's action not counted", []string{"alice", "bob", "carol"}, false},
+		{"author keeps their action", []string{"alice", "bob", "carol", "dave"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.teamRole = "<@&900>"
+			configMgr.roleThreshold = 2
+
+			mapper := newMockUserMapper()
+			nextAction := make(map[string]Action)
+			mapper.mappings["alice"] = "1000"
+			for i, name := range tt.reviewers {
+				if name == "alice" {
+					nextAction[name] = Action{Kind: "fix_tests"}
+					continue
+				}
+				mapper.mappings[name] = fmt.Sprintf("%d", 1001+i)
+				nextAction[name] = Action{Kind: "review"}
+			}
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis:    Analysis{NextAction: nextAction},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if got := strings.Contains(text, "**review** → <@&900>"); got != tt.wantRole {
+				t.Errorf("message = %q, want role mention %v", text, tt.wantRole)
+			}
+			if got := strings.Contains(text, "<@1002>"); got == tt.wantRole {
+				t.Errorf("message = %q, want individual mentions %v", text, !tt.wantRole)
+			}
+			if slices.Contains(tt.reviewers, "alice") && !strings.Contains(text, "<@1000>") {
+				t.Errorf("message = %q, want the author's own action mentioning them", text)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_AuthorTeamChannels(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.channelIDs["platform-reviews"] = "chan-platform"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.botInChannel["chan-platform"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.authorChannels = map[string][]string{"testorg:alice": {"platform-reviews", "testrepo"}}
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+	turn.responses["https://github.com/testorg/testrepo/pull/43"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Other PR", Author: "bob", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	for i, url := range []string{"https://github.com/testorg/testrepo/pull/42", "https://github.com/testorg/testrepo/pull/43"} {
+		coord.ProcessEvent(context.Background(), SprinklerEvent{
+			URL:        url,
+			Type:       "pull_request",
+			DeliveryID: fmt.Sprintf("delivery-%d", i),
+		})
+	}
+	coord.Wait()
+
+	posted := make(map[string]int)
+	for _, msg := range discord.postedMessages {
+		posted[msg.channelID]++
+	}
+	// Both PRs post to the repo channel once; only the platform author's PR goes to the team channel
+	if posted["chan-testrepo"] != 2 || posted["chan-platform"] != 1 {
+		t.Errorf("posts per channel = %v, want chan-testrepo: 2, chan-platform: 1", posted)
+	}
+}
+
+func TestCoordinator_ProcessEvent_ShowOrg(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.showOrg = true
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(context.Background(), SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+	})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "[testorg/testrepo#42]") {
+		t.Errorf("message = %q, want org in PR link", text)
+	}
+}
+
+func TestCoordinator_ProcessEvent_PlainURL(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.plainURL = true
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(context.Background(), SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+	})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "\n<https://github.com/testorg/testrepo/pull/42>") {
+		t.Errorf("message = %q, want the plain PR URL", text)
+	}
+}
+
+func TestCoordinator_ProcessEvent_DashboardFooter(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "dashboard URL",
+			want: "[📊 dashboard](<https://dash.example.com/orgs/testorg>)",
+		},
+		{
+			name: "org template",
+			tmpl: "https://goose.example.org/{org}/{repo}/pull/{number}",
+			want: "[📊 dashboard](<https://goose.example.org/testorg/testrepo/pull/42>)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.dashboardFooter = true
+			configMgr.dashboardTmpl = tt.tmpl
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:      discord,
+				Config:       configMgr,
+				Store:        state.NewMemoryStore(),
+				Turn:         turn,
+				Org:          "testorg",
+				DashboardURL: "https://dash.example.com",
+			})
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			if text := discord.postedMessages[0].text; !strings.Contains(text, tt.want) {
+				t.Errorf("message = %q, want the dashboard footer %q", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_Firehose(t *testing.T) {
+	discord := newMockDiscordClient()
+	for _, channel := range []string{"api", "web", "all-prs"} {
+		discord.channelIDs[channel] = "chan-" + channel
+		discord.botInChannel["chan-"+channel] = true
+	}
+
+	configMgr := newMockConfigManager()
+	configMgr.firehose = "all-prs"
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/api/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add endpoint", Author: "alice", State: "open"},
+	}
+	turn.responses["https://github.com/testorg/web/pull/2"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fix layout", Author: "bob", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	ctx := context.Background()
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: "https://github.com/testorg/api/pull/1", Type: "pull_request", DeliveryID: "d-1"})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: "https://github.com/testorg/web/pull/2", Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+
+	posted := make(map[string][]string)
+	for _, msg := range discord.postedMessages {
+		posted[msg.channelID] = append(posted[msg.channelID], msg.text)
+	}
+	if len(posted["chan-api"]) != 1 || len(posted["chan-web"]) != 1 {
+		t.Errorf("repo channel posts = %d api, %d web, want 1 each", len(posted["chan-api"]), len(posted["chan-web"]))
+	}
+	if strings.Contains(strings.Join(posted["chan-api"], "\n"), "[testorg/") {
+		t.Errorf("repo channel message = %q, want no org in PR link", posted["chan-api"])
+	}
+
+	firehose := strings.Join(posted["chan-all-prs"], "\n")
+	if len(posted["chan-all-prs"]) != 2 {
+		t.Fatalf("firehose posts = %d, want 2", len(posted["chan-all-prs"]))
+	}
+	for _, want := range []string{"[testorg/api#1]", "[testorg/web#2]"} {
+		if !strings.Contains(firehose, want) {
+			t.Errorf("firehose messages = %q, want %s", firehose, want)
+		}
+	}
+
+	// Later events edit the firehose message in place
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: "https://github.com/testorg/api/pull/1", Type: "pull_request", DeliveryID: "d-3"})
+	coord.Wait()
+	if len(discord.postedMessages) != 4 {
+		t.Errorf("postedMessages = %d after an update, want 4", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_ProcessEvent_FirehoseOverrides(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	for _, channel := range []string{"api", "hotfix", "all-prs"} {
+		discord.channelIDs[channel] = "chan-" + channel
+		discord.botInChannel["chan-"+channel] = true
+	}
+
+	configMgr := newMockConfigManager()
+	configMgr.firehose = "all-prs"
+	turn := newMockTurnClient()
+	routedURL := "https://github.com/testorg/api/pull/1"
+	mutedURL := "https://github.com/testorg/api/pull/2"
+	turn.responses[routedURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Hotfix", Author: "alice", State: "open"},
+	}
+	turn.responses[mutedURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Experiment", Author: "bob", State: "closed", Closed: true},
+	}
+
+	store := state.NewMemoryStore()
+	if err := store.SavePROverride(ctx, routedURL, state.PROverride{Channel: "hotfix"}); err != nil {
+		t.Fatalf("SavePROverride() error = %v", err)
+	}
+	// The muted PR was posted to its repo channel before it was muted, but never to the firehose
+	if err := store.SavePROverride(ctx, mutedURL, state.PROverride{Muted: true}); err != nil {
+		t.Fatalf("SavePROverride() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "testorg", "api", 2, "chan-api", state.ThreadInfo{
+		ChannelID: "chan-api",
+		MessageID: "msg-2",
+		LastState: "needs_review",
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: routedURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: mutedURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+
+	posted := make(map[string]int)
+	for _, msg := range discord.postedMessages {
+		posted[msg.channelID]++
+	}
+	if posted["chan-hotfix"] != 1 || posted["chan-api"] != 0 {
+		t.Errorf("posts = %v, want the routed PR only in its override channel", posted)
+	}
+	if posted["chan-all-prs"] != 0 {
+		t.Errorf("firehose posts = %d, want none for routed or muted PRs", posted["chan-all-prs"])
+	}
+	if len(discord.updatedMessages) != 1 {
+		t.Errorf("updatedMessages = %d, want the muted PR's posted message updated on close", len(discord.updatedMessages))
+	}
+}
+
+func TestCoordinator_ProcessEvent_MessagePrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefixTitles bool
+		wantTitle    string
+	}{
+		{"title left alone by default", false, "[testrepo#42] Test PR"},
+		{"title prefixed when configured", true, "[infra] [testrepo#42] Test PR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.forumChannels["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.prefixes = map[string]string{"testorg:testrepo": "[infra]"}
+			configMgr.prefixTitles = tt.prefixTitles
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.forumThreads) != 1 {
+				t.Fatalf("forumThreads = %d, want 1", len(discord.forumThreads))
+			}
+			thread := discord.forumThreads[0]
+			if thread.title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", thread.title, tt.wantTitle)
+			}
+			if !strings.HasPrefix(thread.content, "[infra] ") {
+				t.Errorf("content = %q, want [infra] prefix", thread.content)
+			}
+		})
+	}
+}
+
+type mockBranchResolver struct {
+	branch string
+	err    error
+}
+
+func (m *mockBranchResolver) DefaultBranch(_ context.Context, _, _ string) (string, error) {
+	return m.branch, m.err
+}
+
+func TestCoordinator_ProcessEvent_NonDefaultBase(t *testing.T) {
+	tests := []struct {
+		name          string
+		show          bool
+		base          string
+		configDefault string
+		branches      BranchResolver
+		wantBase      bool
+	}{
+		{"PR into default branch", true, "main", "", &mockBranchResolver{branch: "main"}, false},
+		{"PR into release branch", true, "release-2.0", "", &mockBranchResolver{branch: "main"}, true},
+		{"config default overrides lookup", true, "release-2.0", "release-2.0", &mockBranchResolver{branch: "main"}, false},
+		{"lookup failure assumes main is default", true, "main", "", &mockBranchResolver{err: errors.New("boom")}, false},
+		{"no resolver still shows release branch", true, "release-2.0", "", nil, true},
+		{"toggle disabled", false, "release-2.0", "", &mockBranchResolver{branch: "main"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.showBase = tt.show
+			configMgr.defaultBranch = tt.configDefault
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open", BaseBranch: tt.base},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:  discord,
+				Config:   configMgr,
+				Store:    state.NewMemoryStore(),
+				Turn:     turn,
+				Branches: tt.branches,
+				Org:      "testorg",
+			})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if got := strings.Contains(text, "→ "+tt.base+" "); got != tt.wantBase {
+				t.Errorf("message = %q, want base branch shown: %v", text, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_Deduplication(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"bob": {Kind: "review"},
+			},
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-same",
+	}
+
+	// Process same event twice
+	coord.ProcessEvent(ctx, event)
+	coord.Wait()
+
+	coord.ProcessEvent(ctx, event)
+	coord.Wait()
+
+	// Should only create 1 message (deduplication works)
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("Expected 1 posted message due to deduplication, got %d", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_ProcessForumChannel(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true // Mark as forum
+
+	configMgr := newMockConfigManager()
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"bob": {Kind: "review"},
+			},
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-forum",
+	}
+
+	coord.ProcessEvent(ctx, event)
+	coord.Wait()
+
+	if len(discord.forumThreads) != 1 {
+		t.Errorf("Expected 1 forum thread, got %d", len(discord.forumThreads))
+	}
+}
+
+func TestCoordinator_ProcessEvent_BodySnippet(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	for _, channel := range []string{"forum", "text"} {
+		discord.channelIDs[channel] = "chan-" + channel
+		discord.botInChannel["chan-"+channel] = true
+	}
+	discord.forumChannels["chan-forum"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.bodySnippet = true
+	configMgr.channels["testorg:testrepo"] = []string{"forum", "text"}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Speed up builds",
+			Body:   "<!-- template -->\nCaches **module** downloads\nbetween jobs.",
+			Author: "alice",
+			State:  "open",
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	want := "\n> Caches \\*\\*module\\*\\* downloads between jobs."
+	if len(discord.forumThreads) != 1 {
+		t.Fatalf("forumThreads = %d, want 1", len(discord.forumThreads))
+	}
+	if content := discord.forumThreads[0].content; !strings.Contains(content, want) {
+		t.Errorf("forum content = %q, want the body snippet %q", content, want)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; strings.Contains(text, "Caches") {
+		t.Errorf("text channel message = %q, want no body snippet", text)
+	}
+}
+
+func TestCoordinator_ProcessEvent_ReviewChecklist(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	for _, channel := range []string{"forum", "text"} {
+		discord.channelIDs[channel] = "chan-" + channel
+		discord.botInChannel["chan-"+channel] = true
+	}
+	discord.forumChannels["chan-forum"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.reviewChecklist = []string{"tests pass", "docs updated"}
+	configMgr.channels["testorg:testrepo"] = []string{"forum", "text"}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Speed up builds", Author: "alice", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	want := "**Review checklist**\n- ⬜ tests pass\n- ⬜ docs updated"
+	if len(discord.forumThreads) != 1 {
+		t.Fatalf("forumThreads = %d, want 1", len(discord.forumThreads))
+	}
+	if content := discord.forumThreads[0].content; !strings.Contains(content, want) {
+		t.Errorf("forum content = %q, want the checklist %q", content, want)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; strings.Contains(text, "checklist") {
+		t.Errorf("text channel message = %q, want no checklist", text)
+	}
+}
+
+func TestCoordinator_ProcessForumChannel_RecoversStarterMessageID(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true
+	discord.noStarterID = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if info, ok := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); !ok || info.MessageID != "" {
+		t.Fatalf("stored thread = %+v, %v, want one without a starter message ID", info, ok)
+	}
+
+	// The next update fetches the starter message's ID before editing
+	discord.starterMessageIDs = map[string]string{"thread-chan-testrepo": "msg-starter"}
+	turn.responses[prURL].Analysis.Approved = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request_review", DeliveryID: "d-2"})
+	coord.Wait()
+
+	if len(discord.updatedForumPosts) != 1 {
+		t.Fatalf("updatedForumPosts = %d, want 1", len(discord.updatedForumPosts))
+	}
+	if got := discord.updatedForumPosts[0].messageID; got != "msg-starter" {
+		t.Errorf("updated message ID = %q, want the recovered msg-starter", got)
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); info.MessageID != "msg-starter" {
+		t.Errorf("stored message ID = %q, want msg-starter saved", info.MessageID)
+	}
+}
+
+func TestCoordinator_ProcessForumChannel_Reopened(t *testing.T) {
+	for _, keepArchived := range []bool{false, true} {
+		t.Run(fmt.Sprintf("keepArchived=%v", keepArchived), func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.forumChannels["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.keepArchived = keepArchived
+
+			store := state.NewMemoryStore()
+			// The thread was archived when the PR closed
+			if err := store.SaveThread(ctx, "testorg", "testrepo", 42, "chan-testrepo", state.ThreadInfo{
+				ThreadID:    "thread-42",
+				MessageID:   "msg-42",
+				ChannelID:   "chan-testrepo",
+				ChannelType: "forum",
+				MessageText: "closed content",
+				LastState:   string(format.StateClosed),
+			}); err != nil {
+				t.Fatalf("SaveThread() error = %v", err)
+			}
+
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    turn,
+				Org:     "testorg",
+			})
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-reopen",
+			})
+			coord.Wait()
+
+			if keepArchived {
+				if len(discord.unarchivedThreads) != 0 {
+					t.Errorf("unarchivedThreads = %v, want none when disabled", discord.unarchivedThreads)
+				}
+				return
+			}
+			if len(discord.unarchivedThreads) != 1 || discord.unarchivedThreads[0] != "thread-42" {
+				t.Errorf("unarchivedThreads = %v, want [thread-42]", discord.unarchivedThreads)
+			}
+			if len(discord.updatedForumPosts) != 1 || discord.updatedForumPosts[0].messageID != "msg-42" {
+				t.Errorf("updatedForumPosts = %+v, want one update of msg-42", discord.updatedForumPosts)
+			}
+			if len(discord.forumThreads) != 0 {
+				t.Errorf("forumThreads = %d, want 0 (no duplicate thread)", len(discord.forumThreads))
+			}
+			if info, _ := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); info.LastState == string(format.StateClosed) {
+				t.Error("LastState still closed after reopen")
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessChannel_BotJoinGrace(t *testing.T) {
+	for _, grace := range []time.Duration{0, 5 * time.Second} {
+		t.Run(fmt.Sprintf("grace=%v", grace), func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			// Permissions haven't propagated yet on the first check
+			discord.botJoinPending = map[string]int{"chan-testrepo": 1}
+
+			configMgr := newMockConfigManager()
+			configMgr.joinGrace = grace
+
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-join",
+			})
+			coord.Wait()
+
+			want := 1
+			if grace == 0 {
+				want = 0 // No retry: the event is dropped
+			}
+			if len(discord.postedMessages) != want {
+				t.Errorf("postedMessages = %d, want %d", len(discord.postedMessages), want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessChannel_AuthorFilters(t *testing.T) {
+	tests := []struct {
+		author       string
+		wantChannels []string
+	}{
+		{"ext-bob", []string{"chan-testrepo", "chan-external"}},
+		{"alice", []string{"chan-testrepo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.author, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.channelIDs["external"] = "chan-external"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.botInChannel["chan-external"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.channels["testorg:testrepo"] = []string{"testrepo", "external"}
+			configMgr.authorFilters = map[string]config.ChannelConfig{
+				"external": {OnlyAuthors: []string{"ext-*"}},
+			}
+
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: tt.author, State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+			coord.ProcessEvent(context.Background(), SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1"})
+			coord.Wait()
+
+			var got []string
+			for _, msg := range discord.postedMessages {
+				got = append(got, msg.channelID)
+			}
+			if !slices.Equal(got, tt.wantChannels) {
+				t.Errorf("posted to %v, want %v", got, tt.wantChannels)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessChannel_BotJoinGrace_Replays(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.botJoinPending = map[string]int{"chan-testrepo": 2}
+
+	configMgr := newMockConfigManager()
+	configMgr.joinGrace = 5 * time.Second
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// Waiting for the channel happens in a replay, not while the event holds the PR's lock
+	start := time.Now()
+	if err := coord.processEventSync(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-join"}); err != nil {
+		t.Fatalf("processEventSync() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= botJoinRetryDelay {
+		t.Errorf("processEventSync() took %v, want it to return without waiting for the channel", elapsed)
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d before the recheck, want 0", len(discord.postedMessages))
+	}
+
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after rechecking, want 1", len(discord.postedMessages))
+	}
+	if len(coord.joinRetries.pending) != 0 {
+		t.Errorf("pending join retries = %d, want 0 once the bot can post", len(coord.joinRetries.pending))
+	}
+}
+
+func TestCoordinator_ProcessChannel_DeleteOnClose(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.deleteOnClose = map[string]bool{"testrepo": true}
+
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-open"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); !ok {
+		t.Fatal("message not tracked after posting")
+	}
+
+	turn.responses[prURL].PullRequest.Merged = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-merged"})
+	coord.Wait()
+
+	if len(discord.deletedMessages) != 1 || discord.deletedMessages[0] != "chan-testrepo/msg-chan-testrepo" {
+		t.Errorf("deletedMessages = %v, want [chan-testrepo/msg-chan-testrepo]", discord.deletedMessages)
+	}
+	if len(discord.updatedMessages) != 0 {
+		t.Errorf("updatedMessages = %d, want 0 (deleted, not edited)", len(discord.updatedMessages))
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); ok {
+		t.Error("store still tracks the deleted message")
+	}
+
+	// Later events for the merged PR don't post it again
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-again"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after merge, want 1", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_ProcessForumChannel_MissingThreadPermission(t *testing.T) {
+	ctx := context.Background()
+
+	discordClient := newMockDiscordClient()
+	discordClient.channelIDs["testrepo"] = "chan-testrepo"
+	discordClient.botInChannel["chan-testrepo"] = true
+	discordClient.forumChannels["chan-testrepo"] = true
+	// Bot can send messages but can't create threads in the forum
+	discordClient.channelPerms = map[string]discord.Permissions{
+		"chan-testrepo": {ViewChannel: true, SendMessages: true, ReadMessageHistory: true},
+	}
+
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discordClient,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-forum-perms",
+	})
+	coord.Wait()
+
+	if len(discordClient.forumThreads) != 0 {
+		t.Errorf("Expected no forum thread without Create Public Threads, got %d", len(discordClient.forumThreads))
+	}
+	if len(discordClient.postedMessages) != 0 {
+		t.Errorf("Expected no fallback text message in a forum, got %d", len(discordClient.postedMessages))
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 42, "chan-testrepo"); ok {
+		t.Error("Expected no thread info saved when permissions are missing")
+	}
+}
+
+func TestCoordinator_ConfigReload(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	configMgr := newMockConfigManager()
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// Test config repo PR (should trigger reload)
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/.codeGROOVE/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-config",
+	}
+
+	coord.ProcessEvent(ctx, event)
+	coord.Wait()
+
+	// Should not create any messages for config repo
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("Expected 0 messages for config repo, got %d", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_QueueDMNotifications_Disabled(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["discord-bob"] = true
+
+	configMgr := &mockConfigManagerWithDelay{
+		mockConfigManager: newMockConfigManager(),
+		delay:             0, // DMs disabled
+	}
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"bob": {Kind: "review"},
+			},
+		},
+	}
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-nodm",
+	}
 
-	// Process same event twice
 	coord.ProcessEvent(ctx, event)
 	coord.Wait()
 
-	coord.ProcessEvent(ctx, event)
-	coord.Wait()
+	// No DMs should be queued when delay=0
+	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected 0 pending DMs with delay=0, got %d", len(pending))
+	}
+}
+
+type mockConfigManagerWithDelay struct {
+	*mockConfigManager
+
+	delay int
+}
 
-	// Should only create 1 message (deduplication works)
-	if len(discord.postedMessages) != 1 {
-		t.Errorf("Expected 1 posted message due to deduplication, got %d", len(discord.postedMessages))
-	}
+func (m *mockConfigManagerWithDelay) ReminderDMDelay(_, _ string) int {
+	return m.delay
 }
 
-func TestCoordinator_ProcessForumChannel(t *testing.T) {
+func TestCoordinator_QueueDMNotifications_Tagged(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
 	discord.channelIDs["testrepo"] = "chan-testrepo"
 	discord.botInChannel["chan-testrepo"] = true
-	discord.forumChannels["chan-testrepo"] = true // Mark as forum
+	discord.usersInGuild["discord-bob"] = true
 
-	configMgr := newMockConfigManager()
+	configMgr := &mockConfigManagerWithDelay{
+		mockConfigManager: newMockConfigManager(),
+		delay:             30, // 30 minute delay
+	}
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
 	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
@@ -535,72 +2510,197 @@ func TestCoordinator_ProcessForumChannel(t *testing.T) {
 		},
 	}
 
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
 	})
 
 	event := SprinklerEvent{
 		URL:        "https://github.com/testorg/testrepo/pull/42",
 		Type:       "pull_request",
-		DeliveryID: "delivery-forum",
+		DeliveryID: "delivery-tagged",
 	}
 
 	coord.ProcessEvent(ctx, event)
 	coord.Wait()
 
-	if len(discord.forumThreads) != 1 {
-		t.Errorf("Expected 1 forum thread, got %d", len(discord.forumThreads))
+	// DM should be queued with delay
+	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending DM, got %d", len(pending))
+	}
+
+	// Check that sendAt is delayed by ~30 minutes
+	dm := pending[0]
+	delay := dm.SendAt.Sub(dm.CreatedAt)
+	expectedDelay := 30 * time.Minute
+	if delay < expectedDelay-time.Second || delay > expectedDelay+time.Second {
+		t.Errorf("Expected delay ~%v, got %v", expectedDelay, delay)
 	}
 }
 
-func TestCoordinator_ConfigReload(t *testing.T) {
+type mockConfigManagerWithChannelDelays struct {
+	*mockConfigManager
+
+	delays map[string]int // channel -> delay
+}
+
+func (m *mockConfigManagerWithChannelDelays) ReminderDMDelay(_, channel string) int {
+	return m.delays[channel]
+}
+
+func TestCoordinator_QueueDMNotifications_MultiChannelLongestDelay(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
-	configMgr := newMockConfigManager()
+	discord.channelIDs["fast"] = "chan-fast"
+	discord.channelIDs["slow"] = "chan-slow"
+	discord.botInChannel["chan-fast"] = true
+	discord.botInChannel["chan-slow"] = true
+	discord.usersInGuild["discord-bob"] = true
+
+	configMgr := &mockConfigManagerWithChannelDelays{
+		mockConfigManager: newMockConfigManager(),
+		delays:            map[string]int{"fast": 30, "slow": 90},
+	}
+	configMgr.channels["testorg:testrepo"] = []string{"fast", "slow"}
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+	}
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
 	})
 
-	// Test config repo PR (should trigger reload)
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/.codeGROOVE/pull/1",
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
 		Type:       "pull_request",
-		DeliveryID: "delivery-config",
+		DeliveryID: "delivery-multi-channel",
+	})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 2 {
+		t.Fatalf("postedMessages = %d, want one per channel", len(discord.postedMessages))
+	}
+	pending, err := store.PendingDMs(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending DM, got %d", len(pending))
+	}
+	delay := pending[0].SendAt.Sub(pending[0].CreatedAt)
+	if expected := 90 * time.Minute; delay < expected-time.Second || delay > expected+time.Second {
+		t.Errorf("Expected delay ~%v (longest channel), got %v", expected, delay)
 	}
+}
 
-	coord.ProcessEvent(ctx, event)
+func TestCoordinator_QueueDMNotifications_ActionTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.usersInGuild["discord-alice"] = true
+	discord.usersInGuild["discord-bob"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.dmTemplates = map[string]string{
+		"fix_conflict": "URGENT: {{.Action}} on {{.Owner}}/{{.Repo}}#{{.Number}} {{.PRURL}}",
+	}
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			MergeConflict: true,
+			NextAction: map[string]Action{
+				"alice": {Kind: "fix_conflict"},
+				"bob":   {Kind: "review"},
+			},
+		},
+	}
+
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	mapper.mappings["bob"] = "discord-bob"
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-template",
+	})
 	coord.Wait()
 
-	// Should not create any messages for config repo
-	if len(discord.postedMessages) != 0 {
-		t.Errorf("Expected 0 messages for config repo, got %d", len(discord.postedMessages))
+	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("Expected 2 pending DMs, got %d", len(pending))
+	}
+
+	for _, dm := range pending {
+		switch dm.UserID {
+		case "discord-alice":
+			want := "URGENT: fix conflict on testorg/testrepo#42 https://github.com/testorg/testrepo/pull/42"
+			if dm.MessageText != want {
+				t.Errorf("fix_conflict DM = %q, want %q", dm.MessageText, want)
+			}
+		case "discord-bob":
+			// No template for review - default format
+			if !strings.Contains(dm.MessageText, "**review**") {
+				t.Errorf("review DM = %q, want default format", dm.MessageText)
+			}
+		default:
+			t.Errorf("unexpected DM recipient %q", dm.UserID)
+		}
 	}
 }
 
-func TestCoordinator_QueueDMNotifications_Disabled(t *testing.T) {
+func TestCoordinator_QueueDMNotifications_MinDelayUntagged(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
 	discord.channelIDs["testrepo"] = "chan-testrepo"
-	discord.botInChannel["chan-testrepo"] = true
+	// Bot not in channel, so bob is never tagged
 	discord.usersInGuild["discord-bob"] = true
 
-	configMgr := &mockConfigManagerWithDelay{
-		mockConfigManager: newMockConfigManager(),
-		delay:             0, // DMs disabled
-	}
+	configMgr := newMockConfigManager()
+	configMgr.minDMDelay = 3
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
 	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
@@ -616,48 +2716,252 @@ func TestCoordinator_QueueDMNotifications_Disabled(t *testing.T) {
 		},
 	}
 
-	mapper := newMockUserMapper()
-	mapper.mappings["bob"] = "discord-bob"
-
-	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		UserMapper: mapper,
-		Org:        "testorg",
-	})
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request",
+		DeliveryID: "delivery-min-delay",
+	})
+	coord.Wait()
+
+	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending DM, got %d", len(pending))
+	}
+
+	delay := pending[0].SendAt.Sub(pending[0].CreatedAt)
+	expectedDelay := 3 * time.Minute
+	if delay < expectedDelay-time.Second || delay > expectedDelay+time.Second {
+		t.Errorf("Expected untagged DM delayed by minimum ~%v, got %v", expectedDelay, delay)
+	}
+}
+
+func TestCoordinator_QueueDMNotifications_MutedAndSnoozed(t *testing.T) {
+	const prURL = "https://github.com/testorg/testrepo/pull/42"
+	snoozedUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		info        state.DMInfo
+		wantPending bool
+	}{
+		{name: "muted", info: state.DMInfo{Muted: true}},
+		{name: "snoozed", info: state.DMInfo{SnoozedUntil: snoozedUntil}, wantPending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.usersInGuild["discord-bob"] = true
+
+			store := state.NewMemoryStore()
+			if err := store.SaveDMInfo(ctx, "discord-bob", prURL, tt.info); err != nil {
+				t.Fatalf("SaveDMInfo() error = %v", err)
+			}
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+			}
+			mapper := newMockUserMapper()
+			mapper.mappings["bob"] = "discord-bob"
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     newMockConfigManager(),
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-" + tt.name})
+			coord.Wait()
+
+			pending, err := store.PendingDMs(ctx, time.Now().Add(24*time.Hour))
+			if err != nil {
+				t.Fatalf("PendingDMs() error = %v", err)
+			}
+			if !tt.wantPending {
+				if len(pending) != 0 {
+					t.Errorf("pending DMs = %d, want 0", len(pending))
+				}
+				return
+			}
+			if len(pending) != 1 {
+				t.Fatalf("pending DMs = %d, want 1", len(pending))
+			}
+			if pending[0].SendAt.Before(snoozedUntil) {
+				t.Errorf("SendAt = %v, want no earlier than snooze end %v", pending[0].SendAt, snoozedUntil)
+			}
+		})
+	}
+}
+
+func TestCoordinator_StaleEvent_UpdatesMessageWithoutDM(t *testing.T) {
+	tests := []struct {
+		name        string
+		eventAge    time.Duration
+		eventType   string
+		wantPending int
+		wantSilent  bool
+	}{
+		{"stale webhook event", 3 * time.Hour, "pull_request", 0, true},
+		{"fresh webhook event", time.Minute, "pull_request", 1, false},
+		{"old poll event", 3 * time.Hour, "poll", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["1001"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.maxEventAge = time.Hour
+			store := state.NewMemoryStore()
+			if err := store.SaveThread(ctx, "testorg", "testrepo", 42, "chan-testrepo", state.ThreadInfo{
+				MessageID:   "msg-1",
+				ChannelID:   "chan-testrepo",
+				ChannelType: "text",
+				MessageText: "old content",
+			}); err != nil {
+				t.Fatalf("SaveThread() error = %v", err)
+			}
+
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis: Analysis{
+					NextAction: map[string]Action{"bob": {Kind: "review"}},
+				},
+			}
+			mapper := newMockUserMapper()
+			mapper.mappings["bob"] = "1001"
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        prURL,
+				Type:       tt.eventType,
+				Timestamp:  time.Now().Add(-tt.eventAge),
+				DeliveryID: "delivery-stale",
+			})
+			coord.Wait()
+
+			if len(discord.updatedMessages) != 1 {
+				t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+			}
+			// bob's mention is new, so only staleness makes the edit silent
+			if got := discord.updatedMessages[0].silent; got != tt.wantSilent {
+				t.Errorf("silent = %v, want %v", got, tt.wantSilent)
+			}
+
+			pending, err := store.PendingDMs(ctx, time.Now().Add(24*time.Hour))
+			if err != nil {
+				t.Fatalf("PendingDMs() error = %v", err)
+			}
+			if len(pending) != tt.wantPending {
+				t.Errorf("pending DMs = %d, want %d", len(pending), tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestCoordinator_StaleEvent_NewPostWithoutMentions(t *testing.T) {
+	tests := []struct {
+		name         string
+		eventAge     time.Duration
+		wantMentions bool
+	}{
+		{"stale event", 3 * time.Hour, false},
+		{"fresh event", time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			prURL := "https://github.com/testorg/testrepo/pull/42"
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/testrepo/pull/42",
-		Type:       "pull_request",
-		DeliveryID: "delivery-nodm",
-	}
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["1001"] = true
 
-	coord.ProcessEvent(ctx, event)
-	coord.Wait()
+			configMgr := newMockConfigManager()
+			configMgr.maxEventAge = time.Hour
+			configMgr.teamRole = "<@&900>"
 
-	// No DMs should be queued when delay=0
-	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
-	if err != nil {
-		t.Fatalf("PendingDMs() error = %v", err)
-	}
-	if len(pending) != 0 {
-		t.Errorf("Expected 0 pending DMs with delay=0, got %d", len(pending))
-	}
-}
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+				Analysis: Analysis{
+					NextAction: map[string]Action{"bob": {Kind: "review"}},
+				},
+			}
+			mapper := newMockUserMapper()
+			mapper.mappings["bob"] = "1001"
 
-type mockConfigManagerWithDelay struct {
-	*mockConfigManager
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
 
-	delay int
-}
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        prURL,
+				Type:       "pull_request",
+				Timestamp:  time.Now().Add(-tt.eventAge),
+				DeliveryID: "delivery-new",
+			})
+			coord.Wait()
 
-func (m *mockConfigManagerWithDelay) ReminderDMDelay(_, _ string) int {
-	return m.delay
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if got := strings.Contains(text, "<@"); got != tt.wantMentions {
+				t.Errorf("message = %q, want mentions %v", text, tt.wantMentions)
+			}
+			if !tt.wantMentions && !strings.Contains(text, "bob") {
+				t.Errorf("message = %q, want the reviewer named", text)
+			}
+		})
+	}
 }
 
-func TestCoordinator_QueueDMNotifications_Tagged(t *testing.T) {
+func TestCoordinator_QueueDMNotifications_TaggedDelayExceedsMinimum(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
@@ -667,8 +2971,9 @@ func TestCoordinator_QueueDMNotifications_Tagged(t *testing.T) {
 
 	configMgr := &mockConfigManagerWithDelay{
 		mockConfigManager: newMockConfigManager(),
-		delay:             30, // 30 minute delay
+		delay:             30,
 	}
+	configMgr.minDMDelay = 5
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
 	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
@@ -696,16 +3001,13 @@ func TestCoordinator_QueueDMNotifications_Tagged(t *testing.T) {
 		Org:        "testorg",
 	})
 
-	event := SprinklerEvent{
+	coord.ProcessEvent(ctx, SprinklerEvent{
 		URL:        "https://github.com/testorg/testrepo/pull/42",
 		Type:       "pull_request",
-		DeliveryID: "delivery-tagged",
-	}
-
-	coord.ProcessEvent(ctx, event)
+		DeliveryID: "delivery-tagged-min",
+	})
 	coord.Wait()
 
-	// DM should be queued with delay
 	pending, err := store.PendingDMs(ctx, time.Now().Add(time.Hour))
 	if err != nil {
 		t.Fatalf("PendingDMs() error = %v", err)
@@ -714,9 +3016,8 @@ func TestCoordinator_QueueDMNotifications_Tagged(t *testing.T) {
 		t.Fatalf("Expected 1 pending DM, got %d", len(pending))
 	}
 
-	// Check that sendAt is delayed by ~30 minutes
-	dm := pending[0]
-	delay := dm.SendAt.Sub(dm.CreatedAt)
+	// Tagged delay (30m) wins over the 5m minimum
+	delay := pending[0].SendAt.Sub(pending[0].CreatedAt)
 	expectedDelay := 30 * time.Minute
 	if delay < expectedDelay-time.Second || delay > expectedDelay+time.Second {
 		t.Errorf("Expected delay ~%v, got %v", expectedDelay, delay)
@@ -1690,6 +3991,73 @@ func TestCoordinator_ProcessClosedPR(t *testing.T) {
 	}
 }
 
+func TestCoordinator_ClosedUnmerged_NotifiesAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		merged  bool
+		enabled bool
+		wantDMs int
+	}{
+		{"closed unmerged with toggle", false, true, 1},
+		{"closed unmerged without toggle", false, false, 0},
+		{"merged with toggle", true, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["discord-alice"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.dmClosedUnmerged = tt.enabled
+			mapper := newMockUserMapper()
+			mapper.mappings["alice"] = "discord-alice"
+
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{
+					Title:  "Test PR",
+					Author: "alice",
+					State:  "closed",
+					Closed: true,
+					Merged: tt.merged,
+				},
+				Analysis: Analysis{NextAction: map[string]Action{}},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			// A redelivered close event must not DM the author twice
+			for _, id := range []string{"delivery-1", "delivery-2"} {
+				coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: id})
+				coord.Wait()
+			}
+
+			if len(discord.sentDMs) != tt.wantDMs {
+				t.Fatalf("sentDMs = %d, want %d", len(discord.sentDMs), tt.wantDMs)
+			}
+			if tt.wantDMs > 0 {
+				dm := discord.sentDMs[0]
+				if dm.userID != "discord-alice" || !strings.Contains(dm.text, "closed without merging") {
+					t.Errorf("sent DM = %+v, want closed-without-merging DM to alice", dm)
+				}
+			}
+		})
+	}
+}
+
 func TestCoordinator_ProcessDraftPR(t *testing.T) {
 	ctx := context.Background()
 
@@ -3147,41 +5515,200 @@ func TestCoordinator_ProcessMultipleUsers(t *testing.T) {
 		DeliveryID: "delivery-1",
 	}
 
-	coord.ProcessEvent(ctx, event)
-	coord.Wait()
+	coord.ProcessEvent(ctx, event)
+	coord.Wait()
+
+	// Should queue DMs for both bob and charlie
+}
+
+func TestCoordinator_checkDailyReports_WithGuildID(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.usersInGuild["discord-bob"] = true
+	discord.activeUsers["discord-bob"] = true
+
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+
+	// Setup PR responses
+	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR 1",
+			Author: "bob",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"alice": {Kind: "review"},
+			},
+			WorkflowState: "awaiting_review",
+		},
+	}
+
+	userMapper := newMockUserMapper()
+	userMapper.mappings["bob"] = "discord-bob"
+
+	// Configure with guild ID
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Global: config.GlobalConfig{
+			GuildID: "test-guild-123",
+		},
+	}
+
+	searcher := &mockPRSearcher{
+		openPRs: []PRSearchResult{
+			{
+				URL:       "https://github.com/testorg/repo1/pull/1",
+				UpdatedAt: time.Now(),
+				Owner:     "testorg",
+				Repo:      "repo1",
+				Number:    1,
+			},
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: userMapper,
+		Searcher:   searcher,
+	})
+
+	coord.PollAndReconcile(ctx)
+
+	// Daily reports should be checked
+}
+
+func TestCoordinator_checkUserDailyReport_NoMapping(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Global: config.GlobalConfig{
+			GuildID: "test-guild-123",
+		},
+	}
+
+	searcher := &mockPRSearcher{
+		openPRs: []PRSearchResult{
+			{
+				URL:       "https://github.com/testorg/repo1/pull/1",
+				UpdatedAt: time.Now(),
+				Owner:     "testorg",
+				Repo:      "repo1",
+				Number:    1,
+			},
+		},
+	}
+
+	// No user mapper - should skip
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: nil,
+		Searcher:   searcher,
+	})
+
+	coord.PollAndReconcile(ctx)
+
+	// Should handle gracefully when no user mapper
+}
+
+func TestCoordinator_checkUserDailyReport_UserNotInGuild(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	// Don't add bob to usersInGuild
+
+	store := state.NewMemoryStore()
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"bob": {Kind: "review"},
+			},
+		},
+	}
+
+	userMapper := newMockUserMapper()
+	userMapper.mappings["bob"] = "discord-bob"
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Global: config.GlobalConfig{
+			GuildID: "test-guild-123",
+		},
+	}
+
+	searcher := &mockPRSearcher{
+		openPRs: []PRSearchResult{
+			{
+				URL:       "https://github.com/testorg/repo1/pull/1",
+				UpdatedAt: time.Now(),
+				Owner:     "testorg",
+				Repo:      "repo1",
+				Number:    1,
+			},
+		},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: userMapper,
+		Searcher:   searcher,
+	})
+
+	coord.PollAndReconcile(ctx)
 
-	// Should queue DMs for both bob and charlie
+	// Should skip users not in guild
 }
 
-func TestCoordinator_checkDailyReports_WithGuildID(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_UserNotActive(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
 	discord.usersInGuild["discord-bob"] = true
-	discord.activeUsers["discord-bob"] = true
+	// Don't add bob to activeUsers
 
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
-
-	// Setup PR responses
 	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
 		PullRequest: PRInfo{
-			Title:  "Test PR 1",
-			Author: "bob",
+			Title:  "Test PR",
+			Author: "alice",
 			State:  "open",
 		},
 		Analysis: Analysis{
 			NextAction: map[string]Action{
-				"alice": {Kind: "review"},
+				"bob": {Kind: "review"},
 			},
-			WorkflowState: "awaiting_review",
 		},
 	}
 
 	userMapper := newMockUserMapper()
 	userMapper.mappings["bob"] = "discord-bob"
 
-	// Configure with guild ID
 	configMgr := newMockConfigManager()
 	configMgr.configs["testorg"] = &config.DiscordConfig{
 		Global: config.GlobalConfig{
@@ -3213,15 +5740,34 @@ func TestCoordinator_checkDailyReports_WithGuildID(t *testing.T) {
 
 	coord.PollAndReconcile(ctx)
 
-	// Daily reports should be checked
+	// Should skip inactive users
 }
 
-func TestCoordinator_checkUserDailyReport_NoMapping(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_WithIncomingPRs(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
+	discord.usersInGuild["discord-bob"] = true
+	discord.activeUsers["discord-bob"] = true
+
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"bob": {Kind: "review", Reason: "needs review"},
+			},
+			WorkflowState: "awaiting_review",
+		},
+	}
+
+	userMapper := newMockUserMapper()
+	userMapper.mappings["bob"] = "discord-bob"
 
 	configMgr := newMockConfigManager()
 	configMgr.configs["testorg"] = &config.DiscordConfig{
@@ -3242,27 +5788,27 @@ func TestCoordinator_checkUserDailyReport_NoMapping(t *testing.T) {
 		},
 	}
 
-	// No user mapper - should skip
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord:    discord,
 		Config:     configMgr,
 		Store:      store,
 		Turn:       turn,
 		Org:        "testorg",
-		UserMapper: nil,
+		UserMapper: userMapper,
 		Searcher:   searcher,
 	})
 
 	coord.PollAndReconcile(ctx)
 
-	// Should handle gracefully when no user mapper
+	// Should process incoming PRs for bob
 }
 
-func TestCoordinator_checkUserDailyReport_UserNotInGuild(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_WithOutgoingPRs(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
-	// Don't add bob to usersInGuild
+	discord.usersInGuild["discord-alice"] = true
+	discord.activeUsers["discord-alice"] = true
 
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
@@ -3276,11 +5822,12 @@ func TestCoordinator_checkUserDailyReport_UserNotInGuild(t *testing.T) {
 			NextAction: map[string]Action{
 				"bob": {Kind: "review"},
 			},
+			WorkflowState: "awaiting_review",
 		},
 	}
 
 	userMapper := newMockUserMapper()
-	userMapper.mappings["bob"] = "discord-bob"
+	userMapper.mappings["alice"] = "discord-alice"
 
 	configMgr := newMockConfigManager()
 	configMgr.configs["testorg"] = &config.DiscordConfig{
@@ -3313,15 +5860,15 @@ func TestCoordinator_checkUserDailyReport_UserNotInGuild(t *testing.T) {
 
 	coord.PollAndReconcile(ctx)
 
-	// Should skip users not in guild
+	// Should process outgoing PRs for alice
 }
 
-func TestCoordinator_checkUserDailyReport_UserNotActive(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_WithBlockedPRs(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
-	discord.usersInGuild["discord-bob"] = true
-	// Don't add bob to activeUsers
+	discord.usersInGuild["discord-alice"] = true
+	discord.activeUsers["discord-alice"] = true
 
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
@@ -3332,14 +5879,16 @@ func TestCoordinator_checkUserDailyReport_UserNotActive(t *testing.T) {
 			State:  "open",
 		},
 		Analysis: Analysis{
+			MergeConflict: true,
 			NextAction: map[string]Action{
-				"bob": {Kind: "review"},
+				"alice": {Kind: "resolve_conflict"},
 			},
+			WorkflowState: "blocked",
 		},
 	}
 
 	userMapper := newMockUserMapper()
-	userMapper.mappings["bob"] = "discord-bob"
+	userMapper.mappings["alice"] = "discord-alice"
 
 	configMgr := newMockConfigManager()
 	configMgr.configs["testorg"] = &config.DiscordConfig{
@@ -3372,10 +5921,10 @@ func TestCoordinator_checkUserDailyReport_UserNotActive(t *testing.T) {
 
 	coord.PollAndReconcile(ctx)
 
-	// Should skip inactive users
+	// Should flag blocked PRs
 }
 
-func TestCoordinator_checkUserDailyReport_WithIncomingPRs(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_TurnAPIError(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
@@ -3384,19 +5933,7 @@ func TestCoordinator_checkUserDailyReport_WithIncomingPRs(t *testing.T) {
 
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
-	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
-		Analysis: Analysis{
-			NextAction: map[string]Action{
-				"bob": {Kind: "review", Reason: "needs review"},
-			},
-			WorkflowState: "awaiting_review",
-		},
-	}
+	// Don't set response - will return error
 
 	userMapper := newMockUserMapper()
 	userMapper.mappings["bob"] = "discord-bob"
@@ -3432,34 +5969,21 @@ func TestCoordinator_checkUserDailyReport_WithIncomingPRs(t *testing.T) {
 
 	coord.PollAndReconcile(ctx)
 
-	// Should process incoming PRs for bob
+	// Should handle Turn API errors gracefully
 }
 
-func TestCoordinator_checkUserDailyReport_WithOutgoingPRs(t *testing.T) {
+func TestCoordinator_checkUserDailyReport_InvalidPRURL(t *testing.T) {
 	ctx := context.Background()
 
 	discord := newMockDiscordClient()
-	discord.usersInGuild["discord-alice"] = true
-	discord.activeUsers["discord-alice"] = true
+	discord.usersInGuild["discord-bob"] = true
+	discord.activeUsers["discord-bob"] = true
 
 	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
-	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
-		Analysis: Analysis{
-			NextAction: map[string]Action{
-				"bob": {Kind: "review"},
-			},
-			WorkflowState: "awaiting_review",
-		},
-	}
 
 	userMapper := newMockUserMapper()
-	userMapper.mappings["alice"] = "discord-alice"
+	userMapper.mappings["bob"] = "discord-bob"
 
 	configMgr := newMockConfigManager()
 	configMgr.configs["testorg"] = &config.DiscordConfig{
@@ -3471,11 +5995,8 @@ func TestCoordinator_checkUserDailyReport_WithOutgoingPRs(t *testing.T) {
 	searcher := &mockPRSearcher{
 		openPRs: []PRSearchResult{
 			{
-				URL:       "https://github.com/testorg/repo1/pull/1",
+				URL:       "https://invalid.com/not/a/pr",
 				UpdatedAt: time.Now(),
-				Owner:     "testorg",
-				Repo:      "repo1",
-				Number:    1,
 			},
 		},
 	}
@@ -3490,166 +6011,328 @@ func TestCoordinator_checkUserDailyReport_WithOutgoingPRs(t *testing.T) {
 		Searcher:   searcher,
 	})
 
-	coord.PollAndReconcile(ctx)
+	coord.PollAndReconcile(ctx)
+
+	// Should skip invalid PR URLs
+}
+
+// TestCoordinator_updateDMForClosedPR_DMDoesNotExist tests when no DM exists.
+func TestCoordinator_updateDMForClosedPR_DMDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// DM doesn't exist - should return early without any updates
+	coord.updateDMForClosedPR(ctx, "user123", "https://github.com/owner/repo/pull/1", format.StateMerged, "PR merged!")
+
+	// No DMs should have been updated
+	if len(discord.updatedDMs) != 0 {
+		t.Errorf("Expected no DM updates, got %d", len(discord.updatedDMs))
+	}
+}
+
+// TestCoordinator_updateDMForClosedPR_MissingChannelOrMessageID tests when DM info is incomplete.
+func TestCoordinator_updateDMForClosedPR_MissingChannelOrMessageID(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	// DM exists but missing channel ID
+	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
+		MessageID: "msg123",
+		// ChannelID missing
+	}); err != nil {
+		t.Fatalf("Failed to save DM info: %v", err)
+	}
+	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
+
+	// DM exists but missing message ID
+	if err := store.SaveDMInfo(ctx, "user456", prURL, state.DMInfo{
+		ChannelID: "dm456",
+		// MessageID missing
+	}); err != nil {
+		t.Fatalf("Failed to save DM info: %v", err)
+	}
+	coord.updateDMForClosedPR(ctx, "user456", prURL, format.StateMerged, "PR merged!")
+
+	// No DMs should have been updated
+	if len(discord.updatedDMs) != 0 {
+		t.Errorf("Expected no DM updates, got %d", len(discord.updatedDMs))
+	}
+}
+
+// TestCoordinator_updateDMForClosedPR_IdempotencyCheck tests that identical state updates are skipped.
+func TestCoordinator_updateDMForClosedPR_IdempotencyCheck(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	// DM exists with the same state already
+	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
+		ChannelID: "dm123",
+		MessageID: "msg123",
+		LastState: string(format.StateMerged),
+		SentAt:    time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to save DM info: %v", err)
+	}
+
+	// Try to update with same state - should be skipped (idempotency)
+	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
+
+	// No DMs should have been updated
+	if len(discord.updatedDMs) != 0 {
+		t.Errorf("Expected no DM updates (idempotent), got %d", len(discord.updatedDMs))
+	}
+}
+
+// TestCoordinator_updateDMForClosedPR_UpdateError tests handling of Discord API update errors.
+func TestCoordinator_updateDMForClosedPR_UpdateError(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	// DM exists with different state
+	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
+		ChannelID: "dm123",
+		MessageID: "msg123",
+		LastState: string(format.StateNeedsReview),
+		SentAt:    time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to save DM info: %v", err)
+	}
+
+	// Make Discord API fail
+	discord.shouldFailUpdateDM = true
 
-	// Should process outgoing PRs for alice
+	// Update should be attempted but fail gracefully
+	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
+
+	// Store should NOT be updated on Discord API failure
+	dmInfo, exists := store.DMInfo(ctx, "user123", prURL)
+	if !exists {
+		t.Fatal("DM info should still exist")
+	}
+	if dmInfo.LastState != string(format.StateNeedsReview) {
+		t.Errorf("State should remain %s, got %s", format.StateNeedsReview, dmInfo.LastState)
+	}
 }
 
-func TestCoordinator_checkUserDailyReport_WithBlockedPRs(t *testing.T) {
+// TestCoordinator_updateDMForClosedPR_Success tests successful DM update for closed PR.
+func TestCoordinator_updateDMForClosedPR_Success(t *testing.T) {
 	ctx := context.Background()
-
+	store := newMockStore()
 	discord := newMockDiscordClient()
-	discord.usersInGuild["discord-alice"] = true
-	discord.activeUsers["discord-alice"] = true
-
-	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
-	turn.responses["https://github.com/testorg/repo1/pull/1"] = &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
-		Analysis: Analysis{
-			MergeConflict: true,
-			NextAction: map[string]Action{
-				"alice": {Kind: "resolve_conflict"},
-			},
-			WorkflowState: "blocked",
-		},
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	// DM exists with different state
+	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
+		ChannelID:   "dm123",
+		MessageID:   "msg123",
+		LastState:   string(format.StateNeedsReview),
+		MessageText: "Old message",
+		SentAt:      time.Now().Add(-1 * time.Hour),
+	}); err != nil {
+		t.Fatalf("Failed to save DM info: %v", err)
 	}
 
-	userMapper := newMockUserMapper()
-	userMapper.mappings["alice"] = "discord-alice"
+	newMessage := "PR merged!"
 
-	configMgr := newMockConfigManager()
-	configMgr.configs["testorg"] = &config.DiscordConfig{
-		Global: config.GlobalConfig{
-			GuildID: "test-guild-123",
-		},
+	// Update should succeed
+	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, newMessage)
+
+	// Discord should have been called
+	if len(discord.updatedDMs) != 1 {
+		t.Fatalf("Expected 1 DM update, got %d", len(discord.updatedDMs))
+	}
+	if discord.updatedDMs[0].channelID != "dm123" {
+		t.Errorf("Channel ID = %s, want dm123", discord.updatedDMs[0].channelID)
+	}
+	if discord.updatedDMs[0].messageID != "msg123" {
+		t.Errorf("Message ID = %s, want msg123", discord.updatedDMs[0].messageID)
+	}
+	if discord.updatedDMs[0].text != newMessage {
+		t.Errorf("Content = %s, want %s", discord.updatedDMs[0].text, newMessage)
 	}
 
-	searcher := &mockPRSearcher{
-		openPRs: []PRSearchResult{
-			{
-				URL:       "https://github.com/testorg/repo1/pull/1",
-				UpdatedAt: time.Now(),
-				Owner:     "testorg",
-				Repo:      "repo1",
-				Number:    1,
-			},
-		},
+	// Store should be updated
+	dmInfo, exists := store.DMInfo(ctx, "user123", prURL)
+	if !exists {
+		t.Fatal("DM info should exist")
 	}
+	if dmInfo.LastState != string(format.StateMerged) {
+		t.Errorf("State = %s, want %s", dmInfo.LastState, format.StateMerged)
+	}
+	if dmInfo.MessageText != newMessage {
+		t.Errorf("MessageText = %s, want %s", dmInfo.MessageText, newMessage)
+	}
+}
+
+// TestCoordinator_cancelPendingDMsForPR_GetPendingDMsError tests error handling when fetching pending DMs.
+func TestCoordinator_cancelPendingDMsForPR_GetPendingDMsError(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
-		Searcher:   searcher,
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	coord.PollAndReconcile(ctx)
+	// Make store fail on PendingDMs
+	store.shouldFailPendingDMs = true
 
-	// Should flag blocked PRs
+	// Should handle error gracefully
+	coord.cancelPendingDMsForPR(ctx, "https://github.com/owner/repo/pull/1")
+
+	// No further operations should occur
 }
 
-func TestCoordinator_checkUserDailyReport_TurnAPIError(t *testing.T) {
+// TestCoordinator_cancelPendingDMsForPR_MatchingAndNonMatching tests canceling multiple DMs.
+func TestCoordinator_cancelPendingDMsForPR_MatchingAndNonMatching(t *testing.T) {
 	ctx := context.Background()
-
+	store := newMockStore()
 	discord := newMockDiscordClient()
-	discord.usersInGuild["discord-bob"] = true
-	discord.activeUsers["discord-bob"] = true
-
-	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
-	// Don't set response - will return error
-
-	userMapper := newMockUserMapper()
-	userMapper.mappings["bob"] = "discord-bob"
-
 	configMgr := newMockConfigManager()
-	configMgr.configs["testorg"] = &config.DiscordConfig{
-		Global: config.GlobalConfig{
-			GuildID: "test-guild-123",
-		},
-	}
-
-	searcher := &mockPRSearcher{
-		openPRs: []PRSearchResult{
-			{
-				URL:       "https://github.com/testorg/repo1/pull/1",
-				UpdatedAt: time.Now(),
-				Owner:     "testorg",
-				Repo:      "repo1",
-				Number:    1,
-			},
-		},
-	}
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
-		Searcher:   searcher,
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	coord.PollAndReconcile(ctx)
+	prURL := "https://github.com/owner/repo/pull/1"
+	otherPRURL := "https://github.com/owner/repo/pull/2"
 
-	// Should handle Turn API errors gracefully
+	// Add multiple pending DMs
+	store.pendingDMs = []*state.PendingDM{
+		{ID: "dm1", UserID: "user1", PRURL: prURL, SendAt: time.Now().Add(1 * time.Hour)},
+		{ID: "dm2", UserID: "user2", PRURL: otherPRURL, SendAt: time.Now().Add(1 * time.Hour)},
+		{ID: "dm3", UserID: "user3", PRURL: prURL, SendAt: time.Now().Add(2 * time.Hour)},
+	}
+
+	// Cancel DMs for prURL
+	coord.cancelPendingDMsForPR(ctx, prURL)
+
+	// Should have removed dm1 and dm3, but not dm2
+	if len(store.pendingDMs) != 1 {
+		t.Fatalf("Expected 1 pending DM remaining, got %d", len(store.pendingDMs))
+	}
+	if store.pendingDMs[0].ID != "dm2" {
+		t.Errorf("Wrong DM remained, ID = %s", store.pendingDMs[0].ID)
+	}
 }
 
-func TestCoordinator_checkUserDailyReport_InvalidPRURL(t *testing.T) {
+// TestCoordinator_cancelPendingDMsForPR_RemoveError tests handling of removal errors.
+func TestCoordinator_cancelPendingDMsForPR_RemoveError(t *testing.T) {
 	ctx := context.Background()
-
+	store := newMockStore()
 	discord := newMockDiscordClient()
-	discord.usersInGuild["discord-bob"] = true
-	discord.activeUsers["discord-bob"] = true
-
-	store := state.NewMemoryStore()
 	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
 
-	userMapper := newMockUserMapper()
-	userMapper.mappings["bob"] = "discord-bob"
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
 
-	configMgr := newMockConfigManager()
-	configMgr.configs["testorg"] = &config.DiscordConfig{
-		Global: config.GlobalConfig{
-			GuildID: "test-guild-123",
-		},
-	}
+	prURL := "https://github.com/owner/repo/pull/1"
 
-	searcher := &mockPRSearcher{
-		openPRs: []PRSearchResult{
-			{
-				URL:       "https://invalid.com/not/a/pr",
-				UpdatedAt: time.Now(),
-			},
-		},
+	// Add pending DMs
+	store.pendingDMs = []*state.PendingDM{
+		{ID: "dm1", UserID: "user1", PRURL: prURL, SendAt: time.Now().Add(1 * time.Hour)},
+		{ID: "dm2", UserID: "user2", PRURL: prURL, SendAt: time.Now().Add(2 * time.Hour)},
 	}
 
-	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
-		Searcher:   searcher,
-	})
+	// Make RemovePendingDM fail for first DM
+	store.failRemoveDMID = "dm1"
 
-	coord.PollAndReconcile(ctx)
+	// Should continue processing despite error
+	coord.cancelPendingDMsForPR(ctx, prURL)
 
-	// Should skip invalid PR URLs
+	// dm1 should remain (failed to remove), dm2 should be removed
+	remainingCount := 0
+	for _, dm := range store.pendingDMs {
+		if dm.PRURL == prURL {
+			remainingCount++
+		}
+	}
+
+	if remainingCount != 1 {
+		t.Errorf("Expected 1 DM to remain (failed removal), got %d", remainingCount)
+	}
 }
 
-// TestCoordinator_updateDMForClosedPR_DMDoesNotExist tests when no DM exists.
-func TestCoordinator_updateDMForClosedPR_DMDoesNotExist(t *testing.T) {
+// TestCoordinator_processTextChannel_ClaimFailedFindMessage tests cross-instance race where claim fails.
+func TestCoordinator_processTextChannel_ClaimFailedFindMessage(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -3664,17 +6347,58 @@ func TestCoordinator_updateDMForClosedPR_DMDoesNotExist(t *testing.T) {
 		Org:     "testorg",
 	})
 
-	// DM doesn't exist - should return early without any updates
-	coord.updateDMForClosedPR(ctx, "user123", "https://github.com/owner/repo/pull/1", format.StateMerged, "PR merged!")
+	prURL := "https://github.com/owner/repo/pull/1"
+	channelID := "channel1"
 
-	// No DMs should have been updated
-	if len(discord.updatedDMs) != 0 {
-		t.Errorf("Expected no DM updates, got %d", len(discord.updatedDMs))
+	// Make ClaimThread fail (another instance claimed it)
+	store.claimThreadShouldFail = true
+
+	// Add a message that the other instance created
+	discord.channelMessages[channelID] = map[string]string{
+		"msg_from_other_instance": "PR #1 content from other instance",
+	}
+
+	params := format.ChannelMessageParams{
+		PRURL:       prURL,
+		Number:      1,
+		State:       format.StateNeedsReview,
+		ChannelName: "test-channel",
+	}
+
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title: "Test PR",
+			State: "open",
+		},
+	}
+
+	// Should find the message from other instance
+	err := coord.processTextChannel(ctx, &channelProcessParams{
+		channelID:  channelID,
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		params:     params,
+		checkResp:  checkResp,
+		threadInfo: state.ThreadInfo{},
+		exists:     false,
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Should have saved thread info for the found message
+	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
+	if !exists {
+		t.Error("Thread info should be saved after finding message")
+	}
+	if threadInfo.MessageID != "msg_from_other_instance" {
+		t.Errorf("Message ID = %s, want msg_from_other_instance", threadInfo.MessageID)
 	}
 }
 
-// TestCoordinator_updateDMForClosedPR_MissingChannelOrMessageID tests when DM info is incomplete.
-func TestCoordinator_updateDMForClosedPR_MissingChannelOrMessageID(t *testing.T) {
+// TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent tests successful claim with existing message.
+func TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -3690,33 +6414,62 @@ func TestCoordinator_updateDMForClosedPR_MissingChannelOrMessageID(t *testing.T)
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
+	channelID := "channel1"
 
-	// DM exists but missing channel ID
-	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
-		MessageID: "msg123",
-		// ChannelID missing
-	}); err != nil {
-		t.Fatalf("Failed to save DM info: %v", err)
+	params := format.ChannelMessageParams{
+		PRURL:       prURL,
+		Number:      1,
+		State:       format.StateNeedsReview,
+		ChannelName: "test-channel",
+		Title:       "Test PR",
 	}
-	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
 
-	// DM exists but missing message ID
-	if err := store.SaveDMInfo(ctx, "user456", prURL, state.DMInfo{
-		ChannelID: "dm456",
-		// MessageID missing
-	}); err != nil {
-		t.Fatalf("Failed to save DM info: %v", err)
+	expectedContent := format.ChannelMessage(params)
+
+	// Add existing message with same content
+	discord.channelMessages[channelID] = map[string]string{
+		"existing_msg": expectedContent,
 	}
-	coord.updateDMForClosedPR(ctx, "user456", prURL, format.StateMerged, "PR merged!")
 
-	// No DMs should have been updated
-	if len(discord.updatedDMs) != 0 {
-		t.Errorf("Expected no DM updates, got %d", len(discord.updatedDMs))
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title: "Test PR",
+			State: "open",
+		},
+	}
+
+	// Should find existing message and skip creation
+	err := coord.processTextChannel(ctx, &channelProcessParams{
+		channelID:  channelID,
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		params:     params,
+		checkResp:  checkResp,
+		threadInfo: state.ThreadInfo{},
+		exists:     false,
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Should not have posted a new message
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("Should not post new message when identical one exists, got %d posts", len(discord.postedMessages))
+	}
+
+	// Should have saved thread info
+	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
+	if !exists {
+		t.Error("Thread info should be saved")
+	}
+	if threadInfo.MessageID != "existing_msg" {
+		t.Errorf("Message ID = %s, want existing_msg", threadInfo.MessageID)
 	}
 }
 
-// TestCoordinator_updateDMForClosedPR_IdempotencyCheck tests that identical state updates are skipped.
-func TestCoordinator_updateDMForClosedPR_IdempotencyCheck(t *testing.T) {
+// TestCoordinator_processTextChannel_UpdateFailsFallsThrough tests when cache update fails and falls through to search.
+func TestCoordinator_processTextChannel_UpdateFailsFallsThrough(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -3732,33 +6485,82 @@ func TestCoordinator_updateDMForClosedPR_IdempotencyCheck(t *testing.T) {
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
+	channelID := "channel1"
 
-	// DM exists with the same state already
-	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
-		ChannelID: "dm123",
-		MessageID: "msg123",
-		LastState: string(format.StateMerged),
-		SentAt:    time.Now(),
-	}); err != nil {
-		t.Fatalf("Failed to save DM info: %v", err)
+	params := format.ChannelMessageParams{
+		PRURL:       prURL,
+		Number:      1,
+		State:       format.StateNeedsReview,
+		ChannelName: "test-channel",
+		Title:       "Updated PR Title",
 	}
 
-	// Try to update with same state - should be skipped (idempotency)
-	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
+	// Existing thread in cache with old content
+	oldThreadInfo := state.ThreadInfo{
+		MessageID:   "old_msg",
+		ChannelID:   channelID,
+		MessageText: "old content",
+		LastState:   string(format.StateNeedsReview),
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, channelID, oldThreadInfo); err != nil {
+		t.Fatalf("Failed to save thread: %v", err)
+	}
 
-	// No DMs should have been updated
-	if len(discord.updatedDMs) != 0 {
-		t.Errorf("Expected no DM updates (idempotent), got %d", len(discord.updatedDMs))
+	// Make UpdateMessage fail
+	discord.shouldFailUpdate = true
+
+	// Add an existing message in the channel that will be found by search
+	newContent := format.ChannelMessage(params)
+	discord.channelMessages[channelID] = map[string]string{
+		"found_msg": newContent,
+	}
+
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title: "Updated PR Title",
+			State: "open",
+		},
+	}
+
+	// Should try to update cached message, fail, then search and find the message
+	err := coord.processTextChannel(ctx, &channelProcessParams{
+		channelID:  channelID,
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		params:     params,
+		checkResp:  checkResp,
+		threadInfo: oldThreadInfo,
+		exists:     true,
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Should have attempted 1 update (which failed)
+	if len(discord.updatedMessages) != 1 {
+		t.Errorf("Expected 1 update attempt, got %d", len(discord.updatedMessages))
+	}
+
+	// Should have found the message and updated thread info
+	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
+	if !exists {
+		t.Error("Thread info should exist")
+	}
+	if threadInfo.MessageID != "found_msg" {
+		t.Errorf("Message ID = %s, want found_msg (from search fallback)", threadInfo.MessageID)
 	}
 }
 
-// TestCoordinator_updateDMForClosedPR_UpdateError tests handling of Discord API update errors.
-func TestCoordinator_updateDMForClosedPR_UpdateError(t *testing.T) {
+// TestCoordinator_processTextChannel_AdoptsLostMessage tests that a channel message the store
+// lost track of is adopted rather than duplicated, even when the "when" threshold isn't met.
+func TestCoordinator_processTextChannel_AdoptsLostMessage(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
+	configMgr.whenSettings["owner:test-channel"] = "passing"
 
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
@@ -3768,160 +6570,264 @@ func TestCoordinator_updateDMForClosedPR_UpdateError(t *testing.T) {
 		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
+	channelID := "channel1"
+	discord.channelMessages[channelID] = map[string]string{
+		"posted_before_reset": "PR #1 old content",
+	}
 
-	// DM exists with different state
-	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
-		ChannelID: "dm123",
-		MessageID: "msg123",
-		LastState: string(format.StateNeedsReview),
-		SentAt:    time.Now(),
-	}); err != nil {
-		t.Fatalf("Failed to save DM info: %v", err)
+	params := format.ChannelMessageParams{
+		PRURL:       "https://github.com/owner/repo/pull/1",
+		Number:      1,
+		State:       format.StateTestsBroken,
+		ChannelName: "test-channel",
+		Title:       "Test PR",
+	}
+	err := coord.processTextChannel(ctx, &channelProcessParams{
+		channelID: channelID,
+		owner:     "owner",
+		repo:      "repo",
+		number:    1,
+		params:    params,
+		checkResp: &CheckResponse{
+			PullRequest: PRInfo{Title: "Test PR", State: "open"},
+			Analysis:    Analysis{Checks: Checks{Failing: 1}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("processTextChannel() error = %v", err)
 	}
 
-	// Make Discord API fail
-	discord.shouldFailUpdateDM = true
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("postedMessages = %d, want 0 (existing message adopted)", len(discord.postedMessages))
+	}
+	if len(discord.updatedMessages) != 1 || discord.updatedMessages[0].messageID != "posted_before_reset" {
+		t.Errorf("updatedMessages = %+v, want one edit of the adopted message", discord.updatedMessages)
+	}
+	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
+	if !exists || threadInfo.MessageID != "posted_before_reset" {
+		t.Errorf("Thread() = %+v, %v; want adopted message ID saved", threadInfo, exists)
+	}
+}
 
-	// Update should be attempted but fail gracefully
-	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, "PR merged!")
+func TestCoordinator_processTextChannel_SearchesLostMessageOnce(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	configMgr := newMockConfigManager()
+	configMgr.whenSettings["owner:test-channel"] = "passing" // Keeps the PR from being posted
 
-	// Store should NOT be updated on Discord API failure
-	dmInfo, exists := store.DMInfo(ctx, "user123", prURL)
-	if !exists {
-		t.Fatal("DM info should still exist")
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   newMockStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	for range 3 {
+		err := coord.processTextChannel(ctx, &channelProcessParams{
+			channelID: "channel1",
+			owner:     "owner",
+			repo:      "repo",
+			number:    1,
+			params: format.ChannelMessageParams{
+				PRURL:       "https://github.com/owner/repo/pull/1",
+				Number:      1,
+				State:       format.StateTestsBroken,
+				ChannelName: "test-channel",
+				Title:       "Test PR",
+			},
+			checkResp: &CheckResponse{
+				PullRequest: PRInfo{Title: "Test PR", State: "open"},
+				Analysis:    Analysis{Checks: Checks{Failing: 1}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("processTextChannel() error = %v", err)
+		}
 	}
-	if dmInfo.LastState != string(format.StateNeedsReview) {
-		t.Errorf("State should remain %s, got %s", format.StateNeedsReview, dmInfo.LastState)
+
+	// Only the PR's first event scans the channel; the miss is remembered
+	if discord.findMessageCalls != 1 {
+		t.Errorf("FindChannelMessage calls = %d, want 1", discord.findMessageCalls)
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("postedMessages = %d, want 0 (threshold not met)", len(discord.postedMessages))
 	}
 }
 
-// TestCoordinator_updateDMForClosedPR_Success tests successful DM update for closed PR.
-func TestCoordinator_updateDMForClosedPR_Success(t *testing.T) {
+// TestCoordinator_processDMForUser_ClaimFailed tests when another instance claims the DM.
+func TestCoordinator_processDMForUser_ClaimFailed(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
+	userMapper := newMockUserMapper()
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: userMapper,
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
 
-	// DM exists with different state
-	if err := store.SaveDMInfo(ctx, "user123", prURL, state.DMInfo{
-		ChannelID:   "dm123",
-		MessageID:   "msg123",
-		LastState:   string(format.StateNeedsReview),
-		MessageText: "Old message",
-		SentAt:      time.Now().Add(-1 * time.Hour),
-	}); err != nil {
-		t.Fatalf("Failed to save DM info: %v", err)
-	}
+	// Set up user mapping
+	userMapper.mappings["alice"] = "discord123"
+	discord.usersInGuild["discord123"] = true
 
-	newMessage := "PR merged!"
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+	}
 
-	// Update should succeed
-	coord.updateDMForClosedPR(ctx, "user123", prURL, format.StateMerged, newMessage)
+	// Make ClaimDM fail (another instance claimed it)
+	store.shouldFailClaimDM = true
 
-	// Discord should have been called
-	if len(discord.updatedDMs) != 1 {
-		t.Fatalf("Expected 1 DM update, got %d", len(discord.updatedDMs))
-	}
-	if discord.updatedDMs[0].channelID != "dm123" {
-		t.Errorf("Channel ID = %s, want dm123", discord.updatedDMs[0].channelID)
-	}
-	if discord.updatedDMs[0].messageID != "msg123" {
-		t.Errorf("Message ID = %s, want msg123", discord.updatedDMs[0].messageID)
-	}
-	if discord.updatedDMs[0].text != newMessage {
-		t.Errorf("Content = %s, want %s", discord.updatedDMs[0].text, newMessage)
-	}
+	// Process DM - should not send since another instance claimed it
+	coord.processDMForUser(ctx, dmProcessParams{
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		checkResp:  checkResp,
+		prState:    format.StateNeedsReview,
+		prURL:      prURL,
+		username:   "alice",
+		actionKind: "review",
+	})
 
-	// Store should be updated
-	dmInfo, exists := store.DMInfo(ctx, "user123", prURL)
-	if !exists {
-		t.Fatal("DM info should exist")
-	}
-	if dmInfo.LastState != string(format.StateMerged) {
-		t.Errorf("State = %s, want %s", dmInfo.LastState, format.StateMerged)
-	}
-	if dmInfo.MessageText != newMessage {
-		t.Errorf("MessageText = %s, want %s", dmInfo.MessageText, newMessage)
+	// Should not have sent any DMs (another instance has it)
+	if len(discord.sentDMs) > 0 {
+		t.Errorf("Should not send DM when another instance claimed it, got %d", len(discord.sentDMs))
 	}
 }
 
-// TestCoordinator_cancelPendingDMsForPR_GetPendingDMsError tests error handling when fetching pending DMs.
-func TestCoordinator_cancelPendingDMsForPR_GetPendingDMsError(t *testing.T) {
+// TestCoordinator_processDMForUser_PendingDMUpdate tests updating a pending DM.
+func TestCoordinator_processDMForUser_PendingDMUpdate(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
+	userMapper := newMockUserMapper()
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: userMapper,
 	})
 
-	// Make store fail on PendingDMs
-	store.shouldFailPendingDMs = true
+	prURL := "https://github.com/owner/repo/pull/1"
 
-	// Should handle error gracefully
-	coord.cancelPendingDMsForPR(ctx, "https://github.com/owner/repo/pull/1")
+	// Set up user mapping
+	userMapper.mappings["alice"] = "discord123"
+	discord.usersInGuild["discord123"] = true
 
-	// No further operations should occur
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
+	}
+
+	// Add existing pending DM with different message
+	store.pendingDMs = []*state.PendingDM{
+		{
+			ID:          "pending1",
+			UserID:      "discord123",
+			PRURL:       prURL,
+			MessageText: "old message text",
+			SendAt:      time.Now().Add(1 * time.Hour),
+		},
+	}
+
+	// Process DM with new state - should update pending DM
+	coord.processDMForUser(ctx, dmProcessParams{
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		checkResp:  checkResp,
+		prState:    format.StateNeedsReview,
+		prURL:      prURL,
+		username:   "alice",
+		actionKind: "review",
+	})
+
+	// Old pending DM should be removed (it gets removed and a new one queued)
+	// Since we're testing the removal logic
+	if len(store.pendingDMs) > 1 {
+		t.Errorf("Should have removed old pending DM")
+	}
 }
 
-// TestCoordinator_cancelPendingDMsForPR_MatchingAndNonMatching tests canceling multiple DMs.
-func TestCoordinator_cancelPendingDMsForPR_MatchingAndNonMatching(t *testing.T) {
+// TestCoordinator_processDMForUser_FindDMFallback tests finding existing DM from history.
+func TestCoordinator_processDMForUser_FindDMFallback(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
+	userMapper := newMockUserMapper()
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		Org:        "testorg",
+		UserMapper: userMapper,
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
-	otherPRURL := "https://github.com/owner/repo/pull/2"
 
-	// Add multiple pending DMs
-	store.pendingDMs = []*state.PendingDM{
-		{ID: "dm1", UserID: "user1", PRURL: prURL, SendAt: time.Now().Add(1 * time.Hour)},
-		{ID: "dm2", UserID: "user2", PRURL: otherPRURL, SendAt: time.Now().Add(1 * time.Hour)},
-		{ID: "dm3", UserID: "user3", PRURL: prURL, SendAt: time.Now().Add(2 * time.Hour)},
-	}
+	// Set up user mapping
+	userMapper.mappings["alice"] = "discord123"
+	discord.usersInGuild["discord123"] = true
 
-	// Cancel DMs for prURL
-	coord.cancelPendingDMsForPR(ctx, prURL)
+	// DM exists in Discord history but not in store
+	discord.existingDMs["discord123:"+prURL] = existingDM{
+		channelID: "dm-chan",
+		messageID: "dm-msg",
+	}
 
-	// Should have removed dm1 and dm3, but not dm2
-	if len(store.pendingDMs) != 1 {
-		t.Fatalf("Expected 1 pending DM remaining, got %d", len(store.pendingDMs))
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
+		},
 	}
-	if store.pendingDMs[0].ID != "dm2" {
-		t.Errorf("Wrong DM remained, ID = %s", store.pendingDMs[0].ID)
+
+	// Process DM - should find existing DM and update it
+	coord.processDMForUser(ctx, dmProcessParams{
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		checkResp:  checkResp,
+		prState:    format.StateNeedsReview,
+		prURL:      prURL,
+		username:   "alice",
+		actionKind: "review",
+	})
+
+	// Should have updated existing DM
+	if len(discord.updatedDMs) == 0 {
+		t.Error("Should have updated existing DM found in history")
 	}
 }
 
-// TestCoordinator_cancelPendingDMsForPR_RemoveError tests handling of removal errors.
-func TestCoordinator_cancelPendingDMsForPR_RemoveError(t *testing.T) {
+// TestCoordinator_processForumChannel_Archive tests archiving merged/closed PRs.
+func TestCoordinator_processForumChannel_Archive(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -3937,34 +6843,64 @@ func TestCoordinator_cancelPendingDMsForPR_RemoveError(t *testing.T) {
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
+	channelID := "forum1"
 
-	// Add pending DMs
-	store.pendingDMs = []*state.PendingDM{
-		{ID: "dm1", UserID: "user1", PRURL: prURL, SendAt: time.Now().Add(1 * time.Hour)},
-		{ID: "dm2", UserID: "user2", PRURL: prURL, SendAt: time.Now().Add(2 * time.Hour)},
+	params := format.ChannelMessageParams{
+		PRURL:       prURL,
+		Number:      1,
+		State:       format.StateMerged,
+		ChannelName: "test-forum",
+		Title:       "Test PR",
+		Repo:        "repo",
 	}
 
-	// Make RemovePendingDM fail for first DM
-	store.failRemoveDMID = "dm1"
+	// Existing thread with different content
+	existingThread := state.ThreadInfo{
+		ThreadID:    "thread123",
+		MessageID:   "msg123",
+		ChannelID:   channelID,
+		ChannelType: "forum",
+		MessageText: "old content",
+		LastState:   string(format.StateNeedsReview),
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, channelID, existingThread); err != nil {
+		t.Fatalf("Failed to save thread: %v", err)
+	}
 
-	// Should continue processing despite error
-	coord.cancelPendingDMsForPR(ctx, prURL)
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{
+			Title:  "Test PR",
+			State:  "closed",
+			Merged: true,
+		},
+	}
 
-	// dm1 should remain (failed to remove), dm2 should be removed
-	remainingCount := 0
-	for _, dm := range store.pendingDMs {
-		if dm.PRURL == prURL {
-			remainingCount++
-		}
+	// Process forum channel with merged PR
+	err := coord.processForumChannel(ctx, &channelProcessParams{
+		channelID:  channelID,
+		owner:      "owner",
+		repo:       "repo",
+		number:     1,
+		params:     params,
+		checkResp:  checkResp,
+		threadInfo: existingThread,
+		exists:     true,
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
 
-	if remainingCount != 1 {
-		t.Errorf("Expected 1 DM to remain (failed removal), got %d", remainingCount)
+	// Should have archived the thread
+	if len(discord.archivedThreads) != 1 {
+		t.Errorf("Expected 1 archived thread, got %d", len(discord.archivedThreads))
+	}
+	if discord.archivedThreads[0] != "thread123" {
+		t.Errorf("Archived thread ID = %s, want thread123", discord.archivedThreads[0])
 	}
 }
 
-// TestCoordinator_processTextChannel_ClaimFailedFindMessage tests cross-instance race where claim fails.
-func TestCoordinator_processTextChannel_ClaimFailedFindMessage(t *testing.T) {
+// TestCoordinator_processForumChannel_ClaimFailed tests cross-instance race.
+func TestCoordinator_processForumChannel_ClaimFailed(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -3980,21 +6916,24 @@ func TestCoordinator_processTextChannel_ClaimFailedFindMessage(t *testing.T) {
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "channel1"
+	channelID := "forum1"
 
-	// Make ClaimThread fail (another instance claimed it)
+	// Make ClaimThread fail
 	store.claimThreadShouldFail = true
 
-	// Add a message that the other instance created
-	discord.channelMessages[channelID] = map[string]string{
-		"msg_from_other_instance": "PR #1 content from other instance",
+	// Set up found thread from other instance
+	discord.foundForumThreads[channelID+":"+prURL] = foundThread{
+		threadID:  "thread-other",
+		messageID: "msg-other",
 	}
 
 	params := format.ChannelMessageParams{
 		PRURL:       prURL,
 		Number:      1,
 		State:       format.StateNeedsReview,
-		ChannelName: "test-channel",
+		ChannelName: "test-forum",
+		Title:       "Test PR",
+		Repo:        "repo",
 	}
 
 	checkResp := &CheckResponse{
@@ -4004,8 +6943,8 @@ func TestCoordinator_processTextChannel_ClaimFailedFindMessage(t *testing.T) {
 		},
 	}
 
-	// Should find the message from other instance
-	err := coord.processTextChannel(ctx, &channelProcessParams{
+	// Process forum channel
+	err := coord.processForumChannel(ctx, &channelProcessParams{
 		channelID:  channelID,
 		owner:      "owner",
 		repo:       "repo",
@@ -4019,24 +6958,27 @@ func TestCoordinator_processTextChannel_ClaimFailedFindMessage(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	// Should have saved thread info for the found message
+	// Should have found and updated thread from other instance
 	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
 	if !exists {
-		t.Error("Thread info should be saved after finding message")
+		t.Error("Thread info should be saved after finding thread")
 	}
-	if threadInfo.MessageID != "msg_from_other_instance" {
-		t.Errorf("Message ID = %s, want msg_from_other_instance", threadInfo.MessageID)
+	if threadInfo.ThreadID != "thread-other" {
+		t.Errorf("Thread ID = %s, want thread-other", threadInfo.ThreadID)
 	}
 }
 
-// TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent tests successful claim with existing message.
-func TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent(t *testing.T) {
+// TestCoordinator_processForumChannel_WhenThreshold tests "when" threshold logic.
+func TestCoordinator_processForumChannel_WhenThreshold(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
 
+	// Set "when" to "passing"
+	configMgr.whenSettings["testorg:test-forum"] = "passing"
+
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
 		Config:  configMgr,
@@ -4046,21 +6988,15 @@ func TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent(t *
 	})
 
 	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "channel1"
+	channelID := "forum1"
 
 	params := format.ChannelMessageParams{
 		PRURL:       prURL,
 		Number:      1,
-		State:       format.StateNeedsReview,
-		ChannelName: "test-channel",
+		State:       format.StateTestsBroken,
+		ChannelName: "test-forum",
 		Title:       "Test PR",
-	}
-
-	expectedContent := format.ChannelMessage(params)
-
-	// Add existing message with same content
-	discord.channelMessages[channelID] = map[string]string{
-		"existing_msg": expectedContent,
+		Repo:        "repo",
 	}
 
 	checkResp := &CheckResponse{
@@ -4068,12 +7004,17 @@ func TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent(t *
 			Title: "Test PR",
 			State: "open",
 		},
+		Analysis: Analysis{
+			Checks: Checks{
+				Failing: 3, // Tests failing
+			},
+		},
 	}
 
-	// Should find existing message and skip creation
-	err := coord.processTextChannel(ctx, &channelProcessParams{
+	// Process forum channel - should not create thread yet
+	err := coord.processForumChannel(ctx, &channelProcessParams{
 		channelID:  channelID,
-		owner:      "owner",
+		owner:      "testorg",
 		repo:       "repo",
 		number:     1,
 		params:     params,
@@ -4085,23 +7026,47 @@ func TestCoordinator_processTextChannel_ClaimSucceededSearchFindsSameContent(t *
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	// Should not have posted a new message
-	if len(discord.postedMessages) != 0 {
-		t.Errorf("Should not post new message when identical one exists, got %d posts", len(discord.postedMessages))
+	// Should not have posted (threshold not met)
+	if len(discord.forumThreads) != 0 {
+		t.Errorf("Should not create forum thread when threshold not met, got %d", len(discord.forumThreads))
 	}
+}
 
-	// Should have saved thread info
-	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
-	if !exists {
-		t.Error("Thread info should be saved")
+// TestCoordinator_processEventSync_ConfigRepo tests skipping .codeGROOVE repo.
+// Note: .codeGROOVE starts with a dot and fails ParsePRURL validation,
+// so this tests the error handling path.
+func TestCoordinator_processEventSync_ConfigRepo(t *testing.T) {
+	t.Skip("ParsePRURL doesn't accept repo names starting with dot - this is a known limitation")
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/.codeGROOVE/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
 	}
-	if threadInfo.MessageID != "existing_msg" {
-		t.Errorf("Message ID = %s, want existing_msg", threadInfo.MessageID)
+
+	// Process event
+	err := coord.processEventSync(ctx, event)
+	if err == nil {
+		t.Error("Expected error for invalid PR URL")
 	}
 }
 
-// TestCoordinator_processTextChannel_UpdateFailsFallsThrough tests when cache update fails and falls through to search.
-func TestCoordinator_processTextChannel_UpdateFailsFallsThrough(t *testing.T) {
+// TestCoordinator_processEventSync_AlreadyProcessed tests event deduplication.
+func TestCoordinator_processEventSync_AlreadyProcessed(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
@@ -4116,326 +7081,408 @@ func TestCoordinator_processTextChannel_UpdateFailsFallsThrough(t *testing.T) {
 		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "channel1"
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
+	}
 
-	params := format.ChannelMessageParams{
-		PRURL:       prURL,
-		Number:      1,
-		State:       format.StateNeedsReview,
-		ChannelName: "test-channel",
-		Title:       "Updated PR Title",
+	// Mark event as already processed
+	eventKey := EventKeyPrefix(event.URL) + event.DeliveryID
+	if err := store.MarkProcessed(ctx, eventKey, 5*time.Minute); err != nil {
+		t.Fatalf("Failed to mark processed: %v", err)
 	}
 
-	// Existing thread in cache with old content
-	oldThreadInfo := state.ThreadInfo{
-		MessageID:   "old_msg",
-		ChannelID:   channelID,
-		MessageText: "old content",
-		LastState:   string(format.StateNeedsReview),
+	// Process event
+	err := coord.processEventSync(ctx, event)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
-	if err := store.SaveThread(ctx, "owner", "repo", 1, channelID, oldThreadInfo); err != nil {
-		t.Fatalf("Failed to save thread: %v", err)
+
+	// Should not have called Turn API or posted messages
+	if turn.callCount > 0 {
+		t.Errorf("Should not call Turn API for already processed event, got %d calls", turn.callCount)
 	}
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("Should not post messages for already processed event, got %d", len(discord.postedMessages))
+	}
+}
 
-	// Make UpdateMessage fail
-	discord.shouldFailUpdate = true
+// TestCoordinator_processEventSync_NoChannels tests handling when no channels configured.
+func TestCoordinator_processEventSync_NoChannels(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
 
-	// Add an existing message in the channel that will be found by search
-	newContent := format.ChannelMessage(params)
-	discord.channelMessages[channelID] = map[string]string{
-		"found_msg": newContent,
-	}
+	// Don't configure any channels
+	configMgr.channels = make(map[string][]string)
 
-	checkResp := &CheckResponse{
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	turn.responses["https://github.com/testorg/repo/pull/1"] = &CheckResponse{
 		PullRequest: PRInfo{
-			Title: "Updated PR Title",
-			State: "open",
+			Title:  "Test PR",
+			Author: "alice",
+			State:  "open",
 		},
 	}
 
-	// Should try to update cached message, fail, then search and find the message
-	err := coord.processTextChannel(ctx, &channelProcessParams{
-		channelID:  channelID,
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		params:     params,
-		checkResp:  checkResp,
-		threadInfo: oldThreadInfo,
-		exists:     true,
-	})
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
+	}
+
+	// Process event
+	err := coord.processEventSync(ctx, event)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	// Should have attempted 1 update (which failed)
-	if len(discord.updatedMessages) != 1 {
-		t.Errorf("Expected 1 update attempt, got %d", len(discord.updatedMessages))
+	// Should not have posted any messages (no channels)
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("Should not post when no channels configured, got %d", len(discord.postedMessages))
 	}
+}
 
-	// Should have found the message and updated thread info
-	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
-	if !exists {
-		t.Error("Thread info should exist")
+// TestCoordinator_processEventSync_TurnAPIFailure tests handling Turn API failures gracefully.
+func TestCoordinator_processEventSync_TurnAPIFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	discord.channelIDs["repo"] = "chan-repo"
+	discord.botInChannel["chan-repo"] = true
+	configMgr.channels["testorg:repo"] = []string{"repo"}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// Make Turn API fail
+	turn.shouldFail = true
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
 	}
-	if threadInfo.MessageID != "found_msg" {
-		t.Errorf("Message ID = %s, want found_msg (from search fallback)", threadInfo.MessageID)
+
+	// Process event - should handle gracefully and continue
+	err := coord.processEventSync(ctx, event)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Should still mark as processed even though Turn failed
+	eventKey := EventKeyPrefix(event.URL) + event.DeliveryID
+	if !store.WasProcessed(ctx, eventKey) {
+		t.Error("Event should be marked as processed even after Turn API failure")
 	}
 }
 
-// TestCoordinator_processDMForUser_ClaimFailed tests when another instance claims the DM.
-func TestCoordinator_processDMForUser_ClaimFailed(t *testing.T) {
+func TestCoordinator_ProcessEvent_TurnUnavailableMessage(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
+	discord.channelIDs["repo"] = "chan-repo"
+	discord.botInChannel["chan-repo"] = true
+
+	prURL := "https://github.com/testorg/repo/pull/1"
 	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
-	userMapper := newMockUserMapper()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fix bug", Author: "alice", State: "open"},
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-
-	// Set up user mapping
-	userMapper.mappings["alice"] = "discord123"
-	discord.usersInGuild["discord123"] = true
-
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "Fix bug") || strings.Contains(text, "details unavailable") {
+		t.Errorf("message = %q, want the normal rendering", text)
 	}
 
-	// Make ClaimDM fail (another instance claimed it)
-	store.shouldFailClaimDM = true
-
-	// Process DM - should not send since another instance claimed it
-	coord.processDMForUser(ctx, dmProcessParams{
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		checkResp:  checkResp,
-		prState:    format.StateNeedsReview,
-		prURL:      prURL,
-		username:   "alice",
-		actionKind: "review",
-	})
-
-	// Should not have sent any DMs (another instance has it)
-	if len(discord.sentDMs) > 0 {
-		t.Errorf("Should not send DM when another instance claimed it, got %d", len(discord.sentDMs))
+	turn.shouldFail = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+	}
+	text := discord.updatedMessages[0].text
+	if !strings.Contains(text, "details unavailable, see GitHub") || !strings.Contains(text, prURL) {
+		t.Errorf("message after a failed Turn call = %q, want the unavailable note and PR link", text)
 	}
 }
 
-// TestCoordinator_processDMForUser_PendingDMUpdate tests updating a pending DM.
-func TestCoordinator_processDMForUser_PendingDMUpdate(t *testing.T) {
+func TestCoordinator_ProcessEvent_ReprocessedAfterClearProcessed(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
+	discord.channelIDs["repo"] = "chan-repo"
+	discord.botInChannel["chan-repo"] = true
+
+	prURL := "https://github.com/testorg/repo/pull/1"
 	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
-	userMapper := newMockUserMapper()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fix bug", Author: "alice", State: "open"},
+	}
 
+	store := state.NewMemoryStore()
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
+	event := SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1", Timestamp: time.Now()}
+	for range 2 {
+		if err := coord.processEventSync(ctx, event); err != nil {
+			t.Fatalf("processEventSync() error = %v", err)
+		}
+	}
+	if turn.callCount != 1 {
+		t.Fatalf("turn calls = %d, want 1 (redelivery deduplicated)", turn.callCount)
+	}
 
-	// Set up user mapping
-	userMapper.mappings["alice"] = "discord123"
-	discord.usersInGuild["discord123"] = true
+	cleared, err := store.ClearProcessed(ctx, EventKeyPrefix(prURL))
+	if err != nil {
+		t.Fatalf("ClearProcessed() error = %v", err)
+	}
+	if cleared != 1 {
+		t.Errorf("ClearProcessed() = %d, want 1", cleared)
+	}
 
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
+	if err := coord.processEventSync(ctx, event); err != nil {
+		t.Fatalf("processEventSync() error = %v", err)
+	}
+	if turn.callCount != 2 {
+		t.Errorf("turn calls = %d, want 2 (event processed again after clearing)", turn.callCount)
 	}
+}
 
-	// Add existing pending DM with different message
-	store.pendingDMs = []*state.PendingDM{
-		{
-			ID:          "pending1",
-			UserID:      "discord123",
-			PRURL:       prURL,
-			MessageText: "old message text",
-			SendAt:      time.Now().Add(1 * time.Hour),
-		},
+func TestCoordinator_ProcessEvent_LegacyEventKey(t *testing.T) {
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/repo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fix bug", Author: "alice", State: "open"},
 	}
 
-	// Process DM with new state - should update pending DM
-	coord.processDMForUser(ctx, dmProcessParams{
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		checkResp:  checkResp,
-		prState:    format.StateNeedsReview,
-		prURL:      prURL,
-		username:   "alice",
-		actionKind: "review",
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	// Old pending DM should be removed (it gets removed and a new one queued)
-	// Since we're testing the removal logic
-	if len(store.pendingDMs) > 1 {
-		t.Errorf("Should have removed old pending DM")
+	// Recorded before the deploy that grouped dedup keys by PR
+	if err := store.MarkProcessed(ctx, "delivery-1:"+prURL, time.Hour); err != nil {
+		t.Fatalf("MarkProcessed() error = %v", err)
+	}
+
+	event := SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1", Timestamp: time.Now()}
+	if err := coord.processEventSync(ctx, event); err != nil {
+		t.Fatalf("processEventSync() error = %v", err)
+	}
+	if turn.callCount != 0 {
+		t.Errorf("turn calls = %d, want 0 (already processed under the old key format)", turn.callCount)
 	}
 }
 
-// TestCoordinator_processDMForUser_FindDMFallback tests finding existing DM from history.
-func TestCoordinator_processDMForUser_FindDMFallback(t *testing.T) {
+func TestCoordinator_ProcessEvent_RepoAlias(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
+	discord.channelIDs["new-name"] = "chan-new"
+	discord.botInChannel["chan-new"] = true
+
 	configMgr := newMockConfigManager()
-	userMapper := newMockUserMapper()
+	configMgr.repoAliases = map[string]string{"old-name": "new-name"}
+
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/new-name/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
 
+	store := state.NewMemoryStore()
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord:    discord,
-		Config:     configMgr,
-		Store:      store,
-		Turn:       turn,
-		Org:        "testorg",
-		UserMapper: userMapper,
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-
-	// Set up user mapping
-	userMapper.mappings["alice"] = "discord123"
-	discord.usersInGuild["discord123"] = true
-
-	// DM exists in Discord history but not in store
-	discord.existingDMs["discord123:"+prURL] = existingDM{
-		channelID: "dm-chan",
-		messageID: "dm-msg",
-	}
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/new-name/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-new",
+	})
+	coord.Wait()
 
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
+	// The PR now merges; the event still arrives under the repo's old name
+	turn.responses["https://github.com/testorg/new-name/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "closed", Merged: true},
 	}
-
-	// Process DM - should find existing DM and update it
-	coord.processDMForUser(ctx, dmProcessParams{
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		checkResp:  checkResp,
-		prState:    format.StateNeedsReview,
-		prURL:      prURL,
-		username:   "alice",
-		actionKind: "review",
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/old-name/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-old",
 	})
+	coord.Wait()
 
-	// Should have updated existing DM
-	if len(discord.updatedDMs) == 0 {
-		t.Error("Should have updated existing DM found in history")
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1 (old name resolves to the existing thread)", len(discord.postedMessages))
+	}
+	if len(discord.updatedMessages) != 1 || discord.updatedMessages[0].messageID != "msg-chan-new" {
+		t.Errorf("updatedMessages = %+v, want the canonical message updated", discord.updatedMessages)
+	}
+	if _, ok := store.Thread(ctx, "testorg", "old-name", 1, "chan-new"); ok {
+		t.Error("thread stored under the old repo name")
 	}
 }
 
-// TestCoordinator_processForumChannel_Archive tests archiving merged/closed PRs.
-func TestCoordinator_processForumChannel_Archive(t *testing.T) {
+// TestCoordinator_ProcessEventSync_ConcurrentDuplicates tests that identical events
+// delivered concurrently are processed once.
+func TestCoordinator_ProcessEventSync_ConcurrentDuplicates(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
+	discord.channelIDs["repo"] = "chan-repo"
+	discord.botInChannel["chan-repo"] = true
 	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
+	turn.responses["https://github.com/testorg/repo/pull/1"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
 		Turn:    turn,
 		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "forum1"
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
+	}
 
-	params := format.ChannelMessageParams{
-		PRURL:       prURL,
-		Number:      1,
-		State:       format.StateMerged,
-		ChannelName: "test-forum",
-		Title:       "Test PR",
-		Repo:        "repo",
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			if err := coord.processEventSync(ctx, event); err != nil {
+				t.Errorf("processEventSync() error = %v", err)
+			}
+		})
 	}
+	wg.Wait()
 
-	// Existing thread with different content
-	existingThread := state.ThreadInfo{
-		ThreadID:    "thread123",
-		MessageID:   "msg123",
-		ChannelID:   channelID,
-		ChannelType: "forum",
-		MessageText: "old content",
-		LastState:   string(format.StateNeedsReview),
+	if turn.callCount != 1 {
+		t.Errorf("turn calls = %d, want 1", turn.callCount)
 	}
-	if err := store.SaveThread(ctx, "owner", "repo", 1, channelID, existingThread); err != nil {
-		t.Fatalf("Failed to save thread: %v", err)
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d, want 1", len(discord.postedMessages))
 	}
+}
+
+// TestCoordinator_ProcessEvent_ContextCanceled tests context cancellation during semaphore acquire.
+// NOTE: This test is skipped because it's difficult to test reliably - the semaphore rarely fills
+// up in practice and events process too quickly to reliably catch the cancellation path.
+func TestCoordinator_ProcessEvent_ContextCanceled(t *testing.T) {
+	t.Skip("Context cancellation during semaphore acquire is difficult to test reliably")
+}
+
+// TestCoordinator_processEventSync_ConfigReloadFailure tests config reload failure.
+// NOTE: This test is skipped because the .codeGROOVE repo name starts with a dot,
+// which fails ParsePRURL validation. This is unreachable code in production.
+func TestCoordinator_processEventSync_ConfigReloadFailure(t *testing.T) {
+	t.Skip("Config reload failure path is unreachable - .codeGROOVE repo name fails ParsePRURL")
+}
+
+// TestCoordinator_processEventSync_LoadConfigFailure tests LoadConfig failure handling.
+func TestCoordinator_processEventSync_LoadConfigFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	discord := newMockDiscordClient()
+	turn := newMockTurnClient()
+	configMgr := newMockConfigManager()
+
+	// Make load fail
+	configMgr.shouldFailLoad = true
+
+	// Set up minimal config for processing
+	discord.channelIDs["repo"] = "chan-repo"
+	discord.botInChannel["chan-repo"] = true
+	configMgr.channels["testorg:repo"] = []string{"repo"}
 
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			State:  "closed",
-			Merged: true,
-		},
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
 	}
 
-	// Process forum channel with merged PR
-	err := coord.processForumChannel(ctx, &channelProcessParams{
-		channelID:  channelID,
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		params:     params,
-		checkResp:  checkResp,
-		threadInfo: existingThread,
-		exists:     true,
-	})
+	// Process event - should handle load failure gracefully and continue
+	err := coord.processEventSync(ctx, event)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	// Should have archived the thread
-	if len(discord.archivedThreads) != 1 {
-		t.Errorf("Expected 1 archived thread, got %d", len(discord.archivedThreads))
-	}
-	if discord.archivedThreads[0] != "thread123" {
-		t.Errorf("Archived thread ID = %s, want thread123", discord.archivedThreads[0])
+	// Event should be marked as processed even with load failure
+	eventKey := EventKeyPrefix(event.URL) + event.DeliveryID
+	if !store.WasProcessed(ctx, eventKey) {
+		t.Error("Event should be marked as processed even after LoadConfig failure")
 	}
 }
 
-// TestCoordinator_processForumChannel_ClaimFailed tests cross-instance race.
-func TestCoordinator_processForumChannel_ClaimFailed(t *testing.T) {
+// TestCoordinator_processEventSync_NoChannelsForRepo tests handling when no channels are configured.
+func TestCoordinator_processEventSync_NoChannelsForRepo(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
 	discord := newMockDiscordClient()
 	turn := newMockTurnClient()
 	configMgr := newMockConfigManager()
 
+	// Don't configure any channels for this repo
+	// configMgr.channels is empty
+
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
 		Config:  configMgr,
@@ -4444,319 +7491,610 @@ func TestCoordinator_processForumChannel_ClaimFailed(t *testing.T) {
 		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "forum1"
+	event := SprinklerEvent{
+		URL:        "https://github.com/testorg/repo/pull/1",
+		Type:       "pull_request",
+		DeliveryID: "delivery-1",
+		Timestamp:  time.Now(),
+	}
 
-	// Make ClaimThread fail
-	store.claimThreadShouldFail = true
+	// Process event - should handle gracefully when no channels configured
+	err := coord.processEventSync(ctx, event)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
 
-	// Set up found thread from other instance
-	discord.foundForumThreads[channelID+":"+prURL] = foundThread{
-		threadID:  "thread-other",
-		messageID: "msg-other",
+	// Event should be marked as processed
+	eventKey := EventKeyPrefix(event.URL) + event.DeliveryID
+	if !store.WasProcessed(ctx, eventKey) {
+		t.Error("Event should be marked as processed even when no channels configured")
 	}
 
-	params := format.ChannelMessageParams{
-		PRURL:       prURL,
-		Number:      1,
-		State:       format.StateNeedsReview,
-		ChannelName: "test-forum",
-		Title:       "Test PR",
-		Repo:        "repo",
+	// No messages should be posted
+	if len(discord.postedMessages) > 0 {
+		t.Errorf("No messages should be posted when no channels configured, got %d", len(discord.postedMessages))
 	}
+}
 
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title: "Test PR",
-			State: "open",
-		},
+func TestCoordinator_processTextChannel_SilentEdits(t *testing.T) {
+	alice := format.ActionUser{Username: "alice", Mention: "<@111>", Action: "review"}
+	bob := format.ActionUser{Username: "bob", Mention: "<@222>", Action: "review"}
+
+	tests := []struct {
+		name       string
+		before     []format.ActionUser
+		after      []format.ActionUser
+		loudEdits  bool
+		wantSilent bool
+	}{
+		{"state change with same reviewers", []format.ActionUser{alice}, []format.ActionUser{alice}, false, true},
+		{"reviewer removed", []format.ActionUser{alice, bob}, []format.ActionUser{alice}, false, true},
+		{"new reviewer mentioned", []format.ActionUser{alice}, []format.ActionUser{alice, bob}, false, false},
+		{"silent edits disabled", []format.ActionUser{alice}, []format.ActionUser{alice}, true, false},
 	}
 
-	// Process forum channel
-	err := coord.processForumChannel(ctx, &channelProcessParams{
-		channelID:  channelID,
-		owner:      "owner",
-		repo:       "repo",
-		number:     1,
-		params:     params,
-		checkResp:  checkResp,
-		threadInfo: state.ThreadInfo{},
-		exists:     false,
-	})
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			configMgr := newMockConfigManager()
+			configMgr.loudEdits = tt.loudEdits
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   newMockStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			params := format.ChannelMessageParams{
+				PRURL:       "https://github.com/testorg/repo/pull/1",
+				Number:      1,
+				Repo:        "repo",
+				Title:       "Fix bug",
+				State:       format.StateTestsRunning,
+				ChannelName: "repo",
+				ActionUsers: tt.before,
+			}
+			oldContent := format.ChannelMessage(params)
+			params.State = format.StateNeedsReview
+			params.ActionUsers = tt.after
+
+			err := coord.processTextChannel(ctx, &channelProcessParams{
+				channelID:  "chan-repo",
+				owner:      "testorg",
+				repo:       "repo",
+				number:     1,
+				params:     params,
+				checkResp:  &CheckResponse{},
+				threadInfo: state.ThreadInfo{MessageID: "msg-1", ChannelID: "chan-repo", MessageText: oldContent},
+				exists:     true,
+			})
+			if err != nil {
+				t.Fatalf("processTextChannel() error = %v", err)
+			}
+
+			if len(discord.updatedMessages) != 1 {
+				t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+			}
+			if got := discord.updatedMessages[0].silent; got != tt.wantSilent {
+				t.Errorf("silent = %v, want %v", got, tt.wantSilent)
+			}
+		})
 	}
+}
 
-	// Should have found and updated thread from other instance
-	threadInfo, exists := store.Thread(ctx, "owner", "repo", 1, channelID)
-	if !exists {
-		t.Error("Thread info should be saved after finding thread")
+func TestCoordinator_processTextChannel_NotifyOnTransitions(t *testing.T) {
+	alice := format.ActionUser{Username: "alice", Mention: "<@111>", Action: "review"}
+	bob := format.ActionUser{Username: "bob", Mention: "<@222>", Action: "review"}
+
+	tests := []struct {
+		name       string
+		from       format.PRState
+		to         format.PRState
+		after      []format.ActionUser
+		wantSilent bool
+	}{
+		{"whitelisted transition re-pings", format.StateNeedsReview, format.StateChanges, []format.ActionUser{alice}, false},
+		{"other transition is silent", format.StateTestsRunning, format.StateNeedsReview, []format.ActionUser{alice}, true},
+		{"other transition is silent despite a new mention", format.StateTestsRunning, format.StateNeedsReview, []format.ActionUser{alice, bob}, true},
 	}
-	if threadInfo.ThreadID != "thread-other" {
-		t.Errorf("Thread ID = %s, want thread-other", threadInfo.ThreadID)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			configMgr := newMockConfigManager()
+			configMgr.transitions = []string{"needs_review->changes_requested"}
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   newMockStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			params := format.ChannelMessageParams{
+				PRURL:       "https://github.com/testorg/repo/pull/1",
+				Number:      1,
+				Repo:        "repo",
+				Title:       "Fix bug",
+				State:       tt.from,
+				ChannelName: "repo",
+				ActionUsers: []format.ActionUser{alice},
+			}
+			oldContent := format.ChannelMessage(params)
+			params.State = tt.to
+			params.ActionUsers = tt.after
+
+			err := coord.processTextChannel(ctx, &channelProcessParams{
+				channelID: "chan-repo",
+				owner:     "testorg",
+				repo:      "repo",
+				number:    1,
+				params:    params,
+				checkResp: &CheckResponse{},
+				threadInfo: state.ThreadInfo{
+					MessageID:   "msg-1",
+					ChannelID:   "chan-repo",
+					MessageText: oldContent,
+					LastState:   string(tt.from),
+				},
+				exists: true,
+			})
+			if err != nil {
+				t.Fatalf("processTextChannel() error = %v", err)
+			}
+
+			if len(discord.updatedMessages) != 1 {
+				t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+			}
+			if got := discord.updatedMessages[0].silent; got != tt.wantSilent {
+				t.Errorf("silent = %v, want %v", got, tt.wantSilent)
+			}
+		})
 	}
 }
 
-// TestCoordinator_processForumChannel_WhenThreshold tests "when" threshold logic.
-func TestCoordinator_processForumChannel_WhenThreshold(t *testing.T) {
+func TestCoordinator_processForumChannel_NewReviewerNotSilent(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
-
-	// Set "when" to "passing"
-	configMgr.whenSettings["testorg:test-forum"] = "passing"
-
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
+		Config:  newMockConfigManager(),
+		Store:   newMockStore(),
+		Turn:    newMockTurnClient(),
 		Org:     "testorg",
 	})
 
-	prURL := "https://github.com/owner/repo/pull/1"
-	channelID := "forum1"
-
 	params := format.ChannelMessageParams{
-		PRURL:       prURL,
+		PRURL:       "https://github.com/testorg/repo/pull/1",
 		Number:      1,
-		State:       format.StateTestsBroken,
-		ChannelName: "test-forum",
-		Title:       "Test PR",
 		Repo:        "repo",
+		Title:       "Fix bug",
+		State:       format.StateNeedsReview,
+		ChannelName: "repo",
+		ActionUsers: []format.ActionUser{{Username: "bob", Mention: "<@222>", Action: "review"}},
 	}
 
-	checkResp := &CheckResponse{
-		PullRequest: PRInfo{
-			Title: "Test PR",
-			State: "open",
-		},
-		Analysis: Analysis{
-			Checks: Checks{
-				Failing: 3, // Tests failing
-			},
-		},
-	}
-
-	// Process forum channel - should not create thread yet
 	err := coord.processForumChannel(ctx, &channelProcessParams{
-		channelID:  channelID,
+		channelID:  "chan-repo",
 		owner:      "testorg",
 		repo:       "repo",
 		number:     1,
 		params:     params,
-		checkResp:  checkResp,
-		threadInfo: state.ThreadInfo{},
-		exists:     false,
+		checkResp:  &CheckResponse{},
+		threadInfo: state.ThreadInfo{ThreadID: "thread-1", MessageID: "msg-1", ChannelID: "chan-repo", MessageText: "old content"},
+		exists:     true,
 	})
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("processForumChannel() error = %v", err)
 	}
 
-	// Should not have posted (threshold not met)
-	if len(discord.forumThreads) != 0 {
-		t.Errorf("Should not create forum thread when threshold not met, got %d", len(discord.forumThreads))
+	if len(discord.updatedForumPosts) != 1 {
+		t.Fatalf("updatedForumPosts = %d, want 1", len(discord.updatedForumPosts))
+	}
+	if discord.updatedForumPosts[0].silent {
+		t.Error("forum edit that mentions a new reviewer should not be silent")
 	}
 }
 
-// TestCoordinator_processEventSync_ConfigRepo tests skipping .codeGROOVE repo.
-// Note: .codeGROOVE starts with a dot and fails ParsePRURL validation,
-// so this tests the error handling path.
-func TestCoordinator_processEventSync_ConfigRepo(t *testing.T) {
-	t.Skip("ParsePRURL doesn't accept repo names starting with dot - this is a known limitation")
+func TestCoordinator_BuildActionUsers_ReviewerAge(t *testing.T) {
+	ctx := context.Background()
+	checkResp := &CheckResponse{
+		Analysis: Analysis{
+			NextAction: map[string]Action{
+				"alice": {Kind: "review", Since: time.Now().Add(-2 * time.Hour)},
+				"bob":   {Kind: "review"},
+			},
+		},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			configMgr := newMockConfigManager()
+			configMgr.features = config.Features{featureReviewerAge: enabled}
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: newMockDiscordClient(),
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			users := coord.buildActionUsers(ctx, checkResp)
+			if len(users) != 2 {
+				t.Fatalf("buildActionUsers() = %d users, want 2", len(users))
+			}
+			if users[1].Waiting != 0 {
+				t.Errorf("bob Waiting = %v, want 0 without a Since", users[1].Waiting)
+			}
+			if !enabled {
+				if users[0].Waiting != 0 {
+					t.Errorf("alice Waiting = %v with the flag off, want 0", users[0].Waiting)
+				}
+				return
+			}
+			if users[0].Waiting < 2*time.Hour || users[0].Waiting > 3*time.Hour {
+				t.Errorf("alice Waiting = %v, want about 2h", users[0].Waiting)
+			}
+		})
+	}
+}
+
+func TestCoordinator_RepoEmoji(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
+	discord.channelIDs["shared"] = "chan-shared"
+	discord.botInChannel["chan-shared"] = true
+
 	configMgr := newMockConfigManager()
+	configMgr.channels["testorg:api"] = []string{"shared"}
+	configMgr.channels["testorg:docs"] = []string{"shared"}
+	configMgr.repoEmoji = map[string]string{"api": "🚀"}
+
+	turn := newMockTurnClient()
+	for _, repo := range []string{"api", "docs"} {
+		turn.responses["https://github.com/testorg/"+repo+"/pull/1"] = &CheckResponse{
+			PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+		}
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
 		Config:  configMgr,
-		Store:   store,
+		Store:   state.NewMemoryStore(),
 		Turn:    turn,
 		Org:     "testorg",
 	})
+	for _, repo := range []string{"api", "docs"} {
+		coord.ProcessEvent(ctx, SprinklerEvent{
+			URL:        "https://github.com/testorg/" + repo + "/pull/1",
+			Type:       "pull_request",
+			DeliveryID: "delivery-" + repo,
+		})
+		coord.Wait()
+	}
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/.codeGROOVE/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
+	if len(discord.postedMessages) != 2 {
+		t.Fatalf("postedMessages = %d, want 2", len(discord.postedMessages))
+	}
+	for _, msg := range discord.postedMessages {
+		isAPI := strings.Contains(msg.text, "api#1")
+		if hasEmoji := strings.HasPrefix(msg.text, "🚀 "); hasEmoji != isAPI {
+			t.Errorf("message = %q, want the 🚀 prefix only for the configured api repo", msg.text)
+		}
+	}
+}
+
+func TestCoordinator_SuppressSelfApproved(t *testing.T) {
+	// Turn response bodies as /v1/validate returns them. GitHub doesn't let authors approve
+	// their own PRs, so a solo maintainer's PR is ready to merge without any approvals.
+	const (
+		solo = `{
+			"pull_request": {"title": "Tidy up", "author": "alice", "state": "open"},
+			"analysis": {
+				"workflow_state": "REFINED_WAITING_FOR_APPROVAL",
+				"checks": {"passing": 3},
+				"ready_to_merge": true,
+				"next_action": {"alice": {"kind": "merge", "reason": "ready to merge"}}
+			}
+		}`
+		soloCommented = `{
+			"pull_request": {"title": "Tidy up", "author": "alice", "state": "open", "reviewers": {"alice": "commented"}},
+			"analysis": {
+				"workflow_state": "REFINED_WAITING_FOR_APPROVAL",
+				"checks": {"passing": 3},
+				"ready_to_merge": true,
+				"next_action": {"alice": {"kind": "merge", "reason": "ready to merge"}}
+			}
+		}`
+		approvedByOther = `{
+			"pull_request": {
+				"title": "Tidy up", "author": "alice", "state": "open",
+				"reviewers": {"bob": "approved"}, "approved_by": ["bob"]
+			},
+			"analysis": {
+				"workflow_state": "REFINED_WAITING_FOR_APPROVAL",
+				"checks": {"passing": 3},
+				"ready_to_merge": true,
+				"approved": true,
+				"next_action": {"alice": {"kind": "merge", "reason": "ready to merge"}}
+			}
+		}`
+		waitingOnOther = `{
+			"pull_request": {"title": "Tidy up", "author": "alice", "state": "open"},
+			"analysis": {
+				"workflow_state": "ASSIGNED_WAITING_FOR_REVIEW",
+				"checks": {"passing": 3},
+				"next_action": {"bob": {"kind": "review", "reason": "requested reviewer"}}
+			}
+		}`
+	)
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		body      string
+		wantPosts int
+	}{
+		{"solo author ready to merge", true, solo, 0},
+		{"solo author commented on their own PR", true, soloCommented, 0},
+		{"approved by someone else", true, approvedByOther, 1},
+		{"waiting on another reviewer", true, waitingOnOther, 1},
+		{"disabled", false, solo, 1},
 	}
 
-	// Process event
-	err := coord.processEventSync(ctx, event)
-	if err == nil {
-		t.Error("Expected error for invalid PR URL")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["discord-alice"] = true
+			discord.usersInGuild["discord-bob"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.suppressSelf = tt.enabled
+			mapper := newMockUserMapper()
+			mapper.mappings["alice"] = "discord-alice"
+			mapper.mappings["bob"] = "discord-bob"
+
+			var resp CheckResponse
+			if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &resp
+
+			store := state.NewMemoryStore()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+			if len(discord.postedMessages) != tt.wantPosts {
+				t.Fatalf("postedMessages = %d, want %d", len(discord.postedMessages), tt.wantPosts)
+			}
+			pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+			if err != nil {
+				t.Fatalf("PendingDMs() error = %v", err)
+			}
+			if tt.wantPosts == 0 && (len(pending) != 0 || len(discord.sentDMs) != 0) {
+				t.Errorf("DMs = %d pending, %d sent for a self-approved PR, want none", len(pending), len(discord.sentDMs))
+			}
+		})
 	}
 }
 
-// TestCoordinator_processEventSync_AlreadyProcessed tests event deduplication.
-func TestCoordinator_processEventSync_AlreadyProcessed(t *testing.T) {
+func TestCoordinator_SuppressSelfApproved_UpdatesPostedMessage(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
 	configMgr := newMockConfigManager()
+	configMgr.suppressSelf = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Tidy up", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Pending: 1}, NextAction: map[string]Action{"alice": {Kind: "fix_tests"}}},
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
 		Config:  configMgr,
-		Store:   store,
+		Store:   state.NewMemoryStore(),
 		Turn:    turn,
 		Org:     "testorg",
 	})
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/repo/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
-	}
-
-	// Mark event as already processed
-	eventKey := fmt.Sprintf("%s:%s", event.DeliveryID, event.URL)
-	if err := store.MarkProcessed(ctx, eventKey, 5*time.Minute); err != nil {
-		t.Fatalf("Failed to mark processed: %v", err)
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-running"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d while tests run, want 1", len(discord.postedMessages))
 	}
 
-	// Process event
-	err := coord.processEventSync(ctx, event)
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	// Once it's ready for the author to merge, the posted message still follows it
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Tidy up", Author: "alice", State: "open"},
+		Analysis: Analysis{
+			Checks:       Checks{Passing: 1},
+			ReadyToMerge: true,
+			NextAction:   map[string]Action{"alice": {Kind: "merge"}},
+		},
 	}
-
-	// Should not have called Turn API or posted messages
-	if turn.callCount > 0 {
-		t.Errorf("Should not call Turn API for already processed event, got %d calls", turn.callCount)
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-passed"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d once ready, want no new post", len(discord.postedMessages))
 	}
-	if len(discord.postedMessages) != 0 {
-		t.Errorf("Should not post messages for already processed event, got %d", len(discord.postedMessages))
+	if len(discord.updatedMessages) != 1 {
+		t.Errorf("updatedMessages = %d once ready, want the posted message updated", len(discord.updatedMessages))
 	}
 }
 
-// TestCoordinator_processEventSync_NoChannels tests handling when no channels configured.
-func TestCoordinator_processEventSync_NoChannels(t *testing.T) {
+func TestCoordinator_ProcessEvent_SystemIndicator(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
 	configMgr := newMockConfigManager()
+	configMgr.systemIndicators = []string{"processing"}
 
-	// Don't configure any channels
-	configMgr.channels = make(map[string][]string)
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fresh PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"_system": {Kind: "processing"}}},
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      state.NewMemoryStore(),
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
 	})
 
-	turn.responses["https://github.com/testorg/repo/pull/1"] = &CheckResponse{
-		PullRequest: PRInfo{
-			Title:  "Test PR",
-			Author: "alice",
-			State:  "open",
-		},
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-opened"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
 	}
-
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/repo/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "analyzing…") {
+		t.Errorf("message = %q while only _system is processing, want the analyzing indicator", text)
 	}
 
-	// Process event
-	err := coord.processEventSync(ctx, event)
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	// Once Turn has real actions, they replace the indicator
+	turn.responses[prURL].Analysis.NextAction = map[string]Action{"bob": {Kind: "review"}}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-analyzed"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+	}
+	text := discord.updatedMessages[0].text
+	if strings.Contains(text, "analyzing") || !strings.Contains(text, "<@discord-bob>") {
+		t.Errorf("message = %q, want bob's review instead of the indicator", text)
 	}
 
-	// Should not have posted any messages (no channels)
-	if len(discord.postedMessages) != 0 {
-		t.Errorf("Should not post when no channels configured, got %d", len(discord.postedMessages))
+	// Unlisted _system kinds show nothing, as before
+	configMgr.systemIndicators = nil
+	if coord.analyzing(&CheckResponse{Analysis: Analysis{NextAction: map[string]Action{"_system": {Kind: "processing"}}}}) {
+		t.Error("analyzing() = true without system_indicators, want false")
 	}
 }
 
-// TestCoordinator_processEventSync_TurnAPIFailure tests handling Turn API failures gracefully.
-func TestCoordinator_processEventSync_TurnAPIFailure(t *testing.T) {
+func TestCoordinator_SuppressLabels(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["discord-bob"] = true
+
 	configMgr := newMockConfigManager()
+	configMgr.suppressLabels = []string{"wip", "do-not-merge"}
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
 
-	discord.channelIDs["repo"] = "chan-repo"
-	discord.botInChannel["chan-repo"] = true
-	configMgr.channels["testorg:repo"] = []string{"repo"}
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Half done", Author: "alice", State: "open", Labels: []string{"enhancement", "WIP"}},
+		Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+	}
 
+	store := state.NewMemoryStore()
 	coord := NewCoordinator(CoordinatorConfig{
-		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
-		Turn:    turn,
-		Org:     "testorg",
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
 	})
 
-	// Make Turn API fail
-	turn.shouldFail = true
-
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/repo/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-labeled"})
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d for a WIP PR, want 0", len(discord.postedMessages))
 	}
-
-	// Process event - should handle gracefully and continue
-	err := coord.processEventSync(ctx, event)
+	pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("PendingDMs() error = %v", err)
 	}
-
-	// Should still mark as processed even though Turn failed
-	eventKey := fmt.Sprintf("%s:%s", event.DeliveryID, event.URL)
-	if !store.WasProcessed(ctx, eventKey) {
-		t.Error("Event should be marked as processed even after Turn API failure")
+	if len(pending) != 0 {
+		t.Fatalf("pending DMs = %d for a WIP PR, want 0", len(pending))
 	}
-}
 
-// TestCoordinator_ProcessEvent_ContextCanceled tests context cancellation during semaphore acquire.
-// NOTE: This test is skipped because it's difficult to test reliably - the semaphore rarely fills
-// up in practice and events process too quickly to reliably catch the cancellation path.
-func TestCoordinator_ProcessEvent_ContextCanceled(t *testing.T) {
-	t.Skip("Context cancellation during semaphore acquire is difficult to test reliably")
-}
+	// Removing the label posts the PR and notifies its reviewer
+	turn.responses[prURL].PullRequest.Labels = []string{"enhancement"}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-unlabeled"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d after the label was removed, want 1", len(discord.postedMessages))
+	}
+	pending, err = store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].UserID != "discord-bob" {
+		t.Fatalf("pending DMs = %+v after the label was removed, want one for bob", pending)
+	}
 
-// TestCoordinator_processEventSync_ConfigReloadFailure tests config reload failure.
-// NOTE: This test is skipped because the .codeGROOVE repo name starts with a dot,
-// which fails ParsePRURL validation. This is unreachable code in production.
-func TestCoordinator_processEventSync_ConfigReloadFailure(t *testing.T) {
-	t.Skip("Config reload failure path is unreachable - .codeGROOVE repo name fails ParsePRURL")
+	// Labeling it again drops the queued DM and withdraws the now stale message
+	turn.responses[prURL].PullRequest.Labels = []string{"do-not-merge"}
+	turn.responses[prURL].Analysis.Approved = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-relabeled"})
+	coord.Wait()
+	pending, err = store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending DMs = %d after relabeling, want 0", len(pending))
+	}
+	if len(discord.updatedMessages) != 0 {
+		t.Errorf("updatedMessages = %d after relabeling, want 0", len(discord.updatedMessages))
+	}
+	if len(discord.deletedMessages) != 1 || discord.deletedMessages[0] != "chan-testrepo/msg-chan-testrepo" {
+		t.Errorf("deletedMessages = %v after relabeling, want the PR's message", discord.deletedMessages)
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); ok {
+		t.Error("thread still tracked after relabeling, want it forgotten so unlabeling posts afresh")
+	}
 }
 
-// TestCoordinator_processEventSync_LoadConfigFailure tests LoadConfig failure handling.
-func TestCoordinator_processEventSync_LoadConfigFailure(t *testing.T) {
+func TestCoordinator_SuppressLabels_ArchivesForumThread(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.forumChannels["chan-testrepo"] = true
 
-	// Make load fail
-	configMgr.shouldFailLoad = true
+	configMgr := newMockConfigManager()
+	configMgr.suppressLabels = []string{"wip"}
 
-	// Set up minimal config for processing
-	discord.channelIDs["repo"] = "chan-repo"
-	discord.botInChannel["chan-repo"] = true
-	configMgr.channels["testorg:repo"] = []string{"repo"}
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Half done", Author: "alice", State: "open"},
+	}
 
+	store := state.NewMemoryStore()
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
 		Config:  configMgr,
@@ -4765,66 +8103,77 @@ func TestCoordinator_processEventSync_LoadConfigFailure(t *testing.T) {
 		Org:     "testorg",
 	})
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/repo/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-opened"})
+	coord.Wait()
+	if len(discord.forumThreads) != 1 {
+		t.Fatalf("forumThreads = %d, want 1", len(discord.forumThreads))
 	}
 
-	// Process event - should handle load failure gracefully and continue
-	err := coord.processEventSync(ctx, event)
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	// Labeling it archives the thread, discussion and all, rather than deleting it
+	turn.responses[prURL].PullRequest.Labels = []string{"WIP"}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-labeled"})
+	coord.Wait()
+	if len(discord.deletedThreads) != 0 {
+		t.Errorf("deletedThreads = %v, want the thread kept", discord.deletedThreads)
+	}
+	if len(discord.archivedThreads) != 1 || discord.archivedThreads[0] != "thread-chan-testrepo" {
+		t.Errorf("archivedThreads = %v, want the PR's thread", discord.archivedThreads)
+	}
+	info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok || !info.Withdrawn {
+		t.Fatalf("Thread() = %+v, %v after labeling, want it tracked and withdrawn", info, ok)
 	}
 
-	// Event should be marked as processed even with load failure
-	eventKey := fmt.Sprintf("%s:%s", event.DeliveryID, event.URL)
-	if !store.WasProcessed(ctx, eventKey) {
-		t.Error("Event should be marked as processed even after LoadConfig failure")
+	// Removing the label revives the same thread
+	turn.responses[prURL].PullRequest.Labels = nil
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-unlabeled"})
+	coord.Wait()
+	if len(discord.forumThreads) != 1 {
+		t.Errorf("forumThreads = %d after the label was removed, want the old thread reused", len(discord.forumThreads))
+	}
+	if len(discord.unarchivedThreads) != 1 || len(discord.updatedForumPosts) != 1 {
+		t.Errorf("unarchived %v, updated %d posts after the label was removed, want the thread revived and edited",
+			discord.unarchivedThreads, len(discord.updatedForumPosts))
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); info.Withdrawn {
+		t.Error("thread still marked withdrawn after it was revived")
 	}
 }
 
-// TestCoordinator_processEventSync_NoChannelsForRepo tests handling when no channels are configured.
-func TestCoordinator_processEventSync_NoChannelsForRepo(t *testing.T) {
+func TestCoordinator_ProcessEvent_DropsInvalidEvents(t *testing.T) {
 	ctx := context.Background()
-	store := newMockStore()
 	discord := newMockDiscordClient()
-	turn := newMockTurnClient()
-	configMgr := newMockConfigManager()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
 
-	// Don't configure any channels for this repo
-	// configMgr.channels is empty
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
 
 	coord := NewCoordinator(CoordinatorConfig{
 		Discord: discord,
-		Config:  configMgr,
-		Store:   store,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
 		Turn:    turn,
 		Org:     "testorg",
 	})
 
-	event := SprinklerEvent{
-		URL:        "https://github.com/testorg/repo/pull/1",
-		Type:       "pull_request",
-		DeliveryID: "delivery-1",
-		Timestamp:  time.Now(),
-	}
-
-	// Process event - should handle gracefully when no channels configured
-	err := coord.processEventSync(ctx, event)
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request"})
+	coord.ProcessEvent(ctx, SprinklerEvent{Type: "pull_request", DeliveryID: "d-no-url"})
+	coord.Wait()
+	if turn.callCount != 0 || len(discord.postedMessages) != 0 {
+		t.Fatalf("Turn calls = %d, posts = %d for invalid events, want none", turn.callCount, len(discord.postedMessages))
 	}
-
-	// Event should be marked as processed
-	eventKey := fmt.Sprintf("%s:%s", event.DeliveryID, event.URL)
-	if !store.WasProcessed(ctx, eventKey) {
-		t.Error("Event should be marked as processed even when no channels configured")
+	if got := coord.CoordinatorStats().Errors; got != 2 {
+		t.Errorf("Errors = %d, want 2", got)
 	}
 
-	// No messages should be posted
-	if len(discord.postedMessages) > 0 {
-		t.Errorf("No messages should be posted when no channels configured, got %d", len(discord.postedMessages))
+	// A PR subpage URL is processed as the PR itself
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL + "/files", Type: "pull_request", DeliveryID: "d-files"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 || !strings.Contains(discord.postedMessages[0].text, prURL+"?st=") {
+		t.Errorf("postedMessages = %+v, want one post linking %s", discord.postedMessages, prURL)
 	}
 }
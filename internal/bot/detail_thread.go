@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// processDetailThread handles text channels with detail_thread: the channel message is a compact
+// summary line, and the PR's full details go in a thread started on it. Both are updated as the
+// PR changes, and both are kept in the PR's stored thread info.
+func (c *Coordinator) processDetailThread(ctx context.Context, params *channelProcessParams) error {
+	details := params.params
+	if c.config.ShowBodySnippet(c.org, details.ChannelName) {
+		details.BodySnippet = format.BodySnippet(params.checkResp.PullRequest.Body)
+	}
+	content := format.ChannelMessage(details)
+	// Mentions are only in the details, so they decide whether their edit pings; the
+	// stored state is compared before the summary's update saves the new one
+	silent := c.silentChannelEdit(params, params.threadInfo.DetailText, content)
+
+	if err := c.processTextMessage(ctx, params, format.CompactLine(params.params)); err != nil {
+		return err
+	}
+
+	info, ok := c.store.Thread(ctx, params.owner, params.repo, params.number, params.channelID)
+	if !ok || info.MessageID == "" {
+		return nil // No summary yet, e.g. the channel's "when" threshold isn't met
+	}
+	if info.DetailText == content {
+		return nil
+	}
+	prURL := params.params.PRURL
+
+	if info.DetailThreadID == "" {
+		// A message takes only one thread, and another instance may be starting it
+		if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"detail-thread:"+info.MessageID, eventClaimTTL) {
+			return nil
+		}
+		name := format.ForumThreadTitle(params.repo, params.number, params.params.Title)
+		threadID, err := c.discord.StartMessageThread(ctx, params.channelID, info.MessageID, name)
+		if err != nil {
+			return fmt.Errorf("start detail thread: %w", err)
+		}
+		info.DetailThreadID = threadID
+	}
+
+	if info.DetailMessageID == "" {
+		messageID, err := c.discord.PostMessage(ctx, info.DetailThreadID, content)
+		if err != nil {
+			// Saved without a message, so the next event posts one in the same thread
+			if saveErr := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, info); saveErr != nil {
+				c.logger.Warn("failed to save thread info", "error", saveErr)
+			}
+			return fmt.Errorf("post details: %w", err)
+		}
+		c.counters.posts.Add(1)
+		info.DetailMessageID = messageID
+		c.logger.Info("posted PR details in thread",
+			"thread_id", info.DetailThreadID,
+			"message_id", messageID,
+			"pr", prURL)
+	} else {
+		if !c.editAllowed(info.DetailThreadID, info.DetailMessageID, prURL) {
+			return nil
+		}
+		if err := c.discord.UpdateMessage(ctx, info.DetailThreadID, info.DetailMessageID, content, silent); err != nil {
+			c.logger.Warn("failed to update PR details in thread",
+				"thread_id", info.DetailThreadID,
+				"message_id", info.DetailMessageID,
+				"pr", prURL,
+				"error", err)
+			return nil
+		}
+		c.counters.edits.Add(1)
+	}
+
+	info.DetailText = content
+	if err := c.store.SaveThread(ctx, params.owner, params.repo, params.number, params.channelID, info); err != nil {
+		c.logger.Warn("failed to save thread info", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_DetailThread(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.detailThread = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Speed up builds", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Pending: 1}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	// A compact summary in the channel, and the details in a thread started on it
+	if len(discord.postedMessages) != 2 {
+		t.Fatalf("postedMessages = %d, want summary and details", len(discord.postedMessages))
+	}
+	summary, details := discord.postedMessages[0], discord.postedMessages[1]
+	if summary.channelID != "chan-testrepo" || !strings.Contains(summary.text, "?st=tests_running") {
+		t.Errorf("summary = %q in %s, want a compact line in chan-testrepo", summary.text, summary.channelID)
+	}
+	if len(discord.startedThreads) != 1 || discord.startedThreads[0].messageID != "msg-chan-testrepo" {
+		t.Fatalf("startedThreads = %v, want one on the summary", discord.startedThreads)
+	}
+	if details.channelID != "thread-msg-chan-testrepo" || !strings.Contains(details.text, "Speed up builds") {
+		t.Errorf("details = %q in %s, want the full message in the thread", details.text, details.channelID)
+	}
+
+	info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok || info.MessageID != "msg-chan-testrepo" || info.DetailThreadID != "thread-msg-chan-testrepo" ||
+		info.DetailMessageID != "msg-thread-msg-chan-testrepo" {
+		t.Fatalf("stored thread info = %+v, want both summary and details", info)
+	}
+
+	// A state change updates both
+	turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-2"})
+	coord.Wait()
+
+	if len(discord.updatedMessages) != 2 {
+		t.Fatalf("updatedMessages = %d, want summary and details", len(discord.updatedMessages))
+	}
+	updated := make(map[string]string)
+	for _, u := range discord.updatedMessages {
+		updated[u.channelID+"/"+u.messageID] = u.text
+	}
+	if text := updated["chan-testrepo/msg-chan-testrepo"]; !strings.Contains(text, "?st=tests_broken") {
+		t.Errorf("updated summary = %q, want the new state", text)
+	}
+	if text, ok := updated["thread-msg-chan-testrepo/msg-thread-msg-chan-testrepo"]; !ok || text == details.text {
+		t.Errorf("updated details = %q, want them changed", text)
+	}
+	if len(discord.startedThreads) != 1 || len(discord.postedMessages) != 2 {
+		t.Errorf("startedThreads = %d, postedMessages = %d after the update, want no new ones",
+			len(discord.startedThreads), len(discord.postedMessages))
+	}
+}
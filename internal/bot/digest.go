@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// digestPostedRetention bounds how long a posted digest line is remembered for skipping unchanged re-polls.
+const digestPostedRetention = 7 * 24 * time.Hour
+
+// recordDigestChange accumulates a PR's latest state for a digest-mode channel's next digest,
+// instead of posting or editing a live message.
+func (c *Coordinator) recordDigestChange(ctx context.Context, channelID string, params format.ChannelMessageParams) error {
+	// The digest header carries the prefix, and assignee and check lines would break the one-line-per-PR layout
+	params.Prefix = ""
+	params.Assignees = nil
+	params.Failing = nil
+	line := format.ChannelMessage(params)
+
+	now := time.Now()
+	recorded := false
+	pending := 0
+	err := c.store.UpdateDigest(ctx, channelID, func(info *state.DigestInfo) bool {
+		if info.LastPostedAt.IsZero() {
+			// The first digest goes out one interval after the first change
+			info.LastPostedAt = now
+		}
+		if _, queued := info.Pending[params.PRURL]; !queued && info.Posted[params.PRURL].Line == line {
+			// Polling re-reports PRs that haven't changed since they were last posted
+			return false
+		}
+
+		info.Pending = maps.Clone(info.Pending)
+		if info.Pending == nil {
+			info.Pending = make(map[string]state.DigestEntry)
+		}
+		info.Pending[params.PRURL] = state.DigestEntry{Line: line, UpdatedAt: now}
+		recorded, pending = true, len(info.Pending)
+		return true
+	})
+	if err != nil || !recorded {
+		return err
+	}
+
+	c.logger.Debug("recorded change for channel digest",
+		"channel", params.ChannelName,
+		"pr", params.PRURL,
+		"pending", pending)
+	return nil
+}
+
+// FlushDigests posts a digest to each digest-mode channel whose interval has elapsed
+// since its last digest and that has PR changes waiting.
+func (c *Coordinator) FlushDigests(ctx context.Context) {
+	if c.inMaintenance(ctx) {
+		return
+	}
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return
+	}
+
+	for channelName := range cfg.Channels {
+		interval := c.config.DigestInterval(c.org, channelName)
+		if interval <= 0 {
+			continue
+		}
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if err := c.flushDigest(ctx, channelID, channelName, interval); err != nil {
+			c.logger.Warn("failed to post channel digest",
+				"channel", channelName,
+				"error", err)
+		}
+	}
+}
+
+func (c *Coordinator) flushDigest(ctx context.Context, channelID, channelName string, interval time.Duration) error {
+	info, exists := c.store.Digest(ctx, channelID)
+	if !exists || len(info.Pending) == 0 || time.Since(info.LastPostedAt) < interval {
+		return nil
+	}
+	// Every instance flushes; the first to claim this interval's digest posts it
+	key := "digest:" + channelID + ":" + strconv.FormatInt(info.LastPostedAt.UnixNano(), 10)
+	if !c.store.ClaimEvent(ctx, key, eventClaimTTL) {
+		return nil
+	}
+
+	urls := slices.Sorted(maps.Keys(info.Pending))
+	lines := make([]string, 0, len(urls))
+	for _, url := range urls {
+		lines = append(lines, info.Pending[url].Line)
+	}
+	text := format.DigestMessage(c.config.MessagePrefix(c.org, channelName), lines)
+
+	messageID, err := c.sendDigest(ctx, channelID, channelName, info.MessageID, text)
+	if err != nil {
+		return err
+	}
+	c.logger.Info("sent channel digest",
+		"channel", channelName,
+		"message_id", messageID,
+		"prs", len(lines))
+
+	// Changes recorded while the digest was sent wait for the next one
+	now := time.Now()
+	return c.store.UpdateDigest(ctx, channelID, func(cur *state.DigestInfo) bool {
+		posted := make(map[string]state.DigestEntry, len(cur.Posted)+len(info.Pending))
+		for url, entry := range cur.Posted {
+			if now.Sub(entry.UpdatedAt) < digestPostedRetention {
+				posted[url] = entry
+			}
+		}
+		var pending map[string]state.DigestEntry
+		for url, entry := range cur.Pending {
+			if sent, ok := info.Pending[url]; ok && sent.Line == entry.Line && sent.UpdatedAt.Equal(entry.UpdatedAt) {
+				posted[url] = entry
+				continue
+			}
+			if pending == nil {
+				pending = make(map[string]state.DigestEntry)
+			}
+			pending[url] = entry
+		}
+		*cur = state.DigestInfo{
+			LastPostedAt: now,
+			MessageID:    messageID,
+			Pending:      pending,
+			Posted:       posted,
+		}
+		return true
+	})
+}
+
+// sendDigest edits the channel's digest message to show text, posting a new one if the
+// channel doesn't have one yet or it was deleted, and returns the message's ID.
+func (c *Coordinator) sendDigest(ctx context.Context, channelID, channelName, messageID, text string) (string, error) {
+	if messageID != "" {
+		err := c.discord.UpdateMessage(ctx, channelID, messageID, text, true)
+		if err == nil {
+			return messageID, nil
+		}
+		if !errors.Is(err, discord.ErrNotFound) {
+			return "", err
+		}
+		c.logger.Info("channel digest message no longer exists in Discord, re-creating it",
+			"channel", channelName,
+			"message_id", messageID)
+	}
+
+	messageID, err := c.discord.PostMessage(ctx, channelID, text)
+	if err != nil {
+		return "", err
+	}
+	c.counters.posts.Add(1)
+	return messageID, nil
+}
@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_Digest_OnePostPerInterval(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {DigestInterval: time.Hour}},
+	}
+	configMgr.digestIntervals = map[string]time.Duration{"testrepo": time.Hour}
+
+	turn := newMockTurnClient()
+	for _, n := range []int{1, 2, 3} {
+		turn.responses[fmt.Sprintf("https://github.com/testorg/testrepo/pull/%d", n)] = &CheckResponse{
+			PullRequest: PRInfo{Title: fmt.Sprintf("PR %d", n), Author: "alice", State: "open"},
+		}
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// Several events for three PRs within one interval, including a repeat for PR 1
+	for i, n := range []int{1, 2, 1, 3} {
+		coord.ProcessEvent(ctx, SprinklerEvent{
+			URL:        fmt.Sprintf("https://github.com/testorg/testrepo/pull/%d", n),
+			Type:       "pull_request",
+			DeliveryID: fmt.Sprintf("delivery-%d", i),
+		})
+		coord.Wait()
+	}
+
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d, want 0 (digest channels get no live posts)", len(discord.postedMessages))
+	}
+
+	// The interval hasn't elapsed yet
+	coord.FlushDigests(ctx)
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d before interval elapsed, want 0", len(discord.postedMessages))
+	}
+
+	info, ok := store.Digest(ctx, "chan-testrepo")
+	if !ok {
+		t.Fatal("digest state not saved")
+	}
+	info.LastPostedAt = time.Now().Add(-2 * time.Hour)
+	if err := store.SaveDigest(ctx, "chan-testrepo", info); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+
+	coord.FlushDigests(ctx)
+	coord.FlushDigests(ctx)
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1 digest", len(discord.postedMessages))
+	}
+	text := discord.postedMessages[0].text
+	if !strings.Contains(text, "3 PRs changed") {
+		t.Errorf("digest = %q, want 3 PRs changed", text)
+	}
+	for _, n := range []int{1, 2, 3} {
+		if !strings.Contains(text, fmt.Sprintf("PR %d", n)) {
+			t.Errorf("digest = %q, missing PR %d", text, n)
+		}
+	}
+
+	info, _ = store.Digest(ctx, "chan-testrepo")
+	if info.MessageID != "msg-chan-testrepo" {
+		t.Errorf("MessageID = %q, want msg-chan-testrepo", info.MessageID)
+	}
+	if len(info.Pending) != 0 {
+		t.Errorf("Pending = %d after digest, want 0", len(info.Pending))
+	}
+
+	// A poll re-reporting an unchanged PR doesn't queue it for the next digest
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/2",
+		Type:       "poll",
+		DeliveryID: "delivery-poll",
+	})
+	coord.Wait()
+	if info, _ := store.Digest(ctx, "chan-testrepo"); len(info.Pending) != 0 {
+		t.Errorf("Pending = %d after unchanged re-poll, want 0", len(info.Pending))
+	}
+}
+
+func TestCoordinator_FlushDigests_ReplicasPostOnce(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {DigestInterval: time.Hour}},
+	}
+	configMgr.digestIntervals = map[string]time.Duration{"testrepo": time.Hour}
+
+	store := state.NewMemoryStore()
+	replicas := make([]*Coordinator, 2)
+	for i := range replicas {
+		replicas[i] = NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    newMockTurnClient(),
+			Org:     "testorg",
+		})
+	}
+
+	due := state.DigestInfo{
+		LastPostedAt: time.Now().Add(-2 * time.Hour),
+		Pending: map[string]state.DigestEntry{
+			"https://github.com/testorg/testrepo/pull/1": {Line: "PR 1", UpdatedAt: time.Now()},
+		},
+	}
+	if err := store.SaveDigest(ctx, "chan-testrepo", due); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+
+	replicas[0].FlushDigests(ctx)
+	// The second replica read the digest before the first saved its flush
+	if err := store.SaveDigest(ctx, "chan-testrepo", due); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+	replicas[1].FlushDigests(ctx)
+
+	if got := len(discord.postedMessages); got != 1 {
+		t.Errorf("postedMessages = %d, want 1 digest across replicas", got)
+	}
+}
+
+func TestCoordinator_FlushDigests_EditsMessage(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {DigestInterval: time.Hour}},
+	}
+	configMgr.digestIntervals = map[string]time.Duration{"testrepo": time.Hour}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	if err := store.SaveDigest(ctx, "chan-testrepo", state.DigestInfo{
+		LastPostedAt: time.Now().Add(-2 * time.Hour),
+		MessageID:    "digest-msg",
+		Pending:      map[string]state.DigestEntry{prURL: {Line: "PR 1", UpdatedAt: time.Now()}},
+	}); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+
+	coord.FlushDigests(ctx)
+
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("postedMessages = %d, want the digest edited in place", len(discord.postedMessages))
+	}
+	if len(discord.updatedMessages) != 1 || discord.updatedMessages[0].messageID != "digest-msg" {
+		t.Fatalf("updatedMessages = %+v, want digest-msg edited", discord.updatedMessages)
+	}
+	info, _ := store.Digest(ctx, "chan-testrepo")
+	if info.MessageID != "digest-msg" || len(info.Pending) != 0 || info.Posted[prURL].Line != "PR 1" {
+		t.Errorf("digest = %+v, want the same message with PR 1 posted", info)
+	}
+}
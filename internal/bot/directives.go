@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// directivePrefix starts a PR comment line that controls Discord behavior for that PR,
+// e.g. "/discordian mute" or "/discordian channel #releases".
+const directivePrefix = "/discordian"
+
+// Directive kinds.
+const (
+	directiveMute    = "mute"
+	directiveUnmute  = "unmute"
+	directiveChannel = "channel" // With no argument, restores the configured channels
+)
+
+type prDirective struct {
+	kind string
+	arg  string
+}
+
+// parseDirectives returns the recognized directives in a comment body, in order.
+// Unknown subcommands are ignored so typos don't change behavior.
+func parseDirectives(body string) []prDirective {
+	var directives []prDirective
+	for line := range strings.Lines(body) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], directivePrefix) {
+			continue
+		}
+		switch kind := strings.ToLower(fields[1]); kind {
+		case directiveMute, directiveUnmute:
+			directives = append(directives, prDirective{kind: kind})
+		case directiveChannel:
+			d := prDirective{kind: kind}
+			if len(fields) > 2 {
+				d.arg = strings.TrimPrefix(fields[2], "#")
+			}
+			directives = append(directives, d)
+		default:
+		}
+	}
+	return directives
+}
+
+// isCommentEvent reports whether an event type can carry a new PR comment.
+func isCommentEvent(eventType string) bool {
+	switch eventType {
+	case "issue_comment", "pull_request_review_comment":
+		return true
+	default:
+		return false
+	}
+}
+
+// canDirect reports whether a commenter may change the PR's Discord behavior:
+// the PR author, or anyone with write-level association to the repo (which covers reviewers).
+func canDirect(comment PRComment, prAuthor string) bool {
+	if prAuthor != "" && strings.EqualFold(comment.Author, prAuthor) {
+		return true
+	}
+	switch comment.Association {
+	case "OWNER", "MEMBER", "COLLABORATOR":
+		return true
+	default:
+		return false
+	}
+}
+
+// applyCommentDirectives reads the PR's recent comments and applies any /discordian
+// directives newer than the last one applied.
+func (c *Coordinator) applyCommentDirectives(ctx context.Context, owner, repo string, number int, prAuthor string) {
+	if c.comments == nil {
+		return
+	}
+	comments, err := c.comments.RecentComments(ctx, owner, repo, number)
+	if err != nil {
+		c.logger.Warn("failed to read PR comments for directives", "error", err, "repo", repo, "number", number)
+		return
+	}
+
+	prURL := FormatPRURL(owner, repo, number)
+	override, _ := c.store.PROverride(ctx, prURL)
+	changed := false
+	for _, comment := range comments {
+		if !comment.CreatedAt.After(override.UpdatedAt) {
+			continue
+		}
+		directives := parseDirectives(comment.Body)
+		if len(directives) == 0 {
+			continue
+		}
+		if !canDirect(comment, prAuthor) {
+			c.logger.Info("ignoring PR directive from user without permission",
+				"pr_url", prURL,
+				"user", comment.Author,
+				"association", comment.Association)
+			continue
+		}
+		for _, d := range directives {
+			if d.kind == directiveChannel && !c.mayRouteTo(comment, repo, d.arg) {
+				c.logger.Info("ignoring PR channel directive to an unconfigured channel",
+					"pr_url", prURL,
+					"user", comment.Author,
+					"channel", d.arg)
+				continue
+			}
+			override = applyDirective(override, d)
+		}
+		override.UpdatedAt = comment.CreatedAt
+		override.SetBy = comment.Author
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	c.logger.Info("applied PR comment directives",
+		"pr_url", prURL,
+		"muted", override.Muted,
+		"channel", override.Channel,
+		"set_by", override.SetBy)
+	if err := c.store.SavePROverride(ctx, prURL, override); err != nil {
+		c.logger.Warn("failed to save PR override", "error", err, "pr_url", prURL)
+	}
+}
+
+// closesPostedPR reports whether a PR is merged or closed and already has channel messages.
+// Muting doesn't hold back that update, so the messages don't stay open forever.
+func (c *Coordinator) closesPostedPR(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	override state.PROverride,
+	prState format.PRState,
+) bool {
+	if prState != format.StateMerged && prState != format.StateClosed {
+		return false
+	}
+	channels := c.routedChannels(ctx, repo, checkResp.PullRequest.Author, override)
+	return c.lastThread(ctx, owner, repo, number, channels).LastState != ""
+}
+
+// mayRouteTo reports whether a commenter may send the PR to a channel: any of the org's
+// configured channels, or any channel at all for repo owners.
+func (c *Coordinator) mayRouteTo(comment PRComment, repo, channel string) bool {
+	if channel == "" || comment.Association == "OWNER" {
+		return true
+	}
+	if slices.Contains(c.config.ChannelsForRepo(c.org, repo), channel) {
+		return true
+	}
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return false
+	}
+	_, configured := cfg.Channels[channel]
+	return configured
+}
+
+func applyDirective(override state.PROverride, d prDirective) state.PROverride {
+	switch d.kind {
+	case directiveMute:
+		override.Muted = true
+	case directiveUnmute:
+		override.Muted = false
+	case directiveChannel:
+		override.Channel = d.arg
+	default:
+	}
+	return override
+}
@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+type mockCommentReader struct {
+	comments []PRComment
+	err      error
+}
+
+func (m *mockCommentReader) RecentComments(_ context.Context, _, _ string, _ int) ([]PRComment, error) {
+	return m.comments, m.err
+}
+
+func TestParseDirectives(t *testing.T) {
+	body := "Thanks!\n/discordian mute\n/Discordian CHANNEL #releases\n/discordian frobnicate\n  /discordian channel\n"
+	want := []prDirective{
+		{kind: directiveMute},
+		{kind: directiveChannel, arg: "releases"},
+		{kind: directiveChannel},
+	}
+	if got := parseDirectives(body); !slices.Equal(got, want) {
+		t.Errorf("parseDirectives() = %+v, want %+v", got, want)
+	}
+	if got := parseDirectives("please /discordian mute this"); len(got) != 0 {
+		t.Errorf("parseDirectives() = %+v, want none for mid-line directive", got)
+	}
+}
+
+func newDirectiveTestCoordinator(discord *mockDiscordClient, comments *mockCommentReader) *Coordinator {
+	turn := newMockTurnClient()
+	turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+	return NewCoordinator(CoordinatorConfig{
+		Discord:  discord,
+		Config:   newMockConfigManager(),
+		Store:    state.NewMemoryStore(),
+		Turn:     turn,
+		Comments: comments,
+		Org:      "testorg",
+	})
+}
+
+func TestCoordinator_MuteDirective_SuppressesPosts(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	comments := &mockCommentReader{}
+	coord := newDirectiveTestCoordinator(discord, comments)
+	prURL := "https://github.com/testorg/testrepo/pull/42"
+
+	comments.comments = []PRComment{{
+		CreatedAt:   time.Now(),
+		Author:      "alice",
+		Association: "CONTRIBUTOR",
+		Body:        "/discordian mute",
+	}}
+	for i, eventType := range []string{"issue_comment", "pull_request", "check_run"} {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: eventType, DeliveryID: "delivery-" + eventType})
+		coord.Wait()
+		if len(discord.postedMessages) != 0 {
+			t.Fatalf("event %d (%s): postedMessages = %d, want 0 while muted", i, eventType, len(discord.postedMessages))
+		}
+	}
+
+	// Unmuting resumes posts
+	comments.comments = append(comments.comments, PRComment{
+		CreatedAt:   time.Now().Add(time.Second),
+		Author:      "alice",
+		Association: "CONTRIBUTOR",
+		Body:        "/discordian unmute",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "issue_comment", DeliveryID: "delivery-unmute"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after unmute, want 1", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_MuteDirective_IgnoredFromOutsider(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	coord := newDirectiveTestCoordinator(discord, &mockCommentReader{comments: []PRComment{{
+		CreatedAt:   time.Now(),
+		Author:      "mallory",
+		Association: "NONE",
+		Body:        "/discordian mute",
+	}}})
+
+	coord.ProcessEvent(context.Background(), SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "issue_comment",
+		DeliveryID: "delivery-1",
+	})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d, want 1 (directive from outsider ignored)", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_ChannelDirective_Routes(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.channelIDs["releases"] = "chan-releases"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.botInChannel["chan-releases"] = true
+
+	coord := newDirectiveTestCoordinator(discord, &mockCommentReader{comments: []PRComment{{
+		CreatedAt:   time.Now(),
+		Author:      "bob",
+		Association: "MEMBER",
+		Body:        "/discordian channel #releases",
+	}}})
+	coord.config.(*mockConfigManager).configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"releases": {}},
+	}
+
+	coord.ProcessEvent(context.Background(), SprinklerEvent{
+		URL:        "https://github.com/testorg/testrepo/pull/42",
+		Type:       "pull_request_review_comment",
+		DeliveryID: "delivery-1",
+	})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if got := discord.postedMessages[0].channelID; got != "chan-releases" {
+		t.Errorf("posted to %s, want chan-releases", got)
+	}
+}
+
+func TestCoordinator_ChannelDirective_OnlyConfiguredChannels(t *testing.T) {
+	tests := []struct {
+		name        string
+		association string
+		wantChannel string
+	}{
+		{"author can't pick an unconfigured channel", "CONTRIBUTOR", "chan-testrepo"},
+		{"repo owner can pick any channel", "OWNER", "chan-secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.channelIDs["secret"] = "chan-secret"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.botInChannel["chan-secret"] = true
+
+			coord := newDirectiveTestCoordinator(discord, &mockCommentReader{comments: []PRComment{{
+				CreatedAt:   time.Now(),
+				Author:      "alice",
+				Association: tt.association,
+				Body:        "/discordian channel #secret",
+			}}})
+
+			coord.ProcessEvent(context.Background(), SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "issue_comment",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			if got := discord.postedMessages[0].channelID; got != tt.wantChannel {
+				t.Errorf("posted to %s, want %s", got, tt.wantChannel)
+			}
+		})
+	}
+}
+
+func TestCoordinator_MuteDirective_UpdatesOnMerge(t *testing.T) {
+	ctx := context.Background()
+	const prURL = "https://github.com/testorg/testrepo/pull/42"
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	comments := &mockCommentReader{}
+	coord := newDirectiveTestCoordinator(discord, comments)
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-open"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1 before muting", len(discord.postedMessages))
+	}
+
+	comments.comments = []PRComment{{
+		CreatedAt:   time.Now(),
+		Author:      "alice",
+		Association: "CONTRIBUTOR",
+		Body:        "/discordian mute",
+	}}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "issue_comment", DeliveryID: "delivery-mute"})
+	coord.Wait()
+
+	coord.turn.(*mockTurnClient).responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "closed", Merged: true},
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-merge"})
+	coord.Wait()
+
+	if len(discord.updatedMessages) == 0 {
+		t.Error("updatedMessages = 0, want the muted PR's message updated on merge")
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d, want no new posts while muted", len(discord.postedMessages))
+	}
+}
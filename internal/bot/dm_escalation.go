@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// EscalateUnacknowledgedDMs sends one follow-up DM, in stronger wording, to each user whose
+// DM about an open PR has gone unacknowledged for the org's dm_escalation_after while the PR
+// still waits on them. A DM is acknowledged by reacting to the PR's message, /goose done,
+// muting or snoozing it, or the PR changing state. Each user+PR is escalated at most once.
+func (c *Coordinator) EscalateUnacknowledgedDMs(ctx context.Context) {
+	after := c.config.DMEscalationAfter(c.org)
+	if after <= 0 || c.inMaintenance(ctx) {
+		return
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, tracked := range c.store.ListThreads(ctx, c.org) {
+		prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+		if seen[prURL] {
+			continue
+		}
+		seen[prURL] = true
+		if s := format.PRState(tracked.Info.LastState); s == format.StateMerged || s == format.StateClosed {
+			continue
+		}
+
+		var due []string
+		for _, discordID := range c.store.ListDMUsers(ctx, prURL) {
+			if info, ok := c.store.DMInfo(ctx, discordID, prURL); ok && dmEscalationDue(info, after, now) {
+				due = append(due, discordID)
+			}
+		}
+		if len(due) > 0 {
+			c.escalateDMs(ctx, tracked, due)
+		}
+	}
+}
+
+// dmEscalationDue reports whether a DM is old enough to follow up on and hasn't been
+// followed up on, muted, or snoozed.
+func dmEscalationDue(info state.DMInfo, after time.Duration, now time.Time) bool {
+	return info.EscalationLevel == 0 &&
+		!info.Muted &&
+		!info.SentAt.IsZero() &&
+		now.Sub(info.SentAt) >= after &&
+		!now.Before(info.SnoozedUntil)
+}
+
+// escalateDMs sends the follow-up DM to each of a PR's due users who it still waits on
+// and who hasn't acknowledged their DM in the PR's current state.
+func (c *Coordinator) escalateDMs(ctx context.Context, tracked state.TrackedThread, discordIDs []string) {
+	prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+	checkResp, err := c.checkTurn(ctx, prURL, "", time.Now())
+	if err != nil {
+		c.logger.Warn("failed to check PR for DM escalation", "error", err, "pr_url", prURL)
+		return
+	}
+	prState := prStateFromCheck(checkResp)
+	if prState == format.StateMerged || prState == format.StateClosed {
+		return
+	}
+	override, _ := c.store.PROverride(ctx, prURL)
+	ackedBy := acknowledgedBy(override, prState)
+
+	for _, discordID := range discordIDs {
+		username := c.actionTargetForDiscordUser(ctx, checkResp, discordID)
+		if username == "" || username == ackedBy || c.isOOO(ctx, username) || c.shouldSkipDM(ctx, discordID, username) {
+			continue
+		}
+		c.escalateDM(ctx, tracked, checkResp, prState, discordID, username)
+	}
+}
+
+// escalateDM sends one user's follow-up DM about a PR and records it, so it's sent once.
+func (c *Coordinator) escalateDM(
+	ctx context.Context,
+	tracked state.TrackedThread,
+	checkResp *CheckResponse,
+	prState format.PRState,
+	discordID, username string,
+) {
+	prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+	dmLock := c.dmLocks.get(discordID + ":" + prURL)
+	dmLock.Lock()
+	defer dmLock.Unlock()
+
+	info, ok := c.store.DMInfo(ctx, discordID, prURL)
+	if !ok || info.EscalationLevel > 0 || info.HandledState == string(prState) {
+		return
+	}
+	// A DM from before the PR's last state change is acknowledged by that change; the
+	// DM's update for the new state restarts the clock
+	if info.LastState != "" && info.LastState != string(prState) {
+		return
+	}
+	// Another instance may be escalating the same DM
+	if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"dm-escalation:"+discordID, eventDeduplicationTTL) {
+		return
+	}
+
+	msg := format.DMEscalationMessage(format.ChannelMessageParams{
+		Owner:  tracked.Owner,
+		Repo:   tracked.Repo,
+		Number: tracked.Number,
+		Title:  checkResp.PullRequest.Title,
+		Author: checkResp.PullRequest.Author,
+		State:  prState,
+		PRURL:  prURL,
+	}, format.ActionLabel(checkResp.Analysis.NextAction[username].Kind), time.Since(info.SentAt))
+	if _, _, err := c.sendDM(ctx, discordID, msg); err != nil {
+		c.logger.Warn("failed to send DM escalation",
+			"error", err,
+			"user", username,
+			"pr_url", prURL)
+		return
+	}
+
+	info.EscalationLevel = 1
+	if err := c.saveDMInfo(ctx, discordID, prURL, info); err != nil {
+		c.logger.Warn("failed to save DM escalation", "error", err, "pr_url", prURL)
+	}
+	c.logger.Info("escalated unacknowledged DM",
+		"user", username,
+		"pr_url", prURL,
+		"waiting", time.Since(info.SentAt))
+}
@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_EscalateUnacknowledgedDMs(t *testing.T) {
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "bob", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"alice": {Kind: "review"}}},
+	}
+	prState := prStateFromCheck(checkResp)
+
+	tests := []struct {
+		name    string
+		sentAgo time.Duration
+		ack     func(ctx context.Context, t *testing.T, store *state.MemoryStore, info *state.DMInfo)
+		wantDMs int
+	}{
+		{name: "unacknowledged, escalated once", sentAgo: 2 * time.Hour, wantDMs: 1},
+		{name: "not yet due", sentAgo: 30 * time.Minute},
+		{
+			name: "acknowledged with /goose done", sentAgo: 2 * time.Hour,
+			ack: func(_ context.Context, _ *testing.T, _ *state.MemoryStore, info *state.DMInfo) {
+				info.HandledState = string(prState)
+			},
+		},
+		{
+			name: "acknowledged with a reaction", sentAgo: 2 * time.Hour,
+			ack: func(ctx context.Context, t *testing.T, store *state.MemoryStore, _ *state.DMInfo) {
+				t.Helper()
+				override := state.PROverride{AcknowledgedBy: "alice", AcknowledgedState: string(prState)}
+				if err := store.SavePROverride(ctx, prURL, override); err != nil {
+					t.Fatalf("SavePROverride() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "acknowledged by a state change", sentAgo: 2 * time.Hour,
+			ack: func(_ context.Context, _ *testing.T, _ *state.MemoryStore, info *state.DMInfo) {
+				info.LastState = "tests_broken"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.usersInGuild["discord-alice"] = true
+			mapper := newMockUserMapper()
+			mapper.mappings["alice"] = "discord-alice"
+			configMgr := newMockConfigManager()
+			configMgr.dmEscalation = time.Hour
+			turn := newMockTurnClient()
+			turn.responses[prURL] = checkResp
+
+			store := state.NewMemoryStore()
+			if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+				ChannelID: "chan-testrepo",
+				MessageID: "msg-1",
+				LastState: string(prState),
+			}); err != nil {
+				t.Fatalf("SaveThread() error = %v", err)
+			}
+			info := state.DMInfo{
+				ChannelID: "dm-chan-discord-alice",
+				MessageID: "dm-msg-1",
+				LastState: string(prState),
+				SentAt:    time.Now().Add(-tt.sentAgo),
+			}
+			if tt.ack != nil {
+				tt.ack(ctx, t, store, &info)
+			}
+			if err := store.SaveDMInfo(ctx, "discord-alice", prURL, info); err != nil {
+				t.Fatalf("SaveDMInfo() error = %v", err)
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			// A second pass must not send another follow-up
+			coord.EscalateUnacknowledgedDMs(ctx)
+			coord.EscalateUnacknowledgedDMs(ctx)
+
+			if len(discord.sentDMs) != tt.wantDMs {
+				t.Fatalf("sentDMs = %d, want %d", len(discord.sentDMs), tt.wantDMs)
+			}
+			if tt.wantDMs == 0 {
+				return
+			}
+			if dm := discord.sentDMs[0]; dm.userID != "discord-alice" || !strings.Contains(dm.text, "Still waiting on you to review") {
+				t.Errorf("sentDMs[0] = %+v, want a follow-up to alice", dm)
+			}
+			if got, _ := store.DMInfo(ctx, "discord-alice", prURL); got.EscalationLevel != 1 {
+				t.Errorf("EscalationLevel = %d, want 1", got.EscalationLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+)
+
+// sendDM sends a DM, falling back to a mention in the org's dm_fallback_channel if the user
+// doesn't accept DMs from the bot. A fallback returns no channel or message ID, as there's
+// no DM to edit later.
+func (c *Coordinator) sendDM(ctx context.Context, discordID, text string) (channelID, messageID string, err error) {
+	channelID, messageID, err = c.discord.SendDM(ctx, discordID, text)
+	if !errors.Is(err, discord.ErrDMBlocked) {
+		return channelID, messageID, err
+	}
+	if ferr := c.DMFallback(ctx, discordID, text); ferr != nil {
+		return "", "", fmt.Errorf("%w (fallback: %w)", err, ferr)
+	}
+	return "", "", nil
+}
+
+// DMFallback reaches a user who doesn't accept DMs from the bot by mentioning them with the
+// DM's text in the org's dm_fallback_channel. It fails if none is configured or found.
+func (c *Coordinator) DMFallback(ctx context.Context, discordID, text string) error {
+	name := c.config.DMFallbackChannel(c.org)
+	if name == "" {
+		return errors.New("no dm_fallback_channel configured")
+	}
+	channelID := c.discord.ResolveChannelID(ctx, name)
+	if channelID == name {
+		return fmt.Errorf("dm_fallback_channel %q not found", name)
+	}
+	if _, err := c.discord.PostMessage(ctx, channelID, fmt.Sprintf("<@%s> %s", discordID, text)); err != nil {
+		return fmt.Errorf("post to dm_fallback_channel: %w", err)
+	}
+	c.logger.Info("user doesn't accept DMs, mentioned them in the fallback channel",
+		"user_id", discordID,
+		"channel", name)
+	return nil
+}
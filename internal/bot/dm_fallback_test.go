@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_sendDM_Fallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		blocked     bool
+		fallback    string
+		wantDM      bool
+		wantMention bool
+		wantErr     bool
+	}{
+		{name: "accepts DMs", fallback: "pr-pings", wantDM: true},
+		{name: "blocked, mentioned in fallback channel", blocked: true, fallback: "pr-pings", wantMention: true},
+		{name: "blocked, no fallback channel", blocked: true, wantErr: true},
+		{name: "blocked, fallback channel not found", blocked: true, fallback: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockDiscordClient()
+			mock.channelIDs["pr-pings"] = "chan-pings"
+			if tt.blocked {
+				mock.dmBlocked = map[string]bool{"discord-alice": true}
+			}
+			configMgr := newMockConfigManager()
+			configMgr.dmFallback = tt.fallback
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: mock,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			_, _, err := coord.sendDM(context.Background(), "discord-alice", "Your PR was closed")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sendDM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, discord.ErrDMBlocked) {
+				t.Errorf("sendDM() error = %v, want ErrDMBlocked", err)
+			}
+			if got := len(mock.sentDMs) == 1; got != tt.wantDM {
+				t.Errorf("sentDMs = %d, want DM %v", len(mock.sentDMs), tt.wantDM)
+			}
+			mentioned := len(mock.postedMessages) == 1 &&
+				mock.postedMessages[0].channelID == "chan-pings" &&
+				mock.postedMessages[0].text == "<@discord-alice> Your PR was closed"
+			if mentioned != tt.wantMention {
+				t.Errorf("postedMessages = %+v, want mention %v", mock.postedMessages, tt.wantMention)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package bot
+
+// draftFromSearch builds the check response for a draft PR from the search result a poll found
+// it in, so orgs with skip_turn_for_drafts don't spend Turn budget re-analyzing drafts. Webhook
+// events don't say whether a PR is a draft, so they're always analyzed by Turn; that includes
+// the event for a draft being marked ready for review. It reports false, meaning Turn should be
+// called, unless the search result is a draft with the title and author needed to render it.
+func (c *Coordinator) draftFromSearch(event SprinklerEvent) (*CheckResponse, bool) {
+	pr := event.searched
+	if pr == nil || !pr.Draft || !c.config.SkipTurnForDrafts(c.org) {
+		return nil, false
+	}
+	if pr.Title == "" || pr.Author == "" {
+		return nil, false
+	}
+
+	c.logger.Debug("rendering draft PR from search result without calling Turn",
+		"pr_url", event.URL,
+		"delivery_id", event.DeliveryID)
+	return &CheckResponse{
+		PullRequest: PRInfo{Title: pr.Title, Author: pr.Author, State: "open", Draft: true},
+	}, true
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_PollAndReconcile_SkipTurnForDrafts(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.skipDraftTurn = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Early work", Author: "alice", State: "open"},
+	}
+	searcher := &mockPRSearcher{openPRs: []PRSearchResult{{
+		URL: prURL, Owner: "testorg", Repo: "testrepo", Number: 1,
+		Title: "Early work", Author: "alice", Draft: true, UpdatedAt: time.Now().Add(-time.Minute),
+	}}}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:  discord,
+		Config:   configMgr,
+		Store:    state.NewMemoryStore(),
+		Turn:     turn,
+		Searcher: searcher,
+		Org:      "testorg",
+	})
+
+	coord.PollAndReconcile(ctx)
+
+	if turn.callCount != 0 {
+		t.Errorf("Turn calls = %d for a draft found by the poll, want 0", turn.callCount)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	// Drafts render like any draft analyzed by Turn
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "st="+string(format.StateTestsRunning)) || !strings.Contains(text, "Early work · alice") {
+		t.Errorf("message = %q, want a draft message for the PR", text)
+	}
+
+	// Webhook events carry no draft flag, so they're analyzed by Turn
+	coord.ProcessEvent(ctx, SprinklerEvent{
+		URL:        prURL,
+		Type:       "pull_request",
+		DeliveryID: "d-ready",
+		Timestamp:  time.Now(),
+	})
+	coord.Wait()
+
+	if turn.callCount != 1 {
+		t.Errorf("Turn calls = %d after a webhook event, want 1", turn.callCount)
+	}
+}
+
+func TestCoordinator_draftFromSearch(t *testing.T) {
+	draft := &PRSearchResult{Title: "Early work", Author: "alice", Draft: true}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		searched *PRSearchResult
+		want     bool
+	}{
+		{"draft with title and author", true, draft, true},
+		{"disabled", false, draft, false},
+		{"not a draft", true, &PRSearchResult{Title: "Early work", Author: "alice"}, false},
+		{"missing title", true, &PRSearchResult{Author: "alice", Draft: true}, false},
+		{"webhook event", true, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMgr := newMockConfigManager()
+			configMgr.skipDraftTurn = tt.enabled
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: newMockDiscordClient(),
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			resp, ok := coord.draftFromSearch(SprinklerEvent{URL: "https://github.com/testorg/testrepo/pull/1", searched: tt.searched})
+			if ok != tt.want {
+				t.Fatalf("draftFromSearch() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && (!resp.PullRequest.Draft || resp.PullRequest.Title != "Early work" || resp.PullRequest.Author != "alice") {
+				t.Errorf("draftFromSearch() = %+v, want draft PR info from the search result", resp.PullRequest)
+			}
+		})
+	}
+}
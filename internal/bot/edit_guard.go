@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// Edit loop detection. A PR's message is edited when its state changes, so even a busy PR
+// stays well under editLoopMaxEdits per window; more than that means something is flapping.
+const (
+	editLoopWindow   = 10 * time.Minute
+	editLoopMaxEdits = 30
+	editLoopCooldown = time.Hour
+)
+
+// editLoopGuard counts recent edits per message and halts editing a message that is being
+// edited too often, such as when conflicting logic alternates its content.
+type editLoopGuard struct {
+	swept  time.Time              // When messages no longer edited were last dropped
+	edits  map[string][]time.Time // channelID/messageID -> edit times within editLoopWindow
+	halted map[string]time.Time   // channelID/messageID -> when editing may resume
+	mu     sync.Mutex
+}
+
+// allow reports whether a message may be edited at now, recording the edit if so.
+// It reports halted=true the moment a message trips the guard, so the caller logs it once.
+func (g *editLoopGuard) allow(key string, now time.Time) (ok, halted bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if until, exists := g.halted[key]; exists {
+		if now.Before(until) {
+			return false, false
+		}
+		delete(g.halted, key)
+		delete(g.edits, key)
+	}
+
+	if g.edits == nil {
+		g.edits = make(map[string][]time.Time)
+		g.halted = make(map[string]time.Time)
+	}
+	if now.Sub(g.swept) >= editLoopWindow {
+		g.sweep(now)
+	}
+	recent := g.edits[key][:0]
+	for _, t := range g.edits[key] {
+		if now.Sub(t) < editLoopWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= editLoopMaxEdits {
+		g.halted[key] = now.Add(editLoopCooldown)
+		delete(g.edits, key)
+		return false, true
+	}
+	g.edits[key] = append(recent, now)
+	return true, false
+}
+
+// sweep drops messages with no edits within editLoopWindow and halts that have lapsed, so
+// the guard doesn't grow with every message ever edited. Callers hold g.mu.
+func (g *editLoopGuard) sweep(now time.Time) {
+	for key, times := range g.edits {
+		if now.Sub(times[len(times)-1]) >= editLoopWindow {
+			delete(g.edits, key)
+		}
+	}
+	for key, until := range g.halted {
+		if !now.Before(until) {
+			delete(g.halted, key)
+		}
+	}
+	g.swept = now
+}
+
+// editAllowed reports whether the bot may edit a channel message or forum post, halting
+// edits to one that is edited in a loop until editLoopCooldown passes.
+func (c *Coordinator) editAllowed(channelID, messageID, prURL string) bool {
+	ok, halted := c.editGuard.allow(channelID+"/"+messageID, time.Now())
+	if halted {
+		c.logger.Error("message is being edited in a loop, halting edits to it",
+			"channel_id", channelID,
+			"message_id", messageID,
+			"pr", prURL,
+			"max_edits", editLoopMaxEdits,
+			"window", editLoopWindow,
+			"cooldown", editLoopCooldown)
+	}
+	return ok
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+func TestEditLoopGuard_Allow(t *testing.T) {
+	var g editLoopGuard
+	now := time.Now()
+
+	for i := range editLoopMaxEdits {
+		if ok, _ := g.allow("chan/msg", now); !ok {
+			t.Fatalf("edit %d halted, want allowed under the threshold", i+1)
+		}
+	}
+	ok, halted := g.allow("chan/msg", now)
+	if ok || !halted {
+		t.Fatalf("allow() over threshold = (%v, %v), want (false, true)", ok, halted)
+	}
+	if ok, halted := g.allow("chan/msg", now.Add(time.Minute)); ok || halted {
+		t.Errorf("allow() while halted = (%v, %v), want (false, false)", ok, halted)
+	}
+	if ok, _ := g.allow("chan/other", now); !ok {
+		t.Error("other messages should still be editable")
+	}
+
+	if ok, _ := g.allow("chan/msg", now.Add(editLoopCooldown)); !ok {
+		t.Error("allow() after cooldown = false, want edits to resume")
+	}
+}
+
+func TestEditLoopGuard_Sweeps(t *testing.T) {
+	var g editLoopGuard
+	now := time.Now()
+
+	g.allow("chan/old", now)
+	for range editLoopMaxEdits + 1 {
+		g.allow("chan/looping", now)
+	}
+	// Once the window and cooldown pass, any edit drops both
+	g.allow("chan/new", now.Add(editLoopCooldown))
+	if _, ok := g.edits["chan/old"]; ok {
+		t.Error("edits kept for a message not edited within the window")
+	}
+	if _, ok := g.halted["chan/looping"]; ok {
+		t.Error("halt kept after its cooldown")
+	}
+	if _, ok := g.edits["chan/new"]; !ok {
+		t.Error("edit of chan/new not recorded")
+	}
+}
+
+func TestEditLoopGuard_WindowSlides(t *testing.T) {
+	var g editLoopGuard
+	start := time.Now()
+
+	// Edits spread out over longer than the window never trip the guard
+	for i := range 3 * editLoopMaxEdits {
+		at := start.Add(time.Duration(i) * editLoopWindow / editLoopMaxEdits * 2)
+		if ok, _ := g.allow("chan/msg", at); !ok {
+			t.Fatalf("edit %d halted, want steady edits allowed", i+1)
+		}
+	}
+}
+
+func TestCoordinator_processTextChannel_EditLoopHalted(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	store := newMockStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	// Content alternates on every event, as conflicting logic might cause
+	for i := range editLoopMaxEdits + 5 {
+		params := format.ChannelMessageParams{
+			PRURL:       "https://github.com/testorg/repo/pull/1",
+			Number:      1,
+			Repo:        "repo",
+			Title:       fmt.Sprintf("Title %d", i%2),
+			Author:      "alice",
+			State:       format.StateNeedsReview,
+			ChannelName: "repo",
+		}
+		info, _ := store.Thread(ctx, "testorg", "repo", 1, "chan-repo")
+		info.MessageID = "msg-1"
+		info.ChannelID = "chan-repo"
+		if err := coord.processTextChannel(ctx, &channelProcessParams{
+			channelID:  "chan-repo",
+			owner:      "testorg",
+			repo:       "repo",
+			number:     1,
+			params:     params,
+			checkResp:  &CheckResponse{},
+			threadInfo: info,
+			exists:     true,
+		}); err != nil {
+			t.Fatalf("processTextChannel() error = %v", err)
+		}
+	}
+
+	if len(discord.updatedMessages) != editLoopMaxEdits {
+		t.Errorf("updatedMessages = %d, want edits to stop at %d", len(discord.updatedMessages), editLoopMaxEdits)
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("postedMessages = %d, want no new messages once edits halt", len(discord.postedMessages))
+	}
+}
@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// escalationReviewers is the escalation target that pings the PR's requested reviewers.
+const escalationReviewers = "reviewers"
+
+// trackEscalation records when a PR started needing review in a channel with an
+// escalation ladder, and resets the ladder once the PR no longer needs review.
+func (c *Coordinator) trackEscalation(
+	ctx context.Context,
+	channelID, prURL string,
+	prState format.PRState,
+	actionUsers []format.ActionUser,
+) {
+	c.escalationMu.Lock()
+	defer c.escalationMu.Unlock()
+
+	info, exists := c.store.Escalation(ctx, channelID, prURL)
+	waiting := exists && !info.NeedsReviewSince.IsZero()
+
+	if prState != format.StateNeedsReview {
+		if waiting {
+			if err := c.store.SaveEscalation(ctx, channelID, prURL, state.EscalationInfo{}); err != nil {
+				c.logger.Warn("failed to reset review escalation", "pr", prURL, "error", err)
+			}
+		}
+		return
+	}
+
+	reviewers := reviewerMentions(actionUsers)
+	if waiting && slices.Equal(info.Reviewers, reviewers) {
+		return
+	}
+	if !waiting {
+		info = state.EscalationInfo{NeedsReviewSince: time.Now()}
+	}
+	info.Reviewers = reviewers
+	if err := c.store.SaveEscalation(ctx, channelID, prURL, info); err != nil {
+		c.logger.Warn("failed to save review escalation", "pr", prURL, "error", err)
+	}
+}
+
+// reviewerMentions returns the sorted mentions of users whose next action is a review.
+func reviewerMentions(actionUsers []format.ActionUser) []string {
+	var mentions []string
+	for _, u := range actionUsers {
+		if strings.Contains(u.Action, "review") {
+			mentions = append(mentions, u.Mention)
+		}
+	}
+	slices.Sort(mentions)
+	return mentions
+}
+
+// EscalateStalledReviews pings the next target on each channel's escalation ladder
+// for PRs that have needed review longer than that step's delay. Each step is claimed
+// in the shared store, so it is sent once across instances.
+func (c *Coordinator) EscalateStalledReviews(ctx context.Context) {
+	if c.inMaintenance(ctx) {
+		return
+	}
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return
+	}
+
+	var threads []state.TrackedThread
+	for _, channelName := range slices.Sorted(maps.Keys(cfg.Channels)) {
+		steps := c.config.Escalation(c.org, channelName)
+		if len(steps) == 0 {
+			continue
+		}
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if threads == nil {
+			threads = c.store.ListThreads(ctx, c.org)
+		}
+		for _, tracked := range threads {
+			if tracked.Info.ChannelID != channelID || tracked.Info.LastState != string(format.StateNeedsReview) {
+				continue
+			}
+			c.escalate(ctx, tracked, steps)
+		}
+	}
+}
+
+// escalate sends the highest escalation step a PR has newly crossed, if any.
+func (c *Coordinator) escalate(ctx context.Context, tracked state.TrackedThread, steps []config.EscalationStep) {
+	c.escalationMu.Lock()
+	defer c.escalationMu.Unlock()
+
+	prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+	channelID := tracked.Info.ChannelID
+	info, exists := c.store.Escalation(ctx, channelID, prURL)
+	if !exists || info.NeedsReviewSince.IsZero() {
+		return
+	}
+
+	waiting := time.Since(info.NeedsReviewSince)
+	level := info.Level
+	for level < len(steps) && waiting >= steps[level].After {
+		level++
+	}
+	if level == info.Level {
+		return
+	}
+
+	// Another instance may have reached the same step first
+	key := EventKeyPrefix(prURL) + "escalation:" + channelID + ":" +
+		strconv.FormatInt(info.NeedsReviewSince.UnixNano(), 10) + ":" + strconv.Itoa(level)
+	if !c.store.ClaimEvent(ctx, key, eventDeduplicationTTL) {
+		return
+	}
+
+	step := steps[level-1]
+	text := format.EscalationMessage(tracked.Owner, tracked.Repo, tracked.Number, prURL,
+		c.escalationMentions(ctx, step.Target, info.Reviewers), waiting)
+
+	// Forum escalations go in the PR's thread; text channels get a new message
+	target := channelID
+	if tracked.Info.ChannelType == "forum" && tracked.Info.ThreadID != "" {
+		target = tracked.Info.ThreadID
+	}
+	if _, err := c.discord.PostMessage(ctx, target, text); err != nil {
+		c.logger.Warn("failed to post review escalation",
+			"pr", prURL,
+			"level", level,
+			"error", err)
+		return
+	}
+	c.counters.posts.Add(1)
+
+	c.logger.Info("escalated PR waiting for review",
+		"pr", prURL,
+		"level", level,
+		"target", step.Target,
+		"waiting", waiting)
+	info.Level = level
+	if err := c.store.SaveEscalation(ctx, channelID, prURL, info); err != nil {
+		c.logger.Warn("failed to save review escalation", "pr", prURL, "error", err)
+	}
+}
+
+// escalationMentions resolves an escalation target: the PR's reviewers,
+// a literal Discord mention, or a GitHub username.
+func (c *Coordinator) escalationMentions(ctx context.Context, target string, reviewers []string) string {
+	switch {
+	case target == escalationReviewers:
+		return strings.Join(reviewers, " ")
+	case strings.HasPrefix(target, "<@"):
+		return target
+	case c.UserMapper != nil:
+		return c.UserMapper.Mention(ctx, target)
+	default:
+		return target
+	}
+}
@@ -0,0 +1,202 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_EscalateStalledReviews(t *testing.T) {
+	ctx := context.Background()
+	const prURL = "https://github.com/testorg/testrepo/pull/1"
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {}},
+	}
+	configMgr.escalation = map[string][]config.EscalationStep{
+		"testrepo": {
+			{After: time.Hour, Target: "reviewers"},
+			{After: 4 * time.Hour, Target: "<@&999>"},
+			{After: 8 * time.Hour, Target: "lead"},
+		},
+	}
+
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+	}
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "111"
+	mapper.mappings["lead"] = "222"
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1"})
+	coord.Wait()
+	posted := len(discord.postedMessages)
+
+	info, ok := store.Escalation(ctx, "chan-testrepo", prURL)
+	if !ok || info.NeedsReviewSince.IsZero() {
+		t.Fatalf("Escalation() = %+v, %v; want needs-review start recorded", info, ok)
+	}
+
+	// waitFor backdates when the PR started needing review
+	waitFor := func(d time.Duration) {
+		t.Helper()
+		info, _ := store.Escalation(ctx, "chan-testrepo", prURL)
+		info.NeedsReviewSince = time.Now().Add(-d)
+		if err := store.SaveEscalation(ctx, "chan-testrepo", prURL, info); err != nil {
+			t.Fatalf("SaveEscalation() error = %v", err)
+		}
+	}
+
+	// Before the first threshold nothing is sent
+	coord.EscalateStalledReviews(ctx)
+	if len(discord.postedMessages) != posted {
+		t.Fatalf("postedMessages = %d before first threshold, want %d", len(discord.postedMessages), posted)
+	}
+
+	steps := []struct {
+		waited  time.Duration
+		mention string
+	}{
+		{90 * time.Minute, "<@111>"},
+		{5 * time.Hour, "<@&999>"},
+		{9 * time.Hour, "<@222>"},
+	}
+	for i, step := range steps {
+		waitFor(step.waited)
+		coord.EscalateStalledReviews(ctx)
+		coord.EscalateStalledReviews(ctx)
+
+		if got := len(discord.postedMessages); got != posted+i+1 {
+			t.Fatalf("after %v: postedMessages = %d, want %d (one escalation per threshold)", step.waited, got, posted+i+1)
+		}
+		msg := discord.postedMessages[len(discord.postedMessages)-1]
+		if msg.channelID != "chan-testrepo" || !strings.HasPrefix(msg.text, "⏰ "+step.mention+" ") {
+			t.Errorf("after %v: escalation = %+v, want mention %s", step.waited, msg, step.mention)
+		}
+	}
+
+	// Past the last step nothing more is sent
+	waitFor(48 * time.Hour)
+	coord.EscalateStalledReviews(ctx)
+	if got := len(discord.postedMessages); got != posted+len(steps) {
+		t.Errorf("postedMessages = %d past the last step, want %d", got, posted+len(steps))
+	}
+}
+
+func TestCoordinator_trackEscalation_ResetsWhenReviewed(t *testing.T) {
+	ctx := context.Background()
+	const prURL = "https://github.com/testorg/testrepo/pull/1"
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.escalation = map[string][]config.EscalationStep{"testrepo": {{After: time.Hour, Target: "reviewers"}}}
+
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-1"})
+	coord.Wait()
+	if err := store.SaveEscalation(ctx, "chan-testrepo", prURL, state.EscalationInfo{
+		NeedsReviewSince: time.Now().Add(-2 * time.Hour),
+		Level:            1,
+	}); err != nil {
+		t.Fatalf("SaveEscalation() error = %v", err)
+	}
+
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+		Analysis:    Analysis{Approved: true},
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-2"})
+	coord.Wait()
+
+	if info, _ := store.Escalation(ctx, "chan-testrepo", prURL); !info.NeedsReviewSince.IsZero() || info.Level != 0 {
+		t.Errorf("Escalation() = %+v after approval, want reset", info)
+	}
+}
+
+func TestCoordinator_EscalateStalledReviews_ReplicasPostOnce(t *testing.T) {
+	ctx := context.Background()
+	const prURL = "https://github.com/testorg/testrepo/pull/1"
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"testrepo": {}},
+	}
+	configMgr.escalation = map[string][]config.EscalationStep{"testrepo": {{After: time.Hour, Target: "<@&999>"}}}
+
+	store := state.NewMemoryStore()
+	replicas := make([]*Coordinator, 2)
+	for i := range replicas {
+		replicas[i] = NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    newMockTurnClient(),
+			Org:     "testorg",
+		})
+	}
+
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		ChannelID: "chan-testrepo",
+		MessageID: "msg-1",
+		LastState: "needs_review",
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	waiting := state.EscalationInfo{NeedsReviewSince: time.Now().Add(-2 * time.Hour)}
+	if err := store.SaveEscalation(ctx, "chan-testrepo", prURL, waiting); err != nil {
+		t.Fatalf("SaveEscalation() error = %v", err)
+	}
+
+	replicas[0].EscalateStalledReviews(ctx)
+	// The second replica read the record before the first saved its new level
+	if err := store.SaveEscalation(ctx, "chan-testrepo", prURL, waiting); err != nil {
+		t.Fatalf("SaveEscalation() error = %v", err)
+	}
+	replicas[1].EscalateStalledReviews(ctx)
+
+	if got := len(discord.postedMessages); got != 1 {
+		t.Errorf("postedMessages = %d, want 1 escalation across replicas", got)
+	}
+}
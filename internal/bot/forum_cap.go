@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"slices"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// makeRoomInForum archives the forum's oldest merged/closed thread when the forum is at its
+// configured cap of active threads, so creating a new thread doesn't run into Discord's limit.
+// Open PRs' threads are never archived; if none are safe to archive, creation proceeds anyway.
+func (c *Coordinator) makeRoomInForum(ctx context.Context, forumID, channelName string) {
+	maxThreads := c.config.MaxForumThreads(c.org, channelName)
+	if maxThreads <= 0 {
+		return
+	}
+
+	active, err := c.discord.ActiveForumThreads(ctx, forumID)
+	if err != nil {
+		c.logger.Warn("failed to count active forum threads", "channel", channelName, "error", err)
+		return
+	}
+	if len(active) < maxThreads {
+		return
+	}
+
+	var oldest *state.TrackedThread
+	for _, t := range c.store.ListThreads(ctx, c.org) {
+		if t.Info.ChannelID != forumID || !slices.Contains(active, t.Info.ThreadID) {
+			continue
+		}
+		if t.Info.LastState != string(format.StateMerged) && t.Info.LastState != string(format.StateClosed) {
+			continue
+		}
+		if oldest == nil || t.Info.UpdatedAt.Before(oldest.Info.UpdatedAt) {
+			oldest = &t
+		}
+	}
+	if oldest == nil {
+		c.logger.Warn("forum is at its thread cap but has no merged/closed threads to archive",
+			"channel", channelName,
+			"active", len(active),
+			"max", maxThreads)
+		return
+	}
+
+	if err := c.discord.ArchiveThread(ctx, oldest.Info.ThreadID); err != nil {
+		c.logger.Warn("failed to archive thread to make room in forum",
+			"channel", channelName,
+			"thread_id", oldest.Info.ThreadID,
+			"error", err)
+		return
+	}
+	c.logger.Info("archived oldest finished thread to stay under forum thread cap",
+		"channel", channelName,
+		"thread_id", oldest.Info.ThreadID,
+		"pr", FormatPRURL(oldest.Owner, oldest.Repo, oldest.Number),
+		"active", len(active),
+		"max", maxThreads)
+}
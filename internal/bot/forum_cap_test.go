@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ForumThreadCap(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxThreads   int
+		wantArchived []string
+	}{
+		{"at cap archives oldest finished thread", 3, []string{"thread-merged-old"}},
+		{"under cap archives nothing", 4, nil},
+		{"no cap archives nothing", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-forum"
+			discord.botInChannel["chan-forum"] = true
+			discord.forumChannels["chan-forum"] = true
+			discord.activeThreads = map[string][]string{
+				"chan-forum": {"thread-open", "thread-merged-old", "thread-closed-new"},
+			}
+
+			store := state.NewMemoryStore()
+			for _, th := range []struct {
+				number int
+				id     string
+				state  string
+			}{
+				{1, "thread-open", "awaiting_review"},
+				{2, "thread-merged-old", "merged"},
+				{3, "thread-closed-new", "closed"},
+			} {
+				if err := store.SaveThread(ctx, "testorg", "testrepo", th.number, "chan-forum", state.ThreadInfo{
+					ThreadID:  th.id,
+					ChannelID: "chan-forum",
+					LastState: th.state,
+				}); err != nil {
+					t.Fatalf("SaveThread() error = %v", err)
+				}
+				time.Sleep(time.Millisecond) // Distinct UpdatedAt ordering
+			}
+
+			configMgr := newMockConfigManager()
+			configMgr.maxForumThreads = tt.maxThreads
+			turn := newMockTurnClient()
+			turn.responses["https://github.com/testorg/testrepo/pull/42"] = &CheckResponse{
+				PullRequest: PRInfo{Title: "New PR", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL:        "https://github.com/testorg/testrepo/pull/42",
+				Type:       "pull_request",
+				DeliveryID: "delivery-1",
+			})
+			coord.Wait()
+
+			if !slices.Equal(discord.archivedThreads, tt.wantArchived) {
+				t.Errorf("archivedThreads = %v, want %v", discord.archivedThreads, tt.wantArchived)
+			}
+			if len(discord.forumThreads) != 1 {
+				t.Errorf("forumThreads = %d, want new thread created", len(discord.forumThreads))
+			}
+		})
+	}
+}
+
+func TestCoordinator_ForumThreadCap_NothingSafeToArchive(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.activeThreads = map[string][]string{"chan-forum": {"thread-open"}}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-forum", state.ThreadInfo{
+		ThreadID:  "thread-open",
+		ChannelID: "chan-forum",
+		LastState: "awaiting_review",
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	configMgr := newMockConfigManager()
+	configMgr.maxForumThreads = 1
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	coord.makeRoomInForum(ctx, "chan-forum", "testrepo")
+	if len(discord.archivedThreads) != 0 {
+		t.Errorf("archivedThreads = %v, want open PR threads left alone", discord.archivedThreads)
+	}
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// ErrNotActionTarget is returned when a user marks a PR handled that isn't waiting on them.
+var ErrNotActionTarget = errors.New("PR isn't waiting on this user")
+
+// MarkHandled records that a Discord user has dealt with a PR waiting on them, as with
+// /goose done. Until the PR's state changes, they get no DMs about it and channel messages
+// name them without a mention. Any DM already queued for them is dropped.
+func (c *Coordinator) MarkHandled(ctx context.Context, discordUserID, prURL string) error {
+	pr, ok := ParsePRURL(prURL)
+	if !ok {
+		return fmt.Errorf("invalid PR URL: %s", prURL)
+	}
+	prURL = FormatPRURL(pr.Owner, c.config.CanonicalRepo(c.org, pr.Repo), pr.Number)
+
+	checkResp, err := c.checkTurn(ctx, prURL, "", time.Now())
+	if err != nil {
+		return fmt.Errorf("check PR: %w", err)
+	}
+	username := c.actionTargetForDiscordUser(ctx, checkResp, discordUserID)
+	if username == "" {
+		return ErrNotActionTarget
+	}
+	prState := prStateFromCheck(checkResp)
+
+	dmLock := c.dmLocks.get(discordUserID + ":" + prURL)
+	dmLock.Lock()
+	defer dmLock.Unlock()
+
+	info, _ := c.store.DMInfo(ctx, discordUserID, prURL)
+	info.HandledState = string(prState)
+	if err := c.store.SaveDMInfo(ctx, discordUserID, prURL, info); err != nil {
+		return fmt.Errorf("save DM info: %w", err)
+	}
+
+	pending, err := c.store.PendingDMs(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		c.logger.Warn("failed to check pending DMs", "error", err)
+	}
+	for _, dm := range pending {
+		if dm.UserID != discordUserID || dm.PRURL != prURL {
+			continue
+		}
+		if err := c.store.RemovePendingDM(ctx, dm.ID); err != nil {
+			c.logger.Warn("failed to remove pending DM for handled PR", "error", err, "dm_id", dm.ID)
+		}
+	}
+
+	c.logger.Info("PR marked handled",
+		"pr_url", prURL,
+		"github_user", username,
+		"state", prState)
+	return nil
+}
+
+// handled reports whether a Discord user marked a PR handled in its current state.
+func (c *Coordinator) handled(ctx context.Context, discordID, prURL string, prState format.PRState) bool {
+	info, ok := c.store.DMInfo(ctx, discordID, prURL)
+	return ok && info.HandledState == string(prState)
+}
+
+// saveDMInfo saves DM info read before a slow call, keeping any mute, snooze or
+// /goose done the user set since: the DM footer's quick actions don't take dmLocks.
+func (c *Coordinator) saveDMInfo(ctx context.Context, discordID, prURL string, info state.DMInfo) error {
+	stored, _ := c.store.DMInfo(ctx, discordID, prURL)
+	return c.store.SaveDMInfo(ctx, discordID, prURL, info.WithSettings(stored))
+}
+
+// unmentionHandled names action users who marked the PR handled in its current state
+// instead of mentioning them, so channel messages stop pinging them.
+func (c *Coordinator) unmentionHandled(ctx context.Context, prURL string, prState format.PRState, users []format.ActionUser) []format.ActionUser {
+	if c.UserMapper == nil {
+		return users
+	}
+	for i, u := range users {
+		if discordID := c.UserMapper.DiscordID(ctx, u.Username); discordID != "" && c.handled(ctx, discordID, prURL, prState) {
+			users[i].Mention = u.Username
+		}
+	}
+	return users
+}
+
+// clearHandled forgets /goose done for users whose PR has since changed state,
+// so they're notified again.
+func (c *Coordinator) clearHandled(ctx context.Context, prURL string, prState format.PRState) {
+	for _, discordID := range c.store.ListDMUsers(ctx, prURL) {
+		dmLock := c.dmLocks.get(discordID + ":" + prURL)
+		dmLock.Lock()
+		info, ok := c.store.DMInfo(ctx, discordID, prURL)
+		if ok && info.HandledState != "" && info.HandledState != string(prState) {
+			info.HandledState = ""
+			if err := c.store.SaveDMInfo(ctx, discordID, prURL, info); err != nil {
+				c.logger.Warn("failed to clear handled PR", "error", err, "pr_url", prURL)
+			} else {
+				c.logger.Info("PR state changed, notifying user who marked it handled again",
+					"pr_url", prURL,
+					"user_id", discordID,
+					"state", prState)
+			}
+		}
+		dmLock.Unlock()
+	}
+}
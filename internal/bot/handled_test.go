@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_MarkHandled(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["discord-bob"] = true
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+	mapper.mappings["eve"] = "discord-eve"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Needs eyes", Author: "alice", State: "open"},
+		Analysis: Analysis{
+			Checks:     Checks{Passing: 1},
+			NextAction: map[string]Action{"bob": {Kind: "review"}},
+		},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     newMockConfigManager(),
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+	pendingForBob := func() int {
+		t.Helper()
+		pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+		if err != nil {
+			t.Fatalf("PendingDMs() error = %v", err)
+		}
+		n := 0
+		for _, dm := range pending {
+			if dm.UserID == "discord-bob" && dm.PRURL == prURL {
+				n++
+			}
+		}
+		return n
+	}
+
+	if err := coord.MarkHandled(ctx, "discord-eve", prURL); !errors.Is(err, ErrNotActionTarget) {
+		t.Errorf("MarkHandled() for a non-reviewer error = %v, want ErrNotActionTarget", err)
+	}
+	if err := coord.MarkHandled(ctx, "discord-bob", prURL); err != nil {
+		t.Fatalf("MarkHandled() error = %v", err)
+	}
+
+	// Routine events neither DM nor mention bob
+	for _, id := range []string{"d-1", "d-2"} {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: id})
+		coord.Wait()
+	}
+	if n := pendingForBob(); n != 0 {
+		t.Errorf("pending DMs for bob = %d after marking handled, want 0", n)
+	}
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; strings.Contains(text, "<@discord-bob>") || !strings.Contains(text, "bob") {
+		t.Errorf("message = %q, want bob named without a mention", text)
+	}
+
+	// A state change notifies bob again
+	turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-3"})
+	coord.Wait()
+	if n := pendingForBob(); n != 1 {
+		t.Errorf("pending DMs for bob = %d after state change, want 1", n)
+	}
+	if len(discord.updatedMessages) == 0 {
+		t.Fatal("expected the channel message to be updated after the state change")
+	}
+	if text := discord.updatedMessages[len(discord.updatedMessages)-1].text; !strings.Contains(text, "<@discord-bob>") {
+		t.Errorf("message after state change = %q, want bob mentioned again", text)
+	}
+	if info, _ := store.DMInfo(ctx, "discord-bob", prURL); info.HandledState != "" {
+		t.Errorf("HandledState = %q after state change, want cleared", info.HandledState)
+	}
+}
+
+func TestCoordinator_saveDMInfo_KeepsUserSettings(t *testing.T) {
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	checkResp := &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "bob", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"alice": {Kind: "review"}}},
+	}
+	prState := prStateFromCheck(checkResp)
+
+	discord := newMockDiscordClient()
+	discord.usersInGuild["discord-alice"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	configMgr := newMockConfigManager()
+	configMgr.dmEscalation = time.Hour
+	turn := newMockTurnClient()
+	turn.responses[prURL] = checkResp
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		ChannelID: "chan-testrepo",
+		MessageID: "msg-1",
+		LastState: string(prState),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveDMInfo(ctx, "discord-alice", prURL, state.DMInfo{
+		ChannelID: "dm-chan-discord-alice",
+		MessageID: "dm-msg-1",
+		LastState: string(prState),
+		SentAt:    time.Now().Add(-2 * time.Hour),
+	}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+
+	// The user mutes the PR from the DM footer while the follow-up is being sent
+	discord.onSendDM = func(userID string) {
+		info, _ := store.DMInfo(ctx, userID, prURL)
+		info.Muted = true
+		if err := store.SaveDMInfo(ctx, userID, prURL, info); err != nil {
+			t.Errorf("SaveDMInfo() error = %v", err)
+		}
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+	coord.EscalateUnacknowledgedDMs(ctx)
+
+	info, _ := store.DMInfo(ctx, "discord-alice", prURL)
+	if info.EscalationLevel != 1 {
+		t.Errorf("EscalationLevel = %d, want 1", info.EscalationLevel)
+	}
+	if !info.Muted {
+		t.Error("Muted = false, want the mute made during the follow-up kept")
+	}
+}
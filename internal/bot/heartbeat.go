@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// heartbeatEvent is the event type of a heartbeat's re-check of a stale PR.
+const heartbeatEvent = "heartbeat"
+
+// heartbeat remembers when this instance last looked for stale PRs, and whether it still is.
+type heartbeat struct {
+	last    time.Time
+	running bool
+	mu      sync.Mutex
+}
+
+// Heartbeat re-checks, with Turn, each tracked open PR whose messages haven't been updated for
+// the org's heartbeat_stale_after, and refreshes its messages and DMs. It catches state drift on
+// PRs with no recent events, which the GitHub search poll misses since it only sees PRs updated
+// lately. It runs at most once per heartbeat_interval, and instances share each round's re-checks.
+// The re-checks run in the background so slow Turn calls don't hold up the caller's ticker;
+// a round still running when the next is due is left to finish instead of overlapping it.
+func (c *Coordinator) Heartbeat(ctx context.Context, now time.Time) {
+	staleAfter := c.config.HeartbeatStaleAfter(c.org)
+	if staleAfter <= 0 {
+		return
+	}
+	interval := c.config.HeartbeatInterval(c.org)
+
+	h := &c.heartbeat
+	h.mu.Lock()
+	if h.running {
+		h.mu.Unlock()
+		c.logger.Debug("skipping heartbeat, previous round still running")
+		return
+	}
+	if !h.last.IsZero() && now.Sub(h.last) < interval {
+		h.mu.Unlock()
+		return
+	}
+	h.last = now
+	h.running = true
+	h.mu.Unlock()
+
+	c.wg.Go(func() {
+		defer func() {
+			h.mu.Lock()
+			h.running = false
+			h.mu.Unlock()
+		}()
+		c.heartbeatRound(ctx, now, staleAfter, interval)
+	})
+}
+
+// heartbeatRound re-checks the tracked open PRs that have gone stale.
+func (c *Coordinator) heartbeatRound(ctx context.Context, now time.Time, staleAfter, interval time.Duration) {
+	// A PR posted to several channels is as fresh as its latest update
+	updated := make(map[string]time.Time)
+	for _, t := range c.store.ListThreads(ctx, c.org) {
+		if s := format.PRState(t.Info.LastState); s == format.StateMerged || s == format.StateClosed {
+			continue
+		}
+		prURL := FormatPRURL(t.Owner, t.Repo, t.Number)
+		if t.Info.UpdatedAt.After(updated[prURL]) {
+			updated[prURL] = t.Info.UpdatedAt
+		}
+	}
+
+	// Every instance uses the same delivery ID within a round, so each PR is re-checked once
+	deliveryID := fmt.Sprintf("%s-%d", heartbeatEvent, now.Truncate(interval).Unix())
+	rechecked := 0
+	for _, prURL := range slices.Sorted(maps.Keys(updated)) {
+		if now.Sub(updated[prURL]) < staleAfter {
+			continue
+		}
+		c.logger.Debug("re-checking stale PR",
+			"pr_url", prURL,
+			"last_updated", updated[prURL].Format(time.RFC3339))
+		event := SprinklerEvent{URL: prURL, Type: heartbeatEvent, DeliveryID: deliveryID, Timestamp: now}
+		if err := c.processEventSync(ctx, event); err != nil {
+			c.logger.Warn("failed to re-check stale PR", "pr_url", prURL, "error", err)
+		}
+		rechecked++
+	}
+	if rechecked > 0 {
+		c.logger.Info("heartbeat re-checked stale PRs",
+			"rechecked", rechecked,
+			"tracked", len(updated))
+	}
+}
@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_Heartbeat(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.heartbeatStale = 50 * time.Millisecond
+	configMgr.heartbeatEvery = time.Hour
+
+	staleURL := "https://github.com/testorg/testrepo/pull/1"
+	freshURL := "https://github.com/testorg/testrepo/pull/2"
+	turn := newMockTurnClient()
+	for _, prURL := range []string{staleURL, freshURL} {
+		turn.responses[prURL] = &CheckResponse{
+			PullRequest: PRInfo{Title: "Drifted title", Author: "alice", State: "open"},
+		}
+	}
+
+	store := state.NewMemoryStore()
+	stale := state.ThreadInfo{MessageID: "msg-1", ChannelType: "text", LastState: "open", MessageText: "old"}
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", stale); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	fresh := state.ThreadInfo{MessageID: "msg-2", ChannelType: "text", LastState: "open", MessageText: "old"}
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 2, "chan-testrepo", fresh); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.Heartbeat(ctx, time.Now())
+	coord.Wait()
+	if turn.callCount != 1 {
+		t.Errorf("Turn calls = %d, want 1 for the stale PR only", turn.callCount)
+	}
+	if len(discord.updatedMessages) != 1 || discord.updatedMessages[0].messageID != "msg-1" {
+		t.Fatalf("updatedMessages = %+v, want only the stale PR's msg-1", discord.updatedMessages)
+	}
+
+	// Within the interval, the heartbeat doesn't run again
+	time.Sleep(60 * time.Millisecond)
+	coord.Heartbeat(ctx, time.Now())
+	coord.Wait()
+	if turn.callCount != 1 {
+		t.Errorf("Turn calls = %d within the interval, want still 1", turn.callCount)
+	}
+}
+
+func TestCoordinator_Heartbeat_SkipsWhileRunning(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.heartbeatStale = time.Millisecond
+	configMgr.heartbeatEvery = time.Hour
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{MessageID: "msg-1", ChannelType: "text", LastState: "open"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	turn := newMockTurnClient()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// A round still in progress holds off the next one without using up its interval
+	coord.heartbeat.running = true
+	coord.Heartbeat(ctx, time.Now().Add(time.Minute))
+	coord.Wait()
+	if turn.callCount != 0 {
+		t.Errorf("Turn calls = %d while a round is running, want 0", turn.callCount)
+	}
+
+	coord.heartbeat.running = false
+	coord.Heartbeat(ctx, time.Now().Add(time.Minute))
+	coord.Wait()
+	if turn.callCount != 1 {
+		t.Errorf("Turn calls = %d once the round finished, want 1", turn.callCount)
+	}
+	if coord.heartbeat.running {
+		t.Error("heartbeat still marked running after its round finished")
+	}
+}
+
+func TestCoordinator_Heartbeat_Disabled(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{MessageID: "msg-1", LastState: "open"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	turn := newMockTurnClient()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.Heartbeat(ctx, time.Now().Add(30*24*time.Hour))
+	coord.Wait()
+	if turn.callCount != 0 {
+		t.Errorf("Turn calls = %d with no heartbeat_stale_after, want 0", turn.callCount)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
 	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
@@ -17,12 +18,20 @@ import (
 type DiscordClient interface {
 	// Text channel operations
 	PostMessage(ctx context.Context, channelID, text string) (messageID string, err error)
-	UpdateMessage(ctx context.Context, channelID, messageID, text string) error
+	UpdateMessage(ctx context.Context, channelID, messageID, text string, silent bool) error
+	CrosspostMessage(ctx context.Context, channelID, messageID string) error
+	PinMessage(ctx context.Context, channelID, messageID string) error
+	DeleteMessage(ctx context.Context, channelID, messageID string) error
+	StartMessageThread(ctx context.Context, channelID, messageID, name string) (threadID string, err error)
 
 	// Forum channel operations
 	PostForumThread(ctx context.Context, forumID, title, content string) (threadID, messageID string, err error)
-	UpdateForumPost(ctx context.Context, threadID, messageID, newTitle, newContent string) error
+	UpdateForumPost(ctx context.Context, threadID, messageID, newTitle, newContent string, silent bool) error
+	ForumStarterMessageID(ctx context.Context, threadID string) (string, error)
 	ArchiveThread(ctx context.Context, threadID string) error
+	UnarchiveThread(ctx context.Context, threadID string) error
+	DeleteThread(ctx context.Context, threadID string) error
+	ActiveForumThreads(ctx context.Context, forumID string) ([]string, error)
 
 	// Direct message operations
 	SendDM(ctx context.Context, userID, text string) (channelID, messageID string, err error)
@@ -32,12 +41,16 @@ type DiscordClient interface {
 	ResolveChannelID(ctx context.Context, channelName string) string
 	LookupUserByUsername(ctx context.Context, username string) string
 	IsBotInChannel(ctx context.Context, channelID string) bool
+	BotChannelPermissions(ctx context.Context, channelID string) (discord.Permissions, error)
 	IsUserInGuild(ctx context.Context, userID string) bool
 	IsUserActive(ctx context.Context, userID string) bool
+	ChannelViewers(ctx context.Context, channelID string, limit int) []string
 	IsForumChannel(ctx context.Context, channelID string) bool
+	IsNewsChannel(ctx context.Context, channelID string) bool
 
 	// Guild info
 	GuildID() string
+	BotInfo(ctx context.Context) (discord.BotInfo, error)
 
 	// Search operations (for cross-instance race prevention)
 	FindForumThread(ctx context.Context, forumID, prURL string) (threadID, messageID string, found bool)
@@ -50,11 +63,93 @@ type DiscordClient interface {
 type ConfigManager interface {
 	LoadConfig(ctx context.Context, org string) error
 	ReloadConfig(ctx context.Context, org string) error
+	LoadError(org string) error
 	Config(org string) (*config.DiscordConfig, bool)
 	ChannelsForRepo(org, repo string) []string
+	ChannelsForAuthor(org, author string) []string
 	ChannelType(org, channel string) string
 	DiscordUserID(org, githubUsername string) string
 	ReminderDMDelay(org, channel string) int
+	MinDMDelay(org string) int
+	SilentEdits(org string) bool
+	NotifyOnTransitions(org string) []string
+	SuppressLabels(org string) []string
+	SystemIndicator(org, kind string) bool
+	SuppressSelfApproved(org string) bool
+	ArchivedRepo(org, repo string) bool
+	SkipArchivedRepos(org string) bool
+	ArchivedRepoNote(org string) string
+	ReviveReopenedThreads(org string) bool
+	ReopenRepostAfter(org string) time.Duration
+	DMOnClosedUnmerged(org string) bool
+	DMAuthorOnReview(org string) bool
+	DMOnReopenedReview(org string) bool
+	ConflictResolved(org string) string
+	ConflictResolvedNote(org string) string
+	DMOnUnrequest(org string) bool
+	DMEscalationAfter(org string) time.Duration
+	ReviewReminders(org string) (every time.Duration, maxCount int)
+	OOOBackup(org, githubUsername string) string
+	QueueDuringMaintenance(org string) bool
+	MemberLeave(org string) string
+	SelfMentions(org string) string
+	SoloReviewer(org string) string
+	ShowAssignees(org string) bool
+	ShowApprovals(org string) bool
+	ShowReviewRounds(org string) bool
+	ShowFailingChecks(org string) bool
+	ShowCIStatus(org string) bool
+	ShowLinkedIssues(org string) bool
+	Location(org string) *time.Location
+	ShowNonDefaultBase(org string) bool
+	DefaultBranch(org string) string
+	TurnRateLimit(org string) (perMinute, burst int)
+	SkipTurnForDrafts(org string) bool
+	MaxEventAge(org string) time.Duration
+	BackfillWindow(org string) time.Duration
+	TestStateDebounce(org string) time.Duration
+	PushBurstWindow(org string) time.Duration
+	MinEventsToPost(org string) int
+	WriteCooldown(org string) time.Duration
+	BotJoinGrace(org string) time.Duration
+	FeatureEnabled(org, name string) bool
+	MappingConflictPolicy(org string) string
+	AdminChannel(org string) string
+	FirehoseChannel(org string) string
+	DMFallbackChannel(org string) string
+	ActivitySummary(org string) bool
+	PersistentReport(org string) bool
+	DiscordNames(org string) bool
+	HeartbeatStaleAfter(org string) time.Duration
+	TurnUnavailableMessage(org string) string
+	HeartbeatInterval(org string) time.Duration
+	CanonicalRepo(org, repo string) string
+	Crosspost(org, channel string) bool
+	MessagePrefix(org, channel string) string
+	RepoEmoji(org, repo string) string
+	ShowOrg(org, channel string) bool
+	PlainURL(org, channel string) bool
+	ShowBodySnippet(org, channel string) bool
+	ReviewChecklist(org, channel, repo string) []string
+	DetailThread(org, channel string) bool
+	AppendMode(org, channel string) bool
+	StateSuppressed(org, channel, prState string) bool
+	DashboardFooter(org, channel string) bool
+	DashboardURLTemplate(org string) string
+	TitleStyle(org, channel string) string
+	Layout(org, channel string) string
+	PrefixForumTitles(org, channel string) bool
+	DigestInterval(org, channel string) time.Duration
+	Board(org, channel string) bool
+	BoardMaxPRs(org, channel string) int
+	DeleteOnClose(org, channel string) bool
+	AuthorAllowed(org, channel, author string) bool
+	PostWindow(org, channel string) (config.PostWindow, bool)
+	MaxForumThreads(org, channel string) int
+	ArchiveDelay(org, channel string) time.Duration
+	Escalation(org, channel string) []config.EscalationStep
+	TeamRoleMention(org, channel string) (mention string, threshold int)
+	DMTemplate(org, action string) string
 	When(org, channel string) string
 	GuildID(org string) string
 	SetGitHubClient(org string, client any)
@@ -66,11 +161,20 @@ type ConfigManager interface {
 type StateStore interface {
 	Thread(ctx context.Context, owner, repo string, number int, channelID string) (state.ThreadInfo, bool)
 	SaveThread(ctx context.Context, owner, repo string, number int, channelID string, info state.ThreadInfo) error
+	ListThreads(ctx context.Context, owner string) []state.TrackedThread
+	ThreadForMessage(ctx context.Context, channelID, messageID string) (state.TrackedThread, bool)
+	RemoveThread(ctx context.Context, owner, repo string, number int, channelID string) error
+	SetThreadMessageIDs(ctx context.Context, owner, repo string, number int, channelID string, messageIDs []string) error
 	ClaimThread(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool
+	RenewThreadClaim(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool
+	ReleaseThreadClaim(ctx context.Context, owner, repo string, number int, channelID string) error
 	DMInfo(ctx context.Context, userID, prURL string) (state.DMInfo, bool)
 	SaveDMInfo(ctx context.Context, userID, prURL string, info state.DMInfo) error
 	ClaimDM(ctx context.Context, userID, prURL string, ttl time.Duration) bool
+	ReleaseDMClaim(ctx context.Context, userID, prURL string) error
+	ClaimConfigReload(ctx context.Context, org string, ttl time.Duration) bool
 	ListDMUsers(ctx context.Context, prURL string) []string // Returns all user IDs who received DMs for this PR
+	ClaimEvent(ctx context.Context, eventKey string, ttl time.Duration) bool
 	WasProcessed(ctx context.Context, eventKey string) bool
 	MarkProcessed(ctx context.Context, eventKey string, ttl time.Duration) error
 	QueuePendingDM(ctx context.Context, dm *state.PendingDM) error
@@ -78,6 +182,26 @@ type StateStore interface {
 	RemovePendingDM(ctx context.Context, id string) error
 	DailyReportInfo(ctx context.Context, userID string) (state.DailyReportInfo, bool)
 	SaveDailyReportInfo(ctx context.Context, userID string, info state.DailyReportInfo) error
+	Digest(ctx context.Context, channelID string) (state.DigestInfo, bool)
+	SaveDigest(ctx context.Context, channelID string, info state.DigestInfo) error
+	UpdateDigest(ctx context.Context, channelID string, update func(info *state.DigestInfo) bool) error
+	Board(ctx context.Context, channelID string) (state.BoardInfo, bool)
+	SaveBoard(ctx context.Context, channelID string, info state.BoardInfo) error
+	UpdateBoard(ctx context.Context, channelID string, update func(info *state.BoardInfo) bool) error
+	UpdatePostWindows(ctx context.Context, org string, update func(info *state.PostWindowInfo) bool) error
+	PROverride(ctx context.Context, prURL string) (state.PROverride, bool)
+	SavePROverride(ctx context.Context, prURL string, override state.PROverride) error
+	Escalation(ctx context.Context, channelID, prURL string) (state.EscalationInfo, bool)
+	SaveEscalation(ctx context.Context, channelID, prURL string, info state.EscalationInfo) error
+	Maintenance(ctx context.Context, org string) (state.MaintenanceInfo, bool)
+	SaveMaintenance(ctx context.Context, org string, info state.MaintenanceInfo) error
+	OOO(ctx context.Context, userID string) (state.OOOInfo, bool)
+	RepoRedirect(ctx context.Context, org, repo string) (state.RepoRedirect, bool)
+	SaveRepoRedirect(ctx context.Context, org, repo string, redirect state.RepoRedirect) error
+	LastEventAt(ctx context.Context, org string) (time.Time, bool)
+	SaveLastEventAt(ctx context.Context, org string, seenAt time.Time) error
+	ConfigReloadRequestedAt(ctx context.Context, org string) (time.Time, bool)
+	SaveConfigReloadRequest(ctx context.Context, org string, requestedAt time.Time) error
 	Cleanup(ctx context.Context) error
 }
 
@@ -94,15 +218,25 @@ type CheckResponse struct {
 
 // PRInfo contains pull request metadata.
 type PRInfo struct {
-	Title     string   `json:"title"`
-	Author    string   `json:"author"`
-	State     string   `json:"state"`
-	UpdatedAt string   `json:"updated_at"`
-	Commits   []string `json:"commits,omitempty"`
-	Assignees []string `json:"assignees,omitempty"`
-	Draft     bool     `json:"draft"`
-	Merged    bool     `json:"merged"`
-	Closed    bool     `json:"closed"`
+	Title      string   `json:"title"`
+	Body       string   `json:"body,omitempty"`
+	Author     string   `json:"author"`
+	State      string   `json:"state"`
+	UpdatedAt  string   `json:"updated_at"`
+	BaseBranch string   `json:"base_branch,omitempty"`
+	Commits    []string `json:"commits,omitempty"`
+	Assignees  []string `json:"assignees,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	Draft      bool     `json:"draft"`
+	Merged     bool     `json:"merged"`
+	Closed     bool     `json:"closed"`
+	// Reviewers maps each GitHub user who reviewed the PR to their latest review state, e.g. "approved".
+	Reviewers map[string]string `json:"reviewers,omitempty"`
+	// ApprovedBy lists the GitHub users whose approvals count toward merging the PR.
+	// Older Turn responses leave it out; approvals are then taken from Reviewers.
+	ApprovedBy []string `json:"approved_by,omitempty"`
+	// ClosesIssues lists the numbers of the repo's issues the PR closes when merged.
+	ClosesIssues []int `json:"closes_issues,omitempty"`
 }
 
 // Analysis contains the PR analysis result.
@@ -112,6 +246,7 @@ type Analysis struct {
 	Size               string            `json:"size"`
 	Tags               []string          `json:"tags"`
 	Checks             Checks            `json:"checks"`
+	FailingChecks      []FailingCheck    `json:"failing_checks,omitempty"`
 	UnresolvedComments int               `json:"unresolved_comments"`
 	ReadyToMerge       bool              `json:"ready_to_merge"`
 	Approved           bool              `json:"approved"`
@@ -120,8 +255,15 @@ type Analysis struct {
 
 // Action represents what a user needs to do.
 type Action struct {
-	Kind   string `json:"kind"`
-	Reason string `json:"reason"`
+	Since  time.Time `json:"since"` // When the user was asked to act; zero if Turn didn't say
+	Kind   string    `json:"kind"`
+	Reason string    `json:"reason"`
+}
+
+// FailingCheck is a failing CI check and a link to its run.
+type FailingCheck struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 // Checks contains CI check status.
@@ -140,6 +282,8 @@ type SprinklerEvent struct {
 	URL        string         `json:"url"`
 	DeliveryID string         `json:"delivery_id"`
 	CommitSHA  string         `json:"commit_sha,omitempty"`
+
+	searched *PRSearchResult // The search result a poll event was made from; nil for webhook events
 }
 
 // UserMapper defines user mapping operations.
@@ -156,11 +300,38 @@ type PRSearcher interface {
 	ListClosedPRs(ctx context.Context, org string, closedWithinHours int) ([]PRSearchResult, error)
 }
 
+// BranchResolver looks up repository default branches.
+type BranchResolver interface {
+	DefaultBranch(ctx context.Context, owner, repo string) (string, error)
+}
+
+// RepoStatus looks up whether repositories are archived or disabled.
+type RepoStatus interface {
+	RepoArchived(ctx context.Context, owner, repo string) (bool, error)
+}
+
+// CommentReader lists a PR's recent comments, for reading /discordian directives.
+type CommentReader interface {
+	// RecentComments returns the PR's most recent conversation comments, oldest first.
+	RecentComments(ctx context.Context, owner, repo string, number int) ([]PRComment, error)
+}
+
+// PRComment is a comment on a pull request's conversation.
+type PRComment struct {
+	CreatedAt   time.Time
+	Author      string
+	Association string // GitHub author_association, e.g. "MEMBER" or "CONTRIBUTOR"
+	Body        string
+}
+
 // PRSearchResult contains basic PR info for polling.
 type PRSearchResult struct {
 	UpdatedAt time.Time
 	URL       string
 	Owner     string
 	Repo      string
+	Title     string
+	Author    string
 	Number    int
+	Draft     bool
 }
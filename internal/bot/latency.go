@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the notification latency histogram's buckets.
+// DMs wait out min_dm_delay and reminder delays, so the buckets reach well past channel posts.
+var latencyBuckets = []time.Duration{
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	4 * time.Hour,
+}
+
+// LatencyBuckets returns the upper bounds of LatencyStats's buckets.
+func LatencyBuckets() []time.Duration {
+	return slices.Clone(latencyBuckets)
+}
+
+// LatencyStats is a histogram of notification latency: the time from a PR event to the
+// channel message or DM it caused.
+type LatencyStats struct {
+	Buckets []int64 // Count per latencyBuckets bound, then one for anything longer
+	Count   int64
+	Sum     time.Duration
+}
+
+// Merge returns the histogram of both s's and other's notifications.
+func (s LatencyStats) Merge(other LatencyStats) LatencyStats {
+	merged := LatencyStats{
+		Buckets: make([]int64, len(latencyBuckets)+1),
+		Count:   s.Count + other.Count,
+		Sum:     s.Sum + other.Sum,
+	}
+	for i := range merged.Buckets {
+		if i < len(s.Buckets) {
+			merged.Buckets[i] += s.Buckets[i]
+		}
+		if i < len(other.Buckets) {
+			merged.Buckets[i] += other.Buckets[i]
+		}
+	}
+	return merged
+}
+
+// Quantile estimates the latency that a fraction q of notifications came within, interpolating
+// inside its bucket. Latencies past the last bucket are reported as its bound; with no
+// notifications it's 0.
+func (s LatencyStats) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := q * float64(s.Count)
+	var seen int64
+	for i, n := range s.Buckets {
+		if n == 0 || float64(seen+n) < rank {
+			seen += n
+			continue
+		}
+		if i >= len(latencyBuckets) {
+			return latencyBuckets[len(latencyBuckets)-1]
+		}
+		var lower time.Duration
+		if i > 0 {
+			lower = latencyBuckets[i-1]
+		}
+		fraction := (rank - float64(seen)) / float64(n)
+		return lower + time.Duration(fraction*float64(latencyBuckets[i]-lower))
+	}
+	return latencyBuckets[len(latencyBuckets)-1]
+}
+
+// latencyHistogram collects LatencyStats, observed concurrently by event goroutines.
+type latencyHistogram struct {
+	stats LatencyStats
+	mu    sync.Mutex
+}
+
+func (h *latencyHistogram) observe(latency time.Duration) {
+	latency = max(latency, 0) // GitHub's clock may be ahead of ours
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stats.Buckets == nil {
+		h.stats.Buckets = make([]int64, len(latencyBuckets)+1)
+	}
+	i, _ := slices.BinarySearch(latencyBuckets, latency)
+	h.stats.Buckets[i]++
+	h.stats.Count++
+	h.stats.Sum += latency
+}
+
+func (h *latencyHistogram) snapshot() LatencyStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stats := h.stats
+	stats.Buckets = slices.Clone(stats.Buckets)
+	return stats
+}
+
+// eventTime returns when an event happened, for measuring notification latency, or the zero
+// time for a poll, whose timestamp is only the PR's last update.
+func eventTime(event SprinklerEvent) time.Time {
+	if event.Type == "poll" {
+		return time.Time{}
+	}
+	return event.Timestamp
+}
+
+// observePostLatency records the latency of a channel post or edit caused by an event at eventAt.
+func (c *Coordinator) observePostLatency(eventAt time.Time) {
+	if !eventAt.IsZero() {
+		c.counters.postLatency.observe(time.Since(eventAt))
+	}
+}
+
+// ObserveDMLatency records the latency of a DM sent about one of the org's events, from the
+// event to the DM going out.
+func (c *Coordinator) ObserveDMLatency(latency time.Duration) {
+	c.counters.dmLatency.observe(latency)
+}
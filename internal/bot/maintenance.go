@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// maintenanceEndedEvent is the event type replayed for PRs that changed during maintenance mode.
+const maintenanceEndedEvent = "maintenance_ended"
+
+// maintenanceQueue holds PRs that changed while the org was in maintenance mode.
+type maintenanceQueue struct {
+	pending map[string]bool // PR URLs
+	mu      sync.Mutex
+}
+
+// inMaintenance reports whether the org is in maintenance mode, set with /goose maintenance.
+func (c *Coordinator) inMaintenance(ctx context.Context) bool {
+	info, ok := c.store.Maintenance(ctx, c.org)
+	return ok && info.On
+}
+
+// holdForMaintenance queues a PR that changed during maintenance mode to be replayed when it
+// ends, if the org's config asks for it. Otherwise the change is left for the next poll.
+func (c *Coordinator) holdForMaintenance(prURL string) {
+	if !c.config.QueueDuringMaintenance(c.org) {
+		return
+	}
+
+	q := &c.maintenance
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending == nil {
+		q.pending = make(map[string]bool)
+	}
+	q.pending[prURL] = true
+}
+
+// SetMaintenance turns the org's maintenance mode on or off. While on, events are still
+// processed for dedup but nothing is posted or DMed. Turning it off retires threads a config
+// reload in the meantime left untracked, and replays the PRs that changed.
+func (c *Coordinator) SetMaintenance(ctx context.Context, on bool, setBy string) error {
+	prev, _ := c.store.Maintenance(ctx, c.org)
+	info := state.MaintenanceInfo{On: on, SetBy: setBy, SetAt: time.Now()}
+	if err := c.store.SaveMaintenance(ctx, c.org, info); err != nil {
+		return fmt.Errorf("save maintenance mode: %w", err)
+	}
+	c.logger.Info("maintenance mode changed", "on", on, "set_by", setBy)
+	if on {
+		return nil
+	}
+
+	q := &c.maintenance
+	q.mu.Lock()
+	urls := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	c.reconcileUntrackedThreads(ctx)
+
+	// Events other instances held, or this one held before a restart, are only in the search
+	if prev.On && c.config.QueueDuringMaintenance(c.org) {
+		for _, url := range c.changedSince(ctx, prev.SetAt) {
+			if urls == nil {
+				urls = make(map[string]bool)
+			}
+			urls[url] = true
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	c.logger.Info("maintenance ended, replaying held back PRs", "prs", len(urls))
+	now := time.Now()
+	for _, url := range slices.Sorted(maps.Keys(urls)) {
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        url,
+			Type:       maintenanceEndedEvent,
+			DeliveryID: "maintenance-" + strconv.FormatInt(now.UnixNano(), 10),
+			Timestamp:  now,
+		})
+	}
+	return nil
+}
+
+// changedSince returns the URLs of the org's PRs updated or closed since a time, from the PR
+// searcher, or nil without one.
+func (c *Coordinator) changedSince(ctx context.Context, since time.Time) []string {
+	if c.searcher == nil {
+		return nil
+	}
+	hours := max(int(math.Ceil(time.Since(since).Hours())), 1)
+	open, err := c.searcher.ListOpenPRs(ctx, c.org, hours)
+	if err != nil {
+		c.logger.Warn("failed to list PRs changed during maintenance", "error", err)
+	}
+	closed, err := c.searcher.ListClosedPRs(ctx, c.org, hours)
+	if err != nil {
+		c.logger.Warn("failed to list PRs closed during maintenance", "error", err)
+	}
+
+	var urls []string
+	for _, pr := range slices.Concat(open, closed) {
+		if !pr.UpdatedAt.Before(since) {
+			urls = append(urls, pr.URL)
+		}
+	}
+	return urls
+}
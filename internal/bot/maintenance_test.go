@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Needs eyes", Author: "alice", State: "open"},
+		Analysis: Analysis{
+			Checks:     Checks{Passing: 1},
+			NextAction: map[string]Action{"bob": {Kind: "review"}},
+		},
+	}
+
+	cfg := newMockConfigManager()
+	cfg.maintenanceQueue = true
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     cfg,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	if err := coord.SetMaintenance(ctx, true, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(on) error = %v", err)
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.postedMessages) != 0 || len(discord.sentDMs) != 0 {
+		t.Fatalf("posted %d messages and sent %d DMs in maintenance, want none",
+			len(discord.postedMessages), len(discord.sentDMs))
+	}
+	// The event still counts as processed, so a redelivery isn't replayed later
+	if store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"d-1", eventClaimTTL) {
+		t.Error("event processed during maintenance wasn't recorded for dedup")
+	}
+	if info, ok := store.Maintenance(ctx, "testorg"); !ok || !info.On || info.SetBy != "admin" {
+		t.Errorf("Maintenance() = %+v, %v, want on, set by admin", info, ok)
+	}
+
+	// Turning it off replays the held back PR
+	if err := coord.SetMaintenance(ctx, false, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(off) error = %v", err)
+	}
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("posted %d messages after maintenance, want 1", len(discord.postedMessages))
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); !ok {
+		t.Error("expected the replayed PR's channel message to be tracked")
+	}
+}
+
+func TestCoordinator_Maintenance_DropsWithoutQueue(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Needs eyes", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 1}},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	if err := coord.SetMaintenance(ctx, true, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(on) error = %v", err)
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if err := coord.SetMaintenance(ctx, false, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(off) error = %v", err)
+	}
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("posted %d messages after maintenance, want none without queue_during_maintenance", len(discord.postedMessages))
+	}
+
+	// New events post as usual once it's off
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("posted %d messages after a new event, want 1", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_Maintenance_ReplaysFromSearch(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Needs eyes", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 1}},
+	}
+	cfg := newMockConfigManager()
+	cfg.maintenanceQueue = true
+	store := state.NewMemoryStore()
+	searcher := &mockPRSearcher{}
+	newCoord := func() *Coordinator {
+		return NewCoordinator(CoordinatorConfig{
+			Discord:  discord,
+			Config:   cfg,
+			Store:    store,
+			Turn:     turn,
+			Searcher: searcher,
+			Org:      "testorg",
+		})
+	}
+
+	// One instance holds an event back, then restarts before maintenance ends
+	first := newCoord()
+	if err := first.SetMaintenance(ctx, true, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(on) error = %v", err)
+	}
+	first.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	first.Wait()
+	searcher.openPRs = []PRSearchResult{
+		{URL: prURL, Owner: "testorg", Repo: "testrepo", Number: 1, UpdatedAt: time.Now()},
+		{URL: "https://github.com/testorg/testrepo/pull/2", Owner: "testorg", Repo: "testrepo", Number: 2, UpdatedAt: time.Now().Add(-time.Hour)},
+	}
+
+	restarted := newCoord()
+	if err := restarted.SetMaintenance(ctx, false, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(off) error = %v", err)
+	}
+	restarted.Wait()
+	// Only the PR that changed during maintenance is replayed
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("posted %d messages after maintenance, want 1", len(discord.postedMessages))
+	}
+	if _, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); !ok {
+		t.Error("expected the PR changed during maintenance to be replayed")
+	}
+}
+
+func TestCoordinator_Maintenance_DefersUntrackedThreads(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+	discord.channelIDs["frontend"] = "chan-frontend"
+	discord.channelIDs["ops"] = "chan-ops"
+
+	cfg := newMockConfigManager()
+	cfg.channels["testorg:api"] = []string{"backend"}
+	cfg.adminChannel = "ops"
+	cfg.configs["testorg"] = &config.DiscordConfig{Channels: map[string]config.ChannelConfig{"frontnd": {}}}
+	store := state.NewMemoryStore()
+	info := state.ThreadInfo{ThreadID: "thread-frontend", MessageID: "msg-1", ChannelID: "chan-frontend", ChannelType: "forum", LastState: "needs_review"}
+	if err := store.SaveThread(ctx, "testorg", "api", 42, "chan-frontend", info); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  cfg,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if err := coord.SetMaintenance(ctx, true, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(on) error = %v", err)
+	}
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if len(discord.archivedThreads) != 0 || len(discord.postedMessages) != 0 {
+		t.Fatalf("archived %v and posted %d messages in maintenance, want nothing",
+			discord.archivedThreads, len(discord.postedMessages))
+	}
+
+	// Ending it retires what the reload left untracked
+	if err := coord.SetMaintenance(ctx, false, "admin"); err != nil {
+		t.Fatalf("SetMaintenance(off) error = %v", err)
+	}
+	if !slices.Equal(discord.archivedThreads, []string{"thread-frontend"}) {
+		t.Errorf("archivedThreads = %v after maintenance, want [thread-frontend]", discord.archivedThreads)
+	}
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// messageChunkIDs returns the IDs of the messages a text channel notification spans, in order.
+func messageChunkIDs(info state.ThreadInfo) []string {
+	if len(info.MessageIDs) > 0 {
+		return info.MessageIDs
+	}
+	if info.MessageID == "" {
+		return nil
+	}
+	return []string{info.MessageID}
+}
+
+// postChunks posts the continuation chunks of a notification whose first chunk was just
+// posted as messageID, and records them with the PR's message. A chunk that fails to post
+// ends the notification early; the next edit posts the rest.
+func (c *Coordinator) postChunks(ctx context.Context, params *channelProcessParams, messageID string, chunks []string) {
+	if len(chunks) < 2 {
+		return
+	}
+	ids := []string{messageID}
+	for _, chunk := range chunks[1:] {
+		id, err := c.discord.PostMessage(ctx, params.channelID, chunk)
+		if err != nil {
+			c.logger.Warn("failed to post message chunk", "error", err, "pr", params.params.PRURL)
+			break
+		}
+		ids = append(ids, id)
+	}
+	if err := c.store.SetThreadMessageIDs(ctx, params.owner, params.repo, params.number, params.channelID, ids); err != nil {
+		c.logger.Warn("failed to save message chunk IDs", "error", err)
+	}
+}
+
+// updateChunks edits a notification's messages to the new chunks, posting more messages if
+// it grew and deleting the extras if it shrank. Returns the message IDs it now spans.
+// Fails only if the first message can't be edited.
+func (c *Coordinator) updateChunks(
+	ctx context.Context,
+	channelID string,
+	ids, chunks []string,
+	silent bool,
+) ([]string, error) {
+	if err := c.discord.UpdateMessage(ctx, channelID, ids[0], chunks[0], silent); err != nil {
+		return nil, fmt.Errorf("update message: %w", err)
+	}
+
+	updated := []string{ids[0]}
+	for i, chunk := range chunks[1:] {
+		if i+1 < len(ids) {
+			if err := c.discord.UpdateMessage(ctx, channelID, ids[i+1], chunk, silent); err != nil {
+				c.logger.Warn("failed to update message chunk", "error", err, "message_id", ids[i+1])
+			}
+			updated = append(updated, ids[i+1])
+			continue
+		}
+		id, err := c.discord.PostMessage(ctx, channelID, chunk)
+		if err != nil {
+			c.logger.Warn("failed to post message chunk", "error", err, "channel_id", channelID)
+			break
+		}
+		updated = append(updated, id)
+	}
+	for _, id := range ids[min(len(chunks), len(ids)):] {
+		if err := c.discord.DeleteMessage(ctx, channelID, id); err != nil {
+			c.logger.Warn("failed to delete extra message chunk", "error", err, "message_id", id)
+		}
+	}
+	return updated, nil
+}
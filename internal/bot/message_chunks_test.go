@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessTextChannel_MessageChunks(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.postIDs = []string{"msg-1", "msg-2", "msg-3", "msg-4"}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: strings.Repeat("long title ", 6), Author: "alice", State: "open"},
+		Analysis: Analysis{
+			Checks:     Checks{Passing: 1},
+			NextAction: map[string]Action{"bob": {Kind: "review"}},
+		},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.messageLimit = 120
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok {
+		t.Fatal("expected the PR's channel message to be tracked")
+	}
+	chunks := format.SplitMessage(info.MessageText, coord.messageLimit)
+	if len(chunks) != 2 {
+		t.Fatalf("message split into %d chunks, want 2 for this test: %q", len(chunks), info.MessageText)
+	}
+	if len(discord.postedMessages) != 2 {
+		t.Fatalf("posted %d messages, want one per chunk", len(discord.postedMessages))
+	}
+	if info.MessageID != "msg-1" || !slices.Equal(info.MessageIDs, []string{"msg-1", "msg-2"}) {
+		t.Fatalf("thread = %+v, want message IDs [msg-1 msg-2]", info)
+	}
+
+	// A state change edits both chunks in place
+	turn.responses[prURL].Analysis = Analysis{Checks: Checks{Passing: 1}, Approved: true}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request_review", DeliveryID: "d-2"})
+	coord.Wait()
+
+	var edited []string
+	for _, u := range discord.updatedMessages {
+		edited = append(edited, u.messageID)
+	}
+	if !slices.Equal(edited, []string{"msg-1", "msg-2"}) {
+		t.Errorf("edited messages %v, want [msg-1 msg-2]", edited)
+	}
+	if len(discord.postedMessages) != 2 || len(discord.deletedMessages) != 0 {
+		t.Errorf("posted %d and deleted %v messages on edit, want no new or deleted messages",
+			len(discord.postedMessages), discord.deletedMessages)
+	}
+}
+
+func TestCoordinator_UpdateChunks_Resize(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.postIDs = []string{"msg-3"}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Org:     "testorg",
+	})
+
+	// Growing posts the extra chunk
+	ids, err := coord.updateChunks(ctx, "chan", []string{"msg-1", "msg-2"}, []string{"a", "b", "c"}, true)
+	if err != nil {
+		t.Fatalf("updateChunks() error = %v", err)
+	}
+	if !slices.Equal(ids, []string{"msg-1", "msg-2", "msg-3"}) {
+		t.Errorf("updateChunks() grew to %v, want [msg-1 msg-2 msg-3]", ids)
+	}
+
+	// Shrinking deletes the extras
+	ids, err = coord.updateChunks(ctx, "chan", ids, []string{"a"}, true)
+	if err != nil {
+		t.Fatalf("updateChunks() error = %v", err)
+	}
+	if !slices.Equal(ids, []string{"msg-1"}) {
+		t.Errorf("updateChunks() shrank to %v, want [msg-1]", ids)
+	}
+	if !slices.Equal(discord.deletedMessages, []string{"chan/msg-2", "chan/msg-3"}) {
+		t.Errorf("deleted %v, want [chan/msg-2 chan/msg-3]", discord.deletedMessages)
+	}
+}
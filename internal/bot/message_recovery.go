@@ -0,0 +1,35 @@
+package bot
+
+import "sync"
+
+// maxRecoverySearches bounds the channel/PR pairs remembered as already searched.
+const maxRecoverySearches = 5000
+
+// recoverySearches remembers the channels already searched for a PR message the store lost
+// track of. A search scans the channel's history, so it's only done on a PR's first event
+// in a channel; a miss means there's nothing to recover, and later events skip the scan.
+type recoverySearches struct {
+	searched map[string]bool // recoverySearchKey
+	mu       sync.Mutex
+}
+
+// recoverySearchKey keys a channel's search for a PR message, apart from the write cooldown's keys.
+func recoverySearchKey(channelID, prURL string) string {
+	return "recovery/" + channelID + "/" + prURL
+}
+
+// first reports whether a channel hasn't been searched for a PR's message yet, and marks it searched.
+func (r *recoverySearches) first(channelID, prURL string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := recoverySearchKey(channelID, prURL)
+	if r.searched[key] {
+		return false
+	}
+	if r.searched == nil || len(r.searched) >= maxRecoverySearches {
+		r.searched = make(map[string]bool)
+	}
+	r.searched[key] = true
+	return true
+}
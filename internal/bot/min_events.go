@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// heldPRTTL is how long the events of a held back PR are counted.
+const heldPRTTL = 7 * 24 * time.Hour
+
+// holdNewPR reports whether a PR no channel tracks yet is held back because it hasn't had
+// min_events_to_post events. Held events are counted with "seen but not yet posted" markers
+// in the event store, so every instance counts them. A PR merged or closed before it's
+// posted never is, which is the point: PRs opened and closed straight away stay quiet.
+func (c *Coordinator) holdNewPR(ctx context.Context, owner, repo string, number int, channels []string, prState format.PRState) bool {
+	minEvents := c.config.MinEventsToPost(c.org)
+	if minEvents <= 1 || c.trackedInAnyChannel(ctx, owner, repo, number, channels) {
+		return false
+	}
+	if prState == format.StateMerged || prState == format.StateClosed {
+		return true
+	}
+
+	prefix := EventKeyPrefix(FormatPRURL(owner, repo, number)) + "held:"
+	seen := 0
+	for seen < minEvents-1 && c.store.WasProcessed(ctx, prefix+strconv.Itoa(seen+1)) {
+		seen++
+	}
+	if seen == minEvents-1 {
+		return false
+	}
+	if err := c.store.MarkProcessed(ctx, prefix+strconv.Itoa(seen+1), heldPRTTL); err != nil {
+		c.logger.Warn("failed to count held back PR event", "error", err, "pr_url", FormatPRURL(owner, repo, number))
+	}
+	return true
+}
+
+// trackedInAnyChannel reports whether any of the PR's channels has a message or thread for it.
+func (c *Coordinator) trackedInAnyChannel(ctx context.Context, owner, repo string, number int, channels []string) bool {
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if _, ok := c.store.Thread(ctx, owner, repo, number, channelID); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_MinEventsToPost(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.minEvents = 2
+
+	shortLived := "https://github.com/testorg/testrepo/pull/1"
+	lasting := "https://github.com/testorg/testrepo/pull/2"
+	turn := newMockTurnClient()
+	turn.responses[shortLived] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Oops", Author: "alice", State: "open"},
+	}
+	turn.responses[lasting] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add feature", Author: "bob", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: shortLived, Type: "pull_request", DeliveryID: "d-1"})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: lasting, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d after each PR's first event, want 0", len(discord.postedMessages))
+	}
+
+	// The short-lived PR is closed before its second event; the lasting one gets one
+	turn.responses[shortLived] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Oops", Author: "alice", State: "closed", Closed: true},
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: shortLived, Type: "pull_request", DeliveryID: "d-3"})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: lasting, Type: "pull_request", DeliveryID: "d-4"})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want only the lasting PR posted", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; !strings.Contains(text, "Add feature") {
+		t.Errorf("posted message = %q, want the lasting PR", text)
+	}
+}
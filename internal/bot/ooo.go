@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// redirectOOO hands the actions of out of office users, as set with /goose ooo, to the backups
+// configured in ooo_backups, so the PR's messages and DMs reach someone who can act on them.
+// While anyone else on the PR can still act, out of office users are just left out rather than
+// pulling in a backup. A user without an available backup keeps their action, so the PR is
+// never left waiting on nobody. The check response is copied, never modified.
+func (c *Coordinator) redirectOOO(ctx context.Context, checkResp *CheckResponse) *CheckResponse {
+	out := make(map[string]Action)
+	available := 0
+	for username, action := range checkResp.Analysis.NextAction {
+		if username == "_system" {
+			continue
+		}
+		if c.isOOO(ctx, username) {
+			out[username] = action
+			continue
+		}
+		available++
+	}
+	if len(out) == 0 {
+		return checkResp
+	}
+
+	redirected := *checkResp
+	redirected.Analysis.NextAction = maps.Clone(checkResp.Analysis.NextAction)
+	for username, action := range out {
+		if available > 0 {
+			delete(redirected.Analysis.NextAction, username)
+			c.logger.Info("skipping out of office user while others can act", "user", username)
+			continue
+		}
+		backup := c.config.OOOBackup(c.org, username)
+		if backup == "" || c.isOOO(ctx, backup) {
+			c.logger.Info("out of office user has no available backup, keeping their action", "user", username)
+			continue
+		}
+		delete(redirected.Analysis.NextAction, username)
+		if _, ok := redirected.Analysis.NextAction[backup]; !ok {
+			redirected.Analysis.NextAction[backup] = action
+		}
+		c.logger.Info("redirected out of office user's action to backup",
+			"user", username,
+			"backup", backup,
+			"action", action.Kind)
+	}
+	return &redirected
+}
+
+// isOOO reports whether a GitHub user's Discord account is out of office.
+func (c *Coordinator) isOOO(ctx context.Context, username string) bool {
+	if c.UserMapper == nil {
+		return false
+	}
+	discordID := c.UserMapper.DiscordID(ctx, username)
+	if discordID == "" {
+		return false
+	}
+	info, ok := c.store.OOO(ctx, discordID)
+	return ok && time.Now().Before(info.Until)
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_RedirectOOO(t *testing.T) {
+	tests := []struct {
+		name        string
+		nextAction  map[string]Action
+		backup      string
+		wantPing    string
+		wantNoPing  string
+		wantDMUser  string
+		wantNoDMFor string
+	}{
+		{
+			name:        "only target out, redirected to backup",
+			nextAction:  map[string]Action{"alice": {Kind: "review"}},
+			backup:      "bob",
+			wantPing:    "<@discord-bob>",
+			wantNoPing:  "<@discord-alice>",
+			wantDMUser:  "discord-bob",
+			wantNoDMFor: "discord-alice",
+		},
+		{
+			name:        "others can act, out of office user left out",
+			nextAction:  map[string]Action{"alice": {Kind: "review"}, "carol": {Kind: "review"}},
+			backup:      "bob",
+			wantPing:    "<@discord-carol>",
+			wantNoPing:  "<@discord-bob>",
+			wantDMUser:  "discord-carol",
+			wantNoDMFor: "discord-alice",
+		},
+		{
+			name:       "no backup configured, kept",
+			nextAction: map[string]Action{"alice": {Kind: "review"}},
+			wantPing:   "<@discord-alice>",
+			wantDMUser: "discord-alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			mapper := newMockUserMapper()
+			for _, user := range []string{"alice", "bob", "carol"} {
+				mapper.mappings[user] = "discord-" + user
+				discord.usersInGuild["discord-"+user] = true
+			}
+
+			configMgr := newMockConfigManager()
+			if tt.backup != "" {
+				configMgr.oooBackups = map[string]string{"alice": tt.backup}
+			}
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Add feature", Author: "dave", State: "open"},
+				Analysis:    Analysis{NextAction: tt.nextAction},
+			}
+
+			store := state.NewMemoryStore()
+			if err := store.SaveOOO(ctx, "discord-alice", state.OOOInfo{Until: time.Now().Add(24 * time.Hour)}); err != nil {
+				t.Fatalf("SaveOOO() error = %v", err)
+			}
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if !strings.Contains(text, tt.wantPing) {
+				t.Errorf("message = %q, want it to mention %s", text, tt.wantPing)
+			}
+			if tt.wantNoPing != "" && strings.Contains(text, tt.wantNoPing) {
+				t.Errorf("message = %q, want no mention of %s", text, tt.wantNoPing)
+			}
+
+			pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+			if err != nil {
+				t.Fatalf("PendingDMs() error = %v", err)
+			}
+			dmed := make(map[string]bool)
+			for _, dm := range pending {
+				dmed[dm.UserID] = true
+			}
+			for _, dm := range discord.sentDMs {
+				dmed[dm.userID] = true
+			}
+			if !dmed[tt.wantDMUser] {
+				t.Errorf("DMed users = %v, want %s", dmed, tt.wantDMUser)
+			}
+			if tt.wantNoDMFor != "" && dmed[tt.wantNoDMFor] {
+				t.Errorf("DMed users = %v, want no DM for %s", dmed, tt.wantNoDMFor)
+			}
+		})
+	}
+}
+
+func TestCoordinator_RedirectOOO_Lapsed(t *testing.T) {
+	ctx := context.Background()
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	configMgr := newMockConfigManager()
+	configMgr.oooBackups = map[string]string{"alice": "bob"}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveOOO(ctx, "discord-alice", state.OOOInfo{Until: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatalf("SaveOOO() error = %v", err)
+	}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    newMockDiscordClient(),
+		Config:     configMgr,
+		Store:      store,
+		Turn:       newMockTurnClient(),
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	checkResp := &CheckResponse{Analysis: Analysis{NextAction: map[string]Action{"alice": {Kind: "review"}}}}
+	if got := coord.redirectOOO(ctx, checkResp); got != checkResp {
+		t.Errorf("redirectOOO() = %v, want the response unchanged once out of office has lapsed", got.Analysis.NextAction)
+	}
+}
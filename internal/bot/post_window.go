@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// postWindowOpenedEvent is the event type replayed for PRs held back while a channel's post window was closed.
+const postWindowOpenedEvent = "post_window_opened"
+
+// deferToPostWindow reports whether a PR's post to a channel should wait because the channel's
+// post window is closed, and if so, queues the PR for when it opens. The queue is stored, so
+// it survives restarts and any instance can flush it. Merged and closed PRs are never held
+// back, nor are PRs that couldn't be queued.
+func (c *Coordinator) deferToPostWindow(ctx context.Context, channelName, prURL string, prState format.PRState) bool {
+	if prState == format.StateMerged || prState == format.StateClosed {
+		return false
+	}
+	window, ok := c.config.PostWindow(c.org, channelName)
+	if !ok || window.Open(time.Now()) {
+		return false
+	}
+
+	err := c.store.UpdatePostWindows(ctx, c.org, func(info *state.PostWindowInfo) bool {
+		if info.Pending[channelName][prURL] {
+			return false
+		}
+		info.Pending = maps.Clone(info.Pending)
+		if info.Pending == nil {
+			info.Pending = make(map[string]map[string]bool)
+		}
+		info.Pending[channelName] = maps.Clone(info.Pending[channelName])
+		if info.Pending[channelName] == nil {
+			info.Pending[channelName] = make(map[string]bool)
+		}
+		info.Pending[channelName][prURL] = true
+		return true
+	})
+	if err != nil {
+		c.logger.Warn("failed to hold back channel post, posting now",
+			"error", err,
+			"channel", channelName,
+			"pr", prURL)
+		return false
+	}
+
+	c.logger.Debug("post window closed, holding back channel post",
+		"channel", channelName,
+		"pr", prURL)
+	return true
+}
+
+// FlushPostWindows replays the PRs held back for each channel whose post window is now open.
+func (c *Coordinator) FlushPostWindows(ctx context.Context) {
+	// Held back PRs stay queued until maintenance mode ends
+	if c.inMaintenance(ctx) {
+		return
+	}
+	now := time.Now()
+	urls := make(map[string]bool)
+
+	err := c.store.UpdatePostWindows(ctx, c.org, func(info *state.PostWindowInfo) bool {
+		info.Pending = maps.Clone(info.Pending)
+		for channelName, pending := range info.Pending {
+			window, ok := c.config.PostWindow(c.org, channelName)
+			if ok && !window.Open(now) {
+				continue
+			}
+			// Also flushes channels whose window was removed from config
+			maps.Copy(urls, pending)
+			delete(info.Pending, channelName)
+		}
+		return len(urls) > 0
+	})
+	if err != nil {
+		c.logger.Warn("failed to load held back PRs", "error", err)
+		return
+	}
+	if len(urls) == 0 {
+		return
+	}
+	c.logger.Info("post window opened, replaying held back PRs", "prs", len(urls))
+	for _, url := range slices.Sorted(maps.Keys(urls)) {
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        url,
+			Type:       postWindowOpenedEvent,
+			DeliveryID: "window-" + strconv.FormatInt(now.UnixNano(), 10),
+			Timestamp:  now,
+		})
+	}
+}
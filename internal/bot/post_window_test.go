@@ -0,0 +1,153 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// closedWindow returns a one-minute post window twelve hours from now.
+func closedWindow() config.PostWindow {
+	start := time.Now().UTC().Add(12 * time.Hour)
+	return config.PostWindow{Start: start.Format("15:04"), End: start.Add(time.Minute).Format("15:04")}
+}
+
+func TestCoordinator_PostWindow_DeferredUntilOpen(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.postWindows = map[string]config.PostWindow{"testrepo": closedWindow()}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Overnight PR", Author: "alice", State: "open"},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-night"})
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d outside the window, want 0", len(discord.postedMessages))
+	}
+
+	// Still closed: nothing is flushed
+	coord.FlushPostWindows(ctx)
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d while window closed, want 0", len(discord.postedMessages))
+	}
+
+	// The window opens
+	now := time.Now().UTC()
+	configMgr.postWindows["testrepo"] = config.PostWindow{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	coord.FlushPostWindows(ctx)
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d after window opened, want 1", len(discord.postedMessages))
+	}
+
+	// The queue was drained
+	coord.FlushPostWindows(ctx)
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after second flush, want 1", len(discord.postedMessages))
+	}
+}
+
+func TestCoordinator_PostWindow_TerminalStateBypasses(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.postWindows = map[string]config.PostWindow{"testrepo": closedWindow()}
+
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		MessageID:   "msg-1",
+		ChannelID:   "chan-testrepo",
+		ChannelType: "text",
+		MessageText: "old content",
+		LastState:   "needs_review",
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Overnight PR", Author: "alice", State: "closed", Merged: true},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-merged"})
+	coord.Wait()
+
+	if len(discord.updatedMessages) != 1 {
+		t.Errorf("updatedMessages = %d, want merged PR updated outside the window", len(discord.updatedMessages))
+	}
+}
+
+func TestCoordinator_PostWindow_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.postWindows = map[string]config.PostWindow{"testrepo": closedWindow()}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Overnight PR", Author: "alice", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	newCoordinator := func() *Coordinator {
+		return NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    turn,
+			Org:     "testorg",
+		})
+	}
+
+	before := newCoordinator()
+	before.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "delivery-night"})
+	before.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %d outside the window, want 0", len(discord.postedMessages))
+	}
+
+	// Restarted before the window opens
+	after := newCoordinator()
+	delete(configMgr.postWindows, "testrepo")
+	after.FlushPostWindows(ctx)
+	after.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Errorf("postedMessages = %d after restart, want the held back PR posted", len(discord.postedMessages))
+	}
+}
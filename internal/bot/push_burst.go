@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// pushBurstEvent is the event type replayed once a push's burst window has passed.
+	pushBurstEvent = "push_burst_settled"
+	// maxPushHeads bounds the PR head commits remembered to spot pushes.
+	maxPushHeads = 5000
+)
+
+// pushBursts holds back the events of a push burst: a push to a PR branch is followed within
+// seconds by a check event per CI job, each of which would otherwise re-render the PR.
+type pushBursts struct {
+	pending map[string]*time.Timer // PR URL -> timer that replays the PR once the burst is over
+	heads   map[string]string      // PR URL -> head commit SHA of its last event
+	mu      sync.Mutex
+}
+
+// pushed reports whether an event shows new commits on its PR: its commit SHA differs from
+// the one the PR's last event carried. Sprinkler events name no action, so a synchronize
+// looks like any other pull_request event; whichever event brings the new head, often a
+// check run, marks the push. A PR's first event since startup can't be told apart and
+// isn't one. Must be called with mu held.
+func (b *pushBursts) pushed(event SprinklerEvent) bool {
+	if event.CommitSHA == "" {
+		return false
+	}
+	if b.heads == nil || len(b.heads) >= maxPushHeads {
+		b.heads = make(map[string]string)
+	}
+	last, ok := b.heads[event.URL]
+	b.heads[event.URL] = event.CommitSHA
+	return ok && last != event.CommitSHA
+}
+
+// isCheckEvent reports whether an event is a CI status change.
+func isCheckEvent(eventType string) bool {
+	switch eventType {
+	case "check_run", "check_suite", "status", "workflow_run":
+		return true
+	default:
+		return false
+	}
+}
+
+// coalescePushBurst reports whether an event should be skipped because it's part of a push
+// burst. A push starts a burst lasting the org's push_burst_window; it and the check events
+// during it are only marked processed, and one replay renders the PR's latest state once the
+// window has passed. The window isn't extended by later events, so a busy PR still renders
+// regularly. Other events, such as reviews, are processed as usual.
+func (c *Coordinator) coalescePushBurst(ctx context.Context, event SprinklerEvent) bool {
+	window := c.config.PushBurstWindow(c.org)
+	if window <= 0 {
+		return false
+	}
+
+	b := &c.pushBursts
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	url := event.URL
+	push := event.Type == "push" || b.pushed(event)
+	if _, ok := b.pending[url]; ok {
+		if push || isCheckEvent(event.Type) {
+			c.logger.Debug("coalescing event into push burst",
+				"pr_url", url,
+				"type", event.Type,
+				"delivery_id", event.DeliveryID)
+			return true
+		}
+		return false
+	}
+	if !push {
+		return false
+	}
+	if b.pending == nil {
+		b.pending = make(map[string]*time.Timer)
+	}
+
+	// Counted in wg until the replay is dispatched, so Wait covers pending replays
+	c.wg.Add(1)
+	b.pending[url] = time.AfterFunc(window, func() {
+		defer c.wg.Done()
+		b.mu.Lock()
+		delete(b.pending, url)
+		b.mu.Unlock()
+
+		c.logger.Debug("push burst over, replaying PR", "pr_url", url)
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        url,
+			Type:       pushBurstEvent,
+			DeliveryID: "burst-" + strconv.FormatInt(time.Now().UnixNano(), 10),
+			Timestamp:  time.Now(),
+		})
+	})
+	c.logger.Info("push to PR, coalescing its burst",
+		"pr_url", url,
+		"window", window)
+	return true
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_PushBurst(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.pushBurst = 200 * time.Millisecond
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Speed up builds", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 3}},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-opened", CommitSHA: "abc123"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	turnCalls := turn.callCount
+
+	// A push, then a check event per CI job, all within the window. Sprinkler sends the
+	// synchronize as a bare pull_request event; only its new commit SHA marks the push.
+	turn.responses[prURL].Analysis.Checks = Checks{Pending: 3}
+	events := []SprinklerEvent{{URL: prURL, Type: "pull_request", DeliveryID: "d-push", CommitSHA: "def456"}}
+	for i := range 3 {
+		events = append(events, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: fmt.Sprintf("d-check-%d", i), CommitSHA: "def456"})
+	}
+	for _, event := range events {
+		// Synchronous, since Wait would also wait out the pending replay
+		if err := coord.processEventSync(ctx, event); err != nil {
+			t.Fatalf("%s: processEventSync() error = %v", event.DeliveryID, err)
+		}
+	}
+	if len(discord.updatedMessages) != 0 || turn.callCount != turnCalls {
+		t.Fatalf("updatedMessages = %d, Turn calls = %d during the burst, want none", len(discord.updatedMessages), turn.callCount-turnCalls)
+	}
+
+	// Wait covers the replay, which renders the burst once
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1 render for the burst", len(discord.updatedMessages))
+	}
+	if turn.callCount != turnCalls+1 {
+		t.Errorf("Turn calls = %d for the burst, want 1", turn.callCount-turnCalls)
+	}
+
+	// Once it's over, check events render as usual
+	turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-check-late", CommitSHA: "def456"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 2 {
+		t.Errorf("updatedMessages = %d after the burst, want 2", len(discord.updatedMessages))
+	}
+}
+
+func TestCoordinator_coalescePushBurst_Disabled(t *testing.T) {
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	push := SprinklerEvent{URL: "https://github.com/testorg/testrepo/pull/1", Type: "push", DeliveryID: "d-push"}
+	if coord.coalescePushBurst(context.Background(), push) {
+		t.Error("coalescePushBurst() = true with no window, want false")
+	}
+}
+
+func TestPushBursts_pushed(t *testing.T) {
+	const prURL = "https://github.com/testorg/testrepo/pull/1"
+	var b pushBursts
+	tests := []struct {
+		name  string
+		event SprinklerEvent
+		want  bool
+	}{
+		{"first event since startup", SprinklerEvent{URL: prURL, Type: "pull_request", CommitSHA: "abc123"}, false},
+		{"same head", SprinklerEvent{URL: prURL, Type: "pull_request_review", CommitSHA: "abc123"}, false},
+		{"no commit SHA", SprinklerEvent{URL: prURL, Type: "issue_comment"}, false},
+		{"check run brings the new head", SprinklerEvent{URL: prURL, Type: "check_run", CommitSHA: "def456"}, true},
+		{"synchronize after it", SprinklerEvent{URL: prURL, Type: "pull_request", CommitSHA: "def456"}, false},
+		{"other PR", SprinklerEvent{URL: prURL + "0", Type: "pull_request", CommitSHA: "def456"}, false},
+	}
+	for _, tt := range tests {
+		if got := b.pushed(tt.event); got != tt.want {
+			t.Errorf("%s: pushed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
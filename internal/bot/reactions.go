@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// ackReaction is the reaction an action target adds to a PR's channel message to acknowledge it.
+const ackReaction = "✅"
+
+// reactionAckEvent is the event type replayed to re-render a PR after it was acknowledged.
+const reactionAckEvent = "reaction_ack"
+
+// HandleReaction acknowledges a PR when one of its action targets reacts to the PR's
+// channel message with ✅. The message then notes who acknowledged it and its edits stop
+// pinging until the PR's state changes. Reactions from anyone else are ignored.
+func (c *Coordinator) HandleReaction(ctx context.Context, channelID, messageID, discordUserID, emoji string) {
+	if emoji != ackReaction || discordUserID == "" {
+		return
+	}
+
+	// Forum reactions arrive with the thread as their channel, which is how they're indexed
+	thread, ok := c.store.ThreadForMessage(ctx, channelID, messageID)
+	if !ok || thread.Owner != c.org {
+		return
+	}
+	prURL := FormatPRURL(thread.Owner, thread.Repo, thread.Number)
+	lastState := thread.Info.LastState
+
+	checkResp, err := c.checkTurn(ctx, prURL, "", time.Now())
+	if err != nil {
+		c.logger.Warn("failed to check PR for reaction", "error", err, "pr_url", prURL)
+		return
+	}
+	username := c.actionTargetForDiscordUser(ctx, checkResp, discordUserID)
+	if username == "" {
+		c.logger.Debug("ignoring reaction from user who isn't an action target",
+			"pr_url", prURL,
+			"discord_user_id", discordUserID)
+		return
+	}
+
+	prLock := c.prLocks.get(prURL)
+	prLock.Lock()
+	override, _ := c.store.PROverride(ctx, prURL)
+	alreadyAcked := override.AcknowledgedBy == username && override.AcknowledgedState == lastState
+	override.AcknowledgedBy = username
+	override.AcknowledgedState = lastState
+	err = c.store.SavePROverride(ctx, prURL, override)
+	prLock.Unlock()
+	if err != nil {
+		c.logger.Warn("failed to save PR acknowledgment", "error", err, "pr_url", prURL)
+		return
+	}
+	if alreadyAcked {
+		return
+	}
+
+	c.logger.Info("PR acknowledged by reaction",
+		"pr_url", prURL,
+		"github_user", username,
+		"state", lastState)
+	now := time.Now()
+	c.replayEvent(ctx, SprinklerEvent{
+		URL:        prURL,
+		Type:       reactionAckEvent,
+		DeliveryID: "ack-" + strconv.FormatInt(now.UnixNano(), 10),
+		Timestamp:  now,
+	})
+}
+
+// actionTargetForDiscordUser returns the GitHub username of the PR's action target
+// mapped to a Discord user, or "" if the user isn't one of them.
+func (c *Coordinator) actionTargetForDiscordUser(ctx context.Context, checkResp *CheckResponse, discordUserID string) string {
+	if c.UserMapper == nil {
+		return ""
+	}
+	for _, username := range slices.Sorted(maps.Keys(checkResp.Analysis.NextAction)) {
+		if username == "_system" {
+			continue
+		}
+		if c.UserMapper.DiscordID(ctx, username) == discordUserID {
+			return username
+		}
+	}
+	return ""
+}
+
+// acknowledgedBy returns who acknowledged the PR in its current state, or "" if nobody has.
+func acknowledgedBy(override state.PROverride, prState format.PRState) string {
+	if override.AcknowledgedState != string(prState) {
+		return ""
+	}
+	return override.AcknowledgedBy
+}
@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_HandleReaction(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+	mapper.mappings["eve"] = "discord-eve"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Needs eyes", Author: "alice", State: "open"},
+		Analysis: Analysis{
+			Checks:     Checks{Passing: 1},
+			NextAction: map[string]Action{"bob": {Kind: "review"}},
+		},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     newMockConfigManager(),
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	thread, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok {
+		t.Fatal("expected the PR's channel message to be tracked")
+	}
+
+	// Someone who isn't asked to act on the PR can't acknowledge it
+	coord.HandleReaction(ctx, "chan-testrepo", thread.MessageID, "discord-eve", ackReaction)
+	// Nor does a different reaction from a reviewer count
+	coord.HandleReaction(ctx, "chan-testrepo", thread.MessageID, "discord-bob", "👍")
+	coord.Wait()
+	if override, _ := store.PROverride(ctx, prURL); override.AcknowledgedBy != "" {
+		t.Errorf("AcknowledgedBy = %q after ignored reactions, want empty", override.AcknowledgedBy)
+	}
+	if len(discord.updatedMessages) != 0 {
+		t.Fatalf("updatedMessages = %d after ignored reactions, want 0", len(discord.updatedMessages))
+	}
+
+	// The reviewer's ✅ notes the acknowledgment without pinging anyone
+	coord.HandleReaction(ctx, "chan-testrepo", thread.MessageID, "discord-bob", ackReaction)
+	coord.Wait()
+	override, _ := store.PROverride(ctx, prURL)
+	if override.AcknowledgedBy != "bob" || override.AcknowledgedState != thread.LastState {
+		t.Errorf("override = %+v, want acknowledged by bob in state %q", override, thread.LastState)
+	}
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d after acknowledgment, want 1", len(discord.updatedMessages))
+	}
+	if update := discord.updatedMessages[0]; !strings.Contains(update.text, "acknowledged by bob") || !update.silent {
+		t.Errorf("update = %+v, want a silent edit noting bob's acknowledgment", update)
+	}
+
+	// The acknowledgment lapses once the PR's state changes
+	turn.responses[prURL].Analysis = Analysis{Checks: Checks{Passing: 1}, Approved: true}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request_review", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 2 {
+		t.Fatalf("updatedMessages = %d after state change, want 2", len(discord.updatedMessages))
+	}
+	if text := discord.updatedMessages[1].text; strings.Contains(text, "acknowledged") {
+		t.Errorf("message after state change = %q, want no acknowledgment", text)
+	}
+}
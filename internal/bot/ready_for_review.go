@@ -0,0 +1,21 @@
+package bot
+
+import (
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// markedReady reports whether a PR whose thread in a channel was saved as a draft has since
+// been marked ready for review. Its update is then treated as a fresh notification that pings
+// its reviewers, even where edits are otherwise silent, or where the draft's state was
+// suppressed in the channel.
+func (c *Coordinator) markedReady(
+	channelName, prURL string, threadInfo state.ThreadInfo, exists bool, checkResp *CheckResponse,
+) bool {
+	if !exists || !threadInfo.Draft || checkResp.PullRequest.Draft {
+		return false
+	}
+	c.logger.Info("draft marked ready for review, pinging reviewers",
+		"channel", channelName,
+		"pr_url", prURL)
+	return true
+}
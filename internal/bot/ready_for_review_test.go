@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_DraftMarkedReady(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["discord-alice"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	configMgr := newMockConfigManager()
+	// Without the ready ping, leaving draft would be a silent transition
+	configMgr.transitions = []string{"needs_review->changes_requested"}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "bob", State: "open", Draft: true},
+		Analysis:    Analysis{NextAction: map[string]Action{"alice": {Kind: "review"}}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want the draft posted", len(discord.postedMessages))
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); !info.Draft {
+		t.Error("thread not saved as a draft")
+	}
+
+	turn.responses[prURL].PullRequest.Draft = false
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+	}
+	if msg := discord.updatedMessages[0]; msg.silent || !strings.Contains(msg.text, "<@discord-alice>") {
+		t.Errorf("update = %+v, want a pinging edit mentioning alice", msg)
+	}
+
+	// Later updates go back to the usual rules
+	turn.responses[prURL].PullRequest.Title = "Add caching layer"
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-3"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 2 {
+		t.Fatalf("updatedMessages = %d, want 2", len(discord.updatedMessages))
+	}
+	if !discord.updatedMessages[1].silent {
+		t.Error("second update after marking ready pinged again, want silent")
+	}
+}
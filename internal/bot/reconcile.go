@@ -0,0 +1,306 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+const (
+	// untrackedState marks threads whose repo is no longer routed to their channel.
+	untrackedState = "untracked"
+	// untrackedNote is appended to text channel messages that are no longer updated.
+	untrackedNote = "\n-# No longer tracked: repo removed from this channel's config"
+)
+
+// ConfigChanges describes what a config reload changed for an org.
+type ConfigChanges struct {
+	Added      []string // Channels newly in the config
+	Removed    []string // Channels no longer in the config
+	Unresolved []string // Configured channels not found in Discord
+}
+
+// ReloadConfig reloads the org config and retires threads for repos that
+// were removed from their channels.
+func (c *Coordinator) ReloadConfig(ctx context.Context) error {
+	_, err := c.reloadConfig(ctx)
+	return err
+}
+
+// ForceReloadConfig reloads the org config right away, bypassing the reload debounce, and
+// reports which channels were added and removed. It returns an error if the config couldn't
+// be fetched or parsed; the previous config then stays in effect.
+func (c *Coordinator) ForceReloadConfig(ctx context.Context) (ConfigChanges, error) {
+	before := c.configuredChannels()
+	unresolved, err := c.reloadConfig(ctx)
+	if err != nil {
+		return ConfigChanges{}, err
+	}
+	if err := c.config.LoadError(c.org); err != nil {
+		return ConfigChanges{}, err
+	}
+	// Only this instance was asked; the others follow the request from the store
+	c.reloadMu.Lock()
+	loadedAt := c.configLoadedAt
+	c.reloadMu.Unlock()
+	if err := c.store.SaveConfigReloadRequest(ctx, c.org, loadedAt); err != nil {
+		c.logger.Warn("failed to record forced config reload for other instances", "error", err)
+	}
+
+	after := c.configuredChannels()
+	changes := ConfigChanges{Unresolved: unresolved}
+	for _, name := range after {
+		if !slices.Contains(before, name) {
+			changes.Added = append(changes.Added, name)
+		}
+	}
+	for _, name := range before {
+		if !slices.Contains(after, name) {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	c.logger.Info("forced config reload",
+		"added", changes.Added,
+		"removed", changes.Removed,
+		"unresolved", changes.Unresolved)
+	return changes, nil
+}
+
+// reloadConfig reloads the org config and returns the configured channels that don't exist
+// in the guild. Every instance reloads its own copy of the config, but only the one claiming
+// the reload retires untracked threads and reports missing channels to the admin channel.
+func (c *Coordinator) reloadConfig(ctx context.Context) ([]string, error) {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	if err := c.config.ReloadConfig(ctx, c.org); err != nil {
+		return nil, err
+	}
+	c.configLoadedAt = time.Now()
+
+	if !c.store.ClaimConfigReload(ctx, c.org, c.reloadDebounce) {
+		c.logger.Debug("config reload claimed by another instance, skipping its side effects")
+		return c.unresolvedChannels(ctx), nil
+	}
+	c.reconcileUntrackedThreads(ctx)
+	return c.ValidateChannels(ctx), nil
+}
+
+// FollowConfigReload reloads the org config if a reload was forced on another instance since
+// this one last loaded it.
+func (c *Coordinator) FollowConfigReload(ctx context.Context) {
+	requestedAt, ok := c.store.ConfigReloadRequestedAt(ctx, c.org)
+	if !ok {
+		return
+	}
+	c.reloadMu.Lock()
+	loadedAt := c.configLoadedAt
+	c.reloadMu.Unlock()
+	if !requestedAt.After(loadedAt) {
+		return
+	}
+
+	c.logger.Info("config reload forced on another instance, reloading", "requested_at", requestedAt)
+	if err := c.ReloadConfig(ctx); err != nil {
+		c.logger.Warn("failed to reload config", "error", err)
+	}
+}
+
+// configuredChannels returns the sorted channel names in the org config.
+func (c *Coordinator) configuredChannels() []string {
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(cfg.Channels))
+}
+
+// ValidateChannels resolves every channel named in the org config against the guild and
+// returns the names that don't exist, so typos surface at load rather than as silently
+// dropped events. Results are logged and, if configured, posted to the admin channel unless
+// the org is in maintenance mode.
+func (c *Coordinator) ValidateChannels(ctx context.Context) []string {
+	unresolved := c.unresolvedChannels(ctx)
+	if len(unresolved) == 0 {
+		return nil
+	}
+
+	c.logger.Warn("configured channels not found in Discord",
+		"channels", unresolved,
+		"guild_id", c.discord.GuildID())
+
+	admin := c.config.AdminChannel(c.org)
+	if admin == "" || c.inMaintenance(ctx) {
+		return unresolved
+	}
+	adminID := c.discord.ResolveChannelID(ctx, admin)
+	if adminID == admin {
+		c.logger.Warn("admin channel not found", "channel", admin)
+		return unresolved
+	}
+	msg := fmt.Sprintf("⚠️ discord.yaml for **%s** names channels that don't exist in this server: #%s",
+		c.org, strings.Join(unresolved, ", #"))
+	if _, err := c.discord.PostMessage(ctx, adminID, msg); err != nil {
+		c.logger.Warn("failed to post channel validation to admin channel", "error", err, "channel", admin)
+	}
+	return unresolved
+}
+
+// unresolvedChannels returns the channels named in the org config that don't exist in the guild.
+func (c *Coordinator) unresolvedChannels(ctx context.Context) []string {
+	cfg, ok := c.config.Config(c.org)
+	if !ok {
+		return nil
+	}
+
+	var unresolved []string
+	for _, name := range slices.Sorted(maps.Keys(cfg.Channels)) {
+		if cfg.Channels[name].Mute {
+			continue // Muted channels never receive posts, so needn't exist
+		}
+		if c.discord.ResolveChannelID(ctx, name) == name {
+			unresolved = append(unresolved, name)
+		}
+	}
+	return unresolved
+}
+
+// scheduleConfigReload reloads config after a short debounce, so a burst of
+// pushes to the config repo results in a single reload. Pushes arriving while
+// a reload is pending coalesce into it. Every instance sees the push and
+// reloads; see reloadConfig for what only one of them does. The debounce runs
+// in its own goroutine, so the push's event slot is freed straight away.
+func (c *Coordinator) scheduleConfigReload(ctx context.Context) {
+	if !c.reloadPending.CompareAndSwap(false, true) {
+		c.logger.Debug("config reload already pending, coalescing")
+		return
+	}
+
+	c.wg.Go(func() {
+		c.debouncedConfigReload(ctx)
+	})
+}
+
+// debouncedConfigReload waits out the reload debounce, then reloads config.
+func (c *Coordinator) debouncedConfigReload(ctx context.Context) {
+	timer := time.NewTimer(c.reloadDebounce)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		c.reloadPending.Store(false)
+		return
+	}
+	// Clear before reloading: a push that lands mid-reload may not be visible to it
+	c.reloadPending.Store(false)
+
+	if err := c.ReloadConfig(ctx); err != nil {
+		c.logger.Warn("failed to reload config", "error", err)
+	}
+}
+
+// reconcileUntrackedThreads retires threads/messages in channels that no longer
+// receive a repo after a config reload. Forum threads are archived and text
+// messages get a note, so nothing is left looking live but never updated.
+func (c *Coordinator) reconcileUntrackedThreads(ctx context.Context) {
+	// Ending maintenance mode reconciles again
+	if c.inMaintenance(ctx) {
+		c.logger.Debug("maintenance mode on, leaving untracked threads for when it's off")
+		return
+	}
+	threads := c.store.ListThreads(ctx, c.org)
+	if len(threads) == 0 {
+		return
+	}
+
+	resolved := make(map[string]string) // Channel name -> ID, or "" if it didn't resolve
+	retired := 0
+	for _, t := range threads {
+		switch t.Info.LastState {
+		case untrackedState, string(format.StateMerged), string(format.StateClosed):
+			continue // Already final
+		default:
+		}
+
+		prURL := FormatPRURL(t.Owner, t.Repo, t.Number)
+		channelIDs, ok := c.keptChannelIDs(ctx, t.Repo, prURL, resolved)
+		if !ok {
+			c.logger.Debug("a channel the PR may post to didn't resolve, leaving its threads as they are",
+				"pr", prURL)
+			continue
+		}
+		if slices.Contains(channelIDs, t.Info.ChannelID) {
+			continue
+		}
+
+		prLock := c.prLocks.get(prURL)
+		prLock.Lock()
+
+		info := t.Info
+		var err error
+		if info.ThreadID != "" {
+			err = c.discord.ArchiveThread(ctx, info.ThreadID)
+		} else if info.MessageID != "" {
+			info.MessageText += untrackedNote
+			err = c.discord.UpdateMessage(ctx, info.ChannelID, info.MessageID, info.MessageText, c.silentEdit(t.Info.MessageText, info.MessageText))
+		}
+		if err != nil {
+			prLock.Unlock()
+			c.logger.Warn("failed to retire untracked thread",
+				"error", err,
+				"pr", prURL,
+				"channel_id", info.ChannelID)
+			continue
+		}
+
+		info.LastState = untrackedState
+		if err := c.store.SaveThread(ctx, t.Owner, t.Repo, t.Number, info.ChannelID, info); err != nil {
+			c.logger.Warn("failed to save untracked thread info", "error", err, "pr", prURL)
+		}
+		prLock.Unlock()
+		retired++
+	}
+
+	if retired > 0 {
+		c.logger.Info("retired threads for repos removed from config",
+			"org", c.org,
+			"count", retired)
+	}
+}
+
+// keptChannelIDs returns the IDs of the channels whose threads for a PR stay live: where
+// processEventSync routes it, its repo's configured channels even while a redirect is active,
+// and every author team channel, as the PR's author isn't known here. It returns false if
+// any of them didn't resolve, as a failed lookup can't tell a removed channel from a live one.
+// resolved caches lookups across calls.
+func (c *Coordinator) keptChannelIDs(ctx context.Context, repo, prURL string, resolved map[string]string) ([]string, bool) {
+	override, _ := c.store.PROverride(ctx, prURL)
+	names := c.routedChannels(ctx, repo, "", override)
+	names = append(names, c.config.ChannelsForRepo(c.org, repo)...)
+	if cfg, ok := c.config.Config(c.org); ok {
+		for _, name := range cfg.Global.AuthorTeamChannels {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		id, ok := resolved[name]
+		if !ok {
+			if id = c.discord.ResolveChannelID(ctx, name); id == name {
+				id = ""
+			}
+			resolved[name] = id
+		}
+		if id == "" {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
@@ -0,0 +1,494 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/config"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ReloadConfig_ArchivesRemovedRepoThread(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+	discord.channelIDs["frontend"] = "chan-frontend"
+
+	configMgr := newMockConfigManager()
+	configMgr.channels["testorg:api"] = []string{"backend"}
+	store := state.NewMemoryStore()
+
+	// api was posted to both channels before the config change
+	for _, info := range []state.ThreadInfo{
+		{ThreadID: "thread-backend", MessageID: "msg-1", ChannelID: "chan-backend", ChannelType: "forum", LastState: "needs_review"},
+		{ThreadID: "thread-frontend", MessageID: "msg-2", ChannelID: "chan-frontend", ChannelType: "forum", LastState: "needs_review"},
+	} {
+		if err := store.SaveThread(ctx, "testorg", "api", 42, info.ChannelID, info); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	if len(discord.archivedThreads) != 1 || discord.archivedThreads[0] != "thread-frontend" {
+		t.Errorf("archivedThreads = %v, want [thread-frontend]", discord.archivedThreads)
+	}
+
+	info, ok := store.Thread(ctx, "testorg", "api", 42, "chan-frontend")
+	if !ok || info.LastState != untrackedState {
+		t.Errorf("frontend thread LastState = %q, want %q", info.LastState, untrackedState)
+	}
+	info, ok = store.Thread(ctx, "testorg", "api", 42, "chan-backend")
+	if !ok || info.LastState != "needs_review" {
+		t.Errorf("backend thread LastState = %q, want unchanged", info.LastState)
+	}
+
+	// A second reload should not touch the already-retired thread
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if len(discord.archivedThreads) != 1 {
+		t.Errorf("archivedThreads = %v, want no further archives", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ReloadConfig_NotesRemovedRepoMessage(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+	discord.channelIDs["old-channel"] = "chan-old"
+
+	configMgr := newMockConfigManager()
+	configMgr.channels["testorg:api"] = []string{"backend"}
+	store := state.NewMemoryStore()
+
+	info := state.ThreadInfo{
+		MessageID:   "msg-old",
+		ChannelID:   "chan-old",
+		ChannelType: "text",
+		LastState:   "needs_review",
+		MessageText: "api#42 · Fix bug",
+	}
+	if err := store.SaveThread(ctx, "testorg", "api", 42, "chan-old", info); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	// Merged PRs are final and should be left alone
+	merged := state.ThreadInfo{MessageID: "msg-merged", ChannelID: "chan-old", ChannelType: "text", LastState: "merged"}
+	if err := store.SaveThread(ctx, "testorg", "api", 7, "chan-old", merged); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1", len(discord.updatedMessages))
+	}
+	got := discord.updatedMessages[0]
+	if got.messageID != "msg-old" || !strings.Contains(got.text, "No longer tracked") {
+		t.Errorf("updated message = %+v, want msg-old with untracked note", got)
+	}
+	if len(discord.archivedThreads) != 0 {
+		t.Errorf("text messages should not be archived, got %v", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ReloadConfig_KeepsThreadsWhenChannelDoesNotResolve(t *testing.T) {
+	ctx := context.Background()
+
+	// backend is still configured for api, but looking it up fails
+	discord := newMockDiscordClient()
+	configMgr := newMockConfigManager()
+	configMgr.channels["testorg:api"] = []string{"backend"}
+	store := state.NewMemoryStore()
+	info := state.ThreadInfo{ThreadID: "thread-backend", ChannelID: "chan-backend", ChannelType: "forum", LastState: "needs_review"}
+	if err := store.SaveThread(ctx, "testorg", "api", 42, "chan-backend", info); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	if len(discord.archivedThreads) != 0 {
+		t.Errorf("archivedThreads = %v, want none when a channel didn't resolve", discord.archivedThreads)
+	}
+	if got, _ := store.Thread(ctx, "testorg", "api", 42, "chan-backend"); got.LastState != "needs_review" {
+		t.Errorf("LastState = %q, want unchanged", got.LastState)
+	}
+}
+
+func TestCoordinator_ReloadConfig_KeepsRoutedThreads(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+	discord.channelIDs["all-prs"] = "chan-firehose"
+	discord.channelIDs["hotfix"] = "chan-hotfix"
+	discord.channelIDs["gone"] = "chan-gone"
+	configMgr := newMockConfigManager()
+	configMgr.channels["testorg:api"] = []string{"backend"}
+	configMgr.firehose = "all-prs"
+	store := state.NewMemoryStore()
+
+	// PR 1 is in the firehose; PR 2 was routed to hotfix by a /discordian channel directive
+	threads := []struct {
+		number int
+		info   state.ThreadInfo
+	}{
+		{1, state.ThreadInfo{ThreadID: "thread-firehose", ChannelID: "chan-firehose", ChannelType: "forum", LastState: "needs_review"}},
+		{2, state.ThreadInfo{ThreadID: "thread-hotfix", ChannelID: "chan-hotfix", ChannelType: "forum", LastState: "needs_review"}},
+		{2, state.ThreadInfo{ThreadID: "thread-gone", ChannelID: "chan-gone", ChannelType: "forum", LastState: "needs_review"}},
+	}
+	for _, tt := range threads {
+		if err := store.SaveThread(ctx, "testorg", "api", tt.number, tt.info.ChannelID, tt.info); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+	if err := store.SavePROverride(ctx, FormatPRURL("testorg", "api", 2), state.PROverride{Channel: "hotfix"}); err != nil {
+		t.Fatalf("SavePROverride() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	if err := coord.ReloadConfig(ctx); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	if !slices.Equal(discord.archivedThreads, []string{"thread-gone"}) {
+		t.Errorf("archivedThreads = %v, want only [thread-gone]", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ReloadConfig_Failure(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	configMgr := newMockConfigManager()
+	configMgr.shouldFailReload = true
+	store := state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "api", 1, "chan-gone",
+		state.ThreadInfo{ThreadID: "thread-1", ChannelID: "chan-gone"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if err := coord.ReloadConfig(ctx); err == nil {
+		t.Error("ReloadConfig() expected error")
+	}
+	if len(discord.archivedThreads) != 0 {
+		t.Errorf("should not reconcile on failed reload, archived %v", discord.archivedThreads)
+	}
+}
+
+func TestCoordinator_ForceReloadConfig(t *testing.T) {
+	ctx := context.Background()
+
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+
+	configMgr := newMockConfigManager()
+	configMgr.configs["testorg"] = &config.DiscordConfig{Channels: map[string]config.ChannelConfig{
+		"backend":  {Repos: []string{"api"}},
+		"frontend": {Repos: []string{"web"}},
+	}}
+	configMgr.reloaded = &config.DiscordConfig{Channels: map[string]config.ChannelConfig{
+		"backend": {Repos: []string{"api"}},
+		"mobile":  {Repos: []string{"app"}},
+	}}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	changes, err := coord.ForceReloadConfig(ctx)
+	if err != nil {
+		t.Fatalf("ForceReloadConfig() error = %v", err)
+	}
+	if configMgr.reloadCount != 1 {
+		t.Errorf("reloadCount = %d, want 1", configMgr.reloadCount)
+	}
+	if !slices.Equal(changes.Added, []string{"mobile"}) {
+		t.Errorf("Added = %v, want [mobile]", changes.Added)
+	}
+	if !slices.Equal(changes.Removed, []string{"frontend"}) {
+		t.Errorf("Removed = %v, want [frontend]", changes.Removed)
+	}
+	if !slices.Equal(changes.Unresolved, []string{"mobile"}) {
+		t.Errorf("Unresolved = %v, want [mobile]", changes.Unresolved)
+	}
+}
+
+func TestCoordinator_ForceReloadConfig_InvalidConfig(t *testing.T) {
+	configMgr := newMockConfigManager()
+	configMgr.loadErr = errors.New("failed to parse YAML: bad indentation")
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if _, err := coord.ForceReloadConfig(context.Background()); err == nil || !strings.Contains(err.Error(), "parse YAML") {
+		t.Errorf("ForceReloadConfig() error = %v, want the load error", err)
+	}
+}
+
+func TestCoordinator_scheduleConfigReload_Coalesces(t *testing.T) {
+	configMgr := newMockConfigManager()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	coord.reloadDebounce = 50 * time.Millisecond
+
+	// Two config pushes arriving together
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() { coord.scheduleConfigReload(context.Background()) })
+	}
+	wg.Wait()
+	coord.Wait()
+
+	if configMgr.reloadCount != 1 {
+		t.Errorf("reloadCount = %d, want 1", configMgr.reloadCount)
+	}
+}
+
+func TestCoordinator_scheduleConfigReload_DoesNotBlock(t *testing.T) {
+	configMgr := newMockConfigManager()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+	coord.reloadDebounce = time.Hour
+
+	// The debounce mustn't hold the event slot of the push that scheduled it
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	coord.scheduleConfigReload(ctx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("scheduleConfigReload() took %v, want it to return before the debounce", elapsed)
+	}
+
+	cancel()
+	coord.Wait()
+	if configMgr.reloadCount != 0 {
+		t.Errorf("reloadCount = %d after shutdown, want 0", configMgr.reloadCount)
+	}
+}
+
+func TestCoordinator_scheduleConfigReload_EveryInstanceReloads(t *testing.T) {
+	store := state.NewMemoryStore()
+	newInstance := func() (*Coordinator, *mockConfigManager, *mockDiscordClient) {
+		discord := newMockDiscordClient()
+		discord.channelIDs["ops"] = "chan-ops"
+		configMgr := newMockConfigManager()
+		configMgr.adminChannel = "ops"
+		configMgr.reloaded = &config.DiscordConfig{Channels: map[string]config.ChannelConfig{
+			"frontnd": {Repos: []string{"web"}},
+		}}
+		coord := NewCoordinator(CoordinatorConfig{
+			Discord: discord,
+			Config:  configMgr,
+			Store:   store,
+			Turn:    newMockTurnClient(),
+			Org:     "testorg",
+		})
+		coord.reloadDebounce = 50 * time.Millisecond
+		return coord, configMgr, discord
+	}
+
+	// Two instances sharing a store see the same push
+	firstCoord, first, firstDiscord := newInstance()
+	secondCoord, second, secondDiscord := newInstance()
+	var wg sync.WaitGroup
+	wg.Go(func() { firstCoord.scheduleConfigReload(context.Background()) })
+	wg.Go(func() { secondCoord.scheduleConfigReload(context.Background()) })
+	wg.Wait()
+	firstCoord.Wait()
+	secondCoord.Wait()
+
+	// Each holds its own copy of the config, so each must reload it
+	if first.reloadCount != 1 || second.reloadCount != 1 {
+		t.Errorf("reloadCount = %d and %d, want 1 each", first.reloadCount, second.reloadCount)
+	}
+	// But the missing channel is only reported once
+	if posts := len(firstDiscord.postedMessages) + len(secondDiscord.postedMessages); posts != 1 {
+		t.Errorf("admin posts across instances = %d, want 1", posts)
+	}
+}
+
+func TestCoordinator_FollowConfigReload(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	newInstance := func() (*Coordinator, *mockConfigManager) {
+		configMgr := newMockConfigManager()
+		return NewCoordinator(CoordinatorConfig{
+			Discord: newMockDiscordClient(),
+			Config:  configMgr,
+			Store:   store,
+			Turn:    newMockTurnClient(),
+			Org:     "testorg",
+		}), configMgr
+	}
+	asked, askedConfig := newInstance()
+	other, otherConfig := newInstance()
+
+	other.FollowConfigReload(ctx)
+	if otherConfig.reloadCount != 0 {
+		t.Fatalf("reloadCount = %d before any forced reload, want 0", otherConfig.reloadCount)
+	}
+
+	// /goose reload reaches one instance; the other follows it once
+	if _, err := asked.ForceReloadConfig(ctx); err != nil {
+		t.Fatalf("ForceReloadConfig() error = %v", err)
+	}
+	asked.FollowConfigReload(ctx)
+	other.FollowConfigReload(ctx)
+	other.FollowConfigReload(ctx)
+	if askedConfig.reloadCount != 1 {
+		t.Errorf("reloadCount = %d on the instance asked, want 1", askedConfig.reloadCount)
+	}
+	if otherConfig.reloadCount != 1 {
+		t.Errorf("reloadCount = %d after a forced reload elsewhere, want 1", otherConfig.reloadCount)
+	}
+}
+
+func TestCoordinator_ValidateChannels(t *testing.T) {
+	tests := []struct {
+		name         string
+		adminChannel string
+		wantPosts    int
+	}{
+		{"logs only without admin channel", "", 0},
+		{"posts to admin channel", "ops", 1},
+		{"missing admin channel", "nope", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discord := newMockDiscordClient()
+			discord.channelIDs["backend"] = "chan-backend"
+			discord.channelIDs["ops"] = "chan-ops"
+
+			configMgr := newMockConfigManager()
+			configMgr.adminChannel = tt.adminChannel
+			configMgr.configs["testorg"] = &config.DiscordConfig{
+				Channels: map[string]config.ChannelConfig{
+					"backend":  {Repos: []string{"api"}},
+					"frontnd":  {Repos: []string{"web"}},
+					"archived": {Repos: []string{"old"}},
+					"noisy":    {Mute: true},
+				},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    newMockTurnClient(),
+				Org:     "testorg",
+			})
+
+			got := coord.ValidateChannels(context.Background())
+			want := []string{"archived", "frontnd"}
+			if !slices.Equal(got, want) {
+				t.Errorf("ValidateChannels() = %v, want %v", got, want)
+			}
+			if len(discord.postedMessages) != tt.wantPosts {
+				t.Fatalf("postedMessages = %d, want %d", len(discord.postedMessages), tt.wantPosts)
+			}
+			if tt.wantPosts > 0 {
+				msg := discord.postedMessages[0]
+				if msg.channelID != "chan-ops" || !strings.Contains(msg.text, "#archived, #frontnd") {
+					t.Errorf("admin post = %+v, want unresolved channels in chan-ops", msg)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinator_ValidateChannels_AllResolved(t *testing.T) {
+	discord := newMockDiscordClient()
+	discord.channelIDs["backend"] = "chan-backend"
+	discord.channelIDs["ops"] = "chan-ops"
+
+	configMgr := newMockConfigManager()
+	configMgr.adminChannel = "ops"
+	configMgr.configs["testorg"] = &config.DiscordConfig{
+		Channels: map[string]config.ChannelConfig{"backend": {Repos: []string{"api"}}},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if got := coord.ValidateChannels(context.Background()); len(got) != 0 {
+		t.Errorf("ValidateChannels() = %v, want none", got)
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Errorf("postedMessages = %d, want 0", len(discord.postedMessages))
+	}
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// repostReopened forgets the message of a PR reopened after being closed longer than the org's
+// reopen_repost_after, so a fresh one is posted: the old one is far up the channel by then, if
+// Discord still has it at all. The old message is left as it is.
+func (c *Coordinator) repostReopened(ctx context.Context, params *channelProcessParams) {
+	after := c.config.ReopenRepostAfter(c.org)
+	if after <= 0 || !params.exists {
+		return
+	}
+	last := format.PRState(params.threadInfo.LastState)
+	if last != format.StateMerged && last != format.StateClosed {
+		return
+	}
+	if params.params.State == format.StateMerged || params.params.State == format.StateClosed {
+		return
+	}
+	// The message was last saved when the PR closed
+	closedFor := time.Since(params.threadInfo.UpdatedAt)
+	if closedFor < after {
+		return
+	}
+	c.logger.Info("PR reopened after a long closure, re-creating its message",
+		"pr", params.params.PRURL,
+		"channel", params.params.ChannelName,
+		"closed_for", closedFor.Round(time.Minute))
+	c.forgetMessage(ctx, params)
+}
+
+// recreateVanished forgets a PR's message once Discord reports it no longer exists, e.g. it was
+// deleted by hand or with its thread, so a fresh one is posted rather than the edit failing on
+// every event. It reports whether err was such a not-found error.
+func (c *Coordinator) recreateVanished(ctx context.Context, params *channelProcessParams, err error) bool {
+	if !errors.Is(err, discord.ErrNotFound) {
+		return false
+	}
+	c.logger.Info("PR's message no longer exists in Discord, re-creating it",
+		"pr", params.params.PRURL,
+		"channel", params.params.ChannelName,
+		"thread_id", params.threadInfo.ThreadID,
+		"message_id", params.threadInfo.MessageID)
+	c.forgetMessage(ctx, params)
+	return true
+}
+
+// forgetMessage drops a PR's stored message so the next step posts a new one.
+func (c *Coordinator) forgetMessage(ctx context.Context, params *channelProcessParams) {
+	if err := c.store.RemoveThread(ctx, params.owner, params.repo, params.number, params.channelID); err != nil {
+		c.logger.Warn("failed to remove stale thread info", "error", err, "pr", params.params.PRURL)
+	}
+	params.threadInfo = state.ThreadInfo{}
+	params.exists = false
+	params.recreate = true
+}
+
+// findForumThread searches a forum for a PR's thread, unless its old thread was forgotten.
+func (c *Coordinator) findForumThread(ctx context.Context, params *channelProcessParams) (threadID, messageID string, found bool) {
+	if params.recreate {
+		return "", "", false
+	}
+	return c.discord.FindForumThread(ctx, params.channelID, params.params.PRURL)
+}
+
+// findChannelMessage searches a channel for a PR's message, unless its old message was forgotten.
+func (c *Coordinator) findChannelMessage(ctx context.Context, params *channelProcessParams) (messageID string, found bool) {
+	if params.recreate {
+		return "", false
+	}
+	return c.discord.FindChannelMessage(ctx, params.channelID, params.params.PRURL)
+}
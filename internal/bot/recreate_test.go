@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_RecreateMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		stored       *state.ThreadInfo
+		updateErr    error
+		reopenRepost time.Duration
+		wantUpdates  int
+	}{
+		{
+			name: "reopen with no stored thread",
+		},
+		{
+			name:        "stored message deleted in Discord",
+			stored:      &state.ThreadInfo{MessageID: "old-msg", ChannelType: "text", LastState: "open", MessageText: "old"},
+			updateErr:   fmt.Errorf("failed to edit message: %w", discord.ErrNotFound),
+			wantUpdates: 1,
+		},
+		{
+			name:         "reopened after a long closure",
+			stored:       &state.ThreadInfo{MessageID: "old-msg", ChannelType: "text", LastState: "closed", MessageText: "old"},
+			reopenRepost: time.Nanosecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.updateErr = tt.updateErr
+
+			configMgr := newMockConfigManager()
+			configMgr.reopenRepost = tt.reopenRepost
+
+			store := state.NewMemoryStore()
+			if tt.stored != nil {
+				// The old message is still in the channel's history, but mustn't be adopted
+				discord.channelMessages = map[string]map[string]string{"chan-testrepo": {"old-msg": "old"}}
+				if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", *tt.stored); err != nil {
+					t.Fatalf("SaveThread() error = %v", err)
+				}
+			}
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Revive feature", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   store,
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{
+				URL: prURL, Type: "pull_request", DeliveryID: "d-reopened",
+				Raw: map[string]any{"action": "reopened"},
+			})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1 fresh message", len(discord.postedMessages))
+			}
+			if len(discord.updatedMessages) != tt.wantUpdates {
+				t.Errorf("updatedMessages = %d, want %d", len(discord.updatedMessages), tt.wantUpdates)
+			}
+			info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+			if !ok || info.MessageID != "msg-chan-testrepo" {
+				t.Errorf("stored message = %q (found %v), want the new msg-chan-testrepo", info.MessageID, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// SetRepoRedirect sends a repo's PR notifications to channel instead of its configured channels
+// until the given time, when they revert on their own. A zero until ends the redirect now.
+func (c *Coordinator) SetRepoRedirect(ctx context.Context, repo, channel string, until time.Time, setBy string) error {
+	redirect := state.RepoRedirect{Until: until, SetAt: time.Now(), SetBy: setBy, Channel: channel}
+	if err := c.store.SaveRepoRedirect(ctx, c.org, repo, redirect); err != nil {
+		return fmt.Errorf("save repo redirect: %w", err)
+	}
+	c.logger.Info("repo redirect changed", "repo", repo, "channel", channel, "until", until, "set_by", setBy)
+	return nil
+}
+
+// redirectedChannel returns the channel a repo's notifications are redirected to with
+// /goose redirect, or "" if no redirect is in effect at now.
+func (c *Coordinator) redirectedChannel(ctx context.Context, repo string, now time.Time) string {
+	redirect, ok := c.store.RepoRedirect(ctx, c.org, repo)
+	if !ok || redirect.Channel == "" || !now.Before(redirect.Until) {
+		return ""
+	}
+	return redirect.Channel
+}
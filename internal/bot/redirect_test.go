@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_RepoRedirect(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.channelIDs["incidents"] = "chan-incidents"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.botInChannel["chan-incidents"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Fix outage", Author: "alice", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	if err := coord.SetRepoRedirect(ctx, "testrepo", "incidents", time.Now().Add(time.Hour), "admin"); err != nil {
+		t.Fatalf("SetRepoRedirect() error = %v", err)
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 || discord.postedMessages[0].channelID != "chan-incidents" {
+		t.Fatalf("postedMessages = %+v, want one post to the redirect channel", discord.postedMessages)
+	}
+
+	// Once it lapses, the repo's notifications go back to its own channel
+	if err := store.SaveRepoRedirect(ctx, "testorg", "testrepo", state.RepoRedirect{
+		Channel: "incidents",
+		Until:   time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatalf("SaveRepoRedirect() error = %v", err)
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 2 || discord.postedMessages[1].channelID != "chan-testrepo" {
+		t.Fatalf("postedMessages = %+v, want a post to the repo's channel after the redirect lapsed", discord.postedMessages)
+	}
+}
+
+func TestCoordinator_RedirectedChannel(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	if got := coord.redirectedChannel(ctx, "testrepo", now); got != "" {
+		t.Errorf("redirectedChannel() with no redirect = %q, want \"\"", got)
+	}
+	if err := coord.SetRepoRedirect(ctx, "testrepo", "incidents", now.Add(2*time.Hour), "admin"); err != nil {
+		t.Fatalf("SetRepoRedirect() error = %v", err)
+	}
+	if got := coord.redirectedChannel(ctx, "testrepo", now); got != "incidents" {
+		t.Errorf("redirectedChannel() = %q, want %q", got, "incidents")
+	}
+	if got := coord.redirectedChannel(ctx, "otherrepo", now); got != "" {
+		t.Errorf("redirectedChannel() for another repo = %q, want \"\"", got)
+	}
+	if got := coord.redirectedChannel(ctx, "testrepo", now.Add(3*time.Hour)); got != "" {
+		t.Errorf("redirectedChannel() after it lapsed = %q, want \"\"", got)
+	}
+	if err := coord.SetRepoRedirect(ctx, "testrepo", "", time.Time{}, "admin"); err != nil {
+		t.Fatalf("SetRepoRedirect() off error = %v", err)
+	}
+	if got := coord.redirectedChannel(ctx, "testrepo", now); got != "" {
+		t.Errorf("redirectedChannel() after turning it off = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// lastThread returns the PR's stored thread from the first of its channels tracking it, whose
+// LastState is the state its channel messages last showed, or a zero ThreadInfo if none do.
+// Read before the channels are processed, since processing overwrites it.
+func (c *Coordinator) lastThread(ctx context.Context, owner, repo string, number int, channels []string) state.ThreadInfo {
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if info, ok := c.store.Thread(ctx, owner, repo, number, channelID); ok && info.LastState != "" {
+			return info
+		}
+	}
+	return state.ThreadInfo{}
+}
+
+// transitionKey identifies a PR's move from the state its thread last recorded to prState. Every
+// event and instance that sees the move before the thread is saved again gets the same key, so
+// claiming it acts on the move once, whichever event brought it.
+func transitionKey(last state.ThreadInfo, prState format.PRState) string {
+	return last.LastState + ">" + string(prState) + "@" + strconv.FormatInt(last.UpdatedAt.UnixNano(), 10)
+}
+
+// notifyAuthorOfReopenedReview DMs a PR's author when the PR goes from approved to changes
+// requested. Their existing DM is only edited for the new state, which doesn't notify them,
+// and a PR they thought was ready to merge needing more work is worth a ping. Only the
+// transition itself notifies; further events in the changes requested state don't.
+func (c *Coordinator) notifyAuthorOfReopenedReview(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	last state.ThreadInfo,
+	prState format.PRState,
+) {
+	if format.PRState(last.LastState) != format.StateApproved || prState != format.StateChanges || !c.config.DMOnReopenedReview(c.org) {
+		return
+	}
+	author := checkResp.PullRequest.Author
+	if author == "" {
+		return
+	}
+	discordID := c.discordIDForUser(ctx, author)
+	if discordID == "" || c.shouldSkipDM(ctx, discordID, author) {
+		return
+	}
+
+	prURL := FormatPRURL(owner, repo, number)
+	// Other events, here or on another instance, may have brought the same transition
+	if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"reopened-review-dm:"+transitionKey(last, prState), eventDeduplicationTTL) {
+		return
+	}
+
+	msg := format.DMMessage(format.ChannelMessageParams{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Title:  checkResp.PullRequest.Title,
+		Author: author,
+		State:  prState,
+		PRURL:  prURL,
+	}, "changes requested after approval")
+	if _, _, err := c.sendDM(ctx, discordID, msg); err != nil {
+		c.logger.Warn("failed to DM author about changes requested after approval",
+			"error", err,
+			"user", author,
+			"pr_url", prURL)
+		return
+	}
+	c.logger.Info("notified author of changes requested after approval",
+		"user", author,
+		"pr_url", prURL)
+}
@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_NotifyAuthorOfReopenedReview(t *testing.T) {
+	approved := Analysis{Approved: true, NextAction: map[string]Action{}}
+	changes := Analysis{Approved: true, UnresolvedComments: 1, NextAction: map[string]Action{}}
+	needsReview := Analysis{WorkflowState: "waiting_for_review", NextAction: map[string]Action{}}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		analyses []Analysis // One event per analysis, in order
+		wantDMs  int
+	}{
+		{"approved then changes requested", true, []Analysis{approved, changes}, 1},
+		{"steady changes requested doesn't repeat", true, []Analysis{approved, changes, changes, changes}, 1},
+		{"changes requested without prior approval", true, []Analysis{needsReview, changes}, 0},
+		{"approved again then changes requested", true, []Analysis{approved, changes, approved, changes}, 2},
+		{"disabled", false, []Analysis{approved, changes}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["discord-alice"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.dmOnReopened = tt.enabled
+			mapper := newMockUserMapper()
+			mapper.mappings["alice"] = "discord-alice"
+
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+			turn := newMockTurnClient()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			for i, analysis := range tt.analyses {
+				turn.responses[prURL] = &CheckResponse{
+					PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"},
+					Analysis:    analysis,
+				}
+				coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: fmt.Sprintf("d-%d", i)})
+				coord.Wait()
+			}
+
+			if len(discord.sentDMs) != tt.wantDMs {
+				t.Fatalf("sentDMs = %+v, want %d", discord.sentDMs, tt.wantDMs)
+			}
+			for _, dm := range discord.sentDMs {
+				if dm.userID != "discord-alice" || !strings.Contains(dm.text, "changes requested after approval") {
+					t.Errorf("sent DM = %+v, want alice told of changes requested after approval", dm)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinator_notifyAuthorOfReopenedReview_OncePerTransition(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.usersInGuild["discord-alice"] = true
+	configMgr := newMockConfigManager()
+	configMgr.dmOnReopened = true
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	store := state.NewMemoryStore()
+
+	last := state.ThreadInfo{LastState: string(format.StateApproved), UpdatedAt: time.Now()}
+	checkResp := &CheckResponse{PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open"}}
+	notify := func() {
+		coord := NewCoordinator(CoordinatorConfig{
+			Discord:    discord,
+			Config:     configMgr,
+			Store:      store,
+			Turn:       newMockTurnClient(),
+			UserMapper: mapper,
+			Org:        "testorg",
+		})
+		coord.notifyAuthorOfReopenedReview(ctx, "testorg", "testrepo", 42, checkResp, last, format.StateChanges)
+	}
+
+	// Two instances sharing the store process different events bringing the same transition
+	notify()
+	notify()
+	if len(discord.sentDMs) != 1 {
+		t.Fatalf("sentDMs = %d, want one DM for the transition", len(discord.sentDMs))
+	}
+
+	// Approved again later, then changes requested again, is a new transition
+	last.UpdatedAt = last.UpdatedAt.Add(time.Hour)
+	notify()
+	if len(discord.sentDMs) != 2 {
+		t.Errorf("sentDMs = %d, want another DM for the next transition", len(discord.sentDMs))
+	}
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// notifyAuthorOfReview DMs a PR's author when its review verdict changes: Turn's analysis
+// now shows it approved or needing changes, and the thread last recorded the other verdict
+// or none. It's separate from action DMs: it's sent right away and doesn't replace the
+// author's tracked DM. last is the PR's thread as stored before this event was processed;
+// PRs no channel tracks yet don't notify, so a first post or a backfill doesn't DM for old
+// reviews. Each verdict change notifies once across instances.
+func (c *Coordinator) notifyAuthorOfReview(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	last state.ThreadInfo,
+	prState format.PRState,
+) {
+	if !c.config.DMAuthorOnReview(c.org) || last.LastState == "" {
+		return
+	}
+	if prState != format.StateApproved && prState != format.StateChanges {
+		return
+	}
+	round, verdict := reviewRound(last, prState)
+	if verdict == last.LastReview {
+		return
+	}
+	author := checkResp.PullRequest.Author
+	if author == "" {
+		return
+	}
+	discordID := c.discordIDForUser(ctx, author)
+	if discordID == "" || c.shouldSkipDM(ctx, discordID, author) {
+		return
+	}
+
+	prURL := FormatPRURL(owner, repo, number)
+	// Every event after the change sees it until the thread is saved, and another instance
+	// may be processing one, so dedup on the verdict's round rather than the event
+	if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"review-dm:"+verdict+":"+strconv.Itoa(round), eventDeduplicationTTL) {
+		c.logger.Debug("author already notified of review",
+			"pr_url", prURL,
+			"verdict", verdict)
+		return
+	}
+
+	msg := format.DMMessage(format.ChannelMessageParams{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Title:  checkResp.PullRequest.Title,
+		Author: author,
+		State:  prState,
+		PRURL:  prURL,
+	}, reviewAction(checkResp, prState))
+	if _, _, err := c.sendDM(ctx, discordID, msg); err != nil {
+		c.logger.Warn("failed to DM author about review",
+			"error", err,
+			"user", author,
+			"pr_url", prURL)
+		return
+	}
+	c.logger.Info("notified author of review",
+		"user", author,
+		"verdict", verdict,
+		"round", round,
+		"pr_url", prURL)
+}
+
+// reviewAction describes a review verdict for the author's DM, naming the reviewers behind it
+// when Turn reports them.
+func reviewAction(checkResp *CheckResponse, prState format.PRState) string {
+	if prState == format.StateApproved {
+		if approvedBy, _ := approvals(checkResp); len(approvedBy) > 0 {
+			return strings.Join(approvedBy, ", ") + " approved"
+		}
+		return "approved"
+	}
+	var requesters []string
+	for reviewer, review := range checkResp.PullRequest.Reviewers {
+		if review == "changes_requested" {
+			requesters = append(requesters, reviewer)
+		}
+	}
+	if len(requesters) == 0 {
+		return "changes requested"
+	}
+	slices.Sort(requesters)
+	return strings.Join(requesters, ", ") + " requested changes"
+}
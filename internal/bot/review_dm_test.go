@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_NotifyAuthorOfReview(t *testing.T) {
+	awaiting := Analysis{WorkflowState: "PUBLISHED_WAITING_FOR_REVIEW", NextAction: map[string]Action{}}
+	approved := Analysis{Approved: true, NextAction: map[string]Action{}}
+	changes := Analysis{WorkflowState: "REVIEWED_NEEDS_REFINEMENT", NextAction: map[string]Action{}}
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		reviewers map[string]string
+		analyses  []Analysis // Turn's analysis for each event in turn
+		wantTexts []string   // DMs sent to the author, in order
+	}{
+		{
+			name:      "approval names the approver",
+			enabled:   true,
+			reviewers: map[string]string{"bob": "approved"},
+			analyses:  []Analysis{awaiting, approved, approved},
+			wantTexts: []string{"✅ **bob approved**: [testorg/testrepo#42]"},
+		},
+		{
+			name:      "approval without reviewers from Turn",
+			enabled:   true,
+			analyses:  []Analysis{awaiting, approved},
+			wantTexts: []string{"**approved**: [testorg/testrepo#42]"},
+		},
+		{
+			name:      "each verdict change notifies",
+			enabled:   true,
+			reviewers: map[string]string{"bob": "changes_requested"},
+			analyses:  []Analysis{awaiting, changes, changes, approved},
+			wantTexts: []string{"**bob requested changes**", "**approved**"},
+		},
+		{
+			name:      "first post already approved",
+			enabled:   true,
+			analyses:  []Analysis{approved, approved},
+			wantTexts: nil,
+		},
+		{
+			name:      "no verdict",
+			enabled:   true,
+			analyses:  []Analysis{awaiting, awaiting},
+			wantTexts: nil,
+		},
+		{
+			name:      "disabled",
+			enabled:   false,
+			analyses:  []Analysis{awaiting, approved},
+			wantTexts: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["discord-alice"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.dmOnReview = tt.enabled
+			mapper := newMockUserMapper()
+			mapper.mappings["alice"] = "discord-alice"
+
+			prURL := "https://github.com/testorg/testrepo/pull/42"
+			turn := newMockTurnClient()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      state.NewMemoryStore(),
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			// Sprinkler events carry only the event name, PR URL and delivery ID; the review
+			// itself is only visible through Turn's analysis
+			types := []string{"pull_request", "pull_request_review", "check_run", "pull_request_review"}
+			for i, analysis := range tt.analyses {
+				turn.responses[prURL] = &CheckResponse{
+					PullRequest: PRInfo{Title: "Test PR", Author: "alice", State: "open", Reviewers: tt.reviewers},
+					Analysis:    analysis,
+				}
+				coord.ProcessEvent(ctx, SprinklerEvent{
+					URL:        prURL,
+					Type:       types[i%len(types)],
+					DeliveryID: "delivery-" + string(rune('a'+i)),
+				})
+				coord.Wait()
+			}
+
+			if len(discord.sentDMs) != len(tt.wantTexts) {
+				t.Fatalf("sentDMs = %+v, want %d", discord.sentDMs, len(tt.wantTexts))
+			}
+			for i, want := range tt.wantTexts {
+				if dm := discord.sentDMs[i]; dm.userID != "discord-alice" || !strings.Contains(dm.text, want) {
+					t.Errorf("sentDMs[%d] = %+v, want %q to alice", i, dm, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// RemindReviewers DMs reviewers again every review_reminder_every while an open PR still waits
+// on their review, up to review_reminder_max times. Unlike the one-shot DM escalation, reminders
+// recur; the count starts over when the PR's state changes, and they stop once the reviewer acts,
+// runs /goose done, or mutes or snoozes the DM.
+func (c *Coordinator) RemindReviewers(ctx context.Context, now time.Time) {
+	every, maxCount := c.config.ReviewReminders(c.org)
+	if every <= 0 || maxCount <= 0 || c.inMaintenance(ctx) {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, tracked := range c.store.ListThreads(ctx, c.org) {
+		prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+		if seen[prURL] {
+			continue
+		}
+		seen[prURL] = true
+		if s := format.PRState(tracked.Info.LastState); s == format.StateMerged || s == format.StateClosed {
+			continue
+		}
+
+		var due []string
+		for _, discordID := range c.store.ListDMUsers(ctx, prURL) {
+			if info, ok := c.store.DMInfo(ctx, discordID, prURL); ok && reviewReminderDue(info, every, maxCount, now) {
+				due = append(due, discordID)
+			}
+		}
+		if len(due) > 0 {
+			c.remindReviewers(ctx, tracked, due, every, maxCount, now)
+		}
+	}
+}
+
+// reviewReminders returns how many reminders a DM has had in its PR's current state, and when
+// the next is due. A DM updated for a new state starts over, its first reminder due a full
+// interval after the update.
+func reviewReminders(info state.DMInfo, every time.Duration) (count int, next time.Time) {
+	if info.Reminders == 0 || info.ReminderState != info.LastState {
+		return 0, info.SentAt.Add(every)
+	}
+	return info.Reminders, info.NextReminderAt
+}
+
+// reviewReminderDue reports whether a DM's next reminder is due and it hasn't had them all,
+// been muted, or been snoozed.
+func reviewReminderDue(info state.DMInfo, every time.Duration, maxCount int, now time.Time) bool {
+	if info.Muted || info.SentAt.IsZero() || now.Before(info.SnoozedUntil) {
+		return false
+	}
+	count, next := reviewReminders(info, every)
+	return count < maxCount && !now.Before(next)
+}
+
+// remindReviewers sends the next reminder to each of a PR's due users whose review it still
+// waits on.
+func (c *Coordinator) remindReviewers(
+	ctx context.Context,
+	tracked state.TrackedThread,
+	discordIDs []string,
+	every time.Duration,
+	maxCount int,
+	now time.Time,
+) {
+	prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+	checkResp, err := c.checkTurn(ctx, prURL, "", now)
+	if err != nil {
+		c.logger.Warn("failed to check PR for review reminders", "error", err, "pr_url", prURL)
+		return
+	}
+	prState := prStateFromCheck(checkResp)
+	if prState == format.StateMerged || prState == format.StateClosed {
+		return
+	}
+	override, _ := c.store.PROverride(ctx, prURL)
+	ackedBy := acknowledgedBy(override, prState)
+
+	for _, discordID := range discordIDs {
+		username := c.actionTargetForDiscordUser(ctx, checkResp, discordID)
+		if username == "" || !isReviewAction(checkResp.Analysis.NextAction[username].Kind) {
+			continue // They've acted, or the PR no longer needs their review
+		}
+		if username == ackedBy || c.isOOO(ctx, username) || c.shouldSkipDM(ctx, discordID, username) {
+			continue
+		}
+		c.remindReviewer(ctx, tracked, checkResp, prState, discordID, every, maxCount, now)
+	}
+}
+
+// remindReviewer sends one user's next review reminder about a PR and records it.
+func (c *Coordinator) remindReviewer(
+	ctx context.Context,
+	tracked state.TrackedThread,
+	checkResp *CheckResponse,
+	prState format.PRState,
+	discordID string,
+	every time.Duration,
+	maxCount int,
+	now time.Time,
+) {
+	prURL := FormatPRURL(tracked.Owner, tracked.Repo, tracked.Number)
+	dmLock := c.dmLocks.get(discordID + ":" + prURL)
+	dmLock.Lock()
+	defer dmLock.Unlock()
+
+	info, ok := c.store.DMInfo(ctx, discordID, prURL)
+	if !ok || !reviewReminderDue(info, every, maxCount, now) || info.HandledState == string(prState) {
+		return
+	}
+	// The DM's update for the PR's new state starts the count over
+	if info.LastState != "" && info.LastState != string(prState) {
+		return
+	}
+	count, _ := reviewReminders(info, every)
+	// Another instance may be sending the same reminder
+	key := EventKeyPrefix(prURL) + "review-reminder:" + discordID + ":" + info.LastState + ":" + strconv.Itoa(count+1)
+	if !c.store.ClaimEvent(ctx, key, every) {
+		return
+	}
+
+	msg := format.ReviewReminderMessage(format.ChannelMessageParams{
+		Owner:  tracked.Owner,
+		Repo:   tracked.Repo,
+		Number: tracked.Number,
+		Title:  checkResp.PullRequest.Title,
+		Author: checkResp.PullRequest.Author,
+		State:  prState,
+		PRURL:  prURL,
+	}, count+1, maxCount)
+	if _, _, err := c.sendDM(ctx, discordID, msg); err != nil {
+		c.logger.Warn("failed to send review reminder",
+			"error", err,
+			"user_id", discordID,
+			"pr_url", prURL)
+		return
+	}
+
+	info.Reminders = count + 1
+	info.ReminderState = info.LastState
+	info.NextReminderAt = now.Add(every)
+	if err := c.saveDMInfo(ctx, discordID, prURL, info); err != nil {
+		c.logger.Warn("failed to save review reminder", "error", err, "pr_url", prURL)
+	}
+	c.logger.Info("sent review reminder",
+		"user_id", discordID,
+		"pr_url", prURL,
+		"reminder", info.Reminders,
+		"max", maxCount)
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// newReminderCoordinator sets up a PR waiting on alice's review, with a DM to her sent at sentAt.
+func newReminderCoordinator(
+	t *testing.T, sentAt time.Time,
+) (coord *Coordinator, discord *mockDiscordClient, turn *mockTurnClient, store *state.MemoryStore) {
+	t.Helper()
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+
+	discord = newMockDiscordClient()
+	discord.usersInGuild["discord-alice"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["alice"] = "discord-alice"
+	configMgr := newMockConfigManager()
+	configMgr.reminderEvery = 4 * time.Hour
+	configMgr.reminderMax = 2
+	turn = newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "bob", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"alice": {Kind: "review"}}},
+	}
+	prState := prStateFromCheck(turn.responses[prURL])
+
+	store = state.NewMemoryStore()
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		ChannelID: "chan-testrepo",
+		MessageID: "msg-1",
+		LastState: string(prState),
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveDMInfo(ctx, "discord-alice", prURL, state.DMInfo{
+		ChannelID: "dm-chan-discord-alice",
+		MessageID: "dm-msg-1",
+		LastState: string(prState),
+		SentAt:    sentAt,
+	}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+
+	coord = NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+	return coord, discord, turn, store
+}
+
+func TestCoordinator_RemindReviewers(t *testing.T) {
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	start := time.Now()
+	coord, discord, turn, store := newReminderCoordinator(t, start)
+
+	steps := []struct {
+		after   time.Duration
+		wantDMs int
+	}{
+		{after: 3 * time.Hour, wantDMs: 0},  // Not yet due
+		{after: 4 * time.Hour, wantDMs: 1},  // First reminder
+		{after: 5 * time.Hour, wantDMs: 1},  // Within the interval
+		{after: 8 * time.Hour, wantDMs: 2},  // Second reminder
+		{after: 20 * time.Hour, wantDMs: 2}, // Stopped at the max
+	}
+	for _, step := range steps {
+		coord.RemindReviewers(ctx, start.Add(step.after))
+		if len(discord.sentDMs) != step.wantDMs {
+			t.Fatalf("after %v: sentDMs = %d, want %d", step.after, len(discord.sentDMs), step.wantDMs)
+		}
+	}
+	if dm := discord.sentDMs[1]; dm.userID != "discord-alice" || !strings.Contains(dm.text, "Reminder 2/2") {
+		t.Errorf("sentDMs[1] = %+v, want alice's second reminder", dm)
+	}
+
+	// A state change, and the DM's update for it, starts the count over a full interval later
+	turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+	info, _ := store.DMInfo(ctx, "discord-alice", prURL)
+	info.LastState = string(prStateFromCheck(turn.responses[prURL]))
+	info.SentAt = start.Add(20 * time.Hour)
+	if err := store.SaveDMInfo(ctx, "discord-alice", prURL, info); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+	coord.RemindReviewers(ctx, start.Add(21*time.Hour))
+	if len(discord.sentDMs) != 2 {
+		t.Fatalf("sentDMs = %d right after the state change, want 2", len(discord.sentDMs))
+	}
+	coord.RemindReviewers(ctx, start.Add(24*time.Hour))
+	if len(discord.sentDMs) != 3 || !strings.Contains(discord.sentDMs[2].text, "Reminder 1/2") {
+		t.Fatalf("sentDMs = %+v, want a first reminder in the new state", discord.sentDMs)
+	}
+}
+
+func TestCoordinator_RemindReviewers_StopsOnAction(t *testing.T) {
+	ctx := context.Background()
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	start := time.Now()
+	coord, discord, turn, _ := newReminderCoordinator(t, start)
+
+	coord.RemindReviewers(ctx, start.Add(4*time.Hour))
+	if len(discord.sentDMs) != 1 {
+		t.Fatalf("sentDMs = %d, want the first reminder", len(discord.sentDMs))
+	}
+
+	// Alice reviewed, so the PR now waits on its author
+	turn.responses[prURL].Analysis.NextAction = map[string]Action{"bob": {Kind: "fix_tests"}}
+	coord.RemindReviewers(ctx, start.Add(8*time.Hour))
+	if len(discord.sentDMs) != 1 {
+		t.Errorf("sentDMs = %d after alice acted, want no more reminders", len(discord.sentDMs))
+	}
+}
+
+func TestReviewReminderDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		info state.DMInfo
+		want bool
+	}{
+		{name: "first reminder due", info: state.DMInfo{SentAt: now.Add(-time.Hour)}, want: true},
+		{name: "first reminder not due", info: state.DMInfo{SentAt: now.Add(-time.Minute)}},
+		{name: "muted", info: state.DMInfo{SentAt: now.Add(-time.Hour), Muted: true}},
+		{name: "snoozed", info: state.DMInfo{SentAt: now.Add(-time.Hour), SnoozedUntil: now.Add(time.Hour)}},
+		{
+			name: "max reached",
+			info: state.DMInfo{SentAt: now.Add(-3 * time.Hour), Reminders: 2, NextReminderAt: now.Add(-time.Hour)},
+		},
+		{
+			name: "max reached in an earlier state",
+			info: state.DMInfo{
+				SentAt: now.Add(-time.Hour), LastState: "tests_broken",
+				Reminders: 2, ReminderState: "tests_running", NextReminderAt: now.Add(-time.Hour),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reviewReminderDue(tt.info, 30*time.Minute, 2, now); got != tt.want {
+				t.Errorf("reviewReminderDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
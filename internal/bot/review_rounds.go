@@ -0,0 +1,20 @@
+package bot
+
+import (
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// reviewRound returns the review round a PR is in given its thread's stored verdict, and the
+// verdict to store. The first approval or request for changes starts round 1; each flip
+// between the two starts another. Other states keep the current round.
+func reviewRound(info state.ThreadInfo, prState format.PRState) (round int, verdict string) {
+	if prState != format.StateApproved && prState != format.StateChanges {
+		return info.ReviewRounds, info.LastReview
+	}
+	verdict = string(prState)
+	if verdict == info.LastReview {
+		return max(info.ReviewRounds, 1), verdict
+	}
+	return info.ReviewRounds + 1, verdict
+}
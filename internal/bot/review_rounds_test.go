@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestReviewRound(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        state.ThreadInfo
+		prState     format.PRState
+		wantRound   int
+		wantVerdict string
+	}{
+		{name: "not reviewed yet", prState: format.StateNeedsReview},
+		{name: "first review", prState: format.StateChanges, wantRound: 1, wantVerdict: "changes_requested"},
+		{
+			name:        "same verdict",
+			info:        state.ThreadInfo{LastReview: "changes_requested", ReviewRounds: 1},
+			prState:     format.StateChanges,
+			wantRound:   1,
+			wantVerdict: "changes_requested",
+		},
+		{
+			name:        "addressed and awaiting re-review",
+			info:        state.ThreadInfo{LastReview: "changes_requested", ReviewRounds: 1},
+			prState:     format.StateNeedsReview,
+			wantRound:   1,
+			wantVerdict: "changes_requested",
+		},
+		{
+			name:        "flipped verdict",
+			info:        state.ThreadInfo{LastReview: "changes_requested", ReviewRounds: 1},
+			prState:     format.StateApproved,
+			wantRound:   2,
+			wantVerdict: "approved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			round, verdict := reviewRound(tt.info, tt.prState)
+			if round != tt.wantRound || verdict != tt.wantVerdict {
+				t.Errorf("reviewRound() = %d, %q; want %d, %q", round, verdict, tt.wantRound, tt.wantVerdict)
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_ReviewRounds(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	configMgr := newMockConfigManager()
+	configMgr.reviewRounds = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Rework parser", Author: "bob", State: "open"},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	steps := []struct {
+		name      string
+		analysis  Analysis
+		wantRound int
+	}{
+		{name: "changes requested", analysis: Analysis{Approved: true, UnresolvedComments: 2}, wantRound: 1},
+		{name: "addressed", analysis: Analysis{}, wantRound: 1},
+		{name: "approved", analysis: Analysis{Approved: true}, wantRound: 2},
+		{name: "changes requested again", analysis: Analysis{Approved: true, UnresolvedComments: 1}, wantRound: 3},
+		{name: "addressed again", analysis: Analysis{}, wantRound: 3},
+		{name: "approved again", analysis: Analysis{Approved: true}, wantRound: 4},
+	}
+	for i, step := range steps {
+		turn.responses[prURL].Analysis = step.analysis
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-" + step.name})
+		coord.Wait()
+
+		info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+		if !ok {
+			t.Fatalf("%s: thread not saved", step.name)
+		}
+		if info.ReviewRounds != step.wantRound {
+			t.Errorf("%s: ReviewRounds = %d, want %d", step.name, info.ReviewRounds, step.wantRound)
+		}
+		if i == 0 {
+			continue // Posted before any round was stored
+		}
+		text := discord.updatedMessages[len(discord.updatedMessages)-1].text
+		switch {
+		case step.wantRound == 1 && strings.Contains(text, " · round "):
+			t.Errorf("%s: message = %q, want no round shown in round 1", step.name, text)
+		case step.wantRound > 1 && !strings.Contains(text, " · round "+strconv.Itoa(step.wantRound)):
+			t.Errorf("%s: message = %q, want round %d", step.name, text, step.wantRound)
+		}
+	}
+}
+
+func TestCoordinator_ProcessEvent_ReviewRoundsOff(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Rework parser", Author: "bob", State: "open"},
+		Analysis:    Analysis{Approved: true},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok {
+		t.Fatal("thread not saved")
+	}
+	if info.ReviewRounds != 0 || info.LastReview != "" {
+		t.Errorf("ReviewRounds, LastReview = %d, %q; want none counted with show_review_rounds and dm_author_on_review off", info.ReviewRounds, info.LastReview)
+	}
+}
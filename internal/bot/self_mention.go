@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// botUserID returns the bot's own Discord user ID, or "" if it isn't known yet.
+func (c *Coordinator) botUserID(ctx context.Context) string {
+	info, err := c.discord.BotInfo(ctx)
+	if err != nil {
+		return ""
+	}
+	return info.UserID
+}
+
+// withoutSelfMentions neutralizes mentions of the bot in a PR's title and description, per the
+// org's self_mentions, so messages rendered from them don't have the bot ping itself. The check
+// response is copied, never modified.
+func (c *Coordinator) withoutSelfMentions(ctx context.Context, prURL string, checkResp *CheckResponse) *CheckResponse {
+	botID := c.botUserID(ctx)
+	if checkResp == nil || botID == "" {
+		return checkResp
+	}
+	strip := c.config.SelfMentions(c.org) == "strip"
+	title := format.NeutralizeUserMention(checkResp.PullRequest.Title, botID, strip)
+	body := format.NeutralizeUserMention(checkResp.PullRequest.Body, botID, strip)
+	if title == checkResp.PullRequest.Title && body == checkResp.PullRequest.Body {
+		return checkResp
+	}
+
+	c.logger.Info("neutralized mentions of the bot in PR", "pr_url", prURL)
+	sanitized := *checkResp
+	sanitized.PullRequest.Title = title
+	sanitized.PullRequest.Body = body
+	return &sanitized
+}
+
+// mentionFor returns how to mention a GitHub user in a message, or "" for a user mapped to the
+// bot itself, who's left out rather than having the bot ping itself.
+func (c *Coordinator) mentionFor(ctx context.Context, username string) string {
+	if c.UserMapper == nil {
+		return username
+	}
+	if c.isBotUser(ctx, c.UserMapper.DiscordID(ctx, username)) {
+		c.logger.Warn("GitHub user is mapped to the bot itself, leaving them out", "github_user", username)
+		return ""
+	}
+	return c.UserMapper.Mention(ctx, username)
+}
+
+// isBotUser reports whether a Discord user ID is the bot's own; it's never mentioned or DMed.
+func (c *Coordinator) isBotUser(ctx context.Context, discordID string) bool {
+	return discordID != "" && discordID == c.botUserID(ctx)
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_SelfMentionInTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    string
+		wantTitle string
+	}{
+		{name: "escaped by default", wantTitle: "Ask <@\u200b999> to rerun"},
+		{name: "stripped", policy: "strip", wantTitle: "Ask  to rerun"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.botUserID = "999"
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+
+			configMgr := newMockConfigManager()
+			configMgr.selfMentions = tt.policy
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Ask <@999> to rerun", Author: "alice", State: "open"},
+			}
+
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord: discord,
+				Config:  configMgr,
+				Store:   state.NewMemoryStore(),
+				Turn:    turn,
+				Org:     "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			text := discord.postedMessages[0].text
+			if strings.Contains(text, "<@999>") {
+				t.Errorf("message = %q, want the bot's mention neutralized", text)
+			}
+			if !strings.Contains(text, tt.wantTitle) {
+				t.Errorf("message = %q, want title %q", text, tt.wantTitle)
+			}
+			if turn.responses[prURL].PullRequest.Title != "Ask <@999> to rerun" {
+				t.Error("Turn's check response was modified")
+			}
+		})
+	}
+}
+
+func TestCoordinator_ProcessEvent_UserMappedToBot(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.botUserID = "999"
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["999"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["goose-bot"] = "999"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Bump deps", Author: "alice", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"goose-bot": {Kind: "review"}}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     newMockConfigManager(),
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+	if text := discord.postedMessages[0].text; strings.Contains(text, "999") {
+		t.Errorf("message = %q, want no mention of the bot", text)
+	}
+	if len(discord.sentDMs) != 0 {
+		t.Errorf("sentDMs = %d, want no DM to the bot", len(discord.sentDMs))
+	}
+	pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending DMs = %d, want none for the bot", len(pending))
+	}
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// soloReviewerViewers is how many of a channel's viewers are looked up to tell whether the
+// reviewer is the only one; any more than one means others see the channel too.
+const soloReviewerViewers = 2
+
+// coalesceSoloReviewer leaves the channel ping out for a PR waiting only on one user's review
+// when they're the only person who can see the channel and solo_reviewer is "dm", so they get
+// their DM alone rather than both. Left untagged, their DM also skips reminder_dm_delay.
+func (c *Coordinator) coalesceSoloReviewer(
+	ctx context.Context,
+	channelID string,
+	checkResp *CheckResponse,
+	users []format.ActionUser,
+) []format.ActionUser {
+	if len(users) != 1 || c.UserMapper == nil || c.config.SoloReviewer(c.org) != "dm" {
+		return users
+	}
+	u := users[0]
+	if u.Mention == u.Username || !isReviewAction(checkResp.Analysis.NextAction[u.Username].Kind) {
+		return users
+	}
+	discordID := c.UserMapper.DiscordID(ctx, u.Username)
+	if discordID == "" {
+		return users
+	}
+	viewers := c.discord.ChannelViewers(ctx, channelID, soloReviewerViewers)
+	if len(viewers) != 1 || viewers[0] != discordID {
+		return users
+	}
+
+	c.logger.Debug("reviewer is the channel's only viewer, leaving the ping to their DM",
+		"channel_id", channelID,
+		"github_user", u.Username)
+	u.Mention = u.Username
+	return []format.ActionUser{u}
+}
@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_SoloReviewer(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		viewers     []string
+		wantMention bool
+	}{
+		{name: "pinged and DMed by default", viewers: []string{"discord-bob"}, wantMention: true},
+		{name: "only DMed under dm", policy: "dm", viewers: []string{"discord-bob"}},
+		{name: "pinged when others see the channel", policy: "dm", viewers: []string{"discord-bob", "discord-carol"}, wantMention: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			discord := newMockDiscordClient()
+			discord.channelIDs["testrepo"] = "chan-testrepo"
+			discord.botInChannel["chan-testrepo"] = true
+			discord.usersInGuild["discord-bob"] = true
+			discord.channelViewers = map[string][]string{"chan-testrepo": tt.viewers}
+			mapper := newMockUserMapper()
+			mapper.mappings["bob"] = "discord-bob"
+
+			configMgr := newMockConfigManager()
+			configMgr.soloReviewer = tt.policy
+
+			prURL := "https://github.com/testorg/testrepo/pull/1"
+			turn := newMockTurnClient()
+			turn.responses[prURL] = &CheckResponse{
+				PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+				Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+			}
+
+			store := state.NewMemoryStore()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:    discord,
+				Config:     configMgr,
+				Store:      store,
+				Turn:       turn,
+				UserMapper: mapper,
+				Org:        "testorg",
+			})
+
+			coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+			coord.Wait()
+
+			if len(discord.postedMessages) != 1 {
+				t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+			}
+			if got := strings.Contains(discord.postedMessages[0].text, "<@discord-bob>"); got != tt.wantMention {
+				t.Errorf("message = %q, mentions bob = %v, want %v", discord.postedMessages[0].text, got, tt.wantMention)
+			}
+			pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+			if err != nil {
+				t.Fatalf("PendingDMs() error = %v", err)
+			}
+			if len(pending)+len(discord.sentDMs) != 1 {
+				t.Errorf("DMs = %d pending and %d sent, want bob DMed once", len(pending), len(discord.sentDMs))
+			}
+		})
+	}
+}
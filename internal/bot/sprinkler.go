@@ -187,6 +187,45 @@ func FormatPRURL(owner, repo string, number int) string {
 	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, number)
 }
 
+// ErrInvalidEvent is returned by SprinklerEvent.Validate for events that can't be processed.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// Normalize cleans up an event before it's processed: the URL is trimmed and reduced to
+// the PR's canonical URL, dropping trailing slashes, queries, and subpages such as /files,
+// and a missing Timestamp defaults to now. URLs that aren't PR URLs are left for Validate.
+func (e *SprinklerEvent) Normalize() {
+	e.URL = strings.TrimSpace(e.URL)
+	if u, err := url.Parse(e.URL); err == nil {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) > 4 && parts[2] == "pull" {
+			parts = parts[:4]
+		}
+		u.Path = "/" + strings.Join(parts, "/")
+		u.RawQuery, u.Fragment = "", ""
+		if pr, ok := ParsePRURL(u.String()); ok {
+			e.URL = FormatPRURL(pr.Owner, pr.Repo, pr.Number)
+		}
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+}
+
+// Validate reports why an event can't be processed, wrapping ErrInvalidEvent,
+// or nil if it can. Call Normalize first.
+func (e SprinklerEvent) Validate() error {
+	switch {
+	case e.URL == "":
+		return fmt.Errorf("%w: missing URL", ErrInvalidEvent)
+	case e.DeliveryID == "":
+		return fmt.Errorf("%w: missing delivery ID for %s", ErrInvalidEvent, e.URL)
+	}
+	if _, ok := ParsePRURL(e.URL); !ok {
+		return fmt.Errorf("%w: %s", ErrUnparseableURL, e.URL)
+	}
+	return nil
+}
+
 // TurnHTTPClient implements TurnClient using HTTP.
 type TurnHTTPClient struct {
 	tokenProvider TokenProvider
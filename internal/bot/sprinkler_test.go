@@ -3,8 +3,10 @@ package bot
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -369,3 +371,69 @@ func TestFormatPRURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSprinklerEvent_Normalize(t *testing.T) {
+	const want = "https://github.com/org/repo/pull/12"
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"canonical", want, want},
+		{"surrounding whitespace", "  " + want + "\n", want},
+		{"trailing slash", want + "/", want},
+		{"files tab", want + "/files", want},
+		{"commits tab with slash", want + "/commits/", want},
+		{"query and fragment", want + "?w=1#discussion_r1", want},
+		{"not a PR", "https://github.com/org/repo/issues/12", "https://github.com/org/repo/issues/12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := SprinklerEvent{URL: tt.url, DeliveryID: "d-1"}
+			event.Normalize()
+			if event.URL != tt.want {
+				t.Errorf("URL = %q, want %q", event.URL, tt.want)
+			}
+		})
+	}
+
+	event := SprinklerEvent{URL: want}
+	before := time.Now()
+	event.Normalize()
+	if event.Timestamp.Before(before) {
+		t.Errorf("Timestamp = %v, want now for an event without one", event.Timestamp)
+	}
+	sent := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	event = SprinklerEvent{URL: want, Timestamp: sent}
+	event.Normalize()
+	if !event.Timestamp.Equal(sent) {
+		t.Errorf("Timestamp = %v, want the event's own %v", event.Timestamp, sent)
+	}
+}
+
+func TestSprinklerEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   SprinklerEvent
+		wantErr string
+	}{
+		{"valid", SprinklerEvent{URL: "https://github.com/org/repo/pull/1", DeliveryID: "d-1"}, ""},
+		{"missing URL", SprinklerEvent{DeliveryID: "d-1"}, "missing URL"},
+		{"missing delivery ID", SprinklerEvent{URL: "https://github.com/org/repo/pull/1"}, "missing delivery ID"},
+		{"not a PR URL", SprinklerEvent{URL: "https://github.com/org/repo/issues/1", DeliveryID: "d-1"}, "not a GitHub PR URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidEvent) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want ErrInvalidEvent mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
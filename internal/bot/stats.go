@@ -0,0 +1,53 @@
+package bot
+
+import "sync/atomic"
+
+// CoordinatorStats counts a coordinator's activity since it started.
+type CoordinatorStats struct {
+	EventsProcessed int64 // Events claimed and processed, excluding duplicate deliveries
+	MessagesPosted  int64 // Channel messages and forum threads posted, including digests and escalations
+	MessagesEdited  int64 // Edits to PR messages and forum posts
+	DMsQueued       int64
+	TurnCalls       int64
+	TurnDeferred    int64        // Turn calls that waited for the org's Turn rate limit
+	TurnThrottled   int64        // Events whose Turn call was over the rate limit, retried later
+	Errors          int64        // Events or channels that failed to process
+	UnparseableURLs int64        // Events dropped because their URL isn't a GitHub PR URL
+	PostLatency     LatencyStats // From an event to the post or edit of its PR's channel messages
+	DMLatency       LatencyStats // From an event to the DMs it queued going out
+}
+
+// coordinatorCounters back CoordinatorStats, updated concurrently by event goroutines.
+type coordinatorCounters struct {
+	events    atomic.Int64
+	posts     atomic.Int64
+	edits     atomic.Int64
+	dmsQueued atomic.Int64
+	turnCalls atomic.Int64
+	errors    atomic.Int64
+
+	turnDeferred  atomic.Int64
+	turnThrottled atomic.Int64
+
+	unparseable atomic.Int64
+
+	postLatency latencyHistogram
+	dmLatency   latencyHistogram
+}
+
+// CoordinatorStats returns the coordinator's activity counts.
+func (c *Coordinator) CoordinatorStats() CoordinatorStats {
+	return CoordinatorStats{
+		EventsProcessed: c.counters.events.Load(),
+		MessagesPosted:  c.counters.posts.Load(),
+		MessagesEdited:  c.counters.edits.Load(),
+		DMsQueued:       c.counters.dmsQueued.Load(),
+		TurnCalls:       c.counters.turnCalls.Load(),
+		TurnDeferred:    c.counters.turnDeferred.Load(),
+		TurnThrottled:   c.counters.turnThrottled.Load(),
+		Errors:          c.counters.errors.Load(),
+		UnparseableURLs: c.counters.unparseable.Load(),
+		PostLatency:     c.counters.postLatency.snapshot(),
+		DMLatency:       c.counters.dmLatency.snapshot(),
+	}
+}
@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_CoordinatorStats(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Counted", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 1}},
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	if stats := coord.CoordinatorStats(); !reflect.DeepEqual(stats, CoordinatorStats{}) {
+		t.Fatalf("CoordinatorStats() before any events = %+v, want zero", stats)
+	}
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	// A duplicate delivery isn't processed again
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	turn.responses[prURL].Analysis.Approved = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request_review", DeliveryID: "d-2"})
+	coord.Wait()
+
+	// An invalid URL is counted on its own rather than as a processing error
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: "not-a-pr", Type: "pull_request", DeliveryID: "d-3"})
+	coord.Wait()
+
+	want := CoordinatorStats{
+		EventsProcessed: 2,
+		MessagesPosted:  1,
+		MessagesEdited:  1,
+		TurnCalls:       2,
+		UnparseableURLs: 1,
+	}
+	stats := coord.CoordinatorStats()
+	// Each write's latency is measured from its event
+	if stats.PostLatency.Count != 2 {
+		t.Errorf("PostLatency.Count = %d, want 2", stats.PostLatency.Count)
+	}
+	stats.PostLatency = LatencyStats{}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("CoordinatorStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestCoordinator_NotificationLatency(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	discord.usersInGuild["discord-bob"] = true
+	mapper := newMockUserMapper()
+	mapper.mappings["bob"] = "discord-bob"
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Timed", Author: "alice", State: "open"},
+		Analysis:    Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     newMockConfigManager(),
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+
+	eventAt := time.Now().Add(-3 * time.Second)
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1", Timestamp: eventAt})
+	coord.Wait()
+
+	// The queued DM carries the event's time, for measuring when it's sent
+	pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	if len(pending) != 1 || !pending[0].EventAt.Equal(eventAt) {
+		t.Fatalf("pending DMs = %+v, want one with EventAt %v", pending, eventAt)
+	}
+
+	// Polls carry the PR's last update, not when anything happened, so they aren't measured
+	turn.responses[prURL].Analysis.Approved = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "poll", DeliveryID: "d-2", Timestamp: eventAt})
+	coord.Wait()
+
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want the poll's edit", len(discord.updatedMessages))
+	}
+	posts := coord.CoordinatorStats().PostLatency
+	if posts.Count != 1 || posts.Sum < 3*time.Second || posts.Sum > time.Minute {
+		t.Fatalf("PostLatency = %+v, want one post about 3s after the event", posts)
+	}
+	if p50 := posts.Quantile(0.5); p50 < 2*time.Second || p50 > 5*time.Second {
+		t.Errorf("PostLatency p50 = %v, want within the 2-5s bucket", p50)
+	}
+	coord.ObserveDMLatency(10 * time.Minute)
+	if dms := coord.CoordinatorStats().DMLatency; dms.Count != 1 || dms.Sum != 10*time.Minute {
+		t.Errorf("DMLatency = %+v, want the observed DM", dms)
+	}
+}
+
+func TestLatencyStats_Quantile(t *testing.T) {
+	var h latencyHistogram
+	for range 90 {
+		h.observe(1500 * time.Millisecond) // 1-2s bucket
+	}
+	for range 10 {
+		h.observe(45 * time.Second) // 30s-1m bucket
+	}
+	h.observe(-time.Second) // Clock skew counts as instant
+	stats := h.snapshot()
+
+	if got := stats.Quantile(0.5); got < time.Second || got > 2*time.Second {
+		t.Errorf("Quantile(0.5) = %v, want within 1-2s", got)
+	}
+	if got := stats.Quantile(0.95); got < 30*time.Second || got > time.Minute {
+		t.Errorf("Quantile(0.95) = %v, want within 30s-1m", got)
+	}
+	if got := (LatencyStats{}).Quantile(0.5); got != 0 {
+		t.Errorf("Quantile() with no samples = %v, want 0", got)
+	}
+
+	merged := stats.Merge(stats)
+	if merged.Count != 2*stats.Count || merged.Buckets[0] != 2 || merged.Sum != 2*stats.Sum {
+		t.Errorf("Merge() = %+v, want doubled counts", merged)
+	}
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// suppressState reports whether a channel's suppress_states hides the PR's state, in which case
+// nothing is posted or edited. An existing message's stored state still moves to it, so the PR
+// leaving the suppressed state is seen as a change and its message catches up. Whether it's a
+// draft is kept up to date along with it.
+func (c *Coordinator) suppressState(
+	ctx context.Context,
+	channelID, channelName, owner, repo string,
+	number int,
+	info state.ThreadInfo,
+	exists bool,
+	prState format.PRState,
+	draft bool,
+) bool {
+	if !c.config.StateSuppressed(c.org, channelName, string(prState)) {
+		return false
+	}
+	c.logger.Debug("channel suppresses PR state, skipping post",
+		"channel", channelName,
+		"pr", FormatPRURL(owner, repo, number),
+		"state", prState)
+	if !exists || (info.LastState == string(prState) && info.Draft == draft) {
+		return true
+	}
+	info.LastState, info.Draft = string(prState), draft
+	if err := c.store.SaveThread(ctx, owner, repo, number, channelID, info); err != nil {
+		c.logger.Warn("failed to save suppressed state", "error", err, "channel", channelName)
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_SuppressStates(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.suppressStates = []string{string(format.StateTestsRunning)}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Pending: 1}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("postedMessages = %+v, want nothing posted while tests run", discord.postedMessages)
+	}
+
+	// Tests passed, so the PR needs review and that's shown
+	turn.responses[prURL].Analysis = Analysis{
+		Checks:     Checks{Passing: 1},
+		NextAction: map[string]Action{"bob": {Kind: "review"}},
+	}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-2"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want the needs review post", len(discord.postedMessages))
+	}
+	info, ok := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo")
+	if !ok || info.LastState != string(format.StateNeedsReview) {
+		t.Fatalf("thread = %+v, want a needs review message", info)
+	}
+
+	// Tests rerunning leave the message alone, but the stored state follows
+	turn.responses[prURL].Analysis.Checks = Checks{Pending: 1}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-3"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 0 {
+		t.Fatalf("updatedMessages = %+v, want no edit for the suppressed state", discord.updatedMessages)
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); info.LastState != string(format.StateTestsRunning) {
+		t.Errorf("LastState = %q, want %q", info.LastState, format.StateTestsRunning)
+	}
+
+	turn.responses[prURL].Analysis.Checks = Checks{Failing: 1}
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: "d-4"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 || !strings.Contains(discord.updatedMessages[0].text, "Add caching") {
+		t.Fatalf("updatedMessages = %+v, want the message edited once tests finished", discord.updatedMessages)
+	}
+}
+
+func TestCoordinator_ProcessEvent_SuppressStates_KeepsDraft(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.suppressStates = []string{string(format.StateTestsRunning)}
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add caching", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Pending: 1}},
+	}
+
+	store := state.NewMemoryStore()
+	// The draft was posted, then marked ready while its tests run
+	if err := store.SaveThread(ctx, "testorg", "testrepo", 1, "chan-testrepo", state.ThreadInfo{
+		MessageID:   "msg-1",
+		ChannelID:   "chan-testrepo",
+		ChannelType: "text",
+		LastState:   string(format.StateTestsRunning),
+		Draft:       true,
+	}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   store,
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 0 {
+		t.Fatalf("updatedMessages = %+v, want no edit for the suppressed state", discord.updatedMessages)
+	}
+	if info, _ := store.Thread(ctx, "testorg", "testrepo", 1, "chan-testrepo"); info.Draft {
+		t.Error("Draft = true after the PR was marked ready, want false")
+	}
+}
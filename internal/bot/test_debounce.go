@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// testStateSettledEvent is the event type replayed once a PR's test state has been stable
+// for the org's test_state_debounce.
+const testStateSettledEvent = "test_state_settled"
+
+// testStateShownTTL is how long a PR's shown state is remembered without a new event. A PR
+// that has been quiet longer is treated as new, so its next state is shown right away.
+const testStateShownTTL = 24 * time.Hour
+
+// testStateDebouncer holds back flips between transient test states so CI reruns
+// produce one settled edit rather than an edit per flip.
+type testStateDebouncer struct {
+	pending map[string]*time.Timer    // PR URL -> timer that replays the PR once settled
+	shown   map[string]shownTestState // PR URL -> state last reflected in messages
+	mu      sync.Mutex
+}
+
+type shownTestState struct {
+	at    time.Time // When the state was last reflected, for forgetting quiet PRs
+	state format.PRState
+}
+
+func isTransientTestState(s format.PRState) bool {
+	return s == format.StateTestsRunning || s == format.StateTestsBroken
+}
+
+// deferTestState reports whether rendering prState for an event should wait until the PR's
+// state has been stable for the debounce period. Each deferred flip restarts the timer;
+// when it fires, the PR is replayed with its latest state. Any other state applies
+// immediately and cancels a pending replay. Caller must hold the PR lock.
+func (c *Coordinator) deferTestState(ctx context.Context, event SprinklerEvent, prState format.PRState) bool {
+	debounce := c.config.TestStateDebounce(c.org)
+	if debounce <= 0 {
+		return false
+	}
+
+	d := &c.testDebounce
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending == nil {
+		d.pending = make(map[string]*time.Timer)
+		d.shown = make(map[string]shownTestState)
+	}
+
+	url := event.URL
+	now := time.Now()
+	shown, seen := d.shown[url]
+	seen = seen && now.Sub(shown.at) < testStateShownTTL
+	// A PR's first state since startup is shown right away, so new PRs aren't delayed
+	if event.Type != testStateSettledEvent && seen && shown.state != prState && isTransientTestState(prState) {
+		if timer, ok := d.pending[url]; ok && timer.Stop() {
+			timer.Reset(debounce)
+			return true
+		}
+		// Counted in wg until the replay is dispatched, so Wait covers pending replays
+		c.wg.Add(1)
+		d.pending[url] = time.AfterFunc(debounce, func() {
+			defer c.wg.Done()
+			c.logger.Debug("test state settled, replaying PR", "pr_url", url)
+			c.replayEvent(ctx, SprinklerEvent{
+				URL:        url,
+				Type:       testStateSettledEvent,
+				DeliveryID: "settled-" + strconv.FormatInt(time.Now().UnixNano(), 10),
+				Timestamp:  time.Now(),
+			})
+		})
+		return true
+	}
+
+	if timer, ok := d.pending[url]; ok {
+		if timer.Stop() {
+			c.wg.Done()
+		}
+		delete(d.pending, url)
+	}
+	if prState == format.StateMerged || prState == format.StateClosed {
+		delete(d.shown, url)
+		return false
+	}
+	if !seen {
+		// Forget PRs that went quiet without closing, e.g. because they left the org's channels
+		for u, s := range d.shown {
+			if now.Sub(s.at) >= testStateShownTTL {
+				delete(d.shown, u)
+			}
+		}
+	}
+	d.shown[url] = shownTestState{at: now, state: prState}
+	return false
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_TestStateDebounce(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	configMgr := newMockConfigManager()
+	configMgr.testDebounce = 200 * time.Millisecond
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	respond := func(checks Checks) {
+		turn.responses[prURL] = &CheckResponse{
+			PullRequest: PRInfo{Title: "Flaky", Author: "alice", State: "open"},
+			Analysis:    Analysis{Checks: checks},
+		}
+	}
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	// The first state is posted right away
+	respond(Checks{})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-0"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+
+	// CI reruns flap between running and broken faster than the debounce
+	for i, checks := range []Checks{{Pending: 1}, {Failing: 1}, {Pending: 1}, {Failing: 1}} {
+		respond(checks)
+		// Synchronous, since Wait would also wait out the pending replay
+		if err := coord.processEventSync(ctx, SprinklerEvent{URL: prURL, Type: "check_run", DeliveryID: fmt.Sprintf("d-%d", i+1)}); err != nil {
+			t.Fatalf("flap %d: processEventSync() error = %v", i, err)
+		}
+	}
+
+	// Wait covers the pending replay; every flap before it was held back
+	coord.Wait()
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want 1 settled edit", len(discord.updatedMessages))
+	}
+	if text := discord.updatedMessages[0].text; !strings.Contains(text, format.StateText(format.StateTestsBroken)) {
+		t.Errorf("settled edit = %q, want tests failing", text)
+	}
+
+	// A non-test state applies immediately
+	respond(Checks{})
+	turn.responses[prURL].PullRequest.Merged = true
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-merged"})
+	coord.Wait()
+	if len(discord.updatedMessages) != 2 {
+		t.Errorf("updatedMessages = %d after merge, want 2", len(discord.updatedMessages))
+	}
+}
+
+func TestCoordinator_deferTestState_ForgetsQuietPRs(t *testing.T) {
+	configMgr := newMockConfigManager()
+	configMgr.testDebounce = time.Hour
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    newMockTurnClient(),
+		Org:     "testorg",
+	})
+
+	quiet := "https://github.com/testorg/testrepo/pull/1"
+	coord.testDebounce.pending = make(map[string]*time.Timer)
+	coord.testDebounce.shown = map[string]shownTestState{
+		quiet: {at: time.Now().Add(-testStateShownTTL), state: format.StateTestsRunning},
+	}
+
+	// A quiet PR's next flip is shown right away, like a new PR's
+	if coord.deferTestState(context.Background(), SprinklerEvent{URL: quiet}, format.StateTestsBroken) {
+		t.Error("deferTestState() = true for a PR quiet past the TTL, want false")
+	}
+
+	coord.testDebounce.shown[quiet] = shownTestState{at: time.Now().Add(-testStateShownTTL), state: format.StateTestsRunning}
+	coord.deferTestState(context.Background(), SprinklerEvent{URL: "https://github.com/testorg/testrepo/pull/2"}, format.StateTestsRunning)
+	if _, ok := coord.testDebounce.shown[quiet]; ok {
+		t.Error("shown still has a PR quiet past the TTL")
+	}
+	if len(coord.testDebounce.shown) != 1 {
+		t.Errorf("shown = %d entries, want 1", len(coord.testDebounce.shown))
+	}
+}
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTurnThrottleWait bounds how long a call waits for the org's Turn budget.
+	// Events whose call would wait longer are throttled, and their PR is replayed later.
+	maxTurnThrottleWait = 30 * time.Second
+	// turnRetryEvent is the event type replayed for a PR whose Turn call was throttled.
+	turnRetryEvent = "turn_throttle_elapsed"
+)
+
+// errTurnThrottled is returned when a Turn call is dropped by the org's rate limit.
+var errTurnThrottled = errors.New("turn API rate limit exceeded")
+
+// turnLimiter is a token bucket bounding Turn API calls for one org,
+// so a flood of events for one org can't exhaust a shared Turn budget.
+type turnLimiter struct {
+	last   time.Time
+	tokens float64
+	mu     sync.Mutex
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+// Returns false without taking a token if the wait would exceed maxWait.
+// Rate and burst are passed per call so config reloads apply immediately.
+func (l *turnLimiter) reserve(now time.Time, perMinute, burst int, maxWait time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	perSecond := float64(perMinute) / 60
+	if l.last.IsZero() {
+		l.tokens = float64(burst)
+	} else {
+		l.tokens = min(float64(burst), l.tokens+now.Sub(l.last).Seconds()*perSecond)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	wait := time.Duration((1 - l.tokens) / perSecond * float64(time.Second))
+	if wait > maxWait {
+		return 0, false
+	}
+	l.tokens--
+	return wait, true
+}
+
+// checkTurn calls the Turn API, first waiting for the org's rate limit if one is configured.
+// Returns errTurnThrottled if the call would have to wait longer than maxTurnThrottleWait.
+func (c *Coordinator) checkTurn(ctx context.Context, prURL, username string, updatedAt time.Time) (*CheckResponse, error) {
+	perMinute, burst := c.config.TurnRateLimit(c.org)
+	if perMinute > 0 {
+		wait, ok := c.turnLimiter.reserve(time.Now(), perMinute, burst, maxTurnThrottleWait)
+		if !ok {
+			c.counters.turnThrottled.Add(1)
+			return nil, errTurnThrottled
+		}
+		if wait > 0 {
+			c.counters.turnDeferred.Add(1)
+			c.logger.Debug("deferring Turn API call for rate limit", "pr_url", prURL, "wait", wait)
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	c.counters.turnCalls.Add(1)
+	checkResp, err := c.turn.Check(ctx, prURL, username, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c.withoutSelfMentions(ctx, prURL, checkResp), nil
+}
+
+// turnRetries holds back the PRs whose events were throttled by the org's Turn rate limit,
+// replaying each once the budget has had time to refill.
+type turnRetries struct {
+	pending map[string]*time.Timer // PR URL -> timer that replays the PR
+	mu      sync.Mutex
+}
+
+// retryThrottled schedules a replay of a PR whose event was throttled, so the event is
+// delayed rather than lost. Further throttled events for the PR are coalesced into the
+// pending replay, which renders the PR's latest state. A replay that's throttled again
+// schedules another.
+func (c *Coordinator) retryThrottled(ctx context.Context, prURL string) {
+	r := &c.turnRetries
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pending[prURL]; ok {
+		c.logger.Debug("Turn API call throttled, coalescing into pending retry", "pr_url", prURL)
+		return
+	}
+	if r.pending == nil {
+		r.pending = make(map[string]*time.Timer)
+	}
+
+	// Counted in wg until the replay is dispatched, so Wait covers pending replays
+	c.wg.Add(1)
+	r.pending[prURL] = time.AfterFunc(c.turnRetryDelay, func() {
+		defer c.wg.Done()
+		r.mu.Lock()
+		delete(r.pending, prURL)
+		r.mu.Unlock()
+
+		c.logger.Debug("retrying PR throttled by Turn API rate limit", "pr_url", prURL)
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        prURL,
+			Type:       turnRetryEvent,
+			DeliveryID: "turn-retry-" + strconv.FormatInt(time.Now().UnixNano(), 10),
+			Timestamp:  time.Now(),
+		})
+	})
+	c.logger.Info("Turn API rate limit exceeded, retrying PR later",
+		"pr_url", prURL,
+		"wait", c.turnRetryDelay)
+}
@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestTurnLimiter_Reserve(t *testing.T) {
+	var l turnLimiter
+	now := time.Now()
+
+	// 60/minute with a burst of 2: two immediate calls, then one per second
+	for i := range 2 {
+		if wait, ok := l.reserve(now, 60, 2, time.Minute); !ok || wait != 0 {
+			t.Fatalf("call %d: reserve() = (%v, %v), want immediate", i, wait, ok)
+		}
+	}
+	if wait, ok := l.reserve(now, 60, 2, time.Minute); !ok || wait != time.Second {
+		t.Errorf("third call: reserve() = (%v, %v), want 1s wait", wait, ok)
+	}
+	if wait, ok := l.reserve(now, 60, 2, time.Minute); !ok || wait != 2*time.Second {
+		t.Errorf("fourth call: reserve() = (%v, %v), want 2s wait", wait, ok)
+	}
+
+	// Waiting longer than maxWait drops the call without consuming budget
+	if _, ok := l.reserve(now, 60, 2, time.Second); ok {
+		t.Error("reserve() should drop a call that would wait past maxWait")
+	}
+
+	// Tokens refill over time, capped at burst
+	later := now.Add(time.Hour)
+	for i := range 2 {
+		if wait, ok := l.reserve(later, 60, 2, time.Minute); !ok || wait != 0 {
+			t.Errorf("after refill, call %d: reserve() = (%v, %v), want immediate", i, wait, ok)
+		}
+	}
+	if wait, _ := l.reserve(later, 60, 2, time.Minute); wait == 0 {
+		t.Error("refill should be capped at burst")
+	}
+}
+
+func TestCoordinator_checkTurn_DefersBurst(t *testing.T) {
+	configMgr := newMockConfigManager()
+	configMgr.turnPerMinute = 1200 // one call per 50ms
+	configMgr.turnBurst = 1
+	turn := newMockTurnClient()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	start := time.Now()
+	for range 3 {
+		if _, err := coord.checkTurn(context.Background(), "https://github.com/testorg/repo/pull/1", "", time.Now()); err != nil {
+			t.Fatalf("checkTurn() error = %v", err)
+		}
+	}
+
+	if turn.callCount != 3 {
+		t.Errorf("turn calls = %d, want 3", turn.callCount)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("burst finished in %v, want calls beyond the bucket deferred", elapsed)
+	}
+	if stats := coord.CoordinatorStats(); stats.TurnDeferred != 2 || stats.TurnThrottled != 0 {
+		t.Errorf("TurnDeferred, TurnThrottled = %d, %d, want 2, 0", stats.TurnDeferred, stats.TurnThrottled)
+	}
+}
+
+func TestCoordinator_checkTurn_Dropped(t *testing.T) {
+	configMgr := newMockConfigManager()
+	configMgr.turnPerMinute = 1 // next token is a minute away, past maxTurnThrottleWait
+	configMgr.turnBurst = 1
+	turn := newMockTurnClient()
+
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: newMockDiscordClient(),
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	ctx := context.Background()
+	if _, err := coord.checkTurn(ctx, "https://github.com/testorg/repo/pull/1", "", time.Now()); err != nil {
+		t.Fatalf("first checkTurn() error = %v", err)
+	}
+	if _, err := coord.checkTurn(ctx, "https://github.com/testorg/repo/pull/1", "", time.Now()); !errors.Is(err, errTurnThrottled) {
+		t.Errorf("second checkTurn() error = %v, want errTurnThrottled", err)
+	}
+	if turn.callCount != 1 {
+		t.Errorf("turn calls = %d, want 1", turn.callCount)
+	}
+	if throttled := coord.CoordinatorStats().TurnThrottled; throttled != 1 {
+		t.Errorf("TurnThrottled = %d, want 1", throttled)
+	}
+}
+
+// mockConfigManagerWithTurnLimit lets a test lift the Turn rate limit while events are in flight.
+type mockConfigManagerWithTurnLimit struct {
+	*mockConfigManager
+
+	perMinute atomic.Int64
+}
+
+func (m *mockConfigManagerWithTurnLimit) TurnRateLimit(_ string) (perMinute, burst int) {
+	return int(m.perMinute.Load()), 1
+}
+
+func TestCoordinator_ProcessEvent_RetriesThrottled(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{PullRequest: PRInfo{Title: "Throttled", Author: "alice", State: "open"}}
+
+	configMgr := &mockConfigManagerWithTurnLimit{mockConfigManager: newMockConfigManager()}
+	configMgr.perMinute.Store(1) // next token is a minute away, past maxTurnThrottleWait
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  configMgr,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+	coord.turnRetryDelay = 50 * time.Millisecond
+
+	// Spend the bucket's only token
+	if _, err := coord.checkTurn(ctx, prURL, "", time.Now()); err != nil {
+		t.Fatalf("checkTurn() error = %v", err)
+	}
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	deadline := time.Now().Add(time.Second)
+	for coord.CoordinatorStats().TurnThrottled == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("event's Turn call wasn't throttled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(discord.postedMessages) != 0 {
+		t.Fatalf("posted %d messages while throttled, want 0", len(discord.postedMessages))
+	}
+
+	// Once the org is under its limit again, the retry renders the PR
+	configMgr.perMinute.Store(0)
+	coord.Wait()
+
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("posted %d messages after the retry, want 1", len(discord.postedMessages))
+	}
+	if turn.callCount != 2 {
+		t.Errorf("turn calls = %d, want 2", turn.callCount)
+	}
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ErrUnparseableURL is returned for events whose URL isn't a GitHub PR URL. It wraps
+// ErrInvalidEvent.
+var ErrUnparseableURL = fmt.Errorf("%w: not a GitHub PR URL", ErrInvalidEvent)
+
+// What happens to events whose URL isn't a GitHub PR URL. A spike of them signals an
+// upstream problem, so they're always counted in CoordinatorStats.
+const (
+	UnparseableLog        = "log"         // Log at the policy's level
+	UnparseableDrop       = "drop"        // Drop without logging
+	UnparseableDeadLetter = "dead_letter" // Log, and hand to the policy's sink for investigation
+)
+
+// UnparseablePolicy says how events with unparseable URLs are handled. The zero value logs
+// them as errors.
+type UnparseablePolicy struct {
+	Sink     UnparseableSink // Receives events under UnparseableDeadLetter
+	Action   string          // UnparseableLog, UnparseableDrop, or UnparseableDeadLetter
+	LogLevel slog.Level      // Level logged at; unused while Action is empty
+}
+
+// UnparseableSink receives events whose URL couldn't be parsed.
+type UnparseableSink interface {
+	UnparseableEvent(ctx context.Context, org string, event SprinklerEvent)
+}
+
+// handleUnparseable counts an event whose URL couldn't be parsed and handles it as the
+// coordinator's UnparseablePolicy says.
+func (c *Coordinator) handleUnparseable(ctx context.Context, event SprinklerEvent, err error) {
+	c.counters.unparseable.Add(1)
+
+	policy := c.unparseable
+	if policy.Action == UnparseableDrop {
+		return
+	}
+	level := slog.LevelError
+	if policy.Action != "" {
+		level = policy.LogLevel
+	}
+	c.logger.Log(ctx, level, "dropping event with unparseable URL",
+		"error", err,
+		"url", event.URL,
+		"type", event.Type,
+		"delivery_id", event.DeliveryID)
+
+	if policy.Action == UnparseableDeadLetter && policy.Sink != nil {
+		policy.Sink.UnparseableEvent(ctx, c.org, event)
+	}
+}
+
+// isUnparseable reports whether err is for an event whose URL couldn't be parsed.
+func isUnparseable(err error) bool {
+	return errors.Is(err, ErrUnparseableURL)
+}
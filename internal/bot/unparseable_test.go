@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+type recordingUnparseableSink struct {
+	events []SprinklerEvent
+	org    string
+}
+
+func (s *recordingUnparseableSink) UnparseableEvent(_ context.Context, org string, event SprinklerEvent) {
+	s.org = org
+	s.events = append(s.events, event)
+}
+
+func TestCoordinator_ProcessEvent_UnparseableURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     UnparseablePolicy
+		wantLog    string // Level logged at, or "" for no log
+		wantRouted bool
+	}{
+		{name: "default logs as error", wantLog: "level=ERROR"},
+		{name: "log at configured level", policy: UnparseablePolicy{Action: UnparseableLog, LogLevel: slog.LevelWarn}, wantLog: "level=WARN"},
+		{name: "drop", policy: UnparseablePolicy{Action: UnparseableDrop, LogLevel: slog.LevelError}},
+		{
+			name:       "dead letter",
+			policy:     UnparseablePolicy{Action: UnparseableDeadLetter, LogLevel: slog.LevelInfo},
+			wantLog:    "level=INFO",
+			wantRouted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			sink := &recordingUnparseableSink{}
+			tt.policy.Sink = sink
+			discord := newMockDiscordClient()
+			coord := NewCoordinator(CoordinatorConfig{
+				Discord:     discord,
+				Config:      newMockConfigManager(),
+				Store:       state.NewMemoryStore(),
+				Turn:        newMockTurnClient(),
+				Logger:      slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				Org:         "testorg",
+				Unparseable: tt.policy,
+			})
+
+			event := SprinklerEvent{URL: "https://github.com/testorg/testrepo/issues/1", Type: "issues", DeliveryID: "d-1"}
+			coord.ProcessEvent(context.Background(), event)
+			coord.Wait()
+
+			stats := coord.CoordinatorStats()
+			if stats.UnparseableURLs != 1 {
+				t.Errorf("UnparseableURLs = %d, want 1", stats.UnparseableURLs)
+			}
+			if stats.Errors != 0 {
+				t.Errorf("Errors = %d, want unparseable URLs counted on their own", stats.Errors)
+			}
+			if len(discord.postedMessages) != 0 {
+				t.Errorf("postedMessages = %d, want 0", len(discord.postedMessages))
+			}
+
+			logged := strings.Contains(logs.String(), "unparseable URL")
+			switch {
+			case tt.wantLog == "" && logged:
+				t.Errorf("logs = %q, want the event dropped silently", logs.String())
+			case tt.wantLog != "" && (!logged || !strings.Contains(logs.String(), tt.wantLog)):
+				t.Errorf("logs = %q, want the event logged at %s", logs.String(), tt.wantLog)
+			}
+
+			if routed := len(sink.events) == 1; routed != tt.wantRouted {
+				t.Errorf("routed to sink = %v, want %v", routed, tt.wantRouted)
+			}
+			if tt.wantRouted && (sink.org != "testorg" || sink.events[0].DeliveryID != "d-1") {
+				t.Errorf("sink got org %q, event %+v, want testorg's d-1", sink.org, sink.events[0])
+			}
+		})
+	}
+}
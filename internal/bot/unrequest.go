@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// isReviewAction reports whether a Turn action kind asks a user to review a PR.
+func isReviewAction(kind string) bool {
+	switch kind {
+	case "review", "re_review", "approve":
+		return true
+	default:
+		return false
+	}
+}
+
+// requestedReviewers returns the GitHub users a PR asks to review, sorted.
+func requestedReviewers(checkResp *CheckResponse) []string {
+	var reviewers []string
+	for _, username := range slices.Sorted(maps.Keys(checkResp.Analysis.NextAction)) {
+		if username != "_system" && isReviewAction(checkResp.Analysis.NextAction[username].Kind) {
+			reviewers = append(reviewers, username)
+		}
+	}
+	return reviewers
+}
+
+// lastRequestedReviewers returns the reviewers the PR last asked for, from the first of its
+// channels tracking it. Read before the channels are processed, since processing overwrites it.
+func (c *Coordinator) lastRequestedReviewers(ctx context.Context, owner, repo string, number int, channels []string) []string {
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		if info, ok := c.store.Thread(ctx, owner, repo, number, channelID); ok && len(info.RequestedReviewers) > 0 {
+			return info.RequestedReviewers
+		}
+	}
+	return nil
+}
+
+// noteRequestedReviewers records the reviewers a PR asks for on each of its channels' threads,
+// for the next event to diff against.
+func (c *Coordinator) noteRequestedReviewers(ctx context.Context, owner, repo string, number int, channels, reviewers []string) {
+	for _, channelName := range channels {
+		channelID := c.discord.ResolveChannelID(ctx, channelName)
+		if channelID == channelName {
+			continue
+		}
+		info, ok := c.store.Thread(ctx, owner, repo, number, channelID)
+		if !ok || slices.Equal(info.RequestedReviewers, reviewers) {
+			continue
+		}
+		info.RequestedReviewers = reviewers
+		if err := c.store.SaveThread(ctx, owner, repo, number, channelID, info); err != nil {
+			c.logger.Warn("failed to save requested reviewers", "error", err)
+		}
+	}
+}
+
+// notifyUnrequested DMs reviewers who were asked to review an open PR and no longer are,
+// without having reviewed it, so they can drop it from their queue. The channel message
+// already stops mentioning them, and a DM still queued for them is dropped. Reviewers who
+// left because they reviewed, or who are out of office, aren't notified.
+func (c *Coordinator) notifyUnrequested(
+	ctx context.Context,
+	event SprinklerEvent,
+	owner, repo string,
+	number int,
+	checkResp *CheckResponse,
+	lastReviewers []string,
+	prState format.PRState,
+) {
+	if prState == format.StateMerged || prState == format.StateClosed {
+		return
+	}
+
+	prURL := FormatPRURL(owner, repo, number)
+	for _, username := range lastReviewers {
+		if _, ok := checkResp.Analysis.NextAction[username]; ok {
+			continue
+		}
+		if _, reviewed := checkResp.PullRequest.Reviewers[username]; reviewed || username == checkResp.PullRequest.Author {
+			continue
+		}
+		if c.isOOO(ctx, username) {
+			continue
+		}
+		discordID := c.discordIDForUser(ctx, username)
+		if discordID == "" || c.shouldSkipDM(ctx, discordID, username) {
+			continue
+		}
+		// Another instance may have seen the same removal
+		if !c.store.ClaimEvent(ctx, EventKeyPrefix(prURL)+"unrequest-dm:"+username+":"+event.DeliveryID, eventDeduplicationTTL) {
+			continue
+		}
+		c.cancelPendingDM(ctx, discordID, prURL)
+
+		msg := format.DMMessage(format.ChannelMessageParams{
+			Owner:  owner,
+			Repo:   repo,
+			Number: number,
+			Title:  checkResp.PullRequest.Title,
+			Author: checkResp.PullRequest.Author,
+			State:  prState,
+			PRURL:  prURL,
+		}, "no longer requested")
+		if _, _, err := c.sendDM(ctx, discordID, msg); err != nil {
+			c.logger.Warn("failed to DM unrequested reviewer",
+				"error", err,
+				"user", username,
+				"pr_url", prURL)
+			continue
+		}
+		c.logger.Info("notified reviewer they're no longer requested",
+			"user", username,
+			"pr_url", prURL)
+	}
+}
+
+// cancelPendingDM drops a user's queued, not yet sent DM about a PR.
+func (c *Coordinator) cancelPendingDM(ctx context.Context, discordID, prURL string) {
+	pendingDMs, err := c.store.PendingDMs(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		c.logger.Warn("failed to get pending DMs for cancellation", "error", err)
+		return
+	}
+	for _, dm := range pendingDMs {
+		if dm.UserID != discordID || dm.PRURL != prURL {
+			continue
+		}
+		if err := c.store.RemovePendingDM(ctx, dm.ID); err != nil {
+			c.logger.Warn("failed to cancel pending DM",
+				"dm_id", dm.ID,
+				"pr_url", prURL,
+				"error", err)
+		}
+	}
+}
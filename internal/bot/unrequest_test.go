@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_ProcessEvent_UnrequestDM(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+	mapper := newMockUserMapper()
+	for _, user := range []string{"alice", "bob", "carol"} {
+		mapper.mappings[user] = "discord-" + user
+		discord.usersInGuild["discord-"+user] = true
+	}
+
+	configMgr := newMockConfigManager()
+	configMgr.dmOnUnrequest = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "Add feature", Author: "dave", State: "open"},
+		Analysis: Analysis{NextAction: map[string]Action{
+			"alice": {Kind: "review"},
+			"bob":   {Kind: "review"},
+			"carol": {Kind: "review"},
+		}},
+	}
+
+	store := state.NewMemoryStore()
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord:    discord,
+		Config:     configMgr,
+		Store:      store,
+		Turn:       turn,
+		UserMapper: mapper,
+		Org:        "testorg",
+	})
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+
+	// alice is unrequested; carol leaves the list by reviewing
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{
+			Title: "Add feature", Author: "dave", State: "open",
+			Reviewers: map[string]string{"carol": "commented"},
+		},
+		Analysis: Analysis{NextAction: map[string]Action{"bob": {Kind: "review"}}},
+	}
+	for _, id := range []string{"d-2", "d-3"} {
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: id})
+		coord.Wait()
+	}
+
+	unrequested := make(map[string]int)
+	for _, dm := range discord.sentDMs {
+		if strings.Contains(dm.text, "no longer requested") {
+			unrequested[dm.userID]++
+		}
+	}
+	if unrequested["discord-alice"] != 1 {
+		t.Errorf("unrequest DMs to alice = %d, want 1", unrequested["discord-alice"])
+	}
+	if unrequested["discord-carol"] != 0 || unrequested["discord-bob"] != 0 {
+		t.Errorf("unrequest DMs = %v, want none for reviewers who reviewed or are still requested", unrequested)
+	}
+
+	pending, err := store.PendingDMs(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	for _, dm := range pending {
+		if dm.UserID == "discord-alice" {
+			t.Errorf("pending DM for alice = %q, want it cancelled once unrequested", dm.MessageText)
+		}
+	}
+
+	if len(discord.updatedMessages) == 0 {
+		t.Fatal("updatedMessages = 0, want the channel message updated")
+	}
+	if text := discord.updatedMessages[len(discord.updatedMessages)-1].text; strings.Contains(text, "discord-alice") {
+		t.Errorf("channel message = %q, want alice no longer mentioned", text)
+	}
+}
+
+func TestRequestedReviewers(t *testing.T) {
+	checkResp := &CheckResponse{Analysis: Analysis{NextAction: map[string]Action{
+		"carol":   {Kind: "approve"},
+		"alice":   {Kind: "review"},
+		"dave":    {Kind: "fix_tests"},
+		"_system": {Kind: "review"},
+	}}}
+	if got := strings.Join(requestedReviewers(checkResp), ","); got != "alice,carol" {
+		t.Errorf("requestedReviewers() = %s, want alice,carol", got)
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// writeCooldownEvent is the event type replayed once a PR's write cooldown in a channel has passed.
+const writeCooldownEvent = "write_cooldown_elapsed"
+
+// writeCooldown spaces out writes to the same PR's message in a channel, so a misbehaving
+// upstream posting and editing in quick succession can't hammer Discord. Unlike the test
+// state debounce it doesn't look at what changed, only at when the last write happened.
+type writeCooldown struct {
+	last    map[string]time.Time   // channel ID/PR URL -> last write
+	pending map[string]*time.Timer // channel ID/PR URL -> timer that replays the PR once the cooldown passes
+	mu      sync.Mutex
+}
+
+func writeCooldownKey(channelID, prURL string) string {
+	return channelID + "/" + prURL
+}
+
+// deferWrite reports whether a write to a PR's message in a channel must wait because the
+// previous one was less than the org's write_cooldown ago. The first deferred write schedules
+// a replay of the PR for when the cooldown passes; later ones are coalesced into it, and the
+// replay renders the PR's latest state.
+func (c *Coordinator) deferWrite(ctx context.Context, channelID, prURL string) bool {
+	cooldown := c.config.WriteCooldown(c.org)
+	if cooldown <= 0 {
+		return false
+	}
+
+	w := &c.writeCooldown
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := writeCooldownKey(channelID, prURL)
+	wait := time.Until(w.last[key].Add(cooldown))
+	if wait <= 0 {
+		return false
+	}
+	if _, ok := w.pending[key]; ok {
+		c.logger.Debug("write within cooldown, coalescing into pending replay",
+			"channel_id", channelID,
+			"pr_url", prURL)
+		return true
+	}
+	if w.pending == nil {
+		w.pending = make(map[string]*time.Timer)
+	}
+
+	// Counted in wg until the replay is dispatched, so Wait covers pending replays
+	c.wg.Add(1)
+	w.pending[key] = time.AfterFunc(wait, func() {
+		defer c.wg.Done()
+		w.mu.Lock()
+		delete(w.pending, key)
+		w.mu.Unlock()
+
+		c.logger.Debug("write cooldown passed, replaying PR", "channel_id", channelID, "pr_url", prURL)
+		c.replayEvent(ctx, SprinklerEvent{
+			URL:        prURL,
+			Type:       writeCooldownEvent,
+			DeliveryID: "cooldown-" + strconv.FormatInt(time.Now().UnixNano(), 10),
+			Timestamp:  time.Now(),
+		})
+	})
+	c.logger.Info("write within cooldown, deferring",
+		"channel_id", channelID,
+		"pr_url", prURL,
+		"wait", wait)
+	return true
+}
+
+// noteWrite records a write to a PR's message in a channel, starting its cooldown.
+func (c *Coordinator) noteWrite(channelID, prURL string) {
+	cooldown := c.config.WriteCooldown(c.org)
+	if cooldown <= 0 {
+		return
+	}
+
+	w := &c.writeCooldown
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.last == nil {
+		w.last = make(map[string]time.Time)
+	}
+	now := time.Now()
+	for key, at := range w.last {
+		if now.Sub(at) >= cooldown {
+			delete(w.last, key)
+		}
+	}
+	w.last[writeCooldownKey(channelID, prURL)] = now
+}
@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+func TestCoordinator_WriteCooldownCoalescesWrites(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "First title", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 1}},
+	}
+
+	cfg := newMockConfigManager()
+	cfg.writeCooldown = 200 * time.Millisecond
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  cfg,
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	start := time.Now()
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	if len(discord.postedMessages) != 1 {
+		t.Fatalf("postedMessages = %d, want 1", len(discord.postedMessages))
+	}
+
+	// Two edits right after the post are held and coalesced into one replay
+	for i, title := range []string{"Second title", "Third title"} {
+		turn.responses[prURL].PullRequest.Title = title
+		coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: fmt.Sprintf("d-%d", i+2)})
+	}
+	// Wait covers the pending replay
+	coord.Wait()
+
+	if len(discord.updatedMessages) != 1 {
+		t.Fatalf("updatedMessages = %d, want the edits coalesced into 1", len(discord.updatedMessages))
+	}
+	if text := discord.updatedMessages[0].text; !strings.Contains(text, "Third title") {
+		t.Errorf("coalesced edit = %q, want the latest title", text)
+	}
+	if elapsed := time.Since(start); elapsed < cfg.writeCooldown {
+		t.Errorf("edit applied after %v, want at least the %v cooldown", elapsed, cfg.writeCooldown)
+	}
+}
+
+func TestCoordinator_WriteCooldownDisabled(t *testing.T) {
+	ctx := context.Background()
+	discord := newMockDiscordClient()
+	discord.channelIDs["testrepo"] = "chan-testrepo"
+	discord.botInChannel["chan-testrepo"] = true
+
+	prURL := "https://github.com/testorg/testrepo/pull/1"
+	turn := newMockTurnClient()
+	turn.responses[prURL] = &CheckResponse{
+		PullRequest: PRInfo{Title: "First title", Author: "alice", State: "open"},
+		Analysis:    Analysis{Checks: Checks{Passing: 1}},
+	}
+	coord := NewCoordinator(CoordinatorConfig{
+		Discord: discord,
+		Config:  newMockConfigManager(),
+		Store:   state.NewMemoryStore(),
+		Turn:    turn,
+		Org:     "testorg",
+	})
+
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-1"})
+	coord.Wait()
+	turn.responses[prURL].PullRequest.Title = "Second title"
+	coord.ProcessEvent(ctx, SprinklerEvent{URL: prURL, Type: "pull_request", DeliveryID: "d-2"})
+	coord.Wait()
+
+	if len(discord.updatedMessages) != 1 {
+		t.Errorf("updatedMessages = %d, want the edit applied right away", len(discord.updatedMessages))
+	}
+}
+
+func TestWriteCooldownKey_DistinctFromOtherFeatures(t *testing.T) {
+	keys := []string{
+		writeCooldownKey("chan-1", "https://github.com/o/r/pull/1"),
+		joinRetryKey("chan-1", "https://github.com/o/r/pull/1"),
+		recoverySearchKey("chan-1", "https://github.com/o/r/pull/1"),
+	}
+	if keys[0] == keys[1] || keys[0] == keys[2] || keys[1] == keys[2] {
+		t.Errorf("keys = %q, want one per feature", keys)
+	}
+}
@@ -2,16 +2,22 @@
 package config
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"path"
+	"regexp"
 	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/codeGROOVE-dev/retry"
 	"github.com/google/go-github/v50/github"
@@ -21,6 +27,12 @@ import (
 const (
 	defaultReminderDMDelayMinutes = 65
 	defaultConfigCacheTTL         = 20 * time.Minute
+	defaultTurnBurst              = 10
+	defaultBotJoinGrace           = 3 * time.Second
+	defaultHeartbeatInterval      = time.Hour
+	defaultTurnUnavailableMessage = "details unavailable, see GitHub"
+	defaultConflictResolvedNote   = "✅ conflict resolved"
+	defaultReviewReminderMax      = 3
 	maxRetryAttempts              = 5
 	retryDelay                    = time.Second
 	maxRetryDelay                 = 2 * time.Minute
@@ -35,7 +47,29 @@ type ServerConfig struct {
 	DiscordBotToken       string
 	GCPProject            string
 	Port                  string
+	APIToken              string        // Bearer token for the read-only state API; empty disables it
+	DeadLetterFile        string        // Append undeliverable DMs here as JSON lines; empty only logs them
+	UnparseableURLs       string        // Handling of events with unparseable URLs: "log", "drop" or "dead_letter"
+	UnparseableLogLevel   slog.Level    // Level events with unparseable URLs are logged at
+	DiscordOpenAttempts   int           // Attempts to open each guild's Discord connection at startup
+	DiscordShardCount     int           // Gateway shards; each guild connects as its own shard. 0 or 1 disables sharding
+	DiscordRequestRate    int           // Discord write requests per second across all guilds; 0 disables pacing
+	DiscordGuildWrites    int           // Discord write requests one guild may have in flight at once; 0 disables the cap
+	DiscordMemberBatch    int           // Members fetched per request when looking up users by name
+	DiscordMaxMembers     int           // Members scanned per user lookup; 0 scans the whole guild
+	ForumStarterAttempts  int           // Fetches of a new forum thread's starter message ID before leaving it to the next update
+	SearchCacheTTL        time.Duration // How long per-user PR search results are reused; 0 disables caching
+	MessagePreviewLength  int           // Characters of message content previewed in debug logs; 0 disables previews
+	MessagePreviewSample  int           // Preview 1 in this many messages in debug logs
+	MessagePreviewRedact  bool          // Preview only PR URLs, leaving PR titles out of logs
+	CommandScope          string        // Where slash commands are registered: "guild" (each guild, instant) or "global" (once)
+	ChannelRenames        string        // What a renamed channel's old name resolves to: "follow" (the channel) or "strict" (nothing)
+	DashboardMaxPRs       int           // PRs listed per /goose dash section before linking to the dashboard
 	AllowPersonalAccounts bool
+	DMDigest              bool          // Combine a user's due DMs into one message with a section per org
+	DMBurstWindow         time.Duration // Collapse a user's DMs queued within this window into one message; 0 disables
+	ClaimJitter           time.Duration // Random delay of up to this long before claiming each webhook event, so replicas don't claim it at once; 0 disables
+	DefaultGuildID        string        // Guild for orgs whose discord.yaml sets no guild_id; empty leaves them unrouted
 }
 
 // DiscordConfig represents the discord.yaml configuration for a GitHub org.
@@ -43,6 +77,7 @@ type DiscordConfig struct {
 	Channels map[string]ChannelConfig `yaml:"channels"`
 	Users    map[string]string        `yaml:"users"` // GitHub username -> Discord ID
 	Global   GlobalConfig             `yaml:"global"`
+	location *time.Location           // Global.Timezone, parsed when the config loads
 }
 
 // UserMappings returns the user mappings (for reverse lookup interface).
@@ -50,11 +85,197 @@ func (c *DiscordConfig) UserMappings() map[string]string {
 	return c.Users
 }
 
+// Features maps feature flag names to whether they're on for an org.
+type Features map[string]bool
+
+// Enabled reports whether the named feature is on. Unknown features are off.
+func (f Features) Enabled(name string) bool {
+	return f[name]
+}
+
 // GlobalConfig holds global settings for the org.
 type GlobalConfig struct {
 	GuildID         string `yaml:"guild_id"`
 	When            string `yaml:"when"`
 	ReminderDMDelay int    `yaml:"reminder_dm_delay"`
+	MinDMDelay      int    `yaml:"min_dm_delay"` // Minutes; applies even when the user wasn't tagged
+	// DMTemplates maps action kinds (e.g. "review", "fix_conflict") to text/template DM bodies.
+	DMTemplates map[string]string `yaml:"dm_templates"`
+	// SilentEdits flags message edits that don't mention anyone new as silent. Discord applies
+	// the flag to new posts only, so it doesn't change whether an edit notifies anyone.
+	// Defaults to true when unset.
+	SilentEdits *bool `yaml:"silent_edits"`
+	// NotifyOnTransitions lists the "from->to" state transitions, e.g. "needs_review->changes_requested",
+	// whose edits re-ping the PR's action users. When set, every other edit is silent.
+	NotifyOnTransitions []string `yaml:"notify_on_transitions"`
+	// DMOnClosedUnmerged DMs the author when their PR is closed without merging.
+	DMOnClosedUnmerged bool `yaml:"dm_on_closed_unmerged"`
+	// DMAuthorOnReview DMs the author as soon as a review approves or requests changes on their PR.
+	DMAuthorOnReview bool `yaml:"dm_author_on_review"`
+	// DMOnReopenedReview DMs the author when an approved PR gets changes requested, since
+	// the edit to their existing DM wouldn't notify them.
+	DMOnReopenedReview bool `yaml:"dm_on_reopened_review"`
+	// ConflictResolved announces a PR going from a merge conflict back to mergeable in its
+	// channels: "note" posts ConflictResolvedNote, "ping" also mentions the PR's approvers so
+	// they know it's ready again. Off when unset.
+	ConflictResolved string `yaml:"conflict_resolved"`
+	// ConflictResolvedNote is the note, emoji included, announcing a resolved conflict.
+	// Defaults to "✅ conflict resolved".
+	ConflictResolvedNote string `yaml:"conflict_resolved_note"`
+	// DMOnUnrequest DMs reviewers when they're no longer asked to review a PR they hadn't
+	// reviewed yet, so they can drop it from their queue.
+	DMOnUnrequest bool `yaml:"dm_on_unrequest"`
+	// DMEscalationAfter sends one follow-up DM, in stronger wording, to a user whose DM about a
+	// PR has gone unacknowledged this long while the PR still waits on them. 0 disables it.
+	DMEscalationAfter time.Duration `yaml:"dm_escalation_after"`
+	// ReviewReminderEvery DMs a reviewer again this often while a PR still waits on their
+	// review, up to ReviewReminderMax times (default 3). The count starts over when the PR's
+	// state changes. 0 disables recurring reminders.
+	ReviewReminderEvery time.Duration `yaml:"review_reminder_every"`
+	ReviewReminderMax   int           `yaml:"review_reminder_max"`
+	// SuppressLabels lists PR labels, such as "wip", that hold back a PR's posts and DMs
+	// until the label is removed. Matching ignores case.
+	SuppressLabels []string `yaml:"suppress_labels"`
+	// SystemIndicators lists the kinds of _system next actions, such as "processing", that show
+	// "analyzing…" on a PR's message while they're its only next action. Others show nothing.
+	SystemIndicators []string `yaml:"system_indicators"`
+	// SuppressSelfApproved holds back new posts and DMs for PRs ready for their author to merge
+	// with nobody else reviewing, as in solo maintainer repos where nobody else needs to hear
+	// about them. Messages already posted are still updated.
+	SuppressSelfApproved bool `yaml:"suppress_self_approved"`
+	// ArchivedRepos lists repos, by name, whose PRs get no posts or DMs, such as repos being
+	// wound down before they're archived on GitHub. Matching ignores case.
+	ArchivedRepos []string `yaml:"archived_repos"`
+	// SkipArchivedRepos also looks up whether each repo is archived or disabled on GitHub,
+	// and gives its PRs no posts or DMs if so.
+	SkipArchivedRepos bool `yaml:"skip_archived_repos"`
+	// ArchivedRepoNote, when set, is posted once to a skipped repo's channels, emoji included.
+	ArchivedRepoNote string `yaml:"archived_repo_note"`
+	// QueueDuringMaintenance replays PRs that changed during /goose maintenance once it's
+	// turned off. Otherwise their updates are dropped and picked up by the next poll.
+	QueueDuringMaintenance bool `yaml:"queue_during_maintenance"`
+	// ReviveReopenedThreads unarchives a reopened PR's forum thread and updates it in place.
+	// Defaults to true when unset.
+	ReviveReopenedThreads *bool `yaml:"revive_reopened_threads"`
+	// ReopenRepostAfter posts a fresh message for a PR reopened after being closed this long,
+	// rather than updating its old one far up the channel. 0 (the default) always updates.
+	ReopenRepostAfter time.Duration `yaml:"reopen_repost_after"`
+	// TurnUnavailableMessage is shown in place of a PR's state and actions when its details
+	// couldn't be fetched from Turn. Defaults to "details unavailable, see GitHub".
+	TurnUnavailableMessage string `yaml:"turn_unavailable_message"`
+	// TurnCallsPerMinute caps Turn API calls for the org; 0 means unlimited.
+	// TurnBurst is how many calls may be made back to back before the cap applies.
+	TurnCallsPerMinute int `yaml:"turn_calls_per_minute"`
+	TurnBurst          int `yaml:"turn_burst"`
+	// SkipTurnForDrafts renders draft PRs the poll finds from their search results instead of
+	// calling Turn. Webhook events, which don't say whether a PR is a draft, still call Turn.
+	SkipTurnForDrafts bool `yaml:"skip_turn_for_drafts"`
+	// ShowAssignees adds an "assigned to" line mentioning the PR's assignees.
+	ShowAssignees bool `yaml:"show_assignees"`
+	// ShowApprovals adds a line naming who has approved the PR and whose approval it still waits on.
+	ShowApprovals bool `yaml:"show_approvals"`
+	// ShowReviewRounds adds "round N" once a PR has gone back and forth between approved and
+	// changes requested, to signal churn.
+	ShowReviewRounds bool `yaml:"show_review_rounds"`
+	// ShowFailingChecks adds links to the first few failing CI checks for PRs with broken tests.
+	ShowFailingChecks bool `yaml:"show_failing_checks"`
+	// ShowCIStatus adds a 🟢/🟡/🔴 CI status after the PR link, whatever the PR's overall state.
+	ShowCIStatus bool `yaml:"show_ci_status"`
+	// ShowLinkedIssues adds a "closes #45, #46" line linking the issues the PR closes.
+	ShowLinkedIssues bool `yaml:"show_linked_issues"`
+	// ShowNonDefaultBase shows "→ branch" for PRs that don't target the repo's default branch.
+	ShowNonDefaultBase bool `yaml:"show_non_default_base"`
+	// DefaultBranch overrides the default branch looked up from GitHub for every repo in the org.
+	DefaultBranch string `yaml:"default_branch"`
+	// MaxEventAge marks older webhook events as stale: they update messages but send no DMs or pings.
+	MaxEventAge time.Duration `yaml:"max_event_age"`
+	// BackfillWindow has the bot, on startup, process PRs updated since the last event it saw
+	// before going down, looking back at most this far. 0 disables the backfill.
+	BackfillWindow time.Duration `yaml:"backfill_window"`
+	// TestStateDebounce holds back flips between tests running and tests broken until the
+	// PR's state has been stable this long, so CI reruns don't cause edit storms. 0 disables it.
+	TestStateDebounce time.Duration `yaml:"test_state_debounce"`
+	// PushBurstWindow coalesces a push to a PR and the check events that follow it within
+	// this long into a single render once it's passed. 0 disables it.
+	PushBurstWindow time.Duration `yaml:"push_burst_window"`
+	// MinEventsToPost holds back a new PR until it has had this many events, so PRs opened
+	// and closed straight away in high churn repos are never posted. 0 or 1 posts on the first.
+	MinEventsToPost int `yaml:"min_events_to_post"`
+	// WriteCooldown is the minimum time between two writes to the same PR's message in a
+	// channel. Writes arriving sooner are held and the latest is applied once it passes. 0 disables it.
+	WriteCooldown time.Duration `yaml:"write_cooldown"`
+	// BotJoinGrace is how long a channel's "bot not in channel" check is retried before the
+	// event is dropped, covering permission propagation after the bot joins. Negative disables it.
+	BotJoinGrace time.Duration `yaml:"bot_join_grace"`
+	// MappingConflictPolicy picks the winner when config and a /goose github-user link
+	// disagree: "config" (default), "storage", or "newest".
+	MappingConflictPolicy string `yaml:"mapping_conflict_policy"`
+	// MemberLeave is what happens to a user's self-service mapping when they leave the
+	// Discord server: "flag" (default) ignores it until they link again, "delete" removes
+	// it, and "keep" leaves it alone. Their queued DMs are dropped unless it's "keep".
+	MemberLeave string `yaml:"member_leave"`
+	// SelfMentions is what happens to mentions of the bot itself in PR titles and descriptions,
+	// which would otherwise have it ping itself: "escape" (default) shows them as plain text,
+	// and "strip" removes them.
+	SelfMentions string `yaml:"self_mentions"`
+	// SoloReviewer is how a PR waiting only on one user's review reaches them when they're the
+	// only person who can see its channel: "both" (default) pings them in the channel and DMs
+	// them, and "dm" leaves the channel ping out, so they just get the DM.
+	SoloReviewer string `yaml:"solo_reviewer"`
+	// OOOBackups maps GitHub usernames to the GitHub user who takes over their PR actions
+	// while they're out of office, as set with /goose ooo.
+	OOOBackups map[string]string `yaml:"ooo_backups"`
+	// AdminChannel receives config problems found at load, such as channels that don't exist.
+	AdminChannel string `yaml:"admin_channel"`
+	// DMFallbackChannel receives a mention in place of each DM to a user who doesn't accept
+	// DMs from the bot, so they're still reached.
+	DMFallbackChannel string `yaml:"dm_fallback_channel"`
+	// DashboardURLTemplate replaces the reviewGOOSE dashboard for orgs whose dashboard uses
+	// another path scheme, such as https://dash.example.com/{org}/{repo}/pull/{number}. {org},
+	// {repo} and {number} are filled in per PR. Org links use it only if it has no {repo} or
+	// {number}, and otherwise link the reviewGOOSE dashboard.
+	DashboardURLTemplate string `yaml:"dashboard_url_template"`
+	// ActivitySummary posts a daily summary of the org's PR activity to the admin channel.
+	ActivitySummary bool `yaml:"activity_summary"`
+	// PersistentReport edits one "your PR dashboard" DM per user in place for each daily
+	// report, instead of sending a new DM every day.
+	PersistentReport bool `yaml:"persistent_report"`
+	// DiscordNames shows mapped PR authors and reviewers by their Discord display name in
+	// /goose dash, instead of their GitHub login.
+	DiscordNames bool `yaml:"discord_names"`
+	// HeartbeatStaleAfter re-checks tracked open PRs with Turn once their messages haven't
+	// been updated for this long, catching state drift on PRs with no recent events.
+	// 0 (the default) disables the heartbeat.
+	HeartbeatStaleAfter time.Duration `yaml:"heartbeat_stale_after"`
+	// HeartbeatInterval is how often the heartbeat looks for stale PRs. Defaults to 1h.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	// Timezone is the IANA name, e.g. "America/New_York", of the org's timezone, used for
+	// post windows without their own timezone, for daily schedules, and for /goose ooo dates.
+	// Defaults to UTC.
+	Timezone string `yaml:"timezone"`
+	// FirehoseChannel receives every PR across the org's repos, linked as org/repo#123,
+	// in addition to their own channels. Channel filters still apply.
+	FirehoseChannel string `yaml:"firehose_channel"`
+	// TitleStyle restyles all-uppercase PR titles in channel messages: "none" (default),
+	// "sentence", or "title".
+	TitleStyle string `yaml:"title_style"`
+	// Teams maps team names to their members' GitHub usernames, for AuthorTeamChannels.
+	Teams map[string][]string `yaml:"teams"`
+	// AuthorTeamChannels maps team names to a channel that gets every PR authored by the
+	// team's members, in addition to the repo's own channels.
+	AuthorTeamChannels map[string]string `yaml:"author_team_channels"`
+	// RepoAliases maps renamed repos' old names to their current names, so events under
+	// either name share the same threads and DMs.
+	RepoAliases map[string]string `yaml:"repo_aliases"`
+	// RepoEmoji maps repo names to an emoji shown before their messages, so PRs from
+	// different repos in a shared channel are easy to tell apart. Entries that aren't a
+	// single emoji or a Discord custom emoji are dropped when the config loads.
+	RepoEmoji map[string]string `yaml:"repo_emoji"`
+	// RepoReviewChecklist maps repo names to the review checklist shown in their forum posts,
+	// replacing the channel's review_checklist. An empty list shows no checklist for the repo.
+	RepoReviewChecklist map[string][]string `yaml:"repo_review_checklist"`
+	// Features turns on behaviors that are being rolled out gradually, by name.
+	Features Features `yaml:"features"`
 }
 
 // ChannelConfig holds per-channel settings.
@@ -64,6 +285,137 @@ type ChannelConfig struct {
 	Type            string   `yaml:"type"`
 	Repos           []string `yaml:"repos"`
 	Mute            bool     `yaml:"mute"`
+	Crosspost       bool     `yaml:"crosspost"` // Publish new posts in announcement channels to followers
+	// MessagePrefix is prepended to every PR message in the channel, e.g. "[infra]".
+	MessagePrefix string `yaml:"message_prefix"`
+	// PrefixForumTitles also adds MessagePrefix to forum thread titles.
+	PrefixForumTitles bool `yaml:"prefix_forum_titles"`
+	// ShowOrg links PRs as org/repo#123, for channels that several orgs post to.
+	ShowOrg bool `yaml:"show_org"`
+	// PlainURL adds the PR's raw URL on its own line, for copying.
+	PlainURL bool `yaml:"plain_url"`
+	// DashboardFooter ends each PR message with a subtle link to the PR's org on the dashboard,
+	// or to the PR itself with a PR-shaped dashboard_url_template.
+	DashboardFooter bool `yaml:"dashboard_footer"`
+	// ShowBodySnippet quotes the start of the PR's description under it in forum posts.
+	// Text channels keep to one line per PR, so only forum posts and detail threads use it.
+	ShowBodySnippet bool `yaml:"show_body_snippet"`
+	// ReviewChecklist lists what reviewers should check, e.g. "docs updated", shown in forum
+	// posts as a static checklist. Text channels keep to one line per PR and skip it.
+	ReviewChecklist []string `yaml:"review_checklist"`
+	// DetailThread makes a text channel's message a compact summary line, with the PR's full
+	// details in a thread started on it. Both are updated as the PR changes.
+	DetailThread bool `yaml:"detail_thread"`
+	// AppendMode posts a new text channel message, linking to the previous one, each time the
+	// PR's state changes, instead of editing one message: an append-only log of the PR.
+	AppendMode bool `yaml:"append_mode"`
+	// SuppressStates are PR states the channel never shows, e.g. "tests_running": a PR entering
+	// one gets no post or edit, and its message catches up once it leaves.
+	SuppressStates []string `yaml:"suppress_states"`
+	// TitleStyle overrides the org's title_style for this channel.
+	TitleStyle string `yaml:"title_style"`
+	// Layout is "wide" (default) for one line per PR, or "narrow" for mobile-heavy channels:
+	// shorter titles, with who needs to act on a line of their own.
+	Layout string `yaml:"layout"`
+	// DigestInterval replaces live per-PR posts with one digest of changed PRs per interval.
+	// Only applies to text channels; 0 keeps live posts.
+	DigestInterval time.Duration `yaml:"digest_interval"`
+	// Board replaces live per-PR posts with one message listing the channel's open PRs, one
+	// compact line each with the most urgent first, edited as they change. Text channels only.
+	Board bool `yaml:"board"`
+	// BoardMaxPRs caps how many PRs a board lists; the rest are counted in a closing line
+	// linking the dashboard. 0 uses a default that keeps the board within one message.
+	BoardMaxPRs int `yaml:"board_max_prs"`
+	// MaxForumThreads caps active threads in a forum channel; at the cap, the oldest
+	// merged/closed thread is archived before a new one is created. 0 means no cap.
+	MaxForumThreads int `yaml:"max_forum_threads"`
+	// ArchiveDelay keeps a merged/closed PR's forum thread active this long before archiving it,
+	// so the final update stays visible. 0 archives right away.
+	ArchiveDelay time.Duration `yaml:"archive_delay"`
+	// DeleteOnClose removes a PR's message (or forum thread) once it is merged or closed.
+	DeleteOnClose bool `yaml:"delete_on_close"`
+	// PostWindow holds back posts and edits outside set hours until the window opens.
+	// Merged and closed PRs are posted right away. DMs aren't affected.
+	PostWindow *PostWindow `yaml:"post_window"`
+	// Escalation pings further targets the longer a PR waits for review.
+	Escalation []EscalationStep `yaml:"escalation"`
+	// TeamRole is a Discord role ID mentioned instead of individuals once a PR's
+	// action users exceed RoleMentionThreshold. Either being unset keeps individual mentions.
+	TeamRole             string `yaml:"team_role"`
+	RoleMentionThreshold int    `yaml:"role_mention_threshold"`
+	// OnlyAuthors limits the channel to PRs whose author matches one of these globs,
+	// e.g. "ext-*" for an external contributors channel. Empty allows every author.
+	OnlyAuthors []string `yaml:"only_authors"`
+	// IgnoreAuthors skips PRs whose author matches one of these globs, e.g. "dependabot*".
+	IgnoreAuthors []string `yaml:"ignore_authors"`
+}
+
+// AllowsAuthor reports whether the channel takes PRs by author, per OnlyAuthors and
+// IgnoreAuthors. Matching ignores case; malformed globs match nothing.
+func (c ChannelConfig) AllowsAuthor(author string) bool {
+	if len(c.OnlyAuthors) > 0 && !matchesAuthor(c.OnlyAuthors, author) {
+		return false
+	}
+	return !matchesAuthor(c.IgnoreAuthors, author)
+}
+
+func matchesAuthor(patterns []string, author string) bool {
+	author = strings.ToLower(author)
+	for _, pattern := range patterns {
+		if ok, err := path.Match(strings.ToLower(pattern), author); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// EscalationStep pings Target once a PR has needed review for After.
+// Target is "reviewers" (the PR's requested reviewers), a Discord mention
+// such as "<@&123>" for a role, or a GitHub username.
+type EscalationStep struct {
+	Target string        `yaml:"target"`
+	After  time.Duration `yaml:"after"`
+}
+
+// PostWindow is a daily window, such as business hours, during which a channel gets posts.
+type PostWindow struct {
+	Start    string `yaml:"start"`    // "09:00"
+	End      string `yaml:"end"`      // "17:00"; before Start for windows spanning midnight
+	Timezone string `yaml:"timezone"` // IANA name, e.g. "America/New_York"; defaults to the org's timezone
+	Weekdays bool   `yaml:"weekdays"` // Only open Monday through Friday
+
+	location *time.Location // Timezone, parsed when the config loads
+}
+
+// Open reports whether t falls inside the window. A window with unparseable times is always open,
+// so a typo can't silence a channel.
+func (w PostWindow) Open(t time.Time) bool {
+	start, errStart := time.Parse("15:04", w.Start)
+	end, errEnd := time.Parse("15:04", w.End)
+	if errStart != nil || errEnd != nil || start.Equal(end) {
+		return true
+	}
+	if w.location != nil {
+		t = t.In(w.location)
+	} else if loc, err := time.LoadLocation(w.Timezone); err == nil {
+		t = t.In(loc)
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	day := t.Weekday()
+	if from > to && minute < to {
+		// Early morning part of a window that began the day before
+		day = (day + 6) % 7
+	}
+	if w.Weekdays && (day == time.Saturday || day == time.Sunday) {
+		return false
+	}
+	if from < to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
 }
 
 type configCacheEntry struct {
@@ -122,17 +474,23 @@ func (c *configCache) stats() (hits, misses int64) {
 
 // Manager manages repository configurations.
 type Manager struct {
-	configs map[string]*DiscordConfig
-	clients map[string]any
-	cache   *configCache
-	mu      sync.RWMutex
+	configs  map[string]*DiscordConfig
+	clients  map[string]any
+	cache    *configCache
+	loadErrs map[string]error // org -> why its last load fell back to a previous or default config
+	// Guild for orgs whose discord.yaml sets no guild_id; empty leaves them unrouted
+	defaultGuildID string
+	guildWarned    map[string]bool // orgs already warned about having no guild
+	mu             sync.RWMutex
 }
 
 // New creates a new config manager.
 func New() *Manager {
 	return &Manager{
-		configs: make(map[string]*DiscordConfig),
-		clients: make(map[string]any),
+		configs:     make(map[string]*DiscordConfig),
+		clients:     make(map[string]any),
+		loadErrs:    make(map[string]error),
+		guildWarned: make(map[string]bool),
 		cache: &configCache{
 			entries: make(map[string]configCacheEntry),
 			ttl:     defaultConfigCacheTTL,
@@ -147,6 +505,14 @@ func (m *Manager) SetGitHubClient(org string, client any) {
 	m.clients[org] = client
 }
 
+// SetDefaultGuildID sets the guild used for orgs whose discord.yaml doesn't set guild_id.
+// Without one, those orgs get no coordinator, so nothing is posted or DMed for them.
+func (m *Manager) SetDefaultGuildID(guildID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultGuildID = guildID
+}
+
 func createDefaultConfig() *DiscordConfig {
 	return &DiscordConfig{
 		Global: GlobalConfig{
@@ -192,6 +558,7 @@ func (m *Manager) LoadConfig(ctx context.Context, org string) error {
 		// Check if we have a previous config to fall back to
 		m.mu.Lock()
 		previousCfg, hasPrevious := m.configs[org]
+		m.loadErrs[org] = err
 		m.mu.Unlock()
 
 		if hasPrevious {
@@ -208,9 +575,13 @@ func (m *Manager) LoadConfig(ctx context.Context, org string) error {
 			"reason", err)
 		cfg = createDefaultConfig()
 	}
+	m.resolveGuild(org, cfg)
 
 	m.mu.Lock()
 	m.configs[org] = cfg
+	if err == nil {
+		delete(m.loadErrs, org)
+	}
 	m.mu.Unlock()
 
 	m.cache.set(org, cfg)
@@ -300,10 +671,84 @@ func (*Manager) fetchConfig(ctx context.Context, client *github.Client, org stri
 	if err := yaml.Unmarshal([]byte(configContent), &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
+	dropInvalidRepoEmoji(org, &cfg)
+	loadTimezones(org, &cfg)
 
 	return &cfg, nil
 }
 
+// customEmojiPattern matches Discord custom emoji such as <:shipit:123456789012345678>.
+var customEmojiPattern = regexp.MustCompile(`^<a?:\w{2,32}:\d{17,20}>$`)
+
+// maxEmojiRunes allows for emoji built from several code points, such as flags,
+// skin tones, and ZWJ sequences.
+const maxEmojiRunes = 10
+
+// validEmoji reports whether s is a single Unicode emoji or a Discord custom emoji.
+func validEmoji(s string) bool {
+	if customEmojiPattern.MatchString(s) {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	if first == utf8.RuneError || !unicode.Is(unicode.So, first) || utf8.RuneCountInString(s) > maxEmojiRunes {
+		return false
+	}
+	return !strings.ContainsFunc(s, func(r rune) bool {
+		return r < utf8.RuneSelf || unicode.IsSpace(r)
+	})
+}
+
+// dropInvalidRepoEmoji removes repo_emoji entries that aren't emoji, so a typo shows up
+// in the logs rather than as stray text before every message.
+func dropInvalidRepoEmoji(org string, cfg *DiscordConfig) {
+	for repo, emoji := range cfg.Global.RepoEmoji {
+		if validEmoji(emoji) {
+			continue
+		}
+		slog.Warn("ignoring invalid repo_emoji entry",
+			"org", org,
+			"repo", repo,
+			"emoji", emoji)
+		delete(cfg.Global.RepoEmoji, repo)
+	}
+}
+
+// loadTimezones parses the org's timezone and those of its post windows once, as the config
+// loads. A timezone that isn't a known IANA name is cleared, so the org uses UTC, or a post
+// window the org's timezone, and the typo shows up once in the logs.
+func loadTimezones(org string, cfg *DiscordConfig) {
+	cfg.location = time.UTC
+	if cfg.Global.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Global.Timezone)
+		if err != nil {
+			slog.Warn("ignoring invalid timezone, using UTC",
+				"org", org,
+				"timezone", cfg.Global.Timezone,
+				"error", err)
+			cfg.Global.Timezone = ""
+		} else {
+			cfg.location = loc
+		}
+	}
+
+	for name, ch := range cfg.Channels {
+		if ch.PostWindow == nil || ch.PostWindow.Timezone == "" {
+			continue
+		}
+		loc, err := time.LoadLocation(ch.PostWindow.Timezone)
+		if err != nil {
+			slog.Warn("ignoring invalid post window timezone, using the org's",
+				"org", org,
+				"channel", name,
+				"timezone", ch.PostWindow.Timezone,
+				"error", err)
+			ch.PostWindow.Timezone = ""
+			continue
+		}
+		ch.PostWindow.location = loc
+	}
+}
+
 // Config returns the configuration for a GitHub org.
 func (m *Manager) Config(org string) (*DiscordConfig, bool) {
 	m.mu.RLock()
@@ -369,6 +814,40 @@ func (m *Manager) ChannelsForRepo(org, repo string) []string {
 	return channels
 }
 
+// ChannelsForAuthor returns the channels that get a PR because its author is on a team
+// listed in author_team_channels. Muted channels are left out.
+func (m *Manager) ChannelsForAuthor(org, author string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || author == "" {
+		return nil
+	}
+
+	var channels []string
+	for _, team := range slices.Sorted(maps.Keys(cfg.Global.AuthorTeamChannels)) {
+		isMember := slices.ContainsFunc(cfg.Global.Teams[team], func(member string) bool {
+			return strings.EqualFold(member, author)
+		})
+		channel := strings.ToLower(cfg.Global.AuthorTeamChannels[team])
+		if !isMember || channel == "" || slices.Contains(channels, channel) {
+			continue
+		}
+		muted := false
+		for channelName, channelCfg := range cfg.Channels {
+			if strings.EqualFold(channelName, channel) && channelCfg.Mute {
+				muted = true
+				break
+			}
+		}
+		if !muted {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
 // ChannelType returns the channel type ("forum" or "text").
 func (m *Manager) ChannelType(org, channel string) string {
 	m.mu.RLock()
@@ -385,8 +864,21 @@ func (m *Manager) ChannelType(org, channel string) string {
 	return "text"
 }
 
-// DiscordUserID returns the mapped Discord ID for a GitHub username.
-func (m *Manager) DiscordUserID(org, githubUsername string) string {
+// Crosspost reports whether new posts in an announcement channel should be
+// published to following servers.
+func (m *Manager) Crosspost(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].Crosspost
+}
+
+// MessagePrefix returns the text prepended to PR messages in a channel, or "" if none.
+func (m *Manager) MessagePrefix(org, channel string) string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -394,55 +886,169 @@ func (m *Manager) DiscordUserID(org, githubUsername string) string {
 	if !exists {
 		return ""
 	}
-	return cfg.Users[githubUsername]
+	return strings.TrimSpace(cfg.Channels[channel].MessagePrefix)
 }
 
-// ReminderDMDelay returns the DM delay in minutes for a channel.
-func (m *Manager) ReminderDMDelay(org, channel string) int {
+// RepoEmoji returns the emoji shown before a repo's messages, or "" if it has none.
+func (m *Manager) RepoEmoji(org, repo string) string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	cfg, exists := m.configs[org]
 	if !exists {
-		return defaultReminderDMDelayMinutes
+		return ""
 	}
+	return cfg.Global.RepoEmoji[repo]
+}
 
-	if channelCfg, ok := cfg.Channels[channel]; ok && channelCfg.ReminderDMDelay != nil {
-		return *channelCfg.ReminderDMDelay
+// TitleStyle returns how PR titles are restyled in a channel: "sentence", "title",
+// or "none" (default). A channel's title_style overrides the org's.
+func (m *Manager) TitleStyle(org, channel string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "none"
+	}
+	style := cfg.Channels[channel].TitleStyle
+	if style == "" {
+		style = cfg.Global.TitleStyle
 	}
+	switch style = strings.ToLower(strings.TrimSpace(style)); style {
+	case "sentence", "title":
+		return style
+	default:
+		return "none"
+	}
+}
 
-	if cfg.Global.ReminderDMDelay > 0 {
-		return cfg.Global.ReminderDMDelay
+// Layout returns how a channel's messages are laid out: "narrow" or "wide" (default).
+func (m *Manager) Layout(org, channel string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || !strings.EqualFold(strings.TrimSpace(cfg.Channels[channel].Layout), "narrow") {
+		return "wide"
 	}
-	return defaultReminderDMDelayMinutes
+	return "narrow"
 }
 
-// When returns the posting threshold for a channel.
-// Returns "immediate" (default), "assigned", "blocked", or "passing".
-func (m *Manager) When(org, channel string) string {
+// PrefixForumTitles reports whether a channel's message prefix also applies to forum thread titles.
+func (m *Manager) PrefixForumTitles(org, channel string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	cfg, exists := m.configs[org]
 	if !exists {
-		return "immediate" // Default
+		return false
 	}
+	return cfg.Channels[channel].PrefixForumTitles
+}
 
-	// Check for channel-specific override
-	if channelCfg, ok := cfg.Channels[channel]; ok && channelCfg.When != nil {
-		return *channelCfg.When
+// ShowOrg reports whether PR links in a channel include the org.
+func (m *Manager) ShowOrg(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
 	}
+	return cfg.Channels[channel].ShowOrg
+}
 
-	// Return global setting (or default if not set)
-	if cfg.Global.When != "" {
-		return cfg.Global.When
+// PlainURL reports whether PR messages in a channel include the PR's raw URL.
+func (m *Manager) PlainURL(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
 	}
+	return cfg.Channels[channel].PlainURL
+}
 
-	return "immediate" // Default
+// ShowBodySnippet reports whether forum posts and detail threads in a channel quote the start of the PR's description.
+func (m *Manager) ShowBodySnippet(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].ShowBodySnippet
 }
 
-// GuildID returns the guild ID for an organization.
-func (m *Manager) GuildID(org string) string {
+// ReviewChecklist returns the checklist shown in a forum channel's posts for a repo's PRs,
+// if it has one. A repo's repo_review_checklist overrides the channel's review_checklist.
+func (m *Manager) ReviewChecklist(org, channel, repo string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return nil
+	}
+	if items, ok := cfg.Global.RepoReviewChecklist[repo]; ok {
+		return items
+	}
+	return cfg.Channels[channel].ReviewChecklist
+}
+
+// DashboardFooter reports whether PR messages in a channel end with a dashboard link.
+func (m *Manager) DashboardFooter(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].DashboardFooter
+}
+
+// DetailThread reports whether a text channel posts compact summaries with a thread of details.
+func (m *Manager) DetailThread(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].DetailThread
+}
+
+// AppendMode reports whether a text channel posts a new message on each PR state change.
+func (m *Manager) AppendMode(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].AppendMode
+}
+
+// StateSuppressed reports whether a channel's suppress_states hides a PR state.
+func (m *Manager) StateSuppressed(org, channel, prState string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return slices.Contains(cfg.Channels[channel].SuppressStates, prState)
+}
+
+// DashboardURLTemplate returns the org's dashboard URL template, or "" to link the reviewGOOSE dashboard.
+func (m *Manager) DashboardURLTemplate(org string) string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -450,13 +1056,940 @@ func (m *Manager) GuildID(org string) string {
 	if !exists {
 		return ""
 	}
-	return cfg.Global.GuildID
+	return strings.TrimSpace(cfg.Global.DashboardURLTemplate)
 }
 
-// ReloadConfig reloads the configuration for an org.
-func (m *Manager) ReloadConfig(ctx context.Context, org string) error {
-	m.cache.invalidate(org)
-	return m.LoadConfig(ctx, org)
+// DigestInterval returns how often a channel gets a digest instead of live posts, or 0 for live posts.
+func (m *Manager) DigestInterval(org, channel string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return 0
+	}
+	return max(cfg.Channels[channel].DigestInterval, 0)
+}
+
+// Board reports whether a channel keeps one board message of its open PRs instead of live posts.
+func (m *Manager) Board(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Channels[channel].Board
+}
+
+// BoardMaxPRs returns how many PRs a channel's board lists, or 0 for the default.
+func (m *Manager) BoardMaxPRs(org, channel string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return 0
+	}
+	return max(cfg.Channels[channel].BoardMaxPRs, 0)
+}
+
+// DeleteOnClose reports whether a channel's PR messages are deleted once the PR is merged or closed.
+func (m *Manager) DeleteOnClose(org, channel string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return cfg.Channels[channel].DeleteOnClose
+}
+
+// AuthorAllowed reports whether a channel takes PRs by author, per its only_authors and
+// ignore_authors. Channels without filters take every author.
+func (m *Manager) AuthorAllowed(org, channel, author string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return true
+	}
+	return cfg.Channels[channel].AllowsAuthor(author)
+}
+
+// PostWindow returns a channel's posting window, if it has one.
+func (m *Manager) PostWindow(org, channel string) (PostWindow, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return PostWindow{}, false
+	}
+	window := cfg.Channels[channel].PostWindow
+	if window == nil || window.Start == "" || window.End == "" {
+		return PostWindow{}, false
+	}
+	w := *window
+	if w.Timezone == "" {
+		w.Timezone = cfg.Global.Timezone
+		w.location = cfg.location
+	}
+	return w, true
+}
+
+// MaxForumThreads returns the cap on active threads in a forum channel, or 0 for no cap.
+func (m *Manager) MaxForumThreads(org, channel string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return 0
+	}
+	return max(cfg.Channels[channel].MaxForumThreads, 0)
+}
+
+// ArchiveDelay returns how long a forum channel's merged/closed threads stay active before
+// they're archived, or 0 to archive right away.
+func (m *Manager) ArchiveDelay(org, channel string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return 0
+	}
+	return max(cfg.Channels[channel].ArchiveDelay, 0)
+}
+
+// Escalation returns a channel's review escalation ladder, ordered by After.
+// Steps without a target or delay are dropped.
+func (m *Manager) Escalation(org, channel string) []EscalationStep {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return nil
+	}
+	var steps []EscalationStep
+	for _, step := range cfg.Channels[channel].Escalation {
+		if step.After > 0 && strings.TrimSpace(step.Target) != "" {
+			steps = append(steps, step)
+		}
+	}
+	slices.SortStableFunc(steps, func(a, b EscalationStep) int { return cmp.Compare(a.After, b.After) })
+	return steps
+}
+
+// TeamRoleMention returns the role mention that replaces individual mentions in a channel
+// and how many action users a PR needs beyond which it applies. Returns "" if not configured.
+func (m *Manager) TeamRoleMention(org, channel string) (mention string, threshold int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "", 0
+	}
+	ch := cfg.Channels[channel]
+	role := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(ch.TeamRole), "<@&"), ">")
+	if role == "" || ch.RoleMentionThreshold <= 0 {
+		return "", 0
+	}
+	return "<@&" + role + ">", ch.RoleMentionThreshold
+}
+
+// DiscordUserID returns the mapped Discord ID for a GitHub username.
+func (m *Manager) DiscordUserID(org, githubUsername string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return cfg.Users[githubUsername]
+}
+
+// OOOBackup returns the GitHub user who covers for a user while they're out of office,
+// or "" if none is configured.
+func (m *Manager) OOOBackup(org, githubUsername string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return cfg.Global.OOOBackups[githubUsername]
+}
+
+// ReminderDMDelay returns the DM delay in minutes for a channel.
+func (m *Manager) ReminderDMDelay(org, channel string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return defaultReminderDMDelayMinutes
+	}
+
+	if channelCfg, ok := cfg.Channels[channel]; ok && channelCfg.ReminderDMDelay != nil {
+		return *channelCfg.ReminderDMDelay
+	}
+
+	if cfg.Global.ReminderDMDelay > 0 {
+		return cfg.Global.ReminderDMDelay
+	}
+	return defaultReminderDMDelayMinutes
+}
+
+// MinDMDelay returns the minimum delay in minutes before any DM is sent.
+// Unlike ReminderDMDelay, this applies to untagged users too. Returns 0 if unset.
+func (m *Manager) MinDMDelay(org string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.MinDMDelay < 0 {
+		return 0
+	}
+	return cfg.Global.MinDMDelay
+}
+
+// DMTemplate returns the custom DM template for an action kind.
+// Returns "" if none is configured, meaning the default DM format is used.
+func (m *Manager) DMTemplate(org, action string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return cfg.Global.DMTemplates[action]
+}
+
+// SilentEdits reports whether message edits should be flagged as silent.
+// Defaults to true.
+func (m *Manager) SilentEdits(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.SilentEdits == nil {
+		return true
+	}
+	return *cfg.Global.SilentEdits
+}
+
+// NotifyOnTransitions returns the "from->to" state transitions whose edits re-ping the
+// PR's action users, or nil if the org doesn't restrict re-pings to transitions.
+func (m *Manager) NotifyOnTransitions(org string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return nil
+	}
+	return cfg.Global.NotifyOnTransitions
+}
+
+// SuppressLabels returns the PR labels that hold back a PR's posts and DMs.
+func (m *Manager) SuppressLabels(org string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return nil
+	}
+	return cfg.Global.SuppressLabels
+}
+
+// SystemIndicator reports whether a _system next action of this kind shows "analyzing…"
+// on a PR's message. Matching ignores case.
+func (m *Manager) SystemIndicator(org, kind string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return slices.ContainsFunc(cfg.Global.SystemIndicators, func(k string) bool {
+		return strings.EqualFold(k, kind)
+	})
+}
+
+// SuppressSelfApproved reports whether PRs ready for their author to merge unreviewed get no new posts or DMs.
+func (m *Manager) SuppressSelfApproved(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.SuppressSelfApproved
+}
+
+// ArchivedRepo reports whether a repo is listed in archived_repos.
+func (m *Manager) ArchivedRepo(org, repo string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return false
+	}
+	return slices.ContainsFunc(cfg.Global.ArchivedRepos, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), repo)
+	})
+}
+
+// SkipArchivedRepos reports whether repos archived or disabled on GitHub get no posts or DMs.
+func (m *Manager) SkipArchivedRepos(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.SkipArchivedRepos
+}
+
+// ArchivedRepoNote returns the note posted once to a skipped archived repo's channels,
+// or "" to post nothing.
+func (m *Manager) ArchivedRepoNote(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return strings.TrimSpace(cfg.Global.ArchivedRepoNote)
+}
+
+// ReviveReopenedThreads reports whether a reopened PR's archived forum thread is unarchived
+// and updated in place. Defaults to true.
+func (m *Manager) ReviveReopenedThreads(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.ReviveReopenedThreads == nil {
+		return true
+	}
+	return *cfg.Global.ReviveReopenedThreads
+}
+
+// ReopenRepostAfter returns how long a PR must have been closed for a reopen to get a fresh
+// message instead of an update to its old one. Returns 0 (always update) if unset.
+func (m *Manager) ReopenRepostAfter(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.ReopenRepostAfter < 0 {
+		return 0
+	}
+	return cfg.Global.ReopenRepostAfter
+}
+
+// DMOnClosedUnmerged reports whether authors get a DM when their PR is closed without merging.
+func (m *Manager) DMOnClosedUnmerged(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.DMOnClosedUnmerged
+}
+
+// DMAuthorOnReview reports whether authors get a DM when a review approves or requests changes on their PR.
+func (m *Manager) DMAuthorOnReview(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.DMAuthorOnReview
+}
+
+// DMOnReopenedReview reports whether authors get a DM when their approved PR gets changes requested.
+func (m *Manager) DMOnReopenedReview(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.DMOnReopenedReview
+}
+
+// ConflictResolved returns how a PR's resolved merge conflict is announced: "note", "ping",
+// or "" (not at all) if unset or unrecognized.
+func (m *Manager) ConflictResolved(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	switch mode := strings.ToLower(strings.TrimSpace(cfg.Global.ConflictResolved)); mode {
+	case "note", "ping":
+		return mode
+	default:
+		return ""
+	}
+}
+
+// ConflictResolvedNote returns the note announcing a resolved conflict.
+// Defaults to "✅ conflict resolved".
+func (m *Manager) ConflictResolvedNote(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || strings.TrimSpace(cfg.Global.ConflictResolvedNote) == "" {
+		return defaultConflictResolvedNote
+	}
+	return strings.TrimSpace(cfg.Global.ConflictResolvedNote)
+}
+
+// DMOnUnrequest reports whether reviewers get a DM when they're no longer asked to review a PR.
+func (m *Manager) DMOnUnrequest(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.DMOnUnrequest
+}
+
+// DMEscalationAfter returns how long a DM can go unacknowledged before its one follow-up DM
+// is sent. Returns 0 (no follow-ups) if unset.
+func (m *Manager) DMEscalationAfter(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.DMEscalationAfter < 0 {
+		return 0
+	}
+	return cfg.Global.DMEscalationAfter
+}
+
+// ReviewReminders returns how often a reviewer is DMed again while a PR waits on their review,
+// and at most how many times. Returns 0 (no reminders) if unset.
+func (m *Manager) ReviewReminders(org string) (every time.Duration, maxCount int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.ReviewReminderEvery <= 0 {
+		return 0, 0
+	}
+	maxCount = cfg.Global.ReviewReminderMax
+	if maxCount <= 0 {
+		maxCount = defaultReviewReminderMax
+	}
+	return cfg.Global.ReviewReminderEvery, maxCount
+}
+
+// QueueDuringMaintenance reports whether PRs that changed during maintenance mode are replayed when it ends.
+func (m *Manager) QueueDuringMaintenance(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.QueueDuringMaintenance
+}
+
+// ShowNonDefaultBase reports whether messages show the base branch of PRs not targeting the default branch.
+func (m *Manager) ShowNonDefaultBase(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowNonDefaultBase
+}
+
+// DefaultBranch returns the org's configured default branch, or "" to look it up per repo.
+func (m *Manager) DefaultBranch(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return strings.TrimSpace(cfg.Global.DefaultBranch)
+}
+
+// TurnRateLimit returns the org's Turn API call budget.
+// perMinute is 0 when unlimited; burst defaults to defaultTurnBurst.
+func (m *Manager) TurnRateLimit(org string) (perMinute, burst int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.TurnCallsPerMinute <= 0 {
+		return 0, 0
+	}
+	burst = cfg.Global.TurnBurst
+	if burst <= 0 {
+		burst = defaultTurnBurst
+	}
+	return cfg.Global.TurnCallsPerMinute, burst
+}
+
+// SkipTurnForDrafts reports whether draft PRs found by the poll are rendered without calling Turn.
+func (m *Manager) SkipTurnForDrafts(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.SkipTurnForDrafts
+}
+
+// ShowAssignees reports whether PR messages list assignees separately from reviewers.
+func (m *Manager) ShowAssignees(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowAssignees
+}
+
+// ShowApprovals reports whether PR messages list who has approved and who still needs to.
+func (m *Manager) ShowApprovals(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowApprovals
+}
+
+// ShowReviewRounds reports whether PR messages count the review rounds a PR has been through.
+func (m *Manager) ShowReviewRounds(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowReviewRounds
+}
+
+// ShowFailingChecks returns whether messages for PRs with broken tests link their failing checks.
+func (m *Manager) ShowFailingChecks(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowFailingChecks
+}
+
+// ShowCIStatus returns whether messages show the PR's CI status inline after its link.
+func (m *Manager) ShowCIStatus(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowCIStatus
+}
+
+// Location returns the org's timezone, parsed when its config loaded, or UTC if it's unset
+// or not a known IANA name.
+func (m *Manager) Location(org string) *time.Location {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.location == nil {
+		return time.UTC
+	}
+	return cfg.location
+}
+
+// ShowLinkedIssues returns whether messages link the issues a PR closes.
+func (m *Manager) ShowLinkedIssues(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ShowLinkedIssues
+}
+
+// MaxEventAge returns the age beyond which webhook events are treated as stale.
+// Returns 0 (no limit) if unset.
+func (m *Manager) MaxEventAge(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.MaxEventAge < 0 {
+		return 0
+	}
+	return cfg.Global.MaxEventAge
+}
+
+// BackfillWindow returns how far back the startup backfill looks for PRs updated while the bot
+// was down. Returns 0 (no backfill) if unset.
+func (m *Manager) BackfillWindow(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.BackfillWindow < 0 {
+		return 0
+	}
+	return cfg.Global.BackfillWindow
+}
+
+// TestStateDebounce returns how long a tests running or tests broken state must be stable
+// before an existing message reflects it. Returns 0 (no debounce) if unset.
+func (m *Manager) TestStateDebounce(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.TestStateDebounce < 0 {
+		return 0
+	}
+	return cfg.Global.TestStateDebounce
+}
+
+// PushBurstWindow returns how long after a push to a PR its check events are coalesced
+// into one render. Returns 0 (no coalescing) if unset.
+func (m *Manager) PushBurstWindow(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.PushBurstWindow < 0 {
+		return 0
+	}
+	return cfg.Global.PushBurstWindow
+}
+
+// MinEventsToPost returns how many events a new PR needs before it's posted; at least 1.
+func (m *Manager) MinEventsToPost(org string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return 1
+	}
+	return max(cfg.Global.MinEventsToPost, 1)
+}
+
+// WriteCooldown returns the minimum time between two writes to the same PR's message in a
+// channel. Returns 0 (no cooldown) if unset.
+func (m *Manager) WriteCooldown(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.WriteCooldown < 0 {
+		return 0
+	}
+	return cfg.Global.WriteCooldown
+}
+
+// FeatureEnabled reports whether a feature flag is on for the org.
+func (m *Manager) FeatureEnabled(org, name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.Features.Enabled(name)
+}
+
+// BotJoinGrace returns how long to keep rechecking a channel the bot can't post to
+// before giving up on it. Defaults to 3s; returns 0 (no retries) if disabled.
+func (m *Manager) BotJoinGrace(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.BotJoinGrace == 0 {
+		return defaultBotJoinGrace
+	}
+	return max(cfg.Global.BotJoinGrace, 0)
+}
+
+// MappingConflictPolicy returns which user mapping source wins when config and
+// self-service links disagree: "config" (default), "storage", or "newest".
+func (m *Manager) MappingConflictPolicy(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "config"
+	}
+	switch p := cfg.Global.MappingConflictPolicy; p {
+	case "storage", "newest":
+		return p
+	case "", "config":
+		return "config"
+	default:
+		slog.Warn("invalid mapping_conflict_policy, using config", "org", org, "policy", p)
+		return "config"
+	}
+}
+
+// SelfMentions returns what to do with mentions of the bot in PR titles and descriptions:
+// "escape" (default) or "strip".
+func (m *Manager) SelfMentions(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "escape"
+	}
+	switch a := cfg.Global.SelfMentions; a {
+	case "strip":
+		return a
+	case "", "escape":
+		return "escape"
+	default:
+		slog.Warn("invalid self_mentions, using escape", "org", org, "self_mentions", a)
+		return "escape"
+	}
+}
+
+// SoloReviewer returns how to notify a sole reviewer who is their PR channel's only viewer:
+// "both" (default) or "dm".
+func (m *Manager) SoloReviewer(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "both"
+	}
+	switch p := cfg.Global.SoloReviewer; p {
+	case "dm":
+		return p
+	case "", "both":
+		return "both"
+	default:
+		slog.Warn("invalid solo_reviewer, using both", "org", org, "solo_reviewer", p)
+		return "both"
+	}
+}
+
+// MemberLeave returns what to do with a user's mapping when they leave the Discord server:
+// "flag" (default), "delete", or "keep".
+func (m *Manager) MemberLeave(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "flag"
+	}
+	switch a := cfg.Global.MemberLeave; a {
+	case "delete", "keep":
+		return a
+	case "", "flag":
+		return "flag"
+	default:
+		slog.Warn("invalid member_leave, using flag", "org", org, "member_leave", a)
+		return "flag"
+	}
+}
+
+// AdminChannel returns the channel that receives config problem reports, or "" if none.
+func (m *Manager) AdminChannel(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(cfg.Global.AdminChannel), "#")
+}
+
+// DMFallbackChannel returns the channel where users who block DMs are mentioned instead, or "" if none.
+func (m *Manager) DMFallbackChannel(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(cfg.Global.DMFallbackChannel), "#")
+}
+
+// FirehoseChannel returns the channel that receives every PR in the org, or "" if none.
+func (m *Manager) FirehoseChannel(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(cfg.Global.FirehoseChannel), "#"))
+}
+
+// ActivitySummary reports whether a daily summary of PR activity is posted to the org's admin channel.
+func (m *Manager) ActivitySummary(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.ActivitySummary
+}
+
+// PersistentReport reports whether daily reports edit each user's one report DM in place.
+func (m *Manager) PersistentReport(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.PersistentReport
+}
+
+// DiscordNames reports whether /goose dash shows mapped PR authors and reviewers by their Discord names.
+func (m *Manager) DiscordNames(org string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	return exists && cfg.Global.DiscordNames
+}
+
+// TurnUnavailableMessage returns the note shown on a PR's message when its details couldn't be
+// fetched from Turn. Defaults to "details unavailable, see GitHub".
+func (m *Manager) TurnUnavailableMessage(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || strings.TrimSpace(cfg.Global.TurnUnavailableMessage) == "" {
+		return defaultTurnUnavailableMessage
+	}
+	return strings.TrimSpace(cfg.Global.TurnUnavailableMessage)
+}
+
+// HeartbeatStaleAfter returns how long a tracked open PR's messages can go without an update
+// before the heartbeat re-checks it. Returns 0 (no heartbeat) if unset.
+func (m *Manager) HeartbeatStaleAfter(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.HeartbeatStaleAfter < 0 {
+		return 0
+	}
+	return cfg.Global.HeartbeatStaleAfter
+}
+
+// HeartbeatInterval returns how often the heartbeat looks for stale PRs. Defaults to 1h.
+func (m *Manager) HeartbeatInterval(org string) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists || cfg.Global.HeartbeatInterval <= 0 {
+		return defaultHeartbeatInterval
+	}
+	return cfg.Global.HeartbeatInterval
+}
+
+// CanonicalRepo returns the current name for a repo, following repo_aliases.
+// Repos without an alias are returned unchanged.
+func (m *Manager) CanonicalRepo(org, repo string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return repo
+	}
+	if canonical := cfg.Global.RepoAliases[repo]; canonical != "" {
+		return canonical
+	}
+	return repo
+}
+
+// When returns the posting threshold for a channel.
+// Returns "immediate" (default), "assigned", "blocked", or "passing".
+func (m *Manager) When(org, channel string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return "immediate" // Default
+	}
+
+	// Check for channel-specific override
+	if channelCfg, ok := cfg.Channels[channel]; ok && channelCfg.When != nil {
+		return *channelCfg.When
+	}
+
+	// Return global setting (or default if not set)
+	if cfg.Global.When != "" {
+		return cfg.Global.When
+	}
+
+	return "immediate" // Default
+}
+
+// GuildID returns the guild ID for an organization.
+func (m *Manager) GuildID(org string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cfg, exists := m.configs[org]
+	if !exists {
+		return ""
+	}
+	return cfg.Global.GuildID
+}
+
+// ReloadConfig reloads the configuration for an org.
+func (m *Manager) ReloadConfig(ctx context.Context, org string) error {
+	m.cache.invalidate(org)
+	return m.LoadConfig(ctx, org)
+}
+
+// resolveGuild routes an org whose discord.yaml sets no guild_id to the default guild, if
+// there is one, and otherwise warns once that the org is unrouted rather than on every load.
+func (m *Manager) resolveGuild(org string, cfg *DiscordConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cfg.Global.GuildID != "" {
+		delete(m.guildWarned, org)
+		return
+	}
+	cfg.Global.GuildID = m.defaultGuildID
+	if m.guildWarned[org] {
+		return
+	}
+	m.guildWarned[org] = true
+	if m.defaultGuildID != "" {
+		slog.Info("org has no guild_id, using the default guild",
+			"org", org,
+			"guild_id", m.defaultGuildID)
+		return
+	}
+	slog.Warn("org has no guild_id, so nothing will be posted or DMed for it; "+
+		"set global.guild_id in its .codeGROOVE/discord.yaml, or DEFAULT_GUILD_ID on the server",
+		"org", org)
+}
+
+// LoadError returns why an org's last config load failed, such as a missing file or invalid
+// YAML, or nil if it succeeded. A failed load keeps the previous config, so this is the only
+// sign that a config change didn't take effect.
+func (m *Manager) LoadError(org string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.loadErrs[org]
 }
 
 // CacheStats returns cache statistics.
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"slices"
@@ -11,6 +13,7 @@ import (
 	"time"
 
 	"github.com/google/go-github/v50/github"
+	"gopkg.in/yaml.v3"
 )
 
 func TestManager_ChannelsForRepo(t *testing.T) {
@@ -189,6 +192,1593 @@ func TestManager_ReminderDMDelay(t *testing.T) {
 	}
 }
 
+func TestManager_MinDMDelay(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{MinDMDelay: 5},
+	}
+	m.configs["negative"] = &DiscordConfig{
+		Global: GlobalConfig{MinDMDelay: -3},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := []struct {
+		name string
+		org  string
+		want int
+	}{
+		{"configured", "testorg", 5},
+		{"negative treated as zero", "negative", 0},
+		{"unset defaults to zero", "unset", 0},
+		{"unknown org", "unknownorg", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.MinDMDelay(tt.org); got != tt.want {
+				t.Errorf("MinDMDelay(%q) = %d, want %d", tt.org, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SilentEdits(t *testing.T) {
+	m := New()
+
+	disabled := false
+	m.configs["loud"] = &DiscordConfig{
+		Global: GlobalConfig{SilentEdits: &disabled},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if m.SilentEdits("loud") {
+		t.Error("SilentEdits(loud) = true, want false when disabled")
+	}
+	if !m.SilentEdits("unset") {
+		t.Error("SilentEdits(unset) = false, want true by default")
+	}
+	if !m.SilentEdits("unknownorg") {
+		t.Error("SilentEdits(unknownorg) = false, want true by default")
+	}
+}
+
+func TestManager_NotifyOnTransitions(t *testing.T) {
+	m := New()
+	m.configs["strict"] = &DiscordConfig{
+		Global: GlobalConfig{NotifyOnTransitions: []string{"needs_review->changes_requested"}},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if got := m.NotifyOnTransitions("strict"); len(got) != 1 || got[0] != "needs_review->changes_requested" {
+		t.Errorf("NotifyOnTransitions(strict) = %v, want [needs_review->changes_requested]", got)
+	}
+	if got := m.NotifyOnTransitions("unset"); got != nil {
+		t.Errorf("NotifyOnTransitions(unset) = %v, want nil", got)
+	}
+	if got := m.NotifyOnTransitions("unknownorg"); got != nil {
+		t.Errorf("NotifyOnTransitions(unknownorg) = %v, want nil", got)
+	}
+}
+
+func TestManager_TurnRateLimit(t *testing.T) {
+	m := New()
+
+	m.configs["limited"] = &DiscordConfig{
+		Global: GlobalConfig{TurnCallsPerMinute: 120, TurnBurst: 5},
+	}
+	m.configs["default-burst"] = &DiscordConfig{
+		Global: GlobalConfig{TurnCallsPerMinute: 60},
+	}
+	m.configs["unset"] = &DiscordConfig{
+		Global: GlobalConfig{TurnBurst: 5},
+	}
+
+	tests := []struct {
+		org       string
+		perMinute int
+		burst     int
+	}{
+		{"limited", 120, 5},
+		{"default-burst", 60, defaultTurnBurst},
+		{"unset", 0, 0},
+		{"unknownorg", 0, 0},
+	}
+	for _, tt := range tests {
+		perMinute, burst := m.TurnRateLimit(tt.org)
+		if perMinute != tt.perMinute || burst != tt.burst {
+			t.Errorf("TurnRateLimit(%s) = (%d, %d), want (%d, %d)", tt.org, perMinute, burst, tt.perMinute, tt.burst)
+		}
+	}
+}
+
+func TestManager_ShowNonDefaultBase(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowNonDefaultBase: true, DefaultBranch: "develop"},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowNonDefaultBase("enabled") {
+		t.Error("ShowNonDefaultBase(enabled) = false, want true")
+	}
+	if got := m.DefaultBranch("enabled"); got != "develop" {
+		t.Errorf("DefaultBranch(enabled) = %q, want develop", got)
+	}
+	if m.ShowNonDefaultBase("unset") || m.DefaultBranch("unset") != "" {
+		t.Error("unset org should not show base branches or override the default branch")
+	}
+	if m.ShowNonDefaultBase("unknownorg") || m.DefaultBranch("unknownorg") != "" {
+		t.Error("unknown org should use defaults")
+	}
+}
+
+func TestManager_SkipTurnForDrafts(t *testing.T) {
+	m := New()
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{SkipTurnForDrafts: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.SkipTurnForDrafts("enabled") {
+		t.Error("SkipTurnForDrafts(enabled) = false, want true")
+	}
+	if m.SkipTurnForDrafts("unset") || m.SkipTurnForDrafts("unknownorg") {
+		t.Error("SkipTurnForDrafts() should default to false")
+	}
+}
+
+func TestManager_ShowApprovals(t *testing.T) {
+	m := New()
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowApprovals: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowApprovals("enabled") {
+		t.Error("ShowApprovals(enabled) = false, want true")
+	}
+	if m.ShowApprovals("unset") || m.ShowApprovals("unknownorg") {
+		t.Error("ShowApprovals() should default to false")
+	}
+}
+
+func TestManager_ShowReviewRounds(t *testing.T) {
+	m := New()
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowReviewRounds: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowReviewRounds("enabled") {
+		t.Error("ShowReviewRounds(enabled) = false, want true")
+	}
+	if m.ShowReviewRounds("unset") || m.ShowReviewRounds("unknownorg") {
+		t.Error("ShowReviewRounds() should default to false")
+	}
+}
+
+func TestManager_ShowAssignees(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowAssignees: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowAssignees("enabled") {
+		t.Error("ShowAssignees(enabled) = false, want true")
+	}
+	if m.ShowAssignees("unset") {
+		t.Error("ShowAssignees(unset) = true, want false by default")
+	}
+	if m.ShowAssignees("unknownorg") {
+		t.Error("ShowAssignees(unknownorg) = true, want false")
+	}
+}
+
+func TestManager_ShowFailingChecks(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowFailingChecks: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowFailingChecks("enabled") {
+		t.Error("ShowFailingChecks(enabled) = false, want true")
+	}
+	if m.ShowFailingChecks("unset") {
+		t.Error("ShowFailingChecks(unset) = true, want false by default")
+	}
+	if m.ShowFailingChecks("unknownorg") {
+		t.Error("ShowFailingChecks(unknownorg) = true, want false")
+	}
+}
+
+func TestManager_DMOnClosedUnmerged(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{DMOnClosedUnmerged: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.DMOnClosedUnmerged("enabled") {
+		t.Error("DMOnClosedUnmerged(enabled) = false, want true")
+	}
+	if m.DMOnClosedUnmerged("unset") {
+		t.Error("DMOnClosedUnmerged(unset) = true, want false by default")
+	}
+	if m.DMOnClosedUnmerged("unknownorg") {
+		t.Error("DMOnClosedUnmerged(unknownorg) = true, want false")
+	}
+}
+
+func TestManager_SuppressSelfApproved(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{SuppressSelfApproved: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.SuppressSelfApproved("enabled") {
+		t.Error("SuppressSelfApproved(enabled) = false, want true")
+	}
+	if m.SuppressSelfApproved("unset") {
+		t.Error("SuppressSelfApproved(unset) = true, want false by default")
+	}
+}
+
+func TestManager_ShowCIStatus(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowCIStatus: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowCIStatus("enabled") {
+		t.Error("ShowCIStatus(enabled) = false, want true")
+	}
+	if m.ShowCIStatus("unset") {
+		t.Error("ShowCIStatus(unset) = true, want false by default")
+	}
+	if m.ShowCIStatus("unknownorg") {
+		t.Error("ShowCIStatus(unknownorg) = true, want false")
+	}
+}
+
+func TestManager_DMAuthorOnReview(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{DMAuthorOnReview: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.DMAuthorOnReview("enabled") {
+		t.Error("DMAuthorOnReview(enabled) = false, want true")
+	}
+	if m.DMAuthorOnReview("unset") {
+		t.Error("DMAuthorOnReview(unset) = true, want false by default")
+	}
+}
+
+func TestManager_ActivitySummary(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ActivitySummary: true, AdminChannel: "bot-admin"},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ActivitySummary("enabled") {
+		t.Error("ActivitySummary(enabled) = false, want true")
+	}
+	if m.ActivitySummary("unset") {
+		t.Error("ActivitySummary(unset) = true, want false by default")
+	}
+}
+
+func TestManager_PersistentReport(t *testing.T) {
+	m := New()
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{PersistentReport: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.PersistentReport("enabled") {
+		t.Error("PersistentReport(enabled) = false, want true")
+	}
+	if m.PersistentReport("unset") || m.PersistentReport("unknownorg") {
+		t.Error("PersistentReport() should default to false")
+	}
+}
+
+func TestManager_DiscordNames(t *testing.T) {
+	m := New()
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{DiscordNames: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.DiscordNames("enabled") {
+		t.Error("DiscordNames(enabled) = false, want true")
+	}
+	if m.DiscordNames("unset") || m.DiscordNames("unknownorg") {
+		t.Error("DiscordNames() should default to false")
+	}
+}
+
+func TestManager_DMFallbackChannel(t *testing.T) {
+	m := New()
+
+	m.configs["set"] = &DiscordConfig{
+		Global: GlobalConfig{DMFallbackChannel: "#pr-pings"},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if got := m.DMFallbackChannel("set"); got != "pr-pings" {
+		t.Errorf("DMFallbackChannel(set) = %q, want %q", got, "pr-pings")
+	}
+	if got := m.DMFallbackChannel("unset"); got != "" {
+		t.Errorf("DMFallbackChannel(unset) = %q, want none by default", got)
+	}
+}
+
+func TestManager_FirehoseChannel(t *testing.T) {
+	m := New()
+
+	m.configs["set"] = &DiscordConfig{
+		Global: GlobalConfig{FirehoseChannel: " #All-PRs "},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if got := m.FirehoseChannel("set"); got != "all-prs" {
+		t.Errorf("FirehoseChannel(set) = %q, want %q", got, "all-prs")
+	}
+	if got := m.FirehoseChannel("unset"); got != "" {
+		t.Errorf("FirehoseChannel(unset) = %q, want none by default", got)
+	}
+	if got := m.FirehoseChannel("missing"); got != "" {
+		t.Errorf("FirehoseChannel(missing) = %q, want none", got)
+	}
+}
+
+func TestManager_ShowLinkedIssues(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{ShowLinkedIssues: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.ShowLinkedIssues("enabled") {
+		t.Error("ShowLinkedIssues(enabled) = false, want true")
+	}
+	if m.ShowLinkedIssues("unset") {
+		t.Error("ShowLinkedIssues(unset) = true, want false by default")
+	}
+}
+
+func TestManager_DMOnReopenedReview(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{DMOnReopenedReview: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.DMOnReopenedReview("enabled") {
+		t.Error("DMOnReopenedReview(enabled) = false, want true")
+	}
+	if m.DMOnReopenedReview("unset") {
+		t.Error("DMOnReopenedReview(unset) = true, want false by default")
+	}
+}
+
+func TestManager_DMOnUnrequest(t *testing.T) {
+	m := New()
+
+	m.configs["enabled"] = &DiscordConfig{
+		Global: GlobalConfig{DMOnUnrequest: true},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.DMOnUnrequest("enabled") {
+		t.Error("DMOnUnrequest(enabled) = false, want true")
+	}
+	if m.DMOnUnrequest("unset") {
+		t.Error("DMOnUnrequest(unset) = true, want false by default")
+	}
+}
+
+func TestManager_OOOBackup(t *testing.T) {
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  ooo_backups:\n    alice: bob\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m := New()
+	m.configs["testorg"] = &cfg
+
+	if got := m.OOOBackup("testorg", "alice"); got != "bob" {
+		t.Errorf("OOOBackup(alice) = %q, want bob", got)
+	}
+	if got := m.OOOBackup("testorg", "carol"); got != "" {
+		t.Errorf("OOOBackup(carol) = %q, want none", got)
+	}
+	if got := m.OOOBackup("unknown", "alice"); got != "" {
+		t.Errorf("OOOBackup(unknown org) = %q, want none", got)
+	}
+}
+
+func TestManager_QueueDuringMaintenance(t *testing.T) {
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  queue_during_maintenance: true\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m := New()
+	m.configs["enabled"] = &cfg
+	m.configs["unset"] = &DiscordConfig{}
+
+	if !m.QueueDuringMaintenance("enabled") {
+		t.Error("QueueDuringMaintenance(enabled) = false, want true")
+	}
+	if m.QueueDuringMaintenance("unset") {
+		t.Error("QueueDuringMaintenance(unset) = true, want false by default")
+	}
+}
+
+func TestManager_SuppressLabels(t *testing.T) {
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  suppress_labels: [wip, do-not-merge]\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m := New()
+	m.configs["testorg"] = &cfg
+
+	if got := m.SuppressLabels("testorg"); !slices.Equal(got, []string{"wip", "do-not-merge"}) {
+		t.Errorf("SuppressLabels() = %v, want [wip do-not-merge]", got)
+	}
+	if got := m.SuppressLabels("unknownorg"); got != nil {
+		t.Errorf("SuppressLabels(unknownorg) = %v, want nil", got)
+	}
+}
+
+func TestManager_SystemIndicator(t *testing.T) {
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  system_indicators: [processing]\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m := New()
+	m.configs["testorg"] = &cfg
+
+	if !m.SystemIndicator("testorg", "Processing") {
+		t.Error("SystemIndicator(processing) = false, want true")
+	}
+	if m.SystemIndicator("testorg", "rate_limited") {
+		t.Error("SystemIndicator(rate_limited) = true, want false for unlisted kinds")
+	}
+	if m.SystemIndicator("unknownorg", "processing") {
+		t.Error("SystemIndicator(unknownorg) = true, want false")
+	}
+}
+
+func TestManager_MaxEventAge(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{MaxEventAge: 2 * time.Hour},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if got := m.MaxEventAge("testorg"); got != 2*time.Hour {
+		t.Errorf("MaxEventAge(testorg) = %v, want 2h", got)
+	}
+	if got := m.MaxEventAge("unset"); got != 0 {
+		t.Errorf("MaxEventAge(unset) = %v, want 0", got)
+	}
+	if got := m.MaxEventAge("unknownorg"); got != 0 {
+		t.Errorf("MaxEventAge(unknownorg) = %v, want 0", got)
+	}
+
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  max_event_age: 90m\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if cfg.Global.MaxEventAge != 90*time.Minute {
+		t.Errorf("parsed max_event_age = %v, want 90m", cfg.Global.MaxEventAge)
+	}
+}
+
+func TestManager_BackfillWindow(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{BackfillWindow: 12 * time.Hour},
+	}
+	m.configs["negative"] = &DiscordConfig{
+		Global: GlobalConfig{BackfillWindow: -time.Hour},
+	}
+	m.configs["unset"] = &DiscordConfig{}
+
+	if got := m.BackfillWindow("testorg"); got != 12*time.Hour {
+		t.Errorf("BackfillWindow(testorg) = %v, want 12h", got)
+	}
+	for _, org := range []string{"negative", "unset", "unknownorg"} {
+		if got := m.BackfillWindow(org); got != 0 {
+			t.Errorf("BackfillWindow(%s) = %v, want 0", org, got)
+		}
+	}
+}
+
+func TestFeatures_Enabled(t *testing.T) {
+	features := Features{"embeds": true, "buttons": false}
+
+	if !features.Enabled("embeds") {
+		t.Error("Enabled(embeds) = false, want true")
+	}
+	if features.Enabled("buttons") {
+		t.Error("Enabled(buttons) = true, want false")
+	}
+	if features.Enabled("board_mode") {
+		t.Error("Enabled(board_mode) = true, want false for unknown flag")
+	}
+	if Features(nil).Enabled("embeds") {
+		t.Error("nil Features.Enabled() = true, want false")
+	}
+}
+
+func TestManager_FeatureEnabled(t *testing.T) {
+	m := New()
+
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  features:\n    embeds: true\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m.configs["testorg"] = &cfg
+
+	if !m.FeatureEnabled("testorg", "embeds") {
+		t.Error("FeatureEnabled(testorg, embeds) = false, want true")
+	}
+	if m.FeatureEnabled("testorg", "buttons") {
+		t.Error("FeatureEnabled(testorg, buttons) = true, want false")
+	}
+	if m.FeatureEnabled("unknownorg", "embeds") {
+		t.Error("FeatureEnabled(unknownorg, embeds) = true, want false")
+	}
+}
+
+func TestPostWindow_Open(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// Wednesday 2026-01-07 and Saturday 2026-01-10
+	wed := func(hour, minute int) time.Time { return time.Date(2026, 1, 7, hour, minute, 0, 0, time.UTC) }
+	sat := func(hour, minute int) time.Time { return time.Date(2026, 1, 10, hour, minute, 0, 0, time.UTC) }
+
+	business := PostWindow{Start: "09:00", End: "17:00", Weekdays: true}
+	overnight := PostWindow{Start: "22:00", End: "06:00"}
+
+	tests := []struct {
+		name   string
+		window PostWindow
+		at     time.Time
+		want   bool
+	}{
+		{"business hours", business, wed(10, 30), true},
+		{"at start", business, wed(9, 0), true},
+		{"at end", business, wed(17, 0), false},
+		{"before start", business, wed(7, 59), false},
+		{"weekend", business, sat(10, 30), false},
+		{"weekend allowed", PostWindow{Start: "09:00", End: "17:00"}, sat(10, 30), true},
+		{"overnight late", overnight, wed(23, 0), true},
+		{"overnight early", overnight, wed(5, 0), true},
+		{"overnight daytime", overnight, wed(12, 0), false},
+		{"timezone", PostWindow{Start: "09:00", End: "17:00", Timezone: "America/New_York"}, time.Date(2026, 1, 7, 9, 30, 0, 0, ny), true},
+		{"timezone outside", PostWindow{Start: "09:00", End: "17:00", Timezone: "America/New_York"}, wed(10, 0), false},
+		{"invalid times always open", PostWindow{Start: "9am", End: "5pm"}, wed(3, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Open(tt.at); got != tt.want {
+				t.Errorf("Open(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelConfig_AllowsAuthor(t *testing.T) {
+	external := ChannelConfig{OnlyAuthors: []string{"ext-*"}}
+	noBots := ChannelConfig{IgnoreAuthors: []string{"dependabot*", "renovate*"}}
+	both := ChannelConfig{OnlyAuthors: []string{"ext-*"}, IgnoreAuthors: []string{"ext-bot*"}}
+
+	tests := []struct {
+		name    string
+		channel ChannelConfig
+		author  string
+		want    bool
+	}{
+		{"no filters", ChannelConfig{}, "alice", true},
+		{"only authors match", external, "ext-bob", true},
+		{"only authors ignore case", external, "EXT-Bob", true},
+		{"only authors no match", external, "alice", false},
+		{"ignored", noBots, "dependabot[bot]", false},
+		{"not ignored", noBots, "alice", true},
+		{"ignore wins over only", both, "ext-botty", false},
+		{"allowed by both", both, "ext-bob", true},
+		{"malformed glob matches nothing", ChannelConfig{OnlyAuthors: []string{"ext-["}}, "ext-[", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.channel.AllowsAuthor(tt.author); got != tt.want {
+				t.Errorf("AllowsAuthor(%q) = %v, want %v", tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_AuthorAllowed(t *testing.T) {
+	m := New()
+
+	var cfg DiscordConfig
+	yamlConfig := "channels:\n  external:\n    only_authors: [\"ext-*\"]\n"
+	if err := yaml.Unmarshal([]byte(yamlConfig), &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	m.configs["testorg"] = &cfg
+
+	if !m.AuthorAllowed("testorg", "external", "ext-bob") {
+		t.Error("AuthorAllowed(external, ext-bob) = false, want true")
+	}
+	if m.AuthorAllowed("testorg", "external", "alice") {
+		t.Error("AuthorAllowed(external, alice) = true, want false")
+	}
+	if !m.AuthorAllowed("testorg", "other", "alice") {
+		t.Error("AuthorAllowed(other, alice) = false, want true for channels without filters")
+	}
+	if !m.AuthorAllowed("unknownorg", "external", "alice") {
+		t.Error("AuthorAllowed(unknownorg) = false, want true")
+	}
+}
+
+func TestManager_PostWindow(t *testing.T) {
+	m := New()
+
+	var cfg DiscordConfig
+	yamlConfig := "channels:\n  standup:\n    post_window:\n      start: \"09:00\"\n      end: \"17:00\"\n      weekdays: true\n"
+	if err := yaml.Unmarshal([]byte(yamlConfig), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	m.configs["testorg"] = &cfg
+
+	window, ok := m.PostWindow("testorg", "standup")
+	if !ok || window.Start != "09:00" || window.End != "17:00" || !window.Weekdays {
+		t.Errorf("PostWindow(standup) = (%+v, %v), want 09:00-17:00 weekdays", window, ok)
+	}
+	if _, ok := m.PostWindow("testorg", "other"); ok {
+		t.Error("PostWindow(other) ok = true, want false")
+	}
+	if _, ok := m.PostWindow("unknownorg", "standup"); ok {
+		t.Error("PostWindow(unknownorg) ok = true, want false")
+	}
+
+	// Windows without their own timezone use the org's
+	cfg.Global.Timezone = "Europe/Berlin"
+	if window, _ := m.PostWindow("testorg", "standup"); window.Timezone != "Europe/Berlin" {
+		t.Errorf("PostWindow(standup).Timezone = %q, want the org's Europe/Berlin", window.Timezone)
+	}
+}
+
+func TestManager_Location(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	m := New()
+	for org, tz := range map[string]string{"valid": "America/New_York", "invalid": "Mars/Olympus_Mons", "unset": ""} {
+		cfg := &DiscordConfig{Global: GlobalConfig{Timezone: tz}}
+		loadTimezones(org, cfg)
+		m.configs[org] = cfg
+	}
+
+	if got := m.Location("valid"); got.String() != ny.String() {
+		t.Errorf("Location(valid) = %v, want %v", got, ny)
+	}
+	if got := m.Location("invalid"); got != time.UTC {
+		t.Errorf("Location(invalid) = %v, want UTC", got)
+	}
+	if got := m.Location("unset"); got != time.UTC {
+		t.Errorf("Location(unset) = %v, want UTC", got)
+	}
+	if got := m.Location("unknownorg"); got != time.UTC {
+		t.Errorf("Location(unknownorg) = %v, want UTC", got)
+	}
+}
+
+func TestLoadTimezones(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	invalid := &DiscordConfig{Global: GlobalConfig{Timezone: "Mars/Olympus_Mons"}}
+	loadTimezones("testorg", invalid)
+	if invalid.Global.Timezone != "" || invalid.location != time.UTC {
+		t.Errorf("Timezone = %q, location = %v, want invalid timezone dropped for UTC", invalid.Global.Timezone, invalid.location)
+	}
+
+	m := New()
+	valid := &DiscordConfig{
+		Global: GlobalConfig{Timezone: "Europe/Berlin"},
+		Channels: map[string]ChannelConfig{
+			"standup": {PostWindow: &PostWindow{Start: "09:00", End: "17:00"}},
+			"typo":    {PostWindow: &PostWindow{Start: "09:00", End: "17:00", Timezone: "Berlin"}},
+			"tokyo":   {PostWindow: &PostWindow{Start: "09:00", End: "17:00", Timezone: "Asia/Tokyo"}},
+		},
+	}
+	loadTimezones("testorg", valid)
+	m.configs["testorg"] = valid
+
+	if valid.Global.Timezone != "Europe/Berlin" || valid.location.String() != berlin.String() {
+		t.Errorf("Timezone = %q, location = %v, want valid timezone kept", valid.Global.Timezone, valid.location)
+	}
+	for channel, want := range map[string]string{"standup": "Europe/Berlin", "typo": "Europe/Berlin", "tokyo": "Asia/Tokyo"} {
+		window, _ := m.PostWindow("testorg", channel)
+		if window.location == nil || window.location.String() != want {
+			t.Errorf("PostWindow(%s) location = %v, want %s", channel, window.location, want)
+		}
+	}
+}
+
+func TestManager_BotJoinGrace(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{BotJoinGrace: 10 * time.Second}}
+	m.configs["disabled"] = &DiscordConfig{Global: GlobalConfig{BotJoinGrace: -time.Second}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]time.Duration{
+		"custom":     10 * time.Second,
+		"disabled":   0,
+		"unset":      defaultBotJoinGrace,
+		"unknownorg": defaultBotJoinGrace,
+	}
+	for org, want := range tests {
+		if got := m.BotJoinGrace(org); got != want {
+			t.Errorf("BotJoinGrace(%s) = %v, want %v", org, got, want)
+		}
+	}
+}
+
+func TestManager_WriteCooldown(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{WriteCooldown: 5 * time.Second}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{WriteCooldown: -time.Second}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]time.Duration{
+		"custom":     5 * time.Second,
+		"negative":   0,
+		"unset":      0,
+		"unknownorg": 0,
+	}
+	for org, want := range tests {
+		if got := m.WriteCooldown(org); got != want {
+			t.Errorf("WriteCooldown(%s) = %v, want %v", org, got, want)
+		}
+	}
+}
+
+func TestManager_PushBurstWindow(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{PushBurstWindow: 10 * time.Second}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{PushBurstWindow: -time.Second}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]time.Duration{
+		"custom":     10 * time.Second,
+		"negative":   0,
+		"unset":      0,
+		"unknownorg": 0,
+	}
+	for org, want := range tests {
+		if got := m.PushBurstWindow(org); got != want {
+			t.Errorf("PushBurstWindow(%s) = %v, want %v", org, got, want)
+		}
+	}
+}
+
+func TestManager_DMEscalationAfter(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{DMEscalationAfter: 4 * time.Hour}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{DMEscalationAfter: -time.Hour}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]time.Duration{
+		"custom":     4 * time.Hour,
+		"negative":   0,
+		"unset":      0,
+		"unknownorg": 0,
+	}
+	for org, want := range tests {
+		if got := m.DMEscalationAfter(org); got != want {
+			t.Errorf("DMEscalationAfter(%s) = %v, want %v", org, got, want)
+		}
+	}
+}
+
+func TestManager_ReviewReminders(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{ReviewReminderEvery: 8 * time.Hour, ReviewReminderMax: 5}}
+	m.configs["default_max"] = &DiscordConfig{Global: GlobalConfig{ReviewReminderEvery: 8 * time.Hour}}
+	m.configs["max_only"] = &DiscordConfig{Global: GlobalConfig{ReviewReminderMax: 5}}
+
+	tests := []struct {
+		org       string
+		wantEvery time.Duration
+		wantMax   int
+	}{
+		{org: "custom", wantEvery: 8 * time.Hour, wantMax: 5},
+		{org: "default_max", wantEvery: 8 * time.Hour, wantMax: 3},
+		{org: "max_only"},
+		{org: "unknownorg"},
+	}
+	for _, tt := range tests {
+		every, maxCount := m.ReviewReminders(tt.org)
+		if every != tt.wantEvery || maxCount != tt.wantMax {
+			t.Errorf("ReviewReminders(%s) = %v, %d, want %v, %d", tt.org, every, maxCount, tt.wantEvery, tt.wantMax)
+		}
+	}
+}
+
+func TestManager_ReopenRepostAfter(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{ReopenRepostAfter: 30 * 24 * time.Hour}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{ReopenRepostAfter: -time.Hour}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]time.Duration{
+		"custom":     30 * 24 * time.Hour,
+		"negative":   0,
+		"unset":      0,
+		"unknownorg": 0,
+	}
+	for org, want := range tests {
+		if got := m.ReopenRepostAfter(org); got != want {
+			t.Errorf("ReopenRepostAfter(%s) = %v, want %v", org, got, want)
+		}
+	}
+}
+
+func TestManager_ConflictResolved(t *testing.T) {
+	m := New()
+
+	m.configs["note"] = &DiscordConfig{Global: GlobalConfig{ConflictResolved: "note"}}
+	m.configs["ping"] = &DiscordConfig{Global: GlobalConfig{ConflictResolved: " Ping ", ConflictResolvedNote: "🧩 mergeable again"}}
+	m.configs["typo"] = &DiscordConfig{Global: GlobalConfig{ConflictResolved: "pings"}}
+
+	tests := []struct {
+		org      string
+		wantMode string
+		wantNote string
+	}{
+		{org: "note", wantMode: "note", wantNote: "✅ conflict resolved"},
+		{org: "ping", wantMode: "ping", wantNote: "🧩 mergeable again"},
+		{org: "typo", wantMode: "", wantNote: "✅ conflict resolved"},
+		{org: "unknownorg", wantMode: "", wantNote: "✅ conflict resolved"},
+	}
+	for _, tt := range tests {
+		if got := m.ConflictResolved(tt.org); got != tt.wantMode {
+			t.Errorf("ConflictResolved(%s) = %q, want %q", tt.org, got, tt.wantMode)
+		}
+		if got := m.ConflictResolvedNote(tt.org); got != tt.wantNote {
+			t.Errorf("ConflictResolvedNote(%s) = %q, want %q", tt.org, got, tt.wantNote)
+		}
+	}
+}
+
+func TestManager_TurnUnavailableMessage(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{TurnUnavailableMessage: " Turn is down, check GitHub "}}
+	m.configs["blank"] = &DiscordConfig{Global: GlobalConfig{TurnUnavailableMessage: "  "}}
+
+	tests := map[string]string{
+		"custom":     "Turn is down, check GitHub",
+		"blank":      "details unavailable, see GitHub",
+		"unknownorg": "details unavailable, see GitHub",
+	}
+	for org, want := range tests {
+		if got := m.TurnUnavailableMessage(org); got != want {
+			t.Errorf("TurnUnavailableMessage(%s) = %q, want %q", org, got, want)
+		}
+	}
+}
+
+func TestManager_Heartbeat(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{HeartbeatStaleAfter: 6 * time.Hour, HeartbeatInterval: 30 * time.Minute}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{HeartbeatStaleAfter: -time.Hour, HeartbeatInterval: -time.Hour}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]struct {
+		staleAfter time.Duration
+		interval   time.Duration
+	}{
+		"custom":     {staleAfter: 6 * time.Hour, interval: 30 * time.Minute},
+		"negative":   {staleAfter: 0, interval: time.Hour},
+		"unset":      {staleAfter: 0, interval: time.Hour},
+		"unknownorg": {staleAfter: 0, interval: time.Hour},
+	}
+	for org, want := range tests {
+		if got := m.HeartbeatStaleAfter(org); got != want.staleAfter {
+			t.Errorf("HeartbeatStaleAfter(%s) = %v, want %v", org, got, want.staleAfter)
+		}
+		if got := m.HeartbeatInterval(org); got != want.interval {
+			t.Errorf("HeartbeatInterval(%s) = %v, want %v", org, got, want.interval)
+		}
+	}
+}
+
+func TestManager_MinEventsToPost(t *testing.T) {
+	m := New()
+
+	m.configs["custom"] = &DiscordConfig{Global: GlobalConfig{MinEventsToPost: 2}}
+	m.configs["negative"] = &DiscordConfig{Global: GlobalConfig{MinEventsToPost: -1}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]int{
+		"custom":     2,
+		"negative":   1,
+		"unset":      1,
+		"unknownorg": 1,
+	}
+	for org, want := range tests {
+		if got := m.MinEventsToPost(org); got != want {
+			t.Errorf("MinEventsToPost(%s) = %d, want %d", org, got, want)
+		}
+	}
+}
+
+func TestManager_MappingConflictPolicy(t *testing.T) {
+	m := New()
+
+	m.configs["storage"] = &DiscordConfig{Global: GlobalConfig{MappingConflictPolicy: "storage"}}
+	m.configs["newest"] = &DiscordConfig{Global: GlobalConfig{MappingConflictPolicy: "newest"}}
+	m.configs["bogus"] = &DiscordConfig{Global: GlobalConfig{MappingConflictPolicy: "whoever"}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := []struct {
+		org  string
+		want string
+	}{
+		{"storage", "storage"},
+		{"newest", "newest"},
+		{"bogus", "config"},
+		{"unset", "config"},
+		{"unknownorg", "config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.org, func(t *testing.T) {
+			if got := m.MappingConflictPolicy(tt.org); got != tt.want {
+				t.Errorf("MappingConflictPolicy(%q) = %q, want %q", tt.org, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SelfMentions(t *testing.T) {
+	m := New()
+
+	m.configs["strip"] = &DiscordConfig{Global: GlobalConfig{SelfMentions: "strip"}}
+	m.configs["bogus"] = &DiscordConfig{Global: GlobalConfig{SelfMentions: "shout"}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]string{
+		"strip":      "strip",
+		"bogus":      "escape",
+		"unset":      "escape",
+		"unknownorg": "escape",
+	}
+	for org, want := range tests {
+		if got := m.SelfMentions(org); got != want {
+			t.Errorf("SelfMentions(%q) = %q, want %q", org, got, want)
+		}
+	}
+}
+
+func TestManager_MemberLeave(t *testing.T) {
+	m := New()
+
+	m.configs["delete"] = &DiscordConfig{Global: GlobalConfig{MemberLeave: "delete"}}
+	m.configs["keep"] = &DiscordConfig{Global: GlobalConfig{MemberLeave: "keep"}}
+	m.configs["bogus"] = &DiscordConfig{Global: GlobalConfig{MemberLeave: "ban"}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := []struct {
+		org  string
+		want string
+	}{
+		{"delete", "delete"},
+		{"keep", "keep"},
+		{"bogus", "flag"},
+		{"unset", "flag"},
+		{"unknownorg", "flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.org, func(t *testing.T) {
+			if got := m.MemberLeave(tt.org); got != tt.want {
+				t.Errorf("MemberLeave(%q) = %q, want %q", tt.org, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_CanonicalRepo(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{RepoAliases: map[string]string{"old-name": "new-name"}},
+	}
+
+	if got := m.CanonicalRepo("testorg", "old-name"); got != "new-name" {
+		t.Errorf("CanonicalRepo(old-name) = %q, want new-name", got)
+	}
+	if got := m.CanonicalRepo("testorg", "other"); got != "other" {
+		t.Errorf("CanonicalRepo(other) = %q, want other", got)
+	}
+	if got := m.CanonicalRepo("unknownorg", "old-name"); got != "old-name" {
+		t.Errorf("CanonicalRepo(unknownorg) = %q, want old-name", got)
+	}
+
+	var cfg DiscordConfig
+	if err := yaml.Unmarshal([]byte("global:\n  repo_aliases:\n    legacy: current\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if cfg.Global.RepoAliases["legacy"] != "current" {
+		t.Errorf("parsed repo_aliases = %v, want legacy: current", cfg.Global.RepoAliases)
+	}
+}
+
+func TestManager_Escalation(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"backend": {Escalation: []EscalationStep{
+				{After: 24 * time.Hour, Target: "lead"},
+				{After: time.Hour, Target: "reviewers"},
+				{After: 2 * time.Hour, Target: ""},
+				{Target: "<@&1>"},
+			}},
+		},
+	}
+
+	got := m.Escalation("testorg", "backend")
+	want := []EscalationStep{{After: time.Hour, Target: "reviewers"}, {After: 24 * time.Hour, Target: "lead"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Escalation(backend) = %v, want %v", got, want)
+	}
+	if got := m.Escalation("testorg", "other"); len(got) != 0 {
+		t.Errorf("Escalation(other) = %v, want none", got)
+	}
+	if got := m.Escalation("unknownorg", "backend"); len(got) != 0 {
+		t.Errorf("Escalation(unknownorg) = %v, want none", got)
+	}
+
+	var cfg DiscordConfig
+	yamlText := "channels:\n  backend:\n    escalation:\n      - after: 4h\n        target: reviewers\n"
+	if err := yaml.Unmarshal([]byte(yamlText), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if steps := cfg.Channels["backend"].Escalation; len(steps) != 1 || steps[0].After != 4*time.Hour || steps[0].Target != "reviewers" {
+		t.Errorf("parsed escalation = %v, want 4h reviewers", steps)
+	}
+}
+
+func TestManager_TeamRoleMention(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"backend":   {TeamRole: "123456", RoleMentionThreshold: 3},
+			"frontend":  {TeamRole: "<@&789>", RoleMentionThreshold: 2},
+			"no-role":   {RoleMentionThreshold: 3},
+			"no-thresh": {TeamRole: "123456"},
+		},
+	}
+
+	tests := []struct {
+		channel       string
+		wantMention   string
+		wantThreshold int
+	}{
+		{"backend", "<@&123456>", 3},
+		{"frontend", "<@&789>", 2},
+		{"no-role", "", 0},
+		{"no-thresh", "", 0},
+		{"other", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			mention, threshold := m.TeamRoleMention("testorg", tt.channel)
+			if mention != tt.wantMention || threshold != tt.wantThreshold {
+				t.Errorf("TeamRoleMention(%q) = (%q, %d), want (%q, %d)",
+					tt.channel, mention, threshold, tt.wantMention, tt.wantThreshold)
+			}
+		})
+	}
+}
+
+func TestManager_ChannelsForAuthor(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{
+			Teams: map[string][]string{
+				"platform": {"Alice", "bob"},
+				"security": {"alice"},
+				"quiet":    {"alice"},
+			},
+			AuthorTeamChannels: map[string]string{
+				"platform": "Platform-Reviews",
+				"security": "security-reviews",
+				"quiet":    "muted-reviews",
+				"nobody":   "empty-team",
+			},
+		},
+		Channels: map[string]ChannelConfig{
+			"muted-reviews": {Mute: true},
+		},
+	}
+
+	got := m.ChannelsForAuthor("testorg", "alice")
+	want := []string{"platform-reviews", "security-reviews"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ChannelsForAuthor(alice) = %v, want %v", got, want)
+	}
+	if got := m.ChannelsForAuthor("testorg", "bob"); !slices.Equal(got, []string{"platform-reviews"}) {
+		t.Errorf("ChannelsForAuthor(bob) = %v, want [platform-reviews]", got)
+	}
+	if got := m.ChannelsForAuthor("testorg", "carol"); got != nil {
+		t.Errorf("ChannelsForAuthor(carol) = %v, want nil", got)
+	}
+	if got := m.ChannelsForAuthor("unknownorg", "alice"); got != nil {
+		t.Errorf("ChannelsForAuthor(unknownorg) = %v, want nil", got)
+	}
+}
+
+func TestManager_ShowOrg(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"shared": {ShowOrg: true},
+		},
+	}
+
+	if !m.ShowOrg("testorg", "shared") {
+		t.Error("ShowOrg(shared) = false, want true")
+	}
+	if m.ShowOrg("testorg", "unconfigured") || m.ShowOrg("unknownorg", "shared") {
+		t.Error("ShowOrg() should default to false")
+	}
+}
+
+func TestManager_PlainURL(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"copyable": {PlainURL: true},
+		},
+	}
+
+	if !m.PlainURL("testorg", "copyable") {
+		t.Error("PlainURL(copyable) = false, want true")
+	}
+	if m.PlainURL("testorg", "unconfigured") || m.PlainURL("unknownorg", "copyable") {
+		t.Error("PlainURL() should default to false")
+	}
+}
+
+func TestManager_ShowBodySnippet(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"reviews": {ShowBodySnippet: true},
+		},
+	}
+
+	if !m.ShowBodySnippet("testorg", "reviews") {
+		t.Error("ShowBodySnippet(reviews) = false, want true")
+	}
+	if m.ShowBodySnippet("testorg", "unconfigured") || m.ShowBodySnippet("unknownorg", "reviews") {
+		t.Error("ShowBodySnippet() should default to false")
+	}
+}
+
+func TestManager_ReviewChecklist(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{
+			RepoReviewChecklist: map[string][]string{
+				"infra": {"plan reviewed"},
+				"docs":  {},
+			},
+		},
+		Channels: map[string]ChannelConfig{
+			"reviews": {ReviewChecklist: []string{"tests pass", "docs updated"}},
+		},
+	}
+
+	if got := m.ReviewChecklist("testorg", "reviews", "api"); !slices.Equal(got, []string{"tests pass", "docs updated"}) {
+		t.Errorf("ReviewChecklist(reviews, api) = %v, want the channel's items", got)
+	}
+	if got := m.ReviewChecklist("testorg", "reviews", "infra"); !slices.Equal(got, []string{"plan reviewed"}) {
+		t.Errorf("ReviewChecklist(reviews, infra) = %v, want the repo's items", got)
+	}
+	if got := m.ReviewChecklist("testorg", "unconfigured", "infra"); !slices.Equal(got, []string{"plan reviewed"}) {
+		t.Errorf("ReviewChecklist(unconfigured, infra) = %v, want the repo's items", got)
+	}
+	if got := m.ReviewChecklist("testorg", "reviews", "docs"); len(got) != 0 {
+		t.Errorf("ReviewChecklist(reviews, docs) = %v, want the repo's empty list to hide the channel's", got)
+	}
+	if m.ReviewChecklist("testorg", "unconfigured", "api") != nil || m.ReviewChecklist("unknownorg", "reviews", "api") != nil {
+		t.Error("ReviewChecklist() should default to none")
+	}
+}
+
+func TestManager_DetailThread(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"reviews": {DetailThread: true},
+		},
+	}
+
+	if !m.DetailThread("testorg", "reviews") {
+		t.Error("DetailThread(reviews) = false, want true")
+	}
+	if m.DetailThread("testorg", "unconfigured") || m.DetailThread("unknownorg", "reviews") {
+		t.Error("DetailThread() should default to false")
+	}
+}
+
+func TestManager_AppendMode(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"audit": {AppendMode: true},
+		},
+	}
+
+	if !m.AppendMode("testorg", "audit") {
+		t.Error("AppendMode(audit) = false, want true")
+	}
+	if m.AppendMode("testorg", "unconfigured") || m.AppendMode("unknownorg", "audit") {
+		t.Error("AppendMode() should default to false")
+	}
+}
+
+func TestManager_Board(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"open-prs": {Board: true},
+		},
+	}
+
+	if !m.Board("testorg", "open-prs") {
+		t.Error("Board(open-prs) = false, want true")
+	}
+	if m.Board("testorg", "unconfigured") || m.Board("unknownorg", "open-prs") {
+		t.Error("Board() should default to false")
+	}
+}
+
+func TestManager_BoardMaxPRs(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"open-prs": {Board: true, BoardMaxPRs: 5},
+			"negative": {Board: true, BoardMaxPRs: -1},
+		},
+	}
+
+	if got := m.BoardMaxPRs("testorg", "open-prs"); got != 5 {
+		t.Errorf("BoardMaxPRs(open-prs) = %d, want 5", got)
+	}
+	if got := m.BoardMaxPRs("testorg", "negative"); got != 0 {
+		t.Errorf("BoardMaxPRs(negative) = %d, want 0 (default)", got)
+	}
+	if got := m.BoardMaxPRs("unknownorg", "open-prs"); got != 0 {
+		t.Errorf("BoardMaxPRs(unknownorg) = %d, want 0", got)
+	}
+}
+
+func TestManager_SoloReviewer(t *testing.T) {
+	m := New()
+
+	m.configs["dm"] = &DiscordConfig{Global: GlobalConfig{SoloReviewer: "dm"}}
+	m.configs["bogus"] = &DiscordConfig{Global: GlobalConfig{SoloReviewer: "pigeon"}}
+	m.configs["unset"] = &DiscordConfig{}
+
+	tests := map[string]string{
+		"dm":         "dm",
+		"bogus":      "both",
+		"unset":      "both",
+		"unknownorg": "both",
+	}
+	for org, want := range tests {
+		if got := m.SoloReviewer(org); got != want {
+			t.Errorf("SoloReviewer(%q) = %q, want %q", org, got, want)
+		}
+	}
+}
+
+func TestManager_StateSuppressed(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"quiet": {SuppressStates: []string{"tests_running", "draft"}},
+		},
+	}
+
+	if !m.StateSuppressed("testorg", "quiet", "tests_running") {
+		t.Error("StateSuppressed(quiet, tests_running) = false, want true")
+	}
+	if m.StateSuppressed("testorg", "quiet", "needs_review") {
+		t.Error("StateSuppressed(quiet, needs_review) = true, want false")
+	}
+	if m.StateSuppressed("testorg", "unconfigured", "tests_running") || m.StateSuppressed("unknownorg", "quiet", "draft") {
+		t.Error("StateSuppressed() should default to false")
+	}
+}
+
+func TestManager_DashboardFooter(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"linked": {DashboardFooter: true},
+		},
+	}
+
+	if !m.DashboardFooter("testorg", "linked") {
+		t.Error("DashboardFooter(linked) = false, want true")
+	}
+	if m.DashboardFooter("testorg", "unconfigured") || m.DashboardFooter("unknownorg", "linked") {
+		t.Error("DashboardFooter() should default to false")
+	}
+}
+
+func TestManager_ArchivedRepos(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{
+			ArchivedRepos:     []string{" Old-API "},
+			SkipArchivedRepos: true,
+			ArchivedRepoNote:  " 📦 archived ",
+		},
+	}
+
+	if !m.ArchivedRepo("testorg", "old-api") {
+		t.Error("ArchivedRepo(old-api) = false, want true")
+	}
+	if m.ArchivedRepo("testorg", "api") {
+		t.Error("ArchivedRepo(api) = true, want false")
+	}
+	if m.ArchivedRepo("unknownorg", "old-api") {
+		t.Error("ArchivedRepo(unknownorg) = true, want false")
+	}
+	if !m.SkipArchivedRepos("testorg") || m.SkipArchivedRepos("unknownorg") {
+		t.Error("SkipArchivedRepos() should only be true where set")
+	}
+	if got := m.ArchivedRepoNote("testorg"); got != "📦 archived" {
+		t.Errorf("ArchivedRepoNote(testorg) = %q, want trimmed note", got)
+	}
+	if got := m.ArchivedRepoNote("unknownorg"); got != "" {
+		t.Errorf("ArchivedRepoNote(unknownorg) = %q, want empty", got)
+	}
+}
+
+func TestManager_DashboardURLTemplate(t *testing.T) {
+	m := New()
+	m.configs["templated"] = &DiscordConfig{
+		Global: GlobalConfig{DashboardURLTemplate: " https://dash.example.com/{org}/{repo}/pull/{number} "},
+	}
+	m.configs["plain"] = &DiscordConfig{}
+
+	if got, want := m.DashboardURLTemplate("templated"), "https://dash.example.com/{org}/{repo}/pull/{number}"; got != want {
+		t.Errorf("DashboardURLTemplate(templated) = %q, want %q", got, want)
+	}
+	if got := m.DashboardURLTemplate("plain"); got != "" {
+		t.Errorf("DashboardURLTemplate(plain) = %q, want empty", got)
+	}
+	if got := m.DashboardURLTemplate("unknownorg"); got != "" {
+		t.Errorf("DashboardURLTemplate(unknownorg) = %q, want empty", got)
+	}
+}
+
+func TestManager_Crosspost(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"announcements": {Crosspost: true},
+			"general":       {},
+		},
+	}
+
+	if !m.Crosspost("testorg", "announcements") {
+		t.Error("Crosspost(announcements) = false, want true")
+	}
+	if m.Crosspost("testorg", "general") {
+		t.Error("Crosspost(general) = true, want false")
+	}
+	if m.Crosspost("testorg", "unconfigured") || m.Crosspost("unknownorg", "announcements") {
+		t.Error("Crosspost() should default to false")
+	}
+}
+
+func TestManager_MessagePrefix(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"infra":   {MessagePrefix: " [infra] ", PrefixForumTitles: true},
+			"general": {},
+		},
+	}
+
+	if got := m.MessagePrefix("testorg", "infra"); got != "[infra]" {
+		t.Errorf("MessagePrefix(infra) = %q, want %q", got, "[infra]")
+	}
+	if !m.PrefixForumTitles("testorg", "infra") {
+		t.Error("PrefixForumTitles(infra) = false, want true")
+	}
+	if got := m.MessagePrefix("testorg", "general"); got != "" {
+		t.Errorf("MessagePrefix(general) = %q, want empty", got)
+	}
+	if m.MessagePrefix("unknownorg", "infra") != "" || m.PrefixForumTitles("unknownorg", "infra") {
+		t.Error("unknown org should have no prefix")
+	}
+}
+
+func TestValidEmoji(t *testing.T) {
+	tests := []struct {
+		emoji string
+		want  bool
+	}{
+		{"🚀", true},
+		{"🛰️", true},
+		{"👩🏽‍💻", true},
+		{"🇺🇸", true},
+		{"✅", true},
+		{"<:shipit:123456789012345678>", true},
+		{"<a:party:123456789012345678>", true},
+		{"", false},
+		{"api", false},
+		{":rocket:", false},
+		{"🚀 api", false},
+		{"🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀", false},
+		{"<:shipit:123>", false},
+	}
+	for _, tt := range tests {
+		if got := validEmoji(tt.emoji); got != tt.want {
+			t.Errorf("validEmoji(%q) = %v, want %v", tt.emoji, got, tt.want)
+		}
+	}
+}
+
+func TestManager_RepoEmoji(t *testing.T) {
+	var cfg DiscordConfig
+	yamlText := "global:\n  repo_emoji:\n    api: \"🚀\"\n    web: \":globe:\"\n"
+	if err := yaml.Unmarshal([]byte(yamlText), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	dropInvalidRepoEmoji("testorg", &cfg)
+
+	m := New()
+	m.configs["testorg"] = &cfg
+
+	if got := m.RepoEmoji("testorg", "api"); got != "🚀" {
+		t.Errorf("RepoEmoji(api) = %q, want 🚀", got)
+	}
+	if got := m.RepoEmoji("testorg", "web"); got != "" {
+		t.Errorf("RepoEmoji(web) = %q, want invalid entry dropped", got)
+	}
+	if got := m.RepoEmoji("testorg", "docs"); got != "" {
+		t.Errorf("RepoEmoji(docs) = %q, want none for an unconfigured repo", got)
+	}
+	if got := m.RepoEmoji("unknownorg", "api"); got != "" {
+		t.Errorf("RepoEmoji(unknownorg) = %q, want none", got)
+	}
+}
+
+func TestManager_TitleStyle(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{TitleStyle: "sentence"},
+		Channels: map[string]ChannelConfig{
+			"design": {TitleStyle: " Title "},
+			"raw":    {TitleStyle: "none"},
+			"bogus":  {TitleStyle: "shouty"},
+		},
+	}
+
+	tests := []struct {
+		org, channel, want string
+	}{
+		{"testorg", "general", "sentence"},
+		{"testorg", "design", "title"},
+		{"testorg", "raw", "none"},
+		{"testorg", "bogus", "none"},
+		{"unknownorg", "general", "none"},
+	}
+	for _, tt := range tests {
+		if got := m.TitleStyle(tt.org, tt.channel); got != tt.want {
+			t.Errorf("TitleStyle(%s, %s) = %q, want %q", tt.org, tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestManager_Layout(t *testing.T) {
+	m := New()
+	m.configs["testorg"] = &DiscordConfig{
+		Channels: map[string]ChannelConfig{
+			"mobile": {Layout: " Narrow "},
+			"desk":   {Layout: "wide"},
+			"bogus":  {Layout: "tiny"},
+		},
+	}
+
+	tests := []struct {
+		org, channel, want string
+	}{
+		{"testorg", "mobile", "narrow"},
+		{"testorg", "desk", "wide"},
+		{"testorg", "bogus", "wide"},
+		{"testorg", "general", "wide"},
+		{"unknownorg", "mobile", "wide"},
+	}
+	for _, tt := range tests {
+		if got := m.Layout(tt.org, tt.channel); got != tt.want {
+			t.Errorf("Layout(%s, %s) = %q, want %q", tt.org, tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestManager_DMTemplate(t *testing.T) {
+	m := New()
+
+	m.configs["testorg"] = &DiscordConfig{
+		Global: GlobalConfig{
+			DMTemplates: map[string]string{"fix_conflict": "fix {{.PRURL}}"},
+		},
+	}
+
+	if got := m.DMTemplate("testorg", "fix_conflict"); got != "fix {{.PRURL}}" {
+		t.Errorf("DMTemplate(fix_conflict) = %q, want configured template", got)
+	}
+	if got := m.DMTemplate("testorg", "review"); got != "" {
+		t.Errorf("DMTemplate(review) = %q, want empty", got)
+	}
+	if got := m.DMTemplate("unknownorg", "fix_conflict"); got != "" {
+		t.Errorf("DMTemplate() for unknown org = %q, want empty", got)
+	}
+}
+
 func TestManager_GuildID(t *testing.T) {
 	m := New()
 
@@ -529,6 +2119,9 @@ users:
 	if cfg.Users["alice"] != "111111111" {
 		t.Errorf("Users[alice] = %q, want %q", cfg.Users["alice"], "111111111")
 	}
+	if err := m.LoadError("testorg"); err != nil {
+		t.Errorf("LoadError() = %v, want nil", err)
+	}
 }
 
 func TestManager_LoadConfig_NotFound(t *testing.T) {
@@ -597,6 +2190,9 @@ func TestManager_LoadConfig_InvalidYAML(t *testing.T) {
 	if cfg.Global.ReminderDMDelay != 65 {
 		t.Errorf("Default ReminderDMDelay = %d, want 65", cfg.Global.ReminderDMDelay)
 	}
+	if err := m.LoadError("testorg"); err == nil || !strings.Contains(err.Error(), "parse YAML") {
+		t.Errorf("LoadError() = %v, want YAML parse error", err)
+	}
 }
 
 func TestManager_fetchConfig_EmptyContent(t *testing.T) {
@@ -744,3 +2340,60 @@ func TestManager_When(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestManager_LoadConfig_MissingGuildID(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultGuild string
+		wantGuild    string
+		wantLog      string
+		missing      bool // No discord.yaml, so the org falls back to the default config
+	}{
+		{name: "warns once", wantLog: "level=WARN msg=\"org has no guild_id"},
+		{name: "routes to the default guild", defaultGuild: "999", wantGuild: "999", wantLog: "using the default guild"},
+		{name: "warns once without a config", wantLog: "level=WARN msg=\"org has no guild_id", missing: true},
+		{name: "routes to the default guild without a config", defaultGuild: "999", wantGuild: "999", wantLog: "using the default guild", missing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			mux.HandleFunc("/repos/testorg/.codeGROOVE/contents/discord.yaml", func(w http.ResponseWriter, r *http.Request) {
+				if tt.missing {
+					http.NotFound(w, r)
+					return
+				}
+				encoded := base64.StdEncoding.EncodeToString([]byte("channels:\n  general:\n    repos: [\"*\"]\n"))
+				w.Header().Set("Content-Type", "application/json")
+				//nolint:errcheck // test handler
+				w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "` + encoded + `"}`))
+			})
+
+			var logs bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+			defer slog.SetDefault(previous)
+
+			m := New()
+			m.SetGitHubClient("testorg", newTestGitHubClient(t, server.URL))
+			m.SetDefaultGuildID(tt.defaultGuild)
+			for range 2 {
+				if err := m.ReloadConfig(context.Background(), "testorg"); err != nil {
+					t.Fatalf("ReloadConfig() error = %v", err)
+				}
+			}
+
+			if got := m.GuildID("testorg"); got != tt.wantGuild {
+				t.Errorf("GuildID() = %q, want %q", got, tt.wantGuild)
+			}
+			if n := strings.Count(logs.String(), "org has no guild_id"); n != 1 {
+				t.Errorf("logged %d guild_id messages over two loads, want 1:\n%s", n, logs.String())
+			}
+			if !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("logs = %s, want %q", logs.String(), tt.wantLog)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ type StateStore interface {
 // DiscordDMSender sends DMs to users.
 type DiscordDMSender interface {
 	SendDM(ctx context.Context, userID, text string) (channelID, messageID string, err error)
+	UpdateDM(ctx context.Context, channelID, messageID, newText string) error
 }
 
 // Sender handles sending daily reports to users.
@@ -43,6 +44,7 @@ type Sender struct {
 	stateStore StateStore
 	dmSenders  map[string]DiscordDMSender // guildID -> sender
 	logger     *slog.Logger
+	persistent bool // Edit each user's last report DM in place rather than sending a new one
 }
 
 // NewSender creates a new daily report sender.
@@ -62,6 +64,13 @@ func (s *Sender) RegisterGuild(guildID string, sender DiscordDMSender) {
 	s.dmSenders[guildID] = sender
 }
 
+// SetPersistent has reports edit each user's last report DM in place, so they keep one
+// up-to-date dashboard message, rather than sending a new DM each time. A user whose last
+// report DM can't be edited, e.g. because they deleted it, gets a new one.
+func (s *Sender) SetPersistent(persistent bool) {
+	s.persistent = persistent
+}
+
 // ShouldSendReport determines if a report should be sent to a user now.
 // Note: Caller should check if user is active before calling this.
 func (s *Sender) ShouldSendReport(ctx context.Context, userInfo UserBlockingInfo) bool {
@@ -99,16 +108,21 @@ func (s *Sender) SendReport(ctx context.Context, userInfo UserBlockingInfo) erro
 	// Build the report message
 	message := BuildReportMessage(userInfo.IncomingPRs, userInfo.OutgoingPRs)
 
-	// Send the DM
-	_, _, err := sender.SendDM(ctx, userInfo.DiscordUserID, message)
-	if err != nil {
-		return fmt.Errorf("failed to send DM: %w", err)
+	channelID, messageID, edited := s.editReport(ctx, sender, userInfo, message)
+	if !edited {
+		var err error
+		channelID, messageID, err = sender.SendDM(ctx, userInfo.DiscordUserID, message)
+		if err != nil {
+			return fmt.Errorf("failed to send DM: %w", err)
+		}
 	}
 
 	// Record that we sent the report
 	if err := s.stateStore.SaveDailyReportInfo(ctx, userInfo.DiscordUserID, state.DailyReportInfo{
 		LastSentAt: time.Now(),
 		GuildID:    userInfo.GuildID,
+		ChannelID:  channelID,
+		MessageID:  messageID,
 	}); err != nil {
 		s.logger.Warn("failed to record report send time",
 			"user", userInfo.DiscordUserID,
@@ -119,12 +133,35 @@ func (s *Sender) SendReport(ctx context.Context, userInfo UserBlockingInfo) erro
 	s.logger.Info("sent daily report",
 		"user", userInfo.DiscordUserID,
 		"github_user", userInfo.GitHubUsername,
+		"edited", edited,
 		"incoming_count", len(userInfo.IncomingPRs),
 		"outgoing_count", len(userInfo.OutgoingPRs))
 
 	return nil
 }
 
+// editReport edits a user's last report DM to the new report when reports are persistent,
+// returning the DM's location. It reports false if there's no DM in the guild to edit, or
+// editing it failed.
+func (s *Sender) editReport(
+	ctx context.Context, sender DiscordDMSender, userInfo UserBlockingInfo, message string,
+) (channelID, messageID string, ok bool) {
+	if !s.persistent {
+		return "", "", false
+	}
+	info, exists := s.stateStore.DailyReportInfo(ctx, userInfo.DiscordUserID)
+	if !exists || info.MessageID == "" || info.GuildID != userInfo.GuildID {
+		return "", "", false
+	}
+	if err := sender.UpdateDM(ctx, info.ChannelID, info.MessageID, message); err != nil {
+		s.logger.Warn("failed to edit persistent report, sending a new one",
+			"user", userInfo.DiscordUserID,
+			"error", err)
+		return "", "", false
+	}
+	return info.ChannelID, info.MessageID, true
+}
+
 // randomGreeting returns a friendly greeting (timezone-agnostic).
 func randomGreeting() string {
 	greetings := []string{
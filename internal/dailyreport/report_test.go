@@ -2,6 +2,7 @@ package dailyreport
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -34,8 +35,16 @@ func (m *mockStateStore) SaveDailyReportInfo(_ context.Context, userID string, i
 
 // mockDMSender implements DiscordDMSender for testing
 type mockDMSender struct {
-	sentDMs []sentDM
-	sendErr error
+	sentDMs    []sentDM
+	updatedDMs []updatedDM
+	sendErr    error
+	updateErr  error
+}
+
+type updatedDM struct {
+	channelID string
+	messageID string
+	text      string
 }
 
 type sentDM struct {
@@ -55,6 +64,14 @@ func (m *mockDMSender) SendDM(_ context.Context, userID, text string) (channelID
 	return "dm-channel-123", "dm-message-456", nil
 }
 
+func (m *mockDMSender) UpdateDM(_ context.Context, channelID, messageID, newText string) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.updatedDMs = append(m.updatedDMs, updatedDM{channelID: channelID, messageID: messageID, text: newText})
+	return nil
+}
+
 func TestSender_ShouldSendReport_NoPRs(t *testing.T) {
 	store := newMockStateStore()
 	sender := NewSender(store, nil)
@@ -125,6 +142,64 @@ func TestSender_SendReport(t *testing.T) {
 	}
 }
 
+func TestSender_SendReport_Persistent(t *testing.T) {
+	userInfo := UserBlockingInfo{
+		DiscordUserID:  "user123",
+		GitHubUsername: "ghuser",
+		GuildID:        "guild1",
+		IncomingPRs: []discord.PRSummary{
+			{Repo: "myrepo", Number: 42, Title: "Fix bug", URL: "https://github.com/o/myrepo/pull/42"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		persistent  bool
+		updateErr   error
+		wantSent    int
+		wantUpdated int
+	}{
+		{name: "edits the last report", persistent: true, wantSent: 1, wantUpdated: 1},
+		{name: "new report when the edit fails", persistent: true, updateErr: errors.New("unknown message"), wantSent: 2},
+		{name: "new report when not persistent", wantSent: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newMockStateStore()
+			sender := NewSender(store, nil)
+			sender.SetPersistent(tt.persistent)
+			dmSender := newMockDMSender()
+			dmSender.updateErr = tt.updateErr
+			sender.RegisterGuild("guild1", dmSender)
+
+			if err := sender.SendReport(ctx, userInfo); err != nil {
+				t.Fatalf("first SendReport() error = %v", err)
+			}
+			if info := store.reports["user123"]; info.ChannelID != "dm-channel-123" || info.MessageID != "dm-message-456" {
+				t.Errorf("saved report DM = %s/%s, want dm-channel-123/dm-message-456", info.ChannelID, info.MessageID)
+			}
+
+			userInfo.OutgoingPRs = []discord.PRSummary{{Repo: "myrepo", Number: 43, Title: "Add docs"}}
+			if err := sender.SendReport(ctx, userInfo); err != nil {
+				t.Fatalf("second SendReport() error = %v", err)
+			}
+			userInfo.OutgoingPRs = nil
+
+			if len(dmSender.sentDMs) != tt.wantSent || len(dmSender.updatedDMs) != tt.wantUpdated {
+				t.Fatalf("sent %d and edited %d report DMs, want %d and %d",
+					len(dmSender.sentDMs), len(dmSender.updatedDMs), tt.wantSent, tt.wantUpdated)
+			}
+			if tt.wantUpdated > 0 {
+				edit := dmSender.updatedDMs[0]
+				if edit.channelID != "dm-channel-123" || edit.messageID != "dm-message-456" || !strings.Contains(edit.text, "Add docs") {
+					t.Errorf("edit = %+v, want the first report DM updated to the new report", edit)
+				}
+			}
+		})
+	}
+}
+
 func TestSender_SendReport_NoSender(t *testing.T) {
 	store := newMockStateStore()
 	sender := NewSender(store, nil)
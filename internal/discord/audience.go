@@ -0,0 +1,185 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// viewerCacheTTL bounds how long a channel's viewers are cached. Listing them scans the
+// guild's members, so it's kept longer than permissions; joins show up within minutes.
+const viewerCacheTTL = 10 * time.Minute
+
+type viewerCacheEntry struct {
+	fetchedAt time.Time
+	viewers   []string
+	limit     int
+	unknown   bool // The viewers couldn't all be found
+}
+
+// ChannelViewers returns the IDs of up to limit people, not bots, who can see a channel, for
+// telling when its audience is just one user. Results are cached per channel for a few
+// minutes, unknown ones included. Returns nil whenever the answer may be incomplete: the
+// guild's members can't all be listed, as when the member scan is capped, or the guild's
+// roles or the channel's overwrites can't be read.
+func (c *Client) ChannelViewers(_ context.Context, channelID string, limit int) []string {
+	c.mu.RLock()
+	entry, ok := c.viewerCache[channelID]
+	guildID := c.guildID
+	c.mu.RUnlock()
+	if ok && entry.limit >= limit && time.Since(entry.fetchedAt) < viewerCacheTTL {
+		if entry.unknown {
+			return nil
+		}
+		return entry.viewers[:min(limit, len(entry.viewers))]
+	}
+	if guildID == "" {
+		return nil
+	}
+
+	viewers, known := c.findViewers(guildID, channelID, limit)
+	c.mu.Lock()
+	if c.viewerCache == nil {
+		c.viewerCache = make(map[string]viewerCacheEntry)
+	}
+	c.viewerCache[channelID] = viewerCacheEntry{viewers: viewers, limit: limit, unknown: !known, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	if !known {
+		return nil
+	}
+	return viewers
+}
+
+// findViewers returns up to limit people who can see a channel, and whether that's all of
+// them. Permissions are worked out from each member's roles, so checking a member costs
+// no request.
+func (c *Client) findViewers(guildID, channelID string, limit int) ([]string, bool) {
+	guild, channel, err := c.permissionSources(guildID, channelID)
+	if err != nil {
+		slog.Warn("failed to read roles and overwrites for channel viewers",
+			"guild_id", guildID,
+			"channel_id", channelID,
+			"error", err)
+		return nil, false
+	}
+
+	members, complete := c.viewerCandidates(guildID)
+	var viewers []string
+	for _, member := range members {
+		if member.User == nil || member.User.Bot {
+			continue
+		}
+		bits := memberChannelPermissions(guild, channel, member.User.ID, member.Roles)
+		if !PermissionsFromBits(bits).ViewChannel {
+			continue
+		}
+		viewers = append(viewers, member.User.ID)
+		if len(viewers) >= limit {
+			break
+		}
+	}
+	// Fewer than limit viewers among some of the members says nothing about the rest
+	return viewers, complete || len(viewers) >= limit
+}
+
+// permissionSources returns the guild's owner and roles and the channel's permission
+// overwrites, copied from the session's state when it has them and fetched otherwise.
+func (c *Client) permissionSources(guildID, channelID string) (*discordgo.Guild, *discordgo.Channel, error) {
+	var guild *discordgo.Guild
+	var channel *discordgo.Channel
+	if st := c.session.GetState(); st != nil {
+		if g, err := st.Guild(guildID); err == nil && len(g.Roles) > 0 {
+			st.RLock()
+			guild = &discordgo.Guild{ID: g.ID, OwnerID: g.OwnerID, Roles: slices.Clone(g.Roles)}
+			st.RUnlock()
+		}
+		if ch, err := st.Channel(channelID); err == nil {
+			st.RLock()
+			channel = &discordgo.Channel{ID: ch.ID, PermissionOverwrites: slices.Clone(ch.PermissionOverwrites)}
+			st.RUnlock()
+		}
+	}
+
+	var err error
+	if guild == nil {
+		if guild, err = c.session.Guild(guildID); err != nil {
+			return nil, nil, fmt.Errorf("fetch guild: %w", err)
+		}
+	}
+	if channel == nil {
+		if channel, err = c.session.Channel(channelID); err != nil {
+			return nil, nil, fmt.Errorf("fetch channel: %w", err)
+		}
+	}
+	return guild, channel, nil
+}
+
+// memberChannelPermissions computes a member's permission bits in a channel from the
+// guild's roles and the channel's overwrites, following Discord's permission hierarchy:
+// @everyone, then the member's roles, then the channel's @everyone, role and member overwrites.
+func memberChannelPermissions(guild *discordgo.Guild, channel *discordgo.Channel, userID string, roles []string) int64 {
+	if userID == guild.OwnerID {
+		return discordgo.PermissionAll
+	}
+
+	var bits int64
+	for _, role := range guild.Roles {
+		if role.ID == guild.ID || slices.Contains(roles, role.ID) {
+			bits |= role.Permissions
+		}
+	}
+	if bits&discordgo.PermissionAdministrator != 0 {
+		return discordgo.PermissionAll
+	}
+
+	var roleAllow, roleDeny int64
+	var member *discordgo.PermissionOverwrite
+	for _, overwrite := range channel.PermissionOverwrites {
+		switch {
+		case overwrite.ID == guild.ID:
+			bits = bits&^overwrite.Deny | overwrite.Allow
+		case overwrite.Type == discordgo.PermissionOverwriteTypeRole && slices.Contains(roles, overwrite.ID):
+			roleAllow |= overwrite.Allow
+			roleDeny |= overwrite.Deny
+		case overwrite.Type == discordgo.PermissionOverwriteTypeMember && overwrite.ID == userID:
+			member = overwrite
+		}
+	}
+	bits = bits&^roleDeny | roleAllow
+	if member != nil {
+		bits = bits&^member.Deny | member.Allow
+	}
+	return bits
+}
+
+// viewerCandidates returns a guild's members, and whether they're all of them. The session's
+// state has them without a request once the gateway has sent the whole guild; otherwise
+// they're listed through the API, up to the client's member cap.
+func (c *Client) viewerCandidates(guildID string) ([]*discordgo.Member, bool) {
+	if st := c.session.GetState(); st != nil {
+		if guild, err := st.Guild(guildID); err == nil {
+			st.RLock()
+			members, count := slices.Clone(guild.Members), guild.MemberCount
+			st.RUnlock()
+			if count > 0 && len(members) >= count {
+				return members, true
+			}
+		}
+	}
+
+	members, err := c.guildMembers(guildID)
+	if err != nil {
+		slog.Warn("failed to list guild members for channel viewers",
+			"guild_id", guildID,
+			"error", err)
+		return nil, false
+	}
+	c.mu.RLock()
+	maxMembers := c.maxMembers
+	c.mu.RUnlock()
+	return members, maxMembers == 0 || len(members) < maxMembers
+}
@@ -0,0 +1,159 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// addViewerChannels sets up a guild where everyone can see "general" and only the users
+// given can see "private".
+func addViewerChannels(mockSession *MockSession, privateViewers ...string) {
+	mockSession.Guilds["test-guild"] = &discordgo.Guild{
+		ID:      "test-guild",
+		OwnerID: "owner",
+		Roles:   []*discordgo.Role{{ID: "test-guild", Permissions: discordgo.PermissionViewChannel}},
+	}
+	mockSession.Channels["general"] = NewMockChannel("general", "general", "test-guild", discordgo.ChannelTypeGuildText)
+	private := NewMockChannel("private", "private", "test-guild", discordgo.ChannelTypeGuildText)
+	private.PermissionOverwrites = []*discordgo.PermissionOverwrite{
+		{ID: "test-guild", Type: discordgo.PermissionOverwriteTypeRole, Deny: discordgo.PermissionViewChannel},
+	}
+	for _, id := range privateViewers {
+		private.PermissionOverwrites = append(private.PermissionOverwrites, &discordgo.PermissionOverwrite{
+			ID: id, Type: discordgo.PermissionOverwriteTypeMember, Allow: discordgo.PermissionViewChannel,
+		})
+	}
+	mockSession.Channels["private"] = private
+}
+
+func TestClient_ChannelViewers(t *testing.T) {
+	ctx := context.Background()
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("test-guild")
+	for _, u := range []*discordgo.User{{ID: "bot-1", Bot: true}, {ID: "alice"}, {ID: "bob"}, {ID: "carol"}} {
+		mockSession.AddMember("test-guild", &discordgo.Member{User: u})
+	}
+	addViewerChannels(mockSession, "bob")
+	mockSession.UserChannelPermissionsError = errors.New("permissions are computed, not fetched")
+
+	if got := client.ChannelViewers(ctx, "private", 2); !slices.Equal(got, []string{"bob"}) {
+		t.Errorf("ChannelViewers(private) = %v, want [bob]", got)
+	}
+	if got := client.ChannelViewers(ctx, "general", 2); !slices.Equal(got, []string{"alice", "bob"}) {
+		t.Errorf("ChannelViewers(general) = %v, want the first 2 people, not bots", got)
+	}
+
+	// Cached until the channel changes
+	calls := mockSession.GuildMembersCalls
+	client.ChannelViewers(ctx, "private", 2)
+	if mockSession.GuildMembersCalls != calls {
+		t.Error("ChannelViewers() listed members again, want the cached viewers")
+	}
+	client.onChannelUpdate(nil, &discordgo.ChannelUpdate{Channel: &discordgo.Channel{ID: "private", GuildID: "test-guild"}})
+	client.ChannelViewers(ctx, "private", 2)
+	if mockSession.GuildMembersCalls == calls {
+		t.Error("ChannelViewers() used the cache after the channel was updated")
+	}
+}
+
+func TestClient_ChannelViewers_Incomplete(t *testing.T) {
+	ctx := context.Background()
+	newClient := func() (*Client, *MockSession) {
+		mockSession := NewMockSession()
+		client := newTestClientWithMock(mockSession)
+		client.SetGuildID("test-guild")
+		for _, id := range []string{"alice", "bob", "carol"} {
+			mockSession.AddMember("test-guild", &discordgo.Member{User: &discordgo.User{ID: id}})
+		}
+		addViewerChannels(mockSession, "alice")
+		return client, mockSession
+	}
+
+	t.Run("capped member scan", func(t *testing.T) {
+		client, _ := newClient()
+		client.SetMemberScan(1, 2)
+		// Only alice among the first two, but carol was never checked
+		if got := client.ChannelViewers(ctx, "private", 2); got != nil {
+			t.Errorf("ChannelViewers() = %v with a capped scan, want nil", got)
+		}
+		// Enough viewers found is an answer even so
+		if got := client.ChannelViewers(ctx, "general", 2); !slices.Equal(got, []string{"alice", "bob"}) {
+			t.Errorf("ChannelViewers(general) = %v, want [alice bob]", got)
+		}
+	})
+
+	t.Run("overwrites unreadable", func(t *testing.T) {
+		client, mockSession := newClient()
+		mockSession.ChannelError = errors.New("rate limited")
+		if got := client.ChannelViewers(ctx, "private", 2); got != nil {
+			t.Errorf("ChannelViewers() = %v when the channel can't be read, want nil", got)
+		}
+		// Unknown answers are cached too, so a failing lookup isn't retried on every event
+		mockSession.ChannelError = nil
+		if got := client.ChannelViewers(ctx, "private", 2); got != nil {
+			t.Errorf("ChannelViewers() = %v, want the cached unknown answer", got)
+		}
+		client.onChannelUpdate(nil, &discordgo.ChannelUpdate{Channel: &discordgo.Channel{ID: "private", GuildID: "test-guild"}})
+		if got := client.ChannelViewers(ctx, "private", 2); !slices.Equal(got, []string{"alice"}) {
+			t.Errorf("ChannelViewers() = %v after the channel changed, want [alice]", got)
+		}
+	})
+
+	t.Run("members from state", func(t *testing.T) {
+		client, mockSession := newClient()
+		members := []*discordgo.Member{{User: &discordgo.User{ID: "alice"}}, {User: &discordgo.User{ID: "dave"}}}
+		if err := mockSession.MockState.GuildAdd(&discordgo.Guild{ID: "test-guild", MemberCount: 2, Members: members}); err != nil {
+			t.Fatalf("GuildAdd() error = %v", err)
+		}
+		if got := client.ChannelViewers(ctx, "general", 3); !slices.Equal(got, []string{"alice", "dave"}) {
+			t.Errorf("ChannelViewers() = %v, want the state's members", got)
+		}
+		if mockSession.GuildMembersCalls != 0 {
+			t.Errorf("GuildMembers calls = %d, want none with the whole guild in state", mockSession.GuildMembersCalls)
+		}
+	})
+}
+
+func TestMemberChannelPermissions(t *testing.T) {
+	view := int64(discordgo.PermissionViewChannel)
+	guild := &discordgo.Guild{
+		ID:      "guild",
+		OwnerID: "owner",
+		Roles: []*discordgo.Role{
+			{ID: "guild", Permissions: view},
+			{ID: "admins", Permissions: discordgo.PermissionAdministrator},
+			{ID: "staff"},
+		},
+	}
+	channel := &discordgo.Channel{PermissionOverwrites: []*discordgo.PermissionOverwrite{
+		{ID: "guild", Type: discordgo.PermissionOverwriteTypeRole, Deny: view},
+		{ID: "staff", Type: discordgo.PermissionOverwriteTypeRole, Allow: view},
+		{ID: "banned", Type: discordgo.PermissionOverwriteTypeMember, Deny: view},
+	}}
+
+	tests := []struct {
+		name   string
+		userID string
+		roles  []string
+		want   bool
+	}{
+		{"everyone denied", "user", nil, false},
+		{"role allowed", "user", []string{"staff"}, true},
+		{"member denied over role", "banned", []string{"staff"}, false},
+		{"administrator", "user", []string{"admins"}, true},
+		{"owner", "owner", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits := memberChannelPermissions(guild, channel, tt.userID, tt.roles)
+			if got := PermissionsFromBits(bits).ViewChannel; got != tt.want {
+				t.Errorf("ViewChannel = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Channel rename policies: what a renamed channel's old name resolves to. PR messages are
+// tracked by channel ID, so they stay valid either way; the policy decides whether config
+// naming the channel by its old name keeps reaching it.
+const (
+	// ChannelRenamesFollow keeps resolving the old name to the renamed channel until restart,
+	// so PRs tracked there keep updating until the config catches up.
+	ChannelRenamesFollow = "follow"
+	// ChannelRenamesStrict stops resolving the old name at once; the config must use the new one.
+	ChannelRenamesStrict = "strict"
+)
+
+// SetChannelRenamePolicy sets what a renamed channel's old name resolves to:
+// ChannelRenamesFollow (the default) or ChannelRenamesStrict.
+func (c *Client) SetChannelRenamePolicy(policy string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.renamePolicy = policy
+}
+
+// onChannelUpdate drops cached lookups a channel's update may have made stale: its type and
+// permissions and viewers, and its name, which another channel may also have been cached under.
+func (c *Client) onChannelUpdate(_ *discordgo.Session, e *discordgo.ChannelUpdate) {
+	if e.Channel == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e.GuildID != c.guildID {
+		return
+	}
+	delete(c.channelTypeCache, e.ID)
+	delete(c.permissionCache, e.ID)
+	delete(c.viewerCache, e.ID)
+
+	var oldNames []string
+	for name, id := range c.channelCache {
+		switch {
+		case strings.TrimPrefix(name, "#") == e.Name:
+			// Resolve the name afresh, in case it was cached for a channel that's since lost it
+			delete(c.channelCache, name)
+		case id == e.ID:
+			oldNames = append(oldNames, name)
+			if c.renamePolicy == ChannelRenamesStrict {
+				delete(c.channelCache, name)
+			}
+		}
+	}
+	if len(oldNames) > 0 {
+		slog.Info("channel renamed",
+			"channel_id", e.ID,
+			"old_names", oldNames,
+			"new_name", e.Name,
+			"old_names_resolve", c.renamePolicy != ChannelRenamesStrict)
+	}
+}
+
+// onChannelCreate drops a cached name the new channel takes, such as a renamed channel's old
+// name still followed to it.
+func (c *Client) onChannelCreate(_ *discordgo.Session, e *discordgo.ChannelCreate) {
+	if e.Channel == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e.GuildID != c.guildID {
+		return
+	}
+	for name := range c.channelCache {
+		if strings.TrimPrefix(name, "#") == e.Name {
+			delete(c.channelCache, name)
+		}
+	}
+}
+
+// onChannelDelete drops every cached lookup of a deleted channel.
+func (c *Client) onChannelDelete(_ *discordgo.Session, e *discordgo.ChannelDelete) {
+	if e.Channel == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e.GuildID != c.guildID {
+		return
+	}
+	delete(c.channelTypeCache, e.ID)
+	delete(c.permissionCache, e.ID)
+	delete(c.viewerCache, e.ID)
+	for name, id := range c.channelCache {
+		if id == e.ID {
+			delete(c.channelCache, name)
+		}
+	}
+}
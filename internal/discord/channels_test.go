@@ -0,0 +1,93 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestClient_onChannelUpdate_Rename(t *testing.T) {
+	tests := []struct {
+		policy     string
+		wantOldRes string
+	}{
+		{policy: ChannelRenamesFollow, wantOldRes: "chan-1"},
+		{policy: ChannelRenamesStrict, wantOldRes: "general"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			ctx := context.Background()
+			mockSession := NewMockSession()
+			client := newTestClientWithMock(mockSession)
+			client.SetGuildID("test-guild")
+			client.SetChannelRenamePolicy(tt.policy)
+			channel := &discordgo.Channel{ID: "chan-1", Name: "general", GuildID: "test-guild"}
+			mockSession.AddChannel(channel)
+
+			if got := client.ResolveChannelID(ctx, "general"); got != "chan-1" {
+				t.Fatalf("ResolveChannelID(general) = %q, want chan-1", got)
+			}
+			client.channelTypeCache["chan-1"] = discordgo.ChannelTypeGuildText
+
+			renamed := &discordgo.Channel{ID: "chan-1", Name: "dev", GuildID: "test-guild"}
+			mockSession.AddChannel(renamed)
+			client.onChannelUpdate(nil, &discordgo.ChannelUpdate{Channel: renamed})
+
+			if got := client.ResolveChannelID(ctx, "dev"); got != "chan-1" {
+				t.Errorf("ResolveChannelID(dev) = %q, want the new name to resolve to chan-1", got)
+			}
+			if got := client.ResolveChannelID(ctx, "general"); got != tt.wantOldRes {
+				t.Errorf("ResolveChannelID(general) = %q, want %q", got, tt.wantOldRes)
+			}
+			if _, ok := client.channelTypeCache["chan-1"]; ok {
+				t.Error("channel type cache kept the updated channel")
+			}
+		})
+	}
+}
+
+func TestClient_onChannelUpdate_NameTaken(t *testing.T) {
+	ctx := context.Background()
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("test-guild")
+	mockSession.AddChannel(&discordgo.Channel{ID: "chan-1", Name: "general", GuildID: "test-guild"})
+	client.ResolveChannelID(ctx, "general")
+
+	// general is renamed away, and another channel takes its name
+	renamed := &discordgo.Channel{ID: "chan-1", Name: "general-old", GuildID: "test-guild"}
+	mockSession.AddChannel(renamed)
+	client.onChannelUpdate(nil, &discordgo.ChannelUpdate{Channel: renamed})
+	taken := &discordgo.Channel{ID: "chan-2", Name: "general", GuildID: "test-guild"}
+	mockSession.AddChannel(taken)
+	client.onChannelCreate(nil, &discordgo.ChannelCreate{Channel: taken})
+
+	if got := client.ResolveChannelID(ctx, "general"); got != "chan-2" {
+		t.Errorf("ResolveChannelID(general) = %q, want the channel that now has the name", got)
+	}
+
+	// Another guild's events are ignored
+	other := &discordgo.Channel{ID: "chan-9", Name: "general", GuildID: "other-guild"}
+	client.onChannelUpdate(nil, &discordgo.ChannelUpdate{Channel: other})
+	if client.channelCache["general"] != "chan-2" {
+		t.Error("another guild's channel update changed the cache")
+	}
+}
+
+func TestClient_onChannelDelete(t *testing.T) {
+	client := newTestClientWithMock(NewMockSession())
+	client.SetGuildID("test-guild")
+	client.channelCache["general"] = "chan-1"
+	client.channelCache["#general"] = "chan-1"
+	client.channelCache["random"] = "chan-2"
+
+	client.onChannelDelete(nil, &discordgo.ChannelDelete{
+		Channel: &discordgo.Channel{ID: "chan-1", Name: "general", GuildID: "test-guild"},
+	})
+
+	if len(client.channelCache) != 1 || client.channelCache["random"] != "chan-2" {
+		t.Errorf("channelCache = %v, want only the other channel left", client.channelCache)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -21,14 +23,44 @@ type Client struct {
 	session          session
 	realSession      *discordgo.Session               // Keep reference for Session() method
 	channelCache     map[string]string                // channel name -> ID
+	roleCache        map[string]string                // role name -> ID
 	channelTypeCache map[string]discordgo.ChannelType // channel ID -> type
 	userCache        map[string]string                // username -> ID
+	dmChannelCache   map[string]string                // user ID -> DM channel ID
+	permissionCache  map[string]permissionCacheEntry  // channel ID -> bot permissions
+	viewerCache      map[string]viewerCacheEntry      // channel ID -> people who can see it
+	profileCache     map[string]profileCacheEntry     // user ID -> display name, or not a member
+	pacer            *RequestPacer                    // Shared across clients for the bot token; nil doesn't pace
+	writeSlots       chan struct{}                    // Bounds the guild's concurrent writes; nil doesn't
+	reactionHandler  ReactionHandler
+	memberHandler    MemberRemoveHandler
+	removeHandlers   []func() // Unregister the client's gateway handlers, which may be on a shared session
 	guildID          string
+	openRetryDelay   time.Duration // Initial backoff between Open attempts
+	memberBatchSize  int           // Members fetched per GuildMembers request
+	maxMembers       int           // Cap on members scanned per username lookup; 0 scans the whole guild
+	previewLen       int           // Characters of content logged per message; 0 disables previews
+	previewSample    int           // Log a preview for 1 in this many messages
+	previewCount     atomic.Uint64 // Messages considered for a preview, for sampling
+	redactPreviews   bool          // Log only PR URLs in previews, never titles
+	starterAttempts  int           // Fetches of a new forum thread's starter message; 0 uses the default
+	renamePolicy     string        // What a renamed channel's old name resolves to; "" follows the channel
 	mu               sync.RWMutex
+	connected        atomic.Bool
+	maintenance      atomic.Bool // Shows the maintenance status; reapplied on reconnect
 }
 
-// New creates a new Discord client for a specific guild.
+// New creates a new Discord client for a specific guild, with a gateway session of its own.
 func New(token string) (*Client, error) {
+	session, err := newGatewaySession(token)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(session, &sessionAdapter{Session: session}), nil
+}
+
+// newGatewaySession creates an unopened gateway session for a bot token.
+func newGatewaySession(token string) (*discordgo.Session, error) {
 	session, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
@@ -37,18 +69,63 @@ func New(token string) (*Client, error) {
 	// Set required intents
 	// GUILD_PRESENCES is needed to detect user online/offline status
 	// GUILDS, GUILD_MESSAGES, and MESSAGE_CONTENT are needed for normal bot operations
+	// GUILD_MESSAGE_REACTIONS is needed to see acknowledgments on PR messages
+	// GUILD_MEMBERS is needed to see mapped users leave
 	session.Identify.Intents = discordgo.IntentsGuilds |
+		discordgo.IntentsGuildMembers |
 		discordgo.IntentsGuildMessages |
+		discordgo.IntentsGuildMessageReactions |
 		discordgo.IntentsGuildPresences |
 		discordgo.IntentsMessageContent
+	return session, nil
+}
 
-	return &Client{
-		session:          &sessionAdapter{Session: session},
+// newClient creates a client on a gateway session, which it may share with other guilds' clients.
+// sess is how the client opens, closes, and calls the session.
+func newClient(session *discordgo.Session, sess session) *Client {
+	c := &Client{
+		session:          sess,
 		realSession:      session,
 		channelCache:     make(map[string]string),
+		roleCache:        make(map[string]string),
 		channelTypeCache: make(map[string]discordgo.ChannelType),
 		userCache:        make(map[string]string),
-	}, nil
+		dmChannelCache:   make(map[string]string),
+		permissionCache:  make(map[string]permissionCacheEntry),
+		viewerCache:      make(map[string]viewerCacheEntry),
+		profileCache:     make(map[string]profileCacheEntry),
+		writeSlots:       make(chan struct{}, DefaultGuildWriteConcurrency),
+		openRetryDelay:   defaultOpenRetryDelay,
+		memberBatchSize:  DefaultMemberBatchSize,
+		previewLen:       DefaultPreviewLength,
+		previewSample:    1,
+	}
+
+	// discordgo reconnects on its own; track it so the state can be logged and reported
+	c.removeHandlers = []func(){
+		session.AddHandler(c.onConnect),
+		session.AddHandler(c.onDisconnect),
+		session.AddHandler(c.onResumed),
+		session.AddHandler(c.onReactionAdd),
+		session.AddHandler(c.onGuildMemberRemove),
+		session.AddHandler(c.onChannelCreate),
+		session.AddHandler(c.onChannelUpdate),
+		session.AddHandler(c.onChannelDelete),
+	}
+	return c
+}
+
+// ShardForGuild returns the gateway shard that receives a guild's events,
+// using Discord's formula: (guild_id >> 22) % shard_count.
+func ShardForGuild(guildID string, shardCount int) int {
+	if shardCount <= 1 {
+		return 0
+	}
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return int((id >> 22) % uint64(shardCount)) //nolint:gosec // result is below shardCount
 }
 
 // retryableCtx wraps a function with standard retry configuration.
@@ -67,6 +144,44 @@ func retryableCtx(ctx context.Context, fn func() error) error {
 	)
 }
 
+// SetRequestPacer paces the client's write requests. Share one pacer across
+// every client using the same bot token.
+func (c *Client) SetRequestPacer(pacer *RequestPacer) {
+	c.pacer = pacer
+}
+
+// SetWriteConcurrency caps how many write requests the client's guild may have in flight at
+// once, so one guild's burst can't take every slot of the shared pacer. 0 removes the cap.
+// Must be called before the client is used.
+func (c *Client) SetWriteConcurrency(n int) {
+	if n <= 0 {
+		c.writeSlots = nil
+		return
+	}
+	c.writeSlots = make(chan struct{}, n)
+}
+
+// SetMemberScan sets how many members each GuildMembers request fetches (at most
+// Discord's limit of 1000; 0 uses the default) and caps the members scanned per
+// username lookup (0 scans the whole guild).
+func (c *Client) SetMemberScan(batchSize, maxMembers int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if batchSize <= 0 || batchSize > DefaultMemberBatchSize {
+		batchSize = DefaultMemberBatchSize
+	}
+	c.memberBatchSize = batchSize
+	c.maxMembers = max(maxMembers, 0)
+}
+
+// SetStarterFetchAttempts sets how many times a forum thread's starter message is fetched for
+// its ID before giving up; 0 uses DefaultStarterFetchAttempts.
+func (c *Client) SetStarterFetchAttempts(attempts int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.starterAttempts = attempts
+}
+
 // SetGuildID sets the guild ID for this client.
 func (c *Client) SetGuildID(guildID string) {
 	c.mu.Lock()
@@ -81,8 +196,24 @@ func (c *Client) GuildID() string {
 	return c.guildID
 }
 
-// openTimeout is the maximum time to wait for Discord connection.
-const openTimeout = 30 * time.Second
+const (
+	// openTimeout is the maximum time to wait for Discord connection.
+	openTimeout = 30 * time.Second
+	// DefaultOpenAttempts is how many times OpenWithRetry tries to connect.
+	DefaultOpenAttempts = 4
+	// DefaultMemberBatchSize is Discord's limit on members returned per GuildMembers request.
+	DefaultMemberBatchSize = 1000
+	// defaultOpenRetryDelay is the initial backoff between Open attempts.
+	defaultOpenRetryDelay = 2 * time.Second
+	// DefaultStarterFetchAttempts is how many times a forum thread's starter message is fetched
+	// for its ID; a new thread's message isn't always listed right away.
+	DefaultStarterFetchAttempts = 3
+	// starterFetchDelay is the pause between fetches of a forum thread's starter message.
+	starterFetchDelay = 500 * time.Millisecond
+)
+
+// errNoStarterMessage is returned while a forum thread's starter message isn't listed yet.
+var errNoStarterMessage = errors.New("forum thread has no messages yet")
 
 // Open opens the WebSocket connection to Discord with a timeout.
 func (c *Client) Open() error {
@@ -93,6 +224,9 @@ func (c *Client) Open() error {
 
 	select {
 	case err := <-done:
+		if err == nil {
+			c.connected.Store(true)
+		}
 		return err
 	case <-time.After(openTimeout):
 		// Try to close the session to clean up
@@ -101,8 +235,91 @@ func (c *Client) Open() error {
 	}
 }
 
-// Close closes the WebSocket connection.
+// OpenWithRetry opens the connection, retrying with backoff so a transient
+// gateway hiccup at startup doesn't fail guild registration.
+func (c *Client) OpenWithRetry(ctx context.Context, attempts int) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	err := retry.Do(
+		c.Open,
+		retry.Context(ctx),
+		retry.Attempts(uint(attempts)), //nolint:gosec // attempts is at least 1
+		retry.Delay(c.openRetryDelay),
+		retry.MaxDelay(openTimeout),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			slog.Warn("Discord connection failed, retrying",
+				"guild_id", c.GuildID(),
+				"attempt", n+1,
+				"error", err)
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("open Discord connection after %d attempts: %w", attempts, err)
+	}
+	return nil
+}
+
+// Connected reports whether the gateway connection is currently up.
+func (c *Client) Connected() bool {
+	return c.connected.Load()
+}
+
+func (c *Client) onConnect(_ *discordgo.Session, _ *discordgo.Connect) {
+	if !c.connected.Swap(true) {
+		slog.Info("Discord gateway connected", "guild_id", c.GuildID())
+	}
+	// A new gateway session starts without a status
+	if c.maintenance.Load() {
+		if err := c.applyPresence(); err != nil {
+			slog.Warn("failed to restore maintenance status", "error", err, "guild_id", c.GuildID())
+		}
+	}
+}
+
+// maintenanceStatus is the bot's custom status while its guild is in maintenance mode.
+const maintenanceStatus = "Under maintenance"
+
+// SetMaintenancePresence shows or clears the bot's "Under maintenance" status.
+// The status belongs to the gateway session, so it shows in every guild sharing it.
+func (c *Client) SetMaintenancePresence(on bool) error {
+	c.maintenance.Store(on)
+	return c.applyPresence()
+}
+
+func (c *Client) applyPresence() error {
+	if c.realSession == nil {
+		return nil
+	}
+	status := ""
+	if c.maintenance.Load() {
+		status = maintenanceStatus
+	}
+	if err := c.realSession.UpdateCustomStatus(status); err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) onDisconnect(_ *discordgo.Session, _ *discordgo.Disconnect) {
+	c.connected.Store(false)
+	slog.Warn("Discord gateway disconnected, waiting for reconnect", "guild_id", c.GuildID())
+}
+
+func (c *Client) onResumed(_ *discordgo.Session, _ *discordgo.Resumed) {
+	c.connected.Store(true)
+	slog.Info("Discord gateway session resumed", "guild_id", c.GuildID())
+}
+
+// Close closes the WebSocket connection. A shared session stays open until its last client closes.
 func (c *Client) Close() error {
+	c.connected.Store(false)
+	for _, remove := range c.removeHandlers {
+		remove()
+	}
+	c.removeHandlers = nil
 	return c.session.Close()
 }
 
@@ -115,6 +332,10 @@ func (c *Client) Session() *discordgo.Session {
 func (c *Client) PostMessage(ctx context.Context, channelID, text string) (string, error) {
 	var msg *discordgo.Message
 	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		var err error
 		msg, err = c.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
 			Content: text,
@@ -129,22 +350,44 @@ func (c *Client) PostMessage(ctx context.Context, channelID, text string) (strin
 	slog.Info("posted channel message",
 		"channel_id", channelID,
 		"message_id", msg.ID,
-		"content", text)
+		c.preview("content", text))
 
 	return msg.ID, nil
 }
 
-// UpdateMessage edits an existing message.
-func (c *Client) UpdateMessage(ctx context.Context, channelID, messageID, newText string) error {
+// editFlags returns the message flags for an edit. Silent edits set
+// MessageFlagsSuppressNotifications, but Discord applies it to new posts only:
+// on an edit it has no effect. Edits don't re-ping users already mentioned either
+// way, so the flag only records that the edit was meant to be silent.
+func editFlags(silent bool) discordgo.MessageFlags {
+	if silent {
+		return discordgo.MessageFlagsSuppressEmbeds | discordgo.MessageFlagsSuppressNotifications
+	}
+	return discordgo.MessageFlagsSuppressEmbeds
+}
+
+// UpdateMessage edits an existing message. silent is passed to editFlags, which
+// has no effect on notifications for an edit; see editFlags.
+func (c *Client) UpdateMessage(ctx context.Context, channelID, messageID, newText string, silent bool) error {
 	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		_, err := c.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 			ID:      messageID,
 			Channel: channelID,
 			Content: &newText,
-			Flags:   discordgo.MessageFlagsSuppressEmbeds,
+			Flags:   editFlags(silent),
 		})
+		if isNotFound(err) {
+			return retry.Unrecoverable(err)
+		}
 		return err
 	})
+	if isNotFound(err) {
+		return fmt.Errorf("failed to edit message: %w: %w", ErrNotFound, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to edit message: %w", err)
 	}
@@ -152,7 +395,8 @@ func (c *Client) UpdateMessage(ctx context.Context, channelID, messageID, newTex
 	slog.Info("updated channel message",
 		"channel_id", channelID,
 		"message_id", messageID,
-		"content", newText)
+		"silent", silent,
+		c.preview("content", newText))
 
 	return nil
 }
@@ -161,6 +405,10 @@ func (c *Client) UpdateMessage(ctx context.Context, channelID, messageID, newTex
 func (c *Client) PostForumThread(ctx context.Context, channelID, title, content string) (threadID, messageID string, err error) {
 	var thread *discordgo.Channel
 	err = retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		var err error
 		thread, err = c.session.ForumThreadStartComplex(channelID, &discordgo.ThreadStart{
 			Name: format.Truncate(title, 100), // Discord limits thread names
@@ -182,45 +430,105 @@ func (c *Client) PostForumThread(ctx context.Context, channelID, title, content
 		"guild_id", guildID,
 		"channel_id", channelID,
 		"thread_id", thread.ID,
-		"title", title,
-		"content", content)
+		c.preview("title", title, "content", content))
 
-	// Get the first message in the thread to return its ID
-	var messages []*discordgo.Message
-	err = retryableCtx(ctx, func() error {
-		var err error
-		messages, err = c.session.ChannelMessages(thread.ID, 1, "", "", "")
-		return err
-	})
-	if err == nil && len(messages) > 0 {
-		return thread.ID, messages[0].ID, nil
+	// Without the starter message's ID only the title can be updated, until the next
+	// update recovers it with ForumStarterMessageID
+	messageID, err = c.ForumStarterMessageID(ctx, thread.ID)
+	if err != nil {
+		slog.Warn("failed to fetch new forum thread's starter message",
+			"thread_id", thread.ID,
+			"error", err)
+		return thread.ID, "", nil
+	}
+	return thread.ID, messageID, nil
+}
+
+// ForumStarterMessageID returns the ID of the message a forum thread was started with,
+// retrying briefly while it isn't listed yet.
+func (c *Client) ForumStarterMessageID(ctx context.Context, threadID string) (string, error) {
+	c.mu.RLock()
+	attempts := c.starterAttempts
+	c.mu.RUnlock()
+	if attempts < 1 {
+		attempts = DefaultStarterFetchAttempts
 	}
 
-	return thread.ID, "", nil
+	var messageID string
+	err := retry.Do(
+		func() error {
+			// The thread's oldest message is the one it was started with
+			messages, err := c.session.ChannelMessages(threadID, 1, "", "0", "")
+			if isNotFound(err) {
+				return retry.Unrecoverable(err)
+			}
+			if err != nil {
+				return err
+			}
+			if len(messages) == 0 {
+				return errNoStarterMessage
+			}
+			messageID = messages[0].ID
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Attempts(uint(attempts)), //nolint:gosec // attempts is at least 1
+		retry.Delay(starterFetchDelay),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+	)
+	if isNotFound(err) {
+		return "", fmt.Errorf("fetch starter message of thread %s: %w: %w", threadID, ErrNotFound, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("fetch starter message of thread %s: %w", threadID, err)
+	}
+	return messageID, nil
 }
 
-// UpdateForumPost updates both the thread title and starter message.
-func (c *Client) UpdateForumPost(ctx context.Context, threadID, messageID, newTitle, newContent string) error {
+// UpdateForumPost updates both the thread title and starter message. silent is
+// passed to editFlags, which has no effect on notifications for an edit; see editFlags.
+func (c *Client) UpdateForumPost(ctx context.Context, threadID, messageID, newTitle, newContent string, silent bool) error {
 	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		_, err := c.session.ChannelEdit(threadID, &discordgo.ChannelEdit{
 			Name: format.Truncate(newTitle, 100),
 		})
+		if isNotFound(err) {
+			return retry.Unrecoverable(err)
+		}
 		return err
 	})
+	if isNotFound(err) {
+		return fmt.Errorf("failed to update thread title: %w: %w", ErrNotFound, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update thread title: %w", err)
 	}
 
 	if messageID != "" {
 		err = retryableCtx(ctx, func() error {
+			if err := c.beginWrite(ctx); err != nil {
+				return err
+			}
+			defer c.endWrite()
 			_, err := c.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 				ID:      messageID,
 				Channel: threadID,
 				Content: &newContent,
-				Flags:   discordgo.MessageFlagsSuppressEmbeds,
+				Flags:   editFlags(silent),
 			})
+			if isNotFound(err) {
+				return retry.Unrecoverable(err)
+			}
 			return err
 		})
+		if isNotFound(err) {
+			return fmt.Errorf("failed to update thread message: %w: %w", ErrNotFound, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to update thread message: %w", err)
 		}
@@ -228,15 +536,44 @@ func (c *Client) UpdateForumPost(ctx context.Context, threadID, messageID, newTi
 
 	slog.Info("updated forum post",
 		"thread_id", threadID,
-		"title", newTitle,
-		"content", newContent)
+		"silent", silent,
+		c.preview("title", newTitle, "content", newContent))
 
 	return nil
 }
 
+// StartMessageThread starts a thread on a text channel message, returning the thread's ID.
+func (c *Client) StartMessageThread(ctx context.Context, channelID, messageID, name string) (string, error) {
+	var thread *discordgo.Channel
+	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
+		var err error
+		thread, err = c.session.MessageThreadStartComplex(channelID, messageID, &discordgo.ThreadStart{
+			Name: format.Truncate(name, 100), // Discord limits thread names
+		})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start message thread: %w", err)
+	}
+
+	slog.Info("started message thread",
+		"channel_id", channelID,
+		"message_id", messageID,
+		"thread_id", thread.ID)
+	return thread.ID, nil
+}
+
 // ArchiveThread archives a forum thread.
 func (c *Client) ArchiveThread(ctx context.Context, threadID string) error {
 	archived := true
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	defer c.endWrite()
 	_, err := c.session.ChannelEdit(threadID, &discordgo.ChannelEdit{
 		Archived: &archived,
 	})
@@ -248,43 +585,186 @@ func (c *Client) ArchiveThread(ctx context.Context, threadID string) error {
 	return nil
 }
 
-// SendDM sends a direct message to a user with link embeds suppressed.
-func (c *Client) SendDM(ctx context.Context, userID, text string) (channelID, messageID string, err error) {
-	var channel *discordgo.Channel
-	err = retryableCtx(ctx, func() error {
+// DeleteMessage deletes a message from a text channel.
+func (c *Client) DeleteMessage(ctx context.Context, channelID, messageID string) error {
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	defer c.endWrite()
+	if err := c.session.ChannelMessageDelete(channelID, messageID); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	slog.Debug("deleted message", "channel_id", channelID, "message_id", messageID)
+	return nil
+}
+
+// DeleteThread deletes a forum thread along with its messages.
+func (c *Client) DeleteThread(ctx context.Context, threadID string) error {
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	defer c.endWrite()
+	if _, err := c.session.ChannelDelete(threadID); err != nil {
+		return fmt.Errorf("failed to delete thread: %w", err)
+	}
+
+	slog.Debug("deleted thread", "thread_id", threadID)
+	return nil
+}
+
+// UnarchiveThread reopens an archived forum thread so its starter message can be edited again.
+func (c *Client) UnarchiveThread(ctx context.Context, threadID string) error {
+	archived := false
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	defer c.endWrite()
+	_, err := c.session.ChannelEdit(threadID, &discordgo.ChannelEdit{
+		Archived: &archived,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unarchive thread: %w", err)
+	}
+
+	slog.Debug("unarchived thread", "thread_id", threadID)
+	return nil
+}
+
+// ActiveForumThreads returns the IDs of a forum's active (unarchived) threads. Discord only
+// lists active threads per guild, so the guild's are filtered to the forum's.
+func (c *Client) ActiveForumThreads(ctx context.Context, forumID string) ([]string, error) {
+	var threads *discordgo.ThreadsList
+	err := retryableCtx(ctx, func() error {
 		var err error
-		channel, err = c.session.UserChannelCreate(userID)
+		threads, err = c.session.GuildThreadsActive(c.guildID)
 		return err
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active threads: %w", err)
+	}
+
+	var ids []string
+	for _, thread := range threads.Threads {
+		if thread.ParentID == forumID {
+			ids = append(ids, thread.ID)
+		}
+	}
+	return ids, nil
+}
+
+// ErrDMBlocked is returned by SendDM when a user doesn't accept DMs from the bot, because
+// they've turned off DMs from server members or blocked it. Retrying won't help.
+var ErrDMBlocked = errors.New("user does not accept DMs from the bot")
+
+// ErrNotFound is returned by UpdateMessage and UpdateForumPost when the message or thread
+// no longer exists, e.g. because it was deleted in Discord. Retrying won't help.
+var ErrNotFound = errors.New("message or thread no longer exists")
+
+// isNotFound reports whether err is Discord's unknown message or unknown channel error.
+func isNotFound(err error) bool {
+	return hasErrorCode(err, discordgo.ErrCodeUnknownMessage) || hasErrorCode(err, discordgo.ErrCodeUnknownChannel)
+}
+
+// hasErrorCode reports whether err is a Discord API error with the given JSON error code.
+func hasErrorCode(err error, code int) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == code
+}
+
+// SendDM sends a direct message to a user with link embeds suppressed.
+// DMs about a single PR get quick-action buttons (see DMFooter).
+func (c *Client) SendDM(ctx context.Context, userID, text string) (channelID, messageID string, err error) {
+	channelID, err = c.dmChannel(ctx, userID, true)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create DM channel: %w", err)
 	}
 
 	var msg *discordgo.Message
 	err = retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		var err error
-		msg, err = c.session.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
-			Content: text,
-			Flags:   discordgo.MessageFlagsSuppressEmbeds,
+		msg, err = c.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Content:    text,
+			Components: dmFooterForText(text),
+			Flags:      discordgo.MessageFlagsSuppressEmbeds,
 		})
+		if hasErrorCode(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+			return retry.Unrecoverable(err)
+		}
 		return err
 	})
+	if hasErrorCode(err, discordgo.ErrCodeCannotSendMessagesToThisUser) {
+		return "", "", fmt.Errorf("failed to send DM: %w: %w", ErrDMBlocked, err)
+	}
 	if err != nil {
+		// The cached channel may be what failed; the next DM creates it afresh
+		c.forgetDMChannel(userID)
 		return "", "", fmt.Errorf("failed to send DM: %w", err)
 	}
 
 	slog.Info("sent DM",
 		"user_id", userID,
-		"channel_id", channel.ID,
+		"channel_id", channelID,
 		"message_id", msg.ID,
-		"content", text)
+		c.preview("content", text))
+
+	return channelID, msg.ID, nil
+}
+
+// dmChannel returns the ID of the DM channel with a user. Discord returns the same channel
+// for every UserChannelCreate call, so it's cached to save a round-trip per DM.
+// paced makes the create call wait on the request pacer, as writes do.
+func (c *Client) dmChannel(ctx context.Context, userID string, paced bool) (string, error) {
+	c.mu.RLock()
+	channelID, ok := c.dmChannelCache[userID]
+	c.mu.RUnlock()
+	if ok {
+		return channelID, nil
+	}
+
+	var channel *discordgo.Channel
+	err := retryableCtx(ctx, func() error {
+		if paced {
+			if err := c.beginWrite(ctx); err != nil {
+				return err
+			}
+			defer c.endWrite()
+		}
+		var err error
+		channel, err = c.session.UserChannelCreate(userID)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	if c.dmChannelCache == nil {
+		c.dmChannelCache = make(map[string]string)
+	}
+	c.dmChannelCache[userID] = channel.ID
+	c.mu.Unlock()
+	return channel.ID, nil
+}
 
-	return channel.ID, msg.ID, nil
+// forgetDMChannel drops a user's cached DM channel after a failure involving it.
+func (c *Client) forgetDMChannel(userID string) {
+	c.mu.Lock()
+	delete(c.dmChannelCache, userID)
+	c.mu.Unlock()
 }
 
 // UpdateDM updates an existing DM message.
 func (c *Client) UpdateDM(ctx context.Context, channelID, messageID, newText string) error {
 	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
 		_, err := c.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 			ID:      messageID,
 			Channel: channelID,
@@ -300,17 +780,21 @@ func (c *Client) UpdateDM(ctx context.Context, channelID, messageID, newText str
 	slog.Info("updated DM",
 		"channel_id", channelID,
 		"message_id", messageID,
-		"content", newText)
+		c.preview("content", newText))
 
 	return nil
 }
 
-// ResolveChannelID resolves a channel name to its ID.
+// ResolveChannelID resolves a channel name to its ID. Channel links pasted from Discord,
+// like <#123456789012345678>, resolve to the ID inside them.
 func (c *Client) ResolveChannelID(ctx context.Context, channelName string) string {
 	// If it looks like an ID already (long numeric string), return it
 	if len(channelName) > 15 && isAllDigits(channelName) {
 		return channelName
 	}
+	if id, ok := channelLinkID(channelName); ok {
+		return id
+	}
 
 	// Check cache
 	c.mu.RLock()
@@ -358,6 +842,59 @@ func (c *Client) ResolveChannelID(ctx context.Context, channelName string) strin
 	return channelName
 }
 
+// ResolveRoleID resolves a role name to its ID, for mentioning the role.
+func (c *Client) ResolveRoleID(ctx context.Context, roleName string) string {
+	// If it looks like an ID already (long numeric string), return it
+	if len(roleName) > 15 && isAllDigits(roleName) {
+		return roleName
+	}
+
+	// Check cache
+	c.mu.RLock()
+	if id, ok := c.roleCache[roleName]; ok {
+		c.mu.RUnlock()
+		return id
+	}
+	guildID := c.guildID
+	c.mu.RUnlock()
+
+	if guildID == "" {
+		return roleName
+	}
+
+	roles, err := c.session.GuildRoles(guildID)
+	if err != nil {
+		slog.Warn("failed to fetch guild roles",
+			"guild_id", guildID,
+			"error", err)
+		return roleName
+	}
+
+	name := strings.TrimPrefix(roleName, "@")
+
+	for _, role := range roles {
+		if role.Name != name {
+			continue
+		}
+
+		c.mu.Lock()
+		c.roleCache[roleName] = role.ID
+		c.mu.Unlock()
+
+		slog.Debug("resolved role",
+			"name", roleName,
+			"id", role.ID)
+
+		return role.ID
+	}
+
+	slog.Debug("role not found",
+		"name", roleName,
+		"guild_id", guildID)
+
+	return roleName
+}
+
 // ChannelType returns the type of a channel (forum, text, etc.).
 func (c *Client) ChannelType(ctx context.Context, channelID string) (discordgo.ChannelType, error) {
 	// Check cache first
@@ -391,6 +928,134 @@ func (c *Client) IsForumChannel(ctx context.Context, channelID string) bool {
 	return channelType == discordgo.ChannelTypeGuildForum
 }
 
+// IsNewsChannel returns true if the channel is an announcement channel.
+func (c *Client) IsNewsChannel(ctx context.Context, channelID string) bool {
+	channelType, err := c.ChannelType(ctx, channelID)
+	if err != nil {
+		slog.Debug("failed to check channel type", "channel_id", channelID, "error", err)
+		return false
+	}
+	return channelType == discordgo.ChannelTypeGuildNews
+}
+
+// CrosspostMessage publishes an announcement channel message to following servers.
+func (c *Client) CrosspostMessage(ctx context.Context, channelID, messageID string) error {
+	err := retryableCtx(ctx, func() error {
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
+		_, err := c.session.ChannelMessageCrosspost(channelID, messageID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to crosspost message: %w", err)
+	}
+
+	slog.Info("crossposted announcement message",
+		"channel_id", channelID,
+		"message_id", messageID)
+
+	return nil
+}
+
+// PinMessage pins a message, such as a board, so it stays easy to find however far the
+// channel scrolls. Pinning a pinned message is a no-op. Discord caps a channel's pins at
+// 50; at the cap, the bot's own oldest pin is unpinned to make room, never anyone else's.
+func (c *Client) PinMessage(ctx context.Context, channelID, messageID string) error {
+	err := c.pin(ctx, channelID, messageID)
+	if hasErrorCode(err, discordgo.ErrCodeMaximumPinsReached) {
+		if err := c.unpinOldestBotPin(ctx, channelID); err != nil {
+			return fmt.Errorf("failed to pin message: channel is at the pin limit: %w", err)
+		}
+		err = c.pin(ctx, channelID, messageID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to pin message: %w", err)
+	}
+
+	slog.Info("pinned message",
+		"channel_id", channelID,
+		"message_id", messageID)
+
+	return nil
+}
+
+// pin pins a message once; it isn't retried, so the pin limit is reported straight away.
+func (c *Client) pin(ctx context.Context, channelID, messageID string) error {
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	defer c.endWrite()
+	return c.session.ChannelMessagePin(channelID, messageID)
+}
+
+// unpinOldestBotPin unpins the oldest message the bot pinned in a channel.
+func (c *Client) unpinOldestBotPin(ctx context.Context, channelID string) error {
+	st := c.session.GetState()
+	if st == nil || st.User == nil {
+		return errors.New("bot user unknown")
+	}
+	if err := c.beginWrite(ctx); err != nil {
+		return err
+	}
+	pinned, err := c.session.ChannelMessagesPinned(channelID)
+	c.endWrite()
+	if err != nil {
+		return fmt.Errorf("list pins: %w", err)
+	}
+
+	// Pins are listed newest first
+	for i := len(pinned) - 1; i >= 0; i-- {
+		msg := pinned[i]
+		if msg.Author == nil || msg.Author.ID != st.User.ID {
+			continue
+		}
+		if err := c.beginWrite(ctx); err != nil {
+			return err
+		}
+		defer c.endWrite()
+		if err := c.session.ChannelMessageUnpin(channelID, msg.ID); err != nil {
+			return fmt.Errorf("unpin: %w", err)
+		}
+		slog.Info("unpinned oldest bot message to make room for a pin",
+			"channel_id", channelID,
+			"message_id", msg.ID)
+		return nil
+	}
+	return errors.New("no pins of the bot's to make room with")
+}
+
+// guildMembers lists a guild's members, paging through GuildMembers until the guild
+// or the client's member cap is exhausted.
+func (c *Client) guildMembers(guildID string) ([]*discordgo.Member, error) {
+	c.mu.RLock()
+	batchSize, maxMembers := c.memberBatchSize, c.maxMembers
+	c.mu.RUnlock()
+	if batchSize <= 0 {
+		batchSize = DefaultMemberBatchSize
+	}
+
+	var members []*discordgo.Member
+	after := ""
+	for {
+		limit := batchSize
+		if maxMembers > 0 {
+			limit = min(limit, maxMembers-len(members))
+		}
+		page, err := c.session.GuildMembers(guildID, after, limit)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) < limit || (maxMembers > 0 && len(members) >= maxMembers) {
+			return members, nil
+		}
+		// Pages are ordered by user ID, so the next page starts after this one's last member
+		after = page[len(page)-1].User.ID
+	}
+}
+
 // LookupUserByUsername finds a Discord user ID by username match.
 // Uses multi-tier matching: exact, case-insensitive, then prefix (if unambiguous).
 func (c *Client) LookupUserByUsername(ctx context.Context, username string) string {
@@ -406,7 +1071,7 @@ func (c *Client) LookupUserByUsername(ctx context.Context, username string) stri
 		return ""
 	}
 
-	members, err := c.session.GuildMembers(guildID, "", 1000)
+	members, err := c.guildMembers(guildID)
 	if err != nil {
 		slog.Warn("failed to fetch guild members",
 			"guild_id", guildID,
@@ -747,7 +1412,7 @@ func (c *Client) FindForumThread(ctx context.Context, forumID, prURL string) (th
 	var threads *discordgo.ThreadsList
 	err := retryableCtx(ctx, func() error {
 		var err error
-		threads, err = c.session.ThreadsActive(c.guildID)
+		threads, err = c.session.GuildThreadsActive(c.guildID)
 		return err
 	})
 	if err != nil {
@@ -830,16 +1495,12 @@ func (c *Client) FindChannelMessage(ctx context.Context, channelID, prURL string
 	for i, msg := range messages {
 		// Log first 5 messages for debugging
 		if i < 5 {
-			preview := msg.Content
-			if len(preview) > 100 {
-				preview = preview[:100] + "..."
-			}
 			slog.Debug("checking message",
 				"index", i,
 				"message_id", msg.ID,
 				"author_id", msg.Author.ID,
 				"is_bot_message", botID != "" && msg.Author.ID == botID,
-				"content_preview", preview)
+				c.preview("content", msg.Content))
 		}
 
 		if botID != "" && msg.Author.ID != botID {
@@ -867,12 +1528,7 @@ func (c *Client) FindChannelMessage(ctx context.Context, channelID, prURL string
 // FindDMForPR searches for an existing DM about a specific PR.
 // Returns channelID, messageID if found.
 func (c *Client) FindDMForPR(ctx context.Context, userID, prURL string) (channelID, messageID string, found bool) {
-	var channel *discordgo.Channel
-	err := retryableCtx(ctx, func() error {
-		var err error
-		channel, err = c.session.UserChannelCreate(userID)
-		return err
-	})
+	dmChannelID, err := c.dmChannel(ctx, userID, false)
 	if err != nil {
 		slog.Warn("failed to get DM channel after retries", "user_id", userID, "error", err)
 		return "", "", false
@@ -886,11 +1542,11 @@ func (c *Client) FindDMForPR(ctx context.Context, userID, prURL string) (channel
 	var messages []*discordgo.Message
 	err = retryableCtx(ctx, func() error {
 		var err error
-		messages, err = c.session.ChannelMessages(channel.ID, 50, "", "", "")
+		messages, err = c.session.ChannelMessages(dmChannelID, 50, "", "", "")
 		return err
 	})
 	if err != nil {
-		slog.Warn("failed to fetch DM messages after retries", "channel_id", channel.ID, "error", err)
+		slog.Warn("failed to fetch DM messages after retries", "channel_id", dmChannelID, "error", err)
 		return "", "", false
 	}
 
@@ -901,10 +1557,10 @@ func (c *Client) FindDMForPR(ctx context.Context, userID, prURL string) (channel
 		if strings.Contains(msg.Content, prURL) {
 			slog.Debug("found existing DM for PR",
 				"user_id", userID,
-				"channel_id", channel.ID,
+				"channel_id", dmChannelID,
 				"message_id", msg.ID,
 				"pr_url", prURL)
-			return channel.ID, msg.ID, true
+			return dmChannelID, msg.ID, true
 		}
 	}
 
@@ -925,6 +1581,20 @@ func (c *Client) MessageContent(ctx context.Context, channelID, messageID string
 	return msg.Content, nil
 }
 
+// channelLinkID returns the ID in a Discord channel link, <#id>. Anything else, including
+// links without a numeric ID, isn't one and is left to be resolved as a name.
+func channelLinkID(s string) (string, bool) {
+	id, ok := strings.CutPrefix(s, "<#")
+	if !ok {
+		return "", false
+	}
+	id, ok = strings.CutSuffix(id, ">")
+	if !ok || !isAllDigits(id) {
+		return "", false
+	}
+	return id, true
+}
+
 // isAllDigits returns true if the string is non-empty and contains only digit characters.
 func isAllDigits(s string) bool {
 	if s == "" {
@@ -2,9 +2,11 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -15,8 +17,11 @@ func newTestClientWithMock(mock *MockSession) *Client {
 		session:          mock,
 		realSession:      nil,
 		channelCache:     make(map[string]string),
+		roleCache:        make(map[string]string),
 		channelTypeCache: make(map[string]discordgo.ChannelType),
 		userCache:        make(map[string]string),
+		dmChannelCache:   make(map[string]string),
+		permissionCache:  make(map[string]permissionCacheEntry),
 	}
 }
 
@@ -454,6 +459,44 @@ func TestClient_ResolveChannelID_NoGuildID(t *testing.T) {
 	}
 }
 
+// TestClient_ResolveRoleID tests resolving role names, which are cached after the first lookup.
+func TestClient_ResolveRoleID(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.Roles = map[string][]*discordgo.Role{
+		"test-guild": {
+			{ID: "900000000000000001", Name: "@everyone"},
+			{ID: "900000000000000002", Name: "reviewers"},
+		},
+	}
+	client := newTestClientWithMock(mockSession)
+	client.guildID = "test-guild"
+	ctx := context.Background()
+
+	for _, name := range []string{"reviewers", "reviewers"} {
+		if got := client.ResolveRoleID(ctx, name); got != "900000000000000002" {
+			t.Errorf("ResolveRoleID(%q) = %q, want %q", name, got, "900000000000000002")
+		}
+	}
+	if mockSession.GuildRolesCalls != 1 {
+		t.Errorf("GuildRoles calls = %d, want 1 with the role cached", mockSession.GuildRolesCalls)
+	}
+
+	if got := client.ResolveRoleID(ctx, "@reviewers"); got != "900000000000000002" {
+		t.Errorf("ResolveRoleID(%q) = %q, want %q", "@reviewers", got, "900000000000000002")
+	}
+	if got := client.ResolveRoleID(ctx, "maintainers"); got != "maintainers" {
+		t.Errorf("ResolveRoleID(%q) = %q, want the name back for an unknown role", "maintainers", got)
+	}
+	if got := client.ResolveRoleID(ctx, "12345678901234567890"); got != "12345678901234567890" {
+		t.Errorf("ResolveRoleID() = %q, want an ID returned as-is", got)
+	}
+
+	mockSession.GuildRolesError = errors.New("api error")
+	if got := client.ResolveRoleID(ctx, "admins"); got != "admins" {
+		t.Errorf("ResolveRoleID(%q) = %q, want the name back on error", "admins", got)
+	}
+}
+
 // TestClient_ChannelType_CacheHit tests ChannelType with cached channel type.
 func TestClient_ChannelType_CacheHit(t *testing.T) {
 	client := &Client{
@@ -620,7 +663,7 @@ func TestClient_UpdateMessage(t *testing.T) {
 	messageID := "msg-456"
 	newText := "Updated content"
 
-	err := client.UpdateMessage(ctx, channelID, messageID, newText)
+	err := client.UpdateMessage(ctx, channelID, messageID, newText, false)
 	if err != nil {
 		t.Fatalf("UpdateMessage() error = %v, want nil", err)
 	}
@@ -648,12 +691,37 @@ func TestClient_UpdateMessage_Error(t *testing.T) {
 
 	client := newTestClientWithMock(mockSession)
 
-	err := client.UpdateMessage(context.Background(), "channel-123", "msg-456", "test")
+	err := client.UpdateMessage(context.Background(), "channel-123", "msg-456", "test", false)
 	if err == nil {
 		t.Error("UpdateMessage() error = nil, want error")
 	}
 }
 
+// TestClient_UpdateMessage_Silent tests that silent edits suppress notifications.
+func TestClient_UpdateMessage_Silent(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	if err := client.UpdateMessage(ctx, "channel-123", "msg-456", "quiet", true); err != nil {
+		t.Fatalf("UpdateMessage() error = %v, want nil", err)
+	}
+	if err := client.UpdateMessage(ctx, "channel-123", "msg-456", "loud", false); err != nil {
+		t.Fatalf("UpdateMessage() error = %v, want nil", err)
+	}
+
+	quiet, loud := mockSession.EditedMessages[0].Flags, mockSession.EditedMessages[1].Flags
+	if quiet&discordgo.MessageFlagsSuppressNotifications == 0 {
+		t.Error("silent edit should set SuppressNotifications")
+	}
+	if loud&discordgo.MessageFlagsSuppressNotifications != 0 {
+		t.Error("non-silent edit should not set SuppressNotifications")
+	}
+	if quiet&discordgo.MessageFlagsSuppressEmbeds == 0 || loud&discordgo.MessageFlagsSuppressEmbeds == 0 {
+		t.Error("edits should always suppress embeds")
+	}
+}
+
 // TestClient_PostForumThread tests creating a forum thread.
 func TestClient_PostForumThread(t *testing.T) {
 	mockSession := NewMockSession()
@@ -687,6 +755,25 @@ func TestClient_PostForumThread(t *testing.T) {
 	}
 }
 
+func TestClient_StartMessageThread(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+
+	threadID, err := client.StartMessageThread(context.Background(), "chan-1", "msg-1", "repo#1: Add feature")
+	if err != nil {
+		t.Fatalf("StartMessageThread() error = %v", err)
+	}
+	if threadID != "thread-msg-1" {
+		t.Errorf("StartMessageThread() = %q, want thread-msg-1", threadID)
+	}
+	if len(mockSession.CreatedThreads) != 1 || mockSession.CreatedThreads[0].ParentID != "chan-1" {
+		t.Fatalf("CreatedThreads = %v, want one thread in chan-1", mockSession.CreatedThreads)
+	}
+	if name := mockSession.CreatedThreads[0].Name; name != "repo#1: Add feature" {
+		t.Errorf("thread name = %q, want repo#1: Add feature", name)
+	}
+}
+
 // TestClient_PostForumThread_Error tests PostForumThread error handling.
 func TestClient_PostForumThread_Error(t *testing.T) {
 	mockSession := NewMockSession()
@@ -712,7 +799,7 @@ func TestClient_UpdateForumPost(t *testing.T) {
 	newTitle := "Updated PR Discussion"
 	newContent := "Updated content"
 
-	err := client.UpdateForumPost(ctx, threadID, messageID, newTitle, newContent)
+	err := client.UpdateForumPost(ctx, threadID, messageID, newTitle, newContent, false)
 	if err != nil {
 		t.Fatalf("UpdateForumPost() error = %v, want nil", err)
 	}
@@ -743,7 +830,7 @@ func TestClient_UpdateForumPost_NoMessageID(t *testing.T) {
 	newTitle := "Updated PR Discussion"
 	newContent := "Updated content"
 
-	err := client.UpdateForumPost(ctx, threadID, "", newTitle, newContent)
+	err := client.UpdateForumPost(ctx, threadID, "", newTitle, newContent, false)
 	if err != nil {
 		t.Fatalf("UpdateForumPost() error = %v, want nil", err)
 	}
@@ -761,7 +848,7 @@ func TestClient_UpdateForumPost_TitleEditError(t *testing.T) {
 	client := newTestClientWithMock(mockSession)
 
 	ctx := context.Background()
-	err := client.UpdateForumPost(ctx, "thread-123", "msg-456", "New Title", "New Content")
+	err := client.UpdateForumPost(ctx, "thread-123", "msg-456", "New Title", "New Content", false)
 	if err == nil {
 		t.Error("UpdateForumPost() error = nil, want error when title edit fails")
 	}
@@ -774,7 +861,7 @@ func TestClient_UpdateForumPost_MessageEditError(t *testing.T) {
 	client := newTestClientWithMock(mockSession)
 
 	ctx := context.Background()
-	err := client.UpdateForumPost(ctx, "thread-123", "msg-456", "New Title", "New Content")
+	err := client.UpdateForumPost(ctx, "thread-123", "msg-456", "New Title", "New Content", false)
 	if err == nil {
 		t.Error("UpdateForumPost() error = nil, want error when message edit fails")
 	}
@@ -802,6 +889,41 @@ func TestClient_PostForumThread_MessagesFetchError(t *testing.T) {
 	}
 }
 
+func TestClient_PostForumThread_MessagesFetchRetried(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.MessagesError = errors.New("messages fetch failed")
+	mockSession.MessagesFailures = 1
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("guild-123")
+
+	threadID, messageID, err := client.PostForumThread(context.Background(), "channel-123", "title", "content")
+	if err != nil {
+		t.Fatalf("PostForumThread() error = %v, want nil", err)
+	}
+	if threadID == "" || messageID == "" {
+		t.Errorf("PostForumThread() = %q, %q, want the starter message ID after a retry", threadID, messageID)
+	}
+	if mockSession.MessagesCalls != 2 {
+		t.Errorf("ChannelMessages calls = %d, want 2", mockSession.MessagesCalls)
+	}
+}
+
+func TestClient_ForumStarterMessageID(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.Messages["thread-1"] = []*discordgo.Message{{ID: "msg-starter", ChannelID: "thread-1"}}
+	client := newTestClientWithMock(mockSession)
+	client.SetStarterFetchAttempts(1)
+
+	id, err := client.ForumStarterMessageID(context.Background(), "thread-1")
+	if err != nil || id != "msg-starter" {
+		t.Errorf("ForumStarterMessageID() = %q, %v, want msg-starter", id, err)
+	}
+
+	if _, err := client.ForumStarterMessageID(context.Background(), "thread-empty"); !errors.Is(err, errNoStarterMessage) {
+		t.Errorf("ForumStarterMessageID() of an empty thread error = %v, want errNoStarterMessage", err)
+	}
+}
+
 // TestClient_ArchiveThread tests archiving a thread.
 func TestClient_ArchiveThread(t *testing.T) {
 	mockSession := NewMockSession()
@@ -839,6 +961,33 @@ func TestClient_ArchiveThread_Error(t *testing.T) {
 	}
 }
 
+func TestClient_DeleteMessageAndThread(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	if err := client.DeleteMessage(ctx, "chan-1", "msg-1"); err != nil {
+		t.Fatalf("DeleteMessage() error = %v", err)
+	}
+	if err := client.DeleteThread(ctx, "thread-1"); err != nil {
+		t.Fatalf("DeleteThread() error = %v", err)
+	}
+	if len(mockSession.DeletedMessages) != 1 || mockSession.DeletedMessages[0] != "chan-1/msg-1" {
+		t.Errorf("DeletedMessages = %v, want [chan-1/msg-1]", mockSession.DeletedMessages)
+	}
+	if len(mockSession.DeletedChannels) != 1 || mockSession.DeletedChannels[0] != "thread-1" {
+		t.Errorf("DeletedChannels = %v, want [thread-1]", mockSession.DeletedChannels)
+	}
+
+	mockSession.DeleteError = fmt.Errorf("API error")
+	if err := client.DeleteMessage(ctx, "chan-1", "msg-2"); err == nil {
+		t.Error("DeleteMessage() error = nil, want error")
+	}
+	if err := client.DeleteThread(ctx, "thread-2"); err == nil {
+		t.Error("DeleteThread() error = nil, want error")
+	}
+}
+
 // TestClient_SendDM tests sending a direct message.
 func TestClient_SendDM(t *testing.T) {
 	mockSession := NewMockSession()
@@ -900,6 +1049,92 @@ func TestClient_SendDM_MessageSendError(t *testing.T) {
 	}
 }
 
+// TestClient_SendDM_Blocked tests that a user who doesn't accept DMs is reported without retrying.
+func TestClient_SendDM_Blocked(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.ChannelMessageSendComplexError = &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+		Code:    discordgo.ErrCodeCannotSendMessagesToThisUser,
+		Message: "Cannot send messages to this user",
+	}}
+
+	client := newTestClientWithMock(mockSession)
+
+	start := time.Now()
+	_, _, err := client.SendDM(context.Background(), "user-123", "test")
+	if !errors.Is(err, ErrDMBlocked) {
+		t.Errorf("SendDM() error = %v, want ErrDMBlocked", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("SendDM() took %v, want no retries for a blocked user", elapsed)
+	}
+}
+
+// TestClient_UpdateMessage_NotFound tests that editing a deleted message is reported without retrying.
+func TestClient_UpdateMessage_NotFound(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.ChannelMessageEditComplexError = &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+		Code:    discordgo.ErrCodeUnknownMessage,
+		Message: "Unknown Message",
+	}}
+
+	client := newTestClientWithMock(mockSession)
+
+	start := time.Now()
+	err := client.UpdateMessage(context.Background(), "channel-123", "msg-123", "test", false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateMessage() error = %v, want ErrNotFound", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("UpdateMessage() took %v, want no retries for a deleted message", elapsed)
+	}
+
+	// A deleted forum thread is reported the same way
+	mockSession.ChannelEditError = &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+		Code:    discordgo.ErrCodeUnknownChannel,
+		Message: "Unknown Channel",
+	}}
+	if err := client.UpdateForumPost(context.Background(), "thread-123", "msg-123", "title", "test", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateForumPost() error = %v, want ErrNotFound", err)
+	}
+}
+
+// TestClient_SendDM_ReusesDMChannel tests that repeated DMs to a user skip the channel create call.
+func TestClient_SendDM_ReusesDMChannel(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	for i := range 2 {
+		if _, _, err := client.SendDM(ctx, "user-123", fmt.Sprintf("DM %d", i)); err != nil {
+			t.Fatalf("SendDM() #%d error = %v", i+1, err)
+		}
+	}
+	if len(mockSession.CreatedChannels) != 1 {
+		t.Errorf("created DM channels = %d after two DMs, want 1", len(mockSession.CreatedChannels))
+	}
+	if _, _, found := client.FindDMForPR(ctx, "user-123", "https://github.com/owner/repo/pull/1"); found {
+		t.Error("FindDMForPR() found = true, want false")
+	}
+	if len(mockSession.CreatedChannels) != 1 {
+		t.Errorf("created DM channels = %d after FindDMForPR, want cached channel reused", len(mockSession.CreatedChannels))
+	}
+
+	// A failed send forgets the channel, so the next DM creates it again
+	mockSession.ChannelMessageSendComplexError = errors.New("unknown channel")
+	failCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.SendDM(failCtx, "user-123", "DM 3"); err == nil {
+		t.Fatal("SendDM() error = nil, want error")
+	}
+	mockSession.ChannelMessageSendComplexError = nil
+	if _, _, err := client.SendDM(ctx, "user-123", "DM 4"); err != nil {
+		t.Fatalf("SendDM() after failure error = %v", err)
+	}
+	if len(mockSession.CreatedChannels) != 2 {
+		t.Errorf("created DM channels = %d after a failed send, want 2", len(mockSession.CreatedChannels))
+	}
+}
+
 // TestClient_UpdateDM tests updating a DM.
 func TestClient_UpdateDM(t *testing.T) {
 	mockSession := NewMockSession()
@@ -960,6 +1195,65 @@ func TestClient_LookupUserByUsername(t *testing.T) {
 	}
 }
 
+// TestClient_guildMembers_Pagination tests that members are assembled across GuildMembers pages.
+func TestClient_guildMembers_Pagination(t *testing.T) {
+	mockSession := NewMockSession()
+	for i := range 7 {
+		mockSession.AddMember("guild-123", NewMockMember(fmt.Sprintf("user-%d", i), fmt.Sprintf("member%d", i), ""))
+	}
+
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("guild-123")
+	client.SetMemberScan(3, 0)
+
+	members, err := client.guildMembers("guild-123")
+	if err != nil {
+		t.Fatalf("guildMembers() error = %v", err)
+	}
+	if len(members) != 7 {
+		t.Fatalf("guildMembers() returned %d members, want 7", len(members))
+	}
+	for i, member := range members {
+		if want := fmt.Sprintf("user-%d", i); member.User.ID != want {
+			t.Errorf("members[%d] = %s, want %s", i, member.User.ID, want)
+		}
+	}
+	if mockSession.GuildMembersCalls != 3 {
+		t.Errorf("GuildMembers calls = %d, want 3 pages", mockSession.GuildMembersCalls)
+	}
+
+	// A member on the last page is found by username
+	if id := client.LookupUserByUsername(context.Background(), "member6"); id != "user-6" {
+		t.Errorf("LookupUserByUsername(\"member6\") = %q, want %q", id, "user-6")
+	}
+}
+
+// TestClient_guildMembers_Cap tests that the member cap bounds how many members are scanned.
+func TestClient_guildMembers_Cap(t *testing.T) {
+	mockSession := NewMockSession()
+	for i := range 7 {
+		mockSession.AddMember("guild-123", NewMockMember(fmt.Sprintf("user-%d", i), fmt.Sprintf("member%d", i), ""))
+	}
+
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("guild-123")
+	client.SetMemberScan(3, 4)
+
+	members, err := client.guildMembers("guild-123")
+	if err != nil {
+		t.Fatalf("guildMembers() error = %v", err)
+	}
+	if len(members) != 4 {
+		t.Errorf("guildMembers() returned %d members, want 4", len(members))
+	}
+	if mockSession.GuildMembersCalls != 2 {
+		t.Errorf("GuildMembers calls = %d, want 2", mockSession.GuildMembersCalls)
+	}
+	if id := client.LookupUserByUsername(context.Background(), "member6"); id != "" {
+		t.Errorf("LookupUserByUsername(\"member6\") = %q beyond the cap, want empty", id)
+	}
+}
+
 // TestClient_LookupUserByUsername_CacheHit tests user lookup with cached value.
 func TestClient_LookupUserByUsername_CacheHit(t *testing.T) {
 	client := newTestClientWithMock(NewMockSession())
@@ -1033,6 +1327,95 @@ func TestClient_IsForumChannel_TextChannel(t *testing.T) {
 	}
 }
 
+// TestClient_IsNewsChannel tests announcement channel detection.
+func TestClient_IsNewsChannel(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.AddChannel(&discordgo.Channel{ID: "news-123", Type: discordgo.ChannelTypeGuildNews})
+	mockSession.AddChannel(&discordgo.Channel{ID: "text-123", Type: discordgo.ChannelTypeGuildText})
+
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	if !client.IsNewsChannel(ctx, "news-123") {
+		t.Error("IsNewsChannel() = false, want true for announcement channel")
+	}
+	if client.IsNewsChannel(ctx, "text-123") {
+		t.Error("IsNewsChannel() = true, want false for text channel")
+	}
+}
+
+// TestClient_CrosspostMessage tests publishing an announcement message.
+func TestClient_CrosspostMessage(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+
+	if err := client.CrosspostMessage(context.Background(), "news-123", "msg-456"); err != nil {
+		t.Fatalf("CrosspostMessage() error = %v", err)
+	}
+	if len(mockSession.Crossposted) != 1 || mockSession.Crossposted[0] != "msg-456" {
+		t.Errorf("Crossposted = %v, want [msg-456]", mockSession.Crossposted)
+	}
+}
+
+func TestClient_PinMessage(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.MockState.User = &discordgo.User{ID: "bot-123"}
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	if err := client.PinMessage(ctx, "chan-1", "board-1"); err != nil {
+		t.Fatalf("PinMessage() error = %v", err)
+	}
+	if pins := mockSession.Pins["chan-1"]; len(pins) != 1 || pins[0].ID != "board-1" {
+		t.Errorf("pins = %v, want the board message pinned", pins)
+	}
+
+	// Pinning again is a no-op
+	if err := client.PinMessage(ctx, "chan-1", "board-1"); err != nil {
+		t.Fatalf("PinMessage() again error = %v", err)
+	}
+	if len(mockSession.Pins["chan-1"]) != 1 {
+		t.Errorf("pins = %d after pinning twice, want 1", len(mockSession.Pins["chan-1"]))
+	}
+}
+
+func TestClient_PinMessage_PinLimit(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.MockState.User = &discordgo.User{ID: "bot-123"}
+	mockSession.PinLimit = 3
+	bot := &discordgo.User{ID: "bot-123"}
+	human := &discordgo.User{ID: "user-456"}
+	mockSession.Pins = map[string][]*discordgo.Message{
+		"chan-1": { // Newest first
+			{ID: "bot-new", Author: bot},
+			{ID: "bot-old", Author: bot},
+			{ID: "rules", Author: human},
+		},
+		"chan-2": {{ID: "a", Author: human}, {ID: "b", Author: human}, {ID: "c", Author: human}},
+	}
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	if err := client.PinMessage(ctx, "chan-1", "board-1"); err != nil {
+		t.Fatalf("PinMessage() at the pin limit error = %v", err)
+	}
+	var ids []string
+	for _, msg := range mockSession.Pins["chan-1"] {
+		ids = append(ids, msg.ID)
+	}
+	if got := strings.Join(ids, ","); got != "board-1,bot-new,rules" {
+		t.Errorf("pins = %s, want the bot's oldest pin replaced by the board", got)
+	}
+
+	// Other users' pins are never unpinned
+	if err := client.PinMessage(ctx, "chan-2", "board-2"); err == nil {
+		t.Error("PinMessage() = nil with only others' pins at the limit, want error")
+	}
+	if len(mockSession.Pins["chan-2"]) != 3 {
+		t.Errorf("pins = %d, want others' pins left alone", len(mockSession.Pins["chan-2"]))
+	}
+}
+
 // TestClient_IsForumChannel_Error tests IsForumChannel error handling.
 func TestClient_IsForumChannel_Error(t *testing.T) {
 	mockSession := NewMockSession()
@@ -1336,6 +1719,33 @@ func TestClient_ResolveChannelID_WithHashPrefix(t *testing.T) {
 	}
 }
 
+func TestClient_ResolveChannelID_ChannelLink(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("test-guild")
+	for _, name := range []string{"<#123>", "<#abc>", "<#>", "<#12345678901234567890"} {
+		mockSession.AddChannel(&discordgo.Channel{ID: "named-" + name, Name: name, GuildID: "test-guild"})
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "<#12345678901234567890>", want: "12345678901234567890"},
+		{input: "<#123>", want: "123"},
+		// Malformed links fall through to name resolution
+		{input: "<#abc>", want: "named-<#abc>"},
+		{input: "<#>", want: "named-<#>"},
+		{input: "<#12345678901234567890", want: "named-<#12345678901234567890"},
+		{input: "<#not-a-channel>", want: "<#not-a-channel>"},
+	}
+	for _, tt := range tests {
+		if got := client.ResolveChannelID(context.Background(), tt.input); got != tt.want {
+			t.Errorf("ResolveChannelID(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
 // Note: FindForumThread tests are skipped because they require realSession.ThreadsArchived()
 // which is complex to mock. The method uses realSession directly for archived threads.
 
@@ -1701,3 +2111,22 @@ func TestSessionAdapter_GetState(t *testing.T) {
 		t.Errorf("GetState().User.ID = %v, want bot-123", gotState.User.ID)
 	}
 }
+
+// TestClient_ActiveForumThreads tests listing a forum's active threads.
+func TestClient_ActiveForumThreads(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("test-guild")
+
+	mockSession.AddActiveThread(&discordgo.Channel{ID: "thread-1", ParentID: "forum-1"})
+	mockSession.AddActiveThread(&discordgo.Channel{ID: "thread-2", ParentID: "forum-2"})
+	mockSession.AddActiveThread(&discordgo.Channel{ID: "thread-3", ParentID: "forum-1"})
+
+	got, err := client.ActiveForumThreads(context.Background(), "forum-1")
+	if err != nil {
+		t.Fatalf("ActiveForumThreads() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "thread-1" || got[1] != "thread-3" {
+		t.Errorf("ActiveForumThreads() = %v, want [thread-1 thread-3]", got)
+	}
+}
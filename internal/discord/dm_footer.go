@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DM footer quick actions. Button custom IDs carry the action and PR URL.
+const (
+	dmActionSnooze   = "dm_snooze"
+	dmActionMute     = "dm_mute"
+	dmSnoozeDuration = time.Hour
+	maxCustomIDLen   = 100 // Discord's limit on component custom IDs
+)
+
+var prURLPattern = regexp.MustCompile(`https://github\.com/[^/\s()]+/[^/\s()]+/pull/\d+`)
+
+// DMActionHandler applies the quick actions offered in DM footers.
+type DMActionHandler interface {
+	// SnoozeDM holds off DMs to a user about a PR for the given duration.
+	SnoozeDM(ctx context.Context, userID, prURL string, d time.Duration) error
+	// MuteDM stops DMs to a user about a PR.
+	MuteDM(ctx context.Context, userID, prURL string) error
+}
+
+// DMFooter renders the "Snooze 1h · Mute · Open PR" buttons shown under a PR's DM.
+func DMFooter(prURL string) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	if len(dmActionSnooze)+1+len(prURL) <= maxCustomIDLen {
+		buttons = append(buttons,
+			discordgo.Button{
+				Label:    "Snooze 1h",
+				Style:    discordgo.SecondaryButton,
+				Emoji:    &discordgo.ComponentEmoji{Name: "😴"},
+				CustomID: dmActionSnooze + ":" + prURL,
+			},
+			discordgo.Button{
+				Label:    "Mute",
+				Style:    discordgo.SecondaryButton,
+				Emoji:    &discordgo.ComponentEmoji{Name: "🔕"},
+				CustomID: dmActionMute + ":" + prURL,
+			},
+		)
+	}
+	buttons = append(buttons, discordgo.Button{
+		Label: "Open PR",
+		Style: discordgo.LinkButton,
+		URL:   prURL,
+	})
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// dmFooterForText returns the footer for a DM about a single PR.
+// DMs covering several PRs, such as digests and daily reports, get none.
+func dmFooterForText(text string) []discordgo.MessageComponent {
+	urls := prURLPattern.FindAllString(text, -1)
+	if len(urls) == 0 {
+		return nil
+	}
+	for _, u := range urls[1:] {
+		if u != urls[0] {
+			return nil
+		}
+	}
+	return DMFooter(urls[0])
+}
+
+// parseDMAction splits a DM footer button's custom ID into its action and PR URL.
+func parseDMAction(customID string) (action, prURL string, ok bool) {
+	action, prURL, found := strings.Cut(customID, ":")
+	if !found || (action != dmActionSnooze && action != dmActionMute) || !prURLPattern.MatchString(prURL) {
+		return "", "", false
+	}
+	return action, prURL, true
+}
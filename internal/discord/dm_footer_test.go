@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func footerButtons(t *testing.T, components []discordgo.MessageComponent) []discordgo.Button {
+	t.Helper()
+	if len(components) != 1 {
+		t.Fatalf("components = %d, want 1 action row", len(components))
+	}
+	row, ok := components[0].(discordgo.ActionsRow)
+	if !ok {
+		t.Fatalf("component = %T, want ActionsRow", components[0])
+	}
+	buttons := make([]discordgo.Button, 0, len(row.Components))
+	for _, c := range row.Components {
+		b, ok := c.(discordgo.Button)
+		if !ok {
+			t.Fatalf("row component = %T, want Button", c)
+		}
+		buttons = append(buttons, b)
+	}
+	return buttons
+}
+
+func TestDMFooter(t *testing.T) {
+	const prURL = "https://github.com/org/repo/pull/42"
+	buttons := footerButtons(t, DMFooter(prURL))
+
+	if len(buttons) != 3 {
+		t.Fatalf("buttons = %d, want 3", len(buttons))
+	}
+	if buttons[0].Label != "Snooze 1h" || buttons[0].CustomID != "dm_snooze:"+prURL {
+		t.Errorf("snooze button = %+v", buttons[0])
+	}
+	if buttons[1].Label != "Mute" || buttons[1].CustomID != "dm_mute:"+prURL {
+		t.Errorf("mute button = %+v", buttons[1])
+	}
+	if buttons[2].Style != discordgo.LinkButton || buttons[2].URL != prURL {
+		t.Errorf("open button = %+v", buttons[2])
+	}
+
+	// Custom IDs that would exceed Discord's limit leave only the link
+	longURL := "https://github.com/org/" + strings.Repeat("r", 80) + "/pull/1"
+	if buttons := footerButtons(t, DMFooter(longURL)); len(buttons) != 1 || buttons[0].URL != longURL {
+		t.Errorf("long URL footer = %+v, want only the link", buttons)
+	}
+}
+
+func TestDMFooterForText(t *testing.T) {
+	if got := dmFooterForText("🔍 **review**: [org/repo#1](https://github.com/org/repo/pull/1) Fix by alice"); got == nil {
+		t.Error("single-PR DM should get a footer")
+	}
+	if got := dmFooterForText("[org/a#1](https://github.com/org/a/pull/1)\n[org/b#2](https://github.com/org/b/pull/2)"); got != nil {
+		t.Error("multi-PR DM should get no footer")
+	}
+	if got := dmFooterForText("Hello"); got != nil {
+		t.Error("DM without a PR should get no footer")
+	}
+}
+
+func TestParseDMAction(t *testing.T) {
+	tests := []struct {
+		customID   string
+		wantAction string
+		wantURL    string
+		wantOK     bool
+	}{
+		{"dm_snooze:https://github.com/org/repo/pull/1", dmActionSnooze, "https://github.com/org/repo/pull/1", true},
+		{"dm_mute:https://github.com/org/repo/pull/1", dmActionMute, "https://github.com/org/repo/pull/1", true},
+		{"dm_delete:https://github.com/org/repo/pull/1", "", "", false},
+		{"dm_snooze:not-a-url", "", "", false},
+		{"dm_snooze", "", "", false},
+	}
+	for _, tt := range tests {
+		action, url, ok := parseDMAction(tt.customID)
+		if action != tt.wantAction || url != tt.wantURL || ok != tt.wantOK {
+			t.Errorf("parseDMAction(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.customID, action, url, ok, tt.wantAction, tt.wantURL, tt.wantOK)
+		}
+	}
+}
+
+func TestClient_SendDM_Footer(t *testing.T) {
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+
+	text := "🔍 **review**: [org/repo#1](https://github.com/org/repo/pull/1) Fix by alice"
+	if _, _, err := client.SendDM(context.Background(), "user-123", text); err != nil {
+		t.Fatalf("SendDM() error = %v", err)
+	}
+
+	buttons := footerButtons(t, mockSession.SentMessages[0].Components)
+	if len(buttons) != 3 {
+		t.Errorf("footer buttons = %d, want 3", len(buttons))
+	}
+}
@@ -1,17 +1,23 @@
 package discord
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"log/slog"
+	"strconv"
 	"sync"
 )
 
 // GuildManager manages Discord clients for multiple guilds.
 type GuildManager struct {
-	logger  *slog.Logger
-	clients map[string]*Client // guildID -> client
-	mu      sync.RWMutex
+	logger       *slog.Logger
+	clients      map[string]*Client        // guildID -> client
+	sessions     map[string]*sharedSession // bot token and shard -> gateway session its guilds share
+	pacer        *RequestPacer             // Shared by all clients, which use the same bot token
+	shardCount   int                       // Gateway shards; 0 or 1 means unsharded
+	openAttempts int                       // Tries to open each client's connection; 0 uses DefaultOpenAttempts
+	mu           sync.RWMutex
 }
 
 // NewGuildManager creates a new guild manager.
@@ -20,11 +26,41 @@ func NewGuildManager(logger *slog.Logger) *GuildManager {
 		logger = slog.Default()
 	}
 	return &GuildManager{
-		logger:  logger,
-		clients: make(map[string]*Client),
+		logger:   logger,
+		clients:  make(map[string]*Client),
+		sessions: make(map[string]*sharedSession),
 	}
 }
 
+// SetShardCount shards gateway connections across count shards. Each guild's client
+// connects as the shard Discord routes that guild's events to. Needed beyond ~2500 guilds.
+func (m *GuildManager) SetShardCount(count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shardCount = count
+}
+
+// SetOpenAttempts sets how many times ClientFromToken tries to open a client's connection.
+func (m *GuildManager) SetOpenAttempts(attempts int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.openAttempts = attempts
+}
+
+// SetRequestPacer paces write requests across every client the manager creates.
+func (m *GuildManager) SetRequestPacer(pacer *RequestPacer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pacer = pacer
+}
+
+// RequestPacer returns the pacer shared by the manager's clients, or nil if writes aren't paced.
+func (m *GuildManager) RequestPacer() *RequestPacer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pacer
+}
+
 // RegisterClient registers a Discord client for a guild.
 func (m *GuildManager) RegisterClient(guildID string, client *Client) {
 	m.mu.Lock()
@@ -107,17 +143,68 @@ func (m *GuildManager) ForEach(fn func(guildID string, client *Client)) {
 
 // ClientFromToken creates and registers a new client from a bot token.
 func (m *GuildManager) ClientFromToken(ctx context.Context, guildID, token string) (*Client, error) {
-	client, err := New(token)
+	client, err := m.NewClient(guildID, token)
 	if err != nil {
 		return nil, err
 	}
+	return m.openAndRegister(ctx, guildID, client)
+}
+
+// NewClient creates an unopened client for a guild. Guilds on the same bot token and shard
+// share one gateway session, which connects when the first of their clients opens.
+func (m *GuildManager) NewClient(guildID, token string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	shard := ShardForGuild(guildID, m.shardCount)
+	key := token + "/" + strconv.Itoa(shard)
+	shared, ok := m.sessions[key]
+	if !ok {
+		session, err := newGatewaySession(token)
+		if err != nil {
+			return nil, err
+		}
+		if m.shardCount > 1 {
+			session.ShardID = shard
+			session.ShardCount = m.shardCount
+			m.logger.Info("sharding Discord gateway connection",
+				"shard_id", shard,
+				"shard_count", m.shardCount)
+		}
+		shared = &sharedSession{sessionAdapter: &sessionAdapter{Session: session}}
+		m.sessions[key] = shared
+	}
 
+	client := newClient(shared.Session, shared)
 	client.SetGuildID(guildID)
+	client.SetRequestPacer(m.pacer)
+	return client, nil
+}
+
+// openAndRegister opens a client's connection, retrying transient failures, and registers it.
+func (m *GuildManager) openAndRegister(ctx context.Context, guildID string, client *Client) (*Client, error) {
+	m.mu.RLock()
+	attempts := cmp.Or(m.openAttempts, DefaultOpenAttempts)
+	m.mu.RUnlock()
 
-	if err := client.Open(); err != nil {
+	if err := client.OpenWithRetry(ctx, attempts); err != nil {
 		return nil, err
 	}
 
 	m.RegisterClient(guildID, client)
 	return client, nil
 }
+
+// DisconnectedGuilds returns the IDs of guilds whose gateway connection is down.
+func (m *GuildManager) DisconnectedGuilds() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ids []string
+	for id, client := range m.clients {
+		if !client.Connected() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -1,6 +1,9 @@
 package discord
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/bwmarrin/discordgo"
@@ -199,3 +202,190 @@ func TestGuildManager_RemoveClient_Cleanup(t *testing.T) {
 		}
 	}
 }
+
+func TestGuildManager_openAndRegister_RetriesOpen(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.OpenError = errors.New("gateway unavailable")
+	mockSession.OpenFailures = 2
+	client := newTestClientWithMock(mockSession)
+	m := NewGuildManager(nil)
+
+	got, err := m.openAndRegister(context.Background(), "guild1", client)
+	if err != nil {
+		t.Fatalf("openAndRegister() error = %v", err)
+	}
+	if got != client {
+		t.Error("openAndRegister() should return the client")
+	}
+	if mockSession.OpenCalls != 3 {
+		t.Errorf("Open() calls = %d, want 3", mockSession.OpenCalls)
+	}
+	if _, ok := m.Client("guild1"); !ok {
+		t.Error("client should be registered after a successful open")
+	}
+	if !client.Connected() || len(m.DisconnectedGuilds()) != 0 {
+		t.Error("client should be connected after a successful open")
+	}
+}
+
+func TestGuildManager_openAndRegister_Exhausted(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.OpenError = errors.New("gateway unavailable")
+	client := newTestClientWithMock(mockSession)
+	m := NewGuildManager(nil)
+
+	_, err := m.openAndRegister(context.Background(), "guild1", client)
+	if err == nil || !strings.Contains(err.Error(), "after 4 attempts") || !strings.Contains(err.Error(), "gateway unavailable") {
+		t.Errorf("openAndRegister() error = %v, want attempts and cause", err)
+	}
+	if mockSession.OpenCalls != DefaultOpenAttempts {
+		t.Errorf("Open() calls = %d, want %d", mockSession.OpenCalls, DefaultOpenAttempts)
+	}
+	if _, ok := m.Client("guild1"); ok {
+		t.Error("client should not be registered when open fails")
+	}
+}
+
+func TestGuildManager_openAndRegister_ConfiguredAttempts(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.OpenError = errors.New("gateway unavailable")
+	client := newTestClientWithMock(mockSession)
+	m := NewGuildManager(nil)
+	m.SetOpenAttempts(2)
+
+	if _, err := m.openAndRegister(context.Background(), "guild1", client); err == nil {
+		t.Fatal("openAndRegister() error = nil, want error")
+	}
+	if mockSession.OpenCalls != 2 {
+		t.Errorf("Open() calls = %d, want the configured 2", mockSession.OpenCalls)
+	}
+}
+
+func TestGuildManager_DisconnectedGuilds(t *testing.T) {
+	m := NewGuildManager(nil)
+	client := newTestClientWithMock(NewMockSession())
+	m.RegisterClient("guild1", client)
+
+	client.onConnect(nil, &discordgo.Connect{})
+	if len(m.DisconnectedGuilds()) != 0 {
+		t.Errorf("DisconnectedGuilds() = %v, want none after connect", m.DisconnectedGuilds())
+	}
+
+	client.onDisconnect(nil, &discordgo.Disconnect{})
+	if got := m.DisconnectedGuilds(); len(got) != 1 || got[0] != "guild1" {
+		t.Errorf("DisconnectedGuilds() = %v, want [guild1] after disconnect", got)
+	}
+
+	// discordgo resumes the session on its own
+	client.onResumed(nil, &discordgo.Resumed{})
+	if !client.Connected() {
+		t.Error("Connected() = false after resume, want true")
+	}
+}
+
+func TestShardForGuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		guildID    string
+		shardCount int
+		want       int
+	}{
+		{"unsharded", "41771983423143937", 1, 0},
+		{"zero count", "41771983423143937", 0, 0},
+		{"four shards", "41771983423143937", 4, 2},
+		{"sixteen shards", "41771983423143937", 16, 6},
+		{"invalid guild ID", "not-a-snowflake", 4, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShardForGuild(tt.guildID, tt.shardCount); got != tt.want {
+				t.Errorf("ShardForGuild(%s, %d) = %d, want %d", tt.guildID, tt.shardCount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuildManager_NewClient_Sharding(t *testing.T) {
+	manager := NewGuildManager(nil)
+
+	client, err := manager.NewClient("41771983423143937", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.realSession.ShardCount > 1 {
+		t.Errorf("unsharded ShardCount = %d, want at most 1", client.realSession.ShardCount)
+	}
+
+	manager.SetShardCount(16)
+	client, err = manager.NewClient("41771983423143937", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.GuildID() != "41771983423143937" {
+		t.Errorf("GuildID() = %q", client.GuildID())
+	}
+	if client.realSession.ShardID != 6 || client.realSession.ShardCount != 16 {
+		t.Errorf("session shard = %d/%d, want 6/16", client.realSession.ShardID, client.realSession.ShardCount)
+	}
+}
+
+func TestGuildManager_NewClient_SharesSession(t *testing.T) {
+	manager := NewGuildManager(nil)
+	manager.SetShardCount(16)
+
+	// 41771983423143937 and 41771983423143938 are on shard 6; 41771983444115456 is on shard 11
+	first, err := manager.NewClient("41771983423143937", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	second, err := manager.NewClient("41771983423143938", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	other, err := manager.NewClient("41771983444115456", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if first.Session() != second.Session() {
+		t.Error("guilds on the same shard should share a gateway session")
+	}
+	if first.Session() == other.Session() {
+		t.Error("guilds on different shards should have their own gateway sessions")
+	}
+	if other.Session().ShardID != 11 {
+		t.Errorf("other session ShardID = %d, want 11", other.Session().ShardID)
+	}
+
+	// The session stays up until its last client closes
+	shared, ok := first.session.(*sharedSession)
+	if !ok {
+		t.Fatalf("client session is %T, want *sharedSession", first.session)
+	}
+	shared.clients = 2
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if shared.clients != 1 {
+		t.Errorf("clients after one Close() = %d, want 1", shared.clients)
+	}
+	if len(first.removeHandlers) != 0 {
+		t.Error("Close() should unregister the client's handlers from the shared session")
+	}
+}
+
+func TestGuildManager_NewClient_SharesRequestPacer(t *testing.T) {
+	manager := NewGuildManager(nil)
+	pacer := NewRequestPacer(10)
+	manager.SetRequestPacer(pacer)
+
+	for _, guildID := range []string{"guild1", "guild2"} {
+		client, err := manager.NewClient(guildID, "test-token")
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		if client.pacer != pacer {
+			t.Errorf("client for %s doesn't use the shared pacer", guildID)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// memberRemoveTimeout bounds handling of a single member leaving.
+const memberRemoveTimeout = 30 * time.Second
+
+// MemberRemoveHandler handles members leaving a client's guild.
+type MemberRemoveHandler interface {
+	HandleMemberRemove(ctx context.Context, guildID, userID string)
+}
+
+// SetMemberRemoveHandler sets the handler for members leaving the client's guild.
+func (c *Client) SetMemberRemoveHandler(handler MemberRemoveHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memberHandler = handler
+}
+
+func (c *Client) onGuildMemberRemove(_ *discordgo.Session, m *discordgo.GuildMemberRemove) {
+	if m.Member == nil || m.User == nil {
+		return
+	}
+	userID := m.User.ID
+
+	c.mu.Lock()
+	handler, guildID := c.memberHandler, c.guildID
+	if m.GuildID == guildID {
+		// Username lookups mustn't keep resolving to someone who left
+		for username, id := range c.userCache {
+			if id == userID {
+				delete(c.userCache, username)
+			}
+		}
+		delete(c.profileCache, userID)
+	}
+	c.mu.Unlock()
+	if handler == nil || m.GuildID != guildID {
+		return
+	}
+
+	// Context is created here because this is a callback from discordgo library
+	ctx, cancel := context.WithTimeout(context.Background(), memberRemoveTimeout)
+	defer cancel()
+	handler.HandleMemberRemove(ctx, m.GuildID, userID)
+}
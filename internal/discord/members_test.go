@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type recordingMemberRemoveHandler struct {
+	removed []string // "guildID/userID"
+}
+
+func (h *recordingMemberRemoveHandler) HandleMemberRemove(_ context.Context, guildID, userID string) {
+	h.removed = append(h.removed, guildID+"/"+userID)
+}
+
+func TestClient_onGuildMemberRemove(t *testing.T) {
+	client := newTestClientWithMock(NewMockSession())
+	client.SetGuildID("guild-1")
+	client.userCache["alice"] = "user-1"
+	client.userCache["bob"] = "user-2"
+
+	removal := func(guildID, userID string) *discordgo.GuildMemberRemove {
+		return &discordgo.GuildMemberRemove{
+			Member: &discordgo.Member{GuildID: guildID, User: &discordgo.User{ID: userID}},
+		}
+	}
+
+	handler := &recordingMemberRemoveHandler{}
+	client.SetMemberRemoveHandler(handler)
+	client.onGuildMemberRemove(nil, removal("guild-1", "user-1"))
+	client.onGuildMemberRemove(nil, removal("guild-2", "user-2"))
+
+	want := []string{"guild-1/user-1"}
+	if len(handler.removed) != len(want) || handler.removed[0] != want[0] {
+		t.Errorf("handled removals = %v, want %v", handler.removed, want)
+	}
+	if _, ok := client.userCache["alice"]; ok {
+		t.Error("username lookup cache still resolves to the member who left")
+	}
+	if _, ok := client.userCache["bob"]; !ok {
+		t.Error("username lookup cache dropped a member of another guild's removal")
+	}
+}
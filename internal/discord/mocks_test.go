@@ -2,6 +2,7 @@ package discord
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
@@ -11,6 +12,10 @@ import (
 type MockSession struct {
 	// Programmable responses
 	OpenError                      error
+	OpenFailures                   int // Open fails with OpenError this many times, then succeeds (0: always use OpenError)
+	OpenCalls                      int
+	GuildMembersCalls              int
+	GuildRolesCalls                int
 	CloseError                     error
 	MessageSendError               error
 	MessageEditError               error
@@ -20,15 +25,23 @@ type MockSession struct {
 	ChannelError                   error
 	GuildChannelsError             error
 	MessagesError                  error
+	MessagesFailures               int // ChannelMessages fails with MessagesError this many times, then succeeds (0: always use MessagesError)
+	MessagesCalls                  int
 	ThreadsActiveError             error
 	ApplicationCommandsError       error
 	InteractionResponseError       error
 	ChannelMessageSendComplexError error
 	ChannelMessageEditComplexError error
 	ForumThreadStartComplexError   error
+	MessageThreadStartError        error
 	ChannelEditError               error
 	GuildError                     error
+	GuildRolesError                error
 	UserChannelPermissionsError    error
+	CrosspostError                 error
+	DeleteError                    error
+	PinError                       error
+	PinLimit                       int // Pinning past this many pins in a channel fails (0: no limit)
 
 	// Storage for tracking calls
 	SentMessages    []*sentMessage
@@ -36,13 +49,20 @@ type MockSession struct {
 	CreatedChannels []string
 	CreatedThreads  []*discordgo.Channel
 	Interactions    []*discordgo.InteractionResponse
+	Crossposted     []string // message IDs
+	DeletedMessages []string // channelID/messageID
+	DeletedChannels []string
+	Pins            map[string][]*discordgo.Message // channelID -> pinned messages, newest first
 
 	// Mock data
 	Channels      map[string]*discordgo.Channel
 	Members       map[string][]*discordgo.Member
 	Guilds        map[string]*discordgo.Guild
+	Roles         map[string][]*discordgo.Role // guildID -> roles
 	Messages      map[string][]*discordgo.Message
 	ActiveThreads []*discordgo.Channel
+	ChannelPerms  map[string]int64 // channelID -> permission bits (default: all)
+	UserPerms     map[string]int64 // userID:channelID -> permission bits, taking precedence over ChannelPerms
 	Commands      []*discordgo.ApplicationCommand
 	MockState     *discordgo.State
 
@@ -50,9 +70,10 @@ type MockSession struct {
 }
 
 type sentMessage struct {
-	ChannelID string
-	Content   string
-	Embed     *discordgo.MessageEmbed
+	ChannelID  string
+	Content    string
+	Embed      *discordgo.MessageEmbed
+	Components []discordgo.MessageComponent
 }
 
 type editedMessage struct {
@@ -60,6 +81,7 @@ type editedMessage struct {
 	MessageID string
 	Content   string
 	Embed     *discordgo.MessageEmbed
+	Flags     discordgo.MessageFlags
 }
 
 func NewMockSession() *MockSession {
@@ -77,6 +99,12 @@ func NewMockSession() *MockSession {
 }
 
 func (m *MockSession) Open() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.OpenCalls++
+	if m.OpenFailures > 0 && m.OpenCalls > m.OpenFailures {
+		return nil
+	}
 	return m.OpenError
 }
 
@@ -190,11 +218,27 @@ func (m *MockSession) GuildMembers(guildID string, after string, limit int, opti
 		return nil, m.GuildMembersError
 	}
 
-	if members, ok := m.Members[guildID]; ok {
-		return members, nil
+	m.mu.Lock()
+	m.GuildMembersCalls++
+	m.mu.Unlock()
+
+	// Pages continue after the member with the given ID, like Discord's ordering by user ID
+	members := m.Members[guildID]
+	if after != "" {
+		for i, member := range members {
+			if member.User.ID == after {
+				members = members[i+1:]
+				break
+			}
+		}
 	}
-
-	return []*discordgo.Member{}, nil
+	if limit > 0 && len(members) > limit {
+		members = members[:limit]
+	}
+	if members == nil {
+		return []*discordgo.Member{}, nil
+	}
+	return members, nil
 }
 
 func (m *MockSession) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
@@ -225,7 +269,11 @@ func (m *MockSession) GuildChannels(guildID string, options ...discordgo.Request
 }
 
 func (m *MockSession) ChannelMessages(channelID string, limit int, beforeID, afterID, aroundID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error) {
-	if m.MessagesError != nil {
+	m.mu.Lock()
+	m.MessagesCalls++
+	calls := m.MessagesCalls
+	m.mu.Unlock()
+	if m.MessagesError != nil && (m.MessagesFailures == 0 || calls <= m.MessagesFailures) {
 		return nil, m.MessagesError
 	}
 
@@ -236,16 +284,6 @@ func (m *MockSession) ChannelMessages(channelID string, limit int, beforeID, aft
 	return []*discordgo.Message{}, nil
 }
 
-func (m *MockSession) ThreadsActive(guildID string, options ...discordgo.RequestOption) (*discordgo.ThreadsList, error) {
-	if m.ThreadsActiveError != nil {
-		return nil, m.ThreadsActiveError
-	}
-
-	return &discordgo.ThreadsList{
-		Threads: m.ActiveThreads,
-	}, nil
-}
-
 func (m *MockSession) ApplicationCommandBulkOverwrite(appID, guildID string, commands []*discordgo.ApplicationCommand, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error) {
 	if m.ApplicationCommandsError != nil {
 		return nil, m.ApplicationCommandsError
@@ -329,6 +367,95 @@ func NewMockMessage(id, channelID, content, authorID string) *discordgo.Message
 	}
 }
 
+// ChannelMessageCrosspost mocks publishing an announcement channel message
+func (m *MockSession) ChannelMessageCrosspost(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if m.CrosspostError != nil {
+		return nil, m.CrosspostError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Crossposted = append(m.Crossposted, messageID)
+
+	return &discordgo.Message{ID: messageID, ChannelID: channelID}, nil
+}
+
+// ChannelMessagePin mocks pinning a message
+func (m *MockSession) ChannelMessagePin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	if m.PinError != nil {
+		return m.PinError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, msg := range m.Pins[channelID] {
+		if msg.ID == messageID {
+			return nil
+		}
+	}
+	if m.PinLimit > 0 && len(m.Pins[channelID]) >= m.PinLimit {
+		return &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+			Code:    discordgo.ErrCodeMaximumPinsReached,
+			Message: "Maximum number of pins reached (50)",
+		}}
+	}
+	if m.Pins == nil {
+		m.Pins = make(map[string][]*discordgo.Message)
+	}
+	var author *discordgo.User
+	if m.MockState != nil {
+		author = m.MockState.User
+	}
+	pinned := &discordgo.Message{ID: messageID, ChannelID: channelID, Author: author}
+	m.Pins[channelID] = append([]*discordgo.Message{pinned}, m.Pins[channelID]...)
+	return nil
+}
+
+// ChannelMessageUnpin mocks unpinning a message
+func (m *MockSession) ChannelMessageUnpin(channelID, messageID string, options ...discordgo.RequestOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Pins[channelID] = slices.DeleteFunc(m.Pins[channelID], func(msg *discordgo.Message) bool {
+		return msg.ID == messageID
+	})
+	return nil
+}
+
+// ChannelMessagesPinned mocks listing a channel's pinned messages
+func (m *MockSession) ChannelMessagesPinned(channelID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.Pins[channelID]), nil
+}
+
+// ChannelMessageDelete mocks deleting a message
+func (m *MockSession) ChannelMessageDelete(channelID, messageID string, options ...discordgo.RequestOption) error {
+	if m.DeleteError != nil {
+		return m.DeleteError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeletedMessages = append(m.DeletedMessages, channelID+"/"+messageID)
+	return nil
+}
+
+// ChannelDelete mocks deleting a channel or thread
+func (m *MockSession) ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	if m.DeleteError != nil {
+		return nil, m.DeleteError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeletedChannels = append(m.DeletedChannels, channelID)
+	delete(m.Channels, channelID)
+	return &discordgo.Channel{ID: channelID}, nil
+}
+
 // ChannelMessageSendComplex mocks sending a complex message
 func (m *MockSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
 	if m.ChannelMessageSendComplexError != nil {
@@ -344,9 +471,10 @@ func (m *MockSession) ChannelMessageSendComplex(channelID string, data *discordg
 	}
 
 	m.SentMessages = append(m.SentMessages, &sentMessage{
-		ChannelID: channelID,
-		Content:   data.Content,
-		Embed:     embed,
+		ChannelID:  channelID,
+		Content:    data.Content,
+		Embed:      embed,
+		Components: data.Components,
 	})
 
 	msgID := fmt.Sprintf("msg-%d", len(m.SentMessages))
@@ -382,6 +510,7 @@ func (m *MockSession) ChannelMessageEditComplex(data *discordgo.MessageEdit, opt
 		MessageID: data.ID,
 		Content:   content,
 		Embed:     embed,
+		Flags:     data.Flags,
 	})
 
 	return &discordgo.Message{
@@ -391,6 +520,25 @@ func (m *MockSession) ChannelMessageEditComplex(data *discordgo.MessageEdit, opt
 	}, nil
 }
 
+// MessageThreadStartComplex mocks starting a thread on a message
+func (m *MockSession) MessageThreadStartComplex(channelID, messageID string, data *discordgo.ThreadStart, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	if m.MessageThreadStartError != nil {
+		return nil, m.MessageThreadStartError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	thread := &discordgo.Channel{
+		ID:       "thread-" + messageID,
+		Name:     data.Name,
+		Type:     discordgo.ChannelTypeGuildPublicThread,
+		ParentID: channelID,
+	}
+	m.CreatedThreads = append(m.CreatedThreads, thread)
+	return thread, nil
+}
+
 // ForumThreadStartComplex mocks creating a forum thread
 func (m *MockSession) ForumThreadStartComplex(channelID string, threadData *discordgo.ThreadStart, messageData *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
 	if m.ForumThreadStartComplexError != nil {
@@ -513,12 +661,32 @@ func (m *MockSession) Guild(guildID string, options ...discordgo.RequestOption)
 	return nil, fmt.Errorf("guild not found")
 }
 
+// GuildRoles mocks fetching a guild's roles
+func (m *MockSession) GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.GuildRolesCalls++
+	if m.GuildRolesError != nil {
+		return nil, m.GuildRolesError
+	}
+
+	return m.Roles[guildID], nil
+}
+
 // UserChannelPermissions mocks checking user permissions
 func (m *MockSession) UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error) {
 	if m.UserChannelPermissionsError != nil {
 		return 0, m.UserChannelPermissionsError
 	}
 
+	if perms, ok := m.UserPerms[userID+":"+channelID]; ok {
+		return perms, nil
+	}
+	if perms, ok := m.ChannelPerms[channelID]; ok {
+		return perms, nil
+	}
+
 	// Return full permissions for testing
 	return discordgo.PermissionAll, nil
 }
@@ -0,0 +1,109 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRequestsPerSecond paces writes below Discord's global limit of 50 requests per second per bot.
+const DefaultRequestsPerSecond = 40
+
+// DefaultGuildWriteConcurrency is how many write requests one guild may have in flight at once.
+const DefaultGuildWriteConcurrency = 4
+
+// slowPaceWait is how long a write may be held back before it's logged.
+const slowPaceWait = time.Second
+
+// RequestPacer spaces out Discord write requests evenly. One pacer is shared by every
+// Client using the same bot token, since Discord's global rate limit is per bot, so bursts
+// across many channels and guilds don't trip a global 429.
+// A nil *RequestPacer doesn't pace.
+type RequestPacer struct {
+	next     time.Time // When the next request may be sent
+	interval time.Duration
+	requests atomic.Int64
+	delayed  atomic.Int64
+	waited   atomic.Int64 // Total nanoseconds requests were held back
+	mu       sync.Mutex
+}
+
+// NewRequestPacer returns a pacer allowing perSecond write requests per second,
+// or nil (no pacing) if perSecond isn't positive.
+func NewRequestPacer(perSecond int) *RequestPacer {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &RequestPacer{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the caller's request may be sent, or ctx is done.
+func (p *RequestPacer) Wait(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+
+	p.requests.Add(1)
+	wait := at.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	p.delayed.Add(1)
+	p.waited.Add(int64(wait))
+	if wait >= slowPaceWait {
+		slog.Warn("Discord writes are backed up behind the global request pace", "wait", wait)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns how many requests went through the pacer, how many of them were
+// held back, and the total time they waited.
+func (p *RequestPacer) Stats() (requests, delayed int64, waited time.Duration) {
+	if p == nil {
+		return 0, 0, 0
+	}
+	return p.requests.Load(), p.delayed.Load(), time.Duration(p.waited.Load())
+}
+
+// beginWrite waits for one of the guild's write slots, then for the shared pace. A successful
+// call must be paired with endWrite once the request is done.
+func (c *Client) beginWrite(ctx context.Context) error {
+	if c.writeSlots != nil {
+		select {
+		case c.writeSlots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := c.pacer.Wait(ctx); err != nil {
+		c.endWrite()
+		return err
+	}
+	return nil
+}
+
+// endWrite frees the write slot taken by beginWrite.
+func (c *Client) endWrite() {
+	if c.writeSlots != nil {
+		<-c.writeSlots
+	}
+}
@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestRequestPacer_ConcurrentPostsPaced(t *testing.T) {
+	ctx := context.Background()
+	const perSecond = 100
+	pacer := NewRequestPacer(perSecond)
+
+	mockSession := NewMockSession()
+	// Several guilds' clients share the bot token's pacer
+	clients := make([]*Client, 3)
+	for i := range clients {
+		clients[i] = newTestClientWithMock(mockSession)
+		clients[i].SetRequestPacer(pacer)
+	}
+
+	const posts = 21
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := range posts {
+		wg.Go(func() {
+			if _, err := clients[i%len(clients)].PostMessage(ctx, "chan", "hello"); err != nil {
+				t.Errorf("PostMessage() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// The first post goes out at once; each later one waits its turn
+	if minElapsed := (posts - 1) * time.Second / perSecond; elapsed < minElapsed {
+		t.Errorf("%d posts took %v, want at least %v at %d/s", posts, elapsed, minElapsed, perSecond)
+	}
+	requests, delayed, waited := pacer.Stats()
+	if requests != posts {
+		t.Errorf("requests = %d, want %d", requests, posts)
+	}
+	if delayed < posts-1 || waited <= 0 {
+		t.Errorf("delayed = %d, waited = %v; want at least %d delayed", delayed, waited, posts-1)
+	}
+}
+
+func TestRequestPacer_WaitCanceled(t *testing.T) {
+	pacer := NewRequestPacer(1)
+	if err := pacer.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pacer.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() with canceled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestRequestPacer_Disabled(t *testing.T) {
+	pacer := NewRequestPacer(0)
+	if pacer != nil {
+		t.Fatal("NewRequestPacer(0) should return nil")
+	}
+	for range 100 {
+		if err := pacer.Wait(context.Background()); err != nil {
+			t.Fatalf("nil pacer Wait() error = %v", err)
+		}
+	}
+	if requests, _, _ := pacer.Stats(); requests != 0 {
+		t.Errorf("nil pacer requests = %d, want 0", requests)
+	}
+}
+
+// slowSession is a MockSession whose message sends take a while, recording the most that were
+// ever in flight at once.
+type slowSession struct {
+	*MockSession
+
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *slowSession) ChannelMessageSendComplex(
+	channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return s.MockSession.ChannelMessageSendComplex(channelID, data, options...)
+}
+
+func TestClient_WriteConcurrencyPerGuild(t *testing.T) {
+	ctx := context.Background()
+	const perGuild = 2
+	pacer := NewRequestPacer(1000)
+
+	sessions := make([]*slowSession, 2)
+	clients := make([]*Client, len(sessions))
+	for i := range sessions {
+		sessions[i] = &slowSession{MockSession: NewMockSession()}
+		clients[i] = newTestClientWithMock(NewMockSession())
+		clients[i].session = sessions[i]
+		clients[i].SetRequestPacer(pacer)
+		clients[i].SetWriteConcurrency(perGuild)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			if _, err := clients[i%len(clients)].PostMessage(ctx, "chan", "hello"); err != nil {
+				t.Errorf("PostMessage() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, s := range sessions {
+		if peak := s.peak.Load(); peak > perGuild {
+			t.Errorf("guild %d had %d writes in flight, want at most %d", i, peak, perGuild)
+		}
+		if sent := len(s.SentMessages); sent != 10 {
+			t.Errorf("guild %d sent %d messages, want 10", i, sent)
+		}
+	}
+}
+
+func TestClient_WriteConcurrencyDoesNotBlockOtherGuilds(t *testing.T) {
+	busy := newTestClientWithMock(NewMockSession())
+	busy.SetWriteConcurrency(1)
+	if err := busy.beginWrite(context.Background()); err != nil {
+		t.Fatalf("beginWrite() error = %v", err)
+	}
+
+	// The busy guild's next write waits for its slot
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := busy.PostMessage(ctx, "chan", "hello"); err == nil {
+		t.Error("PostMessage() on a guild at its write cap succeeded, want it to wait")
+	}
+
+	other := newTestClientWithMock(NewMockSession())
+	other.SetWriteConcurrency(1)
+	if _, err := other.PostMessage(context.Background(), "chan", "hello"); err != nil {
+		t.Errorf("PostMessage() on another guild error = %v", err)
+	}
+
+	busy.endWrite()
+	if _, err := busy.PostMessage(context.Background(), "chan", "hello"); err != nil {
+		t.Errorf("PostMessage() after the slot was freed error = %v", err)
+	}
+}
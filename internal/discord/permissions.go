@@ -0,0 +1,104 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// permissionCacheTTL bounds how long channel permissions are cached.
+// Short enough that fixing a missing permission takes effect quickly.
+const permissionCacheTTL = 5 * time.Minute
+
+// Permissions is the parsed set of channel permissions the bot cares about.
+type Permissions struct {
+	ViewChannel           bool
+	SendMessages          bool
+	SendMessagesInThreads bool
+	CreatePublicThreads   bool
+	EmbedLinks            bool
+	ReadMessageHistory    bool
+	ManageThreads         bool
+}
+
+// PermissionsFromBits parses a Discord permission bitfield.
+// Administrator implies every permission; discordgo's PermissionAll
+// predates the thread permissions, so it can't be relied on for those.
+func PermissionsFromBits(bits int64) Permissions {
+	if bits&discordgo.PermissionAdministrator != 0 {
+		bits = -1
+	}
+	has := func(p int64) bool { return bits&p == p }
+	return Permissions{
+		ViewChannel:           has(discordgo.PermissionViewChannel),
+		SendMessages:          has(discordgo.PermissionSendMessages),
+		SendMessagesInThreads: has(discordgo.PermissionSendMessagesInThreads),
+		CreatePublicThreads:   has(discordgo.PermissionCreatePublicThreads),
+		EmbedLinks:            has(discordgo.PermissionEmbedLinks),
+		ReadMessageHistory:    has(discordgo.PermissionReadMessageHistory),
+		ManageThreads:         has(discordgo.PermissionManageThreads),
+	}
+}
+
+// Missing returns the names of permissions required to post PR updates
+// in a channel of the given kind. Embed Links isn't required since we
+// suppress embeds on everything we post.
+func (p Permissions) Missing(forum bool) []string {
+	var missing []string
+	if !p.ViewChannel {
+		missing = append(missing, "View Channel")
+	}
+	if !p.SendMessages {
+		missing = append(missing, "Send Messages")
+	}
+	if forum {
+		if !p.CreatePublicThreads {
+			missing = append(missing, "Create Public Threads")
+		}
+		if !p.SendMessagesInThreads {
+			missing = append(missing, "Send Messages in Threads")
+		}
+	} else if !p.ReadMessageHistory {
+		missing = append(missing, "Read Message History")
+	}
+	return missing
+}
+
+type permissionCacheEntry struct {
+	fetchedAt time.Time
+	perms     Permissions
+}
+
+// BotChannelPermissions returns the bot's permissions in a channel.
+// Results are cached per channel for a few minutes.
+func (c *Client) BotChannelPermissions(_ context.Context, channelID string) (Permissions, error) {
+	c.mu.RLock()
+	entry, ok := c.permissionCache[channelID]
+	c.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < permissionCacheTTL {
+		return entry.perms, nil
+	}
+
+	st := c.session.GetState()
+	if st == nil || st.User == nil {
+		return Permissions{}, errors.New("bot user not available")
+	}
+
+	bits, err := c.session.UserChannelPermissions(st.User.ID, channelID)
+	if err != nil {
+		return Permissions{}, fmt.Errorf("fetch channel permissions: %w", err)
+	}
+
+	perms := PermissionsFromBits(bits)
+	c.mu.Lock()
+	if c.permissionCache == nil {
+		c.permissionCache = make(map[string]permissionCacheEntry)
+	}
+	c.permissionCache[channelID] = permissionCacheEntry{perms: perms, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return perms, nil
+}
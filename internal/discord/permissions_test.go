@@ -0,0 +1,87 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestPermissionsFromBits(t *testing.T) {
+	bits := int64(discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionEmbedLinks)
+	got := PermissionsFromBits(bits)
+
+	if !got.ViewChannel || !got.SendMessages || !got.EmbedLinks {
+		t.Errorf("PermissionsFromBits() = %+v, want view/send/embed set", got)
+	}
+	if got.CreatePublicThreads || got.SendMessagesInThreads || got.ReadMessageHistory || got.ManageThreads {
+		t.Errorf("PermissionsFromBits() = %+v, want thread/history permissions unset", got)
+	}
+
+	if all := PermissionsFromBits(discordgo.PermissionAll); len(all.Missing(true)) != 0 || len(all.Missing(false)) != 0 {
+		t.Errorf("PermissionAll should not be missing anything, got %+v", all)
+	}
+}
+
+func TestPermissions_Missing(t *testing.T) {
+	canSendOnly := Permissions{ViewChannel: true, SendMessages: true}
+
+	tests := []struct {
+		name  string
+		perms Permissions
+		forum bool
+		want  []string
+	}{
+		{"forum without thread permissions", canSendOnly, true, []string{"Create Public Threads", "Send Messages in Threads"}},
+		{"text without history", canSendOnly, false, []string{"Read Message History"}},
+		{"text fully permitted", Permissions{ViewChannel: true, SendMessages: true, ReadMessageHistory: true}, false, nil},
+		{"nothing granted", Permissions{}, false, []string{"View Channel", "Send Messages", "Read Message History"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.perms.Missing(tt.forum); !slices.Equal(got, tt.want) {
+				t.Errorf("Missing(%v) = %v, want %v", tt.forum, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_BotChannelPermissions(t *testing.T) {
+	mockSession := NewMockSession()
+	mockSession.MockState.User = &discordgo.User{ID: "bot-id"}
+	mockSession.ChannelPerms = map[string]int64{
+		"forum-1": discordgo.PermissionViewChannel | discordgo.PermissionSendMessages,
+	}
+	client := newTestClientWithMock(mockSession)
+	ctx := context.Background()
+
+	perms, err := client.BotChannelPermissions(ctx, "forum-1")
+	if err != nil {
+		t.Fatalf("BotChannelPermissions() error = %v", err)
+	}
+	if !perms.SendMessages || perms.CreatePublicThreads {
+		t.Errorf("BotChannelPermissions() = %+v, want send but not create threads", perms)
+	}
+
+	// Cached: a later failure doesn't affect the cached result
+	mockSession.UserChannelPermissionsError = errors.New("should not be called")
+	if _, err := client.BotChannelPermissions(ctx, "forum-1"); err != nil {
+		t.Errorf("BotChannelPermissions() should use cache, got error %v", err)
+	}
+
+	// Uncached channel surfaces the error
+	if _, err := client.BotChannelPermissions(ctx, "other"); err == nil {
+		t.Error("BotChannelPermissions() expected error for uncached channel")
+	}
+}
+
+func TestClient_BotChannelPermissions_NoUser(t *testing.T) {
+	client := newTestClientWithMock(NewMockSession())
+
+	if _, err := client.BotChannelPermissions(context.Background(), "chan"); err == nil {
+		t.Error("BotChannelPermissions() expected error when bot user unknown")
+	}
+}
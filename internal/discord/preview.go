@@ -0,0 +1,60 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+)
+
+// DefaultPreviewLength is how much of a message's content is logged by default.
+const DefaultPreviewLength = 50
+
+// SetPreviewLogging sets how sent and edited messages are previewed in debug logs: maxLen
+// caps each preview (0 disables previews), 1 in sampleEvery messages is previewed (at
+// most 1 samples all of them), and redact logs only a message's PR URLs and length,
+// leaving out PR titles and other content.
+func (c *Client) SetPreviewLogging(maxLen, sampleEvery int, redact bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.previewLen = max(maxLen, 0)
+	c.previewSample = max(sampleEvery, 1)
+	c.redactPreviews = redact
+}
+
+// preview returns a "preview" log attribute holding the given key and text pairs, capped and
+// redacted per SetPreviewLogging. It's empty, so logged as nothing, unless debug logging is on
+// and this message is sampled.
+func (c *Client) preview(pairs ...string) slog.Attr {
+	c.mu.RLock()
+	maxLen, sample, redact := c.previewLen, c.previewSample, c.redactPreviews
+	c.mu.RUnlock()
+
+	if maxLen == 0 || !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return slog.Attr{}
+	}
+	if n := c.previewCount.Add(1); sample > 1 && (n-1)%uint64(sample) != 0 {
+		return slog.Attr{}
+	}
+
+	args := make([]any, 0, len(pairs))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		text := pairs[i+1]
+		if redact {
+			text = redactPreview(text)
+		}
+		args = append(args, pairs[i], format.Truncate(text, maxLen))
+	}
+	return slog.Group("preview", args...)
+}
+
+// redactPreview reduces text to its PR URLs and length, so PR titles aren't logged.
+func redactPreview(text string) string {
+	redacted := "[redacted " + strconv.Itoa(len(text)) + " chars]"
+	if urls := prURLPattern.FindAllString(text, -1); len(urls) > 0 {
+		redacted += " " + strings.Join(urls, " ")
+	}
+	return redacted
+}
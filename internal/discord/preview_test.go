@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureLogs sends the default logger's output at level to the returned buffer for the rest
+// of the test.
+func captureLogs(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func TestClient_PreviewLogging(t *testing.T) {
+	text := "[o/r#1] Rotate the signing keys https://github.com/o/r/pull/1"
+	tests := []struct {
+		name        string
+		maxLen      int
+		sample      int
+		redact      bool
+		wantLogged  int
+		wantPreview string
+		wantNot     string
+	}{
+		{name: "every message, capped", maxLen: 20, sample: 1, wantLogged: 4, wantPreview: "[o/r#1] Rotate th..."},
+		{name: "disabled", maxLen: 0, sample: 1, wantLogged: 0},
+		{name: "sampled 1 in 2", maxLen: 100, sample: 2, wantLogged: 2, wantPreview: "Rotate the signing keys"},
+		{
+			name: "redacted", maxLen: 100, sample: 1, redact: true, wantLogged: 4,
+			wantPreview: "https://github.com/o/r/pull/1", wantNot: "Rotate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t, slog.LevelDebug)
+			mock := &MockSession{}
+			client := newTestClientWithMock(mock)
+			client.SetPreviewLogging(tt.maxLen, tt.sample, tt.redact)
+
+			for range 4 {
+				if _, err := client.PostMessage(context.Background(), "chan-1", text); err != nil {
+					t.Fatalf("PostMessage() error = %v", err)
+				}
+			}
+
+			out := logs.String()
+			if got := strings.Count(out, "preview.content="); got != tt.wantLogged {
+				t.Errorf("previews logged = %d, want %d\n%s", got, tt.wantLogged, out)
+			}
+			if tt.wantPreview != "" && !strings.Contains(out, tt.wantPreview) {
+				t.Errorf("logs = %q, want preview %q", out, tt.wantPreview)
+			}
+			if tt.wantNot != "" && strings.Contains(out, tt.wantNot) {
+				t.Errorf("logs = %q, want no %q", out, tt.wantNot)
+			}
+		})
+	}
+}
+
+func TestClient_PreviewLogging_NotAtInfo(t *testing.T) {
+	logs := captureLogs(t, slog.LevelInfo)
+	client := newTestClientWithMock(&MockSession{})
+	client.SetPreviewLogging(DefaultPreviewLength, 1, false)
+	if _, err := client.PostMessage(context.Background(), "chan-1", "Rotate the signing keys"); err != nil {
+		t.Fatalf("PostMessage() error = %v", err)
+	}
+	if strings.Contains(logs.String(), "Rotate") {
+		t.Errorf("logs = %q, want no preview without debug logging", logs.String())
+	}
+}
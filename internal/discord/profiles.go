@@ -0,0 +1,57 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// profileCacheTTL bounds how long a member's display name is cached; renames show up within
+// the hour. Users who aren't members are cached as such for as long, so reports listing them
+// don't ask Discord again for each one.
+const profileCacheTTL = time.Hour
+
+type profileCacheEntry struct {
+	fetchedAt time.Time
+	profile   UserProfile
+	member    bool
+}
+
+// UserProfile is how a guild member appears on Discord.
+type UserProfile struct {
+	DisplayName string // Server nickname, else global display name, else username
+}
+
+// UserProfile returns how a guild member appears on Discord, for showing mapped GitHub users
+// by their Discord identity. Results are cached per user. Returns false if they aren't a member.
+func (c *Client) UserProfile(_ context.Context, userID string) (UserProfile, bool) {
+	c.mu.RLock()
+	entry, ok := c.profileCache[userID]
+	guildID := c.guildID
+	c.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < profileCacheTTL {
+		return entry.profile, entry.member
+	}
+	if guildID == "" || userID == "" {
+		return UserProfile{}, false
+	}
+
+	entry = profileCacheEntry{fetchedAt: time.Now()}
+	member, err := c.session.GuildMember(guildID, userID)
+	if err != nil || member.User == nil {
+		slog.Debug("failed to fetch guild member profile",
+			"user_id", userID,
+			"guild_id", guildID,
+			"error", err)
+	} else {
+		entry.profile, entry.member = UserProfile{DisplayName: member.DisplayName()}, true
+	}
+
+	c.mu.Lock()
+	if c.profileCache == nil {
+		c.profileCache = make(map[string]profileCacheEntry)
+	}
+	c.profileCache[userID] = entry
+	c.mu.Unlock()
+	return entry.profile, entry.member
+}
@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestClient_UserProfile(t *testing.T) {
+	ctx := context.Background()
+	mockSession := NewMockSession()
+	client := newTestClientWithMock(mockSession)
+	client.SetGuildID("test-guild")
+	mockSession.AddMember("test-guild", &discordgo.Member{
+		Nick: "Al",
+		User: &discordgo.User{ID: "alice", Username: "alice_l", GlobalName: "Alice L.", Avatar: "abc"},
+	})
+	mockSession.AddMember("test-guild", &discordgo.Member{
+		User: &discordgo.User{ID: "bob", Username: "bob_b", GlobalName: "Bob B."},
+	})
+
+	alice, ok := client.UserProfile(ctx, "alice")
+	if !ok || alice.DisplayName != "Al" {
+		t.Errorf("UserProfile(alice) = %+v, %v, want the nickname", alice, ok)
+	}
+	if bob, ok := client.UserProfile(ctx, "bob"); !ok || bob.DisplayName != "Bob B." {
+		t.Errorf("UserProfile(bob) = %+v, %v, want the display name", bob, ok)
+	}
+	if _, ok := client.UserProfile(ctx, "carol"); ok {
+		t.Error("UserProfile(carol) ok = true, want false for a non-member")
+	}
+
+	// Not being a member is cached too
+	mockSession.AddMember("test-guild", &discordgo.Member{User: &discordgo.User{ID: "carol", Username: "carol"}})
+	if _, ok := client.UserProfile(ctx, "carol"); ok {
+		t.Error("UserProfile(carol) ok = true, want the cached non-member")
+	}
+
+	// Cached until the member leaves
+	mockSession.GuildMemberError = discordgo.ErrUnauthorized
+	if got, ok := client.UserProfile(ctx, "alice"); !ok || got != alice {
+		t.Errorf("UserProfile(alice) = %+v, %v, want the cached profile", got, ok)
+	}
+	client.onGuildMemberRemove(nil, &discordgo.GuildMemberRemove{
+		Member: &discordgo.Member{GuildID: "test-guild", User: &discordgo.User{ID: "alice"}},
+	})
+	if _, ok := client.UserProfile(ctx, "alice"); ok {
+		t.Error("UserProfile(alice) ok = true after leaving, want false")
+	}
+}
@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// reactionTimeout bounds handling of a single reaction.
+const reactionTimeout = 30 * time.Second
+
+// ReactionHandler handles reactions people add to messages in a client's guild.
+type ReactionHandler interface {
+	HandleReaction(ctx context.Context, guildID, channelID, messageID, userID, emoji string)
+}
+
+// SetReactionHandler sets the handler for reactions added in the client's guild.
+func (c *Client) SetReactionHandler(handler ReactionHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reactionHandler = handler
+}
+
+func (c *Client) onReactionAdd(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	c.mu.RLock()
+	handler, guildID := c.reactionHandler, c.guildID
+	c.mu.RUnlock()
+	if handler == nil || r.MessageReaction == nil || r.GuildID != guildID {
+		return
+	}
+	// Bots, including this one, can't acknowledge anything
+	if r.Member != nil && r.Member.User != nil && r.Member.User.Bot {
+		return
+	}
+
+	// Context is created here because this is a callback from discordgo library
+	ctx, cancel := context.WithTimeout(context.Background(), reactionTimeout)
+	defer cancel()
+	handler.HandleReaction(ctx, r.GuildID, r.ChannelID, r.MessageID, r.UserID, r.Emoji.Name)
+}
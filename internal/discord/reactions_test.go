@@ -0,0 +1,48 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type recordingReactionHandler struct {
+	reactions []string // "channelID/messageID/userID/emoji"
+}
+
+func (h *recordingReactionHandler) HandleReaction(_ context.Context, _, channelID, messageID, userID, emoji string) {
+	h.reactions = append(h.reactions, channelID+"/"+messageID+"/"+userID+"/"+emoji)
+}
+
+func TestClient_onReactionAdd(t *testing.T) {
+	client := newTestClientWithMock(NewMockSession())
+	client.SetGuildID("guild-1")
+
+	reaction := func(guildID, userID string, bot bool) *discordgo.MessageReactionAdd {
+		return &discordgo.MessageReactionAdd{
+			MessageReaction: &discordgo.MessageReaction{
+				GuildID:   guildID,
+				ChannelID: "chan-1",
+				MessageID: "msg-1",
+				UserID:    userID,
+				Emoji:     discordgo.Emoji{Name: "✅"},
+			},
+			Member: &discordgo.Member{User: &discordgo.User{ID: userID, Bot: bot}},
+		}
+	}
+
+	// Without a handler, reactions are dropped
+	client.onReactionAdd(nil, reaction("guild-1", "user-1", false))
+
+	handler := &recordingReactionHandler{}
+	client.SetReactionHandler(handler)
+	client.onReactionAdd(nil, reaction("guild-1", "user-1", false))
+	client.onReactionAdd(nil, reaction("guild-2", "user-1", false))
+	client.onReactionAdd(nil, reaction("guild-1", "bot-1", true))
+
+	want := []string{"chan-1/msg-1/user-1/✅"}
+	if len(handler.reactions) != len(want) || handler.reactions[0] != want[0] {
+		t.Errorf("handled reactions = %v, want %v", handler.reactions, want)
+	}
+}
@@ -0,0 +1,52 @@
+package discord
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Readiness reports whether the server is ready to handle events: config has been loaded
+// for its orgs and every guild they use has an open gateway session. It backs the
+// readiness probe, so a new instance gets no traffic while its events would be dropped.
+type Readiness struct {
+	manager  *GuildManager
+	expected map[string]bool // Guild IDs of configured orgs
+	loaded   bool
+	mu       sync.Mutex
+}
+
+// NewReadiness creates a readiness gate over a guild manager's clients.
+func NewReadiness(manager *GuildManager) *Readiness {
+	return &Readiness{
+		manager:  manager,
+		expected: make(map[string]bool),
+	}
+}
+
+// MarkLoaded records that org configs have been loaded, and sets the guilds that must have
+// an open session before the server is ready. Each pass over the orgs replaces the set, so a
+// guild no org uses anymore stops holding the server unready.
+func (r *Readiness) MarkLoaded(guildIDs []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.expected)
+	for _, guildID := range guildIDs {
+		r.expected[guildID] = true
+	}
+	r.loaded = true
+}
+
+// Ready reports whether configs are loaded and every expected guild's client is registered
+// and connected. If not, it returns the guilds still waiting on a session.
+func (r *Readiness) Ready() (ready bool, waiting []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, guildID := range slices.Sorted(maps.Keys(r.expected)) {
+		if client, ok := r.manager.Client(guildID); !ok || !client.Connected() {
+			waiting = append(waiting, guildID)
+		}
+	}
+	return r.loaded && len(waiting) == 0, waiting
+}
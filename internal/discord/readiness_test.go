@@ -0,0 +1,57 @@
+package discord
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestReadiness_Ready(t *testing.T) {
+	m := NewGuildManager(nil)
+	r := NewReadiness(m)
+
+	if ready, _ := r.Ready(); ready {
+		t.Fatal("Ready() = true before configs loaded")
+	}
+
+	r.MarkLoaded([]string{"guild1", "guild2"})
+	if ready, waiting := r.Ready(); ready || !slices.Equal(waiting, []string{"guild1", "guild2"}) {
+		t.Fatalf("Ready() = (%v, %v) with no clients, want (false, [guild1 guild2])", ready, waiting)
+	}
+
+	// A registered client isn't enough until its session is open
+	client1 := newTestClientWithMock(NewMockSession())
+	client2 := newTestClientWithMock(NewMockSession())
+	m.RegisterClient("guild1", client1)
+	m.RegisterClient("guild2", client2)
+	client1.onConnect(nil, &discordgo.Connect{})
+	if ready, waiting := r.Ready(); ready || !slices.Equal(waiting, []string{"guild2"}) {
+		t.Fatalf("Ready() = (%v, %v) with one session open, want (false, [guild2])", ready, waiting)
+	}
+
+	client2.onConnect(nil, &discordgo.Connect{})
+	if ready, waiting := r.Ready(); !ready || len(waiting) != 0 {
+		t.Fatalf("Ready() = (%v, %v) with all sessions open, want (true, none)", ready, waiting)
+	}
+
+	// A dropped session makes the server unready again
+	client2.onDisconnect(nil, &discordgo.Disconnect{})
+	if ready, _ := r.Ready(); ready {
+		t.Error("Ready() = true after a session dropped")
+	}
+
+	// Once no org uses guild2, it no longer holds the server unready
+	r.MarkLoaded([]string{"guild1"})
+	if ready, waiting := r.Ready(); !ready || len(waiting) != 0 {
+		t.Errorf("Ready() = (%v, %v) after guild2 was dropped, want (true, none)", ready, waiting)
+	}
+}
+
+func TestReadiness_NoGuilds(t *testing.T) {
+	r := NewReadiness(NewGuildManager(nil))
+	r.MarkLoaded(nil)
+	if ready, waiting := r.Ready(); !ready || len(waiting) != 0 {
+		t.Errorf("Ready() = (%v, %v) with no configured guilds, want (true, none)", ready, waiting)
+	}
+}
@@ -0,0 +1,40 @@
+package discord
+
+import "sync"
+
+// sharedSession is a gateway session shared by the clients of every guild on the same bot
+// token and shard. Discord delivers all of a shard's guilds over one connection, so the
+// session connects when its first client opens and disconnects when its last one closes.
+type sharedSession struct {
+	*sessionAdapter
+
+	clients int // Clients that have opened the session and not closed it
+	mu      sync.Mutex
+}
+
+// Open connects the session unless another client already has.
+func (s *sharedSession) Open() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clients == 0 {
+		if err := s.sessionAdapter.Open(); err != nil {
+			return err
+		}
+	}
+	s.clients++
+	return nil
+}
+
+// Close disconnects the session once no other client is using it.
+func (s *sharedSession) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clients == 0 {
+		return nil
+	}
+	s.clients--
+	if s.clients > 0 {
+		return nil
+	}
+	return s.sessionAdapter.Close()
+}
@@ -2,10 +2,14 @@
 package discord
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,8 +31,19 @@ type SlashCommandHandler struct {
 	userMapGetter     UserMapGetter
 	channelMapGetter  ChannelMapGetter
 	dailyReportGetter DailyReportGetter
+	dmActionHandler   DMActionHandler
+	dedupClearer      DedupClearer
+	prInspector       PRInspector
+	handledMarker     HandledMarker
+	maintenance       MaintenanceToggler
+	repoRedirector    RepoRedirector
+	configReloader    ConfigReloader
+	timezones         TimezoneGetter
+	mappingCache      MappingInvalidator
 	store             state.Store
 	dashboardURL      string
+	dashboardLinker   DashboardLinker
+	dashboardMaxPRs   int // PRs listed per /goose dash section; 0 uses DefaultDashboardMaxPRs
 }
 
 // StatusGetter provides bot status information.
@@ -47,6 +62,8 @@ type ReportGetter interface {
 type UserMapGetter interface {
 	// UserMappings returns all user mappings for a guild.
 	UserMappings(ctx context.Context, guildID string) (*UserMappings, error)
+	// WhoAmI returns how a Discord user is mapped to GitHub, including conflicts.
+	WhoAmI(ctx context.Context, guildID, userID string) (*WhoAmI, error)
 }
 
 // ChannelMapGetter provides channel mapping information.
@@ -61,6 +78,72 @@ type DailyReportGetter interface {
 	DailyReport(ctx context.Context, guildID, userID string, force bool) (*DailyReportDebug, error)
 }
 
+// DashboardLinker provides each org's dashboard link, for orgs with their own dashboard.
+type DashboardLinker interface {
+	// DashboardOrgURL returns the link to the org's PRs on its own dashboard,
+	// or "" to link the org on the handler's dashboard URL.
+	DashboardOrgURL(org string) string
+}
+
+// DedupClearer clears event deduplication entries so redelivered events are processed again.
+type DedupClearer interface {
+	// ClearDedup clears entries for one PR, or for all of the guild's orgs if prURL is empty.
+	// Returns how many entries were cleared.
+	ClearDedup(ctx context.Context, guildID, prURL string) (int, error)
+}
+
+// PRInspector looks up what the store holds about a PR, for /goose inspect.
+type PRInspector interface {
+	// InspectPR returns the PR's stored state, or an error if its org isn't monitored by the guild.
+	InspectPR(ctx context.Context, guildID, prURL string) (PRInspection, error)
+}
+
+// ErrNotActionTarget is returned by MarkHandled when the PR isn't waiting on the user.
+var ErrNotActionTarget = errors.New("PR isn't waiting on this user")
+
+// HandledMarker records that a user has dealt with a PR waiting on them.
+type HandledMarker interface {
+	// MarkHandled stops DMs and pings to a user about a PR until its state changes.
+	// It returns ErrNotActionTarget if the PR isn't waiting on them.
+	MarkHandled(ctx context.Context, guildID, userID, prURL string) error
+}
+
+// MaintenanceToggler turns maintenance mode on or off for a guild's orgs.
+type MaintenanceToggler interface {
+	// SetMaintenance pauses (on) or resumes (off) channel posts and DMs, recording who toggled it.
+	SetMaintenance(ctx context.Context, guildID, userID string, on bool) error
+}
+
+// TimezoneGetter provides the timezone dates given in a guild's slash commands are read in.
+type TimezoneGetter interface {
+	// GuildLocation returns the timezone of the guild's orgs, or UTC if they don't set one.
+	GuildLocation(guildID string) *time.Location
+}
+
+// RepoRedirector temporarily sends a repo's PR notifications to another channel.
+type RepoRedirector interface {
+	// RedirectRepo sends notifications for a repo, named alone or as org/repo, to channel until
+	// the given time, recording who set it. A zero until ends the redirect.
+	RedirectRepo(ctx context.Context, guildID, userID, repo, channel string, until time.Time) error
+}
+
+// ConfigReloader reloads org configs on demand.
+type ConfigReloader interface {
+	// ReloadConfig reloads the config of each of the guild's orgs and reports what changed.
+	ReloadConfig(ctx context.Context, guildID string) ([]ConfigReload, error)
+}
+
+// MappingInvalidator drops cached user mappings after a mapping is saved, so it applies immediately.
+type MappingInvalidator interface {
+	// InvalidateUserMapping forgets cached mappings for a GitHub user and a Discord user in a guild.
+	InvalidateUserMapping(guildID, gitHubUsername, discordUserID string)
+}
+
+// memberLookup fetches a guild member; implemented by *discordgo.Session.
+type memberLookup interface {
+	GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+}
+
 // DailyReportDebug contains debug information about daily report eligibility.
 type DailyReportDebug struct {
 	LastSentAt         time.Time
@@ -106,6 +189,32 @@ type UserMapping struct {
 	Org            string
 }
 
+// WhoAmI describes how a Discord user is mapped to GitHub.
+type WhoAmI struct {
+	LinkedUsername  string   // Self-service link via /goose github-user
+	ConfigUsernames []string // GitHub users mapped to this Discord user in config
+	Conflicts       []MappingConflict
+}
+
+// MappingConflict is a GitHub user mapped to different Discord IDs by config and a self-service link.
+type MappingConflict struct {
+	GitHubUsername string
+	Org            string
+	ConfigID       string
+	LinkedID       string
+	ResolvedID     string
+	Policy         string
+}
+
+// ConfigReload is the outcome of reloading one org's config.
+type ConfigReload struct {
+	Err        error // Why the config couldn't be loaded; the previous config stays in effect
+	Org        string
+	Added      []string // Channels newly in the config
+	Removed    []string // Channels no longer in the config
+	Unresolved []string // Configured channels not found in this server
+}
+
 // BotStatus contains bot status information.
 type BotStatus struct {
 	LastEventTime        string
@@ -120,6 +229,21 @@ type BotStatus struct {
 	DMsSent              int64
 	DailyReportsSent     int64
 	ChannelMessagesSent  int64
+	ChannelMessageEdits  int64
+	EventsProcessed      int64
+	DMsQueued            int64
+	TurnCalls            int64
+	TurnDeferred         int64 // Turn calls that waited for an org's Turn rate limit
+	TurnThrottled        int64 // Events over an org's Turn rate limit, retried later
+	ProcessingErrors     int64
+	UnparseableURLs      int64         // Events whose URL isn't a GitHub PR URL, not counted as errors
+	DiscordWrites        int64         // Write requests through the bot's shared request pacer
+	DiscordWritesPaced   int64         // Writes the pacer held back
+	DiscordPaceWait      time.Duration // Total time writes were held back
+	PostLatencyP50       time.Duration // From PR events to their channel messages
+	PostLatencyP95       time.Duration
+	DMLatencyP50         time.Duration // From PR events to the DMs they queued, including DM delays
+	DMLatencyP95         time.Duration
 	Connected            bool
 }
 
@@ -132,15 +256,18 @@ type PRReport struct {
 
 // PRSummary contains summary info for a single PR.
 type PRSummary struct {
-	Repo      string
-	Title     string
-	Author    string
-	State     string
-	URL       string
-	Action    string
-	UpdatedAt string
-	Number    int
-	IsBlocked bool
+	ReviewerNames map[string]string // Reviewers' Discord display names, for those mapped when discord_names is on
+	Repo          string
+	Title         string
+	Author        string
+	AuthorName    string // Author's Discord display name, if they're mapped and discord_names is on
+	State         string
+	URL           string
+	Action        string
+	UpdatedAt     string
+	Reviewers     []string // GitHub users who have reviewed the PR
+	Number        int
+	IsBlocked     bool
 }
 
 // NewSlashCommandHandler creates a new slash command handler.
@@ -152,7 +279,7 @@ func NewSlashCommandHandler(session *discordgo.Session, logger *slog.Logger) *Sl
 	return &SlashCommandHandler{
 		session:      session,
 		logger:       logger,
-		dashboardURL: "https://reviewgoose.dev",
+		dashboardURL: format.DefaultDashboardURL,
 	}
 }
 
@@ -186,13 +313,92 @@ func (h *SlashCommandHandler) SetDashboardURL(url string) {
 	h.dashboardURL = url
 }
 
+// SetDashboardLinker sets the provider of per-org dashboard links. Without one, org links
+// point at the dashboard URL.
+func (h *SlashCommandHandler) SetDashboardLinker(linker DashboardLinker) {
+	h.dashboardLinker = linker
+}
+
+// DefaultDashboardMaxPRs is how many PRs each /goose dash section lists by default.
+const DefaultDashboardMaxPRs = 10
+
+// SetDashboardMaxPRs sets how many PRs each /goose dash section lists before linking to the
+// dashboard for the rest; 0 uses DefaultDashboardMaxPRs. Sections stay within Discord's embed
+// limits however many are allowed.
+func (h *SlashCommandHandler) SetDashboardMaxPRs(n int) {
+	h.dashboardMaxPRs = n
+}
+
+// SetDMActionHandler sets the handler for DM footer quick actions.
+func (h *SlashCommandHandler) SetDMActionHandler(handler DMActionHandler) {
+	h.dmActionHandler = handler
+}
+
+// SetDedupClearer sets the handler for /goose dedup clear.
+func (h *SlashCommandHandler) SetDedupClearer(clearer DedupClearer) {
+	h.dedupClearer = clearer
+}
+
+// SetPRInspector sets the handler for /goose inspect.
+func (h *SlashCommandHandler) SetPRInspector(inspector PRInspector) {
+	h.prInspector = inspector
+}
+
+// SetHandledMarker sets the handler for /goose done.
+func (h *SlashCommandHandler) SetHandledMarker(marker HandledMarker) {
+	h.handledMarker = marker
+}
+
+// SetMaintenanceToggler sets the handler for /goose maintenance.
+func (h *SlashCommandHandler) SetMaintenanceToggler(toggler MaintenanceToggler) {
+	h.maintenance = toggler
+}
+
+// SetRepoRedirector sets the handler for /goose redirect.
+func (h *SlashCommandHandler) SetRepoRedirector(redirector RepoRedirector) {
+	h.repoRedirector = redirector
+}
+
+// SetTimezoneGetter sets where the timezone for dates such as /goose ooo's comes from.
+func (h *SlashCommandHandler) SetTimezoneGetter(timezones TimezoneGetter) {
+	h.timezones = timezones
+}
+
+// SetConfigReloader sets the handler for /goose reload.
+func (h *SlashCommandHandler) SetConfigReloader(reloader ConfigReloader) {
+	h.configReloader = reloader
+}
+
+// SetMappingInvalidator sets the handler that drops cached mappings after a link or map.
+func (h *SlashCommandHandler) SetMappingInvalidator(invalidator MappingInvalidator) {
+	h.mappingCache = invalidator
+}
+
 // SetStore sets the state store.
 func (h *SlashCommandHandler) SetStore(store state.Store) {
 	h.store = store
 }
 
-// RegisterCommands registers the slash commands with Discord.
-func (h *SlashCommandHandler) RegisterCommands(guildID string) error {
+// Command scopes: guild commands are registered with each guild and update instantly, which
+// suits development; global commands are registered once for every guild, but changes take
+// up to an hour to reach them.
+const (
+	CommandScopeGuild  = "guild"
+	CommandScopeGlobal = "global"
+)
+
+// CommandTarget returns the guild ID a guild's slash commands are registered with under a
+// command scope: the guild itself, or "" (global) for CommandScopeGlobal.
+func CommandTarget(scope, guildID string) string {
+	if scope == CommandScopeGlobal {
+		return ""
+	}
+	return guildID
+}
+
+// slashCommands returns the bot's slash commands. They act on the guild they're run in, so
+// they're only offered in guilds: registered globally, they'd otherwise show up in DMs too.
+func slashCommands() []*discordgo.ApplicationCommand {
 	commands := []*discordgo.ApplicationCommand{
 		{
 			Name:        "goose",
@@ -213,6 +419,11 @@ func (h *SlashCommandHandler) RegisterCommands(guildID string) error {
 					Name:        "report",
 					Description: "Get your daily report with debug info",
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "mine",
+					Description: "List open PRs you've been notified about",
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "help",
@@ -228,6 +439,11 @@ func (h *SlashCommandHandler) RegisterCommands(guildID string) error {
 					Name:        "channels",
 					Description: "Show repository to channel mappings",
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "whoami",
+					Description: "Show which GitHub account you're mapped to",
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "github-user",
@@ -241,17 +457,159 @@ func (h *SlashCommandHandler) RegisterCommands(guildID string) error {
 						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "map",
+					Description: "Map a GitHub username to a server member (server admins only)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "github",
+							Description: "GitHub username",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "discord",
+							Description: "Discord user to notify for this GitHub user",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "done",
+					Description: "Stop notifications about a PR until its state changes",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "pr-url",
+							Description: "The PR you've dealt with",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "ooo",
+					Description: "Hand your PR pings and DMs to your backup while you're out of office",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "until",
+							Description: "Last day you're out, as YYYY-MM-DD, or \"off\" if you're back",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "maintenance",
+					Description: "Pause or resume channel posts and DMs (server admins only)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "state",
+							Description: "Turn maintenance mode on or off",
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "on", Value: "on"},
+								{Name: "off", Value: "off"},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "redirect",
+					Description: "Send a repo's PR notifications to another channel for a while (server admins only)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "repo",
+							Description: "The repo, as name or org/name",
+							Required:    true,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "channel",
+							Description:  "Channel to post the repo's PRs in",
+							Required:     true,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildForum},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "duration",
+							Description: "How long, like 4h or 2d, or \"off\" to end it now",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "reload",
+					Description: "Reload the server's org configs now (server admins only)",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "inspect",
+					Description: "Show what the bot has stored about a PR (server admins only)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "pr-url",
+							Description: "The PR to inspect",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "dedup",
+					Description: "Manage the event dedup cache (server admins only)",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "clear",
+							Description: "Forget processed events so redeliveries are processed again",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "pr-url",
+									Description: "Only clear events for this PR",
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 	}
 
+	dmPermission := false
+	guildOnly := []discordgo.InteractionContextType{discordgo.InteractionContextGuild}
+	for _, cmd := range commands {
+		cmd.DMPermission = &dmPermission
+		cmd.Contexts = &guildOnly
+	}
+	return commands
+}
+
+// RegisterCommands registers the slash commands with a guild, or globally if guildID is
+// empty, replacing whatever commands were registered there before.
+func (h *SlashCommandHandler) RegisterCommands(guildID string) error {
+	commands := slashCommands()
+	if _, err := h.session.ApplicationCommandBulkOverwrite(h.session.State.User.ID, guildID, commands); err != nil {
+		return fmt.Errorf("register commands: %w", err)
+	}
+	scope := CommandScopeGuild
+	if guildID == "" {
+		scope = CommandScopeGlobal
+	}
 	for _, cmd := range commands {
-		_, err := h.session.ApplicationCommandCreate(h.session.State.User.ID, guildID, cmd)
-		if err != nil {
-			return fmt.Errorf("create command %s: %w", cmd.Name, err)
-		}
 		h.logger.Info("registered slash command",
 			"command", cmd.Name,
+			"scope", scope,
 			"guild_id", guildID)
 	}
 
@@ -264,9 +622,20 @@ func (h *SlashCommandHandler) SetupHandler() {
 }
 
 func (h *SlashCommandHandler) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionMessageComponent {
+		h.handleDMAction(s, i)
+		return
+	}
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
+	// Commands are guild-only, but one registered before they were may still be run in a DM,
+	// where there's no member to act for
+	if i.Member == nil || i.Member.User == nil {
+		h.logger.Warn("slash command run outside a guild", "interaction_id", i.ID)
+		h.respondError(s, i, "reviewGOOSE commands only work in a server, not in DMs.")
+		return
+	}
 
 	data := i.ApplicationCommandData()
 
@@ -302,14 +671,34 @@ func (h *SlashCommandHandler) handleGooseCommand(
 		h.handleDashCommand(s, i)
 	case "report":
 		h.handleReportCommand(s, i)
+	case "mine":
+		h.handleMineCommand(s, i)
 	case "help":
 		h.handleHelpCommand(s, i)
 	case "users":
 		h.handleUsersCommand(s, i)
 	case "channels":
 		h.handleChannelsCommand(s, i)
+	case "whoami":
+		h.handleWhoAmICommand(s, i)
 	case "github-user":
 		h.handleGitHubUserCommand(s, i, data.Options[0])
+	case "map":
+		h.handleMapCommand(s, i, data.Options[0])
+	case "done":
+		h.handleDoneCommand(s, i, data.Options[0])
+	case "ooo":
+		h.handleOOOCommand(s, i, data.Options[0])
+	case "dedup":
+		h.handleDedupCommand(s, i, data.Options[0])
+	case "maintenance":
+		h.handleMaintenanceCommand(s, i, data.Options[0])
+	case "redirect":
+		h.handleRedirectCommand(s, i, data.Options[0])
+	case "reload":
+		h.handleReloadCommand(s, i)
+	case "inspect":
+		h.handleInspectCommand(s, i, data.Options[0])
 	default:
 		h.respondError(s, i, "Unknown subcommand")
 	}
@@ -393,6 +782,44 @@ func (h *SlashCommandHandler) handleStatusCommand(session *discordgo.Session, in
 				Value:  strconv.Itoa(len(status.ConfiguredRepos)),
 				Inline: true,
 			},
+			{
+				Name:   "Events",
+				Value:  strconv.FormatInt(status.EventsProcessed, 10),
+				Inline: true,
+			},
+			{
+				Name:   "Turn Calls",
+				Value:  formatTurnCalls(status),
+				Inline: true,
+			},
+			{
+				Name:   "Errors",
+				Value:  formatErrors(status),
+				Inline: true,
+			},
+			{
+				Name:   "Messages",
+				Value:  fmt.Sprintf("%d posted, %d edited", status.ChannelMessagesSent, status.ChannelMessageEdits),
+				Inline: true,
+			},
+			{
+				Name:   "DMs Queued",
+				Value:  strconv.FormatInt(status.DMsQueued, 10),
+				Inline: true,
+			},
+			{
+				Name: "Discord Writes",
+				Value: fmt.Sprintf("%d (%d paced, %s waiting)",
+					status.DiscordWrites, status.DiscordWritesPaced, status.DiscordPaceWait.Round(time.Second)),
+				Inline: true,
+			},
+			{
+				Name: "Latency (p50 / p95)",
+				Value: fmt.Sprintf("Posts %s / %s\nDMs %s / %s",
+					formatLatency(status.PostLatencyP50), formatLatency(status.PostLatencyP95),
+					formatLatency(status.DMLatencyP50), formatLatency(status.DMLatencyP95)),
+				Inline: true,
+			},
 		},
 	}
 
@@ -419,6 +846,35 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// formatLatency formats a notification latency to the second, or as "n/a" before there's any.
+func formatLatency(d time.Duration) string {
+	if d <= 0 {
+		return "n/a"
+	}
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatTurnCalls formats the Turn call count, noting calls held back by orgs' Turn rate limits.
+func formatTurnCalls(status BotStatus) string {
+	calls := strconv.FormatInt(status.TurnCalls, 10)
+	if status.TurnDeferred == 0 && status.TurnThrottled == 0 {
+		return calls
+	}
+	return fmt.Sprintf("%s (%d deferred, %d throttled)", calls, status.TurnDeferred, status.TurnThrottled)
+}
+
+// formatErrors formats the processing error count, noting events with unparseable URLs.
+func formatErrors(status BotStatus) string {
+	errs := strconv.FormatInt(status.ProcessingErrors, 10)
+	if status.UnparseableURLs == 0 {
+		return errs
+	}
+	return fmt.Sprintf("%s (%d unparseable URLs)", errs, status.UnparseableURLs)
+}
+
 func (h *SlashCommandHandler) handleDashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	h.logger.Info("handling dash command",
 		"guild_id", i.GuildID,
@@ -494,7 +950,7 @@ func (h *SlashCommandHandler) generateAndSendDash(s *discordgo.Session, i *disco
 			orgLinks = "\n\n**Organization Dashboards:**\n"
 			var orgLinksSb435 strings.Builder
 			for _, org := range status.ConnectedOrgs {
-				orgLinksSb435.WriteString(fmt.Sprintf("• %s: [View Dashboard](%s/orgs/%s)\n", org, h.dashboardURL, org))
+				orgLinksSb435.WriteString(fmt.Sprintf("• %s: [View Dashboard](%s)\n", org, h.orgDashboardURL(org)))
 			}
 			orgLinks += orgLinksSb435.String()
 		}
@@ -517,7 +973,7 @@ func (h *SlashCommandHandler) generateAndSendDash(s *discordgo.Session, i *disco
 	h.editResponse(s, i, "", embed)
 }
 
-func (*SlashCommandHandler) formatDashboardEmbed(report *PRReport, dashboardLink, orgLinks string) *discordgo.MessageEmbed {
+func (h *SlashCommandHandler) formatDashboardEmbed(report *PRReport, dashboardLink, orgLinks string) *discordgo.MessageEmbed {
 	// Use Discord green if there are no PRs to review, yellow if there are
 	color := 0x57F287 // Discord green - all clear
 	if report != nil && len(report.IncomingPRs) > 0 {
@@ -533,35 +989,36 @@ func (*SlashCommandHandler) formatDashboardEmbed(report *PRReport, dashboardLink
 
 	// Add PR sections if report is available
 	if report != nil {
+		limit := h.dashboardMaxPRs
+		if limit <= 0 {
+			limit = DefaultDashboardMaxPRs
+		}
+
 		// Incoming PRs section
 		if len(report.IncomingPRs) > 0 {
-			var b strings.Builder
-			for i := range report.IncomingPRs {
-				pr := &report.IncomingPRs[i]
-				b.WriteString(fmt.Sprintf("**[%s#%d](%s)** %s",
-					pr.Repo, pr.Number, pr.URL, format.Truncate(pr.Title, 50)))
-				if pr.Author != "" {
-					b.WriteString(fmt.Sprintf(" • `%s`", pr.Author))
-				}
-				b.WriteString("\n")
-			}
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:  fmt.Sprintf("📥 Reviewing (%d)", len(report.IncomingPRs)),
-				Value: strings.TrimSpace(b.String()),
+				Name: fmt.Sprintf("📥 Reviewing (%d)", len(report.IncomingPRs)),
+				Value: dashboardPRList(report.IncomingPRs, limit, dashboardLink, func(pr *PRSummary) string {
+					line := fmt.Sprintf("**[%s#%d](%s)** %s", pr.Repo, pr.Number, pr.URL, format.Truncate(pr.Title, 50))
+					if name := dashboardAuthor(pr); name != "" {
+						line += fmt.Sprintf(" • `%s`", name)
+					}
+					return line
+				}),
 			})
 		}
 
 		// Outgoing PRs section
 		if len(report.OutgoingPRs) > 0 {
-			var b strings.Builder
-			for i := range report.OutgoingPRs {
-				pr := &report.OutgoingPRs[i]
-				b.WriteString(fmt.Sprintf("**[%s#%d](%s)** %s\n",
-					pr.Repo, pr.Number, pr.URL, format.Truncate(pr.Title, 50)))
-			}
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:  fmt.Sprintf("📤 Your PRs (%d)", len(report.OutgoingPRs)),
-				Value: strings.TrimSpace(b.String()),
+				Name: fmt.Sprintf("📤 Your PRs (%d)", len(report.OutgoingPRs)),
+				Value: dashboardPRList(report.OutgoingPRs, limit, dashboardLink, func(pr *PRSummary) string {
+					line := fmt.Sprintf("**[%s#%d](%s)** %s", pr.Repo, pr.Number, pr.URL, format.Truncate(pr.Title, 50))
+					if len(pr.Reviewers) > 0 {
+						line += " • reviewed by " + dashboardReviewers(pr)
+					}
+					return line
+				}),
 			})
 		}
 
@@ -589,6 +1046,71 @@ func (*SlashCommandHandler) formatDashboardEmbed(report *PRReport, dashboardLink
 	return embed
 }
 
+// dashboardAuthor returns how a /goose dash line names a PR's author: their Discord display
+// name when it's known, else their GitHub login.
+func dashboardAuthor(pr *PRSummary) string {
+	if pr.AuthorName != "" {
+		return pr.AuthorName
+	}
+	return pr.Author
+}
+
+// dashboardReviewers lists a PR's reviewers for a /goose dash line, each by their Discord
+// display name when it's known, else their GitHub login.
+func dashboardReviewers(pr *PRSummary) string {
+	names := make([]string, 0, len(pr.Reviewers))
+	for _, login := range pr.Reviewers {
+		name := login
+		if n := pr.ReviewerNames[login]; n != "" {
+			name = n
+		}
+		names = append(names, "`"+name+"`")
+	}
+	return strings.Join(names, ", ")
+}
+
+// orgDashboardURL returns the link to an org's PRs on its dashboard.
+func (h *SlashCommandHandler) orgDashboardURL(org string) string {
+	if h.dashboardLinker != nil {
+		if link := h.dashboardLinker.DashboardOrgURL(org); link != "" {
+			return link
+		}
+	}
+	return format.DashboardOrgURL(h.dashboardURL, org)
+}
+
+// maxEmbedFieldValue is the most characters Discord allows in an embed field's value.
+const maxEmbedFieldValue = 1024
+
+// dashboardPRList renders a /goose dash section's PRs one per line: at most limit of them, and
+// no more than fit in an embed field. The rest are counted in a "+N more" dashboard link.
+func dashboardPRList(prs []PRSummary, limit int, dashboardLink string, line func(*PRSummary) string) string {
+	// Room is kept for the overflow note at its longest
+	note := func(n int) string {
+		return fmt.Sprintf("+%d more ([view dashboard](%s))", n, dashboardLink)
+	}
+	room := maxEmbedFieldValue - len(note(len(prs))) - 1
+
+	var b strings.Builder
+	shown := 0
+	for i := range prs {
+		if shown == limit {
+			break
+		}
+		l := line(&prs[i])
+		if b.Len()+len(l)+1 > room {
+			break
+		}
+		b.WriteString(l)
+		b.WriteString("\n")
+		shown++
+	}
+	if shown < len(prs) {
+		b.WriteString(note(len(prs) - shown))
+	}
+	return strings.TrimSpace(b.String())
+}
+
 func (h *SlashCommandHandler) handleReportCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	h.logger.Info("handling report command",
 		"guild_id", i.GuildID,
@@ -638,7 +1160,7 @@ func (h *SlashCommandHandler) generateAndSendReportWithDebug(s *discordgo.Sessio
 			"user_id", userID,
 			"guild_id", guildID,
 			"interaction_id", i.ID)
-		h.editResponse(s, i, fmt.Sprintf("❌ Failed to generate daily report: %s", err), nil)
+		h.editResponse(s, i, "❌ Failed to generate daily report. Please try again later.", nil)
 		return
 	}
 
@@ -747,6 +1269,81 @@ func (*SlashCommandHandler) formatDailyReportEmbed(debug *DailyReportDebug) *dis
 	return embed
 }
 
+func (h *SlashCommandHandler) handleMineCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.logger.Info("handling mine command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if h.store == nil {
+		h.respondError(s, i, "PR tracking is not available.")
+		return
+	}
+
+	// Context is created here because this is a callback from discordgo library
+	// which doesn't provide context in its handler signature
+	ctx := context.Background()
+	h.respond(s, i, formatMinePRsEmbed(h.activePRsForUser(ctx, i.Member.User.ID)))
+}
+
+// activePRsForUser returns the still-open PRs a user was DMed about, most recently notified first.
+// It reads the bot's own tracked state, so unlike /goose dash it needs no GitHub calls.
+func (h *SlashCommandHandler) activePRsForUser(ctx context.Context, userID string) []state.TrackedDM {
+	var active []state.TrackedDM
+	for _, dm := range h.store.ListPRsForUser(ctx, userID) {
+		prState := format.PRState(dm.Info.LastState)
+		if prState == format.StateMerged || prState == format.StateClosed {
+			continue
+		}
+		active = append(active, dm)
+	}
+	slices.SortFunc(active, func(a, b state.TrackedDM) int {
+		return b.Info.SentAt.Compare(a.Info.SentAt)
+	})
+	return active
+}
+
+func formatMinePRsEmbed(dms []state.TrackedDM) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Color: 0x5865F2, // Discord blurple
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Your PRs",
+		},
+	}
+	if len(dms) == 0 {
+		embed.Description = "✅ All caught up! You haven't been notified about any open PRs."
+		return embed
+	}
+
+	var b strings.Builder
+	for _, dm := range dms {
+		prState := format.PRState(dm.Info.LastState)
+		b.WriteString(fmt.Sprintf("%s **[%s](%s)**", format.StateEmoji(prState), prReference(dm.PRURL), dm.PRURL))
+		if text := format.StateText(prState); text != "" {
+			b.WriteString(" • " + text)
+		}
+		b.WriteString("\n")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("📋 Notified (%d)", len(dms)),
+		Value: strings.TrimSpace(b.String()),
+	})
+	return embed
+}
+
+// prReference shortens a GitHub PR URL to owner/repo#number, or returns it unchanged
+// if it isn't one.
+func prReference(prURL string) string {
+	path, ok := strings.CutPrefix(prURL, "https://github.com/")
+	if !ok {
+		return prURL
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 || parts[2] != "pull" {
+		return prURL
+	}
+	return fmt.Sprintf("%s/%s#%s", parts[0], parts[1], parts[3])
+}
+
 func (h *SlashCommandHandler) handleHelpCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	h.logger.Info("handling help command",
 		"guild_id", i.GuildID,
@@ -763,8 +1360,11 @@ func (h *SlashCommandHandler) handleHelpCommand(s *discordgo.Session, i *discord
 				Name: "Commands",
 				Value: "**`/goose dash`** • View your PRs and dashboard\n" +
 					"**`/goose report`** • Generate daily report with debug info\n" +
+					"**`/goose mine`** • Open PRs you've been notified about\n" +
+					"**`/goose done`** • Silence a PR until its state changes\n" +
 					"**`/goose status`** • Bot status and stats\n" +
 					"**`/goose users`** • User mappings\n" +
+					"**`/goose whoami`** • Your GitHub mapping\n" +
 					"**`/goose channels`** • Channel mappings",
 			},
 			{
@@ -858,37 +1458,110 @@ func (*SlashCommandHandler) formatUserMappingsEmbed(mappings *UserMappings) *dis
 	return embed
 }
 
-func (h *SlashCommandHandler) handleChannelsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	h.logger.Info("handling channels command",
+func (h *SlashCommandHandler) handleWhoAmICommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.logger.Info("handling whoami command",
 		"guild_id", i.GuildID,
 		"user_id", i.Member.User.ID)
 
 	// Context is created here because this is a callback from discordgo library
 	// which doesn't provide context in its handler signature
 	ctx := context.Background()
-	guildID := i.GuildID
 
-	if h.channelMapGetter == nil {
-		h.respondError(s, i, "Channel mapping information is not available.")
+	if h.userMapGetter == nil {
+		h.respondError(s, i, "User mapping information is not available.")
 		return
 	}
 
-	mappings, err := h.channelMapGetter.ChannelMappings(ctx, guildID)
+	who, err := h.userMapGetter.WhoAmI(ctx, i.GuildID, i.Member.User.ID)
 	if err != nil {
-		h.logger.Error("failed to get channel mappings",
+		h.logger.Error("failed to get user mapping",
 			"error", err,
-			"guild_id", guildID,
+			"guild_id", i.GuildID,
 			"user_id", i.Member.User.ID)
-		h.respondError(s, i, "Failed to retrieve channel mappings.")
+		h.respondError(s, i, "Failed to retrieve your user mapping.")
 		return
 	}
 
-	embed := h.formatChannelMappingsEmbed(mappings)
-	h.respond(s, i, embed)
+	h.respond(s, i, formatWhoAmIEmbed(who))
 }
 
-func (h *SlashCommandHandler) handleGitHubUserCommand(
-	s *discordgo.Session,
+func formatWhoAmIEmbed(who *WhoAmI) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Color: 0x5865F2, // Discord blurple
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Your GitHub Mapping",
+		},
+	}
+
+	if who.LinkedUsername != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "🔗 Linked",
+			Value: fmt.Sprintf("`%s`", who.LinkedUsername),
+		})
+	}
+	if len(who.ConfigUsernames) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "⚙️ Config",
+			Value: "`" + strings.Join(who.ConfigUsernames, "`, `") + "`",
+		})
+	}
+
+	if len(who.Conflicts) > 0 {
+		embed.Color = 0xFEE75C // Discord yellow - needs attention
+		var b strings.Builder
+		for i := range who.Conflicts {
+			c := &who.Conflicts[i]
+			b.WriteString(fmt.Sprintf("`%s` • config → <@%s>, linked → <@%s>; using <@%s> (`%s` policy)",
+				c.GitHubUsername, c.ConfigID, c.LinkedID, c.ResolvedID, c.Policy))
+			if c.Org != "" {
+				b.WriteString(fmt.Sprintf(" • `%s`", c.Org))
+			}
+			b.WriteString("\n")
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("⚠️ Conflicts (%d)", len(who.Conflicts)),
+			Value: b.String(),
+		})
+	}
+
+	if len(embed.Fields) == 0 {
+		embed.Description = "You're not mapped to a GitHub account. Use `/goose github-user` to link one."
+	}
+
+	return embed
+}
+
+func (h *SlashCommandHandler) handleChannelsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.logger.Info("handling channels command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	// Context is created here because this is a callback from discordgo library
+	// which doesn't provide context in its handler signature
+	ctx := context.Background()
+	guildID := i.GuildID
+
+	if h.channelMapGetter == nil {
+		h.respondError(s, i, "Channel mapping information is not available.")
+		return
+	}
+
+	mappings, err := h.channelMapGetter.ChannelMappings(ctx, guildID)
+	if err != nil {
+		h.logger.Error("failed to get channel mappings",
+			"error", err,
+			"guild_id", guildID,
+			"user_id", i.Member.User.ID)
+		h.respondError(s, i, "Failed to retrieve channel mappings.")
+		return
+	}
+
+	embed := h.formatChannelMappingsEmbed(mappings)
+	h.respond(s, i, embed)
+}
+
+func (h *SlashCommandHandler) handleGitHubUserCommand(
+	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
 	option *discordgo.ApplicationCommandInteractionDataOption,
 ) {
@@ -941,6 +1614,9 @@ func (h *SlashCommandHandler) handleGitHubUserCommand(
 		h.respondError(s, i, "Failed to save user mapping. Please try again.")
 		return
 	}
+	if h.mappingCache != nil {
+		h.mappingCache.InvalidateUserMapping(guildID, gitHubUsername, discordUserID)
+	}
 
 	// Success response
 	embed := &discordgo.MessageEmbed{
@@ -964,6 +1640,718 @@ func (h *SlashCommandHandler) handleGitHubUserCommand(
 	h.respond(s, i, embed)
 }
 
+func (h *SlashCommandHandler) handleMapCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling map command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can map users. Use /goose github-user to link your own account.")
+		return
+	}
+
+	var gitHubUsername, discordUserID string
+	for _, opt := range option.Options {
+		switch opt.Name {
+		case "github":
+			gitHubUsername = strings.TrimSpace(opt.StringValue())
+		case "discord":
+			discordUserID = opt.UserValue(nil).ID
+		}
+	}
+
+	if err := h.mapUser(context.Background(), s, i.GuildID, gitHubUsername, discordUserID); err != nil {
+		h.logger.Warn("failed to map user",
+			"error", err,
+			"guild_id", i.GuildID,
+			"github_username", gitHubUsername,
+			"discord_user_id", discordUserID)
+		h.respondError(s, i, errorReply(err, "Failed to map user. Please try again later."))
+		return
+	}
+
+	h.logger.Info("admin mapped user",
+		"guild_id", i.GuildID,
+		"admin_id", i.Member.User.ID,
+		"github_username", gitHubUsername,
+		"discord_user_id", discordUserID)
+	h.respond(s, i, &discordgo.MessageEmbed{
+		Color: 0x57F287, // Discord green
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "GitHub Account Mapped",
+		},
+		Description: fmt.Sprintf("<@%s> will now receive notifications for PRs associated with GitHub user `%s`.", discordUserID, gitHubUsername),
+	})
+}
+
+// mapUser saves a GitHub -> Discord mapping on a member's behalf and drops stale cached mappings.
+// The Discord user must be a member of the guild.
+func (h *SlashCommandHandler) mapUser(ctx context.Context, members memberLookup, guildID, gitHubUsername, discordUserID string) error {
+	if h.store == nil {
+		return userError("User mapping storage is not available.")
+	}
+	if !gitHubUsernameRegex.MatchString(gitHubUsername) {
+		return userError(fmt.Sprintf("Invalid GitHub username %q.", gitHubUsername))
+	}
+	if discordUserID == "" {
+		return userError("No Discord user given.")
+	}
+	if _, err := members.GuildMember(guildID, discordUserID); err != nil {
+		return userError(fmt.Sprintf("<@%s> is not a member of this server.", discordUserID))
+	}
+
+	if err := h.store.SaveUserMapping(ctx, guildID, state.UserMappingInfo{
+		GitHubUsername: gitHubUsername,
+		DiscordUserID:  discordUserID,
+		GuildID:        guildID,
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		return fmt.Errorf("save mapping: %w", err)
+	}
+	if h.mappingCache != nil {
+		h.mappingCache.InvalidateUserMapping(guildID, gitHubUsername, discordUserID)
+	}
+	return nil
+}
+
+func (h *SlashCommandHandler) handleDedupCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling dedup command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can clear the dedup cache.")
+		return
+	}
+	if h.dedupClearer == nil {
+		h.respondError(s, i, "Dedup cache management is not available.")
+		return
+	}
+	if len(option.Options) == 0 || option.Options[0].Name != "clear" {
+		h.respondError(s, i, "Please specify a subcommand: /goose dedup clear")
+		return
+	}
+
+	var prURL string
+	for _, opt := range option.Options[0].Options {
+		if opt.Name == "pr-url" {
+			prURL = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	cleared, err := h.dedupClearer.ClearDedup(context.Background(), i.GuildID, prURL)
+	if err != nil {
+		h.logger.Error("failed to clear dedup cache",
+			"error", err,
+			"guild_id", i.GuildID,
+			"pr_url", prURL)
+		h.respondError(s, i, "Failed to clear dedup cache. Please try again later.")
+		return
+	}
+
+	h.logger.Info("cleared dedup cache",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID,
+		"pr_url", prURL,
+		"cleared", cleared)
+	h.respond(s, i, formatDedupClearedEmbed(prURL, cleared))
+}
+
+func (h *SlashCommandHandler) handleDoneCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling done command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if h.handledMarker == nil {
+		h.respondError(s, i, "Marking PRs done is not available.")
+		return
+	}
+
+	var prURL string
+	for _, opt := range option.Options {
+		if opt.Name == "pr-url" {
+			prURL = strings.TrimSpace(opt.StringValue())
+		}
+	}
+	if prURL == "" {
+		h.respondError(s, i, "Please specify a PR: /goose done <pr-url>")
+		return
+	}
+
+	if err := h.handledMarker.MarkHandled(context.Background(), i.GuildID, i.Member.User.ID, prURL); err != nil {
+		h.logger.Warn("failed to mark PR done",
+			"error", err,
+			"guild_id", i.GuildID,
+			"user_id", i.Member.User.ID,
+			"pr_url", prURL)
+		if errors.Is(err, ErrNotActionTarget) {
+			h.respondError(s, i, "That PR isn't waiting on you.")
+			return
+		}
+		h.respondError(s, i, "Couldn't mark that PR done. Please try again later.")
+		return
+	}
+
+	h.respond(s, i, formatDoneEmbed(prURL))
+}
+
+func formatDoneEmbed(prURL string) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Color: 0x57F287, // Discord green
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Marked Done",
+		},
+		Description: fmt.Sprintf("You won't be pinged or DMed about %s until its state changes.", prURL),
+	}
+}
+
+func (h *SlashCommandHandler) handleOOOCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling ooo command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if h.store == nil {
+		h.respondError(s, i, "Out of office is not available.")
+		return
+	}
+
+	var value string
+	for _, opt := range option.Options {
+		if opt.Name == "until" {
+			value = opt.StringValue()
+		}
+	}
+	loc := time.UTC
+	if h.timezones != nil {
+		loc = h.timezones.GuildLocation(i.GuildID)
+	}
+	now := time.Now()
+	until, err := parseOOOUntil(value, now, loc)
+	if err != nil {
+		h.respondError(s, i, errorReply(err, "Couldn't read that date."))
+		return
+	}
+
+	info := state.OOOInfo{Until: until, SetAt: now}
+	if err := h.store.SaveOOO(context.Background(), i.Member.User.ID, info); err != nil {
+		h.logger.Error("failed to save out of office status",
+			"error", err,
+			"user_id", i.Member.User.ID)
+		h.respondError(s, i, "Failed to save your out of office status. Please try again.")
+		return
+	}
+
+	h.logger.Info("set out of office status",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID,
+		"until", until)
+	h.respond(s, i, formatOOOEmbed(until))
+}
+
+// parseOOOUntil parses the last day of a user's time out of office, as YYYY-MM-DD, into the
+// end of that day in loc. "off" returns the zero time, ending it.
+func parseOOOUntil(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") {
+		return time.Time{}, nil
+	}
+	day, err := time.ParseInLocation(time.DateOnly, value, loc)
+	if err != nil {
+		return time.Time{}, userError("Please give your last day out as YYYY-MM-DD, or \"off\": /goose ooo 2025-01-31")
+	}
+	until := day.AddDate(0, 0, 1)
+	if !until.After(now) {
+		return time.Time{}, userError("That day has already passed.")
+	}
+	if until.Sub(now) > state.MaxOOO {
+		return time.Time{}, userError(fmt.Sprintf("Out of office can be set for up to %d days.", int(state.MaxOOO.Hours()/24)))
+	}
+	return until, nil
+}
+
+func formatOOOEmbed(until time.Time) *discordgo.MessageEmbed {
+	if until.IsZero() {
+		return &discordgo.MessageEmbed{
+			Color: 0x57F287, // Discord green
+			Author: &discordgo.MessageEmbedAuthor{
+				Name: "Welcome Back",
+			},
+			Description: "You'll be pinged and DMed about your PRs again.",
+		}
+	}
+	return &discordgo.MessageEmbed{
+		Color: 0xFEE75C, // Discord yellow
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Out of Office",
+		},
+		Description: fmt.Sprintf("Through %s, PRs waiting only on you go to your backup if your org has one "+
+			"configured, and you're left out of PRs others can move along. Run `/goose ooo off` when you're back.",
+			until.AddDate(0, 0, -1).Format(time.DateOnly)),
+	}
+}
+
+func (h *SlashCommandHandler) handleMaintenanceCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling maintenance command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can toggle maintenance mode.")
+		return
+	}
+	if h.maintenance == nil {
+		h.respondError(s, i, "Maintenance mode is not available.")
+		return
+	}
+
+	var mode string
+	for _, opt := range option.Options {
+		if opt.Name == "state" {
+			mode = opt.StringValue()
+		}
+	}
+	if mode != "on" && mode != "off" {
+		h.respondError(s, i, "Please specify a state: /goose maintenance on|off")
+		return
+	}
+	on := mode == "on"
+
+	// Turning maintenance off replays the events it held back, which can outlast
+	// Discord's deadline for answering an interaction
+	if !h.deferResponse(s, i) {
+		return
+	}
+	if err := h.maintenance.SetMaintenance(context.Background(), i.GuildID, i.Member.User.ID, on); err != nil {
+		h.logger.Error("failed to toggle maintenance mode",
+			"error", err,
+			"guild_id", i.GuildID,
+			"on", on)
+		h.editResponse(s, i, fmt.Sprintf("Error: Failed to turn maintenance mode %s.", mode), nil)
+		return
+	}
+
+	h.logger.Info("toggled maintenance mode",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID,
+		"on", on)
+	h.editResponse(s, i, "", formatMaintenanceEmbed(on))
+}
+
+func formatMaintenanceEmbed(on bool) *discordgo.MessageEmbed {
+	if on {
+		return &discordgo.MessageEmbed{
+			Color: 0xFEE75C, // Discord yellow
+			Author: &discordgo.MessageEmbedAuthor{
+				Name: "Maintenance Mode On",
+			},
+			Description: "Channel posts and DMs are paused. PR events are still tracked; " +
+				"turn it off with `/goose maintenance off`.",
+		}
+	}
+	return &discordgo.MessageEmbed{
+		Color: 0x57F287, // Discord green
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Maintenance Mode Off",
+		},
+		Description: "Channel posts and DMs have resumed.",
+	}
+}
+
+func (h *SlashCommandHandler) handleRedirectCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling redirect command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can redirect a repo.")
+		return
+	}
+	if h.repoRedirector == nil {
+		h.respondError(s, i, "Redirecting repos is not available.")
+		return
+	}
+
+	var repo, channel, value string
+	for _, opt := range option.Options {
+		switch opt.Name {
+		case "repo":
+			repo = strings.TrimSpace(opt.StringValue())
+		case "channel":
+			// Config refers to channels by name, so store the name where it's known
+			ch := opt.ChannelValue(s)
+			channel = cmp.Or(ch.Name, ch.ID)
+		case "duration":
+			value = opt.StringValue()
+		}
+	}
+	if repo == "" || channel == "" {
+		h.respondError(s, i, "Please specify a repo and channel: /goose redirect <repo> <#channel> <duration>")
+		return
+	}
+	duration, err := parseRedirectDuration(value)
+	if err != nil {
+		h.respondError(s, i, errorReply(err, "Couldn't read that duration."))
+		return
+	}
+	var until time.Time
+	if duration > 0 {
+		until = time.Now().Add(duration)
+	}
+
+	if err := h.repoRedirector.RedirectRepo(context.Background(), i.GuildID, i.Member.User.ID, repo, channel, until); err != nil {
+		h.logger.Error("failed to redirect repo",
+			"error", err,
+			"guild_id", i.GuildID,
+			"repo", repo)
+		h.respondError(s, i, fmt.Sprintf("Failed to redirect %s. Please try again later.", repo))
+		return
+	}
+
+	h.logger.Info("redirected repo",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID,
+		"repo", repo,
+		"channel", channel,
+		"until", until)
+	h.respond(s, i, formatRedirectEmbed(repo, channel, until))
+}
+
+// parseRedirectDuration parses how long to redirect a repo for, as a Go duration like 90m or
+// 4h, or a number of days like 2d. "off" returns 0, ending the redirect.
+func parseRedirectDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") {
+		return 0, nil
+	}
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil || d <= 0 {
+		return 0, userError("Please give a duration like 4h or 2d, or \"off\": /goose redirect repo #channel 4h")
+	}
+	if d > state.MaxRepoRedirect {
+		return 0, userError(fmt.Sprintf("A repo can be redirected for up to %d days.", int(state.MaxRepoRedirect.Hours()/24)))
+	}
+	return d, nil
+}
+
+func formatRedirectEmbed(repo, channel string, until time.Time) *discordgo.MessageEmbed {
+	if until.IsZero() {
+		return &discordgo.MessageEmbed{
+			Color: 0x57F287, // Discord green
+			Author: &discordgo.MessageEmbedAuthor{
+				Name: "Redirect Ended",
+			},
+			Description: fmt.Sprintf("PRs in %s go to their configured channels again.", repo),
+		}
+	}
+	return &discordgo.MessageEmbed{
+		Color: 0xFEE75C, // Discord yellow
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Repo Redirected",
+		},
+		Description: fmt.Sprintf("PRs in %s go to #%s until <t:%d:f>, then back to their configured channels. "+
+			"End it early with `/goose redirect %s #%s off`.", repo, channel, until.Unix(), repo, channel),
+	}
+}
+
+func (h *SlashCommandHandler) handleReloadCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	h.logger.Info("handling reload command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can reload the config.")
+		return
+	}
+	if h.configReloader == nil {
+		h.respondError(s, i, "Config reload is not available.")
+		return
+	}
+
+	// Reloading fetches each org's config from GitHub, which can outlast Discord's
+	// deadline for answering an interaction
+	if !h.deferResponse(s, i) {
+		return
+	}
+	reloads, err := h.configReloader.ReloadConfig(context.Background(), i.GuildID)
+	if err != nil {
+		h.logger.Error("failed to reload config",
+			"error", err,
+			"guild_id", i.GuildID)
+		h.editResponse(s, i, "Error: Failed to reload config.", nil)
+		return
+	}
+	h.editResponse(s, i, "", formatReloadEmbed(reloads))
+}
+
+func formatReloadEmbed(reloads []ConfigReload) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Color: 0x57F287, // Discord green
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Config Reloaded",
+		},
+	}
+
+	failed := 0
+	for _, r := range reloads {
+		var lines []string
+		switch {
+		case r.Err != nil:
+			failed++
+			lines = append(lines, fmt.Sprintf("❌ %v\nThe previous config is still in effect.", r.Err))
+		case len(r.Added) == 0 && len(r.Removed) == 0:
+			lines = append(lines, "No channel changes")
+		default:
+			if len(r.Added) > 0 {
+				lines = append(lines, "Added: "+formatChannelList(r.Added))
+			}
+			if len(r.Removed) > 0 {
+				lines = append(lines, "Removed: "+formatChannelList(r.Removed))
+			}
+		}
+		if r.Err == nil && len(r.Unresolved) > 0 {
+			lines = append(lines, "⚠️ Not found in this server: "+formatChannelList(r.Unresolved))
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  r.Org,
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	switch {
+	case failed > 0 && failed == len(reloads):
+		embed.Color = 0xED4245 // Discord red
+		embed.Author.Name = "Config Reload Failed"
+	case failed > 0:
+		embed.Color = 0xFEE75C // Discord yellow
+		embed.Author.Name = "Config Partially Reloaded"
+	default:
+	}
+	return embed
+}
+
+// formatChannelList formats channel names as #name, comma separated.
+func formatChannelList(channels []string) string {
+	names := make([]string, len(channels))
+	for i, name := range channels {
+		names[i] = "#" + name
+	}
+	return strings.Join(names, ", ")
+}
+
+func (h *SlashCommandHandler) handleInspectCommand(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) {
+	h.logger.Info("handling inspect command",
+		"guild_id", i.GuildID,
+		"user_id", i.Member.User.ID)
+
+	if !isGuildAdmin(i.Member) {
+		h.respondError(s, i, "Only server admins can inspect stored PR state.")
+		return
+	}
+	if h.prInspector == nil {
+		h.respondError(s, i, "PR inspection is not available.")
+		return
+	}
+
+	var prURL string
+	for _, opt := range option.Options {
+		if opt.Name == "pr-url" {
+			prURL = strings.TrimSpace(opt.StringValue())
+		}
+	}
+	inspection, err := h.prInspector.InspectPR(context.Background(), i.GuildID, prURL)
+	if err != nil {
+		h.logger.Warn("failed to inspect PR",
+			"error", err,
+			"guild_id", i.GuildID,
+			"pr_url", prURL)
+		h.respondError(s, i, "Couldn't inspect that PR. Check the URL, and that its org is monitored by this server.")
+		return
+	}
+	h.respond(s, i, formatInspectEmbed(inspection))
+}
+
+// PRInspection is everything the store holds about a PR, for /goose inspect.
+type PRInspection struct {
+	Threads map[string]state.ThreadInfo // Channel ID -> the PR's message or thread there
+	DMs     map[string]state.DMInfo     // Discord user ID -> the user's DM about the PR
+	PRURL   string
+}
+
+// InspectPR collects a PR's stored channel messages and DMs.
+// Callers are responsible for checking that the PR belongs to the requesting guild.
+func InspectPR(ctx context.Context, store state.Store, prURL string) (PRInspection, error) {
+	url := prURLPattern.FindString(prURL)
+	if url == "" {
+		return PRInspection{}, errors.New("not a GitHub PR URL")
+	}
+	// https://github.com/owner/repo/pull/123
+	parts := strings.Split(strings.TrimPrefix(url, "https://github.com/"), "/")
+	number, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return PRInspection{}, fmt.Errorf("invalid PR number: %w", err)
+	}
+
+	inspection := PRInspection{
+		PRURL:   url,
+		Threads: make(map[string]state.ThreadInfo),
+		DMs:     make(map[string]state.DMInfo),
+	}
+	for _, ref := range store.MessagesForPR(ctx, url) {
+		if info, ok := store.Thread(ctx, parts[0], parts[1], number, ref.ChannelID); ok {
+			inspection.Threads[ref.ChannelID] = info
+		}
+	}
+	for _, userID := range store.ListDMUsers(ctx, url) {
+		if info, ok := store.DMInfo(ctx, userID, url); ok {
+			inspection.DMs[userID] = info
+		}
+	}
+	return inspection, nil
+}
+
+// maxEmbedFields is the most fields Discord allows in an embed.
+const maxEmbedFields = 25
+
+func formatInspectEmbed(inspection PRInspection) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Color: 0x5865F2, // Discord blurple
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Stored PR State",
+		},
+		Description: inspection.PRURL,
+	}
+	if len(inspection.Threads) == 0 && len(inspection.DMs) == 0 {
+		embed.Description += "\nNothing is stored for this PR."
+		return embed
+	}
+
+	for _, channelID := range slices.Sorted(maps.Keys(inspection.Threads)) {
+		info := inspection.Threads[channelID]
+		lines := []string{fmt.Sprintf("%s message `%s`", info.ChannelType, info.MessageID)}
+		if info.ThreadID != "" {
+			lines[0] += fmt.Sprintf(" in thread `%s`", info.ThreadID)
+		}
+		if len(info.MessageIDs) > 1 {
+			lines[0] += fmt.Sprintf(" (%d parts)", len(info.MessageIDs))
+		}
+		lines = append(lines,
+			"Last state: "+stateOrNone(info.LastState),
+			"Updated "+discordTimestamp(info.UpdatedAt)+", first seen "+discordTimestamp(info.FirstSeenAt))
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Channel",
+			Value: fmt.Sprintf("<#%s>\n%s", channelID, strings.Join(lines, "\n")),
+		})
+	}
+	for _, userID := range slices.Sorted(maps.Keys(inspection.DMs)) {
+		info := inspection.DMs[userID]
+		lines := []string{
+			fmt.Sprintf("Message `%s`, last state: %s", info.MessageID, stateOrNone(info.LastState)),
+			"Sent " + discordTimestamp(info.SentAt),
+		}
+		if info.HandledState != "" {
+			lines = append(lines, "Done in state "+info.HandledState)
+		}
+		if info.Muted {
+			lines = append(lines, "Muted")
+		}
+		if time.Now().Before(info.SnoozedUntil) {
+			lines = append(lines, "Snoozed until "+discordTimestamp(info.SnoozedUntil))
+		}
+		if info.EscalationLevel > 0 {
+			lines = append(lines, "Followed up")
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "DM",
+			Value: fmt.Sprintf("<@%s>\n%s", userID, strings.Join(lines, "\n")),
+		})
+	}
+
+	if len(embed.Fields) > maxEmbedFields {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Showing %d of %d records", maxEmbedFields, len(embed.Fields)),
+		}
+		embed.Fields = embed.Fields[:maxEmbedFields]
+	}
+	return embed
+}
+
+// stateOrNone returns a stored PR state, or "none" if it's unset.
+func stateOrNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// discordTimestamp formats a time as a Discord timestamp shown relative to now, or "never" if unset.
+func discordTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("<t:%d:R>", t.Unix())
+}
+
+// isGuildAdmin reports whether a member may manage the server.
+func isGuildAdmin(member *discordgo.Member) bool {
+	return member != nil && member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageGuild) != 0
+}
+
+func formatDedupClearedEmbed(prURL string, cleared int) *discordgo.MessageEmbed {
+	scope := "all monitored orgs"
+	if prURL != "" {
+		scope = prURL
+	}
+	entries := "entries"
+	if cleared == 1 {
+		entries = "entry"
+	}
+	return &discordgo.MessageEmbed{
+		Color: 0x57F287, // Discord green
+		Author: &discordgo.MessageEmbedAuthor{
+			Name: "Dedup Cache Cleared",
+		},
+		Description: fmt.Sprintf("Cleared %d %s for %s. Redelivered events will be processed again.", cleared, entries, scope) +
+			"\n\nOnly entries this instance recorded since it last started can be cleared; " +
+			"other instances' entries expire on their own within an hour.",
+	}
+}
+
 func (*SlashCommandHandler) formatChannelMappingsEmbed(mappings *ChannelMappings) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Color: 0x5865F2, // Discord blurple
@@ -1007,6 +2395,60 @@ func (*SlashCommandHandler) formatChannelMappingsEmbed(mappings *ChannelMappings
 	return embed
 }
 
+// handleDMAction handles a click on a DM footer's Snooze or Mute button.
+func (h *SlashCommandHandler) handleDMAction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	action, prURL, ok := parseDMAction(i.MessageComponentData().CustomID)
+	if !ok || i.User == nil {
+		return
+	}
+	if h.dmActionHandler == nil {
+		h.respondError(s, i, "Quick actions are not available right now.")
+		return
+	}
+
+	// Context is created here because this is a callback from discordgo library
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var err error
+	var reply string
+	switch action {
+	case dmActionSnooze:
+		err = h.dmActionHandler.SnoozeDM(ctx, i.User.ID, prURL, dmSnoozeDuration)
+		reply = "😴 Snoozed DMs about this PR for 1h."
+	case dmActionMute:
+		err = h.dmActionHandler.MuteDM(ctx, i.User.ID, prURL)
+		reply = "🔕 Muted DMs about this PR."
+	default:
+		return
+	}
+	if err != nil {
+		h.logger.Warn("failed to apply DM action",
+			"error", err,
+			"action", action,
+			"user_id", i.User.ID,
+			"pr_url", prURL)
+		h.respondError(s, i, "Failed to update your notification settings.")
+		return
+	}
+
+	h.logger.Info("applied DM action",
+		"action", action,
+		"user_id", i.User.ID,
+		"pr_url", prURL)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: reply,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		h.logger.Error("failed to respond to DM action", "error", err)
+	}
+}
+
 func (h *SlashCommandHandler) respond(
 	session *discordgo.Session,
 	i *discordgo.InteractionCreate,
@@ -1034,6 +2476,26 @@ func (h *SlashCommandHandler) respond(
 	}
 }
 
+// deferResponse acknowledges an interaction whose answer may take longer than Discord
+// allows, to be filled in with editResponse. It reports whether the acknowledgment went out.
+func (h *SlashCommandHandler) deferResponse(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		h.logger.Error("failed to defer response",
+			"error", err,
+			"guild_id", i.GuildID,
+			"user_id", i.Member.User.ID,
+			"interaction_id", i.ID)
+		return false
+	}
+	return true
+}
+
 func (h *SlashCommandHandler) editResponse(
 	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
@@ -1070,6 +2532,21 @@ func (h *SlashCommandHandler) editResponse(
 	}
 }
 
+// userError is an error whose text is written for the user who ran a command. Other errors
+// are logged, and the user gets a fixed reply.
+type userError string
+
+func (e userError) Error() string { return string(e) }
+
+// errorReply returns the text of err if it was written for the user, and fallback otherwise.
+func errorReply(err error, fallback string) string {
+	var uerr userError
+	if errors.As(err, &uerr) {
+		return uerr.Error()
+	}
+	return fallback
+}
+
 func (h *SlashCommandHandler) respondError(
 	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
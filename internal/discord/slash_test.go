@@ -2,6 +2,11 @@ package discord
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -9,6 +14,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 
 	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
 func TestTruncate(t *testing.T) {
@@ -178,6 +184,102 @@ func TestFormatDashboardEmbed(t *testing.T) {
 		linksField := assertFieldExists(t, embed.Fields, "Links", "Should have Links field with org links")
 		assertFieldContains(t, linksField, "myorg", "Links field should include org links")
 	})
+
+	manyPRs := func(n int, repo, title string) []PRSummary {
+		prs := make([]PRSummary, n)
+		for i := range prs {
+			prs[i] = PRSummary{
+				Repo:   repo,
+				Number: 1000 + i,
+				Title:  title,
+				Author: "alice",
+				URL:    fmt.Sprintf("https://github.com/o/%s/pull/%d", repo, 1000+i),
+			}
+		}
+		return prs
+	}
+
+	t.Run("many incoming PRs are capped", func(t *testing.T) {
+		report := &PRReport{IncomingPRs: manyPRs(40, "api", "Fix it")}
+		embed := handler.formatDashboardEmbed(report, "https://dash.example.com", "")
+
+		assertEmbedWithinLimits(t, embed)
+		reviewingField := assertFieldExists(t, embed.Fields, "Reviewing (40)", "Should count every incoming PR")
+		assertFieldContains(t, reviewingField, "+30 more", "Should note the PRs left out")
+		assertFieldContains(t, reviewingField, "https://dash.example.com", "Overflow note should link the dashboard")
+		if got := strings.Count(reviewingField.Value, "api#"); got != DefaultDashboardMaxPRs {
+			t.Errorf("listed PRs = %d, want %d", got, DefaultDashboardMaxPRs)
+		}
+	})
+
+	t.Run("high limit still fits the embed", func(t *testing.T) {
+		h := &SlashCommandHandler{}
+		h.SetDashboardMaxPRs(100)
+		long := strings.Repeat("t", 80)
+		report := &PRReport{
+			IncomingPRs: manyPRs(40, "some-long-repository-name", long),
+			OutgoingPRs: manyPRs(40, "some-long-repository-name", long),
+		}
+		embed := h.formatDashboardEmbed(report, "https://dash.example.com", "")
+
+		assertEmbedWithinLimits(t, embed)
+		for _, name := range []string{"Reviewing", "Your PRs"} {
+			field := assertFieldExists(t, embed.Fields, name, "Should have a field per section")
+			assertFieldContains(t, field, "more ([view dashboard]", "Should note the PRs that didn't fit")
+		}
+	})
+
+	t.Run("mapped authors shown by their Discord identity", func(t *testing.T) {
+		report := &PRReport{IncomingPRs: []PRSummary{
+			{Repo: "api", Number: 1, Title: "Add caching", Author: "alice", AuthorName: "Alice L."},
+			{Repo: "api", Number: 2, Title: "Fix typo", Author: "bob"},
+		}, OutgoingPRs: []PRSummary{
+			{
+				Repo: "web", Number: 3, Title: "Add login", Author: "me",
+				Reviewers: []string{"carol", "dave"}, ReviewerNames: map[string]string{"carol": "Carol K."},
+			},
+		}}
+		embed := handler.formatDashboardEmbed(report, "https://dash.example.com", "")
+
+		reviewingField := assertFieldExists(t, embed.Fields, "Reviewing", "Should have Reviewing field")
+		assertFieldContains(t, reviewingField, "`Alice L.`", "Mapped author should show their Discord name")
+		assertFieldContains(t, reviewingField, "`bob`", "Unmapped author should fall back to their GitHub login")
+		if strings.Contains(reviewingField.Value, "`alice`") {
+			t.Errorf("Reviewing = %q, want alice's Discord name instead of the login", reviewingField.Value)
+		}
+		// One PR's author avatar would stand for the whole list, so none is shown
+		if embed.Thumbnail != nil {
+			t.Errorf("Thumbnail = %+v, want none", embed.Thumbnail)
+		}
+
+		yoursField := assertFieldExists(t, embed.Fields, "Your PRs", "Should have Your PRs field")
+		assertFieldContains(t, yoursField, "reviewed by `Carol K.`, `dave`",
+			"Reviewers should show by their Discord name, else their GitHub login")
+	})
+}
+
+// assertEmbedWithinLimits fails the test if an embed would be rejected by Discord.
+func assertEmbedWithinLimits(t *testing.T, embed *discordgo.MessageEmbed) {
+	t.Helper()
+	if len(embed.Fields) > maxEmbedFields {
+		t.Errorf("Fields = %d, want at most %d", len(embed.Fields), maxEmbedFields)
+	}
+	total := len(embed.Title) + len(embed.Description)
+	if embed.Author != nil {
+		total += len(embed.Author.Name)
+	}
+	if embed.Footer != nil {
+		total += len(embed.Footer.Text)
+	}
+	for _, field := range embed.Fields {
+		if len(field.Value) > maxEmbedFieldValue {
+			t.Errorf("field %q value is %d chars, want at most %d", field.Name, len(field.Value), maxEmbedFieldValue)
+		}
+		total += len(field.Name) + len(field.Value)
+	}
+	if total > 6000 {
+		t.Errorf("embed is %d chars, want at most 6000", total)
+	}
 }
 
 func TestNewSlashCommandHandler(t *testing.T) {
@@ -203,6 +305,23 @@ func TestSlashCommandHandler_SetDashboardURL(t *testing.T) {
 	}
 }
 
+func TestSlashCommandHandler_OrgDashboardURL(t *testing.T) {
+	handler := NewSlashCommandHandler(nil, nil)
+	if got, want := handler.orgDashboardURL("myorg"), "https://reviewgoose.dev/orgs/myorg"; got != want {
+		t.Errorf("orgDashboardURL() without a linker = %q, want %q", got, want)
+	}
+
+	handler.SetDashboardLinker(&mockDashboardLinker{urls: map[string]string{
+		"myorg": "https://dash.example.com/myorg",
+	}})
+	if got, want := handler.orgDashboardURL("myorg"), "https://dash.example.com/myorg"; got != want {
+		t.Errorf("orgDashboardURL() = %q, want the org's own dashboard %q", got, want)
+	}
+	if got, want := handler.orgDashboardURL("otherorg"), "https://reviewgoose.dev/orgs/otherorg"; got != want {
+		t.Errorf("orgDashboardURL() for an org without its own dashboard = %q, want %q", got, want)
+	}
+}
+
 func TestSlashCommandHandler_SetStatusGetter(t *testing.T) {
 	handler := NewSlashCommandHandler(nil, nil)
 
@@ -344,6 +463,26 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestFormatTurnCalls(t *testing.T) {
+	if got := formatTurnCalls(BotStatus{TurnCalls: 12}); got != "12" {
+		t.Errorf("formatTurnCalls() without throttling = %q, want %q", got, "12")
+	}
+	got := formatTurnCalls(BotStatus{TurnCalls: 12, TurnDeferred: 3, TurnThrottled: 1})
+	if want := "12 (3 deferred, 1 throttled)"; got != want {
+		t.Errorf("formatTurnCalls() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	if got := formatErrors(BotStatus{ProcessingErrors: 2}); got != "2" {
+		t.Errorf("formatErrors() without unparseable URLs = %q, want %q", got, "2")
+	}
+	got := formatErrors(BotStatus{ProcessingErrors: 2, UnparseableURLs: 5})
+	if want := "2 (5 unparseable URLs)"; got != want {
+		t.Errorf("formatErrors() = %q, want %q", got, want)
+	}
+}
+
 func TestFormatUserMappingsEmbed(t *testing.T) {
 	handler := &SlashCommandHandler{}
 
@@ -452,6 +591,51 @@ func TestFormatUserMappingsEmbed(t *testing.T) {
 	})
 }
 
+func TestFormatWhoAmIEmbed(t *testing.T) {
+	t.Run("unmapped", func(t *testing.T) {
+		embed := formatWhoAmIEmbed(&WhoAmI{})
+		if !strings.Contains(embed.Description, "/goose github-user") {
+			t.Errorf("Description = %q, want hint to link an account", embed.Description)
+		}
+	})
+
+	t.Run("linked without conflict", func(t *testing.T) {
+		embed := formatWhoAmIEmbed(&WhoAmI{LinkedUsername: "alice"})
+		if len(embed.Fields) != 1 || embed.Fields[0].Value != "`alice`" {
+			t.Errorf("Fields = %+v, want single linked field", embed.Fields)
+		}
+		if embed.Color != 0x5865F2 {
+			t.Errorf("Color = %x, want blurple without conflicts", embed.Color)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		embed := formatWhoAmIEmbed(&WhoAmI{
+			LinkedUsername: "alice",
+			Conflicts: []MappingConflict{{
+				GitHubUsername: "alice",
+				Org:            "myorg",
+				ConfigID:       "111",
+				LinkedID:       "222",
+				ResolvedID:     "111",
+				Policy:         "config",
+			}},
+		})
+		if embed.Color != 0xFEE75C {
+			t.Errorf("Color = %x, want yellow for conflicts", embed.Color)
+		}
+		last := embed.Fields[len(embed.Fields)-1]
+		if !strings.Contains(last.Name, "Conflicts (1)") {
+			t.Errorf("field name = %q, want conflicts field", last.Name)
+		}
+		for _, want := range []string{"<@111>", "<@222>", "`config` policy", "`myorg`"} {
+			if !strings.Contains(last.Value, want) {
+				t.Errorf("conflict value = %q, want to contain %q", last.Value, want)
+			}
+		}
+	})
+}
+
 func TestFormatChannelMappingsEmbed(t *testing.T) {
 	handler := &SlashCommandHandler{}
 
@@ -712,8 +896,17 @@ func (m *mockDailyReportGetter) DailyReport(_ context.Context, _, _ string, _ bo
 	return m.debug, m.err
 }
 
+type mockDashboardLinker struct {
+	urls map[string]string
+}
+
+func (m *mockDashboardLinker) DashboardOrgURL(org string) string {
+	return m.urls[org]
+}
+
 type mockUserMapGetter struct {
 	mappings *UserMappings
+	whoami   *WhoAmI
 	err      error
 }
 
@@ -724,6 +917,13 @@ func (m *mockUserMapGetter) UserMappings(_ context.Context, _ string) (*UserMapp
 	return m.mappings, nil
 }
 
+func (m *mockUserMapGetter) WhoAmI(_ context.Context, _, _ string) (*WhoAmI, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.whoami, nil
+}
+
 type mockChannelMapGetter struct {
 	mappings *ChannelMappings
 	err      error
@@ -759,6 +959,74 @@ func TestSlashCommandHandler_RegisterCommands(t *testing.T) {
 	}
 }
 
+func TestSlashCommands_GuildOnly(t *testing.T) {
+	for _, cmd := range slashCommands() {
+		if cmd.DMPermission == nil || *cmd.DMPermission {
+			t.Errorf("/%s DMPermission = %v, want false", cmd.Name, cmd.DMPermission)
+		}
+		if cmd.Contexts == nil || !slices.Equal(*cmd.Contexts, []discordgo.InteractionContextType{discordgo.InteractionContextGuild}) {
+			t.Errorf("/%s Contexts = %v, want guild only", cmd.Name, cmd.Contexts)
+		}
+	}
+}
+
+// recordingTransport answers every Discord API request with 204 No Content, recording its path.
+type recordingTransport struct {
+	paths []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.paths = append(rt.paths, req.URL.Path)
+	return &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestSlashCommandHandler_HandleInteraction_DM(t *testing.T) {
+	session, err := discordgo.New("Bot test-token")
+	if err != nil {
+		t.Fatalf("discordgo.New() error = %v", err)
+	}
+	transport := &recordingTransport{}
+	session.Client = &http.Client{Transport: transport}
+	handler := NewSlashCommandHandler(session, nil)
+
+	// Run in a DM, an interaction has a User but no Member
+	handler.handleInteraction(session, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:    "interaction-1",
+		Type:  discordgo.InteractionApplicationCommand,
+		User:  &discordgo.User{ID: "user-1"},
+		Token: "token",
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name:    "goose",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{{Name: "dash"}},
+		},
+	}})
+
+	if len(transport.paths) != 1 || !strings.Contains(transport.paths[0], "/interactions/interaction-1/token/callback") {
+		t.Errorf("requests = %v, want one error response to the interaction", transport.paths)
+	}
+}
+
+func TestCommandTarget(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  string
+	}{
+		{scope: CommandScopeGuild, want: "guild-123"},
+		{scope: CommandScopeGlobal, want: ""},
+		{scope: "", want: "guild-123"},
+	}
+	for _, tt := range tests {
+		if got := CommandTarget(tt.scope, "guild-123"); got != tt.want {
+			t.Errorf("CommandTarget(%q) = %q, want %q", tt.scope, got, tt.want)
+		}
+	}
+}
+
 func TestSlashCommandHandler_RemoveCommands(t *testing.T) {
 	// We can't easily test RemoveCommands without a real Discord connection
 	// This test just verifies the method exists and the handler can be created
@@ -803,3 +1071,335 @@ func TestSlashCommandHandler_respondError(t *testing.T) {
 		t.Error("NewSlashCommandHandler() should not return nil")
 	}
 }
+
+func TestIsGuildAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		member *discordgo.Member
+		want   bool
+	}{
+		{"administrator", &discordgo.Member{Permissions: discordgo.PermissionAdministrator}, true},
+		{"manage server", &discordgo.Member{Permissions: discordgo.PermissionManageGuild | discordgo.PermissionSendMessages}, true},
+		{"regular member", &discordgo.Member{Permissions: discordgo.PermissionSendMessages}, false},
+		{"no member", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGuildAdmin(tt.member); got != tt.want {
+				t.Errorf("isGuildAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type recordingInvalidator struct {
+	calls []string
+}
+
+func (r *recordingInvalidator) InvalidateUserMapping(guildID, gitHubUsername, discordUserID string) {
+	r.calls = append(r.calls, guildID+"/"+gitHubUsername+"/"+discordUserID)
+}
+
+func TestSlashCommandHandler_MapUser(t *testing.T) {
+	ctx := context.Background()
+	session := NewMockSession()
+	session.Members["guild1"] = []*discordgo.Member{NewMockMember("111", "alice", "Alice")}
+
+	store := state.NewMemoryStore()
+	invalidator := &recordingInvalidator{}
+	h := NewSlashCommandHandler(nil, nil)
+	h.SetStore(store)
+	h.SetMappingInvalidator(invalidator)
+
+	if err := h.mapUser(ctx, session, "guild1", "alice-gh", "111"); err != nil {
+		t.Fatalf("mapUser() error = %v", err)
+	}
+	mapping, ok := store.UserMapping(ctx, "guild1", "alice-gh")
+	if !ok || mapping.DiscordUserID != "111" {
+		t.Errorf("UserMapping(alice-gh) = (%+v, %v), want Discord user 111", mapping, ok)
+	}
+	if len(invalidator.calls) != 1 || invalidator.calls[0] != "guild1/alice-gh/111" {
+		t.Errorf("invalidations = %v, want [guild1/alice-gh/111]", invalidator.calls)
+	}
+
+	// Someone who isn't in the server can't be mapped
+	if err := h.mapUser(ctx, session, "guild1", "mallory-gh", "999"); err == nil {
+		t.Error("mapUser() for non-member: error = nil, want error")
+	}
+	if _, ok := store.UserMapping(ctx, "guild1", "mallory-gh"); ok {
+		t.Error("non-member mapping was saved")
+	}
+
+	if err := h.mapUser(ctx, session, "guild1", "-bad-", "111"); err == nil {
+		t.Error("mapUser() with invalid GitHub username: error = nil, want error")
+	}
+	if len(invalidator.calls) != 1 {
+		t.Errorf("invalidations = %d after rejected maps, want 1", len(invalidator.calls))
+	}
+}
+
+func TestFormatDedupClearedEmbed(t *testing.T) {
+	embed := formatDedupClearedEmbed("", 3)
+	if !strings.Contains(embed.Description, "Cleared 3 entries for all monitored orgs") {
+		t.Errorf("Description = %q", embed.Description)
+	}
+	embed = formatDedupClearedEmbed("https://github.com/o/r/pull/1", 1)
+	if !strings.Contains(embed.Description, "Cleared 1 entry for https://github.com/o/r/pull/1") {
+		t.Errorf("Description = %q", embed.Description)
+	}
+	// The store can only clear what this instance recorded
+	if !strings.Contains(embed.Description, "Only entries this instance recorded") {
+		t.Errorf("Description = %q, want the per-instance limit noted", embed.Description)
+	}
+}
+
+func TestFormatDoneEmbed(t *testing.T) {
+	embed := formatDoneEmbed("https://github.com/o/r/pull/1")
+	if !strings.Contains(embed.Description, "about https://github.com/o/r/pull/1 until its state changes") {
+		t.Errorf("Description = %q", embed.Description)
+	}
+}
+
+func TestInspectPR(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	prURL := "https://github.com/o/r/pull/1"
+
+	for _, channelID := range []string{"chan-1", "chan-2"} {
+		if err := store.SaveThread(ctx, "o", "r", 1, channelID, state.ThreadInfo{
+			MessageID:   "msg-" + channelID,
+			ChannelType: "text",
+			LastState:   "needs_review",
+		}); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+	if err := store.SaveDMInfo(ctx, "user-1", prURL, state.DMInfo{
+		MessageID: "dm-1",
+		LastState: "needs_review",
+		SentAt:    time.Now(),
+		Muted:     true,
+	}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+
+	inspection, err := InspectPR(ctx, store, " "+prURL+"/files ")
+	if err != nil {
+		t.Fatalf("InspectPR() error = %v", err)
+	}
+	if inspection.PRURL != prURL || len(inspection.Threads) != 2 || len(inspection.DMs) != 1 {
+		t.Fatalf("InspectPR() = %+v, want 2 threads and 1 DM for %s", inspection, prURL)
+	}
+
+	embed := formatInspectEmbed(inspection)
+	if len(embed.Fields) != 3 {
+		t.Fatalf("embed has %d fields, want 3", len(embed.Fields))
+	}
+	for i, want := range []string{"<#chan-1>\ntext message `msg-chan-1`", "<#chan-2>", "<@user-1>\nMessage `dm-1`"} {
+		if !strings.Contains(embed.Fields[i].Value, want) {
+			t.Errorf("field %d = %q, want it to contain %q", i, embed.Fields[i].Value, want)
+		}
+	}
+	if !strings.Contains(embed.Fields[2].Value, "Muted") {
+		t.Errorf("DM field = %q, want it marked muted", embed.Fields[2].Value)
+	}
+
+	if _, err := InspectPR(ctx, store, "not a PR"); err == nil {
+		t.Error("InspectPR() error = nil for an invalid URL")
+	}
+	empty, err := InspectPR(ctx, store, "https://github.com/o/r/pull/2")
+	if err != nil {
+		t.Fatalf("InspectPR() error = %v", err)
+	}
+	if embed := formatInspectEmbed(empty); !strings.Contains(embed.Description, "Nothing is stored") {
+		t.Errorf("Description = %q for an untracked PR", embed.Description)
+	}
+}
+
+func TestFormatMaintenanceEmbed(t *testing.T) {
+	if embed := formatMaintenanceEmbed(true); !strings.Contains(embed.Description, "paused") {
+		t.Errorf("on Description = %q, want posts paused", embed.Description)
+	}
+	if embed := formatMaintenanceEmbed(false); !strings.Contains(embed.Description, "resumed") {
+		t.Errorf("off Description = %q, want posts resumed", embed.Description)
+	}
+}
+
+func TestFormatReloadEmbed(t *testing.T) {
+	embed := formatReloadEmbed([]ConfigReload{
+		{Org: "acme", Added: []string{"mobile"}, Removed: []string{"frontend"}, Unresolved: []string{"mobile"}},
+		{Org: "other"},
+	})
+	if embed.Author.Name != "Config Reloaded" || len(embed.Fields) != 2 {
+		t.Fatalf("embed = %q with %d fields, want Config Reloaded with 2", embed.Author.Name, len(embed.Fields))
+	}
+	for _, want := range []string{"Added: #mobile", "Removed: #frontend", "Not found in this server: #mobile"} {
+		if !strings.Contains(embed.Fields[0].Value, want) {
+			t.Errorf("acme field = %q, want %q", embed.Fields[0].Value, want)
+		}
+	}
+	if embed.Fields[1].Value != "No channel changes" {
+		t.Errorf("other field = %q, want no changes", embed.Fields[1].Value)
+	}
+
+	embed = formatReloadEmbed([]ConfigReload{
+		{Org: "acme", Err: errors.New("failed to parse YAML: bad indentation")},
+	})
+	if embed.Author.Name != "Config Reload Failed" {
+		t.Errorf("Author = %q, want Config Reload Failed", embed.Author.Name)
+	}
+	if !strings.Contains(embed.Fields[0].Value, "failed to parse YAML") {
+		t.Errorf("field = %q, want the validation error", embed.Fields[0].Value)
+	}
+}
+
+func TestParseOOOUntil(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-01-31", want: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{value: " 2025-01-10 ", want: time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{value: "OFF"},
+		{value: "2025-01-09", wantErr: true},
+		{value: "2025-12-31", wantErr: true},
+		{value: "next week", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseOOOUntil(tt.value, now, time.UTC)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseOOOUntil(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseOOOUntil(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseOOOUntil_Timezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	got, err := parseOOOUntil("2025-01-31", now, tokyo)
+	if err != nil {
+		t.Fatalf("parseOOOUntil() error = %v", err)
+	}
+	if want := time.Date(2025, 2, 1, 0, 0, 0, 0, tokyo); !got.Equal(want) {
+		t.Errorf("parseOOOUntil() = %v, want the end of the day in Tokyo, %v", got, want)
+	}
+	if embed := formatOOOEmbed(got); !strings.Contains(embed.Description, "Through 2025-01-31") {
+		t.Errorf("Description = %q, want the last day out in Tokyo", embed.Description)
+	}
+}
+
+func TestFormatOOOEmbed(t *testing.T) {
+	embed := formatOOOEmbed(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if !strings.Contains(embed.Description, "Through 2025-01-31") {
+		t.Errorf("Description = %q, want the last day out", embed.Description)
+	}
+	if embed := formatOOOEmbed(time.Time{}); embed.Author.Name != "Welcome Back" {
+		t.Errorf("off Author = %q, want Welcome Back", embed.Author.Name)
+	}
+}
+
+func TestParseRedirectDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "4h", want: 4 * time.Hour},
+		{value: " 90m ", want: 90 * time.Minute},
+		{value: "2d", want: 48 * time.Hour},
+		{value: "Off"},
+		{value: "0h", wantErr: true},
+		{value: "31d", wantErr: true},
+		{value: "a while", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseRedirectDuration(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRedirectDuration(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRedirectDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestErrorReply(t *testing.T) {
+	const fallback = "Failed. Please try again later."
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "written for the user", err: userError("That day has already passed."), want: "That day has already passed."},
+		{name: "wrapped", err: fmt.Errorf("parse: %w", userError("Bad date.")), want: "Bad date."},
+		{name: "internal", err: errors.New("save mapping: connection refused"), want: fallback},
+	}
+	for _, tt := range tests {
+		if got := errorReply(tt.err, fallback); got != tt.want {
+			t.Errorf("%s: errorReply() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRedirectEmbed(t *testing.T) {
+	embed := formatRedirectEmbed("api", "incident", time.Unix(1700000000, 0))
+	for _, want := range []string{"PRs in api go to #incident", "<t:1700000000:f>"} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("Description = %q, want %q", embed.Description, want)
+		}
+	}
+	if embed := formatRedirectEmbed("api", "incident", time.Time{}); embed.Author.Name != "Redirect Ended" {
+		t.Errorf("off Author = %q, want Redirect Ended", embed.Author.Name)
+	}
+}
+
+func TestSlashCommandHandler_ActivePRsForUser(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	h := NewSlashCommandHandler(nil, nil)
+	h.SetStore(store)
+
+	now := time.Now()
+	dms := map[string]state.DMInfo{
+		"https://github.com/owner/repo/pull/1": {SentAt: now.Add(-time.Hour), LastState: string(format.StateNeedsReview)},
+		"https://github.com/owner/repo/pull/2": {SentAt: now, LastState: string(format.StateTestsBroken)},
+		"https://github.com/owner/repo/pull/3": {SentAt: now, LastState: string(format.StateMerged)},
+	}
+	for prURL, info := range dms {
+		if err := store.SaveDMInfo(ctx, "111", prURL, info); err != nil {
+			t.Fatalf("SaveDMInfo(%s) error = %v", prURL, err)
+		}
+	}
+
+	active := h.activePRsForUser(ctx, "111")
+	if len(active) != 2 {
+		t.Fatalf("activePRsForUser() returned %d PRs, want 2 (merged PR excluded)", len(active))
+	}
+	if active[0].PRURL != "https://github.com/owner/repo/pull/2" {
+		t.Errorf("activePRsForUser()[0] = %s, want most recently notified PR first", active[0].PRURL)
+	}
+
+	embed := formatMinePRsEmbed(active)
+	if len(embed.Fields) != 1 {
+		t.Fatalf("embed fields = %d, want 1", len(embed.Fields))
+	}
+	for _, want := range []string{"owner/repo#1", "owner/repo#2", "tests failing"} {
+		if !strings.Contains(embed.Fields[0].Value, want) {
+			t.Errorf("embed = %q, want it to contain %q", embed.Fields[0].Value, want)
+		}
+	}
+
+	if embed := formatMinePRsEmbed(nil); embed.Description == "" {
+		t.Error("empty PR list should describe that the user is caught up")
+	}
+}
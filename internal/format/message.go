@@ -2,8 +2,17 @@
 package format
 
 import (
+	"cmp"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // PR state emoji mappings.
@@ -17,11 +26,14 @@ const (
 	EmojiChanges        = "\U0001FA9A"   // 🪚 Changes requested (saw)
 	EmojiApproved       = "\u2705"       // ✅ Approved
 	EmojiMerged         = "\U0001F680"   // 🚀 Merged
-	EmojiClosed         = "\u274C"       // ❌ Closed
+	EmojiClosed         = "\u274C"       // ❌ Closed without merging
 	EmojiConflict       = "\u26A0\uFE0F" // ⚠️ Merge conflict
 	EmojiUnknown        = "\U0001F4EF"   // 📯 Unknown state (postal horn)
 )
 
+// DefaultDashboardURL is the reviewGOOSE dashboard, linked unless an org has its own.
+const DefaultDashboardURL = "https://reviewgoose.dev"
+
 // PRState represents the simplified state of a PR for formatting.
 type PRState string
 
@@ -87,6 +99,8 @@ func StateText(state PRState) string {
 		return "changes requested"
 	case StateConflict:
 		return "merge conflict"
+	case StateClosed:
+		return "closed without merging"
 	default:
 		return ""
 	}
@@ -94,70 +108,367 @@ func StateText(state PRState) string {
 
 // ChannelMessageParams contains parameters for formatting a channel message.
 type ChannelMessageParams struct {
-	Owner       string
-	Repo        string
-	Title       string
-	Author      string
-	State       PRState
-	PRURL       string
-	ChannelName string
-	ActionUsers []ActionUser
-	Number      int
+	Owner            string
+	Repo             string
+	Title            string
+	Author           string
+	State            PRState
+	PRURL            string
+	ChannelName      string
+	Prefix           string // Optional per-channel text such as "[infra]" put before everything else
+	RepoEmoji        string // Optional per-repo emoji put after Prefix, to group repos visually
+	BaseBranch       string // Shown as "→ branch" after the PR link; leave empty for the default branch
+	ActionUsers      []ActionUser
+	Assignees        []string    // Mentions of assigned users, shown on their own line
+	ApprovedBy       []string    // GitHub users who approved, shown with AwaitingApproval on their own line
+	AwaitingApproval []string    // GitHub users whose approval the PR still waits on
+	Failing          []CheckLink // Failing CI checks, linked on their own line for StateTestsBroken
+	CI               string      // Optional CI status from CIEmoji, shown after the PR link
+	Closes           []int       // Issues the PR closes, linked on their own line
+	Number           int
+	AcknowledgedBy   string   // GitHub user who acknowledged the PR from Discord; shown after the actions
+	ShowOrg          bool     // Link the PR as org/repo#123, for channels shared by several orgs
+	PlainURL         bool     // Add the raw PR URL on its own last line, for copying
+	Analyzing        bool     // The PR is still being analyzed; shown instead of the state text while no one has an action
+	DashboardURL     string   // Link to the PR or its org on the dashboard, added as a subtle footer if the message has room
+	Unavailable      string   // Set when the PR's details couldn't be fetched; shown in place of its state and actions
+	BodySnippet      string   // Start of the PR's description from BodySnippet, quoted under the PR's line
+	ReviewChecklist  []string // Items reviewers should check, listed after the body snippet
+	ReviewRound      int      // Review rounds the PR has been through; "round N" is shown from the second
+	Narrow           bool     // Shorter title, with actions or state on their own line, for mobile-heavy channels
+}
+
+// Checks counts a PR's CI checks by status.
+type Checks struct {
+	Passing int
+	Pending int
+	Failing int
+	Waiting int // Waiting on a deployment gate or approval
+}
+
+// CIEmoji returns a traffic light for a PR's CI: 🔴 if any check fails, 🟡 if any is still
+// pending or waiting, 🟢 if all passed, or "" if there are no checks.
+func CIEmoji(checks Checks) string {
+	switch {
+	case checks.Failing > 0:
+		return "🔴"
+	case checks.Pending > 0 || checks.Waiting > 0:
+		return "🟡"
+	case checks.Passing > 0:
+		return "🟢"
+	default:
+		return ""
+	}
 }
 
+// CheckLink is a CI check name and a link to its run.
+type CheckLink struct {
+	Name string
+	URL  string
+}
+
+// maxFailingChecks bounds how many failing checks are linked before the rest are counted.
+const maxFailingChecks = 3
+
 // ActionUser represents a user who needs to take action.
 type ActionUser struct {
 	Username string
-	Mention  string // Discord mention format or plain username
-	Action   string // e.g., "review", "approve", "fix tests"
+	Mention  string        // Discord mention format or plain username
+	Action   string        // e.g., "review", "approve", "fix tests"
+	Waiting  time.Duration // How long the user has been asked to act; 0 hides the age
 }
 
-// ChannelMessage formats a PR notification for a text channel.
+const (
+	// maxTitle bounds titles in channel messages.
+	maxTitle = 60
+	// maxNarrowTitle bounds titles in narrow layout channels, whose lines wrap on phones.
+	maxNarrowTitle = 40
+)
+
+// titleLimit returns how long the PR's title may be in its channel message.
+func (p ChannelMessageParams) titleLimit() int {
+	if p.Narrow {
+		return maxNarrowTitle
+	}
+	return maxTitle
+}
+
+// ChannelMessage formats a PR notification for a text channel. In a narrow layout, who
+// needs to act, or the PR's state, goes on its own line.
 func ChannelMessage(p ChannelMessageParams) string {
+	if p.Unavailable != "" {
+		return unavailableChannelMessage(p)
+	}
 	emoji := StateEmoji(p.State)
 
 	// Format: emoji [repo#123](url?st=state) · Title · author • action → @users
 	var sb strings.Builder
 
+	if p.Prefix != "" {
+		sb.WriteString(p.Prefix)
+		sb.WriteString(" ")
+	}
+	if p.RepoEmoji != "" {
+		sb.WriteString(p.RepoEmoji)
+		sb.WriteString(" ")
+	}
 	sb.WriteString(emoji)
 	sb.WriteString(" ")
 
-	// PR link with state param - use short form #123 if channel matches repo
-	prRef := fmt.Sprintf("%s#%d", p.Repo, p.Number)
-	if p.ChannelName != "" && strings.EqualFold(p.ChannelName, p.Repo) {
-		prRef = fmt.Sprintf("#%d", p.Number)
+	// PR link with state param
+	sb.WriteString(fmt.Sprintf("[%s](%s?st=%s)", prReference(p), p.PRURL, p.State))
+	if p.BaseBranch != "" {
+		sb.WriteString(" → ")
+		sb.WriteString(p.BaseBranch)
+	}
+	if p.CI != "" {
+		sb.WriteString(" ")
+		sb.WriteString(p.CI)
 	}
-	sb.WriteString(fmt.Sprintf("[%s](%s?st=%s)", prRef, p.PRURL, p.State))
 
 	// Title with dot delimiter
 	sb.WriteString(" · ")
-	sb.WriteString(Truncate(p.Title, 60))
+	sb.WriteString(Truncate(p.Title, p.titleLimit()))
 
 	// Author
 	sb.WriteString(" · ")
 	sb.WriteString(p.Author)
 
+	sep := " • "
+	if p.Narrow {
+		sep = "\n"
+	}
+
 	// Action users - group by action
 	actionSuffix := ActionGroups(p.ActionUsers)
 	if actionSuffix != "" {
 		// If there are action users, show them directly with bullet separator
 		// (matching Slacker behavior - no state text when actions are present)
-		sb.WriteString(" • ")
+		sb.WriteString(sep)
 		sb.WriteString(actionSuffix)
+	} else if p.Analyzing {
+		sb.WriteString(sep)
+		sb.WriteString("⏳ analyzing…")
 	} else {
 		// Only show state text if no action users are present
 		// State text provides context when there's no specific action to take
 		stateText := StateText(p.State)
 		if stateText != "" {
-			sb.WriteString(" • ")
+			sb.WriteString(sep)
 			sb.WriteString(stateText)
 		}
 	}
 
+	if p.AcknowledgedBy != "" {
+		sb.WriteString(" · acknowledged by ")
+		sb.WriteString(p.AcknowledgedBy)
+	}
+
+	if p.ReviewRound > 1 {
+		sb.WriteString(fmt.Sprintf(" · round %d", p.ReviewRound))
+	}
+
+	if p.State == StateTestsBroken && len(p.Failing) > 0 {
+		sb.WriteString("\nfailing: ")
+		sb.WriteString(FailingChecksLine(p.Failing))
+	}
+
+	if len(p.Closes) > 0 {
+		sb.WriteString("\ncloses ")
+		sb.WriteString(closesLine(p.Owner, p.Repo, p.Closes))
+	}
+
+	// Assignees get their own line so they aren't mistaken for reviewers
+	if len(p.Assignees) > 0 {
+		sb.WriteString("\nassigned to ")
+		sb.WriteString(strings.Join(p.Assignees, ", "))
+	}
+
+	if line := approvalStatus(p.ApprovedBy, p.AwaitingApproval); line != "" {
+		sb.WriteString("\n")
+		sb.WriteString(line)
+	}
+
+	if p.BodySnippet != "" {
+		sb.WriteString("\n> ")
+		sb.WriteString(p.BodySnippet)
+	}
+
+	if len(p.ReviewChecklist) > 0 {
+		sb.WriteString("\n**Review checklist**")
+		for _, item := range p.ReviewChecklist {
+			sb.WriteString("\n- ⬜ ")
+			sb.WriteString(item)
+		}
+	}
+
+	var plainURL string
+	if p.PlainURL {
+		// Angle brackets keep Discord from adding a link preview
+		plainURL = "\n<" + p.PRURL + ">"
+	}
+
+	// The footer is the first thing dropped when the message is near the length limit
+	if p.DashboardURL != "" {
+		footer := fmt.Sprintf("\n-# [📊 dashboard](<%s>)", p.DashboardURL)
+		if sb.Len()+len(footer)+len(plainURL) <= MessageLimit {
+			sb.WriteString(footer)
+		}
+	}
+
+	sb.WriteString(plainURL)
+	return sb.String()
+}
+
+// unavailableChannelMessage renders a PR whose details couldn't be fetched: its link, whatever
+// of its title and author is known, and the Unavailable note pointing readers to GitHub.
+func unavailableChannelMessage(p ChannelMessageParams) string {
+	var sb strings.Builder
+	if p.Prefix != "" {
+		sb.WriteString(p.Prefix)
+		sb.WriteString(" ")
+	}
+	if p.RepoEmoji != "" {
+		sb.WriteString(p.RepoEmoji)
+		sb.WriteString(" ")
+	}
+	sb.WriteString(EmojiUnknown)
+	sb.WriteString(fmt.Sprintf(" [%s](%s)", prReference(p), p.PRURL))
+	if p.Title != "" {
+		sb.WriteString(" · ")
+		sb.WriteString(Truncate(p.Title, p.titleLimit()))
+	}
+	if p.Author != "" {
+		sb.WriteString(" · ")
+		sb.WriteString(p.Author)
+	}
+	sb.WriteString(" • ⚠️ ")
+	sb.WriteString(p.Unavailable)
+	if p.PlainURL {
+		sb.WriteString("\n<" + p.PRURL + ">")
+	}
 	return sb.String()
 }
 
-// ActionGroups groups users by action and formats them.
+// bodySnippetLength is how many characters of a PR's description BodySnippet keeps.
+const bodySnippetLength = 200
+
+var (
+	htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// The snippet is one line, so only inline markdown, links and mentions need escaping
+	markdownEscaper   = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, "[", `\[`, "]", `\]`, "<", `\<`)
+	mentionNeutralize = strings.NewReplacer("@everyone", "@\u200beveryone", "@here", "@\u200bhere")
+)
+
+// BodySnippet returns the start of a PR's description for quoting under it: template comments
+// are removed, whitespace collapsed to one line, Discord markdown escaped so it renders as
+// written, and the text cut to about 200 characters. A leading #, - or > is escaped too, as
+// it would start a heading, list or quote. Returns "" for an empty description.
+func BodySnippet(body string) string {
+	text := strings.Join(strings.Fields(htmlComment.ReplaceAllString(body, "")), " ")
+	if text == "" {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > bodySnippetLength {
+		text = strings.TrimSpace(string(runes[:bodySnippetLength-1])) + "…"
+	}
+	text = mentionNeutralize.Replace(markdownEscaper.Replace(text))
+	if strings.ContainsRune("#->", rune(text[0])) {
+		text = `\` + text
+	}
+	return text
+}
+
+// NeutralizeUserMention keeps text from mentioning a Discord user. With strip the mentions are
+// removed; otherwise a zero-width space breaks them, so they show as written without pinging.
+func NeutralizeUserMention(text, userID string, strip bool) string {
+	if userID == "" {
+		return text
+	}
+	mentions := []string{"<@" + userID + ">", "<@!" + userID + ">"}
+	var pairs []string
+	for _, mention := range mentions {
+		replacement := ""
+		if !strip {
+			replacement = "<@\u200b" + mention[2:]
+		}
+		pairs = append(pairs, mention, replacement)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// DashboardOrgURL returns the link to an org on the dashboard at base.
+func DashboardOrgURL(base, owner string) string {
+	return fmt.Sprintf("%s/orgs/%s", base, url.PathEscape(owner))
+}
+
+// DashboardTemplateURL fills in a dashboard URL template's {org}, {repo} and {number}
+// placeholders for a PR's link.
+func DashboardTemplateURL(tmpl, owner, repo string, number int) string {
+	n := ""
+	if number > 0 {
+		n = strconv.Itoa(number)
+	}
+	return strings.NewReplacer(
+		"{org}", url.PathEscape(owner),
+		"{repo}", url.PathEscape(repo),
+		"{number}", n,
+	).Replace(tmpl)
+}
+
+// DashboardTemplateOrgURL fills in a dashboard URL template's {org} placeholder for an org's
+// link. It returns "" for PR-shaped templates using {repo} or {number}, which have no org page.
+func DashboardTemplateOrgURL(tmpl, owner string) string {
+	if strings.Contains(tmpl, "{repo}") || strings.Contains(tmpl, "{number}") {
+		return ""
+	}
+	return strings.ReplaceAll(tmpl, "{org}", url.PathEscape(owner))
+}
+
+// prReference returns the PR's link text: org/repo#123 with ShowOrg,
+// the short form #123 if the channel matches the repo, and repo#123 otherwise.
+func prReference(p ChannelMessageParams) string {
+	switch {
+	case p.ShowOrg:
+		return fmt.Sprintf("%s/%s#%d", p.Owner, p.Repo, p.Number)
+	case p.ChannelName != "" && strings.EqualFold(p.ChannelName, p.Repo):
+		return fmt.Sprintf("#%d", p.Number)
+	default:
+		return fmt.Sprintf("%s#%d", p.Repo, p.Number)
+	}
+}
+
+// closesLine links the issues a PR closes, e.g. "[#45](url), [#46](url)".
+func closesLine(owner, repo string, issues []int) string {
+	parts := make([]string, len(issues))
+	for i, number := range issues {
+		parts[i] = fmt.Sprintf("[#%d](https://github.com/%s/%s/issues/%d)", number, owner, repo, number)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FailingChecksLine links the first few failing checks, counting the rest.
+// Returns format like: "[lint](url), [test (ubuntu)](url) +2 more".
+func FailingChecksLine(checks []CheckLink) string {
+	var parts []string
+	for _, check := range checks[:min(len(checks), maxFailingChecks)] {
+		// Brackets would break the masked link
+		name := Truncate(strings.NewReplacer("[", "(", "]", ")").Replace(check.Name), 40)
+		if check.URL == "" {
+			parts = append(parts, name)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("[%s](%s)", name, check.URL))
+	}
+	line := strings.Join(parts, ", ")
+	if extra := len(checks) - maxFailingChecks; extra > 0 {
+		line += fmt.Sprintf(" +%d more", extra)
+	}
+	return line
+}
+
+// ActionGroups groups users by action and formats them, so an action shared by several
+// users is named once. Actions appear in the order they're first seen, users in input order.
 // Returns format like: "**review** → @alice, @bob; **approve** → @charlie".
 func ActionGroups(users []ActionUser) string {
 	if len(users) == 0 {
@@ -165,25 +476,143 @@ func ActionGroups(users []ActionUser) string {
 	}
 
 	// Group users by action
-	actionGroups := make(map[string][]string)
+	var actions []string
+	actionGroups := make(map[string][]ActionUser)
 	for _, au := range users {
-		actionGroups[au.Action] = append(actionGroups[au.Action], au.Mention)
+		if au.Action == "" {
+			continue
+		}
+		if _, ok := actionGroups[au.Action]; !ok {
+			actions = append(actions, au.Action)
+		}
+		actionGroups[au.Action] = append(actionGroups[au.Action], au)
 	}
 
 	// Format each group
-	var parts []string
-	for action, mentions := range actionGroups {
-		if action == "" {
-			continue
-		}
-		userList := strings.Join(mentions, ", ")
-		parts = append(parts, fmt.Sprintf("**%s** → %s", action, userList))
+	parts := make([]string, 0, len(actions))
+	for _, action := range actions {
+		parts = append(parts, fmt.Sprintf("**%s** → %s", action, ReviewerList(actionGroups[action])))
 	}
 
 	// Join with semicolons (commas used between users)
 	return strings.Join(parts, "; ")
 }
 
+// ReviewerList renders users as Discord mentions, mapped users first, with users
+// that have no Discord mapping summarized at the end so it's clear who won't be notified.
+// Returns format like: "<@1>, <@2> (unmapped: alice, bob)".
+func ReviewerList(users []ActionUser) string {
+	var mapped, unmapped []string
+	for _, au := range users {
+		if strings.HasPrefix(au.Mention, "<@") {
+			mapped = append(mapped, au.Mention+reviewerAge(au.Waiting))
+			continue
+		}
+		name := au.Username
+		if name == "" {
+			name = au.Mention
+		}
+		unmapped = append(unmapped, name+reviewerAge(au.Waiting))
+	}
+
+	list := strings.Join(mapped, ", ")
+	if len(unmapped) == 0 {
+		return list
+	}
+	summary := "(unmapped: " + strings.Join(unmapped, ", ") + ")"
+	if list == "" {
+		return summary
+	}
+	return list + " " + summary
+}
+
+// reviewerAge renders how long a user has been waited on in whole days, as " (2d)" or " (<1d)",
+// or "" if unknown. Days are coarse on purpose: re-renders only edit the message when the day changes.
+func reviewerAge(waiting time.Duration) string {
+	switch {
+	case waiting <= 0:
+		return ""
+	case waiting < 24*time.Hour:
+		return " (<1d)"
+	default:
+		return fmt.Sprintf(" (%dd)", int(waiting.Hours()/24))
+	}
+}
+
+// Title styles for NormalizeTitle.
+const (
+	TitleStyleNone     = "none"
+	TitleStyleSentence = "sentence" // "Fix login redirect"
+	TitleStyleTitle    = "title"    // "Fix Login Redirect"
+)
+
+// NormalizeTitle restyles an all-uppercase PR title as sentence or title case.
+// Titles with any lowercase letter are left alone, as are words that look like code
+// (containing digits, "_", ".", "/" or backticks), so identifiers and mixed-case
+// acronyms survive. Bracketed tags such as "(API)" or "[UI]" keep their capitals, and
+// single-word titles such as "WIP" are also kept.
+func NormalizeTitle(title, style string) string {
+	if style != TitleStyleSentence && style != TitleStyleTitle || !isShouting(title) {
+		return title
+	}
+
+	words := strings.Split(title, " ")
+	first := true
+	inCode := false
+	for i, word := range words {
+		if !strings.ContainsFunc(word, unicode.IsLetter) {
+			continue
+		}
+		ticks := strings.Count(word, "`")
+		if inCode || ticks > 0 || strings.ContainsFunc(word, isCodeRune) {
+			inCode = inCode != (ticks%2 == 1)
+			first = false
+			continue
+		}
+		if isTag(word) {
+			continue
+		}
+		word = strings.ToLower(word)
+		if first || style == TitleStyleTitle {
+			word = upperFirstLetter(word)
+		}
+		words[i] = word
+		first = false
+	}
+	return strings.Join(words, " ")
+}
+
+// isShouting reports whether a title has several words and no lowercase letters.
+func isShouting(title string) bool {
+	if strings.ContainsFunc(title, unicode.IsLower) {
+		return false
+	}
+	words := 0
+	for word := range strings.FieldsSeq(title) {
+		if strings.ContainsFunc(word, unicode.IsLetter) {
+			words++
+		}
+	}
+	return words > 1
+}
+
+// isTag reports whether a word is a bracketed label such as "(API)" or "[UI]:",
+// which is spelled in capitals on purpose.
+func isTag(word string) bool {
+	word = strings.TrimRight(word, ":,")
+	return len(word) > 2 && (word[0] == '(' && word[len(word)-1] == ')' || word[0] == '[' && word[len(word)-1] == ']')
+}
+
+func isCodeRune(r rune) bool {
+	return unicode.IsDigit(r) || strings.ContainsRune("_./`", r)
+}
+
+func upperFirstLetter(word string) string {
+	i := strings.IndexFunc(word, unicode.IsLetter)
+	r, size := utf8.DecodeRuneInString(word[i:])
+	return word[:i] + string(unicode.ToUpper(r)) + word[i+size:]
+}
+
 // ForumThreadTitle formats the title for a forum thread.
 func ForumThreadTitle(repo string, number int, title string) string {
 	// [repo#123] Title (truncated to fit Discord's 100 char limit)
@@ -192,6 +621,336 @@ func ForumThreadTitle(repo string, number int, title string) string {
 	return prefix + Truncate(title, maxTitleLen)
 }
 
+// PrefixedForumThreadTitle formats a forum thread title with a custom prefix in front,
+// e.g. "[infra] [repo#123] Title". An empty prefix matches ForumThreadTitle.
+func PrefixedForumThreadTitle(prefix, repo string, number int, title string) string {
+	if prefix == "" {
+		return ForumThreadTitle(repo, number, title)
+	}
+	return Truncate(fmt.Sprintf("%s [%s#%d] %s", prefix, repo, number, title), 100)
+}
+
+// MessageLimit is Discord's maximum message length.
+const MessageLimit = 2000
+
+// SplitMessage splits text into chunks of at most limit bytes so a long notification can
+// span several messages. It breaks at line ends, then spaces, and only mid-word when a
+// word alone is too long. Text within the limit comes back as one chunk.
+func SplitMessage(text string, limit int) []string {
+	if limit <= 0 || len(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		head := text[:cut]
+		if i := strings.LastIndexByte(head, '\n'); i > 0 {
+			chunks = append(chunks, head[:i])
+			text = text[i+1:]
+			continue
+		}
+		if i := strings.LastIndexByte(head, ' '); i > 0 {
+			chunks = append(chunks, head[:i])
+			text = text[i+1:]
+			continue
+		}
+		chunks = append(chunks, head)
+		text = text[cut:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
+// DigestMessage formats a channel digest listing one line per changed PR.
+// Lines that would push the message past Discord's length limit are summarized as a count.
+func DigestMessage(prefix string, lines []string) string {
+	var sb strings.Builder
+	if prefix != "" {
+		sb.WriteString(prefix)
+		sb.WriteString(" ")
+	}
+	noun := "PRs"
+	if len(lines) == 1 {
+		noun = "PR"
+	}
+	sb.WriteString(fmt.Sprintf("**PR digest** · %d %s changed", len(lines), noun))
+
+	for i, line := range lines {
+		more := fmt.Sprintf("\n…and %d more", len(lines)-i)
+		room := MessageLimit - sb.Len() - len("\n") - len(line)
+		if i < len(lines)-1 {
+			room -= len(more) // Leave space to summarize the lines after this one
+		}
+		if room < 0 {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString("\n")
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+// statePriority ranks states for compact lists: PRs blocked on a fix first, then PRs
+// waiting on people, then PRs that need nothing, then finished ones.
+var statePriority = map[PRState]int{
+	StateConflict:       0,
+	StateTestsBroken:    1,
+	StateChanges:        2,
+	StateNeedsReview:    3,
+	StateAwaitingAssign: 4,
+	StateNewlyPublished: 5,
+	StateTestsRunning:   6,
+	StateDraft:          7,
+	StateApproved:       8,
+	StateMerged:         9,
+	StateClosed:         10,
+}
+
+// StatePriority returns a state's rank in compact lists; lower ranks sort first.
+// Unknown states sort last.
+func StatePriority(state PRState) int {
+	if p, ok := statePriority[state]; ok {
+		return p
+	}
+	return len(statePriority)
+}
+
+// actionPriority ranks action kinds for DM digests: fixes that block a PR first,
+// then feedback and reviews, then merging.
+var actionPriority = map[string]int{
+	"fix_conflict":     0,
+	"resolve_conflict": 0,
+	"fix_tests":        1,
+	"address_comments": 2,
+	"review":           3,
+	"re_review":        3,
+	"approve":          3,
+	"merge":            4,
+}
+
+// ActionPriority returns an action kind's rank in DM digests; lower ranks sort first.
+// Unknown kinds sort last.
+func ActionPriority(kind string) int {
+	if p, ok := actionPriority[kind]; ok {
+		return p
+	}
+	return 5
+}
+
+// SortByStatePriority sorts PRs by StatePriority, then by repo and number,
+// so the PRs most in need of attention lead a compact list.
+func SortByStatePriority(prs []ChannelMessageParams) {
+	slices.SortStableFunc(prs, func(a, b ChannelMessageParams) int {
+		return cmp.Or(
+			cmp.Compare(StatePriority(a.State), StatePriority(b.State)),
+			cmp.Compare(a.Repo, b.Repo),
+			cmp.Compare(a.Number, b.Number),
+		)
+	})
+}
+
+// maxCompactTitle bounds titles in compact lines, which trade detail for density.
+const maxCompactTitle = 40
+
+// MessageLink returns the URL of a Discord message.
+func MessageLink(guildID, channelID, messageID string) string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, channelID, messageID)
+}
+
+// WithPreviousLink ends an append_mode message with a subtle link to the PR's previous message.
+func WithPreviousLink(content, previousURL string) string {
+	return content + fmt.Sprintf("\n-# [⬆️ previous update](<%s>)", previousURL)
+}
+
+// approvalStatus describes a PR's approvals, e.g. "approved by alice; waiting on bob".
+// Users are named, not mentioned, as the PR's actions already ping those it waits on.
+// Returns "" when there's neither.
+func approvalStatus(approvedBy, awaiting []string) string {
+	var parts []string
+	if len(approvedBy) > 0 {
+		parts = append(parts, "approved by "+strings.Join(approvedBy, ", "))
+	}
+	if len(awaiting) > 0 {
+		parts = append(parts, "waiting on "+strings.Join(awaiting, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// CompactLine formats a PR as one dense line: its state emoji, a masked link, and a truncated title.
+// Returns format like: "🪳 [repo#12](url?st=tests_broken) Fix the flaky login test".
+func CompactLine(p ChannelMessageParams) string {
+	return fmt.Sprintf("%s [%s](%s?st=%s) %s", StateEmoji(p.State), prReference(p), p.PRURL, p.State, Truncate(p.Title, maxCompactTitle))
+}
+
+// DefaultBoardMaxPRs is how many PRs a board shows by default; that many compact
+// lines fit well within Discord's 2000 character message limit.
+const DefaultBoardMaxPRs = 12
+
+// BoardMessage formats a board of PRs as compact lines, most urgent first. At most maxPRs
+// are shown (DefaultBoardMaxPRs if maxPRs isn't positive); the rest are summarized as
+// "…and N more", linking moreURL, such as a dashboard, if set. Lines that would push the
+// message past Discord's length limit are summarized too.
+func BoardMessage(prs []ChannelMessageParams, maxPRs int, moreURL string) string {
+	if maxPRs <= 0 {
+		maxPRs = DefaultBoardMaxPRs
+	}
+	sorted := slices.Clone(prs)
+	SortByStatePriority(sorted)
+
+	noun := "PRs"
+	if len(sorted) == 1 {
+		noun = "PR"
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**PR board** · %d %s", len(sorted), noun))
+
+	moreNote := func(n int) string {
+		note := fmt.Sprintf("\n…and %d more", n)
+		if moreURL != "" {
+			note += fmt.Sprintf(" · [dashboard](%s)", moreURL)
+		}
+		return note
+	}
+	for i, p := range sorted {
+		if i == maxPRs {
+			sb.WriteString(moreNote(len(sorted) - i))
+			break
+		}
+		line := CompactLine(p)
+		room := MessageLimit - sb.Len() - len("\n") - len(line)
+		if i < len(sorted)-1 {
+			room -= len(moreNote(len(sorted) - i)) // Leave space to summarize the PRs after this one
+		}
+		if room < 0 {
+			sb.WriteString(moreNote(len(sorted) - i))
+			break
+		}
+		sb.WriteString("\n")
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+// EscalationMessage formats a ping for a PR that has been waiting too long for review.
+func EscalationMessage(owner, repo string, number int, prURL, mentions string, waiting time.Duration) string {
+	var sb strings.Builder
+	sb.WriteString("⏰ ")
+	if mentions != "" {
+		sb.WriteString(mentions)
+		sb.WriteString(" ")
+	}
+	sb.WriteString(fmt.Sprintf("[%s/%s#%d](%s) has been waiting for review for %s", owner, repo, number, prURL, waitText(waiting)))
+	return sb.String()
+}
+
+// ConflictResolvedMessage formats the note announcing that a PR's merge conflict is resolved,
+// mentioning anyone who should know it's ready again.
+func ConflictResolvedMessage(owner, repo string, number int, prURL, note, mentions string) string {
+	msg := fmt.Sprintf("%s: [%s/%s#%d](%s)", note, owner, repo, number, prURL)
+	if mentions != "" {
+		msg += " is ready again " + mentions
+	}
+	return msg
+}
+
+// ArchivedRepoMessage formats the note announcing that an archived repo's PRs are no longer posted.
+func ArchivedRepoMessage(owner, repo, note string) string {
+	return fmt.Sprintf("%s: [%s/%s](<https://github.com/%s/%s>)", note, owner, repo, owner, repo)
+}
+
+// waitText renders a wait as whole minutes, hours, or days.
+func waitText(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// ActivitySummaryParams holds an org's PR activity over the past day.
+type ActivitySummaryParams struct {
+	Org            string
+	Opened         int
+	Merged         int
+	Closed         int           // Closed without merging
+	Reviewed       int           // PRs that got their first review
+	AvgFirstReview time.Duration // Average wait from first seen to first review, over Reviewed
+	DMsSent        int
+}
+
+// ActivitySummaryMessage formats an org's daily activity summary for its admin channel.
+func ActivitySummaryMessage(p ActivitySummaryParams) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📊 **%s** in the last day\n", p.Org))
+	sb.WriteString(fmt.Sprintf("PRs: %d opened · %d merged · %d closed\n", p.Opened, p.Merged, p.Closed))
+	if p.Reviewed > 0 {
+		prs := "PRs"
+		if p.Reviewed == 1 {
+			prs = "PR"
+		}
+		sb.WriteString(fmt.Sprintf("First review: %s on average across %d %s\n", waitText(p.AvgFirstReview), p.Reviewed, prs))
+	} else {
+		sb.WriteString("First review: none\n")
+	}
+	sb.WriteString(fmt.Sprintf("DMs sent: %d", p.DMsSent))
+	return sb.String()
+}
+
+// DMDigestSection is one org's notifications in a DM digest.
+type DMDigestSection struct {
+	Org   string
+	Items []string // Individual DM texts
+}
+
+// DMDigest formats several DM notifications as one message with a header per org,
+// so users active in multiple orgs see each org's PRs together. Notifications that would
+// push the message past Discord's length limit are summarized as a count.
+func DMDigest(sections []DMDigestSection) string {
+	total := 0
+	for _, s := range sections {
+		total += len(s.Items)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📬 **%d PR updates**", total))
+	shown := 0
+	for _, s := range sections {
+		org := s.Org
+		if org == "" {
+			org = "other"
+		}
+		header := "\n\n**" + org + "**"
+		for i, item := range s.Items {
+			chunk := "\n" + item
+			if i == 0 {
+				chunk = header + chunk
+			}
+			more := fmt.Sprintf("\n\n…and %d more", total-shown)
+			room := MessageLimit - sb.Len() - len(chunk)
+			if shown < total-1 {
+				room -= len(more) // Leave space to summarize the notifications after this one
+			}
+			if room < 0 {
+				sb.WriteString(more)
+				return sb.String()
+			}
+			sb.WriteString(chunk)
+			shown++
+		}
+	}
+	return sb.String()
+}
+
 // DMMessage formats a DM notification.
 func DMMessage(p ChannelMessageParams, action string) string {
 	emoji := StateEmoji(p.State)
@@ -219,6 +978,56 @@ func DMMessage(p ChannelMessageParams, action string) string {
 	return sb.String()
 }
 
+// DMEscalationMessage formats the follow-up DM for a PR still waiting on the user after
+// their DM about it went unacknowledged for waiting.
+func DMEscalationMessage(p ChannelMessageParams, action string, waiting time.Duration) string {
+	var sb strings.Builder
+	sb.WriteString("⏰ **Still waiting on you")
+	if action != "" {
+		sb.WriteString(" to ")
+		sb.WriteString(action)
+	}
+	sb.WriteString(fmt.Sprintf("** after %s: [%s/%s#%d](%s) %s by %s",
+		waitText(waiting), p.Owner, p.Repo, p.Number, p.PRURL, p.Title, p.Author))
+	return sb.String()
+}
+
+// ReviewReminderMessage formats a recurring reminder DM for a PR still waiting on the user's
+// review; n is which reminder it is, out of maxCount.
+func ReviewReminderMessage(p ChannelMessageParams, n, maxCount int) string {
+	return fmt.Sprintf("🔁 **Reminder %d/%d, still waiting on your review**: [%s/%s#%d](%s) %s by %s",
+		n, maxCount, p.Owner, p.Repo, p.Number, p.PRURL, p.Title, p.Author)
+}
+
+// DMTemplateData is the data available to custom DM templates.
+// Fields of ChannelMessageParams are promoted, e.g. {{.Title}} or {{.PRURL}}.
+type DMTemplateData struct {
+	ChannelMessageParams
+
+	Emoji  string
+	Action string
+}
+
+// DMMessageFromTemplate renders a DM using a custom text/template.
+// Returns an error if the template fails to parse or execute.
+func DMMessageFromTemplate(tmpl string, p ChannelMessageParams, action string) (string, error) {
+	t, err := template.New("dm").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse DM template: %w", err)
+	}
+
+	var sb strings.Builder
+	data := DMTemplateData{
+		ChannelMessageParams: p,
+		Emoji:                StateEmoji(p.State),
+		Action:               action,
+	}
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render DM template: %w", err)
+	}
+	return sb.String(), nil
+}
+
 // StateAnalysisParams contains parameters for StateFromAnalysis.
 type StateAnalysisParams struct {
 	WorkflowState      string
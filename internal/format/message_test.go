@@ -1,8 +1,12 @@
 package format
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestStateEmoji(t *testing.T) {
@@ -46,12 +50,12 @@ func TestStateText(t *testing.T) {
 		{StateAwaitingAssign, "awaiting assignment"},
 		{StateChanges, "changes requested"},
 		{StateConflict, "merge conflict"},
+		{StateClosed, "closed without merging"},
 		// States without text labels
 		{StateNewlyPublished, ""},
 		{StateDraft, ""},
 		{StateApproved, ""},
 		{StateMerged, ""},
-		{StateClosed, ""},
 		{StateUnknown, ""},
 	}
 
@@ -298,6 +302,698 @@ func TestForumThreadTitle(t *testing.T) {
 	}
 }
 
+func TestChannelMessage_MergedVsClosedUnmerged(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 7,
+		Title:  "Try a thing",
+		Author: "erin",
+		PRURL:  "https://github.com/org/repo/pull/7",
+	}
+
+	params.State = StateMerged
+	merged := ChannelMessage(params)
+	params.State = StateClosed
+	closed := ChannelMessage(params)
+
+	if merged == closed {
+		t.Fatalf("merged and closed-unmerged render identically: %q", merged)
+	}
+	if !strings.HasPrefix(merged, EmojiMerged) || strings.Contains(merged, "closed without merging") {
+		t.Errorf("merged message = %q, want merged emoji and no closed label", merged)
+	}
+	if !strings.HasPrefix(closed, EmojiClosed) || !strings.HasSuffix(closed, "• closed without merging") {
+		t.Errorf("closed message = %q, want closed emoji and closed-without-merging label", closed)
+	}
+}
+
+func TestActionGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []ActionUser
+		want  string
+	}{
+		{
+			name: "shared action named once",
+			users: []ActionUser{
+				{Username: "a", Mention: "<@1>", Action: "review"},
+				{Username: "b", Mention: "<@2>", Action: "review"},
+				{Username: "c", Mention: "<@3>", Action: "review"},
+			},
+			want: "**review** → <@1>, <@2>, <@3>",
+		},
+		{
+			name: "mixed actions in first-seen order",
+			users: []ActionUser{
+				{Username: "a", Mention: "<@1>", Action: "review"},
+				{Username: "b", Mention: "<@2>", Action: "fix tests"},
+				{Username: "c", Mention: "<@3>", Action: "review"},
+				{Username: "d", Mention: "<@4>", Action: "approve"},
+				{Username: "e", Mention: "<@5>", Action: "fix tests"},
+			},
+			want: "**review** → <@1>, <@3>; **fix tests** → <@2>, <@5>; **approve** → <@4>",
+		},
+		{
+			name: "users without an action skipped",
+			users: []ActionUser{
+				{Username: "a", Mention: "<@1>"},
+				{Username: "b", Mention: "<@2>", Action: "review"},
+			},
+			want: "**review** → <@2>",
+		},
+		{
+			name:  "none",
+			users: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeated to catch any dependence on map iteration order
+			for range 10 {
+				if got := ActionGroups(tt.users); got != tt.want {
+					t.Fatalf("ActionGroups() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReviewerList(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []ActionUser
+		want  string
+	}{
+		{
+			name:  "all mapped",
+			users: []ActionUser{{Username: "a", Mention: "<@1>"}, {Username: "b", Mention: "<@2>"}},
+			want:  "<@1>, <@2>",
+		},
+		{
+			name: "mapped first, unmapped summarized",
+			users: []ActionUser{
+				{Username: "alice", Mention: "alice"},
+				{Username: "carol", Mention: "<@3>"},
+				{Username: "bob", Mention: "bob"},
+			},
+			want: "<@3> (unmapped: alice, bob)",
+		},
+		{
+			name:  "only unmapped",
+			users: []ActionUser{{Username: "alice", Mention: "alice"}},
+			want:  "(unmapped: alice)",
+		},
+		{
+			name: "with ages",
+			users: []ActionUser{
+				{Username: "a", Mention: "<@1>", Waiting: 2 * time.Hour},
+				{Username: "b", Mention: "<@2>", Waiting: 30 * time.Second},
+				{Username: "alice", Mention: "alice", Waiting: 72 * time.Hour},
+			},
+			want: "<@1> (<1d), <@2> (<1d) (unmapped: alice (3d))",
+		},
+		{
+			name:  "none",
+			users: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReviewerList(tt.users); got != tt.want {
+				t.Errorf("ReviewerList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewerAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		waiting time.Duration
+		want    string
+	}{
+		{name: "unknown", waiting: 0, want: ""},
+		{name: "in the future", waiting: -time.Minute, want: ""},
+		{name: "just requested", waiting: 5 * time.Minute, want: " (<1d)"},
+		{name: "hours", waiting: 23*time.Hour + 40*time.Minute, want: " (<1d)"},
+		{name: "one day", waiting: 24 * time.Hour, want: " (1d)"},
+		{name: "days", waiting: 71 * time.Hour, want: " (2d)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reviewerAge(tt.waiting); got != tt.want {
+				t.Errorf("reviewerAge(%v) = %q, want %q", tt.waiting, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelMessage_MixedMappedReviewers(t *testing.T) {
+	got := ChannelMessage(ChannelMessageParams{
+		Repo:   "repo",
+		Number: 3,
+		Title:  "Mixed",
+		Author: "erin",
+		State:  StateNeedsReview,
+		PRURL:  "https://github.com/org/repo/pull/3",
+		ActionUsers: []ActionUser{
+			{Username: "alice", Mention: "alice", Action: "review"},
+			{Username: "carol", Mention: "<@3>", Action: "review"},
+		},
+	})
+	if want := " • **review** → <@3> (unmapped: alice)"; !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() = %q, want suffix %q", got, want)
+	}
+}
+
+func TestChannelMessage_Prefix(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 9,
+		Title:  "Tune the thing",
+		Author: "erin",
+		State:  StateApproved,
+		PRURL:  "https://github.com/org/repo/pull/9",
+	}
+	plain := ChannelMessage(params)
+
+	params.Prefix = "[infra]"
+	if got := ChannelMessage(params); got != "[infra] "+plain {
+		t.Errorf("ChannelMessage() with prefix = %q, want %q", got, "[infra] "+plain)
+	}
+
+	params.Prefix = ""
+	if got := ChannelMessage(params); got != plain || !strings.HasPrefix(got, EmojiApproved) {
+		t.Errorf("ChannelMessage() with empty prefix = %q, want %q", got, plain)
+	}
+}
+
+func TestChannelMessage_RepoEmoji(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 9,
+		Title:  "Tune the thing",
+		Author: "erin",
+		State:  StateApproved,
+		PRURL:  "https://github.com/org/repo/pull/9",
+	}
+	plain := ChannelMessage(params)
+
+	params.RepoEmoji = "🚀"
+	if got := ChannelMessage(params); got != "🚀 "+plain {
+		t.Errorf("ChannelMessage() with repo emoji = %q, want %q", got, "🚀 "+plain)
+	}
+
+	params.Prefix = "[infra]"
+	if got := ChannelMessage(params); got != "[infra] 🚀 "+plain {
+		t.Errorf("ChannelMessage() with prefix and repo emoji = %q, want %q", got, "[infra] 🚀 "+plain)
+	}
+}
+
+func TestChannelMessage_ShowOrg(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      11,
+		Title:       "Share the channel",
+		Author:      "erin",
+		State:       StateApproved,
+		PRURL:       "https://github.com/org/repo/pull/11",
+		ChannelName: "repo",
+	}
+	want := "[#11](https://github.com/org/repo/pull/11?st=approved)"
+	if got := ChannelMessage(params); !strings.Contains(got, want) {
+		t.Errorf("ChannelMessage() without org = %q, want to contain %q", got, want)
+	}
+
+	params.ShowOrg = true
+	want = "[org/repo#11](https://github.com/org/repo/pull/11?st=approved)"
+	if got := ChannelMessage(params); !strings.Contains(got, want) {
+		t.Errorf("ChannelMessage() with org = %q, want to contain %q", got, want)
+	}
+}
+
+func TestChannelMessage_PlainURL(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      13,
+		Title:       "Copy me",
+		Author:      "erin",
+		State:       StateApproved,
+		PRURL:       "https://github.com/org/repo/pull/13",
+		ChannelName: "repo",
+		Assignees:   []string{"<@1>"},
+	}
+	if got := ChannelMessage(params); strings.Contains(got, "<https://") {
+		t.Errorf("ChannelMessage() without PlainURL = %q, want no plain URL", got)
+	}
+
+	params.PlainURL = true
+	got := ChannelMessage(params)
+	if want := "\n<https://github.com/org/repo/pull/13>"; !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() with PlainURL = %q, want it to end with %q", got, want)
+	}
+	if want := "[#13](https://github.com/org/repo/pull/13?st=approved)"; !strings.Contains(got, want) {
+		t.Errorf("ChannelMessage() with PlainURL = %q, want the masked link kept", got)
+	}
+}
+
+func TestChannelMessage_Analyzing(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      14,
+		Title:       "Fresh PR",
+		Author:      "erin",
+		State:       StateNeedsReview,
+		PRURL:       "https://github.com/org/repo/pull/14",
+		ChannelName: "repo",
+		Analyzing:   true,
+	}
+	got := ChannelMessage(params)
+	if !strings.HasSuffix(got, " • ⏳ analyzing…") {
+		t.Errorf("ChannelMessage() while analyzing = %q, want the analyzing indicator", got)
+	}
+	if text := StateText(StateNeedsReview); text != "" && strings.Contains(got, text) {
+		t.Errorf("ChannelMessage() while analyzing = %q, want no state text", got)
+	}
+
+	params.ActionUsers = []ActionUser{{Username: "bob", Mention: "<@42>", Action: "review"}}
+	if got := ChannelMessage(params); strings.Contains(got, "analyzing") {
+		t.Errorf("ChannelMessage() with action users = %q, want them instead of the indicator", got)
+	}
+}
+
+func TestChannelMessage_Unavailable(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      15,
+		PRURL:       "https://github.com/org/repo/pull/15",
+		ChannelName: "repo",
+		Unavailable: "details unavailable, see GitHub",
+	}
+	want := EmojiUnknown + " [#15](https://github.com/org/repo/pull/15) • ⚠️ details unavailable, see GitHub"
+	if got := ChannelMessage(params); got != want {
+		t.Errorf("ChannelMessage() = %q, want %q", got, want)
+	}
+
+	params.Title = "Known title"
+	params.PlainURL = true
+	want = EmojiUnknown + " [#15](https://github.com/org/repo/pull/15) · Known title • ⚠️ details unavailable, see GitHub\n<https://github.com/org/repo/pull/15>"
+	if got := ChannelMessage(params); got != want {
+		t.Errorf("ChannelMessage() with a title = %q, want %q", got, want)
+	}
+}
+
+func TestDashboardTemplateURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   string
+		repo   string
+		number int
+		want   string
+	}{
+		{
+			name:   "PR link",
+			tmpl:   "https://dash.example.com/{org}/{repo}/pull/{number}",
+			repo:   "repo",
+			number: 15,
+			want:   "https://dash.example.com/org/repo/pull/15",
+		},
+		{
+			name:   "query placeholders",
+			tmpl:   "https://goose.internal/prs?org={org}&repo={repo}&n={number}",
+			repo:   "repo",
+			number: 15,
+			want:   "https://goose.internal/prs?org=org&repo=repo&n=15",
+		},
+		{
+			name:   "no placeholders",
+			tmpl:   "https://dash.example.com",
+			repo:   "repo",
+			number: 15,
+			want:   "https://dash.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DashboardTemplateURL(tt.tmpl, "org", tt.repo, tt.number); got != tt.want {
+				t.Errorf("DashboardTemplateURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardTemplateOrgURL(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"org template", "https://dash.example.com/{org}", "https://dash.example.com/org"},
+		{"PR template", "https://dash.example.com/{org}/{repo}/pull/{number}", ""},
+		{"query template", "https://goose.internal/prs?org={org}&n={number}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DashboardTemplateOrgURL(tt.tmpl, "org"); got != tt.want {
+				t.Errorf("DashboardTemplateOrgURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "empty", body: "", want: ""},
+		{name: "only a template comment", body: "<!-- Describe your change -->\n\n", want: ""},
+		{
+			name: "collapsed to one line",
+			body: "## Summary\n\nFixes the   flaky test.\r\n- retries once",
+			want: `\## Summary Fixes the flaky test. - retries once`,
+		},
+		{
+			name: "leading quote escaped",
+			body: "> Quoted from the issue",
+			want: `\> Quoted from the issue`,
+		},
+		{
+			name: "leading list item escaped",
+			body: "- first\n- second",
+			want: `\- first - second`,
+		},
+		{
+			name: "markdown escaped",
+			body: "Use `foo_bar` with **care** and [docs](https://x.y) ~~not~~ this | <@123>",
+			want: "Use \\`foo\\_bar\\` with \\*\\*care\\*\\* and \\[docs\\](https://x.y) \\~\\~not\\~\\~ this \\| \\<@123>",
+		},
+		{name: "mass mentions neutralized", body: "cc @everyone and @here", want: "cc @\u200beveryone and @\u200bhere"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BodySnippet(tt.body); got != tt.want {
+				t.Errorf("BodySnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		got := BodySnippet(strings.Repeat("é", 500))
+		if n := utf8.RuneCountInString(got); n != bodySnippetLength {
+			t.Errorf("BodySnippet() is %d characters, want %d", n, bodySnippetLength)
+		}
+		if !strings.HasSuffix(got, "…") || !utf8.ValidString(got) {
+			t.Errorf("BodySnippet() = %q, want valid text ending in an ellipsis", got)
+		}
+	})
+}
+
+func TestChannelMessage_BodySnippet(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      15,
+		Title:       "Track me",
+		Author:      "erin",
+		State:       StateApproved,
+		PRURL:       "https://github.com/org/repo/pull/15",
+		BodySnippet: "Adds tracking",
+		PlainURL:    true,
+	}
+	want := "\n> Adds tracking\n<https://github.com/org/repo/pull/15>"
+	if got := ChannelMessage(params); !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() with BodySnippet = %q, want it to end with %q", got, want)
+	}
+}
+
+func TestChannelMessage_ReviewChecklist(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:           "org",
+		Repo:            "repo",
+		Number:          15,
+		Title:           "Track me",
+		Author:          "erin",
+		State:           StateApproved,
+		PRURL:           "https://github.com/org/repo/pull/15",
+		BodySnippet:     "Adds tracking",
+		ReviewChecklist: []string{"tests pass", "docs updated"},
+	}
+	want := "\n> Adds tracking\n**Review checklist**\n- ⬜ tests pass\n- ⬜ docs updated"
+	if got := ChannelMessage(params); !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() with ReviewChecklist = %q, want it to end with %q", got, want)
+	}
+}
+
+func TestChannelMessage_DashboardFooter(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:        "org",
+		Repo:         "repo",
+		Number:       15,
+		Title:        "Track me",
+		Author:       "erin",
+		State:        StateApproved,
+		PRURL:        "https://github.com/org/repo/pull/15",
+		ChannelName:  "repo",
+		DashboardURL: DashboardOrgURL(DefaultDashboardURL, "org"),
+		PlainURL:     true,
+	}
+	want := "\n-# [📊 dashboard](<https://reviewgoose.dev/orgs/org>)\n<https://github.com/org/repo/pull/15>"
+	if got := ChannelMessage(params); !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() with DashboardURL = %q, want it to end with %q", got, want)
+	}
+
+	// Left out rather than pushing the message past Discord's limit
+	params.Closes = make([]int, 400)
+	if got := ChannelMessage(params); strings.Contains(got, "dashboard") {
+		t.Errorf("ChannelMessage() near the limit = %d chars with the footer, want it left out", len(got))
+	}
+}
+
+func TestChannelMessage_AcknowledgedBy(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      12,
+		Title:       "Needs eyes",
+		Author:      "erin",
+		State:       StateNeedsReview,
+		PRURL:       "https://github.com/org/repo/pull/12",
+		ActionUsers: []ActionUser{{Username: "bob", Mention: "<@42>", Action: "review"}},
+	}
+	if got := ChannelMessage(params); strings.Contains(got, "acknowledged") {
+		t.Errorf("ChannelMessage() without acknowledgment = %q, want no acknowledgment", got)
+	}
+
+	params.AcknowledgedBy = "bob"
+	want := "**review** → <@42> · acknowledged by bob"
+	if got := ChannelMessage(params); !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() = %q, want suffix %q", got, want)
+	}
+}
+
+func TestChannelMessage_ReviewRound(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 14,
+		Title:  "Rework parser",
+		Author: "erin",
+		State:  StateChanges,
+		PRURL:  "https://github.com/org/repo/pull/14",
+	}
+	for _, round := range []int{0, 1} {
+		params.ReviewRound = round
+		if got := ChannelMessage(params); strings.Contains(got, "round") {
+			t.Errorf("ChannelMessage() in round %d = %q, want no round shown", round, got)
+		}
+	}
+
+	params.ReviewRound = 3
+	if got := ChannelMessage(params); !strings.HasSuffix(got, " · round 3") {
+		t.Errorf("ChannelMessage() = %q, want suffix %q", got, " · round 3")
+	}
+}
+
+func TestChannelMessage_Narrow(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:        "repo",
+		Number:      15,
+		Title:       "Replace the legacy session store with the new token-based cache",
+		Author:      "erin",
+		State:       StateNeedsReview,
+		PRURL:       "https://github.com/org/repo/pull/15",
+		ActionUsers: []ActionUser{{Username: "bob", Mention: "<@42>", Action: "review"}},
+	}
+	wide := ChannelMessage(params)
+	if strings.Contains(wide, "\n") {
+		t.Errorf("wide ChannelMessage() = %q, want a single line", wide)
+	}
+	want := "[repo#15](https://github.com/org/repo/pull/15?st=needs_review) · " +
+		Truncate(params.Title, 60) + " · erin • **review** → <@42>"
+	if !strings.HasSuffix(wide, want) {
+		t.Errorf("wide ChannelMessage() = %q, want suffix %q", wide, want)
+	}
+
+	params.Narrow = true
+	narrow := ChannelMessage(params)
+	lines := strings.Split(narrow, "\n")
+	if len(lines) != 2 || lines[1] != "**review** → <@42>" {
+		t.Errorf("narrow ChannelMessage() = %q, want the reviewers on a second line", narrow)
+	}
+	if len(lines[0]) >= len(strings.Split(wide, " • ")[0]) {
+		t.Errorf("narrow first line %q isn't shorter than wide", lines[0])
+	}
+	if !strings.Contains(lines[0], Truncate(params.Title, 40)) {
+		t.Errorf("narrow ChannelMessage() = %q, want the title cut to 40 characters", narrow)
+	}
+
+	// With no one to act, the state goes on its own line
+	params.ActionUsers = nil
+	if got := ChannelMessage(params); !strings.HasSuffix(got, "\n"+StateText(StateNeedsReview)) {
+		t.Errorf("narrow ChannelMessage() = %q, want the state on its own line", got)
+	}
+}
+
+func TestChannelMessage_BaseBranch(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 10,
+		Title:  "Backport fix",
+		Author: "erin",
+		State:  StateApproved,
+		PRURL:  "https://github.com/org/repo/pull/10",
+	}
+	if got := ChannelMessage(params); strings.Contains(got, "→") {
+		t.Errorf("ChannelMessage() without base = %q, want no branch", got)
+	}
+
+	params.BaseBranch = "release-2.0"
+	want := "[repo#10](https://github.com/org/repo/pull/10?st=approved) → release-2.0 · Backport fix"
+	if got := ChannelMessage(params); !strings.Contains(got, want) {
+		t.Errorf("ChannelMessage() = %q, want to contain %q", got, want)
+	}
+}
+
+func TestCIEmoji(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks Checks
+		want   string
+	}{
+		{"all passing", Checks{Passing: 5}, "🟢"},
+		{"pending", Checks{Passing: 3, Pending: 2}, "🟡"},
+		{"waiting on a gate", Checks{Passing: 3, Waiting: 1}, "🟡"},
+		{"failing", Checks{Passing: 3, Pending: 1, Failing: 1}, "🔴"},
+		{"no checks", Checks{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CIEmoji(tt.checks); got != tt.want {
+				t.Errorf("CIEmoji(%+v) = %q, want %q", tt.checks, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelMessage_CI(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:   "repo",
+		Number: 10,
+		Title:  "Add cache",
+		Author: "erin",
+		State:  StateApproved,
+		PRURL:  "https://github.com/org/repo/pull/10",
+	}
+	if got := ChannelMessage(params); strings.Contains(got, "🟢") {
+		t.Errorf("ChannelMessage() without CI = %q, want no CI status", got)
+	}
+
+	params.CI = "🟢"
+	want := "[repo#10](https://github.com/org/repo/pull/10?st=approved) 🟢 · Add cache"
+	if got := ChannelMessage(params); !strings.Contains(got, want) {
+		t.Errorf("ChannelMessage() = %q, want to contain %q", got, want)
+	}
+}
+
+func TestChannelMessage_Closes(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:  "org",
+		Repo:   "repo",
+		Number: 10,
+		Title:  "Fix login",
+		Author: "erin",
+		State:  StateApproved,
+		PRURL:  "https://github.com/org/repo/pull/10",
+	}
+	if got := ChannelMessage(params); strings.Contains(got, "closes") {
+		t.Errorf("ChannelMessage() without linked issues = %q, want no closes line", got)
+	}
+
+	params.Closes = []int{45, 46}
+	want := "\ncloses [#45](https://github.com/org/repo/issues/45), [#46](https://github.com/org/repo/issues/46)"
+	if got := ChannelMessage(params); !strings.HasSuffix(got, want) {
+		t.Errorf("ChannelMessage() = %q, want to end with %q", got, want)
+	}
+}
+
+func TestPrefixedForumThreadTitle(t *testing.T) {
+	if got := PrefixedForumThreadTitle("", "repo", 1, "Title"); got != ForumThreadTitle("repo", 1, "Title") {
+		t.Errorf("PrefixedForumThreadTitle() with empty prefix = %q, want ForumThreadTitle output", got)
+	}
+	if got := PrefixedForumThreadTitle("[infra]", "repo", 1, "Title"); got != "[infra] [repo#1] Title" {
+		t.Errorf("PrefixedForumThreadTitle() = %q, want %q", got, "[infra] [repo#1] Title")
+	}
+	if got := PrefixedForumThreadTitle("[infra]", "repo", 1, strings.Repeat("x", 200)); len(got) != 100 {
+		t.Errorf("PrefixedForumThreadTitle() length = %d, want 100", len(got))
+	}
+}
+
+func TestChannelMessage_Assignees(t *testing.T) {
+	params := ChannelMessageParams{
+		Repo:        "repo",
+		Number:      8,
+		Title:       "Add a thing",
+		Author:      "erin",
+		State:       StateNeedsReview,
+		PRURL:       "https://github.com/org/repo/pull/8",
+		ActionUsers: []ActionUser{{Username: "bob", Mention: "<@2>", Action: "review"}},
+		Assignees:   []string{"<@1>", "carol"},
+	}
+
+	got := ChannelMessage(params)
+	first, assigned, ok := strings.Cut(got, "\n")
+	if !ok {
+		t.Fatalf("ChannelMessage() = %q, want assignees on a separate line", got)
+	}
+	if !strings.HasSuffix(first, "**review** → <@2>") || strings.Contains(first, "<@1>") {
+		t.Errorf("first line = %q, want reviewers only", first)
+	}
+	if assigned != "assigned to <@1>, carol" {
+		t.Errorf("assignee line = %q, want %q", assigned, "assigned to <@1>, carol")
+	}
+
+	// No review actions: the state text stays and assignees are still shown
+	params.ActionUsers = nil
+	got = ChannelMessage(params)
+	if !strings.Contains(got, "• "+StateText(StateNeedsReview)+"\n") || !strings.HasSuffix(got, "assigned to <@1>, carol") {
+		t.Errorf("ChannelMessage() without actions = %q, want state text and assignee line", got)
+	}
+
+	params.Assignees = nil
+	if got := ChannelMessage(params); strings.Contains(got, "assigned to") {
+		t.Errorf("ChannelMessage() without assignees = %q, want no assignee line", got)
+	}
+}
+
 func TestDMMessage(t *testing.T) {
 	params := ChannelMessageParams{
 		Owner:  "org",
@@ -327,6 +1023,41 @@ func TestDMMessage(t *testing.T) {
 	})
 }
 
+func TestDMMessageFromTemplate(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:  "org",
+		Repo:   "repo",
+		Number: 99,
+		Title:  "Important PR",
+		Author: "dave",
+		State:  StateConflict,
+		PRURL:  "https://github.com/org/repo/pull/99",
+	}
+
+	t.Run("renders fields", func(t *testing.T) {
+		got, err := DMMessageFromTemplate("{{.Emoji}} URGENT {{.Action}}: <{{.PRURL}}> {{.Title}} (#{{.Number}})", params, "fix conflict")
+		if err != nil {
+			t.Fatalf("DMMessageFromTemplate() error = %v", err)
+		}
+		want := StateEmoji(StateConflict) + " URGENT fix conflict: <https://github.com/org/repo/pull/99> Important PR (#99)"
+		if got != want {
+			t.Errorf("DMMessageFromTemplate() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		if _, err := DMMessageFromTemplate("{{.Title", params, ""); err == nil {
+			t.Error("DMMessageFromTemplate() expected parse error")
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		if _, err := DMMessageFromTemplate("{{.Nope}}", params, ""); err == nil {
+			t.Error("DMMessageFromTemplate() expected execute error")
+		}
+	})
+}
+
 func TestStateFromAnalysis(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -431,3 +1162,455 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"fits", "short message", 20, []string{"short message"}},
+		{"no limit", "short message", 0, []string{"short message"}},
+		{"at line end", "first line\nsecond line", 15, []string{"first line", "second line"}},
+		{"at space", "one two three four", 10, []string{"one two", "three four"}},
+		{"mid word", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"rune boundary", "ééé", 3, []string{"é", "é", "é"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMessage(tt.text, tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitMessage(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+			}
+			for _, chunk := range got {
+				if tt.limit > 0 && len(chunk) > tt.limit {
+					t.Errorf("chunk %q is longer than %d", chunk, tt.limit)
+				}
+			}
+		})
+	}
+}
+
+func TestDigestMessage(t *testing.T) {
+	got := DigestMessage("[infra]", []string{"line one", "line two"})
+	want := "[infra] **PR digest** · 2 PRs changed\nline one\nline two"
+	if got != want {
+		t.Errorf("DigestMessage() = %q, want %q", got, want)
+	}
+
+	if got := DigestMessage("", []string{"only"}); got != "**PR digest** · 1 PR changed\nonly" {
+		t.Errorf("DigestMessage() single = %q", got)
+	}
+
+	long := make([]string, 50)
+	for i := range long {
+		long[i] = strings.Repeat("x", 100)
+	}
+	got = DigestMessage("", long)
+	if len(got) > MessageLimit {
+		t.Errorf("DigestMessage() length = %d, want <= %d", len(got), MessageLimit)
+	}
+	if !strings.HasSuffix(got, "more") {
+		t.Errorf("DigestMessage() = %q, want overflow summary", got[len(got)-40:])
+	}
+}
+
+func TestDMDigest(t *testing.T) {
+	got := DMDigest([]DMDigestSection{{Org: "orga", Items: []string{"A one"}}, {Items: []string{"other one"}}})
+	want := "📬 **2 PR updates**\n\n**orga**\nA one\n\n**other**\nother one"
+	if got != want {
+		t.Errorf("DMDigest() = %q, want %q", got, want)
+	}
+
+	long := make([]string, 30)
+	for i := range long {
+		long[i] = strings.Repeat("x", 100)
+	}
+	got = DMDigest([]DMDigestSection{{Org: "orga", Items: long[:10]}, {Org: "orgb", Items: long[10:]}})
+	if len(got) > MessageLimit {
+		t.Errorf("DMDigest() length = %d, want <= %d", len(got), MessageLimit)
+	}
+	if !strings.HasSuffix(got, "…and 11 more") {
+		t.Errorf("DMDigest() = %q, want the last 11 summarized", got[len(got)-40:])
+	}
+}
+
+func TestSortByStatePriority(t *testing.T) {
+	prs := []ChannelMessageParams{
+		{Repo: "b", Number: 1, State: StateApproved},
+		{Repo: "b", Number: 2, State: StateNeedsReview},
+		{Repo: "a", Number: 3, State: StateUnknown},
+		{Repo: "b", Number: 3, State: StateTestsBroken},
+		{Repo: "a", Number: 9, State: StateNeedsReview},
+		{Repo: "a", Number: 4, State: StateConflict},
+	}
+	SortByStatePriority(prs)
+
+	var got []string
+	for _, p := range prs {
+		got = append(got, fmt.Sprintf("%s#%d:%s", p.Repo, p.Number, p.State))
+	}
+	want := []string{
+		"a#4:conflict",
+		"b#3:tests_broken",
+		"a#9:needs_review",
+		"b#2:needs_review",
+		"b#1:approved",
+		"a#3:unknown",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortByStatePriority() = %v, want %v", got, want)
+	}
+}
+
+func TestActionPriority(t *testing.T) {
+	kinds := []string{"merge", "unknown_kind", "review", "fix_tests", "address_comments", "fix_conflict"}
+	slices.SortStableFunc(kinds, func(a, b string) int {
+		return ActionPriority(a) - ActionPriority(b)
+	})
+	want := []string{"fix_conflict", "fix_tests", "address_comments", "review", "merge", "unknown_kind"}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("sorted by ActionPriority = %v, want %v", kinds, want)
+	}
+}
+
+func TestCompactLine(t *testing.T) {
+	params := ChannelMessageParams{
+		Owner:       "org",
+		Repo:        "repo",
+		Number:      12,
+		Title:       "Fix the flaky login test that keeps timing out on CI",
+		Author:      "erin",
+		State:       StateTestsBroken,
+		PRURL:       "https://github.com/org/repo/pull/12",
+		ActionUsers: []ActionUser{{Username: "erin", Mention: "<@1>", Action: "fix_tests"}},
+	}
+	want := "🪳 [repo#12](https://github.com/org/repo/pull/12?st=tests_broken) Fix the flaky login test that keeps t..."
+	if got := CompactLine(params); got != want {
+		t.Errorf("CompactLine() = %q, want %q", got, want)
+	}
+
+	params.ChannelName = "repo"
+	params.Title = "Short"
+	want = "🪳 [#12](https://github.com/org/repo/pull/12?st=tests_broken) Short"
+	if got := CompactLine(params); got != want {
+		t.Errorf("CompactLine() in repo channel = %q, want %q", got, want)
+	}
+}
+
+func TestBoardMessage(t *testing.T) {
+	var prs []ChannelMessageParams
+	for i := 1; i <= 5; i++ {
+		prs = append(prs, ChannelMessageParams{
+			Repo:   "repo",
+			Number: i,
+			Title:  fmt.Sprintf("PR %d", i),
+			State:  StateNeedsReview,
+			PRURL:  fmt.Sprintf("https://github.com/org/repo/pull/%d", i),
+		})
+	}
+	prs[4].State = StateConflict
+
+	got := BoardMessage(prs, 3, "https://dash.example.com")
+	lines := strings.Split(got, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("BoardMessage() = %q, want a header, 3 PRs, and an overflow note", got)
+	}
+	if lines[0] != "**PR board** · 5 PRs" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "[repo#5]") {
+		t.Errorf("first line = %q, want the conflicted PR first", lines[1])
+	}
+	if want := "…and 2 more · [dashboard](https://dash.example.com)"; lines[4] != want {
+		t.Errorf("overflow note = %q, want %q", lines[4], want)
+	}
+	if prs[0].Number != 1 {
+		t.Error("BoardMessage() reordered the caller's slice")
+	}
+
+	// Under the cap, no overflow note; without a URL, no link
+	if got := BoardMessage(prs, 10, ""); strings.Contains(got, "more") {
+		t.Errorf("BoardMessage() under the cap = %q, want no overflow note", got)
+	}
+	if got := BoardMessage(prs, 1, ""); !strings.HasSuffix(got, "\n…and 4 more") {
+		t.Errorf("BoardMessage() without a URL = %q, want an unlinked overflow note", got)
+	}
+}
+
+func TestBoardMessage_DefaultFitsMessageLimit(t *testing.T) {
+	var prs []ChannelMessageParams
+	for i := range 100 {
+		prs = append(prs, ChannelMessageParams{
+			Owner:  "some-organization",
+			Repo:   "a-fairly-long-repository-name",
+			Number: 10000 + i,
+			Title:  strings.Repeat("Long title ", 10),
+			State:  StateNeedsReview,
+			PRURL:  fmt.Sprintf("https://github.com/some-organization/a-fairly-long-repository-name/pull/%d", 10000+i),
+		})
+	}
+	// Long lines are summarized before the cap once the message would run over
+	got := BoardMessage(prs, 0, "https://dash.example.com/some-organization")
+	if len(got) > 2000 || !strings.Contains(got, " more · [dashboard]") {
+		t.Errorf("BoardMessage() = %d chars ending %q, want at most 2000 with an overflow note", len(got), got[len(got)-60:])
+	}
+
+	// Typical lines fit the default cap
+	for i := range prs {
+		prs[i].Repo = "api"
+		prs[i].Title = strings.Repeat("x", maxCompactTitle)
+		prs[i].PRURL = fmt.Sprintf("https://github.com/some-organization/api/pull/%d", prs[i].Number)
+	}
+	got = BoardMessage(prs, 0, "https://dash.example.com/some-organization")
+	if want := fmt.Sprintf("…and %d more", 100-DefaultBoardMaxPRs); len(got) > 2000 || !strings.Contains(got, want) {
+		t.Errorf("BoardMessage() = %d chars, want at most 2000 with %q", len(got), want)
+	}
+}
+
+func TestConflictResolvedMessage(t *testing.T) {
+	prURL := "https://github.com/org/repo/pull/7"
+	want := "✅ conflict resolved: [org/repo#7](https://github.com/org/repo/pull/7)"
+	if got := ConflictResolvedMessage("org", "repo", 7, prURL, "✅ conflict resolved", ""); got != want {
+		t.Errorf("ConflictResolvedMessage() = %q, want %q", got, want)
+	}
+	want = "✅ conflict resolved: [org/repo#7](https://github.com/org/repo/pull/7) is ready again <@1> <@2>"
+	if got := ConflictResolvedMessage("org", "repo", 7, prURL, "✅ conflict resolved", "<@1> <@2>"); got != want {
+		t.Errorf("ConflictResolvedMessage() with mentions = %q, want %q", got, want)
+	}
+}
+
+func TestArchivedRepoMessage(t *testing.T) {
+	want := "📦 archived: [org/repo](<https://github.com/org/repo>)"
+	if got := ArchivedRepoMessage("org", "repo", "📦 archived"); got != want {
+		t.Errorf("ArchivedRepoMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestEscalationMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		mentions string
+		waiting  time.Duration
+		want     string
+	}{
+		{
+			name:     "hours with mentions",
+			mentions: "<@1> <@2>",
+			waiting:  5*time.Hour + 30*time.Minute,
+			want:     "⏰ <@1> <@2> [org/repo#7](https://github.com/org/repo/pull/7) has been waiting for review for 5h",
+		},
+		{
+			name:    "days without mentions",
+			waiting: 72 * time.Hour,
+			want:    "⏰ [org/repo#7](https://github.com/org/repo/pull/7) has been waiting for review for 3d",
+		},
+		{
+			name:     "minutes",
+			mentions: "<@&99>",
+			waiting:  45 * time.Minute,
+			want:     "⏰ <@&99> [org/repo#7](https://github.com/org/repo/pull/7) has been waiting for review for 45m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EscalationMessage("org", "repo", 7, "https://github.com/org/repo/pull/7", tt.mentions, tt.waiting)
+			if got != tt.want {
+				t.Errorf("EscalationMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeutralizeUserMention(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		userID string
+		strip  bool
+		want   string
+	}{
+		{name: "escaped", text: "Ping <@999> please", userID: "999", want: "Ping <@\u200b999> please"},
+		{name: "nickname form escaped", text: "Ping <@!999>", userID: "999", want: "Ping <@\u200b!999>"},
+		{name: "stripped", text: "Ping <@999> please", userID: "999", strip: true, want: "Ping  please"},
+		{name: "other users kept", text: "Ping <@123>", userID: "999", want: "Ping <@123>"},
+		{name: "unknown user ID", text: "Ping <@999>", want: "Ping <@999>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeutralizeUserMention(tt.text, tt.userID, tt.strip); got != tt.want {
+				t.Errorf("NeutralizeUserMention() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewReminderMessage(t *testing.T) {
+	p := ChannelMessageParams{
+		Owner:  "org",
+		Repo:   "repo",
+		Number: 7,
+		Title:  "Add caching",
+		Author: "alice",
+		PRURL:  "https://github.com/org/repo/pull/7",
+	}
+	want := "🔁 **Reminder 2/3, still waiting on your review**: [org/repo#7](https://github.com/org/repo/pull/7) Add caching by alice"
+	if got := ReviewReminderMessage(p, 2, 3); got != want {
+		t.Errorf("ReviewReminderMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestDMEscalationMessage(t *testing.T) {
+	p := ChannelMessageParams{
+		Owner:  "org",
+		Repo:   "repo",
+		Number: 7,
+		Title:  "Add caching",
+		Author: "alice",
+		PRURL:  "https://github.com/org/repo/pull/7",
+	}
+	want := "⏰ **Still waiting on you to review** after 26h: [org/repo#7](https://github.com/org/repo/pull/7) Add caching by alice"
+	if got := DMEscalationMessage(p, "review", 26*time.Hour); got != want {
+		t.Errorf("DMEscalationMessage() = %q, want %q", got, want)
+	}
+	want = "⏰ **Still waiting on you** after 3d: [org/repo#7](https://github.com/org/repo/pull/7) Add caching by alice"
+	if got := DMEscalationMessage(p, "", 72*time.Hour); got != want {
+		t.Errorf("DMEscalationMessage() without action = %q, want %q", got, want)
+	}
+}
+
+func TestActivitySummaryMessage(t *testing.T) {
+	got := ActivitySummaryMessage(ActivitySummaryParams{
+		Org:            "testorg",
+		Opened:         3,
+		Merged:         2,
+		Closed:         1,
+		Reviewed:       2,
+		AvgFirstReview: 4*time.Hour + 20*time.Minute,
+		DMsSent:        12,
+	})
+	want := "📊 **testorg** in the last day\n" +
+		"PRs: 3 opened · 2 merged · 1 closed\n" +
+		"First review: 4h on average across 2 PRs\n" +
+		"DMs sent: 12"
+	if got != want {
+		t.Errorf("ActivitySummaryMessage() = %q, want %q", got, want)
+	}
+
+	quiet := ActivitySummaryMessage(ActivitySummaryParams{Org: "testorg"})
+	if !strings.Contains(quiet, "First review: none") {
+		t.Errorf("ActivitySummaryMessage() with no reviews = %q, want first review none", quiet)
+	}
+}
+
+func TestFailingChecksLine(t *testing.T) {
+	checks := []CheckLink{
+		{Name: "lint", URL: "https://ci/1"},
+		{Name: "test [ubuntu]", URL: "https://ci/2"},
+		{Name: "build"},
+		{Name: "e2e", URL: "https://ci/4"},
+		{Name: "docs", URL: "https://ci/5"},
+	}
+
+	got := FailingChecksLine(checks)
+	want := "[lint](https://ci/1), [test (ubuntu)](https://ci/2), build +2 more"
+	if got != want {
+		t.Errorf("FailingChecksLine() = %q, want %q", got, want)
+	}
+
+	long := strings.Repeat("x", 100)
+	if got := FailingChecksLine([]CheckLink{{Name: long, URL: "https://ci/1"}}); len(got) > 60 {
+		t.Errorf("FailingChecksLine() = %q, want long names truncated", got)
+	}
+}
+
+func TestChannelMessage_FailingChecks(t *testing.T) {
+	p := ChannelMessageParams{
+		Owner:   "org",
+		Repo:    "repo",
+		Number:  1,
+		Title:   "Fix",
+		Author:  "alice",
+		State:   StateTestsBroken,
+		PRURL:   "https://github.com/org/repo/pull/1",
+		Failing: []CheckLink{{Name: "lint", URL: "https://ci/1"}},
+	}
+	if got := ChannelMessage(p); !strings.HasSuffix(got, "\nfailing: [lint](https://ci/1)") {
+		t.Errorf("ChannelMessage() = %q, want failing checks line", got)
+	}
+
+	// Only broken-test states show the line
+	p.State = StateNeedsReview
+	if got := ChannelMessage(p); strings.Contains(got, "failing:") {
+		t.Errorf("ChannelMessage() = %q, want no failing checks line", got)
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		style string
+		want  string
+	}{
+		{"none keeps shouting", "FIX LOGIN REDIRECT", TitleStyleNone, "FIX LOGIN REDIRECT"},
+		{"unknown style keeps shouting", "FIX LOGIN REDIRECT", "loud", "FIX LOGIN REDIRECT"},
+		{"sentence", "FIX LOGIN REDIRECT", TitleStyleSentence, "Fix login redirect"},
+		{"title", "FIX LOGIN REDIRECT", TitleStyleTitle, "Fix Login Redirect"},
+		{"sentence leaves mixed case alone", "Fix OAuth redirect for SSO", TitleStyleSentence, "Fix OAuth redirect for SSO"},
+		{"title leaves mixed case alone", "fix login redirect", TitleStyleTitle, "fix login redirect"},
+		{"single word kept", "WIP", TitleStyleSentence, "WIP"},
+		{"identifiers kept", "RENAME MAX_RETRIES IN CONFIG.GO", TitleStyleSentence, "Rename MAX_RETRIES in CONFIG.GO"},
+		{"versions kept", "BUMP GO TO 1.25", TitleStyleTitle, "Bump Go To 1.25"},
+		{"code spans kept", "FIX `SET X` HANDLING", TitleStyleSentence, "Fix `SET X` handling"},
+		{"tag kept", "(API) ADD RETRIES", TitleStyleSentence, "(API) Add retries"},
+		{"bracketed tag kept", "[UI]: FIX LOGIN BUTTON", TitleStyleTitle, "[UI]: Fix Login Button"},
+		{"leading punctuation", "\"QUOTED\" TITLE", TitleStyleSentence, "\"Quoted\" title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTitle(tt.title, tt.style); got != tt.want {
+				t.Errorf("NormalizeTitle(%q, %q) = %q, want %q", tt.title, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPreviousLink(t *testing.T) {
+	got := WithPreviousLink("🪳 [repo#1](url) Fix it", MessageLink("g", "c", "m"))
+	want := "🪳 [repo#1](url) Fix it\n-# [⬆️ previous update](<https://discord.com/channels/g/c/m>)"
+	if got != want {
+		t.Errorf("WithPreviousLink() = %q, want %q", got, want)
+	}
+}
+
+func TestApprovalStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		approvedBy []string
+		awaiting   []string
+		want       string
+	}{
+		{name: "partial", approvedBy: []string{"alice"}, awaiting: []string{"bob", "carol"}, want: "approved by alice; waiting on bob, carol"},
+		{name: "all approved", approvedBy: []string{"alice", "bob"}, want: "approved by alice, bob"},
+		{name: "none yet", awaiting: []string{"bob"}, want: "waiting on bob"},
+		{name: "no reviewers", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := approvalStatus(tt.approvedBy, tt.awaiting); got != tt.want {
+				t.Errorf("approvalStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	p := ChannelMessageParams{
+		Owner: "o", Repo: "r", Number: 1, Title: "T", Author: "dave", State: StateNeedsReview,
+		PRURL: "https://github.com/o/r/pull/1", ApprovedBy: []string{"alice"}, AwaitingApproval: []string{"bob"},
+	}
+	if got := ChannelMessage(p); !strings.HasSuffix(got, "\napproved by alice; waiting on bob") {
+		t.Errorf("ChannelMessage() = %q, want the approval line", got)
+	}
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultBranchTTL bounds how long a repo's default branch and archived status are cached.
+// Both rarely change, so an hour keeps API calls negligible.
+const defaultBranchTTL = time.Hour
+
+type defaultBranchEntry struct {
+	fetchedAt time.Time
+	branch    string
+	archived  bool // Archived or disabled
+}
+
+// BranchResolver looks up repository default branches and archived status, caching results.
+type BranchResolver struct {
+	appClient appClient
+	logger    *slog.Logger
+	cache     map[string]defaultBranchEntry // owner/repo -> repository details
+	mu        sync.RWMutex
+}
+
+// NewBranchResolver creates a new default branch resolver.
+func NewBranchResolver(appClient appClient, logger *slog.Logger) *BranchResolver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &BranchResolver{
+		appClient: appClient,
+		logger:    logger,
+		cache:     make(map[string]defaultBranchEntry),
+	}
+}
+
+// DefaultBranch returns the default branch of a repository.
+func (r *BranchResolver) DefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	entry, err := r.repository(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return entry.branch, nil
+}
+
+// RepoArchived reports whether a repository is archived or disabled.
+func (r *BranchResolver) RepoArchived(ctx context.Context, owner, repo string) (bool, error) {
+	entry, err := r.repository(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	return entry.archived, nil
+}
+
+// repository returns a repository's cached details, fetching them once they're stale.
+func (r *BranchResolver) repository(ctx context.Context, owner, repo string) (defaultBranchEntry, error) {
+	key := owner + "/" + repo
+
+	r.mu.RLock()
+	entry, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < defaultBranchTTL {
+		return entry, nil
+	}
+
+	client, err := r.appClient.ClientForOrg(ctx, owner)
+	if err != nil {
+		return defaultBranchEntry{}, fmt.Errorf("get client for org: %w", err)
+	}
+	repository, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return defaultBranchEntry{}, fmt.Errorf("get repository: %w", err)
+	}
+
+	entry = defaultBranchEntry{
+		branch:    repository.GetDefaultBranch(),
+		archived:  repository.GetArchived() || repository.GetDisabled(),
+		fetchedAt: time.Now(),
+	}
+	r.logger.Debug("resolved repository", "owner", owner, "repo", repo, "branch", entry.branch, "archived", entry.archived)
+
+	r.mu.Lock()
+	r.cache[key] = entry
+	r.mu.Unlock()
+
+	return entry, nil
+}
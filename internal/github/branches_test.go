@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+)
+
+func TestBranchResolver_DefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/repos/testowner/testrepo" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		writeJSONResponse(t, w, &github.Repository{DefaultBranch: github.String("trunk")})
+	}))
+	defer server.Close()
+
+	resolver := NewBranchResolver(&MockAppClient{Client: setupTestGitHubClient(t, server.URL)}, nil)
+
+	for range 2 {
+		branch, err := resolver.DefaultBranch(ctx, "testowner", "testrepo")
+		if err != nil {
+			t.Fatalf("DefaultBranch() error = %v", err)
+		}
+		if branch != "trunk" {
+			t.Errorf("DefaultBranch() = %q, want trunk", branch)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (second lookup cached)", calls)
+	}
+}
+
+func TestBranchResolver_RepoArchived(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testowner/archived":
+			writeJSONResponse(t, w, &github.Repository{DefaultBranch: github.String("main"), Archived: github.Bool(true)})
+		case "/repos/testowner/disabled":
+			writeJSONResponse(t, w, &github.Repository{DefaultBranch: github.String("main"), Disabled: github.Bool(true)})
+		default:
+			writeJSONResponse(t, w, &github.Repository{DefaultBranch: github.String("main")})
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewBranchResolver(&MockAppClient{Client: setupTestGitHubClient(t, server.URL)}, nil)
+
+	for repo, want := range map[string]bool{"archived": true, "disabled": true, "active": false} {
+		got, err := resolver.RepoArchived(ctx, "testowner", repo)
+		if err != nil {
+			t.Fatalf("RepoArchived(%s) error = %v", repo, err)
+		}
+		if got != want {
+			t.Errorf("RepoArchived(%s) = %v, want %v", repo, got, want)
+		}
+	}
+}
+
+func TestBranchResolver_ClientError(t *testing.T) {
+	resolver := NewBranchResolver(&MockAppClient{ClientError: errors.New("no installation")}, nil)
+
+	if _, err := resolver.DefaultBranch(context.Background(), "testowner", "testrepo"); err == nil {
+		t.Error("DefaultBranch() error = nil, want error")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ type AppClient struct {
 	tokens          map[int64]*tokenEntry // Installation token cache
 	installations   map[string]int64      // org -> installationID
 	appID           string
+	apiURL          string // GitHub API base URL override; empty uses api.github.com
 	tokensMu        sync.RWMutex
 	installationsMu sync.RWMutex
 }
@@ -64,15 +66,32 @@ func (c *AppClient) ClientForOrg(ctx context.Context, org string) (*github.Clien
 		return nil, fmt.Errorf("get installation ID: %w", err)
 	}
 
-	token, err := c.installationToken(ctx, installationID)
-	if err != nil {
+	// Fail fast if no token can be issued; later requests refresh it as needed
+	if _, err := c.installationToken(ctx, installationID); err != nil {
 		return nil, fmt.Errorf("get installation token: %w", err)
 	}
 
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(ctx, ts)
+	return c.newGitHubClient(c.installationHTTPClient(installationID)), nil
+}
+
+// installationHTTPClient returns an HTTP client that authenticates as an installation.
+// Clients are long-lived, so the token is looked up per request rather than fixed at creation.
+func (c *AppClient) installationHTTPClient(installationID int64) *http.Client {
+	return &http.Client{Transport: &installationTransport{
+		base:           http.DefaultTransport,
+		app:            c,
+		installationID: installationID,
+	}}
+}
 
-	return github.NewClient(tc), nil
+func (c *AppClient) newGitHubClient(httpClient *http.Client) *github.Client {
+	client := github.NewClient(httpClient)
+	if c.apiURL != "" {
+		if u, err := client.BaseURL.Parse(strings.TrimSuffix(c.apiURL, "/") + "/"); err == nil {
+			client.BaseURL = u
+		}
+	}
+	return client
 }
 
 func (c *AppClient) installationID(ctx context.Context, org string) (int64, error) {
@@ -113,6 +132,32 @@ func (c *AppClient) installationID(ctx context.Context, org string) (int64, erro
 	return 0, fmt.Errorf("no installation found for org: %s", org)
 }
 
+// invalidateToken drops a cached installation token so the next request fetches a new one.
+func (c *AppClient) invalidateToken(installationID int64) {
+	c.tokensMu.Lock()
+	delete(c.tokens, installationID)
+	c.tokensMu.Unlock()
+}
+
+// TokenExpiry returns when the org's cached installation token expires.
+// Tokens are refreshed tokenRefreshBuffer before this time.
+func (c *AppClient) TokenExpiry(org string) (time.Time, bool) {
+	c.installationsMu.RLock()
+	installationID, ok := c.installations[org]
+	c.installationsMu.RUnlock()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	c.tokensMu.RLock()
+	defer c.tokensMu.RUnlock()
+	entry, ok := c.tokens[installationID]
+	if !ok {
+		return time.Time{}, false
+	}
+	return entry.expiresAt, true
+}
+
 func (c *AppClient) installationToken(ctx context.Context, installationID int64) (string, error) {
 	// Check cache.
 	c.tokensMu.RLock()
@@ -148,7 +193,7 @@ func (c *AppClient) jwtClient(ctx context.Context) *github.Client {
 	token := c.generateJWT()
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	tc := oauth2.NewClient(ctx, ts)
-	return github.NewClient(tc)
+	return c.newGitHubClient(tc)
 }
 
 func (c *AppClient) generateJWT() string {
@@ -179,12 +224,59 @@ func (c *AppClient) TokenForOrg(ctx context.Context, org string) (string, error)
 }
 
 // HTTPClient returns an authenticated HTTP client for an organization.
+// The client keeps working across token rotations.
 func (c *AppClient) HTTPClient(ctx context.Context, org string) (*http.Client, error) {
-	token, err := c.TokenForOrg(ctx, org)
+	installationID, err := c.installationID(ctx, org)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := c.installationToken(ctx, installationID); err != nil {
+		return nil, err
+	}
+	return c.installationHTTPClient(installationID), nil
+}
 
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	return oauth2.NewClient(ctx, ts), nil
+// installationTransport authenticates requests with an installation token. The token
+// is refreshed before it expires, and once more if GitHub rejects it with a 401.
+type installationTransport struct {
+	base           http.RoundTripper
+	app            *AppClient
+	installationID int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *installationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// Only retry if the body can be replayed
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	t.app.logger.Warn("GitHub rejected installation token, refreshing",
+		"installation_id", t.installationID,
+		"url", req.URL.Redacted())
+	resp.Body.Close() //nolint:errcheck,gosec // discarding the rejected response
+	t.app.invalidateToken(t.installationID)
+	return t.send(req)
+}
+
+func (t *installationTransport) send(req *http.Request) (*http.Response, error) {
+	token, err := t.app.installationToken(req.Context(), t.installationID)
+	if err != nil {
+		return nil, fmt.Errorf("get installation token: %w", err)
+	}
+
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("replay request body: %w", err)
+		}
+		r.Body = body
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(r)
 }
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/codeGROOVE-dev/discordian/internal/bot"
+	"github.com/google/go-github/v50/github"
+)
+
+// recentCommentLimit bounds how many of a PR's newest comments are read per comment event.
+const recentCommentLimit = 20
+
+// CommentReader reads PR conversation comments through the GitHub App.
+type CommentReader struct {
+	appClient appClient
+	logger    *slog.Logger
+}
+
+// NewCommentReader creates a new PR comment reader.
+func NewCommentReader(appClient appClient, logger *slog.Logger) *CommentReader {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &CommentReader{appClient: appClient, logger: logger}
+}
+
+// RecentComments returns the PR's most recent conversation comments, oldest first.
+func (r *CommentReader) RecentComments(ctx context.Context, owner, repo string, number int) ([]bot.PRComment, error) {
+	client, err := r.appClient.ClientForOrg(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("get client for org: %w", err)
+	}
+
+	comments, _, err := client.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+		Sort:        github.String("created"),
+		Direction:   github.String("desc"),
+		ListOptions: github.ListOptions{PerPage: recentCommentLimit},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list comments: %w", err)
+	}
+
+	result := make([]bot.PRComment, 0, len(comments))
+	for _, c := range comments {
+		result = append(result, bot.PRComment{
+			CreatedAt:   c.GetCreatedAt().Time,
+			Author:      c.GetUser().GetLogin(),
+			Association: c.GetAuthorAssociation(),
+			Body:        c.GetBody(),
+		})
+	}
+	slices.Reverse(result)
+
+	r.logger.Debug("read PR comments", "owner", owner, "repo", repo, "number", number, "count", len(result))
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+func TestCommentReader_RecentComments(t *testing.T) {
+	older := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/testowner/testrepo/issues/7/comments" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("direction") != "desc" {
+			t.Errorf("direction = %q, want desc", r.URL.Query().Get("direction"))
+		}
+		writeJSONResponse(t, w, []*github.IssueComment{
+			{Body: github.String("/discordian mute"), User: &github.User{Login: github.String("bob")},
+				AuthorAssociation: github.String("MEMBER"), CreatedAt: &github.Timestamp{Time: newer}},
+			{Body: github.String("lgtm"), User: &github.User{Login: github.String("alice")},
+				AuthorAssociation: github.String("NONE"), CreatedAt: &github.Timestamp{Time: older}},
+		})
+	}))
+	defer server.Close()
+
+	reader := NewCommentReader(&MockAppClient{Client: setupTestGitHubClient(t, server.URL)}, nil)
+	comments, err := reader.RecentComments(context.Background(), "testowner", "testrepo", 7)
+	if err != nil {
+		t.Fatalf("RecentComments() error = %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("RecentComments() = %d comments, want 2", len(comments))
+	}
+	// Oldest first
+	if comments[0].Author != "alice" || comments[1].Author != "bob" {
+		t.Errorf("order = %s, %s; want alice, bob", comments[0].Author, comments[1].Author)
+	}
+	if comments[1].Association != "MEMBER" || comments[1].Body != "/discordian mute" || !comments[1].CreatedAt.Equal(newer) {
+		t.Errorf("comments[1] = %+v, want bob's mute directive", comments[1])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 )
@@ -158,3 +159,8 @@ func (c *OrgClient) Org() string {
 func (m *Manager) AppClient() *AppClient {
 	return m.appClient
 }
+
+// TokenExpiry returns when the org's cached installation token expires.
+func (m *Manager) TokenExpiry(org string) (time.Time, bool) {
+	return m.appClient.TokenExpiry(org)
+}
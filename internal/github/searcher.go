@@ -5,6 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/codeGROOVE-dev/discordian/internal/bot"
@@ -17,10 +22,25 @@ type appClient interface {
 	ClientForOrg(ctx context.Context, org string) (*github.Client, error)
 }
 
+// DefaultSearchCacheTTL is how long per-user search results are reused by default.
+// Short enough that reports stay current, long enough to absorb repeated commands.
+const DefaultSearchCacheTTL = time.Minute
+
+// maxCachedSearches bounds the search cache; the oldest entry is evicted when full.
+const maxCachedSearches = 512
+
+type searchCacheEntry struct {
+	fetchedAt time.Time
+	results   []bot.PRSearchResult
+}
+
 // Searcher queries GitHub for PRs using the search API.
 type Searcher struct {
 	appClient appClient
 	logger    *slog.Logger
+	cache     map[string]searchCacheEntry // query -> results
+	cacheTTL  time.Duration
+	mu        sync.Mutex
 }
 
 // NewSearcher creates a new PR searcher.
@@ -31,6 +51,18 @@ func NewSearcher(appClient appClient, logger *slog.Logger) *Searcher {
 	return &Searcher{
 		appClient: appClient,
 		logger:    logger,
+		cache:     make(map[string]searchCacheEntry),
+		cacheTTL:  DefaultSearchCacheTTL,
+	}
+}
+
+// SetCacheTTL sets how long per-user search results are reused. 0 disables caching.
+func (s *Searcher) SetCacheTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cacheTTL = ttl
+	if ttl <= 0 {
+		clear(s.cache)
 	}
 }
 
@@ -71,59 +103,130 @@ func (s *Searcher) ListClosedPRs(ctx context.Context, org string, closedWithinHo
 }
 
 // ListAuthoredPRs returns open PRs authored by a specific user.
+// Results are reused for the cache TTL.
 func (s *Searcher) ListAuthoredPRs(ctx context.Context, org, githubUsername string) ([]bot.PRSearchResult, error) {
+	query := fmt.Sprintf("is:pr is:open author:%s org:%s", githubUsername, org)
+	if results, ok := s.cached(query); ok {
+		return results, nil
+	}
+
 	client, err := s.appClient.ClientForOrg(ctx, org)
 	if err != nil {
 		return nil, fmt.Errorf("get client for org: %w", err)
 	}
 
-	query := fmt.Sprintf("is:pr is:open author:%s org:%s", githubUsername, org)
-
 	s.logger.Info("searching for authored PRs",
 		"github_user", githubUsername,
 		"org", org,
 		"query", query)
 
-	return s.searchPRs(ctx, client, query)
+	return s.searchPRsCached(ctx, client, query)
 }
 
 // ListReviewRequestedPRs returns open PRs where a specific user is requested to review.
+// Results are reused for the cache TTL.
 func (s *Searcher) ListReviewRequestedPRs(ctx context.Context, org, githubUsername string) ([]bot.PRSearchResult, error) {
+	query := fmt.Sprintf("is:pr is:open review-requested:%s org:%s", githubUsername, org)
+	if results, ok := s.cached(query); ok {
+		return results, nil
+	}
+
 	client, err := s.appClient.ClientForOrg(ctx, org)
 	if err != nil {
 		return nil, fmt.Errorf("get client for org: %w", err)
 	}
 
-	query := fmt.Sprintf("is:pr is:open review-requested:%s org:%s", githubUsername, org)
-
 	s.logger.Info("searching for review-requested PRs",
 		"github_user", githubUsername,
 		"org", org,
 		"query", query)
 
-	return s.searchPRs(ctx, client, query)
+	return s.searchPRsCached(ctx, client, query)
 }
 
-func (s *Searcher) searchPRs(ctx context.Context, client *github.Client, query string) ([]bot.PRSearchResult, error) {
-	opts := &github.SearchOptions{
-		Sort:  "updated",
-		Order: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
+// cached returns results for a query fetched within the cache TTL.
+func (s *Searcher) cached(query string) ([]bot.PRSearchResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[query]
+	if !ok || time.Since(entry.fetchedAt) >= s.cacheTTL {
+		return nil, false
 	}
+	s.logger.Debug("using cached PR search results", "query", query, "results", len(entry.results))
+	return slices.Clone(entry.results), true
+}
+
+// searchPRsCached runs a search and caches its results. Polling searches bypass the cache,
+// since they must see the latest PR state.
+func (s *Searcher) searchPRsCached(ctx context.Context, client *github.Client, query string) ([]bot.PRSearchResult, error) {
+	results, err := s.searchPRs(ctx, client, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cacheTTL <= 0 {
+		return results, nil
+	}
+	if _, ok := s.cache[query]; !ok && len(s.cache) >= maxCachedSearches {
+		oldest := ""
+		for q, entry := range s.cache {
+			if oldest == "" || entry.fetchedAt.Before(s.cache[oldest].fetchedAt) {
+				oldest = q
+			}
+		}
+		delete(s.cache, oldest)
+	}
+	s.cache[query] = searchCacheEntry{fetchedAt: time.Now(), results: slices.Clone(results)}
+	return results, nil
+}
 
+// searchIssue is an issue search result. go-github's Issue leaves out the draft flag that
+// search results carry for PRs.
+type searchIssue struct {
+	github.Issue
+
+	Draft bool `json:"draft"`
+}
+
+type issuesSearchResult struct {
+	Issues []searchIssue `json:"items"`
+}
+
+// searchIssuesPath returns the issue search API path for one page of a query's results,
+// most recently updated first.
+func searchIssuesPath(query string, page int) string {
+	params := url.Values{
+		"q":        {query},
+		"sort":     {"updated"},
+		"order":    {"desc"},
+		"per_page": {"100"},
+	}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	return "search/issues?" + params.Encode()
+}
+
+func (s *Searcher) searchPRs(ctx context.Context, client *github.Client, query string) ([]bot.PRSearchResult, error) {
 	var results []bot.PRSearchResult
+	page := 0
 
 	for {
-		var result *github.IssuesSearchResult
+		var result issuesSearchResult
 		var resp *github.Response
 
 		// GitHub Search API with retry logic
 		err := retry.Do(
 			func() error {
-				var err error
-				result, resp, err = client.Search.Issues(ctx, query, opts)
+				req, err := client.NewRequest(http.MethodGet, searchIssuesPath(query, page), nil)
+				if err != nil {
+					return err
+				}
+				result = issuesSearchResult{}
+				resp, err = client.Do(ctx, req, &result)
 				return err
 			},
 			retry.Context(ctx),
@@ -150,13 +253,14 @@ func (s *Searcher) searchPRs(ctx context.Context, client *github.Client, query s
 			return nil, fmt.Errorf("search issues: %w", err)
 		}
 
-		for _, issue := range result.Issues {
+		for i := range result.Issues {
+			issue := &result.Issues[i]
 			if issue.PullRequestLinks == nil {
 				continue // Not a PR
 			}
 
 			// Parse owner/repo from repository URL
-			owner, repo := parseRepoFromIssue(issue)
+			owner, repo := parseRepoFromIssue(&issue.Issue)
 			if owner == "" || repo == "" {
 				continue
 			}
@@ -167,6 +271,9 @@ func (s *Searcher) searchPRs(ctx context.Context, client *github.Client, query s
 				Repo:      repo,
 				Number:    issue.GetNumber(),
 				UpdatedAt: issue.GetUpdatedAt().Time,
+				Title:     issue.GetTitle(),
+				Author:    issue.GetUser().GetLogin(),
+				Draft:     issue.Draft,
 			}
 
 			// If HTML URL is empty, construct it
@@ -180,7 +287,7 @@ func (s *Searcher) searchPRs(ctx context.Context, client *github.Client, query s
 		if resp.NextPage == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
+		page = resp.NextPage
 	}
 
 	s.logger.Debug("PR search completed",
@@ -196,6 +196,38 @@ func TestSearchPRs(t *testing.T) {
 		}
 	})
 
+	t.Run("draft, title, and author", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("q"); got != "test query" {
+				t.Errorf("q = %q, want the query", got)
+			}
+			// go-github's Issue has no draft field, but search results carry it for PRs
+			writeJSONResponse(t, w, map[string]any{
+				"total_count": 1,
+				"items": []map[string]any{{
+					"number":         7,
+					"title":          "Early work",
+					"draft":          true,
+					"user":           map[string]any{"login": "alice"},
+					"repository_url": "https://api.github.com/repos/testowner/testrepo",
+					"pull_request":   map[string]any{"html_url": "https://github.com/testowner/testrepo/pull/7"},
+				}},
+			})
+		}))
+		defer server.Close()
+
+		client := setupTestGitHubClient(t, server.URL)
+
+		searcher := NewSearcher(&AppClient{}, nil)
+		results, err := searcher.searchPRs(ctx, client, "test query")
+		if err != nil {
+			t.Fatalf("searchPRs() error = %v, want nil", err)
+		}
+		if len(results) != 1 || !results[0].Draft || results[0].Title != "Early work" || results[0].Author != "alice" {
+			t.Errorf("searchPRs() = %+v, want the draft PR's title and author", results)
+		}
+	})
+
 	t.Run("search with no pull requests", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			response := &github.IssuesSearchResult{
@@ -537,3 +569,60 @@ func TestListReviewRequestedPRs(t *testing.T) {
 		}
 	})
 }
+
+func TestSearcher_CachesUserSearches(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSONResponse(t, w, &github.IssuesSearchResult{
+			Total:  github.Int(1),
+			Issues: []*github.Issue{NewMockPRIssue("testowner", "testrepo", 123, "Test PR")},
+		})
+	}))
+	defer server.Close()
+
+	searcher := NewSearcher(&MockAppClient{Client: setupTestGitHubClient(t, server.URL)}, nil)
+
+	for range 2 {
+		results, err := searcher.ListAuthoredPRs(ctx, "test-org", "alice")
+		if err != nil {
+			t.Fatalf("ListAuthoredPRs() error = %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("ListAuthoredPRs() returned %d results, want 1", len(results))
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (second search cached)", calls)
+	}
+
+	// A different query isn't served from the cache
+	if _, err := searcher.ListAuthoredPRs(ctx, "test-org", "bob"); err != nil {
+		t.Fatalf("ListAuthoredPRs() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 after a different query", calls)
+	}
+
+	// Polling searches always go to the API
+	for range 2 {
+		if _, err := searcher.ListOpenPRs(ctx, "test-org", 24); err != nil {
+			t.Fatalf("ListOpenPRs() error = %v", err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("API calls = %d, want 4 after uncached polling searches", calls)
+	}
+
+	// A zero TTL disables caching
+	searcher.SetCacheTTL(0)
+	for range 2 {
+		if _, err := searcher.ListAuthoredPRs(ctx, "test-org", "alice"); err != nil {
+			t.Fatalf("ListAuthoredPRs() error = %v", err)
+		}
+	}
+	if calls != 6 {
+		t.Errorf("API calls = %d, want 6 with caching disabled", calls)
+	}
+}
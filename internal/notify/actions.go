@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SnoozeDM holds off DMs to a user about a PR for d, pushing back any that are
+// already queued. Triggered by the Snooze quick action in a DM footer.
+func (m *Manager) SnoozeDM(ctx context.Context, userID, prURL string, d time.Duration) error {
+	until := time.Now().Add(d)
+
+	// Only DMs due before the snooze ends need moving
+	dms, err := m.store.PendingDMs(ctx, until)
+	if err != nil {
+		return fmt.Errorf("list pending DMs: %w", err)
+	}
+	for _, dm := range dms {
+		if dm.UserID != userID || dm.PRURL != prURL {
+			continue
+		}
+		dm.SendAt = until
+		if err := m.store.QueuePendingDM(ctx, dm); err != nil {
+			return fmt.Errorf("reschedule pending DM: %w", err)
+		}
+	}
+
+	info, _ := m.store.DMInfo(ctx, userID, prURL)
+	info.SnoozedUntil = until
+	if err := m.store.SaveDMInfo(ctx, userID, prURL, info); err != nil {
+		return fmt.Errorf("save DM info: %w", err)
+	}
+
+	m.logger.Info("snoozed DMs for PR", "user_id", userID, "pr_url", prURL, "until", until)
+	return nil
+}
+
+// MuteDM stops DMs to a user about a PR, dropping any that are already queued.
+// Triggered by the Mute quick action in a DM footer.
+func (m *Manager) MuteDM(ctx context.Context, userID, prURL string) error {
+	dms, err := m.store.PendingDMs(ctx, time.Now().Add(dmTTL))
+	if err != nil {
+		return fmt.Errorf("list pending DMs: %w", err)
+	}
+	for _, dm := range dms {
+		if dm.UserID != userID || dm.PRURL != prURL {
+			continue
+		}
+		if err := m.store.RemovePendingDM(ctx, dm.ID); err != nil {
+			return fmt.Errorf("remove pending DM: %w", err)
+		}
+	}
+
+	info, _ := m.store.DMInfo(ctx, userID, prURL)
+	info.Muted = true
+	if err := m.store.SaveDMInfo(ctx, userID, prURL, info); err != nil {
+		return fmt.Errorf("save DM info: %w", err)
+	}
+
+	m.logger.Info("muted DMs for PR", "user_id", userID, "pr_url", prURL)
+	return nil
+}
+
+// DropUserDMs drops all DMs queued for a user in a guild and returns how many were dropped.
+// Called when the user leaves the guild, since their DMs can no longer be delivered.
+func (m *Manager) DropUserDMs(ctx context.Context, guildID, userID string) (int, error) {
+	dms, err := m.store.PendingDMs(ctx, time.Now().Add(dmTTL))
+	if err != nil {
+		return 0, fmt.Errorf("list pending DMs: %w", err)
+	}
+	dropped := 0
+	for _, dm := range dms {
+		if dm.UserID != userID || dm.GuildID != guildID {
+			continue
+		}
+		if err := m.store.RemovePendingDM(ctx, dm.ID); err != nil {
+			return dropped, fmt.Errorf("remove pending DM: %w", err)
+		}
+		dropped++
+	}
+
+	if dropped > 0 {
+		m.logger.Info("dropped pending DMs for user who left the guild",
+			"user_id", userID,
+			"guild_id", guildID,
+			"dropped", dropped)
+	}
+	return dropped, nil
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// SetBurstWindow collapses bursts of DMs, such as from a user being added as a reviewer to
+// several PRs at once: once more than one of a user's DMs came due within the window, their
+// DMs are held until the window has passed since the first, then sent as one message. Unlike
+// digest mode, DMs that don't arrive in a burst are sent as usual. 0 disables it.
+func (m *Manager) SetBurstWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.burstWindow = window
+}
+
+// holdBursts sets aside the due DMs of users in a DM burst. It returns the DMs to send as
+// usual, and those of bursts that are over, to be sent as one message per user.
+func (m *Manager) holdBursts(dms []*state.PendingDM, now time.Time) (ready, settled []*state.PendingDM) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := m.burstWindow
+	if window <= 0 {
+		return dms, nil
+	}
+
+	byUser := make(map[string][]*state.PendingDM)
+	var users []string
+	for _, dm := range dms {
+		if _, seen := byUser[dm.UserID]; !seen {
+			users = append(users, dm.UserID)
+		}
+		byUser[dm.UserID] = append(byUser[dm.UserID], dm)
+	}
+
+	for _, userID := range users {
+		userDMs := byUser[userID]
+		start, inBurst := m.bursts[userID]
+		if !inBurst {
+			// A burst starts with the first of the user's DMs to come due within the window.
+			// Delayed DMs were queued long before they're due, so queue time says nothing.
+			var recent int
+			for _, dm := range userDMs {
+				due := dueAt(dm)
+				if now.Sub(due) >= window {
+					continue
+				}
+				if recent == 0 || due.Before(start) {
+					start = due
+				}
+				recent++
+			}
+			if recent < 2 {
+				ready = append(ready, userDMs...)
+				continue
+			}
+			m.bursts[userID] = start
+			m.logger.Info("DM burst, holding DMs to send together",
+				"user_id", userID,
+				"count", len(userDMs),
+				"until", start.Add(window))
+		}
+		if now.Sub(start) < window {
+			continue
+		}
+		delete(m.bursts, userID)
+		settled = append(settled, userDMs...)
+	}
+
+	// Bursts whose DMs were all sent or dropped some other way
+	for userID, start := range m.bursts {
+		if _, due := byUser[userID]; !due && now.Sub(start) >= window {
+			delete(m.bursts, userID)
+		}
+	}
+	return ready, settled
+}
+
+// dueAt returns when a pending DM came due: its SendAt, or when it was queued if it was to be
+// sent right away.
+func dueAt(dm *state.PendingDM) time.Time {
+	if dm.SendAt.IsZero() {
+		return dm.CreatedAt
+	}
+	return dm.SendAt
+}
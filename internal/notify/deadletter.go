@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// Reasons a pending DM, or an event, is dead-lettered.
+const (
+	ReasonExpired        = "expired"
+	ReasonMaxRetries     = "max_retries"
+	ReasonUnparseableURL = "unparseable_url" // An event whose URL isn't a GitHub PR URL
+)
+
+// DeadLetter records a DM that was given up on. Events dead-lettered for investigation
+// leave the DM fields empty.
+type DeadLetter struct {
+	FailedAt    time.Time `json:"failed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	GuildID     string    `json:"guild_id"`
+	Org         string    `json:"org"`
+	PRURL       string    `json:"pr_url"`
+	MessageText string    `json:"message_text"`
+	Reason      string    `json:"reason"`
+	RetryCount  int       `json:"retry_count"`
+}
+
+func newDeadLetter(dm *state.PendingDM, reason string) DeadLetter {
+	return DeadLetter{
+		FailedAt:    time.Now(),
+		CreatedAt:   dm.CreatedAt,
+		ID:          dm.ID,
+		UserID:      dm.UserID,
+		GuildID:     dm.GuildID,
+		Org:         dm.Org,
+		PRURL:       dm.PRURL,
+		MessageText: dm.MessageText,
+		Reason:      reason,
+		RetryCount:  dm.RetryCount,
+	}
+}
+
+// DeadLetterSink receives DMs that expired or exhausted their retries,
+// so operators can follow up on notifications that were never delivered.
+type DeadLetterSink interface {
+	DeadLetter(ctx context.Context, dl DeadLetter)
+}
+
+// LogDeadLetterSink logs dead-lettered DMs. It is the default sink.
+type LogDeadLetterSink struct {
+	logger *slog.Logger
+}
+
+// NewLogDeadLetterSink creates a sink that logs dead-lettered DMs.
+func NewLogDeadLetterSink(logger *slog.Logger) *LogDeadLetterSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogDeadLetterSink{logger: logger}
+}
+
+// DeadLetter logs the failed DM or event.
+func (s *LogDeadLetterSink) DeadLetter(_ context.Context, dl DeadLetter) {
+	msg := "dead-lettered pending DM"
+	if dl.Reason == ReasonUnparseableURL {
+		msg = "dead-lettered event"
+	}
+	s.logger.Error(msg,
+		"reason", dl.Reason,
+		"id", dl.ID,
+		"org", dl.Org,
+		"user_id", dl.UserID,
+		"guild_id", dl.GuildID,
+		"pr_url", dl.PRURL,
+		"created_at", dl.CreatedAt,
+		"retry_count", dl.RetryCount)
+}
+
+// FileDeadLetterSink appends dead-lettered DMs to a file as JSON lines.
+// Records are also logged, so a write failure never loses them entirely.
+type FileDeadLetterSink struct {
+	log  *LogDeadLetterSink
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileDeadLetterSink creates a sink that appends to the file at path.
+func NewFileDeadLetterSink(path string, logger *slog.Logger) *FileDeadLetterSink {
+	return &FileDeadLetterSink{path: path, log: NewLogDeadLetterSink(logger)}
+}
+
+// DeadLetter logs the failed DM and appends it to the file.
+func (s *FileDeadLetterSink) DeadLetter(ctx context.Context, dl DeadLetter) {
+	s.log.DeadLetter(ctx, dl)
+	if err := s.append(dl); err != nil {
+		s.log.logger.Warn("failed to write dead letter", "error", err, "path", s.path, "id", dl.ID)
+	}
+}
+
+func (s *FileDeadLetterSink) append(dl DeadLetter) error {
+	line, err := json.Marshal(dl)
+	if err != nil {
+		return fmt.Errorf("marshal dead letter: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open dead letter file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close() //nolint:errcheck // already returning the write error
+		return fmt.Errorf("write dead letter: %w", err)
+	}
+	return f.Close()
+}
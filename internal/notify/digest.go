@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
+	"github.com/codeGROOVE-dev/discordian/internal/format"
+	"github.com/codeGROOVE-dev/discordian/internal/state"
+)
+
+// sendDigests sends one combined DM to each user with more than one DM due,
+// grouped into a section per org. Returns the DMs left to send individually.
+func (m *Manager) sendDigests(ctx context.Context, dms []*state.PendingDM, now time.Time) []*state.PendingDM {
+	byUser := make(map[string][]*state.PendingDM)
+	var users []string
+	for _, dm := range dms {
+		if _, seen := byUser[dm.UserID]; !seen {
+			users = append(users, dm.UserID)
+		}
+		byUser[dm.UserID] = append(byUser[dm.UserID], dm)
+	}
+
+	var single []*state.PendingDM
+	for _, userID := range users {
+		userDMs := byUser[userID]
+		if len(userDMs) == 1 {
+			single = append(single, userDMs[0])
+			continue
+		}
+
+		sent, err := m.sendDigest(ctx, userID, userDMs)
+		if errors.Is(err, discord.ErrDMBlocked) {
+			// Sent one by one, so each goes through the DM fallback
+			single = append(single, userDMs...)
+			continue
+		}
+		if err != nil {
+			m.logger.Error("failed to send DM digest",
+				"error", err,
+				"user_id", userID,
+				"count", len(userDMs))
+			for _, dm := range userDMs {
+				m.scheduleRetry(ctx, dm, now)
+			}
+			continue
+		}
+		if !sent {
+			continue // Rate limited; the DMs stay queued for the next cycle
+		}
+		for _, dm := range userDMs {
+			if err := m.store.RemovePendingDM(ctx, dm.ID); err != nil {
+				m.logger.Warn("failed to remove pending DM", "error", err, "id", dm.ID)
+			}
+		}
+	}
+	return single
+}
+
+// sendDigest sends a user's due DMs as a single message. Returns false without
+// error if the user was DM'd too recently or no guild can reach them.
+func (m *Manager) sendDigest(ctx context.Context, userID string, dms []*state.PendingDM) (bool, error) {
+	lastTime := m.lastDM(ctx, userID)
+	m.mu.RLock()
+	var sender DiscordDMSender
+	for _, dm := range dms {
+		if sender = m.dmSenders[dm.GuildID]; sender != nil {
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if time.Since(lastTime) < minDMInterval {
+		m.logger.Debug("rate limiting DM digest", "user_id", userID, "last_dm", lastTime)
+		return false, nil
+	}
+	if sender == nil {
+		m.logger.Warn("no sender for any guild in DM digest", "user_id", userID)
+		return false, nil
+	}
+
+	text := format.DMDigest(digestSections(dms))
+	channelID, _, err := sender.SendDM(ctx, userID, text)
+	if err != nil {
+		return false, err
+	}
+
+	sentAt := time.Now()
+	m.noteDM(ctx, userID, sentAt)
+
+	// No message ID is saved: editing the digest for one PR's update would drop the others.
+	// The per-PR text still lets the coordinator skip re-sending an unchanged notification.
+	for _, dm := range dms {
+		m.recordLatency(dm, sentAt)
+		stored, _ := m.store.DMInfo(ctx, userID, dm.PRURL)
+		info := state.NewDMInfo(stored, channelID, "", dm.MessageText, "")
+		if err := m.store.SaveDMInfo(ctx, userID, dm.PRURL, info); err != nil {
+			m.logger.Warn("failed to save DM info", "error", err)
+		}
+	}
+
+	m.logger.Info("sent DM digest", "user_id", userID, "count", len(dms))
+	return true, nil
+}
+
+// digestSections groups DMs into one section per org, in org order.
+// Each section lists the most urgent actions first, then keeps queue order.
+func digestSections(dms []*state.PendingDM) []format.DMDigestSection {
+	sorted := slices.Clone(dms)
+	slices.SortStableFunc(sorted, func(a, b *state.PendingDM) int {
+		return cmp.Compare(format.ActionPriority(a.ActionKind), format.ActionPriority(b.ActionKind))
+	})
+
+	byOrg := make(map[string][]string)
+	for _, dm := range sorted {
+		byOrg[dm.Org] = append(byOrg[dm.Org], dm.MessageText)
+	}
+
+	orgs := make([]string, 0, len(byOrg))
+	for org := range byOrg {
+		orgs = append(orgs, org)
+	}
+	slices.Sort(orgs)
+
+	sections := make([]format.DMDigestSection, 0, len(orgs))
+	for _, org := range orgs {
+		sections = append(sections, format.DMDigestSection{Org: org, Items: byOrg[org]})
+	}
+	return sections
+}
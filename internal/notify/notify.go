@@ -3,10 +3,12 @@ package notify
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
 	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
@@ -24,15 +26,32 @@ type DiscordDMSender interface {
 	SendDM(ctx context.Context, userID, text string) (channelID, messageID string, err error)
 }
 
+// DMFallback reaches a user who doesn't accept DMs from the bot some other way,
+// such as a mention in the org's dm_fallback_channel.
+type DMFallback interface {
+	DMFallback(ctx context.Context, org, userID, text string) error
+}
+
+// LatencyRecorder records how long after the PR event that queued it each DM went out.
+type LatencyRecorder interface {
+	RecordDMLatency(org string, latency time.Duration)
+}
+
 // Manager handles pending DM notifications.
 type Manager struct {
-	store      state.Store
-	logger     *slog.Logger
-	dmSenders  map[string]DiscordDMSender // guildID -> sender
-	lastDMTime map[string]time.Time       // userID -> last DM time
-	stopCh     chan struct{}
-	mu         sync.RWMutex
-	wg         sync.WaitGroup
+	store       state.Store
+	logger      *slog.Logger
+	deadLetter  DeadLetterSink
+	fallback    DMFallback
+	latency     LatencyRecorder
+	dmSenders   map[string]DiscordDMSender // guildID -> sender
+	lastDMTime  map[string]time.Time       // userID -> last DM time sent by this instance
+	bursts      map[string]time.Time       // userID -> when their DM burst started
+	stopCh      chan struct{}
+	mu          sync.RWMutex
+	wg          sync.WaitGroup
+	digest      bool          // Combine a user's due DMs into one message
+	burstWindow time.Duration // Collapse a user's DMs queued within this window into one message
 }
 
 // New creates a new notification manager.
@@ -43,9 +62,11 @@ func New(store state.Store, logger *slog.Logger) *Manager {
 
 	return &Manager{
 		store:      store,
+		deadLetter: NewLogDeadLetterSink(logger),
 		dmSenders:  make(map[string]DiscordDMSender),
 		logger:     logger,
 		lastDMTime: make(map[string]time.Time),
+		bursts:     make(map[string]time.Time),
 		stopCh:     make(chan struct{}),
 	}
 }
@@ -57,6 +78,55 @@ func (m *Manager) RegisterGuild(guildID string, sender DiscordDMSender) {
 	m.dmSenders[guildID] = sender
 }
 
+// SetDeadLetterSink sets where expired and undeliverable DMs are recorded.
+// Defaults to logging them.
+func (m *Manager) SetDeadLetterSink(sink DeadLetterSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadLetter = sink
+}
+
+// DeadLetterSink returns where expired and undeliverable DMs are recorded, for sharing with
+// other dead letters.
+func (m *Manager) DeadLetterSink() DeadLetterSink {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.deadLetter
+}
+
+// SetDMFallback sets how users who don't accept DMs from the bot are reached instead.
+// Without one, their DMs are retried and eventually dead-lettered.
+func (m *Manager) SetDMFallback(fallback DMFallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallback = fallback
+}
+
+// SetLatencyRecorder sets where the latency of sent DMs is recorded. Without one, it isn't.
+func (m *Manager) SetLatencyRecorder(recorder LatencyRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latency = recorder
+}
+
+// recordLatency records how long after its event a DM went out, if the event's time is known.
+func (m *Manager) recordLatency(dm *state.PendingDM, sentAt time.Time) {
+	m.mu.RLock()
+	recorder := m.latency
+	m.mu.RUnlock()
+	if recorder != nil && !dm.EventAt.IsZero() {
+		recorder.RecordDMLatency(dm.Org, sentAt.Sub(dm.EventAt))
+	}
+}
+
+// SetDigestMode combines DMs due for the same user in one cycle into a single
+// message with a section per org, instead of sending each separately.
+func (m *Manager) SetDigestMode(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.digest = enabled
+}
+
 // Start begins the notification processing loop.
 func (m *Manager) Start(ctx context.Context) {
 	m.wg.Go(func() {
@@ -86,6 +156,16 @@ func (m *Manager) run(ctx context.Context) {
 	}
 }
 
+// sendToDeadLetter hands a DM that is being given up on to the dead-letter sink.
+func (m *Manager) sendToDeadLetter(ctx context.Context, dm *state.PendingDM, reason string) {
+	m.mu.RLock()
+	sink := m.deadLetter
+	m.mu.RUnlock()
+	if sink != nil {
+		sink.DeadLetter(ctx, newDeadLetter(dm, reason))
+	}
+}
+
 func (m *Manager) processPendingDMs(ctx context.Context) {
 	// Get DMs ready to send
 	dms, err := m.store.PendingDMs(ctx, time.Now())
@@ -101,6 +181,7 @@ func (m *Manager) processPendingDMs(ctx context.Context) {
 	m.logger.Debug("processing pending DMs", "count", len(dms))
 
 	now := time.Now()
+	ready := make([]*state.PendingDM, 0, len(dms))
 	for _, dm := range dms {
 		// Check if DM has expired
 		if !dm.ExpiresAt.IsZero() && now.After(dm.ExpiresAt) {
@@ -109,6 +190,7 @@ func (m *Manager) processPendingDMs(ctx context.Context) {
 				"pr_url", dm.PRURL,
 				"created_at", dm.CreatedAt,
 				"expired_at", dm.ExpiresAt)
+			m.sendToDeadLetter(ctx, dm, ReasonExpired)
 			if err := m.store.RemovePendingDM(ctx, dm.ID); err != nil {
 				m.logger.Warn("failed to remove expired DM", "error", err, "id", dm.ID)
 			}
@@ -122,39 +204,40 @@ func (m *Manager) processPendingDMs(ctx context.Context) {
 				"pr_url", dm.PRURL,
 				"retry_count", dm.RetryCount,
 				"max_retries", maxRetries)
+			m.sendToDeadLetter(ctx, dm, ReasonMaxRetries)
 			if err := m.store.RemovePendingDM(ctx, dm.ID); err != nil {
 				m.logger.Warn("failed to remove failed DM", "error", err, "id", dm.ID)
 			}
 			continue
 		}
 
+		// Held until the org's maintenance mode ends; expiry still applies
+		if info, ok := m.store.Maintenance(ctx, dm.Org); ok && info.On {
+			continue
+		}
+
+		ready = append(ready, dm)
+	}
+
+	ready, settled := m.holdBursts(ready, now)
+
+	m.mu.RLock()
+	digest := m.digest
+	m.mu.RUnlock()
+	if digest {
+		ready = m.sendDigests(ctx, append(ready, settled...), now)
+	} else if len(settled) > 0 {
+		ready = append(ready, m.sendDigests(ctx, settled, now)...)
+	}
+
+	for _, dm := range ready {
 		if err := m.sendDM(ctx, dm); err != nil {
 			m.logger.Error("failed to send DM",
 				"error", err,
 				"user_id", dm.UserID,
 				"pr_url", dm.PRURL,
 				"retry_count", dm.RetryCount)
-
-			// Increment retry count and schedule next retry with exponential backoff
-			dm.RetryCount++
-			// Safe exponential backoff: cap the exponent to prevent overflow
-			// Max exponent is 10 (2^10 = 1024 minutes = ~17 hours)
-			exponent := min(dm.RetryCount, 10)
-			retryDelay := baseRetryDelay * time.Duration(1<<exponent) // Exponential backoff: 2min, 4min, 8min, 16min, ...
-			retryDelay = min(retryDelay, 60*time.Minute)              // Cap at 1 hour
-			dm.SendAt = now.Add(retryDelay)
-
-			// Update the pending DM with new retry info
-			if err := m.store.QueuePendingDM(ctx, dm); err != nil {
-				m.logger.Error("failed to update pending DM retry info", "error", err, "id", dm.ID)
-			} else {
-				m.logger.Info("scheduled DM retry with exponential backoff",
-					"user_id", dm.UserID,
-					"pr_url", dm.PRURL,
-					"retry_count", dm.RetryCount,
-					"next_attempt", dm.SendAt,
-					"delay", retryDelay)
-			}
+			m.scheduleRetry(ctx, dm, now)
 			continue
 		}
 
@@ -165,12 +248,56 @@ func (m *Manager) processPendingDMs(ctx context.Context) {
 	}
 }
 
-func (m *Manager) sendDM(ctx context.Context, dm *state.PendingDM) error {
-	// Check rate limit
+// scheduleRetry requeues a DM that failed to send, with exponential backoff.
+func (m *Manager) scheduleRetry(ctx context.Context, dm *state.PendingDM, now time.Time) {
+	// Increment retry count and schedule next retry with exponential backoff
+	dm.RetryCount++
+	// Safe exponential backoff: cap the exponent to prevent overflow
+	// Max exponent is 10 (2^10 = 1024 minutes = ~17 hours)
+	exponent := min(dm.RetryCount, 10)
+	retryDelay := baseRetryDelay * time.Duration(1<<exponent) // Exponential backoff: 2min, 4min, 8min, 16min, ...
+	retryDelay = min(retryDelay, 60*time.Minute)              // Cap at 1 hour
+	dm.SendAt = now.Add(retryDelay)
+
+	// Update the pending DM with new retry info
+	if err := m.store.QueuePendingDM(ctx, dm); err != nil {
+		m.logger.Error("failed to update pending DM retry info", "error", err, "id", dm.ID)
+		return
+	}
+	m.logger.Info("scheduled DM retry with exponential backoff",
+		"user_id", dm.UserID,
+		"pr_url", dm.PRURL,
+		"retry_count", dm.RetryCount,
+		"next_attempt", dm.SendAt,
+		"delay", retryDelay)
+}
+
+// lastDM returns when a user was last DMed, by this instance or, as recorded in the store,
+// another one or this one before a restart.
+func (m *Manager) lastDM(ctx context.Context, userID string) time.Time {
 	m.mu.RLock()
-	lastTime := m.lastDMTime[dm.UserID]
+	lastTime := m.lastDMTime[userID]
 	m.mu.RUnlock()
 
+	if stored, ok := m.store.LastDM(ctx, userID); ok && stored.After(lastTime) {
+		return stored
+	}
+	return lastTime
+}
+
+// noteDM records that a user was just DMed, for rate limiting their next DM.
+func (m *Manager) noteDM(ctx context.Context, userID string, sentAt time.Time) {
+	m.mu.Lock()
+	m.lastDMTime[userID] = sentAt
+	m.mu.Unlock()
+	if err := m.store.SaveLastDM(ctx, userID, sentAt); err != nil {
+		m.logger.Warn("failed to save last DM time", "error", err, "user_id", userID)
+	}
+}
+
+func (m *Manager) sendDM(ctx context.Context, dm *state.PendingDM) error {
+	// Check rate limit
+	lastTime := m.lastDM(ctx, dm.UserID)
 	if time.Since(lastTime) < minDMInterval {
 		m.logger.Debug("rate limiting DM",
 			"user_id", dm.UserID,
@@ -190,22 +317,21 @@ func (m *Manager) sendDM(ctx context.Context, dm *state.PendingDM) error {
 
 	// Send DM
 	channelID, messageID, err := sender.SendDM(ctx, dm.UserID, dm.MessageText)
+	if errors.Is(err, discord.ErrDMBlocked) {
+		return m.sendFallback(ctx, dm, err)
+	}
 	if err != nil {
 		return err
 	}
 
 	// Update rate limit tracker
-	m.mu.Lock()
-	m.lastDMTime[dm.UserID] = time.Now()
-	m.mu.Unlock()
+	sentAt := time.Now()
+	m.noteDM(ctx, dm.UserID, sentAt)
+	m.recordLatency(dm, sentAt)
 
 	// Save DM info for potential updates
-	dmInfo := state.DMInfo{
-		ChannelID:   channelID,
-		MessageID:   messageID,
-		MessageText: dm.MessageText,
-		SentAt:      time.Now(),
-	}
+	stored, _ := m.store.DMInfo(ctx, dm.UserID, dm.PRURL)
+	dmInfo := state.NewDMInfo(stored, channelID, messageID, dm.MessageText, "")
 	if err := m.store.SaveDMInfo(ctx, dm.UserID, dm.PRURL, dmInfo); err != nil {
 		m.logger.Warn("failed to save DM info", "error", err)
 	}
@@ -271,3 +397,30 @@ func (t *Tracker) MarkDMSent(userID, prURL string) {
 	key := userID + ":" + prURL
 	t.lastDMTime[key] = time.Now()
 }
+
+// sendFallback reaches a user who doesn't accept DMs through the DM fallback, if one is set.
+// Like a digest, no message ID is saved, as there's no DM to edit later. The DM rate limit
+// isn't applied, so the DMs of a digest that couldn't be sent each go out in the same cycle.
+func (m *Manager) sendFallback(ctx context.Context, dm *state.PendingDM, dmErr error) error {
+	m.mu.RLock()
+	fallback := m.fallback
+	m.mu.RUnlock()
+
+	if fallback == nil {
+		return dmErr
+	}
+	if err := fallback.DMFallback(ctx, dm.Org, dm.UserID, dm.MessageText); err != nil {
+		return errors.Join(dmErr, err)
+	}
+
+	stored, _ := m.store.DMInfo(ctx, dm.UserID, dm.PRURL)
+	dmInfo := state.NewDMInfo(stored, "", "", dm.MessageText, "")
+	if err := m.store.SaveDMInfo(ctx, dm.UserID, dm.PRURL, dmInfo); err != nil {
+		m.logger.Warn("failed to save DM info", "error", err)
+	}
+
+	m.logger.Info("user doesn't accept DMs, sent notification through fallback",
+		"user_id", dm.UserID,
+		"pr_url", dm.PRURL)
+	return nil
+}
@@ -2,10 +2,18 @@ package notify
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/discordian/internal/discord"
 	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
@@ -17,11 +25,15 @@ type mockStore struct {
 	removeErr   error
 	saveDMErr   error
 	pendingErr  error
+	maintenance map[string]state.MaintenanceInfo
+	lastDMs     map[string]time.Time
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
 		savedDMInfo: make(map[string]state.DMInfo),
+		maintenance: make(map[string]state.MaintenanceInfo),
+		lastDMs:     make(map[string]time.Time),
 	}
 }
 
@@ -33,10 +45,38 @@ func (m *mockStore) SaveThread(_ context.Context, _, _ string, _ int, _ string,
 	return nil
 }
 
+func (m *mockStore) ListThreads(_ context.Context, _ string) []state.TrackedThread {
+	return nil
+}
+
+func (m *mockStore) SetThreadMessageIDs(_ context.Context, _, _ string, _ int, _ string, _ []string) error {
+	return nil
+}
+
+func (m *mockStore) MessagesForPR(_ context.Context, _ string) []state.MessageRef {
+	return nil
+}
+
+func (m *mockStore) ThreadForMessage(_ context.Context, _, _ string) (state.TrackedThread, bool) {
+	return state.TrackedThread{}, false
+}
+
+func (m *mockStore) RemoveThread(_ context.Context, _, _ string, _ int, _ string) error {
+	return nil
+}
+
 func (m *mockStore) ClaimThread(_ context.Context, _, _ string, _ int, _ string, _ time.Duration) bool {
 	return true // Always succeed in tests
 }
 
+func (m *mockStore) RenewThreadClaim(_ context.Context, _, _ string, _ int, _ string, _ time.Duration) bool {
+	return true
+}
+
+func (m *mockStore) ReleaseThreadClaim(_ context.Context, _, _ string, _ int, _ string) error {
+	return nil
+}
+
 func (m *mockStore) DMInfo(_ context.Context, userID, prURL string) (state.DMInfo, bool) {
 	key := userID + ":" + prURL
 	info, ok := m.savedDMInfo[key]
@@ -56,10 +96,22 @@ func (m *mockStore) ClaimDM(_ context.Context, _, _ string, _ time.Duration) boo
 	return true // Always succeed in tests
 }
 
+func (m *mockStore) ReleaseDMClaim(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) ClaimConfigReload(_ context.Context, _ string, _ time.Duration) bool {
+	return true
+}
+
 func (m *mockStore) ListDMUsers(_ context.Context, _ string) []string {
 	return nil
 }
 
+func (m *mockStore) ListPRsForUser(_ context.Context, _ string) []state.TrackedDM {
+	return nil
+}
+
 func (m *mockStore) WasProcessed(_ context.Context, _ string) bool {
 	return false
 }
@@ -68,6 +120,14 @@ func (m *mockStore) MarkProcessed(_ context.Context, _ string, _ time.Duration)
 	return nil
 }
 
+func (m *mockStore) ClaimEvent(_ context.Context, _ string, _ time.Duration) bool {
+	return true
+}
+
+func (m *mockStore) ClearProcessed(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
 func (m *mockStore) QueuePendingDM(_ context.Context, dm *state.PendingDM) error {
 	m.pendingDMs = append(m.pendingDMs, dm)
 	return nil
@@ -110,6 +170,102 @@ func (m *mockStore) SaveDailyReportInfo(_ context.Context, _ string, _ state.Dai
 	return nil
 }
 
+func (m *mockStore) Digest(_ context.Context, _ string) (state.DigestInfo, bool) {
+	return state.DigestInfo{}, false
+}
+
+func (m *mockStore) SaveDigest(_ context.Context, _ string, _ state.DigestInfo) error {
+	return nil
+}
+
+func (m *mockStore) UpdateDigest(_ context.Context, _ string, _ func(*state.DigestInfo) bool) error {
+	return nil
+}
+
+func (m *mockStore) UpdateBoard(_ context.Context, _ string, _ func(*state.BoardInfo) bool) error {
+	return nil
+}
+
+func (m *mockStore) UpdatePostWindows(_ context.Context, _ string, _ func(*state.PostWindowInfo) bool) error {
+	return nil
+}
+
+func (m *mockStore) Board(_ context.Context, _ string) (state.BoardInfo, bool) {
+	return state.BoardInfo{}, false
+}
+
+func (m *mockStore) SaveBoard(_ context.Context, _ string, _ state.BoardInfo) error {
+	return nil
+}
+
+func (m *mockStore) PROverride(_ context.Context, _ string) (state.PROverride, bool) {
+	return state.PROverride{}, false
+}
+
+func (m *mockStore) Maintenance(_ context.Context, org string) (state.MaintenanceInfo, bool) {
+	info, ok := m.maintenance[org]
+	return info, ok
+}
+
+func (m *mockStore) SaveMaintenance(_ context.Context, org string, info state.MaintenanceInfo) error {
+	m.maintenance[org] = info
+	return nil
+}
+
+func (m *mockStore) OOO(_ context.Context, _ string) (state.OOOInfo, bool) {
+	return state.OOOInfo{}, false
+}
+
+func (m *mockStore) SaveOOO(_ context.Context, _ string, _ state.OOOInfo) error {
+	return nil
+}
+
+func (m *mockStore) RepoRedirect(_ context.Context, _, _ string) (state.RepoRedirect, bool) {
+	return state.RepoRedirect{}, false
+}
+
+func (m *mockStore) SaveRepoRedirect(_ context.Context, _, _ string, _ state.RepoRedirect) error {
+	return nil
+}
+
+func (m *mockStore) LastDM(_ context.Context, userID string) (time.Time, bool) {
+	sentAt, ok := m.lastDMs[userID]
+	return sentAt, ok
+}
+
+func (m *mockStore) SaveLastDM(_ context.Context, userID string, sentAt time.Time) error {
+	m.lastDMs[userID] = sentAt
+	return nil
+}
+
+func (m *mockStore) LastEventAt(_ context.Context, _ string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *mockStore) SaveLastEventAt(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *mockStore) ConfigReloadRequestedAt(_ context.Context, _ string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *mockStore) SaveConfigReloadRequest(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *mockStore) SavePROverride(_ context.Context, _ string, _ state.PROverride) error {
+	return nil
+}
+
+func (m *mockStore) Escalation(_ context.Context, _, _ string) (state.EscalationInfo, bool) {
+	return state.EscalationInfo{}, false
+}
+
+func (m *mockStore) SaveEscalation(_ context.Context, _, _ string, _ state.EscalationInfo) error {
+	return nil
+}
+
 func (m *mockStore) UserMapping(_ context.Context, _, _ string) (state.UserMappingInfo, bool) {
 	return state.UserMappingInfo{}, false
 }
@@ -118,6 +274,10 @@ func (m *mockStore) SaveUserMapping(_ context.Context, _ string, _ state.UserMap
 	return nil
 }
 
+func (m *mockStore) RemoveUserMapping(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (m *mockStore) ListUserMappings(_ context.Context, _ string) []state.UserMappingInfo {
 	return nil
 }
@@ -208,6 +368,37 @@ func TestManager_ProcessPendingDMs(t *testing.T) {
 	}
 }
 
+func TestManager_ProcessPendingDMs_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	manager := New(store, nil)
+
+	sender := newMockDMSender()
+	manager.RegisterGuild("guild1", sender)
+
+	store.maintenance["o"] = state.MaintenanceInfo{On: true}
+	store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+		ID:          "dm1",
+		UserID:      "user1",
+		GuildID:     "guild1",
+		Org:         "o",
+		PRURL:       "https://github.com/o/r/pull/1",
+		MessageText: "Hello",
+		SendAt:      time.Now().Add(-time.Hour),
+	})
+
+	manager.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 0 || len(store.removedDMs) != 0 {
+		t.Fatalf("sent %d DMs and removed %v during maintenance, want the DM held", len(sender.sentDMs), store.removedDMs)
+	}
+
+	store.maintenance["o"] = state.MaintenanceInfo{On: false}
+	manager.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 1 {
+		t.Errorf("sent %d DMs after maintenance, want 1", len(sender.sentDMs))
+	}
+}
+
 func TestManager_ProcessPendingDMs_NoSender(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
@@ -231,6 +422,43 @@ func TestManager_ProcessPendingDMs_NoSender(t *testing.T) {
 	// (current implementation returns nil error for missing sender)
 }
 
+func TestManager_ProcessPendingDMs_KeepsUserSettings(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	manager := New(store, nil)
+	manager.RegisterGuild("guild1", newMockDMSender())
+
+	const prURL = "https://github.com/o/r/pull/1"
+	snoozedUntil := time.Now().Add(-time.Minute)
+	store.savedDMInfo["user1:"+prURL] = state.DMInfo{
+		MessageID:       "old-msg",
+		SnoozedUntil:    snoozedUntil,
+		HandledState:    "needs_review",
+		EscalationLevel: 1,
+	}
+	store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+		ID:          "dm1",
+		UserID:      "user1",
+		GuildID:     "guild1",
+		PRURL:       prURL,
+		MessageText: "Hello",
+		SendAt:      time.Now().Add(-time.Hour),
+	})
+
+	manager.processPendingDMs(ctx)
+
+	info := store.savedDMInfo["user1:"+prURL]
+	if info.MessageID == "old-msg" || info.MessageText != "Hello" {
+		t.Errorf("DMInfo = %+v, want the new DM", info)
+	}
+	if !info.SnoozedUntil.Equal(snoozedUntil) || info.HandledState != "needs_review" {
+		t.Errorf("DMInfo = %+v, want the snooze and done state kept", info)
+	}
+	if info.EscalationLevel != 0 {
+		t.Errorf("EscalationLevel = %d, want it reset for the new DM", info.EscalationLevel)
+	}
+}
+
 func TestManager_ProcessPendingDMs_SendError(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
@@ -260,6 +488,139 @@ func TestManager_ProcessPendingDMs_SendError(t *testing.T) {
 	}
 }
 
+// recordingDMFallback records the notifications sent through the DM fallback.
+type recordingDMFallback struct {
+	sent []string // "org/userID: text"
+}
+
+func (r *recordingDMFallback) DMFallback(_ context.Context, org, userID, text string) error {
+	r.sent = append(r.sent, org+"/"+userID+": "+text)
+	return nil
+}
+
+// recordingLatency records the DM latencies it's given, per org.
+type recordingLatency struct {
+	latencies map[string][]time.Duration
+}
+
+func (r *recordingLatency) RecordDMLatency(org string, latency time.Duration) {
+	if r.latencies == nil {
+		r.latencies = make(map[string][]time.Duration)
+	}
+	r.latencies[org] = append(r.latencies[org], latency)
+}
+
+func TestManager_ProcessPendingDMs_Latency(t *testing.T) {
+	for _, digest := range []bool{false, true} {
+		t.Run(map[bool]string{false: "single", true: "digest"}[digest], func(t *testing.T) {
+			ctx := context.Background()
+			store := newMockStore()
+			manager := New(store, nil)
+			manager.SetDigestMode(digest)
+			manager.RegisterGuild("guild1", newMockDMSender())
+			recorder := &recordingLatency{}
+			manager.SetLatencyRecorder(recorder)
+
+			store.pendingDMs = append(store.pendingDMs,
+				&state.PendingDM{
+					ID:          "dm1",
+					UserID:      "user1",
+					GuildID:     "guild1",
+					Org:         "o",
+					PRURL:       "https://github.com/o/r/pull/1",
+					MessageText: "Review PR 1",
+					SendAt:      time.Now().Add(-time.Minute),
+					EventAt:     time.Now().Add(-10 * time.Minute),
+				},
+				// Queued before event times were recorded, so its latency is unknown
+				&state.PendingDM{
+					ID:          "dm2",
+					UserID:      "user2",
+					GuildID:     "guild1",
+					Org:         "o",
+					PRURL:       "https://github.com/o/r/pull/2",
+					MessageText: "Review PR 2",
+					SendAt:      time.Now().Add(-time.Minute),
+				})
+
+			manager.processPendingDMs(ctx)
+
+			got := recorder.latencies["o"]
+			if len(got) != 1 || got[0] < 10*time.Minute || got[0] > 11*time.Minute {
+				t.Errorf("recorded latencies = %v, want one of about 10m, from the event to the send", got)
+			}
+		})
+	}
+}
+
+func TestManager_ProcessPendingDMs_DMBlocked(t *testing.T) {
+	for _, digest := range []bool{false, true} {
+		t.Run(map[bool]string{false: "single", true: "digest"}[digest], func(t *testing.T) {
+			ctx := context.Background()
+			store := newMockStore()
+			manager := New(store, nil)
+			manager.SetDigestMode(digest)
+
+			sender := newMockDMSender()
+			sender.sendErr = fmt.Errorf("failed to send DM: %w", discord.ErrDMBlocked)
+			manager.RegisterGuild("guild1", sender)
+			fallback := &recordingDMFallback{}
+			manager.SetDMFallback(fallback)
+
+			for _, n := range []string{"1", "2"} {
+				store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+					ID:          "dm" + n,
+					UserID:      "user1",
+					GuildID:     "guild1",
+					Org:         "o",
+					PRURL:       "https://github.com/o/r/pull/" + n,
+					MessageText: "Review PR " + n,
+					SendAt:      time.Now().Add(-time.Hour),
+				})
+			}
+
+			manager.processPendingDMs(ctx)
+
+			want := []string{"o/user1: Review PR 1", "o/user1: Review PR 2"}
+			if !slices.Equal(fallback.sent, want) {
+				t.Errorf("fallback sent = %q, want %q", fallback.sent, want)
+			}
+			if len(store.removedDMs) != 2 {
+				t.Errorf("removedDMs = %v, want both DMs removed once sent through fallback", store.removedDMs)
+			}
+			info, ok := store.savedDMInfo["user1:https://github.com/o/r/pull/1"]
+			if !ok || info.MessageID != "" {
+				t.Errorf("saved DM info = %+v, %v, want one without a message ID", info, ok)
+			}
+		})
+	}
+}
+
+func TestManager_ProcessPendingDMs_DMBlockedNoFallback(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	manager := New(store, nil)
+
+	sender := newMockDMSender()
+	sender.sendErr = fmt.Errorf("failed to send DM: %w", discord.ErrDMBlocked)
+	manager.RegisterGuild("guild1", sender)
+
+	store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+		ID:          "dm1",
+		UserID:      "user1",
+		GuildID:     "guild1",
+		PRURL:       "https://github.com/o/r/pull/1",
+		MessageText: "Hello",
+		SendAt:      time.Now().Add(-time.Hour),
+	})
+
+	manager.processPendingDMs(ctx)
+
+	if len(store.removedDMs) != 0 {
+		t.Errorf("removedDMs = %v, want the DM kept for retry without a fallback", store.removedDMs)
+	}
+}
+
 func TestManager_ProcessPendingDMs_RateLimit(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
@@ -295,6 +656,53 @@ func TestManager_ProcessPendingDMs_RateLimit(t *testing.T) {
 	// rate-limited DMs are skipped and will be re-queued if needed.
 }
 
+func TestManager_ProcessPendingDMs_RateLimitSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	sender := newMockDMSender()
+	manager := New(store, nil)
+	manager.RegisterGuild("guild1", sender)
+
+	store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+		ID:          "dm1",
+		UserID:      "user1",
+		GuildID:     "guild1",
+		PRURL:       "https://github.com/o/r/pull/1",
+		MessageText: "Hello",
+		SendAt:      time.Now().Add(-time.Hour),
+	})
+	manager.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 1 {
+		t.Fatalf("sentDMs = %d, want 1", len(sender.sentDMs))
+	}
+	if _, ok := store.lastDMs["user1"]; !ok {
+		t.Fatal("last DM time wasn't saved to the store")
+	}
+
+	// A restarted manager, or another instance, still knows about the DM
+	store.pendingDMs = []*state.PendingDM{{
+		ID:          "dm2",
+		UserID:      "user1",
+		GuildID:     "guild1",
+		PRURL:       "https://github.com/o/r/pull/2",
+		MessageText: "Hello again",
+		SendAt:      time.Now().Add(-time.Hour),
+	}}
+	restarted := New(store, nil)
+	restarted.RegisterGuild("guild1", sender)
+	restarted.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 1 {
+		t.Errorf("sentDMs = %d after restart, want the second DM rate limited", len(sender.sentDMs))
+	}
+
+	// Once the interval has passed, DMs go out again
+	store.lastDMs["user1"] = time.Now().Add(-2 * minDMInterval)
+	restarted.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 2 {
+		t.Errorf("sentDMs = %d, want the DM sent once the interval passed", len(sender.sentDMs))
+	}
+}
+
 func TestManager_ProcessPendingDMs_FetchError(t *testing.T) {
 	ctx := context.Background()
 	store := newMockStore()
@@ -560,3 +968,362 @@ func TestManager_ProcessPendingDMs_RetryScheduling(t *testing.T) {
 		t.Error("SendAt should be scheduled in the future")
 	}
 }
+
+type recordingDeadLetterSink struct {
+	letters []DeadLetter
+}
+
+func (r *recordingDeadLetterSink) DeadLetter(_ context.Context, dl DeadLetter) {
+	r.letters = append(r.letters, dl)
+}
+
+func TestManager_ProcessPendingDMs_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name       string
+		dm         state.PendingDM
+		wantReason string
+	}{
+		{
+			name:       "expired",
+			dm:         state.PendingDM{ExpiresAt: time.Now().Add(-time.Minute)},
+			wantReason: ReasonExpired,
+		},
+		{
+			name:       "max retries",
+			dm:         state.PendingDM{RetryCount: maxRetries},
+			wantReason: ReasonMaxRetries,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockStore()
+			manager := New(store, nil)
+			sink := &recordingDeadLetterSink{}
+			manager.SetDeadLetterSink(sink)
+			manager.RegisterGuild("guild1", newMockDMSender())
+
+			dm := tt.dm
+			dm.ID = "dm1"
+			dm.UserID = "user1"
+			dm.GuildID = "guild1"
+			dm.Org = "o"
+			dm.PRURL = "https://github.com/o/r/pull/1"
+			dm.SendAt = time.Now().Add(-time.Hour)
+			store.pendingDMs = append(store.pendingDMs, &dm)
+
+			manager.processPendingDMs(context.Background())
+
+			if len(sink.letters) != 1 {
+				t.Fatalf("got %d dead letters, want 1", len(sink.letters))
+			}
+			got := sink.letters[0]
+			if got.Reason != tt.wantReason || got.UserID != "user1" || got.PRURL != dm.PRURL || got.Org != "o" {
+				t.Errorf("dead letter = %+v, want reason %q for user1 on %s", got, tt.wantReason, dm.PRURL)
+			}
+			if len(store.removedDMs) != 1 {
+				t.Error("dead-lettered DM should still be removed from the queue")
+			}
+		})
+	}
+}
+
+func TestFileDeadLetterSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	sink := NewFileDeadLetterSink(path, nil)
+	ctx := context.Background()
+
+	sink.DeadLetter(ctx, DeadLetter{ID: "dm1", UserID: "u1", PRURL: "https://github.com/o/r/pull/1", Reason: ReasonExpired})
+	sink.DeadLetter(ctx, DeadLetter{ID: "dm2", UserID: "u2", PRURL: "https://github.com/o/r/pull/2", Reason: ReasonMaxRetries})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var dl DeadLetter
+	if err := json.Unmarshal([]byte(lines[1]), &dl); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if dl.ID != "dm2" || dl.Reason != ReasonMaxRetries {
+		t.Errorf("second line = %+v, want dm2 with reason %q", dl, ReasonMaxRetries)
+	}
+}
+
+func TestManager_ProcessPendingDMs_DigestGroupsByOrg(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	manager := New(store, nil)
+	manager.SetDigestMode(true)
+
+	sender := newMockDMSender()
+	manager.RegisterGuild("guild1", sender)
+	manager.RegisterGuild("guild2", sender)
+
+	past := time.Now().Add(-time.Hour)
+	store.pendingDMs = []*state.PendingDM{
+		{ID: "dm1", UserID: "user1", GuildID: "guild1", Org: "orgb", PRURL: "https://github.com/orgb/r/pull/1", MessageText: "B one", SendAt: past},
+		{ID: "dm2", UserID: "user1", GuildID: "guild2", Org: "orga", PRURL: "https://github.com/orga/r/pull/2", MessageText: "A two", SendAt: past},
+		{ID: "dm3", UserID: "user1", GuildID: "guild1", Org: "orgb", PRURL: "https://github.com/orgb/r/pull/3", MessageText: "B three", SendAt: past},
+		{ID: "dm4", UserID: "user2", GuildID: "guild1", Org: "orga", PRURL: "https://github.com/orga/r/pull/4", MessageText: "solo", SendAt: past},
+	}
+
+	manager.processPendingDMs(ctx)
+
+	if len(sender.sentDMs) != 2 {
+		t.Fatalf("sent %d DMs, want 2 (one digest, one single)", len(sender.sentDMs))
+	}
+	digest := sender.sentDMs[0]
+	if digest.userID != "user1" {
+		t.Fatalf("first DM to %s, want user1 digest", digest.userID)
+	}
+	want := "📬 **3 PR updates**\n\n**orga**\nA two\n\n**orgb**\nB one\nB three"
+	if digest.text != want {
+		t.Errorf("digest = %q, want %q", digest.text, want)
+	}
+	if sender.sentDMs[1].text != "solo" {
+		t.Errorf("single DM = %q, want solo", sender.sentDMs[1].text)
+	}
+
+	if len(store.removedDMs) != 4 {
+		t.Errorf("removed %d DMs, want 4", len(store.removedDMs))
+	}
+	info, ok := store.savedDMInfo["user1:https://github.com/orgb/r/pull/3"]
+	if !ok || info.MessageText != "B three" || info.MessageID != "" {
+		t.Errorf("digest DM info = %+v, want per-PR text and no message ID", info)
+	}
+}
+
+func TestManager_ProcessPendingDMs_BurstCollapse(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	manager := New(store, nil)
+	manager.SetBurstWindow(time.Minute)
+
+	sender := newMockDMSender()
+	manager.RegisterGuild("guild1", sender)
+
+	// A bulk reviewer assignment: five DMs to one user within seconds
+	now := time.Now()
+	for i := range 5 {
+		n := strconv.Itoa(i + 1)
+		store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+			ID:          "dm" + n,
+			UserID:      "user1",
+			GuildID:     "guild1",
+			Org:         "o",
+			PRURL:       "https://github.com/o/r/pull/" + n,
+			MessageText: "Review PR " + n,
+			CreatedAt:   now.Add(-time.Duration(10-i) * time.Second),
+			SendAt:      now.Add(-time.Duration(10-i) * time.Second),
+		})
+	}
+	// Another user's lone DM isn't a burst
+	store.pendingDMs = append(store.pendingDMs, &state.PendingDM{
+		ID: "solo", UserID: "user2", GuildID: "guild1", Org: "o",
+		PRURL: "https://github.com/o/r/pull/9", MessageText: "solo",
+		CreatedAt: now, SendAt: now.Add(-time.Second),
+	})
+
+	manager.processPendingDMs(ctx)
+	if len(sender.sentDMs) != 1 || sender.sentDMs[0].userID != "user2" {
+		t.Fatalf("sent %+v during the burst, want only user2's DM", sender.sentDMs)
+	}
+	store.pendingDMs = store.pendingDMs[:5]
+
+	// Once the window has passed since the burst's first DM
+	manager.bursts["user1"] = now.Add(-2 * time.Minute)
+	manager.processPendingDMs(ctx)
+
+	if len(sender.sentDMs) != 2 {
+		t.Fatalf("sent %d DMs, want the burst collapsed into one", len(sender.sentDMs))
+	}
+	collapsed := sender.sentDMs[1]
+	if collapsed.userID != "user1" {
+		t.Fatalf("collapsed DM to %s, want user1", collapsed.userID)
+	}
+	for i := 1; i <= 5; i++ {
+		if want := "Review PR " + strconv.Itoa(i); !strings.Contains(collapsed.text, want) {
+			t.Errorf("collapsed DM = %q, want it to include %q", collapsed.text, want)
+		}
+	}
+	if len(store.removedDMs) != 6 {
+		t.Errorf("removedDMs = %v, want all six removed", store.removedDMs)
+	}
+	if _, held := manager.bursts["user1"]; held {
+		t.Error("burst still tracked after its DMs were sent")
+	}
+}
+
+func TestManager_holdBursts_DueTime(t *testing.T) {
+	manager := New(newMockStore(), nil)
+	manager.SetBurstWindow(time.Minute)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		user     string
+		created  [2]time.Duration // Before now
+		sendAt   [2]time.Duration // Before now
+		wantHeld bool
+	}{
+		// Delayed DMs queued an hour ago that both came due in the last few seconds
+		{"delayed DMs due together", "user1", [2]time.Duration{time.Hour, time.Hour}, [2]time.Duration{5 * time.Second, time.Second}, true},
+		// Queued seconds apart, but one was held back and only just came due
+		{"due far apart", "user2", [2]time.Duration{5 * time.Second, time.Second}, [2]time.Duration{5 * time.Minute, time.Second}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dms []*state.PendingDM
+			for i := range 2 {
+				dms = append(dms, &state.PendingDM{
+					ID:        tt.user + strconv.Itoa(i),
+					UserID:    tt.user,
+					CreatedAt: now.Add(-tt.created[i]),
+					SendAt:    now.Add(-tt.sendAt[i]),
+				})
+			}
+			ready, settled := manager.holdBursts(dms, now)
+			if held := len(ready) == 0 && len(settled) == 0; held != tt.wantHeld {
+				t.Errorf("holdBursts() ready = %d, settled = %d; held = %v, want %v", len(ready), len(settled), held, tt.wantHeld)
+			}
+		})
+	}
+}
+
+func TestDigestSections_ActionPriority(t *testing.T) {
+	dms := []*state.PendingDM{
+		{Org: "orga", ActionKind: "merge", MessageText: "merge 1"},
+		{Org: "orga", ActionKind: "review", MessageText: "review 2"},
+		{Org: "orga", ActionKind: "", MessageText: "unknown 3"},
+		{Org: "orga", ActionKind: "fix_tests", MessageText: "fix tests 4"},
+		{Org: "orga", ActionKind: "review", MessageText: "review 5"},
+		{Org: "orga", ActionKind: "fix_conflict", MessageText: "fix conflict 6"},
+		{Org: "orgb", ActionKind: "merge", MessageText: "merge 7"},
+		{Org: "orgb", ActionKind: "fix_tests", MessageText: "fix tests 8"},
+	}
+
+	sections := digestSections(dms)
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(sections))
+	}
+	// Same-priority items keep queue order
+	wantA := []string{"fix conflict 6", "fix tests 4", "review 2", "review 5", "merge 1", "unknown 3"}
+	if !slices.Equal(sections[0].Items, wantA) {
+		t.Errorf("orga items = %v, want %v", sections[0].Items, wantA)
+	}
+	wantB := []string{"fix tests 8", "merge 7"}
+	if !slices.Equal(sections[1].Items, wantB) {
+		t.Errorf("orgb items = %v, want %v", sections[1].Items, wantB)
+	}
+	if dms[0].MessageText != "merge 1" {
+		t.Error("digestSections reordered the caller's DMs")
+	}
+}
+
+func TestManager_SnoozeDM(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	mgr := New(store, nil)
+
+	const prURL = "https://github.com/org/repo/pull/1"
+	dms := []*state.PendingDM{
+		{ID: "dm-1", UserID: "user1", PRURL: prURL, SendAt: time.Now().Add(5 * time.Minute)},
+		{ID: "dm-2", UserID: "user2", PRURL: prURL, SendAt: time.Now().Add(5 * time.Minute)},
+	}
+	for _, dm := range dms {
+		if err := store.QueuePendingDM(ctx, dm); err != nil {
+			t.Fatalf("QueuePendingDM() error = %v", err)
+		}
+	}
+
+	before := time.Now()
+	if err := mgr.SnoozeDM(ctx, "user1", prURL, time.Hour); err != nil {
+		t.Fatalf("SnoozeDM() error = %v", err)
+	}
+
+	pending, err := store.PendingDMs(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("PendingDMs() error = %v", err)
+	}
+	for _, dm := range pending {
+		switch dm.ID {
+		case "dm-1":
+			if dm.SendAt.Before(before.Add(time.Hour)) {
+				t.Errorf("snoozed DM SendAt = %v, want at least an hour out", dm.SendAt)
+			}
+		case "dm-2":
+			if dm.SendAt.After(before.Add(10 * time.Minute)) {
+				t.Errorf("other user's DM SendAt = %v, want unchanged", dm.SendAt)
+			}
+		}
+	}
+
+	info, ok := store.DMInfo(ctx, "user1", prURL)
+	if !ok || info.SnoozedUntil.Before(before.Add(time.Hour)) {
+		t.Errorf("DMInfo = %+v, want SnoozedUntil an hour out", info)
+	}
+}
+
+func TestManager_DropUserDMs(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	mgr := New(store, nil)
+
+	for _, dm := range []*state.PendingDM{
+		{ID: "dm-1", UserID: "user1", GuildID: "guild1", PRURL: "https://github.com/org/repo/pull/1", SendAt: time.Now()},
+		{ID: "dm-2", UserID: "user1", GuildID: "guild1", PRURL: "https://github.com/org/repo/pull/2", SendAt: time.Now().Add(time.Hour)},
+		{ID: "dm-3", UserID: "user1", GuildID: "guild2", PRURL: "https://github.com/other/repo/pull/1", SendAt: time.Now()},
+		{ID: "dm-4", UserID: "user2", GuildID: "guild1", PRURL: "https://github.com/org/repo/pull/1", SendAt: time.Now()},
+	} {
+		if err := store.QueuePendingDM(ctx, dm); err != nil {
+			t.Fatalf("QueuePendingDM() error = %v", err)
+		}
+	}
+
+	dropped, err := mgr.DropUserDMs(ctx, "guild1", "user1")
+	if err != nil {
+		t.Fatalf("DropUserDMs() error = %v", err)
+	}
+	if dropped != 2 {
+		t.Errorf("DropUserDMs() = %d, want 2", dropped)
+	}
+	pending, _ := store.PendingDMs(ctx, time.Now().Add(2*time.Hour))
+	var ids []string
+	for _, dm := range pending {
+		ids = append(ids, dm.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"dm-3", "dm-4"}) {
+		t.Errorf("pending DMs = %v, want [dm-3 dm-4]", ids)
+	}
+}
+
+func TestManager_MuteDM(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	mgr := New(store, nil)
+
+	const prURL = "https://github.com/org/repo/pull/1"
+	if err := store.QueuePendingDM(ctx, &state.PendingDM{ID: "dm-1", UserID: "user1", PRURL: prURL, SendAt: time.Now()}); err != nil {
+		t.Fatalf("QueuePendingDM() error = %v", err)
+	}
+	if err := store.SaveDMInfo(ctx, "user1", prURL, state.DMInfo{MessageID: "msg-1"}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+
+	if err := mgr.MuteDM(ctx, "user1", prURL); err != nil {
+		t.Fatalf("MuteDM() error = %v", err)
+	}
+
+	if pending, _ := store.PendingDMs(ctx, time.Now().Add(time.Hour)); len(pending) != 0 {
+		t.Errorf("pending DMs = %d after mute, want 0", len(pending))
+	}
+	info, _ := store.DMInfo(ctx, "user1", prURL)
+	if !info.Muted || info.MessageID != "msg-1" {
+		t.Errorf("DMInfo = %+v, want muted with message kept", info)
+	}
+}
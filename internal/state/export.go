@@ -0,0 +1,146 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// Snapshot is a portable copy of a store's durable contents, for backing up an
+// in-memory deployment before a restart or moving state between backends.
+// Claims and processed-event markers are short-lived and not included.
+type Snapshot struct {
+	ExportedAt   time.Time                  `json:"exported_at"`
+	Threads      []SnapshotThread           `json:"threads"`
+	DMs          []SnapshotDM               `json:"dms"`
+	PendingDMs   []PendingDM                `json:"pending_dms"`
+	UserMappings []UserMappingInfo          `json:"user_mappings"`
+	DailyReports map[string]DailyReportInfo `json:"daily_reports"` // userID -> info
+	Digests      map[string]DigestInfo      `json:"digests"`       // channelID -> info
+	Boards       map[string]BoardInfo       `json:"boards"`        // channelID -> info
+	PostWindows  map[string]PostWindowInfo  `json:"post_windows"`  // org -> PRs held back for closed post windows
+	Overrides    map[string]PROverride      `json:"overrides"`     // prURL -> override
+	Escalations  map[string]EscalationInfo  `json:"escalations"`   // channelID:prURL -> progress
+	Maintenance  map[string]MaintenanceInfo `json:"maintenance"`   // org -> maintenance mode
+	OOO          map[string]OOOInfo         `json:"ooo"`           // Discord user ID -> out of office status
+	Redirects    map[string]RepoRedirect    `json:"redirects"`     // org/repo -> temporary channel redirect
+}
+
+// SnapshotThread is a stored thread along with its lookup key.
+type SnapshotThread struct {
+	Owner     string     `json:"owner"`
+	Repo      string     `json:"repo"`
+	ChannelID string     `json:"channel_id"`
+	Info      ThreadInfo `json:"info"`
+	Number    int        `json:"number"`
+}
+
+// SnapshotDM is a stored DM along with its lookup key.
+type SnapshotDM struct {
+	UserID string `json:"user_id"`
+	PRURL  string `json:"pr_url"`
+	Info   DMInfo `json:"info"`
+}
+
+// Export serializes the store's threads, DMs, pending DMs, user mappings,
+// daily reports, digests, boards, held back posts, overrides, escalations, maintenance modes, out of office
+// statuses, and repo redirects to JSON.
+func (s *MemoryStore) Export(_ context.Context) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := Snapshot{
+		ExportedAt:   time.Now(),
+		DailyReports: s.dailyReports,
+		Digests:      s.digests,
+		Boards:       s.boards,
+		PostWindows:  s.postWindows,
+		Overrides:    s.overrides,
+		Escalations:  s.escalations,
+		Maintenance:  s.maintenance,
+		OOO:          s.ooo,
+		Redirects:    s.redirects,
+	}
+	for key, tracked := range s.threadIndex {
+		info, exists := s.threads[key]
+		if !exists {
+			continue
+		}
+		prefix := threadKey(tracked.Owner, tracked.Repo, tracked.Number, "")
+		snap.Threads = append(snap.Threads, SnapshotThread{
+			Owner:     tracked.Owner,
+			Repo:      tracked.Repo,
+			Number:    tracked.Number,
+			ChannelID: strings.TrimPrefix(key, prefix),
+			Info:      info,
+		})
+	}
+	for prURL, users := range s.dmUserIndex {
+		for userID := range users {
+			if info, exists := s.dmInfo[dmKey(userID, prURL)]; exists {
+				snap.DMs = append(snap.DMs, SnapshotDM{UserID: userID, PRURL: prURL, Info: info})
+			}
+		}
+	}
+	for _, dm := range s.pendingDMs {
+		snap.PendingDMs = append(snap.PendingDMs, *dm)
+	}
+	for _, mapping := range s.userMappings {
+		snap.UserMappings = append(snap.UserMappings, mapping)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot: %w", err)
+	}
+	return data, nil
+}
+
+// Import loads a snapshot produced by Export, overwriting entries with the same keys.
+// Timestamps are kept as exported.
+func (s *MemoryStore) Import(_ context.Context, data []byte) error {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("unmarshal snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range snap.Threads {
+		key := threadKey(t.Owner, t.Repo, t.Number, t.ChannelID)
+		s.threads[key] = t.Info
+		s.indexThread(key, t.Owner, t.Repo, t.Number, t.ChannelID, t.Info)
+	}
+	for _, dm := range snap.DMs {
+		s.dmInfo[dmKey(dm.UserID, dm.PRURL)] = dm.Info
+		if s.dmUserIndex[dm.PRURL] == nil {
+			s.dmUserIndex[dm.PRURL] = make(map[string]bool)
+		}
+		s.dmUserIndex[dm.PRURL][dm.UserID] = true
+		if s.dmPRIndex[dm.UserID] == nil {
+			s.dmPRIndex[dm.UserID] = make(map[string]bool)
+		}
+		s.dmPRIndex[dm.UserID][dm.PRURL] = true
+	}
+	for _, dm := range snap.PendingDMs {
+		s.pendingDMs[dm.ID] = &dm
+	}
+	for _, mapping := range snap.UserMappings {
+		s.userMappings[userMappingKey(mapping.GuildID, mapping.GitHubUsername)] = mapping
+	}
+	maps.Copy(s.dailyReports, snap.DailyReports)
+	maps.Copy(s.digests, snap.Digests)
+	maps.Copy(s.boards, snap.Boards)
+	maps.Copy(s.postWindows, snap.PostWindows)
+	maps.Copy(s.overrides, snap.Overrides)
+	maps.Copy(s.escalations, snap.Escalations)
+	maps.Copy(s.maintenance, snap.Maintenance)
+	maps.Copy(s.ooo, snap.OOO)
+	maps.Copy(s.redirects, snap.Redirects)
+
+	return nil
+}
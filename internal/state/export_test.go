@@ -0,0 +1,84 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := NewMemoryStore()
+
+	const prURL = "https://github.com/org/repo/pull/1"
+	if err := src.SaveThread(ctx, "org", "repo", 1, "chan1", ThreadInfo{ThreadID: "thread1", MessageID: "msg1", ChannelType: "forum"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := src.SaveDMInfo(ctx, "user1", prURL, DMInfo{ChannelID: "dm1", MessageID: "dmmsg1", LastState: "open"}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+	sendAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := src.QueuePendingDM(ctx, &PendingDM{ID: "pending1", UserID: "user2", PRURL: prURL, SendAt: sendAt}); err != nil {
+		t.Fatalf("QueuePendingDM() error = %v", err)
+	}
+	if err := src.SaveUserMapping(ctx, "guild1", UserMappingInfo{GitHubUsername: "alice", DiscordUserID: "111"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	if err := src.SaveDailyReportInfo(ctx, "user1", DailyReportInfo{GuildID: "guild1"}); err != nil {
+		t.Fatalf("SaveDailyReportInfo() error = %v", err)
+	}
+	if err := src.SaveDigest(ctx, "chan2", DigestInfo{Pending: map[string]DigestEntry{prURL: {Line: "digest1"}}}); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+	if err := src.SavePROverride(ctx, prURL, PROverride{Muted: true}); err != nil {
+		t.Fatalf("SavePROverride() error = %v", err)
+	}
+
+	data, err := src.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	dst := NewMemoryStore()
+	if err := dst.Import(ctx, data); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if thread, ok := dst.Thread(ctx, "org", "repo", 1, "chan1"); !ok || thread.ThreadID != "thread1" || thread.MessageID != "msg1" {
+		t.Errorf("Thread() = %+v, %v; want imported thread", thread, ok)
+	}
+	if threads := dst.ListThreads(ctx, "org"); len(threads) != 1 {
+		t.Errorf("ListThreads() = %d, want 1", len(threads))
+	}
+	if refs := dst.MessagesForPR(ctx, prURL); len(refs) != 1 || refs[0].ChannelID != "chan1" {
+		t.Errorf("MessagesForPR() = %+v, want the imported thread's message", refs)
+	}
+	if dm, ok := dst.DMInfo(ctx, "user1", prURL); !ok || dm.MessageID != "dmmsg1" {
+		t.Errorf("DMInfo() = %+v, %v; want imported DM", dm, ok)
+	}
+	if users := dst.ListDMUsers(ctx, prURL); len(users) != 1 || users[0] != "user1" {
+		t.Errorf("ListDMUsers() = %v, want [user1]", users)
+	}
+	pending, err := dst.PendingDMs(ctx, sendAt)
+	if err != nil || len(pending) != 1 || pending[0].ID != "pending1" || !pending[0].SendAt.Equal(sendAt) {
+		t.Errorf("PendingDMs() = %v, %v; want imported pending DM", pending, err)
+	}
+	if mapping, ok := dst.UserMapping(ctx, "guild1", "alice"); !ok || mapping.DiscordUserID != "111" {
+		t.Errorf("UserMapping() = %+v, %v; want imported mapping", mapping, ok)
+	}
+	if report, ok := dst.DailyReportInfo(ctx, "user1"); !ok || report.GuildID != "guild1" {
+		t.Errorf("DailyReportInfo() = %+v, %v; want imported report", report, ok)
+	}
+	if digest, ok := dst.Digest(ctx, "chan2"); !ok || digest.Pending[prURL].Line != "digest1" {
+		t.Errorf("Digest() = %+v, %v; want imported digest", digest, ok)
+	}
+	if override, ok := dst.PROverride(ctx, prURL); !ok || !override.Muted {
+		t.Errorf("PROverride() = %+v, %v; want imported override", override, ok)
+	}
+}
+
+func TestMemoryStore_Import_InvalidJSON(t *testing.T) {
+	if err := NewMemoryStore().Import(context.Background(), []byte("{not json")); err == nil {
+		t.Error("Import() error = nil, want error")
+	}
+}
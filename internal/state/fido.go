@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 // TTLs for different data types.
 const (
 	threadTTL      = 30 * 24 * time.Hour // 30 days - PRs can be open a while
+	threadIndexTTL = 30 * 24 * time.Hour // Same as threads
 	dmInfoTTL      = 7 * 24 * time.Hour  // 7 days
 	dmUserListTTL  = 7 * 24 * time.Hour  // 7 days - same as dmInfo
 	eventTTL       = 2 * time.Hour       // Short - just for dedup
@@ -21,6 +25,20 @@ const (
 	pendingDMTTL   = 4 * time.Hour       // Max time a DM can be pending
 	claimTTL       = 10 * time.Second    // Short TTL for claims - just enough to post message
 	userMappingTTL = 30 * 24 * time.Hour // 30 days - user mappings rarely change
+	digestTTL      = 30 * 24 * time.Hour // 30 days - longer than any sensible digest interval
+	boardTTL       = 30 * 24 * time.Hour // Same as threads - a board lists the channel's tracked PRs
+	postWindowTTL  = 7 * 24 * time.Hour  // Longer than any post window stays closed
+	overrideTTL    = 30 * 24 * time.Hour // Same as threads - overrides matter while the PR is tracked
+	escalationTTL  = 30 * 24 * time.Hour // Same as threads - escalation matters while the PR is tracked
+	maintenanceTTL = 30 * 24 * time.Hour // A forgotten maintenance mode lapses rather than silencing an org forever
+	oooTTL         = MaxOOO              // Out of office can't be set for longer
+	redirectTTL    = MaxRepoRedirect     // Redirects can't be set for longer
+	lastDMTTL      = time.Hour           // Longer than any DM rate limit interval
+	lastEventTTL   = 30 * 24 * time.Hour // Downtime longer than this isn't backfilled anyway
+	reloadTTL      = 24 * time.Hour      // Running instances pick a forced reload up within minutes
+	leaseTTL       = 5 * time.Second     // Longest a crashed instance's lease holds up the others
+	leaseRetry     = 25 * time.Millisecond
+	indexRefresh   = 24 * time.Hour // How stale a thread's index entry gets before a save renews it
 )
 
 // pendingDMQueue stores all pending DMs in a single persisted value.
@@ -35,42 +53,130 @@ type dmUserList struct {
 	UserIDs map[string]bool `json:"user_ids"` // userID -> true
 }
 
+// dmPRList stores all PR URLs a user received DMs for.
+// Fido has no key scan, so this is what makes ListPRsForUser possible.
+type dmPRList struct {
+	PRURLs map[string]bool `json:"pr_urls"` // prURL -> true
+}
+
+// threadIndex stores the PRs with tracked threads for an org.
+// Fido has no key scan, so this is what makes ListThreads possible.
+type threadIndex struct {
+	Threads map[string]threadIndexEntry `json:"threads"` // thread key -> PR identity
+}
+
+// threadIndexEntry is a tracked thread's PR, and when the thread expires unless saved again.
+// Entries written before expiry was recorded have none, and get one on the next update.
+type threadIndexEntry struct {
+	Owner   string    `json:"owner"`
+	Repo    string    `json:"repo"`
+	Expires time.Time `json:"expires"`
+	Number  int       `json:"number"`
+}
+
+// messageIndexEntry is the tracked thread a PR message belongs to, and when the entry expires
+// unless the thread is saved again.
+// Fido has no key scan, so this is what lets ThreadForMessage skip listing every thread.
+type messageIndexEntry struct {
+	Owner     string    `json:"owner"`
+	Repo      string    `json:"repo"`
+	ChannelID string    `json:"channel_id"`
+	Expires   time.Time `json:"expires"`
+	Number    int       `json:"number"`
+}
+
+// userMappingIndex stores the GitHub usernames mapped in a guild.
+// Fido has no key scan, so this is what makes ListUserMappings possible.
+type userMappingIndex struct {
+	Usernames map[string]bool `json:"usernames"` // GitHub username -> true
+}
+
 // FidoStore implements Store using fido with CloudRun backend.
 //
 // Requires these Datastore databases (must be created before use):
 //   - discordian-threads: PR to Discord thread/message mapping
+//   - discordian-threadindex: Tracked threads per org (org -> thread keys)
+//   - discordian-messageindex: Tracked threads by their PR message (channel/message ID -> thread)
 //   - discordian-dms: DM message tracking
+//   - discordian-usermappings: GitHub to Discord user mappings
+//   - discordian-usermappingindex: Mapped GitHub usernames per guild
 //   - discordian-dmusers: DM user lists (prURL -> list of user IDs)
+//   - discordian-dmprs: DM PR lists (user ID -> list of PR URLs)
 //   - discordian-reports: Daily report tracking
 //   - discordian-pending: Pending DM queue
 //   - discordian-events: Event deduplication (persisted for cross-instance safety)
 //   - discordian-claims: Distributed claims (persisted for cross-instance coordination)
 //   - discordian-usermappings: GitHub username to Discord user ID mappings
+//   - discordian-digests: Digest state for digest-mode channels
+//   - discordian-boards: Board state for board-mode channels
+//   - discordian-postwindows: PRs held back per org until their channels' post windows open
+//   - discordian-overrides: Per-PR overrides from /discordian comment directives
+//   - discordian-escalations: Review escalation progress per channel and PR
+//   - discordian-maintenance: Maintenance mode per org
+//   - discordian-ooo: Out of office status per Discord user
+//   - discordian-redirects: Temporary channel redirects per repo
+//   - discordian-lastdms: When each Discord user was last DMed, for rate limiting
+//   - discordian-lastevents: When each org's last webhook event was seen, for backfilling
+//   - discordian-reloads: When each org's config reload was last forced, for other instances to follow
 type FidoStore struct {
 	threads      *fido.TieredCache[string, ThreadInfo]
+	threadIndex  *fido.TieredCache[string, threadIndex]       // Persisted: org -> tracked thread keys
+	messageIndex *fido.TieredCache[string, messageIndexEntry] // Persisted: channel/message ID -> thread
 	dmInfo       *fido.TieredCache[string, DMInfo]
 	dmUserLists  *fido.TieredCache[string, dmUserList] // Persisted: prURL -> user IDs
+	dmPRLists    *fido.TieredCache[string, dmPRList]   // Persisted: user ID -> prURLs
 	dailyReports *fido.TieredCache[string, DailyReportInfo]
 	pendingDMs   *fido.TieredCache[string, pendingDMQueue]
-	events       *fido.TieredCache[string, time.Time]       // Persisted for cross-instance dedup
-	claims       *fido.TieredCache[string, time.Time]       // Persisted for cross-instance claim coordination
-	userMappings *fido.TieredCache[string, UserMappingInfo] // Persisted: guildID:gitHubUsername -> UserMappingInfo
+	events       *fido.TieredCache[string, time.Time]        // Persisted for cross-instance dedup
+	claims       *fido.TieredCache[string, time.Time]        // Persisted for cross-instance claim coordination
+	userMappings *fido.TieredCache[string, UserMappingInfo]  // Persisted: guildID:gitHubUsername -> UserMappingInfo
+	mappingIndex *fido.TieredCache[string, userMappingIndex] // Persisted: guildID -> mapped GitHub usernames
+	digests      *fido.TieredCache[string, DigestInfo]       // Persisted: channelID -> DigestInfo
+	boards       *fido.TieredCache[string, BoardInfo]        // Persisted: channelID -> BoardInfo
+	postWindows  *fido.TieredCache[string, PostWindowInfo]   // Persisted: org -> PostWindowInfo
+	overrides    *fido.TieredCache[string, PROverride]       // Persisted: prURL -> PROverride
+	escalations  *fido.TieredCache[string, EscalationInfo]   // Persisted: channelID:prURL -> EscalationInfo
+	maintenance  *fido.TieredCache[string, MaintenanceInfo]  // Persisted: org -> MaintenanceInfo
+	ooo          *fido.TieredCache[string, OOOInfo]          // Persisted: Discord user ID -> OOOInfo
+	redirects    *fido.TieredCache[string, RepoRedirect]     // Persisted: org/repo -> RepoRedirect
+	lastDMs      *fido.TieredCache[string, time.Time]        // Persisted: Discord user ID -> last DM time
+	lastEvents   *fido.TieredCache[string, time.Time]        // Persisted: org -> last webhook event time
+	reloads      *fido.TieredCache[string, time.Time]        // Persisted: org -> last forced config reload time
+	eventKeys    map[string]time.Time                        // Event keys this instance recorded -> expiry, for ClearProcessed
 
 	pendingMu sync.Mutex // Serializes pending DM operations
+	eventsMu  sync.Mutex // Makes ClaimEvent's check-and-set atomic within this instance; guards eventKeys
+	indexMu   sync.Mutex // Serializes thread and user mapping index updates
+	leaseMu   sync.Mutex // Makes lease's check-and-set atomic within this instance
 }
 
 // FidoStoreOption configures a FidoStore.
 type FidoStoreOption func(*fidoStoreOptions)
 
 type fidoStoreOptions struct {
-	threadStore      fido.Store[string, ThreadInfo]
-	dmStore          fido.Store[string, DMInfo]
-	dmUserStore      fido.Store[string, dmUserList]
-	reportStore      fido.Store[string, DailyReportInfo]
-	pendingStore     fido.Store[string, pendingDMQueue]
-	eventStore       fido.Store[string, time.Time]
-	claimStore       fido.Store[string, time.Time]
-	userMappingStore fido.Store[string, UserMappingInfo]
+	threadStore       fido.Store[string, ThreadInfo]
+	threadIndexStore  fido.Store[string, threadIndex]
+	messageIndexStore fido.Store[string, messageIndexEntry]
+	dmStore           fido.Store[string, DMInfo]
+	dmUserStore       fido.Store[string, dmUserList]
+	dmPRStore         fido.Store[string, dmPRList]
+	reportStore       fido.Store[string, DailyReportInfo]
+	pendingStore      fido.Store[string, pendingDMQueue]
+	eventStore        fido.Store[string, time.Time]
+	claimStore        fido.Store[string, time.Time]
+	userMappingStore  fido.Store[string, UserMappingInfo]
+	mappingIdxStore   fido.Store[string, userMappingIndex]
+	digestStore       fido.Store[string, DigestInfo]
+	boardStore        fido.Store[string, BoardInfo]
+	postWindowStore   fido.Store[string, PostWindowInfo]
+	overrideStore     fido.Store[string, PROverride]
+	escalationStore   fido.Store[string, EscalationInfo]
+	maintenanceStore  fido.Store[string, MaintenanceInfo]
+	oooStore          fido.Store[string, OOOInfo]
+	redirectStore     fido.Store[string, RepoRedirect]
+	lastDMStore       fido.Store[string, time.Time]
+	lastEventStore    fido.Store[string, time.Time]
+	reloadStore       fido.Store[string, time.Time]
 }
 
 // WithThreadStore sets a custom store for thread data.
@@ -78,6 +184,16 @@ func WithThreadStore(s fido.Store[string, ThreadInfo]) FidoStoreOption {
 	return func(o *fidoStoreOptions) { o.threadStore = s }
 }
 
+// WithThreadIndexStore sets a custom store for the per-org thread index.
+func WithThreadIndexStore(s fido.Store[string, threadIndex]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.threadIndexStore = s }
+}
+
+// WithMessageIndexStore sets a custom store for the index of threads by their PR message.
+func WithMessageIndexStore(s fido.Store[string, messageIndexEntry]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.messageIndexStore = s }
+}
+
 // WithDMStore sets a custom store for DM data.
 func WithDMStore(s fido.Store[string, DMInfo]) FidoStoreOption {
 	return func(o *fidoStoreOptions) { o.dmStore = s }
@@ -93,6 +209,11 @@ func WithReportStore(s fido.Store[string, DailyReportInfo]) FidoStoreOption {
 	return func(o *fidoStoreOptions) { o.reportStore = s }
 }
 
+// WithDMPRStore sets a custom store for DM PR list data.
+func WithDMPRStore(s fido.Store[string, dmPRList]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.dmPRStore = s }
+}
+
 // WithPendingStore sets a custom store for pending DM data.
 func WithPendingStore(s fido.Store[string, pendingDMQueue]) FidoStoreOption {
 	return func(o *fidoStoreOptions) { o.pendingStore = s }
@@ -113,6 +234,66 @@ func WithUserMappingStore(s fido.Store[string, UserMappingInfo]) FidoStoreOption
 	return func(o *fidoStoreOptions) { o.userMappingStore = s }
 }
 
+// WithUserMappingIndexStore sets a custom store for the per-guild user mapping index.
+func WithUserMappingIndexStore(s fido.Store[string, userMappingIndex]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.mappingIdxStore = s }
+}
+
+// WithDigestStore sets a custom store for digest data.
+func WithDigestStore(s fido.Store[string, DigestInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.digestStore = s }
+}
+
+// WithBoardStore sets a custom store for board data.
+func WithBoardStore(s fido.Store[string, BoardInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.boardStore = s }
+}
+
+// WithPostWindowStore sets a custom store for PRs held back for closed post windows.
+func WithPostWindowStore(s fido.Store[string, PostWindowInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.postWindowStore = s }
+}
+
+// WithOverrideStore sets a custom store for per-PR override data.
+func WithOverrideStore(s fido.Store[string, PROverride]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.overrideStore = s }
+}
+
+// WithEscalationStore sets a custom store for escalation data.
+func WithEscalationStore(s fido.Store[string, EscalationInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.escalationStore = s }
+}
+
+// WithMaintenanceStore sets a custom store for maintenance mode data.
+func WithMaintenanceStore(s fido.Store[string, MaintenanceInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.maintenanceStore = s }
+}
+
+// WithOOOStore sets a custom store for out of office data.
+func WithOOOStore(s fido.Store[string, OOOInfo]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.oooStore = s }
+}
+
+// WithRedirectStore sets a custom store for repo redirect data.
+func WithRedirectStore(s fido.Store[string, RepoRedirect]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.redirectStore = s }
+}
+
+// WithLastDMStore sets a custom store for last DM times.
+func WithLastDMStore(s fido.Store[string, time.Time]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.lastDMStore = s }
+}
+
+// WithLastEventStore sets a custom store for last webhook event times.
+func WithLastEventStore(s fido.Store[string, time.Time]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.lastEventStore = s }
+}
+
+// WithReloadStore sets a custom store for forced config reload times.
+func WithReloadStore(s fido.Store[string, time.Time]) FidoStoreOption {
+	return func(o *fidoStoreOptions) { o.reloadStore = s }
+}
+
 // NewFidoStore creates a new fido-backed store.
 // Uses CloudRun backend which auto-detects environment.
 // Use WithThreadStore, WithDMStore, etc. to inject custom stores for testing.
@@ -132,6 +313,24 @@ func NewFidoStore(ctx context.Context, opts ...FidoStoreOption) (*FidoStore, err
 		}
 	}
 
+	threadIndexStore := o.threadIndexStore
+	if threadIndexStore == nil {
+		var err error
+		threadIndexStore, err = cloudrun.New[string, threadIndex](ctx, "discordian-threadindex")
+		if err != nil {
+			return nil, fmt.Errorf("create thread index store: %w", err)
+		}
+	}
+
+	messageIndexStore := o.messageIndexStore
+	if messageIndexStore == nil {
+		var err error
+		messageIndexStore, err = cloudrun.New[string, messageIndexEntry](ctx, "discordian-messageindex")
+		if err != nil {
+			return nil, fmt.Errorf("create message index store: %w", err)
+		}
+	}
+
 	dmStore := o.dmStore
 	if dmStore == nil {
 		var err error
@@ -150,6 +349,15 @@ func NewFidoStore(ctx context.Context, opts ...FidoStoreOption) (*FidoStore, err
 		}
 	}
 
+	dmPRStore := o.dmPRStore
+	if dmPRStore == nil {
+		var err error
+		dmPRStore, err = cloudrun.New[string, dmPRList](ctx, "discordian-dmprs")
+		if err != nil {
+			return nil, fmt.Errorf("create dm pr store: %w", err)
+		}
+	}
+
 	reportStore := o.reportStore
 	if reportStore == nil {
 		var err error
@@ -195,11 +403,129 @@ func NewFidoStore(ctx context.Context, opts ...FidoStoreOption) (*FidoStore, err
 		}
 	}
 
+	mappingIdxStore := o.mappingIdxStore
+	if mappingIdxStore == nil {
+		var err error
+		mappingIdxStore, err = cloudrun.New[string, userMappingIndex](ctx, "discordian-usermappingindex")
+		if err != nil {
+			return nil, fmt.Errorf("create user mapping index store: %w", err)
+		}
+	}
+
+	digestStore := o.digestStore
+	if digestStore == nil {
+		var err error
+		digestStore, err = cloudrun.New[string, DigestInfo](ctx, "discordian-digests")
+		if err != nil {
+			return nil, fmt.Errorf("create digest store: %w", err)
+		}
+	}
+
+	boardStore := o.boardStore
+	if boardStore == nil {
+		var err error
+		boardStore, err = cloudrun.New[string, BoardInfo](ctx, "discordian-boards")
+		if err != nil {
+			return nil, fmt.Errorf("create board store: %w", err)
+		}
+	}
+
+	postWindowStore := o.postWindowStore
+	if postWindowStore == nil {
+		var err error
+		postWindowStore, err = cloudrun.New[string, PostWindowInfo](ctx, "discordian-postwindows")
+		if err != nil {
+			return nil, fmt.Errorf("create post window store: %w", err)
+		}
+	}
+
+	overrideStore := o.overrideStore
+	if overrideStore == nil {
+		var err error
+		overrideStore, err = cloudrun.New[string, PROverride](ctx, "discordian-overrides")
+		if err != nil {
+			return nil, fmt.Errorf("create override store: %w", err)
+		}
+	}
+
+	escalationStore := o.escalationStore
+	if escalationStore == nil {
+		var err error
+		escalationStore, err = cloudrun.New[string, EscalationInfo](ctx, "discordian-escalations")
+		if err != nil {
+			return nil, fmt.Errorf("create escalation store: %w", err)
+		}
+	}
+
+	maintenanceStore := o.maintenanceStore
+	if maintenanceStore == nil {
+		var err error
+		maintenanceStore, err = cloudrun.New[string, MaintenanceInfo](ctx, "discordian-maintenance")
+		if err != nil {
+			return nil, fmt.Errorf("create maintenance store: %w", err)
+		}
+	}
+
+	oooStore := o.oooStore
+	if oooStore == nil {
+		var err error
+		oooStore, err = cloudrun.New[string, OOOInfo](ctx, "discordian-ooo")
+		if err != nil {
+			return nil, fmt.Errorf("create ooo store: %w", err)
+		}
+	}
+
+	redirectStore := o.redirectStore
+	if redirectStore == nil {
+		var err error
+		redirectStore, err = cloudrun.New[string, RepoRedirect](ctx, "discordian-redirects")
+		if err != nil {
+			return nil, fmt.Errorf("create redirect store: %w", err)
+		}
+	}
+
+	lastDMStore := o.lastDMStore
+	if lastDMStore == nil {
+		var err error
+		lastDMStore, err = cloudrun.New[string, time.Time](ctx, "discordian-lastdms")
+		if err != nil {
+			return nil, fmt.Errorf("create last dm store: %w", err)
+		}
+	}
+
+	lastEventStore := o.lastEventStore
+	if lastEventStore == nil {
+		var err error
+		lastEventStore, err = cloudrun.New[string, time.Time](ctx, "discordian-lastevents")
+		if err != nil {
+			return nil, fmt.Errorf("create last event store: %w", err)
+		}
+	}
+
+	reloadStore := o.reloadStore
+	if reloadStore == nil {
+		var err error
+		reloadStore, err = cloudrun.New[string, time.Time](ctx, "discordian-reloads")
+		if err != nil {
+			return nil, fmt.Errorf("create reload store: %w", err)
+		}
+	}
+
 	threads, err := fido.NewTiered(threadStore, fido.TTL(threadTTL))
 	if err != nil {
 		return nil, fmt.Errorf("create thread cache: %w", err)
 	}
 
+	threadIdx, err := fido.NewTiered(threadIndexStore, fido.TTL(threadIndexTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create thread index cache: %w", err)
+	}
+
+	messageIdx, err := fido.NewTiered(messageIndexStore, fido.TTL(threadIndexTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create message index cache: %w", err)
+	}
+
 	dmInfo, err := fido.NewTiered(dmStore, fido.TTL(dmInfoTTL))
 	if err != nil {
 		return nil, fmt.Errorf("create dm cache: %w", err)
@@ -210,6 +536,11 @@ func NewFidoStore(ctx context.Context, opts ...FidoStoreOption) (*FidoStore, err
 		return nil, fmt.Errorf("create dm user list cache: %w", err)
 	}
 
+	dmPRLists, err := fido.NewTiered(dmPRStore, fido.TTL(dmUserListTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create dm pr list cache: %w", err)
+	}
+
 	dailyReports, err := fido.NewTiered(reportStore, fido.TTL(dailyReportTTL))
 	if err != nil {
 		return nil, fmt.Errorf("create report cache: %w", err)
@@ -235,19 +566,111 @@ func NewFidoStore(ctx context.Context, opts ...FidoStoreOption) (*FidoStore, err
 		return nil, fmt.Errorf("create user mapping cache: %w", err)
 	}
 
+	mappingIdx, err := fido.NewTiered(mappingIdxStore, fido.TTL(userMappingTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create user mapping index cache: %w", err)
+	}
+
+	digests, err := fido.NewTiered(digestStore, fido.TTL(digestTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create digest cache: %w", err)
+	}
+
+	boards, err := fido.NewTiered(boardStore, fido.TTL(boardTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create board cache: %w", err)
+	}
+
+	postWindows, err := fido.NewTiered(postWindowStore, fido.TTL(postWindowTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create post window cache: %w", err)
+	}
+
+	overrides, err := fido.NewTiered(overrideStore, fido.TTL(overrideTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create override cache: %w", err)
+	}
+
+	escalations, err := fido.NewTiered(escalationStore, fido.TTL(escalationTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create escalation cache: %w", err)
+	}
+
+	maintenance, err := fido.NewTiered(maintenanceStore, fido.TTL(maintenanceTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create maintenance cache: %w", err)
+	}
+
+	ooo, err := fido.NewTiered(oooStore, fido.TTL(oooTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create ooo cache: %w", err)
+	}
+
+	redirects, err := fido.NewTiered(redirectStore, fido.TTL(redirectTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create redirect cache: %w", err)
+	}
+
+	lastDMs, err := fido.NewTiered(lastDMStore, fido.TTL(lastDMTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create last dm cache: %w", err)
+	}
+
+	lastEvents, err := fido.NewTiered(lastEventStore, fido.TTL(lastEventTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create last event cache: %w", err)
+	}
+
+	reloads, err := fido.NewTiered(reloadStore, fido.TTL(reloadTTL))
+	if err != nil {
+		return nil, fmt.Errorf("create reload cache: %w", err)
+	}
+
 	slog.Info("initialized fido store")
 	return &FidoStore{
 		threads:      threads,
+		threadIndex:  threadIdx,
+		messageIndex: messageIdx,
 		dmInfo:       dmInfo,
 		dmUserLists:  dmUserLists,
+		dmPRLists:    dmPRLists,
 		dailyReports: dailyReports,
 		pendingDMs:   pendingDMs,
 		events:       events,
 		claims:       claims,
 		userMappings: userMappings,
+		mappingIndex: mappingIdx,
+		digests:      digests,
+		boards:       boards,
+		postWindows:  postWindows,
+		overrides:    overrides,
+		escalations:  escalations,
+		maintenance:  maintenance,
+		ooo:          ooo,
+		redirects:    redirects,
+		lastDMs:      lastDMs,
+		lastEvents:   lastEvents,
+		reloads:      reloads,
+		eventKeys:    make(map[string]time.Time),
 	}, nil
 }
 
+// persisted reads a value as persisted, bypassing the memory cache, for values any instance
+// can change that this instance must see promptly. It falls back to the memory cache if the
+// persistence layer can't be read.
+func persisted[V any](ctx context.Context, cache *fido.TieredCache[string, V], key string) (V, bool, error) {
+	value, expiry, found, err := cache.Store.Get(ctx, key)
+	if err != nil {
+		slog.Debug("persistence read error, using cached value", "key", key, "error", err)
+		return cache.Get(ctx, key)
+	}
+	if !found || (!expiry.IsZero() && time.Now().After(expiry)) {
+		var zero V
+		return zero, false, nil
+	}
+	return value, true, nil
+}
+
 // Thread retrieves thread info for a PR.
 func (s *FidoStore) Thread(ctx context.Context, owner, repo string, number int, channelID string) (ThreadInfo, bool) {
 	key := fmt.Sprintf("%s/%s/%d/%s", owner, repo, number, channelID)
@@ -263,9 +686,255 @@ func (s *FidoStore) Thread(ctx context.Context, owner, repo string, number int,
 func (s *FidoStore) SaveThread(ctx context.Context, owner, repo string, number int, channelID string, info ThreadInfo) error {
 	key := fmt.Sprintf("%s/%s/%d/%s", owner, repo, number, channelID)
 	info.UpdatedAt = time.Now()
+	prev, found, err := s.threads.Get(ctx, key)
+	found = err == nil && found
+	if found && !prev.FirstSeenAt.IsZero() {
+		info.FirstSeenAt = prev.FirstSeenAt
+	} else if info.FirstSeenAt.IsZero() {
+		info.FirstSeenAt = info.UpdatedAt
+	}
+	if found && !prev.FirstReviewAt.IsZero() {
+		info.FirstReviewAt = prev.FirstReviewAt
+	}
+	if err := s.threads.Set(ctx, key, info); err != nil {
+		return err
+	}
+
+	// Record the thread in the org index so it can be listed later, and its message in the
+	// message index. Renewing an entry is only needed once in a while, as it just has to
+	// outlive the thread.
+	now := time.Now()
+	s.indexMessage(ctx, key, owner, repo, number, channelID, info, now)
+	if idx, found, err := s.threadIndex.Get(ctx, owner); err == nil && found {
+		if entry, ok := idx.Threads[key]; ok && entry.Expires.After(now.Add(threadTTL-indexRefresh)) {
+			return nil
+		}
+	}
+	s.updateThreadIndex(ctx, owner, func(idx *threadIndex) {
+		idx.Threads[key] = threadIndexEntry{Owner: owner, Repo: repo, Number: number, Expires: now.Add(threadTTL)}
+	})
+	// Don't fail the overall operation on index errors - the thread info is saved
+	return nil
+}
+
+// indexMessage records which thread a saved thread's PR message belongs to, for
+// ThreadForMessage. An entry left behind when a thread's message is replaced points at a
+// thread that no longer has it, and ThreadForMessage ignores it until it expires.
+func (s *FidoStore) indexMessage(
+	ctx context.Context, key, owner, repo string, number int, channelID string, info ThreadInfo, now time.Time,
+) {
+	mk := messageKey(channelID, info)
+	if mk == "" {
+		return
+	}
+	want := messageIndexEntry{Owner: owner, Repo: repo, Number: number, ChannelID: channelID}
+	if entry, found, err := s.messageIndex.Get(ctx, mk); err == nil && found {
+		current := entry
+		current.Expires = time.Time{}
+		if current == want && entry.Expires.After(now.Add(threadTTL-indexRefresh)) {
+			return
+		}
+	}
+	want.Expires = now.Add(threadTTL)
+	if err := s.messageIndex.Set(ctx, mk, want); err != nil {
+		// Don't fail the save; the message just can't be found by ThreadForMessage
+		slog.Warn("failed to index thread message", "key", key, "error", err)
+	}
+}
+
+// ThreadForMessage returns the tracked thread whose PR message is messageID in channelID.
+func (s *FidoStore) ThreadForMessage(ctx context.Context, channelID, messageID string) (TrackedThread, bool) {
+	mk := channelID + "/" + messageID
+	entry, found, err := s.messageIndex.Get(ctx, mk)
+	if err != nil || !found {
+		return TrackedThread{}, false
+	}
+	// As persisted: another instance may have replaced the message since this one cached it
+	info, found, err := persisted(ctx, s.threads, fmt.Sprintf("%s/%s/%d/%s", entry.Owner, entry.Repo, entry.Number, entry.ChannelID))
+	if err != nil || !found || messageKey(entry.ChannelID, info) != mk {
+		return TrackedThread{}, false
+	}
+	return TrackedThread{Owner: entry.Owner, Repo: entry.Repo, Number: entry.Number, Info: info}, true
+}
+
+// updateThreadIndex applies update to the org's thread index, as persisted, and drops entries
+// whose threads have expired. Every instance writes the index, so the update holds a lease on it.
+func (s *FidoStore) updateThreadIndex(ctx context.Context, owner string, update func(idx *threadIndex)) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	release := s.lease(ctx, "threadindex:"+owner)
+	defer release()
+
+	// Another instance may have updated the index since this one last read it
+	idx, _, _, err := s.threadIndex.Store.Get(ctx, owner)
+	if err != nil {
+		slog.Warn("failed to load thread index, skipping update", "owner", owner, "error", err)
+		return
+	}
+	if idx.Threads == nil {
+		idx.Threads = make(map[string]threadIndexEntry)
+	}
+	update(&idx)
+
+	now := time.Now()
+	for key, entry := range idx.Threads {
+		switch {
+		case entry.Expires.IsZero():
+			idx.Threads[key] = threadIndexEntry{Owner: entry.Owner, Repo: entry.Repo, Number: entry.Number, Expires: now.Add(threadTTL)}
+		case now.After(entry.Expires):
+			delete(idx.Threads, key)
+		}
+	}
+	if err := s.threadIndex.Set(ctx, owner, idx); err != nil {
+		slog.Warn("failed to persist thread index", "owner", owner, "error", err)
+	}
+}
+
+// loadThreadIndex returns the org's thread index as persisted, which includes threads
+// other instances saved, falling back to this instance's copy if it can't be read.
+func (s *FidoStore) loadThreadIndex(ctx context.Context, owner string) (threadIndex, bool) {
+	idx, _, found, err := s.threadIndex.Store.Get(ctx, owner)
+	if err == nil {
+		return idx, found
+	}
+	slog.Debug("thread index lookup error", "owner", owner, "error", err)
+	idx, found, err = s.threadIndex.Get(ctx, owner)
+	return idx, err == nil && found
+}
+
+// lease takes a short claim on a value every instance updates, so their read-modify-write
+// updates don't overwrite each other, and returns a func releasing it. It waits for another
+// instance's lease to be released or lapse. The persistence layer has no conditional writes,
+// so after claiming it reads the lease back to check no other instance claimed it meanwhile.
+// Like the other claims, it fails open if the claim store can't be read.
+func (s *FidoStore) lease(ctx context.Context, key string) (release func()) {
+	claimKey := "lease:" + key
+	for {
+		s.leaseMu.Lock()
+		expiry, found, err := s.claimStoreValue(ctx, claimKey)
+		if err != nil {
+			s.leaseMu.Unlock()
+			slog.Debug("lease check error", "key", claimKey, "error", err)
+			return func() {}
+		}
+		if !found || !time.Now().Before(expiry) {
+			mine := time.Now().Add(leaseTTL)
+			if err := s.claims.Set(ctx, claimKey, mine); err != nil {
+				s.leaseMu.Unlock()
+				slog.Debug("lease set error", "key", claimKey, "error", err)
+				return func() {}
+			}
+			got, found, err := s.claimStoreValue(ctx, claimKey)
+			s.leaseMu.Unlock()
+			if err != nil || !found || got.Equal(mine) {
+				return func() {
+					if err := s.claims.Delete(context.WithoutCancel(ctx), claimKey); err != nil {
+						slog.Debug("lease release error", "key", claimKey, "error", err)
+					}
+				}
+			}
+		} else {
+			s.leaseMu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return func() {}
+		case <-time.After(leaseRetry):
+		}
+	}
+}
+
+// claimStoreValue reads a claim as persisted, rather than this instance's copy of it.
+// A claim this instance holds but couldn't persist is still honored.
+func (s *FidoStore) claimStoreValue(ctx context.Context, claimKey string) (time.Time, bool, error) {
+	expiry, _, found, err := s.claims.Store.Get(ctx, claimKey)
+	if err != nil || found {
+		return expiry, found, err
+	}
+	local, found, err := s.claims.Get(ctx, claimKey)
+	return local, found, err
+}
+
+// RemoveThread forgets a PR's thread/message in a channel and drops it from the org index.
+func (s *FidoStore) RemoveThread(ctx context.Context, owner, repo string, number int, channelID string) error {
+	key := fmt.Sprintf("%s/%s/%d/%s", owner, repo, number, channelID)
+	if err := s.threads.Delete(ctx, key); err != nil {
+		return err
+	}
+	// The thread itself is gone; a stale index entry left by an error is skipped by ListThreads
+	s.updateThreadIndex(ctx, owner, func(idx *threadIndex) {
+		delete(idx.Threads, key)
+	})
+	return nil
+}
+
+// SetThreadMessageIDs records the messages a text channel notification spans.
+func (s *FidoStore) SetThreadMessageIDs(
+	ctx context.Context, owner, repo string, number int, channelID string, messageIDs []string,
+) error {
+	key := fmt.Sprintf("%s/%s/%d/%s", owner, repo, number, channelID)
+	info, found, err := s.threads.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("get thread: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("no thread for %s/%s#%d in channel %s", owner, repo, number, channelID)
+	}
+	setMessageIDs(&info, messageIDs)
+	info.UpdatedAt = time.Now()
 	return s.threads.Set(ctx, key, info)
 }
 
+// ListThreads returns all tracked threads/messages for an org.
+// Threads that have expired from the thread store are skipped.
+func (s *FidoStore) ListThreads(ctx context.Context, owner string) []TrackedThread {
+	idx, found := s.loadThreadIndex(ctx, owner)
+	if !found {
+		return nil
+	}
+
+	threads := make([]TrackedThread, 0, len(idx.Threads))
+	for key, entry := range idx.Threads {
+		info, ok, err := s.threads.Get(ctx, key)
+		if err != nil || !ok {
+			continue
+		}
+		threads = append(threads, TrackedThread{Owner: entry.Owner, Repo: entry.Repo, Number: entry.Number, Info: info})
+	}
+	return threads
+}
+
+// MessagesForPR returns the PR's messages in every channel it was posted to. The org's
+// thread index doubles as the PR's, as its keys start with the PR.
+func (s *FidoStore) MessagesForPR(ctx context.Context, prURL string) []MessageRef {
+	owner, repo, number, ok := parsePRURL(prURL)
+	if !ok {
+		return nil
+	}
+	idx, found := s.loadThreadIndex(ctx, owner)
+	if !found {
+		return nil
+	}
+
+	prefix := fmt.Sprintf("%s/%s/%d/", owner, repo, number)
+	var refs []MessageRef
+	for key := range idx.Threads {
+		channelID, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		info, ok, err := s.threads.Get(ctx, key)
+		if err != nil || !ok {
+			continue
+		}
+		refs = append(refs, messageRef(channelID, info))
+	}
+	slices.SortFunc(refs, func(a, b MessageRef) int { return strings.Compare(a.ChannelID, b.ChannelID) })
+	return refs
+}
+
 // ClaimThread attempts to claim a thread for creation.
 // Returns true if the claim was successful, false if another instance already claimed it.
 func (s *FidoStore) ClaimThread(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool {
@@ -302,6 +971,34 @@ func (s *FidoStore) ClaimThread(ctx context.Context, owner, repo string, number
 	return true
 }
 
+// RenewThreadClaim extends a thread claim that hasn't lapsed.
+func (s *FidoStore) RenewThreadClaim(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool {
+	claimKey := fmt.Sprintf("claim:thread:%s/%s/%d/%s", owner, repo, number, channelID)
+
+	expiry, found, err := s.claims.Get(ctx, claimKey)
+	if err != nil {
+		slog.Debug("claim check error", "key", claimKey, "error", err)
+		return false
+	}
+	if !found || !time.Now().Before(expiry) {
+		return false
+	}
+	if err := s.claims.Set(ctx, claimKey, time.Now().Add(ttl)); err != nil {
+		slog.Warn("failed to renew claim", "key", claimKey, "error", err)
+		return false
+	}
+	return true
+}
+
+// ReleaseThreadClaim frees a thread claim so it can be claimed again immediately.
+func (s *FidoStore) ReleaseThreadClaim(ctx context.Context, owner, repo string, number int, channelID string) error {
+	claimKey := fmt.Sprintf("claim:thread:%s/%s/%d/%s", owner, repo, number, channelID)
+	if err := s.claims.Delete(ctx, claimKey); err != nil {
+		return fmt.Errorf("release claim: %w", err)
+	}
+	return nil
+}
+
 // DMInfo retrieves DM info for a user/PR.
 func (s *FidoStore) DMInfo(ctx context.Context, userID, prURL string) (DMInfo, bool) {
 	key := fmt.Sprintf("%s:%s", userID, prURL)
@@ -341,6 +1038,19 @@ func (s *FidoStore) SaveDMInfo(ctx context.Context, userID, prURL string, info D
 		// Don't fail the overall operation - the DM info is saved
 	}
 
+	// Update persisted PR list for this user
+	prList, _, err := s.dmPRLists.Get(ctx, userID)
+	if err != nil {
+		slog.Debug("dm pr list fetch error, creating new", "user_id", userID, "error", err)
+	}
+	if prList.PRURLs == nil {
+		prList.PRURLs = make(map[string]bool)
+	}
+	prList.PRURLs[prURL] = true
+	if err := s.dmPRLists.Set(ctx, userID, prList); err != nil {
+		slog.Warn("failed to persist dm pr list", "user_id", userID, "error", err)
+	}
+
 	return nil
 }
 
@@ -376,6 +1086,40 @@ func (s *FidoStore) ClaimDM(ctx context.Context, userID, prURL string, ttl time.
 	return true
 }
 
+// ReleaseDMClaim frees a DM claim so it can be claimed again immediately.
+func (s *FidoStore) ReleaseDMClaim(ctx context.Context, userID, prURL string) error {
+	claimKey := fmt.Sprintf("claim:dm:%s:%s", userID, prURL)
+	if err := s.claims.Delete(ctx, claimKey); err != nil {
+		return fmt.Errorf("release DM claim: %w", err)
+	}
+	return nil
+}
+
+// ClaimConfigReload attempts to claim the config reload for an org.
+// Returns true if the claim was successful, false if another instance already claimed it.
+func (s *FidoStore) ClaimConfigReload(ctx context.Context, org string, ttl time.Duration) bool {
+	claimKey := "claim:reload:" + org
+
+	expiry, found, err := s.claims.Get(ctx, claimKey)
+	if err != nil {
+		slog.Debug("config reload claim check error", "key", claimKey, "error", err)
+		// On error, allow claim to proceed (fail open)
+	}
+	if found && time.Now().Before(expiry) {
+		slog.Debug("config reload already claimed by another instance", "org", org)
+		return false
+	}
+
+	expiry = time.Now().Add(ttl)
+	if err := s.claims.Set(ctx, claimKey, expiry); err != nil {
+		slog.Warn("failed to set config reload claim", "key", claimKey, "error", err)
+		return false
+	}
+
+	slog.Debug("successfully claimed config reload", "org", org, "ttl", ttl)
+	return true
+}
+
 // ListDMUsers returns all user IDs who received DMs for a PR.
 func (s *FidoStore) ListDMUsers(ctx context.Context, prURL string) []string {
 	userList, found, err := s.dmUserLists.Get(ctx, prURL)
@@ -394,6 +1138,29 @@ func (s *FidoStore) ListDMUsers(ctx context.Context, prURL string) []string {
 	return result
 }
 
+// ListPRsForUser returns the PRs a user was DMed about, with their DM info.
+func (s *FidoStore) ListPRsForUser(ctx context.Context, userID string) []TrackedDM {
+	prList, found, err := s.dmPRLists.Get(ctx, userID)
+	if err != nil {
+		slog.Debug("dm pr list lookup error", "user_id", userID, "error", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	dms := make([]TrackedDM, 0, len(prList.PRURLs))
+	for prURL := range prList.PRURLs {
+		// DM info can expire before the list does
+		info, exists := s.DMInfo(ctx, userID, prURL)
+		if !exists {
+			continue
+		}
+		dms = append(dms, TrackedDM{PRURL: prURL, Info: info})
+	}
+	return dms
+}
+
 // WasProcessed checks if an event was already processed.
 func (s *FidoStore) WasProcessed(ctx context.Context, eventKey string) bool {
 	expiry, found, err := s.events.Get(ctx, eventKey)
@@ -409,10 +1176,73 @@ func (s *FidoStore) WasProcessed(ctx context.Context, eventKey string) bool {
 
 // MarkProcessed marks an event as processed.
 func (s *FidoStore) MarkProcessed(ctx context.Context, eventKey string, ttl time.Duration) error {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
 	expiry := time.Now().Add(ttl)
+	s.eventKeys[eventKey] = expiry
 	return s.events.Set(ctx, eventKey, expiry)
 }
 
+// ClaimEvent claims an event for processing unless it was already claimed or processed.
+// The claim is stored with the event's dedup entry, so MarkProcessed extends it.
+// Like the other claims, check-and-set is atomic within an instance and best-effort across instances.
+func (s *FidoStore) ClaimEvent(ctx context.Context, eventKey string, ttl time.Duration) bool {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
+	expiry, found, err := s.events.Get(ctx, eventKey)
+	if err != nil {
+		slog.Debug("event claim check error", "key", eventKey, "error", err)
+		// On error, allow claim to proceed (fail open)
+	}
+	if found && time.Now().Before(expiry) {
+		return false
+	}
+
+	expiry = time.Now().Add(ttl)
+	if err := s.events.Set(ctx, eventKey, expiry); err != nil {
+		slog.Warn("failed to set event claim", "key", eventKey, "error", err)
+		return false
+	}
+	s.eventKeys[eventKey] = expiry
+	return true
+}
+
+// ClearProcessed removes dedup entries whose keys start with keyPrefix, so those events
+// are processed again if redelivered. An empty prefix clears every entry.
+// The persistence layer can't list keys, so a non-empty prefix only reaches events
+// this instance recorded since it started.
+func (s *FidoStore) ClearProcessed(ctx context.Context, keyPrefix string) (int, error) {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
+	if keyPrefix == "" {
+		clear(s.eventKeys)
+		// Cached entries are also persisted, and the tiered Flush counts both copies,
+		// so count the persisted entries and only fall back to the cached ones
+		persisted, err := s.events.Store.Flush(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("flush events: %w", err)
+		}
+		cached, err := s.events.Flush(ctx)
+		return max(persisted, cached), err
+	}
+
+	cleared := 0
+	for key := range s.eventKeys {
+		if !strings.HasPrefix(key, keyPrefix) {
+			continue
+		}
+		if err := s.events.Delete(ctx, key); err != nil {
+			return cleared, fmt.Errorf("delete event %s: %w", key, err)
+		}
+		delete(s.eventKeys, key)
+		cleared++
+	}
+	return cleared, nil
+}
+
 // DailyReportInfo retrieves daily report info for a user.
 func (s *FidoStore) DailyReportInfo(ctx context.Context, userID string) (DailyReportInfo, bool) {
 	info, found, err := s.dailyReports.Get(ctx, userID)
@@ -428,6 +1258,209 @@ func (s *FidoStore) SaveDailyReportInfo(ctx context.Context, userID string, info
 	return s.dailyReports.Set(ctx, userID, info)
 }
 
+// Digest retrieves the digest state for a channel, as persisted, since every instance adds to it.
+func (s *FidoStore) Digest(ctx context.Context, channelID string) (DigestInfo, bool) {
+	info, found, err := persisted(ctx, s.digests, channelID)
+	if err != nil {
+		slog.Debug("digest lookup error", "channel_id", channelID, "error", err)
+		return DigestInfo{}, false
+	}
+	return info, found
+}
+
+// SaveDigest stores the digest state for a channel.
+func (s *FidoStore) SaveDigest(ctx context.Context, channelID string, info DigestInfo) error {
+	return s.digests.Set(ctx, channelID, info)
+}
+
+// UpdateDigest applies update to the digest state for a channel, holding a lease on it so
+// other instances' updates aren't overwritten.
+func (s *FidoStore) UpdateDigest(ctx context.Context, channelID string, update func(info *DigestInfo) bool) error {
+	release := s.lease(ctx, "digest:"+channelID)
+	defer release()
+
+	info, _, err := persisted(ctx, s.digests, channelID)
+	if err != nil {
+		return fmt.Errorf("load digest: %w", err)
+	}
+	if !update(&info) {
+		return nil
+	}
+	return s.digests.Set(ctx, channelID, info)
+}
+
+// Board retrieves the board state for a channel, as persisted, since every instance updates it.
+func (s *FidoStore) Board(ctx context.Context, channelID string) (BoardInfo, bool) {
+	info, found, err := persisted(ctx, s.boards, channelID)
+	if err != nil {
+		slog.Debug("board lookup error", "channel_id", channelID, "error", err)
+		return BoardInfo{}, false
+	}
+	return info, found
+}
+
+// SaveBoard stores the board state for a channel.
+func (s *FidoStore) SaveBoard(ctx context.Context, channelID string, info BoardInfo) error {
+	return s.boards.Set(ctx, channelID, info)
+}
+
+// UpdateBoard applies update to the board state for a channel, holding a lease on it so
+// other instances' updates aren't overwritten.
+func (s *FidoStore) UpdateBoard(ctx context.Context, channelID string, update func(info *BoardInfo) bool) error {
+	release := s.lease(ctx, "board:"+channelID)
+	defer release()
+
+	info, _, err := persisted(ctx, s.boards, channelID)
+	if err != nil {
+		return fmt.Errorf("load board: %w", err)
+	}
+	if !update(&info) {
+		return nil
+	}
+	return s.boards.Set(ctx, channelID, info)
+}
+
+// UpdatePostWindows applies update to the PRs an org holds back for closed post windows,
+// holding a lease on them so other instances' updates aren't overwritten.
+func (s *FidoStore) UpdatePostWindows(ctx context.Context, org string, update func(info *PostWindowInfo) bool) error {
+	release := s.lease(ctx, "postwindow:"+org)
+	defer release()
+
+	info, _, err := persisted(ctx, s.postWindows, org)
+	if err != nil {
+		return fmt.Errorf("load post windows: %w", err)
+	}
+	if !update(&info) {
+		return nil
+	}
+	return s.postWindows.Set(ctx, org, info)
+}
+
+// PROverride retrieves the comment directive overrides for a PR.
+func (s *FidoStore) PROverride(ctx context.Context, prURL string) (PROverride, bool) {
+	override, found, err := s.overrides.Get(ctx, prURL)
+	if err != nil {
+		slog.Debug("override lookup error", "pr_url", prURL, "error", err)
+		return PROverride{}, false
+	}
+	return override, found
+}
+
+// SavePROverride stores the comment directive overrides for a PR.
+func (s *FidoStore) SavePROverride(ctx context.Context, prURL string, override PROverride) error {
+	return s.overrides.Set(ctx, prURL, override)
+}
+
+// Escalation retrieves review escalation progress for a PR in a channel.
+func (s *FidoStore) Escalation(ctx context.Context, channelID, prURL string) (EscalationInfo, bool) {
+	info, found, err := s.escalations.Get(ctx, channelID+":"+prURL)
+	if err != nil {
+		slog.Debug("escalation lookup error", "key", channelID+":"+prURL, "error", err)
+		return EscalationInfo{}, false
+	}
+	return info, found
+}
+
+// SaveEscalation stores review escalation progress for a PR in a channel.
+func (s *FidoStore) SaveEscalation(ctx context.Context, channelID, prURL string, info EscalationInfo) error {
+	return s.escalations.Set(ctx, channelID+":"+prURL, info)
+}
+
+// Maintenance retrieves an org's maintenance mode. Any instance can turn it off, so it's
+// read as persisted rather than from this instance's memory cache.
+func (s *FidoStore) Maintenance(ctx context.Context, org string) (MaintenanceInfo, bool) {
+	info, found, err := persisted(ctx, s.maintenance, org)
+	if err != nil {
+		slog.Debug("maintenance lookup error", "org", org, "error", err)
+		return MaintenanceInfo{}, false
+	}
+	return info, found
+}
+
+// SaveMaintenance stores an org's maintenance mode.
+func (s *FidoStore) SaveMaintenance(ctx context.Context, org string, info MaintenanceInfo) error {
+	return s.maintenance.Set(ctx, org, info)
+}
+
+// OOO retrieves a user's out of office status. Users set it through whichever instance
+// answers their command, so this reads what's persisted.
+func (s *FidoStore) OOO(ctx context.Context, userID string) (OOOInfo, bool) {
+	info, found, err := persisted(ctx, s.ooo, userID)
+	if err != nil {
+		slog.Debug("ooo lookup error", "user_id", userID, "error", err)
+		return OOOInfo{}, false
+	}
+	return info, found
+}
+
+// SaveOOO stores a user's out of office status.
+func (s *FidoStore) SaveOOO(ctx context.Context, userID string, info OOOInfo) error {
+	return s.ooo.Set(ctx, userID, info)
+}
+
+// RepoRedirect retrieves a repo's temporary channel redirect. Redirects are set through
+// whichever instance answers the command, so this reads what's persisted.
+func (s *FidoStore) RepoRedirect(ctx context.Context, org, repo string) (RepoRedirect, bool) {
+	redirect, found, err := persisted(ctx, s.redirects, redirectKey(org, repo))
+	if err != nil {
+		slog.Debug("redirect lookup error", "org", org, "repo", repo, "error", err)
+		return RepoRedirect{}, false
+	}
+	return redirect, found
+}
+
+// SaveRepoRedirect stores a repo's temporary channel redirect.
+func (s *FidoStore) SaveRepoRedirect(ctx context.Context, org, repo string, redirect RepoRedirect) error {
+	return s.redirects.Set(ctx, redirectKey(org, repo), redirect)
+}
+
+// LastDM retrieves when a user was last DMed. Any instance may have sent the last DM, so
+// this reads what's persisted rather than this instance's copy.
+func (s *FidoStore) LastDM(ctx context.Context, userID string) (time.Time, bool) {
+	sentAt, found, err := persisted(ctx, s.lastDMs, userID)
+	if err != nil {
+		slog.Debug("last dm lookup error", "user_id", userID, "error", err)
+		return time.Time{}, false
+	}
+	return sentAt, found
+}
+
+// SaveLastDM stores when a user was last DMed.
+func (s *FidoStore) SaveLastDM(ctx context.Context, userID string, sentAt time.Time) error {
+	return s.lastDMs.Set(ctx, userID, sentAt)
+}
+
+// LastEventAt retrieves when an org's last webhook event was seen.
+func (s *FidoStore) LastEventAt(ctx context.Context, org string) (time.Time, bool) {
+	seenAt, found, err := s.lastEvents.Get(ctx, org)
+	if err != nil {
+		slog.Debug("last event lookup error", "org", org, "error", err)
+		return time.Time{}, false
+	}
+	return seenAt, found
+}
+
+// SaveLastEventAt stores when an org's last webhook event was seen.
+func (s *FidoStore) SaveLastEventAt(ctx context.Context, org string, seenAt time.Time) error {
+	return s.lastEvents.Set(ctx, org, seenAt)
+}
+
+// ConfigReloadRequestedAt retrieves when an org's config reload was last forced. Reloads are
+// forced on any instance for all of them to follow, so this reads what's persisted.
+func (s *FidoStore) ConfigReloadRequestedAt(ctx context.Context, org string) (time.Time, bool) {
+	requestedAt, found, err := persisted(ctx, s.reloads, org)
+	if err != nil {
+		slog.Debug("config reload lookup error", "org", org, "error", err)
+		return time.Time{}, false
+	}
+	return requestedAt, found
+}
+
+// SaveConfigReloadRequest stores when an org's config reload was forced.
+func (s *FidoStore) SaveConfigReloadRequest(ctx context.Context, org string, requestedAt time.Time) error {
+	return s.reloads.Set(ctx, org, requestedAt)
+}
+
 const pendingQueueKey = "queue" // Single key for all pending DMs
 
 // QueuePendingDM adds a pending DM to the queue.
@@ -497,9 +1530,17 @@ func (s *FidoStore) RemovePendingDM(ctx context.Context, id string) error {
 
 // Cleanup removes expired entries.
 func (s *FidoStore) Cleanup(ctx context.Context) error {
-	// Most entries (threads, dmInfo, dmUserLists, events) are managed by fido cache with automatic TTL cleanup
+	// Most entries (threads, dmInfo, dmUserLists, events, escalations) are managed by fido cache with automatic TTL cleanup
 	now := time.Now()
 
+	s.eventsMu.Lock()
+	for key, expiry := range s.eventKeys {
+		if now.After(expiry) {
+			delete(s.eventKeys, key)
+		}
+	}
+	s.eventsMu.Unlock()
+
 	// Clean up stale pending DMs
 	s.pendingMu.Lock()
 	defer s.pendingMu.Unlock()
@@ -557,6 +1598,10 @@ func (s *FidoStore) SaveUserMapping(ctx context.Context, guildID string, info Us
 	if err := s.userMappings.Set(ctx, key, info); err != nil {
 		return fmt.Errorf("save user mapping: %w", err)
 	}
+	// Saving also renews the index, which must outlive the mappings it lists
+	s.updateUserMappingIndex(ctx, guildID, func(idx *userMappingIndex) {
+		idx.Usernames[info.GitHubUsername] = true
+	})
 
 	slog.Info("saved user mapping",
 		"guild_id", guildID,
@@ -566,15 +1611,68 @@ func (s *FidoStore) SaveUserMapping(ctx context.Context, guildID string, info Us
 	return nil
 }
 
-// ListUserMappings returns all user mappings for a guild.
-func (*FidoStore) ListUserMappings(_ context.Context, guildID string) []UserMappingInfo {
-	// Note: Fido TieredCache doesn't have a List or Scan method,
-	// so we can't efficiently list all mappings without scanning all keys.
-	// For now, return empty slice - this should be populated from usermapping.Mapper cache
-	// or we need to implement a separate index.
-	slog.Warn("ListUserMappings not fully implemented for FidoStore",
-		"guild_id", guildID)
-	return []UserMappingInfo{}
+// RemoveUserMapping deletes the user mapping for a GitHub username in a guild.
+func (s *FidoStore) RemoveUserMapping(ctx context.Context, guildID, gitHubUsername string) error {
+	key := fmt.Sprintf("%s:%s", guildID, gitHubUsername)
+	if err := s.userMappings.Delete(ctx, key); err != nil {
+		return fmt.Errorf("remove user mapping: %w", err)
+	}
+	s.updateUserMappingIndex(ctx, guildID, func(idx *userMappingIndex) {
+		delete(idx.Usernames, gitHubUsername)
+	})
+	return nil
+}
+
+// ListUserMappings returns all user mappings for a guild, including those other instances saved.
+func (s *FidoStore) ListUserMappings(ctx context.Context, guildID string) []UserMappingInfo {
+	idx, found, err := persisted(ctx, s.mappingIndex, guildID)
+	if err != nil || !found {
+		if err != nil {
+			slog.Debug("user mapping index lookup error", "guild_id", guildID, "error", err)
+		}
+		return nil
+	}
+
+	var mappings []UserMappingInfo
+	for _, username := range slices.Sorted(maps.Keys(idx.Usernames)) {
+		key := fmt.Sprintf("%s:%s", guildID, username)
+		// Mappings that expired since they were indexed are dropped on the next index update
+		if info, found, err := persisted(ctx, s.userMappings, key); err == nil && found {
+			mappings = append(mappings, info)
+		}
+	}
+	return mappings
+}
+
+// updateUserMappingIndex applies update to the guild's user mapping index, as persisted.
+// Every instance writes the index, so the update holds a lease on it. Usernames whose
+// mappings have expired are dropped.
+func (s *FidoStore) updateUserMappingIndex(ctx context.Context, guildID string, update func(idx *userMappingIndex)) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	release := s.lease(ctx, "usermappingindex:"+guildID)
+	defer release()
+
+	idx, _, _, err := s.mappingIndex.Store.Get(ctx, guildID)
+	if err != nil {
+		slog.Warn("failed to load user mapping index, skipping update", "guild_id", guildID, "error", err)
+		return
+	}
+	if idx.Usernames == nil {
+		idx.Usernames = make(map[string]bool)
+	}
+	update(&idx)
+
+	for username := range idx.Usernames {
+		key := fmt.Sprintf("%s:%s", guildID, username)
+		if _, found, err := persisted(ctx, s.userMappings, key); err == nil && !found {
+			delete(idx.Usernames, username)
+		}
+	}
+	if err := s.mappingIndex.Set(ctx, guildID, idx); err != nil {
+		slog.Warn("failed to persist user mapping index", "guild_id", guildID, "error", err)
+	}
 }
 
 // Close releases resources.
@@ -584,12 +1682,21 @@ func (s *FidoStore) Close() error {
 	if err := s.threads.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("close threads: %w", err))
 	}
+	if err := s.threadIndex.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close threadIndex: %w", err))
+	}
+	if err := s.messageIndex.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close messageIndex: %w", err))
+	}
 	if err := s.dmInfo.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("close dmInfo: %w", err))
 	}
 	if err := s.dmUserLists.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("close dmUserLists: %w", err))
 	}
+	if err := s.dmPRLists.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close dmPRLists: %w", err))
+	}
 	if err := s.dailyReports.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("close dailyReports: %w", err))
 	}
@@ -605,6 +1712,42 @@ func (s *FidoStore) Close() error {
 	if err := s.userMappings.Close(); err != nil {
 		errs = append(errs, fmt.Errorf("close userMappings: %w", err))
 	}
+	if err := s.mappingIndex.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close mappingIndex: %w", err))
+	}
+	if err := s.digests.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close digests: %w", err))
+	}
+	if err := s.boards.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close boards: %w", err))
+	}
+	if err := s.postWindows.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close postWindows: %w", err))
+	}
+	if err := s.overrides.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close overrides: %w", err))
+	}
+	if err := s.escalations.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close escalations: %w", err))
+	}
+	if err := s.maintenance.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close maintenance: %w", err))
+	}
+	if err := s.ooo.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close ooo: %w", err))
+	}
+	if err := s.redirects.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close redirects: %w", err))
+	}
+	if err := s.lastDMs.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close lastDMs: %w", err))
+	}
+	if err := s.lastEvents.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close lastEvents: %w", err))
+	}
+	if err := s.reloads.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close reloads: %w", err))
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("close errors: %v", errs)
@@ -2,32 +2,106 @@ package state
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/fido"
 	"github.com/codeGROOVE-dev/fido/pkg/store/null"
 )
 
-// newTestFidoStore creates a FidoStore with null stores for testing.
-func newTestFidoStore(t *testing.T) *FidoStore {
+// newTestFidoStore creates a FidoStore with null stores for testing; opts replace any of them.
+func newTestFidoStore(t *testing.T, opts ...FidoStoreOption) *FidoStore {
 	t.Helper()
 	ctx := context.Background()
 
-	store, err := NewFidoStore(ctx,
+	store, err := NewFidoStore(ctx, append([]FidoStoreOption{
 		WithThreadStore(null.New[string, ThreadInfo]()),
+		WithThreadIndexStore(newMapStore[threadIndex]()),
 		WithDMStore(null.New[string, DMInfo]()),
 		WithDMUserStore(null.New[string, dmUserList]()),
+		WithDMPRStore(null.New[string, dmPRList]()),
 		WithReportStore(null.New[string, DailyReportInfo]()),
 		WithPendingStore(null.New[string, pendingDMQueue]()),
 		WithEventStore(null.New[string, time.Time]()),
+		WithClaimStore(newMapStore[time.Time]()),
 		WithUserMappingStore(null.New[string, UserMappingInfo]()),
-	)
+	}, opts...)...)
 	if err != nil {
 		t.Fatalf("failed to create test fido store: %v", err)
 	}
 	return store
 }
 
+// mapStore is an in-memory persistence layer, which FidoStores in a test can share
+// like instances share Datastore. Values are stored encoded, so readers never share them.
+type mapStore[V any] struct {
+	values  map[string][]byte
+	expires map[string]time.Time
+	mu      sync.Mutex
+}
+
+func newMapStore[V any]() *mapStore[V] {
+	return &mapStore[V]{values: make(map[string][]byte), expires: make(map[string]time.Time)}
+}
+
+func (*mapStore[V]) ValidateKey(string) error { return nil }
+
+func (m *mapStore[V]) Get(_ context.Context, key string) (v V, expiry time.Time, found bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, found := m.values[key]
+	expiry = m.expires[key]
+	if !found || (!expiry.IsZero() && time.Now().After(expiry)) {
+		return v, time.Time{}, false, nil
+	}
+	err = json.Unmarshal(data, &v)
+	return v, expiry, true, err
+}
+
+func (m *mapStore[V]) Set(_ context.Context, key string, value V, expiry time.Time) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = data
+	m.expires[key] = expiry
+	return nil
+}
+
+func (m *mapStore[V]) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	delete(m.expires, key)
+	return nil
+}
+
+func (*mapStore[V]) Cleanup(context.Context, time.Duration) (int, error) { return 0, nil }
+
+func (m *mapStore[V]) Flush(context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := len(m.values)
+	clear(m.values)
+	clear(m.expires)
+	return n, nil
+}
+
+func (m *mapStore[V]) Len(context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.values), nil
+}
+
+func (*mapStore[V]) Close() error { return nil }
+
 func TestFidoStore_Thread(t *testing.T) {
 	store := newTestFidoStore(t)
 	defer store.Close() //nolint:errcheck // test cleanup
@@ -60,6 +134,17 @@ func TestFidoStore_Thread(t *testing.T) {
 	if got.UpdatedAt.IsZero() {
 		t.Error("Thread().UpdatedAt should be set")
 	}
+	if got.FirstSeenAt.IsZero() {
+		t.Error("Thread().FirstSeenAt should be set")
+	}
+
+	// An update keeps the first-seen time
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{ThreadID: "thread123", LastState: "approved"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if updated, _ := store.Thread(ctx, "owner", "repo", 1, "chan1"); !updated.FirstSeenAt.Equal(got.FirstSeenAt) {
+		t.Errorf("FirstSeenAt = %v after update, want %v", updated.FirstSeenAt, got.FirstSeenAt)
+	}
 
 	// Different channel returns nothing
 	_, ok = store.Thread(ctx, "owner", "repo", 1, "chan2")
@@ -136,6 +221,42 @@ func TestFidoStore_EventProcessing(t *testing.T) {
 	}
 }
 
+func TestFidoStore_ClearProcessed_All(t *testing.T) {
+	tests := []struct {
+		name  string
+		store fido.Store[string, time.Time]
+	}{
+		{"persisted", newMapStore[time.Time]()},
+		{"memory only", null.New[string, time.Time]()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestFidoStore(t, WithEventStore(tt.store))
+			defer store.Close() //nolint:errcheck // test cleanup
+
+			// One key in the current format, one in the format from before keys were grouped by PR
+			for _, key := range []string{"https://github.com/o/r/pull/1#d1", "d2:https://github.com/o/r/pull/1"} {
+				if err := store.MarkProcessed(ctx, key, time.Hour); err != nil {
+					t.Fatalf("MarkProcessed(%q) error = %v", key, err)
+				}
+			}
+
+			cleared, err := store.ClearProcessed(ctx, "")
+			if err != nil {
+				t.Fatalf("ClearProcessed() error = %v", err)
+			}
+			if cleared != 2 {
+				t.Errorf("ClearProcessed() = %d, want 2", cleared)
+			}
+			if store.WasProcessed(ctx, "d2:https://github.com/o/r/pull/1") {
+				t.Error("WasProcessed() = true after clearing everything")
+			}
+		})
+	}
+}
+
 func TestFidoStore_EventProcessing_Expired(t *testing.T) {
 	store := newTestFidoStore(t)
 	defer store.Close() //nolint:errcheck // test cleanup
@@ -476,6 +597,35 @@ func TestFidoStore_ListDMUsers(t *testing.T) {
 	}
 }
 
+func TestFidoStore_ListPRsForUser(t *testing.T) {
+	store := newTestFidoStore(t)
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	ctx := context.Background()
+	if dms := store.ListPRsForUser(ctx, "user1"); len(dms) != 0 {
+		t.Errorf("ListPRsForUser() = %v, want empty", dms)
+	}
+
+	for _, prURL := range []string{"https://github.com/owner/repo/pull/1", "https://github.com/owner/repo/pull/2"} {
+		if err := store.SaveDMInfo(ctx, "user1", prURL, DMInfo{MessageID: "msg", LastState: "needs_review"}); err != nil {
+			t.Fatalf("SaveDMInfo() error = %v", err)
+		}
+	}
+	if err := store.SaveDMInfo(ctx, "user2", "https://github.com/owner/repo/pull/3", DMInfo{MessageID: "msg"}); err != nil {
+		t.Fatalf("SaveDMInfo() error = %v", err)
+	}
+
+	dms := store.ListPRsForUser(ctx, "user1")
+	if len(dms) != 2 {
+		t.Fatalf("ListPRsForUser() returned %d PRs, want 2", len(dms))
+	}
+	for _, dm := range dms {
+		if dm.Info.LastState != "needs_review" {
+			t.Errorf("ListPRsForUser() %s LastState = %q, want needs_review", dm.PRURL, dm.Info.LastState)
+		}
+	}
+}
+
 func TestFidoStore_DMInfo_LastState(t *testing.T) {
 	store := newTestFidoStore(t)
 	defer store.Close() //nolint:errcheck // test cleanup
@@ -548,6 +698,51 @@ func TestFidoStore_ClaimThread(t *testing.T) {
 	}
 }
 
+func TestFidoStore_ReleaseClaims(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewFidoStore(ctx,
+		WithThreadStore(null.New[string, ThreadInfo]()),
+		WithDMStore(null.New[string, DMInfo]()),
+		WithDMUserStore(null.New[string, dmUserList]()),
+		WithReportStore(null.New[string, DailyReportInfo]()),
+		WithPendingStore(null.New[string, pendingDMQueue]()),
+		WithEventStore(null.New[string, time.Time]()),
+		WithClaimStore(null.New[string, time.Time]()),
+	)
+	if err != nil {
+		t.Fatalf("NewFidoStore() error = %v", err)
+	}
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if !store.ClaimThread(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Fatal("ClaimThread() should succeed on first attempt")
+	}
+	if !store.RenewThreadClaim(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Error("RenewThreadClaim() should succeed for a held claim")
+	}
+	if err := store.ReleaseThreadClaim(ctx, "owner", "repo", 1, "chan1"); err != nil {
+		t.Fatalf("ReleaseThreadClaim() error = %v", err)
+	}
+	if store.RenewThreadClaim(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Error("RenewThreadClaim() should fail once released")
+	}
+	if !store.ClaimThread(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Error("ClaimThread() should succeed immediately after release")
+	}
+
+	prURL := "https://github.com/owner/repo/pull/1"
+	if !store.ClaimDM(ctx, "user123", prURL, time.Minute) {
+		t.Fatal("ClaimDM() should succeed on first attempt")
+	}
+	if err := store.ReleaseDMClaim(ctx, "user123", prURL); err != nil {
+		t.Fatalf("ReleaseDMClaim() error = %v", err)
+	}
+	if !store.ClaimDM(ctx, "user123", prURL, time.Minute) {
+		t.Error("ClaimDM() should succeed immediately after release")
+	}
+}
+
 // TestFidoStore_ClaimDM tests DM claim locking with claim store.
 func TestFidoStore_ClaimDM(t *testing.T) {
 	ctx := context.Background()
@@ -659,18 +854,540 @@ func TestFidoStore_UserMapping(t *testing.T) {
 // TestFidoStore_ListUserMappings tests that ListUserMappings returns empty slice
 func TestFidoStore_ListUserMappings(t *testing.T) {
 	ctx := context.Background()
+	mappings := newMapStore[UserMappingInfo]()
+	index := newMapStore[userMappingIndex]()
+	claims := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx,
+			WithThreadStore(null.New[string, ThreadInfo]()),
+			WithThreadIndexStore(null.New[string, threadIndex]()),
+			WithDMStore(null.New[string, DMInfo]()),
+			WithDMUserStore(null.New[string, dmUserList]()),
+			WithDMPRStore(null.New[string, dmPRList]()),
+			WithReportStore(null.New[string, DailyReportInfo]()),
+			WithPendingStore(null.New[string, pendingDMQueue]()),
+			WithEventStore(null.New[string, time.Time]()),
+			WithClaimStore(claims),
+			WithUserMappingStore(mappings),
+			WithUserMappingIndexStore(index),
+		)
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	if got := a.ListUserMappings(ctx, "guild-123"); len(got) != 0 {
+		t.Errorf("ListUserMappings() = %v before any were saved, want none", got)
+	}
+
+	// Each instance lists the other's mappings
+	if err := a.SaveUserMapping(ctx, "guild-123", UserMappingInfo{GitHubUsername: "alice", DiscordUserID: "111"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	if err := b.SaveUserMapping(ctx, "guild-123", UserMappingInfo{GitHubUsername: "bob", DiscordUserID: "222"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	if err := b.SaveUserMapping(ctx, "guild-456", UserMappingInfo{GitHubUsername: "carol", DiscordUserID: "333"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	for _, store := range []*FidoStore{a, b} {
+		got := store.ListUserMappings(ctx, "guild-123")
+		if len(got) != 2 || got[0].DiscordUserID != "111" || got[1].DiscordUserID != "222" {
+			t.Errorf("ListUserMappings() = %+v, want alice and bob", got)
+		}
+	}
+
+	if err := b.RemoveUserMapping(ctx, "guild-123", "alice"); err != nil {
+		t.Fatalf("RemoveUserMapping() error = %v", err)
+	}
+	if got := a.ListUserMappings(ctx, "guild-123"); len(got) != 1 || got[0].GitHubUsername != "bob" {
+		t.Errorf("ListUserMappings() = %+v after removing alice, want just bob", got)
+	}
+}
+
+func TestFidoStore_MessagesForPR(t *testing.T) {
 	store := newTestFidoStore(t)
 	defer store.Close() //nolint:errcheck // test cleanup
 
-	// ListUserMappings currently returns empty slice and logs a warning
-	// This is a known limitation of FidoStore
-	mappings := store.ListUserMappings(ctx, "guild-123")
+	ctx := context.Background()
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	if got := store.MessagesForPR(ctx, prURL); len(got) != 0 {
+		t.Errorf("MessagesForPR() = %v, want empty", got)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan2", ThreadInfo{ThreadID: "t1", MessageID: "m2", ChannelType: "forum"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 12, "chan1", ThreadInfo{MessageID: "m3"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	want := []MessageRef{
+		{ChannelID: "chan1", ChannelType: "text", MessageID: "m1"},
+		{ChannelID: "chan2", ChannelType: "forum", ThreadID: "t1", MessageID: "m2"},
+	}
+	if got := store.MessagesForPR(ctx, prURL); !reflect.DeepEqual(got, want) {
+		t.Errorf("MessagesForPR() = %+v, want %+v", got, want)
+	}
+	if got := store.MessagesForPR(ctx, "not a PR URL"); got != nil {
+		t.Errorf("MessagesForPR(invalid) = %+v, want nil", got)
+	}
+}
 
-	if mappings == nil {
-		t.Error("ListUserMappings() should not return nil")
+func TestFidoStore_ThreadForMessage(t *testing.T) {
+	ctx := context.Background()
+	threads := newMapStore[ThreadInfo]()
+	messages := newMapStore[messageIndexEntry]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithThreadStore(threads), WithMessageIndexStore(messages))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	if err := a.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := a.SaveThread(ctx, "owner", "repo", 2, "chan2", ThreadInfo{ThreadID: "t2", MessageID: "m2", ChannelType: "forum"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
 	}
 
-	if len(mappings) != 0 {
-		t.Errorf("ListUserMappings() = %v, want empty slice", mappings)
+	// Reactions may reach an instance that didn't post the message
+	if got, ok := b.ThreadForMessage(ctx, "chan1", "m1"); !ok || got.Owner != "owner" || got.Number != 1 {
+		t.Errorf("ThreadForMessage(chan1, m1) = %+v, %v; want PR 1", got, ok)
+	}
+	if got, ok := b.ThreadForMessage(ctx, "t2", "m2"); !ok || got.Number != 2 {
+		t.Errorf("ThreadForMessage(t2, m2) = %+v, %v; want PR 2 by its forum thread", got, ok)
+	}
+	if _, ok := b.ThreadForMessage(ctx, "chan1", "m9"); ok {
+		t.Error("ThreadForMessage() found an unknown message")
+	}
+
+	// The old message's entry is left to expire, but no longer matches
+	if err := a.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1b", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if _, ok := b.ThreadForMessage(ctx, "chan1", "m1"); ok {
+		t.Error("ThreadForMessage() found the replaced message")
+	}
+}
+
+func TestFidoStore_ListThreads(t *testing.T) {
+	store := newTestFidoStore(t)
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	ctx := context.Background()
+
+	if got := store.ListThreads(ctx, "owner"); len(got) != 0 {
+		t.Errorf("ListThreads() = %v, want empty", got)
+	}
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{ThreadID: "t1"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{ThreadID: "t1", LastState: "approved"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "other", "repo", 2, "chan1", ThreadInfo{ThreadID: "t2"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	got := store.ListThreads(ctx, "owner")
+	if len(got) != 1 {
+		t.Fatalf("ListThreads() returned %d threads, want 1", len(got))
+	}
+	if got[0].Repo != "repo" || got[0].Number != 1 {
+		t.Errorf("ListThreads()[0] = %s#%d, want repo#1", got[0].Repo, got[0].Number)
+	}
+	if got[0].Info.LastState != "approved" {
+		t.Errorf("ListThreads()[0].Info.LastState = %q, want latest saved state", got[0].Info.LastState)
+	}
+}
+
+func TestFidoStore_ThreadIndex_SharedAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	threads := newMapStore[ThreadInfo]()
+	index := newMapStore[threadIndex]()
+	claims := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx,
+			WithThreadStore(threads),
+			WithThreadIndexStore(index),
+			WithClaimStore(claims),
+			WithDMStore(null.New[string, DMInfo]()),
+			WithDMUserStore(null.New[string, dmUserList]()),
+			WithDMPRStore(null.New[string, dmPRList]()),
+			WithReportStore(null.New[string, DailyReportInfo]()),
+			WithPendingStore(null.New[string, pendingDMQueue]()),
+			WithEventStore(null.New[string, time.Time]()),
+			WithUserMappingStore(null.New[string, UserMappingInfo]()),
+		)
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	// Each instance lists the other's threads, and neither overwrites the other's entries
+	var wg sync.WaitGroup
+	for n := 1; n <= 10; n++ {
+		store := a
+		if n%2 == 0 {
+			store = b
+		}
+		wg.Go(func() {
+			if err := store.SaveThread(ctx, "owner", "repo", n, "chan1", ThreadInfo{MessageID: "m"}); err != nil {
+				t.Errorf("SaveThread(%d) error = %v", n, err)
+			}
+		})
+	}
+	wg.Wait()
+	for name, store := range map[string]*FidoStore{"a": a, "b": b} {
+		if got := store.ListThreads(ctx, "owner"); len(got) != 10 {
+			t.Errorf("ListThreads() on %s = %d threads, want all 10", name, len(got))
+		}
+	}
+
+	// Removing a thread drops its index entry for every instance
+	if err := b.RemoveThread(ctx, "owner", "repo", 1, "chan1"); err != nil {
+		t.Fatalf("RemoveThread() error = %v", err)
+	}
+	idx, _, _, _ := index.Get(ctx, "owner") //nolint:errcheck // mapStore doesn't fail
+	if _, ok := idx.Threads["owner/repo/1/chan1"]; ok || len(idx.Threads) != 9 {
+		t.Errorf("index = %d entries after RemoveThread, want 9 without the removed thread", len(idx.Threads))
+	}
+}
+
+func TestFidoStore_ThreadIndex_PrunesExpired(t *testing.T) {
+	store := newTestFidoStore(t)
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	ctx := context.Background()
+	expired := threadIndex{Threads: map[string]threadIndexEntry{
+		"owner/repo/1/chan1": {Owner: "owner", Repo: "repo", Number: 1, Expires: time.Now().Add(-time.Hour)},
+		"owner/repo/2/chan1": {Owner: "owner", Repo: "repo", Number: 2},
+	}}
+	if err := store.threadIndex.Store.Set(ctx, "owner", expired, time.Time{}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := store.SaveThread(ctx, "owner", "repo", 3, "chan1", ThreadInfo{MessageID: "m3"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	idx, _, _, _ := store.threadIndex.Store.Get(ctx, "owner") //nolint:errcheck // mapStore doesn't fail
+	if _, ok := idx.Threads["owner/repo/1/chan1"]; ok {
+		t.Error("index kept an expired thread's entry")
+	}
+	if entry, ok := idx.Threads["owner/repo/2/chan1"]; !ok || entry.Expires.IsZero() {
+		t.Errorf("index entry without an expiry = %+v, want it kept with one", entry)
+	}
+	if _, ok := idx.Threads["owner/repo/3/chan1"]; !ok {
+		t.Error("index is missing the saved thread")
+	}
+}
+
+func TestFidoStore_Maintenance_SeesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	shared := newMapStore[MaintenanceInfo]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithMaintenanceStore(shared))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	if err := b.SaveMaintenance(ctx, "org", MaintenanceInfo{On: true}); err != nil {
+		t.Fatalf("SaveMaintenance() error = %v", err)
+	}
+	if info, ok := a.Maintenance(ctx, "org"); !ok || !info.On {
+		t.Fatalf("Maintenance() = %+v, %v, want on", info, ok)
+	}
+
+	// Turned off by the other instance, after this one read it as on
+	if err := b.SaveMaintenance(ctx, "org", MaintenanceInfo{}); err != nil {
+		t.Fatalf("SaveMaintenance() error = %v", err)
+	}
+	if info, _ := a.Maintenance(ctx, "org"); info.On {
+		t.Error("Maintenance() still on after another instance turned it off")
+	}
+}
+
+func TestFidoStore_ConfigReloadRequestedAt_SeesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	shared := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithReloadStore(shared))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	first := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := a.SaveConfigReloadRequest(ctx, "org", first); err != nil {
+		t.Fatalf("SaveConfigReloadRequest() error = %v", err)
+	}
+	if got, ok := a.ConfigReloadRequestedAt(ctx, "org"); !ok || !got.Equal(first) {
+		t.Fatalf("ConfigReloadRequestedAt() = %v, %v, want %v", got, ok, first)
+	}
+
+	// Forced again on the other instance, after this one read the first request
+	second := first.Add(30 * time.Minute)
+	if err := b.SaveConfigReloadRequest(ctx, "org", second); err != nil {
+		t.Fatalf("SaveConfigReloadRequest() error = %v", err)
+	}
+	if got, _ := a.ConfigReloadRequestedAt(ctx, "org"); !got.Equal(second) {
+		t.Errorf("ConfigReloadRequestedAt() = %v, want the other instance's %v", got, second)
+	}
+}
+
+func TestFidoStore_OOO_SeesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	shared := newMapStore[OOOInfo]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithOOOStore(shared))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	until := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	if err := a.SaveOOO(ctx, "user-1", OOOInfo{Until: until}); err != nil {
+		t.Fatalf("SaveOOO() error = %v", err)
+	}
+	if info, ok := b.OOO(ctx, "user-1"); !ok || !info.Until.Equal(until) {
+		t.Fatalf("OOO() = %+v, %v, want until %v", info, ok, until)
+	}
+
+	// Back early, cleared on the other instance after this one read it
+	if err := b.SaveOOO(ctx, "user-1", OOOInfo{}); err != nil {
+		t.Fatalf("SaveOOO() error = %v", err)
+	}
+	if info, _ := a.OOO(ctx, "user-1"); !info.Until.IsZero() {
+		t.Errorf("OOO() = %+v after another instance cleared it, want no end time", info)
+	}
+}
+
+func TestFidoStore_RepoRedirect_SeesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	shared := newMapStore[RepoRedirect]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithRedirectStore(shared))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	until := time.Now().Add(time.Hour)
+	if err := a.SaveRepoRedirect(ctx, "org", "My-Repo", RepoRedirect{Channel: "oncall", Until: until}); err != nil {
+		t.Fatalf("SaveRepoRedirect() error = %v", err)
+	}
+	// Looked up as events spell the repo, whatever the command was given
+	if redirect, ok := b.RepoRedirect(ctx, "org", "my-repo"); !ok || redirect.Channel != "oncall" {
+		t.Fatalf("RepoRedirect() = %+v, %v, want the redirect to oncall", redirect, ok)
+	}
+
+	// Ended on the other instance after this one read it
+	if err := b.SaveRepoRedirect(ctx, "org", "my-repo", RepoRedirect{}); err != nil {
+		t.Fatalf("SaveRepoRedirect() error = %v", err)
+	}
+	if redirect, _ := a.RepoRedirect(ctx, "org", "My-Repo"); redirect.Channel != "" {
+		t.Errorf("RepoRedirect() = %+v after another instance ended it, want none", redirect)
+	}
+}
+
+func TestFidoStore_LastDM_SeesOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	shared := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithLastDMStore(shared))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	first := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	if err := a.SaveLastDM(ctx, "user-1", first); err != nil {
+		t.Fatalf("SaveLastDM() error = %v", err)
+	}
+	if got, ok := a.LastDM(ctx, "user-1"); !ok || !got.Equal(first) {
+		t.Fatalf("LastDM() = %v, %v, want %v", got, ok, first)
+	}
+
+	// DMed again by the other instance, after this one read the first DM
+	second := first.Add(5 * time.Minute)
+	if err := b.SaveLastDM(ctx, "user-1", second); err != nil {
+		t.Fatalf("SaveLastDM() error = %v", err)
+	}
+	if got, _ := a.LastDM(ctx, "user-1"); !got.Equal(second) {
+		t.Errorf("LastDM() = %v, want the other instance's %v", got, second)
+	}
+}
+
+func TestFidoStore_UpdateDigest_SharedAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	digests := newMapStore[DigestInfo]()
+	claims := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithDigestStore(digests), WithClaimStore(claims))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	var wg sync.WaitGroup
+	for n := range 10 {
+		store := a
+		if n%2 == 0 {
+			store = b
+		}
+		wg.Go(func() {
+			err := store.UpdateDigest(ctx, "chan1", func(info *DigestInfo) bool {
+				pending := make(map[string]DigestEntry, len(info.Pending)+1)
+				for url, entry := range info.Pending {
+					pending[url] = entry
+				}
+				pending[fmt.Sprintf("pr-%d", n)] = DigestEntry{Line: "changed"}
+				info.Pending = pending
+				return true
+			})
+			if err != nil {
+				t.Errorf("UpdateDigest() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if info, _ := a.Digest(ctx, "chan1"); len(info.Pending) != 10 {
+		t.Errorf("Pending = %d, want every instance's 10 changes", len(info.Pending))
+	}
+}
+
+func TestFidoStore_UpdatePostWindows_SharedAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	postWindows := newMapStore[PostWindowInfo]()
+	claims := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithPostWindowStore(postWindows), WithClaimStore(claims))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	var wg sync.WaitGroup
+	for n := range 10 {
+		store := a
+		if n%2 == 0 {
+			store = b
+		}
+		wg.Go(func() {
+			err := store.UpdatePostWindows(ctx, "org", func(info *PostWindowInfo) bool {
+				pending := make(map[string]bool, len(info.Pending["chan"])+1)
+				maps.Copy(pending, info.Pending["chan"])
+				pending[fmt.Sprintf("pr-%d", n)] = true
+				info.Pending = map[string]map[string]bool{"chan": pending}
+				return true
+			})
+			if err != nil {
+				t.Errorf("UpdatePostWindows() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	var held int
+	if err := a.UpdatePostWindows(ctx, "org", func(info *PostWindowInfo) bool {
+		held = len(info.Pending["chan"])
+		return false
+	}); err != nil {
+		t.Fatalf("UpdatePostWindows() error = %v", err)
+	}
+	if held != 10 {
+		t.Errorf("held back PRs = %d, want every instance's 10", held)
+	}
+}
+
+func TestFidoStore_UpdateBoard_SharedAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	boards := newMapStore[BoardInfo]()
+	claims := newMapStore[time.Time]()
+	newInstance := func() *FidoStore {
+		store, err := NewFidoStore(ctx, WithBoardStore(boards), WithClaimStore(claims))
+		if err != nil {
+			t.Fatalf("NewFidoStore() error = %v", err)
+		}
+		return store
+	}
+	a, b := newInstance(), newInstance()
+	defer a.Close() //nolint:errcheck // test cleanup
+	defer b.Close() //nolint:errcheck // test cleanup
+
+	var wg sync.WaitGroup
+	for n := range 10 {
+		store := a
+		if n%2 == 0 {
+			store = b
+		}
+		wg.Go(func() {
+			err := store.UpdateBoard(ctx, "chan1", func(info *BoardInfo) bool {
+				prs := make(map[string]BoardEntry, len(info.PRs)+1)
+				for url, entry := range info.PRs {
+					prs[url] = entry
+				}
+				prs[fmt.Sprintf("pr-%d", n)] = BoardEntry{Number: n}
+				info.PRs = prs
+				info.Revision++
+				return true
+			})
+			if err != nil {
+				t.Errorf("UpdateBoard() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if info, _ := b.Board(ctx, "chan1"); len(info.PRs) != 10 || info.Revision != 10 {
+		t.Errorf("board = %d PRs at revision %d, want every instance's 10 changes", len(info.PRs), info.Revision)
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -11,11 +13,26 @@ import (
 // MemoryStore provides an in-memory implementation of Store.
 type MemoryStore struct {
 	threads      map[string]ThreadInfo
+	threadIndex  map[string]TrackedThread     // threadKey -> PR identity, for listing by org
+	prThreads    map[string]map[string]string // prURL -> threadKey -> channelID, for MessagesForPR
+	messageIndex map[string]string            // messageKey -> threadKey, for ThreadForMessage
 	dmInfo       map[string]DMInfo
 	dmUserIndex  map[string]map[string]bool // prURL -> userIDs who received DMs
+	dmPRIndex    map[string]map[string]bool // userID -> prURLs they were DMed about
 	processed    map[string]time.Time
 	pendingDMs   map[string]*PendingDM
 	dailyReports map[string]DailyReportInfo
+	digests      map[string]DigestInfo      // channelID -> digest state
+	boards       map[string]BoardInfo       // channelID -> board state
+	postWindows  map[string]PostWindowInfo  // org -> PRs held back for closed post windows
+	overrides    map[string]PROverride      // prURL -> comment directive overrides
+	escalations  map[string]EscalationInfo  // channelID:prURL -> escalation progress
+	maintenance  map[string]MaintenanceInfo // org -> maintenance mode
+	ooo          map[string]OOOInfo         // Discord user ID -> out of office status
+	redirects    map[string]RepoRedirect    // org/repo -> temporary channel redirect
+	lastDMs      map[string]time.Time       // Discord user ID -> when they were last DMed
+	lastEvents   map[string]time.Time       // org -> when its last webhook event was seen
+	reloads      map[string]time.Time       // org -> when its config reload was last forced
 	userMappings map[string]UserMappingInfo // guildID:gitHubUsername -> UserMappingInfo
 	claims       map[string]time.Time       // claimKey -> expiry time
 	mu           sync.RWMutex
@@ -28,11 +45,26 @@ type MemoryStore struct {
 func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
 		threads:      make(map[string]ThreadInfo),
+		threadIndex:  make(map[string]TrackedThread),
+		prThreads:    make(map[string]map[string]string),
+		messageIndex: make(map[string]string),
 		dmInfo:       make(map[string]DMInfo),
 		dmUserIndex:  make(map[string]map[string]bool),
+		dmPRIndex:    make(map[string]map[string]bool),
 		processed:    make(map[string]time.Time),
 		pendingDMs:   make(map[string]*PendingDM),
 		dailyReports: make(map[string]DailyReportInfo),
+		digests:      make(map[string]DigestInfo),
+		boards:       make(map[string]BoardInfo),
+		postWindows:  make(map[string]PostWindowInfo),
+		overrides:    make(map[string]PROverride),
+		escalations:  make(map[string]EscalationInfo),
+		maintenance:  make(map[string]MaintenanceInfo),
+		ooo:          make(map[string]OOOInfo),
+		redirects:    make(map[string]RepoRedirect),
+		lastDMs:      make(map[string]time.Time),
+		lastEvents:   make(map[string]time.Time),
+		reloads:      make(map[string]time.Time),
 		userMappings: make(map[string]UserMappingInfo),
 		claims:       make(map[string]time.Time),
 		threadRetain: 30 * 24 * time.Hour, // 30 days
@@ -53,6 +85,12 @@ func userMappingKey(guildID, gitHubUsername string) string {
 	return fmt.Sprintf("%s:%s", guildID, gitHubUsername)
 }
 
+// redirectKey is case-insensitive, as GitHub names are: /goose redirect takes the repo
+// as typed, and events carry it as GitHub spells it.
+func redirectKey(org, repo string) string {
+	return strings.ToLower(org + "/" + repo)
+}
+
 // Thread returns thread info for a PR in a channel.
 func (s *MemoryStore) Thread(ctx context.Context, owner, repo string, number int, channelID string) (ThreadInfo, bool) {
 	s.mu.RLock()
@@ -68,7 +106,21 @@ func (s *MemoryStore) SaveThread(ctx context.Context, owner, repo string, number
 	defer s.mu.Unlock()
 
 	info.UpdatedAt = time.Now()
-	s.threads[threadKey(owner, repo, number, channelID)] = info
+	key := threadKey(owner, repo, number, channelID)
+	prev, ok := s.threads[key]
+	if ok && !prev.FirstSeenAt.IsZero() {
+		info.FirstSeenAt = prev.FirstSeenAt
+	} else if info.FirstSeenAt.IsZero() {
+		info.FirstSeenAt = info.UpdatedAt
+	}
+	if ok && !prev.FirstReviewAt.IsZero() {
+		info.FirstReviewAt = prev.FirstReviewAt
+	}
+	if ok {
+		s.forgetMessage(key, channelID, prev)
+	}
+	s.threads[key] = info
+	s.indexThread(key, owner, repo, number, channelID, info)
 
 	slog.Debug("saved thread info",
 		"owner", owner,
@@ -80,6 +132,134 @@ func (s *MemoryStore) SaveThread(ctx context.Context, owner, repo string, number
 	return nil
 }
 
+// RemoveThread forgets a PR's thread/message in a channel.
+func (s *MemoryStore) RemoveThread(_ context.Context, owner, repo string, number int, channelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.forgetThread(threadKey(owner, repo, number, channelID))
+	return nil
+}
+
+// indexThread adds a saved thread to the indexes. Callers hold s.mu.
+func (s *MemoryStore) indexThread(key, owner, repo string, number int, channelID string, info ThreadInfo) {
+	s.threadIndex[key] = TrackedThread{Owner: owner, Repo: repo, Number: number}
+	url := prURL(owner, repo, number)
+	if s.prThreads[url] == nil {
+		s.prThreads[url] = make(map[string]string)
+	}
+	s.prThreads[url][key] = channelID
+	if mk := messageKey(channelID, info); mk != "" {
+		s.messageIndex[mk] = key
+	}
+}
+
+// forgetMessage drops a thread's message from the message index, unless another thread's
+// message has taken its place. Callers hold s.mu.
+func (s *MemoryStore) forgetMessage(key, channelID string, info ThreadInfo) {
+	if mk := messageKey(channelID, info); mk != "" && s.messageIndex[mk] == key {
+		delete(s.messageIndex, mk)
+	}
+}
+
+// forgetThread removes a thread and drops it from the indexes. Callers hold s.mu.
+func (s *MemoryStore) forgetThread(key string) {
+	if tracked, ok := s.threadIndex[key]; ok {
+		url := prURL(tracked.Owner, tracked.Repo, tracked.Number)
+		channelID := s.prThreads[url][key]
+		s.forgetMessage(key, channelID, s.threads[key])
+		delete(s.prThreads[url], key)
+		if len(s.prThreads[url]) == 0 {
+			delete(s.prThreads, url)
+		}
+	}
+	delete(s.threads, key)
+	delete(s.threadIndex, key)
+}
+
+// ThreadForMessage returns the tracked thread whose PR message is messageID in channelID.
+func (s *MemoryStore) ThreadForMessage(_ context.Context, channelID, messageID string) (TrackedThread, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.messageIndex[channelID+"/"+messageID]
+	if !ok {
+		return TrackedThread{}, false
+	}
+	tracked, ok := s.threadIndex[key]
+	if !ok {
+		return TrackedThread{}, false
+	}
+	tracked.Info, ok = s.threads[key]
+	return tracked, ok
+}
+
+// MessagesForPR returns the PR's messages in every channel it was posted to.
+func (s *MemoryStore) MessagesForPR(_ context.Context, prURL string) []MessageRef {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var refs []MessageRef
+	for key, channelID := range s.prThreads[prURL] {
+		if info, exists := s.threads[key]; exists {
+			refs = append(refs, messageRef(channelID, info))
+		}
+	}
+	slices.SortFunc(refs, func(a, b MessageRef) int { return strings.Compare(a.ChannelID, b.ChannelID) })
+	return refs
+}
+
+// SetThreadMessageIDs records the messages a text channel notification spans.
+func (s *MemoryStore) SetThreadMessageIDs(
+	_ context.Context, owner, repo string, number int, channelID string, messageIDs []string,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := threadKey(owner, repo, number, channelID)
+	info, exists := s.threads[key]
+	if !exists {
+		return fmt.Errorf("no thread for %s/%s#%d in channel %s", owner, repo, number, channelID)
+	}
+	setMessageIDs(&info, messageIDs)
+	info.UpdatedAt = time.Now()
+	s.threads[key] = info
+	return nil
+}
+
+// setMessageIDs points a thread at the messages its notification spans: the first becomes
+// its MessageID, and MessageIDs is only kept when there's more than one.
+func setMessageIDs(info *ThreadInfo, messageIDs []string) {
+	if len(messageIDs) == 0 {
+		return
+	}
+	info.MessageID = messageIDs[0]
+	info.MessageIDs = nil
+	if len(messageIDs) > 1 {
+		info.MessageIDs = slices.Clone(messageIDs)
+	}
+}
+
+// ListThreads returns all tracked threads/messages for an org.
+func (s *MemoryStore) ListThreads(_ context.Context, owner string) []TrackedThread {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var threads []TrackedThread
+	for key, tracked := range s.threadIndex {
+		if tracked.Owner != owner {
+			continue
+		}
+		info, exists := s.threads[key]
+		if !exists {
+			continue
+		}
+		tracked.Info = info
+		threads = append(threads, tracked)
+	}
+	return threads
+}
+
 // ClaimThread attempts to claim a thread for creation.
 // Returns true if the claim was successful, false if another goroutine already claimed it.
 func (s *MemoryStore) ClaimThread(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool {
@@ -109,6 +289,28 @@ func (s *MemoryStore) ClaimThread(ctx context.Context, owner, repo string, numbe
 	return true
 }
 
+// RenewThreadClaim extends a thread claim that hasn't lapsed.
+func (s *MemoryStore) RenewThreadClaim(_ context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claimKey := fmt.Sprintf("claim:thread:%s", threadKey(owner, repo, number, channelID))
+	if expiry, exists := s.claims[claimKey]; !exists || !time.Now().Before(expiry) {
+		return false
+	}
+	s.claims[claimKey] = time.Now().Add(ttl)
+	return true
+}
+
+// ReleaseThreadClaim frees a thread claim so it can be claimed again immediately.
+func (s *MemoryStore) ReleaseThreadClaim(_ context.Context, owner, repo string, number int, channelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, fmt.Sprintf("claim:thread:%s", threadKey(owner, repo, number, channelID)))
+	return nil
+}
+
 // DMInfo returns DM info for a user/PR combination.
 func (s *MemoryStore) DMInfo(ctx context.Context, userID, prURL string) (DMInfo, bool) {
 	s.mu.RLock()
@@ -130,6 +332,10 @@ func (s *MemoryStore) SaveDMInfo(_ context.Context, userID, prURL string, info D
 		s.dmUserIndex[prURL] = make(map[string]bool)
 	}
 	s.dmUserIndex[prURL][userID] = true
+	if s.dmPRIndex[userID] == nil {
+		s.dmPRIndex[userID] = make(map[string]bool)
+	}
+	s.dmPRIndex[userID][prURL] = true
 
 	slog.Debug("saved DM info",
 		"user_id", userID,
@@ -164,6 +370,32 @@ func (s *MemoryStore) ClaimDM(ctx context.Context, userID, prURL string, ttl tim
 	return true
 }
 
+// ReleaseDMClaim frees a DM claim so it can be claimed again immediately.
+func (s *MemoryStore) ReleaseDMClaim(_ context.Context, userID, prURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, fmt.Sprintf("claim:dm:%s", dmKey(userID, prURL)))
+	return nil
+}
+
+// ClaimConfigReload attempts to claim the config reload for an org.
+// Returns true if the claim was successful, false if a reload is already claimed.
+func (s *MemoryStore) ClaimConfigReload(_ context.Context, org string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claimKey := "claim:reload:" + org
+	if expiry, exists := s.claims[claimKey]; exists && time.Now().Before(expiry) {
+		slog.Debug("config reload already claimed", "org", org)
+		return false
+	}
+
+	s.claims[claimKey] = time.Now().Add(ttl)
+	slog.Debug("successfully claimed config reload", "org", org, "ttl", ttl)
+	return true
+}
+
 // ListDMUsers returns all user IDs who received DMs for a PR.
 func (s *MemoryStore) ListDMUsers(_ context.Context, prURL string) []string {
 	s.mu.RLock()
@@ -181,6 +413,22 @@ func (s *MemoryStore) ListDMUsers(_ context.Context, prURL string) []string {
 	return result
 }
 
+// ListPRsForUser returns the PRs a user was DMed about, with their DM info.
+func (s *MemoryStore) ListPRsForUser(_ context.Context, userID string) []TrackedDM {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var dms []TrackedDM
+	for prURL := range s.dmPRIndex[userID] {
+		info, exists := s.dmInfo[dmKey(userID, prURL)]
+		if !exists {
+			continue
+		}
+		dms = append(dms, TrackedDM{PRURL: prURL, Info: info})
+	}
+	return dms
+}
+
 // WasProcessed checks if an event was already processed.
 func (s *MemoryStore) WasProcessed(ctx context.Context, eventKey string) bool {
 	s.mu.RLock()
@@ -208,6 +456,44 @@ func (s *MemoryStore) MarkProcessed(_ context.Context, eventKey string, _ time.D
 	return nil
 }
 
+// ClaimEvent claims an event for processing unless it was already claimed or processed.
+func (s *MemoryStore) ClaimEvent(_ context.Context, eventKey string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if processedAt, exists := s.processed[eventKey]; exists && time.Since(processedAt) <= s.eventRetain {
+		return false
+	}
+
+	claimKey := "claim:event:" + eventKey
+	if expiry, exists := s.claims[claimKey]; exists && time.Now().Before(expiry) {
+		return false
+	}
+	s.claims[claimKey] = time.Now().Add(ttl)
+	return true
+}
+
+// ClearProcessed removes dedup entries and in-flight claims whose event keys start with
+// keyPrefix, so those events are processed again if redelivered. An empty prefix clears all.
+func (s *MemoryStore) ClearProcessed(_ context.Context, keyPrefix string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cleared := 0
+	for key := range s.processed {
+		if strings.HasPrefix(key, keyPrefix) {
+			delete(s.processed, key)
+			cleared++
+		}
+	}
+	for key := range s.claims {
+		if eventKey, ok := strings.CutPrefix(key, "claim:event:"); ok && strings.HasPrefix(eventKey, keyPrefix) {
+			delete(s.claims, key)
+		}
+	}
+	return cleared, nil
+}
+
 // QueuePendingDM adds a DM to the pending queue.
 func (s *MemoryStore) QueuePendingDM(ctx context.Context, dm *PendingDM) error {
 	s.mu.Lock()
@@ -266,6 +552,222 @@ func (s *MemoryStore) SaveDailyReportInfo(_ context.Context, userID string, info
 	return nil
 }
 
+// Digest returns the digest state for a channel.
+func (s *MemoryStore) Digest(_ context.Context, channelID string) (DigestInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, exists := s.digests[channelID]
+	return info, exists
+}
+
+// SaveDigest saves the digest state for a channel.
+func (s *MemoryStore) SaveDigest(_ context.Context, channelID string, info DigestInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.digests[channelID] = info
+	return nil
+}
+
+// UpdateDigest applies update to the digest state for a channel.
+func (s *MemoryStore) UpdateDigest(_ context.Context, channelID string, update func(info *DigestInfo) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := s.digests[channelID]
+	if update(&info) {
+		s.digests[channelID] = info
+	}
+	return nil
+}
+
+// Board returns the board state for a channel.
+func (s *MemoryStore) Board(_ context.Context, channelID string) (BoardInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, exists := s.boards[channelID]
+	return info, exists
+}
+
+// SaveBoard saves the board state for a channel.
+func (s *MemoryStore) SaveBoard(_ context.Context, channelID string, info BoardInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.boards[channelID] = info
+	return nil
+}
+
+// UpdateBoard applies update to the board state for a channel.
+func (s *MemoryStore) UpdateBoard(_ context.Context, channelID string, update func(info *BoardInfo) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := s.boards[channelID]
+	if update(&info) {
+		s.boards[channelID] = info
+	}
+	return nil
+}
+
+// UpdatePostWindows applies update to the PRs an org holds back for closed post windows.
+func (s *MemoryStore) UpdatePostWindows(_ context.Context, org string, update func(info *PostWindowInfo) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := s.postWindows[org]
+	if update(&info) {
+		s.postWindows[org] = info
+	}
+	return nil
+}
+
+// PROverride returns the comment directive overrides for a PR.
+func (s *MemoryStore) PROverride(_ context.Context, prURL string) (PROverride, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	override, exists := s.overrides[prURL]
+	return override, exists
+}
+
+// SavePROverride saves the comment directive overrides for a PR.
+func (s *MemoryStore) SavePROverride(_ context.Context, prURL string, override PROverride) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides[prURL] = override
+	return nil
+}
+
+// Escalation returns review escalation progress for a PR in a channel.
+func (s *MemoryStore) Escalation(_ context.Context, channelID, prURL string) (EscalationInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, exists := s.escalations[channelID+":"+prURL]
+	return info, exists
+}
+
+// SaveEscalation saves review escalation progress for a PR in a channel.
+func (s *MemoryStore) SaveEscalation(_ context.Context, channelID, prURL string, info EscalationInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.escalations[channelID+":"+prURL] = info
+	return nil
+}
+
+// Maintenance returns an org's maintenance mode.
+func (s *MemoryStore) Maintenance(_ context.Context, org string) (MaintenanceInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, exists := s.maintenance[org]
+	return info, exists
+}
+
+// SaveMaintenance saves an org's maintenance mode.
+func (s *MemoryStore) SaveMaintenance(_ context.Context, org string, info MaintenanceInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maintenance[org] = info
+	return nil
+}
+
+// OOO returns a user's out of office status.
+func (s *MemoryStore) OOO(_ context.Context, userID string) (OOOInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, exists := s.ooo[userID]
+	return info, exists
+}
+
+// SaveOOO saves a user's out of office status.
+func (s *MemoryStore) SaveOOO(_ context.Context, userID string, info OOOInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ooo[userID] = info
+	return nil
+}
+
+// RepoRedirect returns a repo's temporary channel redirect.
+func (s *MemoryStore) RepoRedirect(_ context.Context, org, repo string) (RepoRedirect, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	redirect, exists := s.redirects[redirectKey(org, repo)]
+	return redirect, exists
+}
+
+// SaveRepoRedirect saves a repo's temporary channel redirect.
+func (s *MemoryStore) SaveRepoRedirect(_ context.Context, org, repo string, redirect RepoRedirect) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.redirects[redirectKey(org, repo)] = redirect
+	return nil
+}
+
+// LastDM returns when a user was last DMed.
+func (s *MemoryStore) LastDM(_ context.Context, userID string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sentAt, exists := s.lastDMs[userID]
+	return sentAt, exists
+}
+
+// SaveLastDM saves when a user was last DMed.
+func (s *MemoryStore) SaveLastDM(_ context.Context, userID string, sentAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastDMs[userID] = sentAt
+	return nil
+}
+
+// LastEventAt returns when an org's last webhook event was seen.
+func (s *MemoryStore) LastEventAt(_ context.Context, org string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seenAt, exists := s.lastEvents[org]
+	return seenAt, exists
+}
+
+// SaveLastEventAt saves when an org's last webhook event was seen.
+func (s *MemoryStore) SaveLastEventAt(_ context.Context, org string, seenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastEvents[org] = seenAt
+	return nil
+}
+
+// ConfigReloadRequestedAt returns when an org's config reload was last forced.
+func (s *MemoryStore) ConfigReloadRequestedAt(_ context.Context, org string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requestedAt, exists := s.reloads[org]
+	return requestedAt, exists
+}
+
+// SaveConfigReloadRequest saves when an org's config reload was forced.
+func (s *MemoryStore) SaveConfigReloadRequest(_ context.Context, org string, requestedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reloads[org] = requestedAt
+	return nil
+}
+
 // Cleanup removes old entries from the store.
 func (s *MemoryStore) Cleanup(ctx context.Context) error {
 	s.mu.Lock()
@@ -277,7 +779,7 @@ func (s *MemoryStore) Cleanup(ctx context.Context) error {
 	// Clean old threads
 	for key, info := range s.threads {
 		if now.Sub(info.UpdatedAt) > s.threadRetain {
-			delete(s.threads, key)
+			s.forgetThread(key)
 			threadsCleaned++
 		}
 	}
@@ -298,6 +800,13 @@ func (s *MemoryStore) Cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Last DM times only matter for rate limiting
+	for userID, sentAt := range s.lastDMs {
+		if now.Sub(sentAt) > lastDMTTL {
+			delete(s.lastDMs, userID)
+		}
+	}
+
 	// Clean expired claims
 	var claimsCleaned int
 	for key, expiry := range s.claims {
@@ -307,12 +816,22 @@ func (s *MemoryStore) Cleanup(ctx context.Context) error {
 		}
 	}
 
-	if threadsCleaned > 0 || dmsCleaned > 0 || eventsCleaned > 0 || claimsCleaned > 0 {
+	// Escalations that were reset, or outlived their PR's thread
+	var escalationsCleaned int
+	for key, info := range s.escalations {
+		if info.NeedsReviewSince.IsZero() || now.Sub(info.NeedsReviewSince) > s.threadRetain {
+			delete(s.escalations, key)
+			escalationsCleaned++
+		}
+	}
+
+	if threadsCleaned > 0 || dmsCleaned > 0 || eventsCleaned > 0 || claimsCleaned > 0 || escalationsCleaned > 0 {
 		slog.Info("cleaned up old state entries",
 			"threads", threadsCleaned,
 			"dms", dmsCleaned,
 			"events", eventsCleaned,
-			"claims", claimsCleaned)
+			"claims", claimsCleaned,
+			"escalations", escalationsCleaned)
 	}
 
 	return nil
@@ -344,6 +863,15 @@ func (s *MemoryStore) SaveUserMapping(ctx context.Context, guildID string, info
 	return nil
 }
 
+// RemoveUserMapping deletes the user mapping for a GitHub username in a guild.
+func (s *MemoryStore) RemoveUserMapping(_ context.Context, guildID, gitHubUsername string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.userMappings, userMappingKey(guildID, gitHubUsername))
+	return nil
+}
+
 // ListUserMappings returns all user mappings for a guild.
 func (s *MemoryStore) ListUserMappings(ctx context.Context, guildID string) []UserMappingInfo {
 	s.mu.RLock()
@@ -2,6 +2,8 @@ package state
 
 import (
 	"context"
+	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -199,6 +201,13 @@ func TestMemoryStore_Cleanup(t *testing.T) {
 		t.Fatalf("SaveDMInfo() error = %v", err)
 	}
 
+	if err := store.SaveEscalation(ctx, "c", "pr-url", EscalationInfo{NeedsReviewSince: time.Now()}); err != nil {
+		t.Fatalf("SaveEscalation() error = %v", err)
+	}
+	if err := store.SaveEscalation(ctx, "c", "reset-pr-url", EscalationInfo{}); err != nil {
+		t.Fatalf("SaveEscalation() error = %v", err)
+	}
+
 	// Wait for expiration
 	time.Sleep(10 * time.Millisecond)
 
@@ -223,6 +232,226 @@ func TestMemoryStore_Cleanup(t *testing.T) {
 	if ok {
 		t.Error("old DM info should have been cleaned up")
 	}
+
+	// Escalations are cleaned up with their threads, and once reset
+	if _, ok := store.Escalation(ctx, "c", "pr-url"); ok {
+		t.Error("old escalation should have been cleaned up")
+	}
+	if _, ok := store.Escalation(ctx, "c", "reset-pr-url"); ok {
+		t.Error("reset escalation should have been cleaned up")
+	}
+}
+
+func TestMemoryStore_ListThreads(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{ThreadID: "t1", ChannelID: "chan1"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan2", ThreadInfo{MessageID: "m1", ChannelID: "chan2"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "other", "repo", 2, "chan1", ThreadInfo{ThreadID: "t2", ChannelID: "chan1"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	got := store.ListThreads(ctx, "owner")
+	if len(got) != 2 {
+		t.Fatalf("ListThreads() returned %d threads, want 2", len(got))
+	}
+	for _, tt := range got {
+		if tt.Owner != "owner" || tt.Repo != "repo" || tt.Number != 1 {
+			t.Errorf("ListThreads() returned unexpected PR %s/%s#%d", tt.Owner, tt.Repo, tt.Number)
+		}
+		if tt.Info.ChannelID == "" {
+			t.Error("ListThreads() should include thread info")
+		}
+	}
+
+	if got := store.ListThreads(ctx, "nobody"); len(got) != 0 {
+		t.Errorf("ListThreads() for unknown org = %v, want empty", got)
+	}
+}
+
+func TestMemoryStore_SaveThread_FirstSeenAt(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", LastState: "needs_review"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	first, _ := store.Thread(ctx, "owner", "repo", 1, "chan1")
+	if first.FirstSeenAt.IsZero() {
+		t.Fatal("FirstSeenAt not set on first save")
+	}
+
+	// Updates build a fresh ThreadInfo, sometimes with a zero or different FirstSeenAt
+	for _, seen := range []time.Time{{}, time.Now().Add(time.Hour)} {
+		if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", LastState: "approved", FirstSeenAt: seen}); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+		got, _ := store.Thread(ctx, "owner", "repo", 1, "chan1")
+		if !got.FirstSeenAt.Equal(first.FirstSeenAt) {
+			t.Errorf("FirstSeenAt = %v after update, want %v", got.FirstSeenAt, first.FirstSeenAt)
+		}
+	}
+}
+
+func TestMemoryStore_SaveThread_FirstReviewAt(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", LastState: "needs_review"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if got, _ := store.Thread(ctx, "owner", "repo", 1, "chan1"); !got.FirstReviewAt.IsZero() {
+		t.Fatalf("FirstReviewAt = %v before any review, want zero", got.FirstReviewAt)
+	}
+
+	reviewed := time.Now().Add(-time.Hour)
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", LastState: "approved", FirstReviewAt: reviewed}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	// Later saves carry no or a newer review time, but the first one sticks
+	for _, at := range []time.Time{{}, time.Now()} {
+		if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", LastState: "merged", FirstReviewAt: at}); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+		if got, _ := store.Thread(ctx, "owner", "repo", 1, "chan1"); !got.FirstReviewAt.Equal(reviewed) {
+			t.Errorf("FirstReviewAt = %v after update, want %v", got.FirstReviewAt, reviewed)
+		}
+	}
+}
+
+func TestMemoryStore_RemoveThread(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	for _, ch := range []string{"chan1", "chan2"} {
+		if err := store.SaveThread(ctx, "owner", "repo", 1, ch, ThreadInfo{MessageID: "m-" + ch, ChannelID: ch}); err != nil {
+			t.Fatalf("SaveThread() error = %v", err)
+		}
+	}
+
+	if err := store.RemoveThread(ctx, "owner", "repo", 1, "chan1"); err != nil {
+		t.Fatalf("RemoveThread() error = %v", err)
+	}
+	if _, ok := store.Thread(ctx, "owner", "repo", 1, "chan1"); ok {
+		t.Error("Thread() found removed thread")
+	}
+	if _, ok := store.Thread(ctx, "owner", "repo", 1, "chan2"); !ok {
+		t.Error("Thread() lost the other channel's thread")
+	}
+	if got := store.ListThreads(ctx, "owner"); len(got) != 1 || got[0].Info.ChannelID != "chan2" {
+		t.Errorf("ListThreads() = %+v, want only chan2", got)
+	}
+}
+
+func TestMemoryStore_MessagesForPR(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	prURL := "https://github.com/owner/repo/pull/1"
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan2", ThreadInfo{ThreadID: "t1", MessageID: "m2", ChannelType: "forum"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 12, "chan1", ThreadInfo{MessageID: "m3"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	want := []MessageRef{
+		{ChannelID: "chan1", ChannelType: "text", MessageID: "m1"},
+		{ChannelID: "chan2", ChannelType: "forum", ThreadID: "t1", MessageID: "m2"},
+	}
+	if got := store.MessagesForPR(ctx, prURL); !reflect.DeepEqual(got, want) {
+		t.Errorf("MessagesForPR() = %+v, want %+v", got, want)
+	}
+
+	if err := store.RemoveThread(ctx, "owner", "repo", 1, "chan1"); err != nil {
+		t.Fatalf("RemoveThread() error = %v", err)
+	}
+	if got := store.MessagesForPR(ctx, prURL); len(got) != 1 || got[0].ChannelID != "chan2" {
+		t.Errorf("MessagesForPR() after RemoveThread = %+v, want only chan2", got)
+	}
+	if got := store.MessagesForPR(ctx, "https://github.com/owner/repo/pull/99"); len(got) != 0 {
+		t.Errorf("MessagesForPR() for an untracked PR = %+v, want none", got)
+	}
+}
+
+func TestMemoryStore_ThreadForMessage(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 2, "chan2", ThreadInfo{ThreadID: "t2", MessageID: "m2", ChannelType: "forum"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	if got, ok := store.ThreadForMessage(ctx, "chan1", "m1"); !ok || got.Number != 1 || got.Info.MessageID != "m1" {
+		t.Errorf("ThreadForMessage(chan1, m1) = %+v, %v; want PR 1", got, ok)
+	}
+	if got, ok := store.ThreadForMessage(ctx, "t2", "m2"); !ok || got.Number != 2 {
+		t.Errorf("ThreadForMessage(t2, m2) = %+v, %v; want PR 2 by its forum thread", got, ok)
+	}
+	if _, ok := store.ThreadForMessage(ctx, "chan2", "m1"); ok {
+		t.Error("ThreadForMessage() found m1 in the wrong channel")
+	}
+
+	// A reposted message replaces the old one
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1b", ChannelType: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+	if _, ok := store.ThreadForMessage(ctx, "chan1", "m1"); ok {
+		t.Error("ThreadForMessage() found the replaced message")
+	}
+	if _, ok := store.ThreadForMessage(ctx, "chan1", "m1b"); !ok {
+		t.Error("ThreadForMessage() didn't find the reposted message")
+	}
+
+	if err := store.RemoveThread(ctx, "owner", "repo", 2, "chan2"); err != nil {
+		t.Fatalf("RemoveThread() error = %v", err)
+	}
+	if _, ok := store.ThreadForMessage(ctx, "t2", "m2"); ok {
+		t.Error("ThreadForMessage() found a removed thread")
+	}
+	if len(store.messageIndex) != 1 {
+		t.Errorf("messageIndex = %v, want only m1b", store.messageIndex)
+	}
+}
+
+func TestMemoryStore_SetThreadMessageIDs(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SetThreadMessageIDs(ctx, "owner", "repo", 1, "chan1", []string{"m1", "m2"}); err == nil {
+		t.Error("SetThreadMessageIDs() for an untracked PR succeeded, want error")
+	}
+	if err := store.SaveThread(ctx, "owner", "repo", 1, "chan1", ThreadInfo{MessageID: "m1", MessageText: "text"}); err != nil {
+		t.Fatalf("SaveThread() error = %v", err)
+	}
+
+	if err := store.SetThreadMessageIDs(ctx, "owner", "repo", 1, "chan1", []string{"m1", "m2"}); err != nil {
+		t.Fatalf("SetThreadMessageIDs() error = %v", err)
+	}
+	info, _ := store.Thread(ctx, "owner", "repo", 1, "chan1")
+	if info.MessageID != "m1" || !slices.Equal(info.MessageIDs, []string{"m1", "m2"}) || info.MessageText != "text" {
+		t.Errorf("Thread() = %+v, want message IDs [m1 m2] and other fields kept", info)
+	}
+
+	// Back to a single message
+	if err := store.SetThreadMessageIDs(ctx, "owner", "repo", 1, "chan1", []string{"m3"}); err != nil {
+		t.Fatalf("SetThreadMessageIDs() error = %v", err)
+	}
+	info, _ = store.Thread(ctx, "owner", "repo", 1, "chan1")
+	if info.MessageID != "m3" || info.MessageIDs != nil {
+		t.Errorf("Thread() = %+v, want a single message m3", info)
+	}
 }
 
 func TestThreadKey(t *testing.T) {
@@ -267,6 +496,123 @@ func TestMemoryStore_WasProcessed_Expired(t *testing.T) {
 	}
 }
 
+func TestMemoryStore_ClaimEvent(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if !store.ClaimEvent(ctx, "event1", time.Minute) {
+		t.Fatal("first ClaimEvent() = false, want true")
+	}
+	if store.ClaimEvent(ctx, "event1", time.Minute) {
+		t.Error("second ClaimEvent() = true, want false while claimed")
+	}
+
+	// An expired claim can be taken again
+	if !store.ClaimEvent(ctx, "event2", time.Millisecond) {
+		t.Fatal("ClaimEvent(event2) = false, want true")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if !store.ClaimEvent(ctx, "event2", time.Minute) {
+		t.Error("ClaimEvent() = false after claim expired, want true")
+	}
+
+	// A processed event can't be claimed
+	if err := store.MarkProcessed(ctx, "event3", time.Hour); err != nil {
+		t.Fatalf("MarkProcessed() error = %v", err)
+	}
+	if store.ClaimEvent(ctx, "event3", time.Minute) {
+		t.Error("ClaimEvent() = true for processed event, want false")
+	}
+}
+
+func TestMemoryStore_ClearProcessed(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	for _, key := range []string{"pr/1#a", "pr/1#b", "pr/12#a"} {
+		if err := store.MarkProcessed(ctx, key, time.Hour); err != nil {
+			t.Fatalf("MarkProcessed() error = %v", err)
+		}
+	}
+	store.ClaimEvent(ctx, "pr/1#c", time.Minute)
+
+	cleared, err := store.ClearProcessed(ctx, "pr/1#")
+	if err != nil {
+		t.Fatalf("ClearProcessed() error = %v", err)
+	}
+	if cleared != 2 {
+		t.Errorf("ClearProcessed() = %d, want 2", cleared)
+	}
+	if store.WasProcessed(ctx, "pr/1#a") || store.WasProcessed(ctx, "pr/1#b") {
+		t.Error("cleared events still marked processed")
+	}
+	if !store.WasProcessed(ctx, "pr/12#a") {
+		t.Error("event outside the prefix was cleared")
+	}
+	if !store.ClaimEvent(ctx, "pr/1#c", time.Minute) {
+		t.Error("in-flight claim under the prefix wasn't cleared")
+	}
+
+	if cleared, _ := store.ClearProcessed(ctx, ""); cleared != 1 {
+		t.Errorf("ClearProcessed(\"\") = %d, want 1", cleared)
+	}
+}
+
+func TestMemoryStore_Digest(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if _, ok := store.Digest(ctx, "chan1"); ok {
+		t.Error("Digest() found non-existent info")
+	}
+
+	info := DigestInfo{
+		LastPostedAt: time.Now(),
+		Pending:      map[string]DigestEntry{"https://github.com/o/r/pull/1": {Line: "line", UpdatedAt: time.Now()}},
+	}
+	if err := store.SaveDigest(ctx, "chan1", info); err != nil {
+		t.Fatalf("SaveDigest() error = %v", err)
+	}
+
+	got, ok := store.Digest(ctx, "chan1")
+	if !ok {
+		t.Fatal("Digest() did not find saved info")
+	}
+	if !got.LastPostedAt.Equal(info.LastPostedAt) || len(got.Pending) != 1 {
+		t.Errorf("Digest() = %+v, want saved info", got)
+	}
+}
+
+func TestMemoryStore_Board(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if _, ok := store.Board(ctx, "chan1"); ok {
+		t.Error("Board() found non-existent info")
+	}
+
+	info := BoardInfo{
+		MessageID: "msg1",
+		PRs:       map[string]BoardEntry{"https://github.com/o/r/pull/1": {Repo: "r", Number: 1, State: "needs_review"}},
+		Revision:  2,
+	}
+	if err := store.SaveBoard(ctx, "chan1", info); err != nil {
+		t.Fatalf("SaveBoard() error = %v", err)
+	}
+
+	got, ok := store.Board(ctx, "chan1")
+	if !ok {
+		t.Fatal("Board() did not find saved info")
+	}
+	if got.MessageID != "msg1" || got.Revision != 2 || len(got.PRs) != 1 {
+		t.Errorf("Board() = %+v, want saved info", got)
+	}
+}
+
 func TestMemoryStore_DailyReportInfo(t *testing.T) {
 	ctx := context.Background()
 	store := NewMemoryStore()
@@ -355,6 +701,72 @@ func TestMemoryStore_ClaimThread(t *testing.T) {
 	}
 }
 
+func TestMemoryStore_RenewAndReleaseThreadClaim(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if store.RenewThreadClaim(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Error("RenewThreadClaim() should fail for an unclaimed thread")
+	}
+
+	if !store.ClaimThread(ctx, "owner", "repo", 1, "chan1", 50*time.Millisecond) {
+		t.Fatal("ClaimThread() should succeed on first attempt")
+	}
+	if !store.RenewThreadClaim(ctx, "owner", "repo", 1, "chan1", time.Minute) {
+		t.Error("RenewThreadClaim() should succeed for a held claim")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if store.ClaimThread(ctx, "owner", "repo", 1, "chan1", time.Second) {
+		t.Error("ClaimThread() should fail once the claim was renewed past its TTL")
+	}
+
+	if err := store.ReleaseThreadClaim(ctx, "owner", "repo", 1, "chan1"); err != nil {
+		t.Fatalf("ReleaseThreadClaim() error = %v", err)
+	}
+	if !store.ClaimThread(ctx, "owner", "repo", 1, "chan1", time.Second) {
+		t.Error("ClaimThread() should succeed immediately after release")
+	}
+}
+
+func TestMemoryStore_ReleaseDMClaim(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	prURL := "https://github.com/owner/repo/pull/1"
+	if !store.ClaimDM(ctx, "user123", prURL, time.Minute) {
+		t.Fatal("ClaimDM() should succeed on first attempt")
+	}
+	if err := store.ReleaseDMClaim(ctx, "user123", prURL); err != nil {
+		t.Fatalf("ReleaseDMClaim() error = %v", err)
+	}
+	if !store.ClaimDM(ctx, "user123", prURL, time.Minute) {
+		t.Error("ClaimDM() should succeed immediately after release")
+	}
+}
+
+func TestMemoryStore_ClaimConfigReload(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	if !store.ClaimConfigReload(ctx, "org1", 50*time.Millisecond) {
+		t.Error("ClaimConfigReload() should succeed on first attempt")
+	}
+	if store.ClaimConfigReload(ctx, "org1", 50*time.Millisecond) {
+		t.Error("ClaimConfigReload() should fail when already claimed")
+	}
+	if !store.ClaimConfigReload(ctx, "org2", 50*time.Millisecond) {
+		t.Error("ClaimConfigReload() should succeed for a different org")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if !store.ClaimConfigReload(ctx, "org1", 50*time.Millisecond) {
+		t.Error("ClaimConfigReload() should succeed after claim expiry")
+	}
+}
+
 // TestMemoryStore_ClaimDM tests DM claim locking.
 func TestMemoryStore_ClaimDM(t *testing.T) {
 	ctx := context.Background()
@@ -454,6 +866,36 @@ func TestMemoryStore_ListDMUsers(t *testing.T) {
 	}
 }
 
+func TestMemoryStore_ListPRsForUser(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	defer store.Close() //nolint:errcheck // test cleanup
+
+	prURL1 := "https://github.com/owner/repo/pull/1"
+	prURL2 := "https://github.com/owner/repo/pull/2"
+	for _, prURL := range []string{prURL1, prURL2} {
+		if err := store.SaveDMInfo(ctx, "user1", prURL, DMInfo{MessageID: "msg", SentAt: time.Now()}); err != nil {
+			t.Fatalf("SaveDMInfo(%s) error = %v", prURL, err)
+		}
+	}
+	if err := store.SaveDMInfo(ctx, "user2", prURL1, DMInfo{MessageID: "msg", SentAt: time.Now()}); err != nil {
+		t.Fatalf("SaveDMInfo(user2) error = %v", err)
+	}
+
+	dms := store.ListPRsForUser(ctx, "user1")
+	got := make(map[string]bool)
+	for _, dm := range dms {
+		got[dm.PRURL] = true
+	}
+	if len(dms) != 2 || !got[prURL1] || !got[prURL2] {
+		t.Errorf("ListPRsForUser(user1) = %v, want %s and %s", dms, prURL1, prURL2)
+	}
+
+	if dms := store.ListPRsForUser(ctx, "user3"); len(dms) != 0 {
+		t.Errorf("ListPRsForUser(user3) = %v, want empty", dms)
+	}
+}
+
 // TestMemoryStore_UserMapping tests user mapping operations.
 func TestMemoryStore_UserMapping(t *testing.T) {
 	ctx := context.Background()
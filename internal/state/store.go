@@ -3,27 +3,125 @@ package state
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // ThreadInfo stores Discord thread/message info for a PR.
 type ThreadInfo struct {
 	UpdatedAt   time.Time `json:"updated_at"`
+	FirstSeenAt time.Time `json:"first_seen_at"` // Set when the PR is first saved; kept by every later save
 	ThreadID    string    `json:"thread_id"`
 	MessageID   string    `json:"message_id"`
 	ChannelID   string    `json:"channel_id"`
 	ChannelType string    `json:"channel_type"` // "forum" or "text"
 	LastState   string    `json:"last_state"`
 	MessageText string    `json:"message_text"`
+	// MessageIDs lists every message of a text channel notification split across several
+	// messages, in order; MessageIDs[0] is MessageID. Empty for single messages.
+	MessageIDs []string `json:"message_ids,omitempty"`
+	// FirstReviewAt is when the PR was first seen approved or with changes requested.
+	// Once set, it's kept by every later save.
+	FirstReviewAt time.Time `json:"first_review_at,omitempty"`
+	// RequestedReviewers lists the GitHub users the PR last asked to review, to notice
+	// when one is unrequested.
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	// DetailThreadID and DetailMessageID locate the thread of full details started on a text
+	// channel message, in channels with detail_thread; DetailText is its last content.
+	DetailThreadID  string `json:"detail_thread_id,omitempty"`
+	DetailMessageID string `json:"detail_message_id,omitempty"`
+	DetailText      string `json:"detail_text,omitempty"`
+	// PriorMessageIDs lists the first message posted for each earlier state of the PR in an
+	// append_mode channel, oldest first; the current state's is MessageID.
+	PriorMessageIDs []string `json:"prior_message_ids,omitempty"`
+	// LastReview is the PR's last review verdict, "approved" or "changes_requested", and
+	// ReviewRounds counts the verdicts that changed it, starting at 1 with the first.
+	LastReview   string `json:"last_review,omitempty"`
+	ReviewRounds int    `json:"review_rounds,omitempty"`
+	// Draft is whether the PR was a draft when last processed, to notice it being marked ready
+	// for review.
+	Draft bool `json:"draft,omitempty"`
+	// ArchiveAt is when a merged or closed PR's forum thread is due to be archived, in channels
+	// with archive_delay; zero once it's archived. Kept here so the archive outlives restarts.
+	ArchiveAt time.Time `json:"archive_at,omitempty"`
+	// ClosedAt is when the PR was first seen merged or closed; zero while it's open.
+	ClosedAt time.Time `json:"closed_at,omitempty"`
+	// Withdrawn is whether the PR's forum thread was archived when the PR was suppressed, to be
+	// revived when the suppression lifts.
+	Withdrawn bool `json:"withdrawn,omitempty"`
+}
+
+// MessageRef locates a channel message or forum thread posted for a PR.
+type MessageRef struct {
+	ChannelID   string   `json:"channel_id"`
+	ChannelType string   `json:"channel_type"` // "forum" or "text"
+	ThreadID    string   `json:"thread_id,omitempty"`
+	MessageID   string   `json:"message_id"`
+	MessageIDs  []string `json:"message_ids,omitempty"` // Every message of a split notification, in order
+}
+
+// TrackedThread is a stored ThreadInfo along with the PR it belongs to.
+type TrackedThread struct {
+	Owner  string     `json:"owner"`
+	Repo   string     `json:"repo"`
+	Info   ThreadInfo `json:"info"`
+	Number int        `json:"number"`
 }
 
 // DMInfo stores DM message info for updating.
 type DMInfo struct {
-	SentAt      time.Time `json:"sent_at"`
-	ChannelID   string    `json:"channel_id"`
-	MessageID   string    `json:"message_id"`
-	MessageText string    `json:"message_text"`
-	LastState   string    `json:"last_state"` // PR state when DM was sent/updated
+	SentAt       time.Time `json:"sent_at"`
+	SnoozedUntil time.Time `json:"snoozed_until"` // Set by the DM footer's Snooze action
+	ChannelID    string    `json:"channel_id"`
+	MessageID    string    `json:"message_id"`
+	MessageText  string    `json:"message_text"`
+	LastState    string    `json:"last_state"`    // PR state when DM was sent/updated
+	HandledState string    `json:"handled_state"` // PR state when the user ran /goose done; cleared once the state changes
+	Muted        bool      `json:"muted"`         // Set by the DM footer's Mute action
+	// PostedAt is when the DM was first sent. Unlike SentAt, edits leave it alone.
+	PostedAt time.Time `json:"posted_at,omitempty"`
+	// EscalationLevel counts follow-up DMs sent for an unacknowledged DM; at most 1.
+	EscalationLevel int `json:"escalation_level,omitempty"`
+	// Reminders counts the recurring review reminders sent for the DM while the PR was in
+	// ReminderState; NextReminderAt is when the next one is due.
+	Reminders      int       `json:"reminders,omitempty"`
+	ReminderState  string    `json:"reminder_state,omitempty"`
+	NextReminderAt time.Time `json:"next_reminder_at,omitempty"`
+}
+
+// NewDMInfo returns the info to store for a new DM about a PR, replacing stored. What the user
+// set for the PR carries over: muting, snoozing and marking it done.
+func NewDMInfo(stored DMInfo, channelID, messageID, text, prState string) DMInfo {
+	now := time.Now()
+	return DMInfo{
+		ChannelID:    channelID,
+		MessageID:    messageID,
+		MessageText:  text,
+		LastState:    prState,
+		SentAt:       now,
+		PostedAt:     now,
+		Muted:        stored.Muted,
+		SnoozedUntil: stored.SnoozedUntil,
+		HandledState: stored.HandledState,
+	}
+}
+
+// WithSettings returns info with what the user set for the PR taken from stored. Info read
+// before a slow call, such as editing the DM, goes through it before being saved, so a mute,
+// snooze or /goose done made in the meantime isn't undone.
+func (info DMInfo) WithSettings(stored DMInfo) DMInfo {
+	info.Muted = stored.Muted
+	info.SnoozedUntil = stored.SnoozedUntil
+	info.HandledState = stored.HandledState
+	return info
+}
+
+// TrackedDM is a stored DMInfo along with the PR it's about.
+type TrackedDM struct {
+	PRURL string `json:"pr_url"`
+	Info  DMInfo `json:"info"`
 }
 
 // PendingDM represents a scheduled DM notification.
@@ -31,12 +129,14 @@ type PendingDM struct {
 	SendAt      time.Time `json:"send_at"`
 	CreatedAt   time.Time `json:"created_at"`
 	ExpiresAt   time.Time `json:"expires_at"`
+	EventAt     time.Time `json:"event_at,omitempty"` // When the PR event that queued it happened, for latency
 	ID          string    `json:"id"`
 	UserID      string    `json:"user_id"`
 	PRURL       string    `json:"pr_url"`
 	MessageText string    `json:"message_text"`
 	GuildID     string    `json:"guild_id"`
 	Org         string    `json:"org"`
+	ActionKind  string    `json:"action_kind,omitempty"` // Turn action kind, e.g. "review"; orders DM digests
 	RetryCount  int       `json:"retry_count"`
 }
 
@@ -44,11 +144,101 @@ type PendingDM struct {
 type DailyReportInfo struct {
 	LastSentAt time.Time `json:"last_sent_at"`
 	GuildID    string    `json:"guild_id"`
+	// ChannelID and MessageID locate the last report DM, which persistent reports edit in place.
+	ChannelID string `json:"channel_id,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+}
+
+// DigestEntry is a PR's line in a channel digest.
+type DigestEntry struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Line      string    `json:"line"`
+}
+
+// DigestInfo tracks a digest-mode channel: its last digest and the PR changes since.
+type DigestInfo struct {
+	LastPostedAt time.Time              `json:"last_posted_at"`
+	MessageID    string                 `json:"message_id"` // The digest message each flush edits
+	Pending      map[string]DigestEntry `json:"pending"`    // PR URL -> latest change since the last digest
+	Posted       map[string]DigestEntry `json:"posted"`     // PR URL -> line as last posted, so unchanged re-polls are skipped
+}
+
+// BoardEntry is an open PR listed on a channel's board.
+type BoardEntry struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Owner     string    `json:"owner"`
+	Repo      string    `json:"repo"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	Number    int       `json:"number"`
+}
+
+// BoardInfo tracks a board-mode channel: its one board message and the open PRs it lists.
+type BoardInfo struct {
+	MessageID string                `json:"message_id"`
+	PRs       map[string]BoardEntry `json:"prs"`      // PR URL -> entry
+	Revision  int                   `json:"revision"` // Bumped on every change to PRs
+	Rendered  int                   `json:"rendered"` // Revision the board message last showed
+}
+
+// PROverride holds per-PR Discord behavior set by /discordian comment directives
+// and by reactions on the PR's channel messages.
+type PROverride struct {
+	UpdatedAt         time.Time `json:"updated_at"`         // Creation time of the last applied directive comment
+	Channel           string    `json:"channel"`            // Route the PR here instead of its configured channels
+	SetBy             string    `json:"set_by"`             // GitHub user who issued the last directive
+	AcknowledgedBy    string    `json:"acknowledged_by"`    // GitHub user who acknowledged the PR by reacting to its message
+	AcknowledgedState string    `json:"acknowledged_state"` // PR state when acknowledged; the acknowledgment lapses once it changes
+	Muted             bool      `json:"muted"`
+}
+
+// EscalationInfo tracks how long a PR has needed review in a channel and how far
+// up the channel's escalation ladder it has gone.
+type EscalationInfo struct {
+	NeedsReviewSince time.Time `json:"needs_review_since"` // Zero when the PR doesn't need review
+	Reviewers        []string  `json:"reviewers"`          // Mentions of the PR's requested reviewers
+	Level            int       `json:"level"`              // Number of escalation steps already sent
+}
+
+// PostWindowInfo tracks the PRs an org's channels hold back until their post windows open.
+type PostWindowInfo struct {
+	Pending map[string]map[string]bool `json:"pending"` // Channel name -> PR URLs
+}
+
+// MaintenanceInfo records whether an org is in maintenance mode, set with /goose maintenance.
+// While on, the bot keeps processing events but makes no Discord posts or DMs for the org.
+type MaintenanceInfo struct {
+	SetAt time.Time `json:"set_at"`
+	SetBy string    `json:"set_by"` // Discord user ID of the admin who toggled it
+	On    bool      `json:"on"`
+}
+
+// MaxOOO is the longest a user can be out of office for.
+const MaxOOO = 90 * 24 * time.Hour
+
+// OOOInfo records that a Discord user is out of office, set with /goose ooo. Until then,
+// their PR pings and DMs go to a configured backup reviewer instead.
+type OOOInfo struct {
+	Until time.Time `json:"until"`
+	SetAt time.Time `json:"set_at"`
+}
+
+// MaxRepoRedirect is the longest a repo's notifications can be redirected for.
+const MaxRepoRedirect = 30 * 24 * time.Hour
+
+// RepoRedirect sends a repo's PR notifications to another channel until it lapses, set with
+// /goose redirect, e.g. while its usual channel is being migrated or during an incident.
+type RepoRedirect struct {
+	Until   time.Time `json:"until"`
+	SetAt   time.Time `json:"set_at"`
+	SetBy   string    `json:"set_by"`  // Discord user ID of the admin who set it
+	Channel string    `json:"channel"` // Channel name or ID to post to instead
 }
 
 // UserMappingInfo stores explicit GitHub-Discord user mappings.
 type UserMappingInfo struct {
 	CreatedAt      time.Time `json:"created_at"`
+	LeftGuildAt    time.Time `json:"left_guild_at,omitempty"` // Set when the Discord user left the guild; the mapping is then ignored
 	GitHubUsername string    `json:"github_username"`
 	DiscordUserID  string    `json:"discord_user_id"`
 	GuildID        string    `json:"guild_id"`
@@ -56,27 +246,49 @@ type UserMappingInfo struct {
 
 // Store provides persistent state operations.
 //
-//nolint:interfacebloat // Store handles threads, DMs, events, reports, and cleanup
+//nolint:interfacebloat // Store handles threads, DMs, events, reports, digests, boards, overrides, and cleanup
 type Store interface {
 	// Thread/post tracking - maps PR to Discord thread/message
 	Thread(ctx context.Context, owner, repo string, number int, channelID string) (ThreadInfo, bool)
 	SaveThread(ctx context.Context, owner, repo string, number int, channelID string, info ThreadInfo) error
+	ListThreads(ctx context.Context, owner string) []TrackedThread // Returns all tracked threads/messages for an org
+	RemoveThread(ctx context.Context, owner, repo string, number int, channelID string) error
+	SetThreadMessageIDs(ctx context.Context, owner, repo string, number int, channelID string, messageIDs []string) error
+	MessagesForPR(ctx context.Context, prURL string) []MessageRef // Returns the PR's messages in every channel it was posted to
+	// ThreadForMessage returns the tracked thread whose PR message is messageID in channelID,
+	// which for forum posts is the post's thread
+	ThreadForMessage(ctx context.Context, channelID, messageID string) (TrackedThread, bool)
 
 	// Distributed claim mechanism to prevent duplicate thread/message creation across instances
 	// Returns true if claim was successful, false if another instance already claimed it
 	ClaimThread(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool
+	// RenewThreadClaim extends a held claim for long creations; false if it had already lapsed
+	RenewThreadClaim(ctx context.Context, owner, repo string, number int, channelID string, ttl time.Duration) bool
+	// ReleaseThreadClaim frees a claim once creation completes or fails, rather than at its TTL
+	ReleaseThreadClaim(ctx context.Context, owner, repo string, number int, channelID string) error
 
 	// DM tracking
 	DMInfo(ctx context.Context, userID, prURL string) (DMInfo, bool)
 	SaveDMInfo(ctx context.Context, userID, prURL string, info DMInfo) error
-	ListDMUsers(ctx context.Context, prURL string) []string // Returns all user IDs who received DMs for this PR
+	ListDMUsers(ctx context.Context, prURL string) []string        // Returns all user IDs who received DMs for this PR
+	ListPRsForUser(ctx context.Context, userID string) []TrackedDM // Returns the PRs a user was DMed about
 
 	// Distributed claim mechanism for DMs
 	ClaimDM(ctx context.Context, userID, prURL string, ttl time.Duration) bool
+	ReleaseDMClaim(ctx context.Context, userID, prURL string) error
+
+	// Distributed claim for config reloads so rapid config pushes coalesce into one reload
+	ClaimConfigReload(ctx context.Context, org string, ttl time.Duration) bool
 
 	// Event deduplication
 	WasProcessed(ctx context.Context, eventKey string) bool
 	MarkProcessed(ctx context.Context, eventKey string, ttl time.Duration) error
+	// ClaimEvent atomically checks and marks an event, so concurrent identical deliveries
+	// can't both be processed. Returns false if the event was already claimed or processed.
+	ClaimEvent(ctx context.Context, eventKey string, ttl time.Duration) bool
+	// ClearProcessed forgets processed events whose keys start with keyPrefix (all if empty),
+	// returning how many were cleared.
+	ClearProcessed(ctx context.Context, keyPrefix string) (int, error)
 
 	// Pending DM queue
 	QueuePendingDM(ctx context.Context, dm *PendingDM) error
@@ -87,12 +299,105 @@ type Store interface {
 	DailyReportInfo(ctx context.Context, userID string) (DailyReportInfo, bool)
 	SaveDailyReportInfo(ctx context.Context, userID string, info DailyReportInfo) error
 
+	// Digest tracking for channels that post a periodic digest instead of live messages
+	Digest(ctx context.Context, channelID string) (DigestInfo, bool)
+	SaveDigest(ctx context.Context, channelID string, info DigestInfo) error
+	// UpdateDigest applies update to a channel's stored digest state, saving it if update
+	// reports a change. Every instance updates the same digest, so updates don't interleave.
+	UpdateDigest(ctx context.Context, channelID string, update func(info *DigestInfo) bool) error
+
+	// Board tracking for channels that keep one message listing their open PRs
+	Board(ctx context.Context, channelID string) (BoardInfo, bool)
+	SaveBoard(ctx context.Context, channelID string, info BoardInfo) error
+	// UpdateBoard applies update to a channel's stored board state, saving it if update
+	// reports a change. Every instance updates the same board, so updates don't interleave.
+	UpdateBoard(ctx context.Context, channelID string, update func(info *BoardInfo) bool) error
+
+	// UpdatePostWindows applies update to an org's PRs held back for closed post windows,
+	// saving them if update reports a change. Every instance holds PRs back in the same list.
+	UpdatePostWindows(ctx context.Context, org string, update func(info *PostWindowInfo) bool) error
+
+	// Per-PR overrides from comment directives
+	PROverride(ctx context.Context, prURL string) (PROverride, bool)
+	SavePROverride(ctx context.Context, prURL string, override PROverride) error
+
+	// Review escalation tracking per channel and PR
+	Escalation(ctx context.Context, channelID, prURL string) (EscalationInfo, bool)
+	SaveEscalation(ctx context.Context, channelID, prURL string, info EscalationInfo) error
+
+	// Maintenance mode per org
+	Maintenance(ctx context.Context, org string) (MaintenanceInfo, bool)
+	SaveMaintenance(ctx context.Context, org string, info MaintenanceInfo) error
+
+	// Out of office status per Discord user
+	OOO(ctx context.Context, userID string) (OOOInfo, bool)
+	SaveOOO(ctx context.Context, userID string, info OOOInfo) error
+
+	// Temporary channel redirects per repo
+	RepoRedirect(ctx context.Context, org, repo string) (RepoRedirect, bool)
+	SaveRepoRedirect(ctx context.Context, org, repo string, redirect RepoRedirect) error
+
+	// When each Discord user was last DMed, for rate limiting across restarts and instances
+	LastDM(ctx context.Context, userID string) (time.Time, bool)
+	SaveLastDM(ctx context.Context, userID string, sentAt time.Time) error
+
+	// When each org's last webhook event was seen, to backfill what was missed while down
+	LastEventAt(ctx context.Context, org string) (time.Time, bool)
+	SaveLastEventAt(ctx context.Context, org string, seenAt time.Time) error
+
+	// When each org's config reload was last forced, so every instance reloads, not just the one asked
+	ConfigReloadRequestedAt(ctx context.Context, org string) (time.Time, bool)
+	SaveConfigReloadRequest(ctx context.Context, org string, requestedAt time.Time) error
+
 	// User mapping tracking (GitHub username <-> Discord user ID)
 	UserMapping(ctx context.Context, guildID, gitHubUsername string) (UserMappingInfo, bool)
 	SaveUserMapping(ctx context.Context, guildID string, info UserMappingInfo) error
 	ListUserMappings(ctx context.Context, guildID string) []UserMappingInfo
+	RemoveUserMapping(ctx context.Context, guildID, gitHubUsername string) error
 
 	// Lifecycle
 	Cleanup(ctx context.Context) error
 	Close() error
 }
+
+// messageRef returns where a tracked thread's messages are, in a channel.
+func messageRef(channelID string, info ThreadInfo) MessageRef {
+	return MessageRef{
+		ChannelID:   channelID,
+		ChannelType: info.ChannelType,
+		ThreadID:    info.ThreadID,
+		MessageID:   info.MessageID,
+		MessageIDs:  info.MessageIDs,
+	}
+}
+
+// messageKey returns the key a tracked thread's PR message is indexed under for
+// ThreadForMessage: its channel, or its thread for a forum post, and its ID. Returns "" until
+// the message is posted.
+func messageKey(channelID string, info ThreadInfo) string {
+	if info.MessageID == "" {
+		return ""
+	}
+	if info.ThreadID != "" {
+		channelID = info.ThreadID
+	}
+	return channelID + "/" + info.MessageID
+}
+
+// prURL returns the GitHub URL of a PR.
+func prURL(owner, repo string, number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, number)
+}
+
+// parsePRURL splits a GitHub PR URL into its owner, repo, and number.
+func parsePRURL(url string) (owner, repo string, number int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(url, "https://github.com/"), "/")
+	if len(parts) != 4 || parts[2] != "pull" || parts[0] == "" || parts[1] == "" {
+		return "", "", 0, false
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return "", "", 0, false
+	}
+	return parts[0], parts[1], number, true
+}
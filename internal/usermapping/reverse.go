@@ -129,6 +129,13 @@ func (m *ReverseMapper) ClearCache() {
 	m.cache = make(map[string]reverseEntry)
 }
 
+// Forget drops the cached GitHub username for a Discord user.
+func (m *ReverseMapper) Forget(discordUserID string) {
+	m.cacheMu.Lock()
+	defer m.cacheMu.Unlock()
+	delete(m.cache, discordUserID)
+}
+
 // ExportCache returns a copy of the cache for inspection (discordID -> githubUsername).
 func (m *ReverseMapper) ExportCache() map[string]string {
 	m.cacheMu.RLock()
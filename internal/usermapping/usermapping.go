@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -21,9 +23,41 @@ type DiscordLookup interface {
 	LookupUserByUsername(ctx context.Context, username string) string
 }
 
+// Mapping conflict policies: which source wins when config and a self-service
+// link map the same GitHub user to different Discord IDs.
+const (
+	PolicyConfig  = "config"
+	PolicyStorage = "storage"
+	PolicyNewest  = "newest"
+)
+
+// Member leave actions: what happens to a user's self-service mapping when they leave the guild.
+const (
+	MemberLeaveFlag   = "flag"
+	MemberLeaveDelete = "delete"
+	MemberLeaveKeep   = "keep"
+)
+
 // ConfigLookup defines the interface for config-based user lookup.
 type ConfigLookup interface {
 	DiscordUserID(org, githubUsername string) string
+	MappingConflictPolicy(org string) string
+}
+
+// Conflict describes a GitHub user mapped to different Discord IDs by config and storage.
+type Conflict struct {
+	GitHubUsername string
+	ConfigID       string
+	StorageID      string
+	ResolvedID     string
+	Policy         string
+}
+
+// configObservation tracks when a config mapping value last changed.
+// changedAt is zero for values unchanged since they were first seen.
+type configObservation struct {
+	changedAt time.Time
+	discordID string
 }
 
 // cacheEntry stores a cached mapping with timestamp.
@@ -39,6 +73,7 @@ type Mapper struct {
 	store         state.Store
 	guildID       string
 	cache         map[string]cacheEntry
+	configSeen    map[string]configObservation
 	org           string
 	mu            sync.RWMutex
 }
@@ -52,12 +87,13 @@ func New(org string, configLookup ConfigLookup, discordLookup DiscordLookup, sto
 		store:         store,
 		guildID:       guildID,
 		cache:         make(map[string]cacheEntry),
+		configSeen:    make(map[string]configObservation),
 	}
 }
 
 // DiscordID returns the Discord user ID for a GitHub username.
 // Uses a 4-tier lookup:
-// 1. YAML config mapping (explicit)
+// 1. YAML config mapping (explicit; a conflicting self-service link may win per policy)
 // 2. Fido storage (self-service via /goose github-user command)
 // 3. Discord guild username match
 // 4. Empty string (fallback).
@@ -76,44 +112,27 @@ func (m *Mapper) DiscordID(ctx context.Context, githubUsername string) string {
 	}
 	m.mu.RUnlock()
 
-	// Tier 1: YAML config mapping
-	if m.configLookup != nil {
-		if configValue := m.configLookup.DiscordUserID(m.org, githubUsername); configValue != "" {
-			// Check if config value is a numeric ID or a Discord username
-			// Discord IDs are 17-20 digit snowflakes
-			if len(configValue) >= 17 && len(configValue) <= 20 && isAllDigits(configValue) {
-				// It's a numeric ID, use it directly
-				m.cacheResult(githubUsername, configValue)
-				slog.Info("mapped GitHub user to Discord via config (numeric ID)",
-					"github_username", githubUsername,
-					"discord_id", configValue,
-					"org", m.org,
-					"method", "config_numeric_id")
-				return configValue
-			}
-			// It's a Discord username, resolve it to numeric ID
-			if m.discordLookup != nil {
-				if id := m.discordLookup.LookupUserByUsername(ctx, configValue); id != "" {
-					m.cacheResult(githubUsername, id)
-					slog.Info("mapped GitHub user to Discord via config (username resolved)",
-						"github_username", githubUsername,
-						"discord_username", configValue,
-						"discord_id", id,
-						"org", m.org,
-						"method", "config_username_resolved")
-					return id
-				}
-				slog.Warn("config specified Discord username not found in guild",
-					"github_username", githubUsername,
-					"discord_username", configValue,
-					"org", m.org)
-			}
+	// Tier 1: YAML config mapping, unless a conflicting self-service link wins
+	if configID := m.configDiscordID(ctx, githubUsername); configID != "" {
+		id := configID
+		if c, ok := m.conflict(ctx, githubUsername, configID); ok {
+			id = c.ResolvedID
 		}
+		m.cacheResult(githubUsername, id)
+		return id
 	}
 
 	// Tier 2: Fido storage (self-service mappings)
 	if m.store != nil && m.guildID != "" {
-		if mapping, found := m.store.UserMapping(ctx, m.guildID, githubUsername); found {
+		mapping, found := m.store.UserMapping(ctx, m.guildID, githubUsername)
+		if found && !mapping.LeftGuildAt.IsZero() {
+			slog.Debug("ignoring mapping of Discord user who left the guild",
+				"github_username", githubUsername,
+				"discord_id", mapping.DiscordUserID,
+				"left_at", mapping.LeftGuildAt)
+			found = false
+		}
+		if found {
 			m.cacheResult(githubUsername, mapping.DiscordUserID)
 			slog.Info("mapped GitHub user to Discord via Fido storage",
 				"github_username", githubUsername,
@@ -146,6 +165,118 @@ func (m *Mapper) DiscordID(ctx context.Context, githubUsername string) string {
 	return ""
 }
 
+// configDiscordID returns the Discord ID from the org's YAML config, resolving
+// Discord usernames to IDs. Returns "" if there's no usable config mapping.
+func (m *Mapper) configDiscordID(ctx context.Context, githubUsername string) string {
+	if m.configLookup == nil {
+		return ""
+	}
+	configValue := m.configLookup.DiscordUserID(m.org, githubUsername)
+	if configValue == "" {
+		return ""
+	}
+
+	// Check if config value is a numeric ID or a Discord username
+	// Discord IDs are 17-20 digit snowflakes
+	if len(configValue) >= 17 && len(configValue) <= 20 && isAllDigits(configValue) {
+		slog.Info("mapped GitHub user to Discord via config (numeric ID)",
+			"github_username", githubUsername,
+			"discord_id", configValue,
+			"org", m.org,
+			"method", "config_numeric_id")
+		return configValue
+	}
+
+	// It's a Discord username, resolve it to numeric ID
+	if m.discordLookup == nil {
+		return ""
+	}
+	if id := m.discordLookup.LookupUserByUsername(ctx, configValue); id != "" {
+		slog.Info("mapped GitHub user to Discord via config (username resolved)",
+			"github_username", githubUsername,
+			"discord_username", configValue,
+			"discord_id", id,
+			"org", m.org,
+			"method", "config_username_resolved")
+		return id
+	}
+	slog.Warn("config specified Discord username not found in guild",
+		"github_username", githubUsername,
+		"discord_username", configValue,
+		"org", m.org)
+	return ""
+}
+
+// conflict checks a config mapping against the user's self-service link.
+// If they disagree, the org's mapping conflict policy picks the winner.
+func (m *Mapper) conflict(ctx context.Context, githubUsername, configID string) (Conflict, bool) {
+	configChangedAt := m.observeConfig(githubUsername, configID)
+
+	if m.store == nil || m.guildID == "" {
+		return Conflict{}, false
+	}
+	link, found := m.store.UserMapping(ctx, m.guildID, githubUsername)
+	if !found || link.DiscordUserID == "" || link.DiscordUserID == configID || !link.LeftGuildAt.IsZero() {
+		return Conflict{}, false
+	}
+
+	c := Conflict{
+		GitHubUsername: githubUsername,
+		ConfigID:       configID,
+		StorageID:      link.DiscordUserID,
+		ResolvedID:     configID,
+		Policy:         m.configLookup.MappingConflictPolicy(m.org),
+	}
+	switch c.Policy {
+	case PolicyStorage:
+		c.ResolvedID = link.DiscordUserID
+	case PolicyNewest:
+		if link.CreatedAt.After(configChangedAt) {
+			c.ResolvedID = link.DiscordUserID
+		}
+	default:
+		c.Policy = PolicyConfig
+	}
+
+	slog.Warn("GitHub user mapped to different Discord IDs by config and self-service link",
+		"github_username", githubUsername,
+		"config_discord_id", configID,
+		"storage_discord_id", link.DiscordUserID,
+		"resolved_discord_id", c.ResolvedID,
+		"policy", c.Policy,
+		"org", m.org)
+	return c, true
+}
+
+// observeConfig records a config mapping value and returns when it last changed.
+// We can't know when config was edited before we first saw it, so the initial
+// value reports a zero time: under the "newest" policy an existing link wins.
+func (m *Mapper) observeConfig(githubUsername, discordID string) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen, ok := m.configSeen[githubUsername]
+	if !ok {
+		m.configSeen[githubUsername] = configObservation{discordID: discordID}
+		return time.Time{}
+	}
+	if seen.discordID != discordID {
+		seen = configObservation{discordID: discordID, changedAt: time.Now()}
+		m.configSeen[githubUsername] = seen
+	}
+	return seen.changedAt
+}
+
+// CheckConflict reports whether config and a self-service link disagree for a
+// GitHub user, bypassing the cache.
+func (m *Mapper) CheckConflict(ctx context.Context, githubUsername string) (Conflict, bool) {
+	configID := m.configDiscordID(ctx, githubUsername)
+	if configID == "" {
+		return Conflict{}, false
+	}
+	return m.conflict(ctx, githubUsername, configID)
+}
+
 // Mention returns a Discord mention string for a GitHub username.
 // Returns the username in plain text if no Discord ID is found.
 func (m *Mapper) Mention(ctx context.Context, githubUsername string) string {
@@ -184,6 +315,74 @@ func (m *Mapper) ClearCache() {
 	m.cache = make(map[string]cacheEntry)
 }
 
+// Forget drops the cached mapping for a GitHub user, so the next lookup sees a newly saved mapping.
+func (m *Mapper) Forget(githubUsername string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.cache, githubUsername)
+}
+
+// MemberLeft handles a Discord user leaving the guild: their stored mappings are flagged,
+// deleted, or kept per action, and cached lookups that resolve to them are dropped.
+// Returns the GitHub usernames that were mapped to them.
+//
+// Stored mappings are found by listing the guild's mappings and by checking usernames
+// cached as resolving to the user, since not every store can list mappings.
+func (m *Mapper) MemberLeft(ctx context.Context, discordUserID, action string) []string {
+	m.mu.Lock()
+	candidates := make(map[string]bool)
+	for githubUsername, entry := range m.cache {
+		if entry.discordID == discordUserID {
+			candidates[githubUsername] = true
+			delete(m.cache, githubUsername)
+		}
+	}
+	m.mu.Unlock()
+
+	if m.store == nil || m.guildID == "" {
+		return slices.Sorted(maps.Keys(candidates))
+	}
+	for _, mapping := range m.store.ListUserMappings(ctx, m.guildID) {
+		if mapping.DiscordUserID == discordUserID {
+			candidates[mapping.GitHubUsername] = true
+		}
+	}
+
+	for _, githubUsername := range slices.Sorted(maps.Keys(candidates)) {
+		mapping, found := m.store.UserMapping(ctx, m.guildID, githubUsername)
+		if !found || mapping.DiscordUserID != discordUserID {
+			continue
+		}
+		var err error
+		switch action {
+		case MemberLeaveKeep:
+			continue
+		case MemberLeaveDelete:
+			err = m.store.RemoveUserMapping(ctx, m.guildID, githubUsername)
+		default:
+			if !mapping.LeftGuildAt.IsZero() {
+				continue
+			}
+			mapping.LeftGuildAt = time.Now()
+			err = m.store.SaveUserMapping(ctx, m.guildID, mapping)
+		}
+		if err != nil {
+			slog.Warn("failed to update mapping of Discord user who left the guild",
+				"error", err,
+				"github_username", githubUsername,
+				"discord_id", discordUserID,
+				"action", action)
+			continue
+		}
+		slog.Info("updated mapping of Discord user who left the guild",
+			"github_username", githubUsername,
+			"discord_id", discordUserID,
+			"guild_id", m.guildID,
+			"action", action)
+	}
+	return slices.Sorted(maps.Keys(candidates))
+}
+
 // ExportCache returns a copy of the cache for inspection (githubUsername -> discordID).
 func (m *Mapper) ExportCache() map[string]string {
 	m.mu.RLock()
@@ -4,10 +4,13 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/codeGROOVE-dev/discordian/internal/state"
 )
 
 type mockConfigLookup struct {
-	users map[string]string
+	users  map[string]string
+	policy string
 }
 
 func (m *mockConfigLookup) DiscordUserID(_, githubUsername string) string {
@@ -17,6 +20,10 @@ func (m *mockConfigLookup) DiscordUserID(_, githubUsername string) string {
 	return m.users[githubUsername]
 }
 
+func (m *mockConfigLookup) MappingConflictPolicy(_ string) string {
+	return m.policy
+}
+
 type mockDiscordLookup struct {
 	users map[string]string
 }
@@ -107,6 +114,107 @@ func TestMapper_DiscordID_ConfigOverridesDiscord(t *testing.T) {
 	}
 }
 
+func TestMapper_DiscordID_MappingConflictPolicy(t *testing.T) {
+	const (
+		configID  = "111111111111111111"
+		storageID = "222222222222222222"
+	)
+
+	tests := []struct {
+		name   string
+		policy string
+		want   string
+	}{
+		{"config wins by default", "", configID},
+		{"config policy", PolicyConfig, configID},
+		{"storage policy", PolicyStorage, storageID},
+		// Config hasn't changed since first seen, so the existing link is newer
+		{"newest policy", PolicyNewest, storageID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := state.NewMemoryStore()
+			if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{
+				GitHubUsername: "alice",
+				DiscordUserID:  storageID,
+				GuildID:        "test-guild",
+				CreatedAt:      time.Now().Add(-time.Hour),
+			}); err != nil {
+				t.Fatalf("SaveUserMapping() error = %v", err)
+			}
+			configLookup := &mockConfigLookup{
+				users:  map[string]string{"alice": configID},
+				policy: tt.policy,
+			}
+
+			mapper := New("testorg", configLookup, nil, store, "test-guild")
+			if got := mapper.DiscordID(ctx, "alice"); got != tt.want {
+				t.Errorf("DiscordID(alice) = %q, want %q", got, tt.want)
+			}
+
+			c, ok := mapper.CheckConflict(ctx, "alice")
+			if !ok {
+				t.Fatal("CheckConflict() found no conflict")
+			}
+			if c.ConfigID != configID || c.StorageID != storageID || c.ResolvedID != tt.want {
+				t.Errorf("CheckConflict() = %+v, want config %s, storage %s, resolved %s", c, configID, storageID, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapper_DiscordID_NewestPolicyConfigChanged(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{
+		GitHubUsername: "alice",
+		DiscordUserID:  "222222222222222222",
+		GuildID:        "test-guild",
+		CreatedAt:      time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	configLookup := &mockConfigLookup{
+		users:  map[string]string{"alice": "111111111111111111"},
+		policy: PolicyNewest,
+	}
+	mapper := New("testorg", configLookup, nil, store, "test-guild")
+
+	if got := mapper.DiscordID(ctx, "alice"); got != "222222222222222222" {
+		t.Fatalf("DiscordID(alice) = %q, want link to win before config changes", got)
+	}
+
+	// Config edited after the link was made: config is now newest
+	configLookup.users["alice"] = "333333333333333333"
+	mapper.ClearCache()
+	if got := mapper.DiscordID(ctx, "alice"); got != "333333333333333333" {
+		t.Errorf("DiscordID(alice) = %q, want updated config ID", got)
+	}
+}
+
+func TestMapper_CheckConflict_Agreement(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{
+		GitHubUsername: "alice",
+		DiscordUserID:  "111111111111111111",
+		GuildID:        "test-guild",
+	}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	configLookup := &mockConfigLookup{users: map[string]string{"alice": "111111111111111111"}}
+	mapper := New("testorg", configLookup, nil, store, "test-guild")
+
+	if c, ok := mapper.CheckConflict(ctx, "alice"); ok {
+		t.Errorf("CheckConflict() = %+v, want no conflict when sources agree", c)
+	}
+	if _, ok := mapper.CheckConflict(ctx, "bob"); ok {
+		t.Error("CheckConflict() should report no conflict without a config mapping")
+	}
+}
+
 // TestMapper_DiscordID_ConfigUsername tests config value being a Discord username.
 func TestMapper_DiscordID_ConfigUsername(t *testing.T) {
 	ctx := context.Background()
@@ -253,6 +361,103 @@ func TestMapper_ClearCache(t *testing.T) {
 	}
 }
 
+func TestMapper_Forget(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	mapper := New("testorg", nil, nil, store, "test-guild")
+
+	// Cache a miss for alice and a result for bob
+	if got := mapper.DiscordID(ctx, "alice"); got != "" {
+		t.Fatalf("DiscordID(alice) = %q, want empty", got)
+	}
+	if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{GitHubUsername: "bob", DiscordUserID: "222222222222222222"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	mapper.DiscordID(ctx, "bob")
+
+	if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{GitHubUsername: "alice", DiscordUserID: "111111111111111111"}); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	mapper.Forget("alice")
+
+	if got := mapper.DiscordID(ctx, "alice"); got != "111111111111111111" {
+		t.Errorf("DiscordID(alice) after Forget = %q, want new mapping", got)
+	}
+	if _, ok := mapper.ExportCache()["bob"]; !ok {
+		t.Error("Forget(alice) dropped bob's cached mapping")
+	}
+}
+
+func TestMapper_MemberLeft(t *testing.T) {
+	ctx := context.Background()
+	const leaverID = "111111111111111111"
+
+	for _, action := range []string{MemberLeaveFlag, MemberLeaveDelete, MemberLeaveKeep} {
+		t.Run(action, func(t *testing.T) {
+			store := state.NewMemoryStore()
+			mapper := New("testorg", nil, nil, store, "test-guild")
+			for username, id := range map[string]string{"alice": leaverID, "bob": "222222222222222222"} {
+				if err := store.SaveUserMapping(ctx, "test-guild", state.UserMappingInfo{GitHubUsername: username, DiscordUserID: id}); err != nil {
+					t.Fatalf("SaveUserMapping() error = %v", err)
+				}
+			}
+			if got := mapper.DiscordID(ctx, "alice"); got != leaverID {
+				t.Fatalf("DiscordID(alice) = %q before leaving, want %q", got, leaverID)
+			}
+
+			if got := mapper.MemberLeft(ctx, leaverID, action); len(got) != 1 || got[0] != "alice" {
+				t.Errorf("MemberLeft() = %v, want [alice]", got)
+			}
+
+			mapping, found := store.UserMapping(ctx, "test-guild", "alice")
+			want := leaverID
+			switch action {
+			case MemberLeaveFlag:
+				if !found || mapping.LeftGuildAt.IsZero() {
+					t.Errorf("UserMapping(alice) = %+v, %v, want it flagged", mapping, found)
+				}
+				want = ""
+			case MemberLeaveDelete:
+				if found {
+					t.Errorf("UserMapping(alice) = %+v, want it deleted", mapping)
+				}
+				want = ""
+			default:
+				if !found || !mapping.LeftGuildAt.IsZero() {
+					t.Errorf("UserMapping(alice) = %+v, %v, want it kept as is", mapping, found)
+				}
+			}
+			// No mapping means no DMs for the user who left
+			if got := mapper.DiscordID(ctx, "alice"); got != want {
+				t.Errorf("DiscordID(alice) after leaving = %q, want %q", got, want)
+			}
+			if got := mapper.DiscordID(ctx, "bob"); got != "222222222222222222" {
+				t.Errorf("DiscordID(bob) = %q, want bob's mapping untouched", got)
+			}
+		})
+	}
+}
+
+func TestMapper_MemberLeft_RelinkClearsFlag(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	mapper := New("testorg", nil, nil, store, "test-guild")
+	info := state.UserMappingInfo{GitHubUsername: "alice", DiscordUserID: "111111111111111111"}
+	if err := store.SaveUserMapping(ctx, "test-guild", info); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	mapper.MemberLeft(ctx, info.DiscordUserID, MemberLeaveFlag)
+
+	// Linking again with /goose github-user saves a fresh mapping
+	if err := store.SaveUserMapping(ctx, "test-guild", info); err != nil {
+		t.Fatalf("SaveUserMapping() error = %v", err)
+	}
+	mapper.Forget("alice")
+	if got := mapper.DiscordID(ctx, "alice"); got != info.DiscordUserID {
+		t.Errorf("DiscordID(alice) after relinking = %q, want %q", got, info.DiscordUserID)
+	}
+}
+
 func TestMapper_NilLookups(t *testing.T) {
 	ctx := context.Background()
 